@@ -0,0 +1,24 @@
+// Package newsletterservice embeds repository-level assets into the service binaries.
+package newsletterservice
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// APIDocsYAML is the hand-maintained OpenAPI 3 specification (api-docs.yaml)
+//
+//go:embed api-docs.yaml
+var APIDocsYAML []byte
+
+// APIDocsJSON converts the embedded OpenAPI specification to JSON
+func APIDocsJSON() ([]byte, error) {
+	var spec map[string]interface{}
+	if err := yaml.Unmarshal(APIDocsYAML, &spec); err != nil {
+		return nil, fmt.Errorf("failed to parse api-docs.yaml: %w", err)
+	}
+	return json.Marshal(spec)
+}
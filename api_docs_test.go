@@ -0,0 +1,225 @@
+package newsletterservice_test
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	newsletterservice "newsletter-service"
+	"newsletter-service/internal/dtos"
+)
+
+// apiDocsSchema is the part of an OpenAPI schema object the request checks read
+type apiDocsSchema struct {
+	Ref        string                    `yaml:"$ref"`
+	Type       string                    `yaml:"type"`
+	Required   []string                  `yaml:"required"`
+	Properties map[string]*apiDocsSchema `yaml:"properties"`
+	Items      *apiDocsSchema            `yaml:"items"`
+}
+
+type apiDocsOperation struct {
+	RequestBody struct {
+		Content map[string]struct {
+			Schema *apiDocsSchema `yaml:"schema"`
+		} `yaml:"content"`
+	} `yaml:"requestBody"`
+}
+
+type apiDocs struct {
+	Paths      map[string]map[string]yaml.Node `yaml:"paths"` // Path items hold parameters besides operations
+	Components struct {
+		Schemas map[string]*apiDocsSchema `yaml:"schemas"`
+	} `yaml:"components"`
+}
+
+// TestAPIDocsMatchRequestDTOs checks the JSON request body documented for each endpoint against the DTO its
+// handler binds: the same properties, and the same ones required, in nested objects too
+func TestAPIDocsMatchRequestDTOs(t *testing.T) {
+	var docs apiDocs
+	if err := yaml.Unmarshal(newsletterservice.APIDocsYAML, &docs); err != nil {
+		t.Fatalf("failed to parse api-docs.yaml: %v", err)
+	}
+
+	requests := map[string]interface{}{
+		"POST /api/v1/topics":                             dtos.CreateTopicRequest{},
+		"PUT /api/v1/topics/{id}":                         dtos.UpdateTopicRequest{},
+		"POST /api/v1/topics/{id}/migrate-subscribers":    dtos.MigrateSubscribersRequest{},
+		"PUT /api/v1/topics/{id}/theme":                   dtos.Theme{},
+		"POST /api/v1/subscribers":                        dtos.CreateSubscriberRequest{},
+		"PUT /api/v1/subscribers/{id}":                    dtos.UpdateSubscriberRequest{},
+		"POST /api/v1/subscribers/merge":                  dtos.MergeSubscribersRequest{},
+		"POST /api/v1/subscribers/bulk":                   dtos.BulkCreateSubscribersRequest{},
+		"PUT /api/v1/subscribers/bulk":                    dtos.BulkUpdateSubscribersRequest{},
+		"DELETE /api/v1/subscribers/bulk":                 dtos.BulkDeleteSubscribersRequest{},
+		"POST /api/v1/subscribers/{id}/push-devices":      dtos.RegisterPushDeviceRequest{},
+		"PUT /api/v1/subscribers/{id}/preferences":        dtos.UpdatePreferencesRequest{},
+		"POST /referrals/redeem":                          dtos.RedeemReferralRequest{},
+		"POST /api/v1/subscriptions":                      dtos.CreateSubscriptionRequest{},
+		"PUT /api/v1/subscriptions/{id}":                  dtos.UpdateSubscriptionRequest{},
+		"POST /api/v1/contents":                           dtos.CreateContentRequest{},
+		"PUT /api/v1/contents/{id}":                       dtos.UpdateContentRequest{},
+		"PUT /api/v1/contents/{id}/translations/{locale}": dtos.SetContentTranslationRequest{},
+		"POST /api/v1/contents/{id}/submit":               dtos.SubmitContentRequest{},
+		"POST /api/v1/contents/{id}/assign":               dtos.AssignReviewerRequest{},
+		"POST /api/v1/contents/{id}/approve":              dtos.ApprovalDecisionRequest{},
+		"POST /api/v1/contents/{id}/reject":               dtos.ApprovalDecisionRequest{},
+		"POST /api/v1/contents/{id}/comments":             dtos.ApprovalCommentRequest{},
+		"POST /api/v1/contents/{id}/polls":                dtos.CreatePollRequest{},
+		"PUT /api/v1/polls/{id}":                          dtos.UpdatePollRequest{},
+		"POST /api/v1/providers/{name}/pause":             dtos.PauseProviderRequest{},
+		"POST /auth/login":                                dtos.LoginRequest{},
+		"POST /auth/refresh":                              dtos.RefreshTokenRequest{},
+		"POST /auth/logout":                               dtos.LogoutRequest{},
+		"POST /api/v1/api-keys":                           dtos.CreateAPIKeyRequest{},
+		"PUT /api/v1/api-keys/{id}":                       dtos.UpdateAPIKeyRequest{},
+		"POST /api/v1/snippets":                           dtos.CreateSnippetRequest{},
+		"PUT /api/v1/snippets/{id}":                       dtos.UpdateSnippetRequest{},
+		"POST /api/v1/plans":                              dtos.CreatePlanRequest{},
+		"PUT /api/v1/plans/{id}":                          dtos.UpdatePlanRequest{},
+		"POST /api/v1/plans/{id}/checkout":                dtos.CreateCheckoutRequest{},
+		"POST /api/v1/sponsors":                           dtos.CreateSponsorRequest{},
+		"PUT /api/v1/sponsors/{id}":                       dtos.UpdateSponsorRequest{},
+		"POST /api/v1/sponsors/{id}/slots":                dtos.BookSponsorSlotRequest{},
+		"POST /api/v1/webhooks":                           dtos.CreateWebhookRequest{},
+		"PUT /api/v1/webhooks/{id}":                       dtos.UpdateWebhookRequest{},
+		"POST /api/v1/organizations":                      dtos.CreateOrganizationRequest{},
+		"PUT /api/v1/organizations/{id}":                  dtos.UpdateOrganizationRequest{},
+		"PUT /api/v1/email-templates/{name}":              dtos.UpdateEmailTemplateRequest{},
+	}
+
+	for operation, request := range requests {
+		t.Run(operation, func(t *testing.T) {
+			method, path, _ := strings.Cut(operation, " ")
+			node, ok := docs.Paths[path][strings.ToLower(method)]
+			if !ok {
+				t.Fatalf("api-docs.yaml doesn't document %s", operation)
+			}
+			var op apiDocsOperation
+			if err := node.Decode(&op); err != nil {
+				t.Fatalf("failed to parse %s: %v", operation, err)
+			}
+			body, ok := op.RequestBody.Content["application/json"]
+			if !ok || body.Schema == nil {
+				t.Fatalf("api-docs.yaml documents no JSON request body for %s", operation)
+			}
+			checkSchema(t, &docs, reflect.TypeOf(request).Name(), reflect.TypeOf(request), body.Schema)
+		})
+	}
+}
+
+// checkSchema reports the differences between struct type typ and schema, then checks the fields holding
+// structs, or slices of them, against the properties documenting them
+func checkSchema(t *testing.T, docs *apiDocs, where string, typ reflect.Type, schema *apiDocsSchema) {
+	t.Helper()
+	schema = resolveSchema(t, docs, schema)
+
+	fields := requestFields(typ)
+	var missing, undocumented, wantRequired []string
+	for name, field := range fields {
+		property, ok := schema.Properties[name]
+		if !ok {
+			undocumented = append(undocumented, name)
+			continue
+		}
+		if fieldRequired(field) {
+			wantRequired = append(wantRequired, name)
+		}
+		if nested := structType(field.Type); nested != nil {
+			if property = resolveSchema(t, docs, property); property.Type == "array" && property.Items != nil {
+				property = resolveSchema(t, docs, property.Items)
+			}
+			if property.Properties != nil {
+				checkSchema(t, docs, where+"."+name, nested, property)
+			}
+		}
+	}
+	for name := range schema.Properties {
+		if _, ok := fields[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+
+	if len(undocumented) > 0 {
+		sort.Strings(undocumented)
+		t.Errorf("%s: fields missing from the schema: %v", where, undocumented)
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		t.Errorf("%s: schema properties the DTO doesn't have: %v", where, missing)
+	}
+	gotRequired := append([]string(nil), schema.Required...)
+	sort.Strings(gotRequired)
+	sort.Strings(wantRequired)
+	if strings.Join(gotRequired, ",") != strings.Join(wantRequired, ",") {
+		t.Errorf("%s: schema requires %v, the DTO requires %v", where, gotRequired, wantRequired)
+	}
+}
+
+// resolveSchema follows a $ref to a component schema
+func resolveSchema(t *testing.T, docs *apiDocs, schema *apiDocsSchema) *apiDocsSchema {
+	t.Helper()
+	if schema.Ref == "" {
+		return schema
+	}
+	name := strings.TrimPrefix(schema.Ref, "#/components/schemas/")
+	resolved, ok := docs.Components.Schemas[name]
+	if !ok {
+		t.Fatalf("api-docs.yaml has no schema %s", schema.Ref)
+	}
+	return resolveSchema(t, docs, resolved)
+}
+
+// requestFields returns the fields of a struct type by JSON name, with embedded structs' fields promoted
+func requestFields(typ reflect.Type) map[string]reflect.StructField {
+	fields := make(map[string]reflect.StructField)
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if field.Anonymous && name == "" {
+			for embeddedName, embedded := range requestFields(field.Type) {
+				fields[embeddedName] = embedded
+			}
+			continue
+		}
+		if !field.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = field
+	}
+	return fields
+}
+
+// fieldRequired reports whether a field's validate or binding tag requires it. Rules after dive apply to the
+// elements of a slice, not the field.
+func fieldRequired(field reflect.StructField) bool {
+	for _, key := range []string{"validate", "binding"} {
+		for _, rule := range strings.Split(field.Tag.Get(key), ",") {
+			if rule == "dive" {
+				break
+			}
+			if rule == "required" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// structType returns the struct type a field holds directly, by pointer or as slice elements, or nil for
+// any other type. time.Time is a value, not an object.
+func structType(typ reflect.Type) reflect.Type {
+	for typ.Kind() == reflect.Ptr || typ.Kind() == reflect.Slice {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct || typ.PkgPath() == "time" {
+		return nil
+	}
+	return typ
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+
+	gormlogger "gorm.io/gorm/logger"
+
+	"newsletter-service/internal/config"
+	"newsletter-service/internal/connections"
+	"newsletter-service/internal/loadgen"
+)
+
+func main() {
+	defaults := loadgen.DefaultOptions()
+	opts := defaults
+	organizationID := flag.Uint("org", defaults.OrganizationID, "organization the audience is created in")
+	flag.IntVar(&opts.Subscribers, "subscribers", defaults.Subscribers, "subscribers every content is sent to")
+	flag.IntVar(&opts.Runs, "runs", defaults.Runs, "contents sent one after the other")
+	flag.IntVar(&opts.Concurrency, "concurrency", defaults.Concurrency, "concurrent sends, as worker max_async_process; 0 uses the worker default")
+	flag.DurationVar(&opts.Latency, "latency", defaults.Latency, "time the mock provider takes per email")
+	flag.IntVar(&opts.BatchSize, "batch-size", defaults.BatchSize, "rows per INSERT while creating the audience")
+	bench := flag.Bool("bench", false, "run as a Go benchmark, sending as many contents as testing.Benchmark asks for")
+	flag.Parse()
+	opts.OrganizationID = *organizationID
+
+	// Load configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	// Connect to PostgreSQL; the schema must already be migrated
+	cfg.Database.AutoMigrate = false
+	db, err := connections.NewPostgresDB(cfg.Database)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		log.Fatalf("Failed to get underlying sql.DB: %v", err)
+	}
+	defer sqlDB.Close()
+
+	// Query logging would dominate the timings
+	db.Logger = db.Logger.LogMode(gormlogger.Silent)
+
+	if *bench {
+		result, err := loadgen.RunBenchmark(db, opts)
+		if err != nil {
+			sqlDB.Close()
+			log.Fatalf("Benchmark failed: %v", err)
+		}
+		log.Printf("BenchmarkSend/subscribers=%d/concurrency=%d\t%s\t%s", opts.Subscribers, opts.Concurrency, result, result.MemString())
+		return
+	}
+
+	log.Printf("Sending %d contents to %d subscribers (concurrency %d, provider latency %s)", opts.Runs, opts.Subscribers, opts.Concurrency, opts.Latency)
+	reports, err := loadgen.Run(context.Background(), db, opts)
+	for _, report := range reports {
+		log.Print(report)
+	}
+	if err != nil {
+		sqlDB.Close()
+		log.Fatalf("Load test failed: %v", err)
+	}
+}
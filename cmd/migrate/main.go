@@ -0,0 +1,42 @@
+package main
+
+import (
+	"log"
+	"os"
+
+	"newsletter-service/internal/config"
+	"newsletter-service/internal/connections"
+	"newsletter-service/migration"
+)
+
+func main() {
+	command := migration.CommandUp
+	if len(os.Args) > 1 {
+		command = os.Args[1]
+	}
+
+	// Load configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	// Connect to PostgreSQL (never auto-migrate here, goose owns the schema)
+	cfg.Database.AutoMigrate = false
+	db, err := connections.NewPostgresDB(cfg.Database)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		log.Fatalf("Failed to get underlying sql.DB: %v", err)
+	}
+	defer sqlDB.Close()
+
+	log.Printf("Running migration: %s", command)
+	if err := migration.Run(sqlDB, command); err != nil {
+		log.Fatalf("Migration %s failed: %v", command, err)
+	}
+	log.Printf("Migration %s completed successfully", command)
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+
+	gormlogger "gorm.io/gorm/logger"
+
+	"newsletter-service/internal/config"
+	"newsletter-service/internal/connections"
+	"newsletter-service/internal/seed"
+)
+
+func main() {
+	defaults := seed.DefaultOptions()
+	opts := defaults
+	organizationID := flag.Uint("org", defaults.OrganizationID, "organization to seed")
+	flag.IntVar(&opts.Topics, "topics", defaults.Topics, "number of topics")
+	flag.IntVar(&opts.Subscribers, "subscribers", defaults.Subscribers, "number of subscribers, each subscribed to one to three topics")
+	flag.IntVar(&opts.ContentsPerTopic, "contents", defaults.ContentsPerTopic, "contents per topic, about 70% of them already sent")
+	flag.IntVar(&opts.EmailLogs, "email-logs", defaults.EmailLogs, "number of email logs for the sent contents")
+	flag.Int64Var(&opts.Seed, "seed", defaults.Seed, "random seed, for repeatable data")
+	flag.IntVar(&opts.BatchSize, "batch-size", defaults.BatchSize, "rows per INSERT")
+	flag.Parse()
+	opts.OrganizationID = *organizationID
+
+	// Load configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	// Connect to PostgreSQL; the schema must already be migrated
+	cfg.Database.AutoMigrate = false
+	db, err := connections.NewPostgresDB(cfg.Database)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		log.Fatalf("Failed to get underlying sql.DB: %v", err)
+	}
+	defer sqlDB.Close()
+
+	// Query logging would print every generated row
+	db.Logger = db.Logger.LogMode(gormlogger.Silent)
+
+	log.Printf("Seeding organization %d with seed %d", opts.OrganizationID, opts.Seed)
+	summary, err := seed.Run(context.Background(), db, opts)
+	if err != nil {
+		log.Fatalf("Seeding failed: %v", err)
+	}
+	log.Printf("Seeded %d topics, %d subscribers, %d subscriptions, %d contents and %d email logs",
+		summary.Topics, summary.Subscribers, summary.Subscriptions, summary.Contents, summary.EmailLogs)
+}
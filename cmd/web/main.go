@@ -1,20 +1,47 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
-	"strconv"
-
-	"github.com/go-redis/redis/v8"
 
+	"newsletter-service/internal/cache"
 	"newsletter-service/internal/config"
 	"newsletter-service/internal/connections"
+	"newsletter-service/internal/constants"
+	"newsletter-service/internal/events"
+	"newsletter-service/internal/grpcapi"
 	"newsletter-service/internal/handlers"
 	"newsletter-service/internal/router"
+	"newsletter-service/internal/schedulers"
+	"newsletter-service/internal/services/apikey"
+	"newsletter-service/internal/services/approval"
+	"newsletter-service/internal/services/asset"
+	"newsletter-service/internal/services/audit"
+	"newsletter-service/internal/services/auth"
+	"newsletter-service/internal/services/billing"
 	"newsletter-service/internal/services/content"
+	"newsletter-service/internal/services/emailcheck"
+	"newsletter-service/internal/services/emailtemplate"
+	"newsletter-service/internal/services/engagement"
+	"newsletter-service/internal/services/health"
+	"newsletter-service/internal/services/landing"
+	"newsletter-service/internal/services/lint"
 	"newsletter-service/internal/services/notification"
+	"newsletter-service/internal/services/organization"
+	"newsletter-service/internal/services/poll"
+	"newsletter-service/internal/services/preference"
+	"newsletter-service/internal/services/push"
+	"newsletter-service/internal/services/referral"
+	"newsletter-service/internal/services/retention"
+	"newsletter-service/internal/services/snippet"
+	"newsletter-service/internal/services/sponsor"
+	"newsletter-service/internal/services/stats"
 	"newsletter-service/internal/services/subscriber"
 	"newsletter-service/internal/services/topic"
+	"newsletter-service/internal/services/webhook"
+	"newsletter-service/internal/tracing"
+	"newsletter-service/migration"
 )
 
 func main() {
@@ -23,6 +50,17 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to load config: %v", err)
 	}
+	// Report every misconfigured provider and rate limit now rather than at the first send or request
+	if err := cfg.Validate(); err != nil {
+		log.Fatal(err)
+	}
+
+	// Initialize tracing
+	shutdownTracing, err := tracing.Init(context.Background(), cfg.Tracing, constants.ServiceNameMain)
+	if err != nil {
+		log.Fatalf("Failed to initialize tracing: %v", err)
+	}
+	defer shutdownTracing(context.Background())
 
 	// Connect to PostgreSQL
 	db, err := connections.NewPostgresDB(cfg.Database)
@@ -37,43 +75,163 @@ func main() {
 	}
 	defer sqlDB.Close()
 
+	// Apply pending migrations if enabled
+	if cfg.Database.MigrateOnStart {
+		log.Println("Running database migrations...")
+		if err := migration.Run(sqlDB, migration.CommandUp); err != nil {
+			log.Fatalf("Failed to run migrations: %v", err)
+		}
+	}
+
 	// Connect to Redis
-	redisAddr := cfg.Redis.Host + ":" + strconv.Itoa(cfg.Redis.Port)
-	redisClient := redis.NewClient(&redis.Options{
-		Addr:     redisAddr,
-		Password: cfg.Redis.Password,
-		DB:       cfg.Redis.DB,
-	})
-
-	// Test Redis connection
-	_, err = redisClient.Ping(redisClient.Context()).Result()
+	redisClient, err := connections.NewRedisClient(cfg.Redis)
 	if err != nil {
 		log.Printf("Warning: Failed to connect to Redis: %v", err)
 		log.Println("Rate limiting will fall back to memory storage")
-		redisClient = nil
-	} else {
-		log.Println("Connected to Redis successfully")
 	}
 
 	// Initialize repositories
 	topicRepo := topic.NewRepository(db)
 	subscriberRepo := subscriber.NewRepository(db)
 	contentRepo := content.NewRepository(db)
-
-	// Initialize services
-	topicService := topic.NewService(topicRepo)
-	subscriberService := subscriber.NewServiceWithTopic(subscriberRepo, topicService)
+	auditRepo := audit.NewRepository(db)
+	healthRepo := health.NewRepository(db)
+	apiKeyRepo := apikey.NewRepository(db)
+	webhookRepo := webhook.NewRepository(db)
+	statsRepo := stats.NewRepository(db)
+	engagementRepo := engagement.NewRepository(db)
+	retentionRepo := retention.NewRepository(db)
+	emailCheckRepo := emailcheck.NewRepository(db)
+	pushRepo := push.NewRepository(db)
+	preferenceRepo := preference.NewRepository(db)
+
+	// Initialize services (topics, subscribers and subscription counts are cached in Redis when available,
+	// otherwise in memory)
+	var readCache cache.Cache
+	var topicStatsCache topic.StatsCache
+	if redisClient != nil {
+		readCache = cache.NewRedis(redisClient)
+		topicStatsCache = topic.NewRedisStatsCache(redisClient)
+	} else {
+		readCache = cache.NewMemory()
+	}
+	topicService := topic.NewServiceWithCache(topicRepo, topicStatsCache, readCache)
+	emailCheckService := emailcheck.NewService(emailCheckRepo, cfg.EmailCheck)
+	subscriberService := subscriber.NewServiceWithCache(subscriberRepo, topicService, emailCheckService, cfg.Subscribers, readCache)
 	contentService := content.NewService(contentRepo)
+	lintService := lint.NewService(contentService, cfg.Lint)
+	auditService := audit.NewService(auditRepo)
+	healthService := health.NewService(healthRepo, redisClient, cfg)
+	webhookService := webhook.NewService(webhookRepo, cfg.Webhooks)
+	statsService := stats.NewService(statsRepo)
+	engagementService := engagement.NewService(engagementRepo, cfg.Engagement)
+	retentionService := retention.NewService(retentionRepo, cfg.Retention)
+	pushService := push.NewService(pushRepo)
+	preferenceService := preference.NewService(preferenceRepo)
+
+	// Initialize event bus (webhooks consume it in-process; Kafka or NATS receive every event when configured)
+	eventBus, err := events.NewBus(cfg.Events, constants.ServiceNameMain)
+	if err != nil {
+		log.Fatalf("Failed to initialize event bus: %v", err)
+	}
+	defer eventBus.Close()
+	webhook.Subscribe(eventBus, webhookService)
 
 	// Initialize notification service (without email provider - web API doesn't send emails directly)
 	// Email sending is handled by the worker process
 	notificationService := notification.NewService(db, contentService, subscriberService)
 
+	// The scheduler API queues content for the worker rather than sending it here. With Redis it checks the lock
+	// workers hold while sending, to answer 409 for content being sent; without it a local lock sees no worker's,
+	// but still answers 409 to a request queuing content another request of this process is queuing.
+	var dispatchLocker schedulers.Locker
+	if redisClient != nil {
+		dispatchLocker = schedulers.NewRedisLocker(redisClient)
+	} else {
+		dispatchLocker = schedulers.NewMemoryLocker()
+	}
+	notificationScheduler := schedulers.NewNotificationSchedulerWithLocker(contentService, notificationService, eventBus, dispatchLocker, cfg.Worker.LockTTL)
+
+	// Initialize auth service (token revocation is shared through Redis when available)
+	var authRepo auth.Repository
+	if redisClient != nil {
+		authRepo = auth.NewRepository(redisClient)
+	} else {
+		authRepo = auth.NewMemoryRepository()
+	}
+	authService := auth.NewService(authRepo, &cfg.Auth)
+
+	// Initialize API key service (rate limit overrides are cached in Redis when available)
+	var apiKeyCache apikey.Cache
+	if redisClient != nil {
+		apiKeyCache = apikey.NewRedisCache(redisClient)
+	} else {
+		apiKeyCache = apikey.NewMemoryCache()
+	}
+	apiKeyService := apikey.NewService(apiKeyRepo, apiKeyCache)
+
+	// Initialize organization service (organizations scope API keys and the X-Organization-ID header)
+	organizationService := organization.NewService(organization.NewRepository(db))
+
+	// Initialize asset service (uploaded images are kept on local disk or in S3)
+	assetStore, err := asset.NewStore(cfg.Assets)
+	if err != nil {
+		log.Fatalf("Failed to initialize asset storage: %v", err)
+	}
+	assetService := asset.NewService(asset.NewRepository(db), assetStore, cfg.Assets)
+	snippetService := snippet.NewService(snippet.NewRepository(db))
+	referralService := referral.NewService(referral.NewRepository(db), subscriberService, cfg.Referrals)
+	landingService, err := landing.NewService(landing.NewRepository(db), topicService, subscriberService, cfg.Landing)
+	if err != nil {
+		log.Fatalf("Failed to load landing page template: %v", err)
+	}
+	billingService := billing.NewService(billing.NewRepository(db), topicService, subscriberService, cfg.Billing)
+	sponsorService := sponsor.NewService(sponsor.NewRepository(db), topicService, contentService)
+	pollService := poll.NewService(poll.NewRepository(db), contentService, subscriberService, cfg.Polls)
+
+	// Load email templates from [templates] source, falling back to the built-in ones
+	templateStore, err := emailtemplate.NewStore(cfg.Templates, db)
+	if err != nil {
+		log.Fatalf("Failed to initialize email template source: %v", err)
+	}
+	emailTemplateService := emailtemplate.NewService(templateStore, cfg.Templates)
+	emailTemplateService.Start(context.Background())
+
+	// Initialize approval service (content is reviewed before publishing when cfg.Approval.Enabled)
+	approvalService := approval.NewService(approval.NewRepository(db), cfg.Approval)
+
+	// Start the internal gRPC API on its own port
+	if cfg.GRPC.Enabled {
+		grpcServer := grpcapi.NewServer(cfg.GRPC, grpcapi.Services{
+			Topic:        topicService,
+			Subscriber:   subscriberService,
+			Content:      contentService,
+			Lint:         lintService,
+			Approval:     approvalService,
+			Notification: notificationService,
+			Scheduler:    notificationScheduler,
+			Auth:         authService,
+			Audit:        auditService,
+			Events:       eventBus,
+		})
+		go func() {
+			if err := grpcapi.Serve(grpcServer, cfg.GRPC.Port); err != nil {
+				log.Fatalf("Failed to start gRPC server: %v", err)
+			}
+		}()
+	}
+
 	// Initialize handlers
-	handler := handlers.NewHandler(topicService, subscriberService, contentService, notificationService)
+	handler := handlers.NewHandler(topicService, subscriberService, contentService, notificationService, authService, auditService, healthService, apiKeyService, webhookService, statsService, engagementService, retentionService, pushService, preferenceService, organizationService, lintService, assetService, snippetService, approvalService, emailTemplateService, referralService, landingService, billingService, sponsorService, pollService, eventBus, notificationScheduler)
+
+	// Watch configuration so rate limit rules can change without a restart
+	cfgStore := config.NewStore(cfg)
+	if err := cfgStore.Watch(context.Background()); err != nil {
+		log.Printf("Warning: config hot reload disabled: %v", err)
+	}
 
 	// Setup routes
-	router := router.SetupRoutes(handler, cfg, redisClient)
+	router := router.SetupRoutes(handler, cfgStore, redisClient, authService, apiKeyService, organizationService)
 
 	// Get port from environment or use default
 	port := os.Getenv("PORT")
@@ -87,6 +245,7 @@ func main() {
 	log.Printf("Rate limiting: %v (storage: %s)", cfg.RateLimit.Enabled, cfg.RateLimit.Storage)
 	log.Printf("Auto-migration: %v", cfg.Database.AutoMigrate)
 	log.Printf("Scheduler auth: %v", cfg.Scheduler.Enabled)
+	log.Printf("gRPC API: %v (port: %d)", cfg.GRPC.Enabled, cfg.GRPC.Port)
 
 	if err := router.Run(":" + port); err != nil {
 		log.Fatalf("Failed to start server: %v", err)
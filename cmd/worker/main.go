@@ -2,16 +2,38 @@ package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
+	"github.com/go-redis/redis/v8"
+
+	"newsletter-service/internal/cache"
 	"newsletter-service/internal/config"
 	"newsletter-service/internal/connections"
+	"newsletter-service/internal/constants"
+	"newsletter-service/internal/events"
+	"newsletter-service/internal/handlers"
+	"newsletter-service/internal/providers"
+	"newsletter-service/internal/router"
 	"newsletter-service/internal/schedulers"
+	"newsletter-service/internal/services/billing"
 	"newsletter-service/internal/services/content"
+	"newsletter-service/internal/services/emailcheck"
+	"newsletter-service/internal/services/emailtemplate"
+	"newsletter-service/internal/services/engagement"
 	"newsletter-service/internal/services/notification"
+	"newsletter-service/internal/services/referral"
+	"newsletter-service/internal/services/retention"
 	"newsletter-service/internal/services/subscriber"
 	"newsletter-service/internal/services/topic"
+	"newsletter-service/internal/services/webhook"
+	"newsletter-service/internal/tracing"
 )
 
 func main() {
@@ -20,6 +42,17 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to load config: %v", err)
 	}
+	// Report every misconfigured provider and rate limit now rather than at the first send or request
+	if err := cfg.Validate(); err != nil {
+		log.Fatal(err)
+	}
+
+	// Initialize tracing
+	shutdownTracing, err := tracing.Init(context.Background(), cfg.Tracing, constants.ServiceNameWorker)
+	if err != nil {
+		log.Fatalf("Failed to initialize tracing: %v", err)
+	}
+	defer shutdownTracing(context.Background())
 
 	// Connect to database
 	db, err := connections.NewPostgresDB(cfg.Database)
@@ -34,44 +67,347 @@ func main() {
 	}
 	defer sqlDB.Close()
 
-	// Connect to Redis
-	redisClient, err := connections.NewRedisClient(cfg.Redis)
-	if err != nil {
-		log.Fatalf("Failed to connect to Redis: %v", err)
+	// Connect to Redis, which holds the job locks, provider send counts and read cache shared with other replicas
+	redisMode := cfg.Worker.Redis
+	if redisMode == "" {
+		redisMode = config.WorkerRedisOptional
+	}
+	var redisClient redis.UniversalClient
+	switch redisMode {
+	case config.WorkerRedisRequired:
+		redisClient, err = connections.NewRedisClient(cfg.Redis)
+		if err != nil {
+			log.Fatalf("Failed to connect to Redis: %v", err)
+		}
+	case config.WorkerRedisOptional:
+		// The client is kept even if Redis is down now; it reconnects on its own when Redis comes back
+		redisClient, err = connections.OpenRedisClient(cfg.Redis)
+		if err != nil {
+			log.Fatalf("Invalid Redis configuration: %v", err)
+		}
+		pingCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		if err := redisClient.Ping(pingCtx).Err(); err != nil {
+			log.Printf("Warning: Redis unavailable (%v); using process-local locks and send counts until it answers", err)
+		} else {
+			log.Printf("Connected to Redis")
+		}
+		cancel()
+	case config.WorkerRedisDisabled:
+		log.Printf("Redis disabled for the worker; locks and send counts are process-local, so run a single replica")
+	default:
+		log.Fatalf("Unknown worker.redis %q: expected required, optional or disabled", redisMode)
+	}
+	if redisClient != nil {
+		defer redisClient.Close()
+	}
+
+	// Without Redis there is no read cache: a process-local one would miss the web API's invalidations
+	var readCache cache.Cache
+	var locker schedulers.Locker
+	var sendCounter providers.SendCounter
+	if redisClient != nil {
+		readCache = cache.NewRedis(redisClient)
+		locker = schedulers.NewRedisLocker(redisClient)
+		sendCounter = providers.NewRedisSendCounter(redisClient)
+		if redisMode == config.WorkerRedisOptional {
+			locker = schedulers.NewFallbackLocker(locker, schedulers.NewMemoryLocker())
+			sendCounter = providers.NewFallbackSendCounter(sendCounter, providers.NewMemorySendCounter())
+		}
+	} else {
+		locker = schedulers.NewMemoryLocker()
+		sendCounter = providers.NewMemorySendCounter()
 	}
-	defer redisClient.Close()
 
 	// Initialize repositories
 	contentRepo := content.NewRepository(db)
 	subscriberRepo := subscriber.NewRepository(db)
 	topicRepo := topic.NewRepository(db)
+	webhookRepo := webhook.NewRepository(db)
+	engagementRepo := engagement.NewRepository(db)
+	emailCheckRepo := emailcheck.NewRepository(db)
+	retentionRepo := retention.NewRepository(db)
 
-	// Initialize services
-	topicService := topic.NewService(topicRepo)
+	// Initialize services (sends look up topics and subscribers through the cache shared with the web API)
+	topicService := topic.NewServiceWithCache(topicRepo, nil, readCache)
 	contentService := content.NewService(contentRepo)
-	subscriberService := subscriber.NewServiceWithTopic(subscriberRepo, topicService)
+	subscriberService := subscriber.NewServiceWithCache(subscriberRepo, topicService, nil, cfg.Subscribers, readCache)
+	webhookService := webhook.NewService(webhookRepo, cfg.Webhooks)
+	engagementService := engagement.NewService(engagementRepo, cfg.Engagement)
+	emailCheckService := emailcheck.NewService(emailCheckRepo, cfg.EmailCheck)
+	retentionService := retention.NewService(retentionRepo, cfg.Retention)
+
+	// Initialize event bus (webhooks consume it in-process; Kafka or NATS receive every event when configured)
+	eventBus, err := events.NewBus(cfg.Events, constants.ServiceNameWorker)
+	if err != nil {
+		log.Fatalf("Failed to initialize event bus: %v", err)
+	}
+	defer eventBus.Close()
+	webhook.Subscribe(eventBus, webhookService)
+
+	// Post content summaries to the Slack, Discord and Telegram destinations following each topic once it is sent
+	chatBroadcaster, err := providers.NewChatBroadcaster(&cfg.Chat)
+	if err != nil {
+		log.Fatalf("Failed to initialize chat destinations: %v", err)
+	}
+	if chatBroadcaster != nil {
+		notification.SubscribeChat(eventBus, chatBroadcaster, contentService, topicService, cfg.Chat.SummaryLength)
+	}
+
+	// Load email templates from [templates] source, falling back to the built-in ones
+	templateStore, err := emailtemplate.NewStore(cfg.Templates, db)
+	if err != nil {
+		log.Fatalf("Failed to initialize email template source: %v", err)
+	}
+	emailTemplateService := emailtemplate.NewService(templateStore, cfg.Templates)
+	emailTemplateService.Start(context.Background())
 
 	// Initialize notification service with multi-provider support
-	notificationService, err := notification.NewServiceWithProviders(db, contentService, subscriberService, cfg)
+	// Hourly provider limits are counted in Redis so they hold across every worker replica
+	notificationService, err := notification.NewServiceWithProviders(db, contentService, subscriberService, cfg, eventBus, sendCounter)
 	if err != nil {
 		log.Fatalf("Failed to create notification service with providers: %v", err)
 	}
 	log.Printf("Initialized notification service with multi-provider support")
 
-	// Initialize scheduler
-	scheduler := schedulers.NewNotificationScheduler(contentService, notificationService)
+	// Reload provider settings and worker concurrency when the configuration changes
+	cfgStore := config.NewStore(cfg)
+	cfgStore.OnReload(func(newCfg *config.Config) {
+		if err := notificationService.ApplyConfig(newCfg); err != nil {
+			log.Printf("Warning: failed to apply reloaded config: %v", err)
+			return
+		}
+		log.Printf("Applied reloaded provider and worker settings")
+	})
+	if err := cfgStore.Watch(context.Background()); err != nil {
+		log.Printf("Warning: config hot reload disabled: %v", err)
+	}
+
+	// Initialize scheduler; locks in Redis keep replicas from running the same job or sending the same content concurrently
+	scheduler := schedulers.NewNotificationSchedulerWithLocker(contentService, notificationService, eventBus, locker, cfg.Worker.LockTTL)
+
+	// Schedule jobs; each defaults to its section's interval and can be rescheduled or disabled under [worker.jobs]
+	cron := schedulers.NewCron()
+	schedule := func(name string, interval time.Duration, run schedulers.JobFunc) {
+		job := cfg.Worker.Jobs[name]
+		if job.Enabled != nil && !*job.Enabled {
+			log.Printf("Worker job %s disabled", name)
+			return
+		}
+		spec := job.Schedule
+		if spec == "" {
+			spec = "@every " + interval.String()
+		}
+		// The timeout runs inside the lock, so a cancelled run frees the job for the next tick
+		timeout := job.Timeout
+		if timeout <= 0 {
+			timeout = cfg.Worker.JobTimeout
+		}
+		if timeout <= 0 {
+			timeout = schedulers.DefaultJobTimeout
+		}
+		run = schedulers.WithTimeout(timeout, run)
+		if err := cron.AddJob(name, spec, schedulers.LockedJob(locker, name, cfg.Worker.LockTTL, run)); err != nil {
+			log.Fatalf("Invalid worker schedule: %v", err)
+		}
+		log.Printf("Worker job %s scheduled: %s (timeout %s)", name, spec, timeout)
+	}
+
+	// Send notifications for published content and emails queued individually
+	schedule(schedulers.JobPendingNotifications, time.Minute, func(ctx context.Context) error {
+		pendingErr := scheduler.ProcessPendingNotifications(ctx)
+		if pendingErr != nil {
+			log.Printf("Error processing notifications: %v", pendingErr)
+		}
+		queuedErr := scheduler.ProcessQueuedEmails(ctx)
+		if queuedErr != nil {
+			log.Printf("Error sending queued emails: %v", queuedErr)
+		}
+		return errors.Join(pendingErr, queuedErr)
+	})
+
+	// Send high priority content as soon as it is due, even while the pending job is busy with a long send
+	schedule(schedulers.JobPriorityNotifications, 15*time.Second, scheduler.ProcessPriorityNotifications)
 
-	// Start worker
-	log.Println("Worker started, checking for pending notifications every minute...")
-	ticker := time.NewTicker(1 * time.Minute)
-	defer ticker.Stop()
+	// Resend failed emails until they reach the retry limit
+	retryInterval := cfg.Worker.RetryInterval
+	if retryInterval <= 0 {
+		retryInterval = 10 * time.Minute
+	}
+	schedule(schedulers.JobEmailRetries, retryInterval, func(ctx context.Context) error {
+		err := scheduler.RetryFailedNotificationsInBatches(ctx, cfg.Worker.RetryBatchSize)
+		if err != nil {
+			log.Printf("Error retrying failed emails: %v", err)
+		}
+		return err
+	})
 
-	for {
-		select {
-		case <-ticker.C:
-			if err := scheduler.ProcessPendingNotifications(context.Background()); err != nil {
-				log.Printf("Error processing notifications: %v", err)
+	// Track the delivery of sent content until every recipient is sent or failed for good
+	schedule(schedulers.JobDeliveryProgress, time.Minute, func(ctx context.Context) error {
+		completed, err := notificationService.RefreshDeliveryProgress(ctx)
+		if err != nil {
+			log.Printf("Error refreshing delivery progress: %v", err)
+		}
+		if completed > 0 {
+			log.Printf("Completed delivery of %d contents", completed)
+		}
+		return err
+	})
+
+	// Queue daily and weekly digests; they go out with the queued emails on the next pending run
+	schedule(schedulers.JobDigests, time.Hour, func(ctx context.Context) error {
+		queued, err := notificationService.QueueDigests(ctx)
+		if err != nil {
+			log.Printf("Error queueing digests: %v", err)
+		}
+		if queued > 0 {
+			log.Printf("Queued %d digests", queued)
+		}
+		return err
+	})
+
+	// Retry webhook deliveries that failed or were interrupted
+	webhookInterval := cfg.Webhooks.PollInterval
+	if webhookInterval <= 0 {
+		webhookInterval = 30 * time.Second
+	}
+	schedule(schedulers.JobWebhookDeliveries, webhookInterval, func(ctx context.Context) error {
+		err := webhookService.ProcessDueDeliveries(ctx)
+		if err != nil {
+			log.Printf("Error processing webhook deliveries: %v", err)
+		}
+		return err
+	})
+
+	// Recalculate engagement scores and apply the sunset policy
+	engagementInterval := cfg.Engagement.Interval
+	if engagementInterval <= 0 {
+		engagementInterval = 24 * time.Hour
+	}
+	schedule(schedulers.JobEngagement, engagementInterval, func(ctx context.Context) error {
+		result, err := engagementService.Run(ctx)
+		if err != nil {
+			log.Printf("Error updating engagement scores: %v", err)
+		}
+		if result != nil {
+			log.Printf("Engagement: scored %d subscribers, %d sunset, %d restored", result.Scored, result.Sunset, result.Restored)
+		}
+		return err
+	})
+
+	// Verify MX records of subscribers created with async email checks
+	emailCheckInterval := cfg.EmailCheck.PollInterval
+	if emailCheckInterval <= 0 {
+		emailCheckInterval = time.Minute
+	}
+	schedule(schedulers.JobEmailCheck, emailCheckInterval, func(ctx context.Context) error {
+		_, err := emailCheckService.VerifyPending(ctx)
+		if err != nil {
+			log.Printf("Error verifying pending email addresses: %v", err)
+		}
+		return err
+	})
+
+	// Anonymize and delete logs past their retention period
+	retentionInterval := cfg.Retention.Interval
+	if retentionInterval <= 0 {
+		retentionInterval = 24 * time.Hour
+	}
+	schedule(schedulers.JobRetention, retentionInterval, func(ctx context.Context) error {
+		report, err := retentionService.Enforce(ctx, cfg.Retention.DryRun)
+		if err != nil {
+			log.Printf("Error enforcing retention policies: %v", err)
+		}
+		if report != nil {
+			verb := "anonymized %d, deleted %d"
+			if report.DryRun {
+				verb = "would anonymize %d, would delete %d"
 			}
+			for _, t := range report.Tables {
+				log.Printf("Retention %s: "+verb, t.Table, t.Anonymized, t.Deleted)
+			}
+		}
+		return err
+	})
+
+	// Move old email logs to the archive table so email_logs stays small
+	archiveInterval := cfg.EmailLogs.ArchiveInterval
+	if archiveInterval <= 0 {
+		archiveInterval = time.Hour
+	}
+	schedule(schedulers.JobEmailLogArchive, archiveInterval, func(ctx context.Context) error {
+		archived, err := notificationService.ArchiveEmailLogs(ctx)
+		if err != nil {
+			log.Printf("Error archiving email logs: %v", err)
+		}
+		if archived > 0 {
+			log.Printf("Archived %d email logs", archived)
+		}
+		return err
+	})
+
+	// Email confirmation links to readers who signed up through a referral link
+	referralService := referral.NewServiceWithMailer(referral.NewRepository(db), subscriberService, cfg.Referrals, notificationService)
+	referralInterval := cfg.Referrals.PollInterval
+	if referralInterval <= 0 {
+		referralInterval = time.Minute
+	}
+	schedule(schedulers.JobReferrals, referralInterval, func(ctx context.Context) error {
+		sent, err := referralService.SendConfirmations(ctx)
+		if err != nil {
+			log.Printf("Error sending referral confirmations: %v", err)
+		}
+		if sent > 0 {
+			log.Printf("Sent %d referral confirmations", sent)
+		}
+		return err
+	})
+
+	// Email subscribers whose paid subscription's payment failed
+	billingService := billing.NewServiceWithMailer(billing.NewRepository(db), topicService, subscriberService, cfg.Billing, notificationService)
+	dunningInterval := cfg.Billing.PollInterval
+	if dunningInterval <= 0 {
+		dunningInterval = 15 * time.Minute
+	}
+	schedule(schedulers.JobDunning, dunningInterval, func(ctx context.Context) error {
+		sent, err := billingService.SendDunningEmails(ctx)
+		if err != nil {
+			log.Printf("Error sending dunning emails: %v", err)
+		}
+		if sent > 0 {
+			log.Printf("Sent %d dunning emails", sent)
+		}
+		return err
+	})
+
+	// Run until interrupted or drained through the admin API, letting in-flight jobs finish
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var adminServer *http.Server
+	if cfg.Worker.AdminEnabled {
+		adminServer = &http.Server{
+			Addr:    fmt.Sprintf(":%d", cfg.Worker.AdminPort),
+			Handler: router.SetupWorkerAdminRoutes(handlers.NewWorkerHandler(cron, notificationService, emailTemplateService, stop), cfg),
+		}
+		go func() {
+			if err := adminServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				log.Fatalf("Failed to start worker admin API: %v", err)
+			}
+		}()
+		log.Printf("Worker admin API listening on port %d", cfg.Worker.AdminPort)
+	}
+
+	log.Println("Worker started")
+	cron.Start(ctx)
+	<-ctx.Done()
+	log.Println("Worker stopping, waiting for running jobs...")
+	cron.Wait()
+
+	if adminServer != nil {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := adminServer.Shutdown(shutdownCtx); err != nil {
+			log.Printf("Failed to shut down worker admin API: %v", err)
 		}
 	}
 }
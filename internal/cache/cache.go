@@ -0,0 +1,32 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"time"
+)
+
+// Cache holds copies of database rows for cache-aside reads. Callers read through Get, load from the
+// database on a miss and Set the result, and Delete the keys a write touches once it has committed.
+type Cache interface {
+	// Get decodes the value stored at key into dest, a pointer, and reports whether it was found
+	Get(ctx context.Context, key string, dest interface{}) (bool, error)
+	// Set stores value at key for ttl; a zero ttl keeps it until it is deleted
+	Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+}
+
+// Values are gob-encoded rather than JSON so fields hidden from the API with json:"-", such as a
+// subscriber's normalized email, survive the round trip
+func encode(value interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(value); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func decode(data []byte, dest interface{}) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(dest)
+}
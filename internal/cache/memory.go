@@ -0,0 +1,92 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// memoryMaxEntries bounds the in-memory cache; a send can look up every subscriber of a large topic
+const memoryMaxEntries = 10000
+
+// memorySweepInterval is how often Set drops expired entries
+const memorySweepInterval = time.Minute
+
+type memoryEntry struct {
+	data      []byte
+	expiresAt time.Time // Zero never expires
+}
+
+func (e memoryEntry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && now.After(e.expiresAt)
+}
+
+type memoryCache struct {
+	entries   map[string]memoryEntry
+	lastSweep time.Time
+	mu        sync.RWMutex
+}
+
+// NewMemory creates a cache local to this process, for when Redis is not available. Writes made by other
+// instances do not invalidate it, so their changes show up once the entries expire.
+func NewMemory() Cache {
+	return &memoryCache{entries: make(map[string]memoryEntry), lastSweep: time.Now()}
+}
+
+func (m *memoryCache) Get(ctx context.Context, key string, dest interface{}) (bool, error) {
+	m.mu.RLock()
+	entry, exists := m.entries[key]
+	m.mu.RUnlock()
+
+	if !exists || entry.expired(time.Now()) {
+		return false, nil
+	}
+	if err := decode(entry.data, dest); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (m *memoryCache) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	data, err := encode(value)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(m.lastSweep) >= memorySweepInterval {
+		for k, entry := range m.entries {
+			if entry.expired(now) {
+				delete(m.entries, k)
+			}
+		}
+		m.lastSweep = now
+	}
+
+	// When full, make room by dropping an arbitrary entry; it is reloaded on its next miss
+	if _, exists := m.entries[key]; !exists && len(m.entries) >= memoryMaxEntries {
+		for k := range m.entries {
+			delete(m.entries, k)
+			break
+		}
+	}
+
+	entry := memoryEntry{data: data}
+	if ttl > 0 {
+		entry.expiresAt = now.Add(ttl)
+	}
+	m.entries[key] = entry
+	return nil
+}
+
+func (m *memoryCache) Delete(ctx context.Context, keys ...string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, key := range keys {
+		delete(m.entries, key)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// keyPrefix keeps cached rows apart from the locks, counters and rate limit buckets in the same database
+const keyPrefix = "cache:"
+
+type redisCache struct {
+	client redis.UniversalClient
+}
+
+// NewRedis creates a cache shared by every web and worker instance
+func NewRedis(client redis.UniversalClient) Cache {
+	return &redisCache{client: client}
+}
+
+func (r *redisCache) Get(ctx context.Context, key string, dest interface{}) (bool, error) {
+	data, err := r.client.Get(ctx, keyPrefix+key).Bytes()
+	if err == redis.Nil {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if err := decode(data, dest); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *redisCache) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	data, err := encode(value)
+	if err != nil {
+		return err
+	}
+	return r.client.Set(ctx, keyPrefix+key, data, ttl).Err()
+}
+
+func (r *redisCache) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	// One DEL per key, pipelined, as keys in different cluster slots can't share a command
+	_, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, key := range keys {
+			pipe.Del(ctx, keyPrefix+key)
+		}
+		return nil
+	})
+	return err
+}
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"context"
 	"log"
 	"os"
 	"reflect"
@@ -12,19 +13,46 @@ import (
 )
 
 type Config struct {
-	Env       string          `toml:"env"`
-	Auth      AuthConfig      `toml:"auth"`
-	Scheduler SchedulerConfig `toml:"scheduler"`
-	Database  DatabaseConfig  `toml:"database"`
-	Redis     RedisConfig     `toml:"redis"`
-	Worker    WorkerConfig    `toml:"worker"`
-	Providers ProvidersConfig `toml:"providers"`
-	RateLimit RateLimitConfig `toml:"rate_limit"`
+	Env         string            `toml:"env"`
+	Auth        AuthConfig        `toml:"auth"`
+	Scheduler   SchedulerConfig   `toml:"scheduler"`
+	Database    DatabaseConfig    `toml:"database"`
+	Redis       RedisConfig       `toml:"redis"`
+	Worker      WorkerConfig      `toml:"worker"`
+	Providers   ProvidersConfig   `toml:"providers"`
+	RateLimit   RateLimitConfig   `toml:"rate_limit"`
+	Timeouts    TimeoutsConfig    `toml:"timeouts"`
+	Tracing     TracingConfig     `toml:"tracing"`
+	Idempotency IdempotencyConfig `toml:"idempotency"`
+	GRPC        GRPCConfig        `toml:"grpc"`
+	Webhooks    WebhookConfig     `toml:"webhooks"`
+	Events      EventsConfig      `toml:"events"`
+	Engagement  EngagementConfig  `toml:"engagement"`
+	EmailCheck  EmailCheckConfig  `toml:"email_check"`
+	Lint        LintConfig        `toml:"lint"`
+	Assets      AssetsConfig      `toml:"assets"`
+	Templates   TemplatesConfig   `toml:"templates"`
+	Approval    ApprovalConfig    `toml:"approval"`
+	Retention   RetentionConfig   `toml:"retention"`
+	Subscribers SubscribersConfig `toml:"subscribers"`
+	Referrals   ReferralsConfig   `toml:"referrals"`
+	Landing     LandingConfig     `toml:"landing_pages"`
+	Billing     BillingConfig     `toml:"billing"`
+	Polls       PollsConfig       `toml:"polls"`
+	EmailLogs   EmailLogsConfig   `toml:"email_logs"`
+	SendReports SendReportsConfig `toml:"send_reports"`
+	SMS         SMSConfig         `toml:"sms"`
+	Push        PushConfig        `toml:"push"`
+	Chat        ChatConfig        `toml:"chat"`
 }
 
 type AuthConfig struct {
-	Username string `toml:"username"`
-	Password string `toml:"password"`
+	Username        string        `toml:"username"`
+	Password        string        `toml:"password"`
+	JWTSecret       string        `toml:"jwt_secret"`
+	JWTIssuer       string        `toml:"jwt_issuer"`
+	AccessTokenTTL  time.Duration `toml:"access_token_ttl"`  // Lifetime of issued access tokens
+	RefreshTokenTTL time.Duration `toml:"refresh_token_ttl"` // Lifetime of issued refresh tokens
 }
 
 type SchedulerConfig struct {
@@ -34,46 +62,467 @@ type SchedulerConfig struct {
 }
 
 type DatabaseConfig struct {
-	Host        string `toml:"host"`
-	Port        int    `toml:"port"`
-	User        string `toml:"user"`
-	Password    string `toml:"password"`
-	Name        string `toml:"name"`
-	SSLMode     string `toml:"sslmode"`
-	AutoMigrate bool   `toml:"auto_migrate"`
+	Host           string `toml:"host"`
+	Port           int    `toml:"port"`
+	User           string `toml:"user"`
+	Password       string `toml:"password"`
+	Name           string `toml:"name"`
+	SSLMode        string `toml:"sslmode"`
+	AutoMigrate    bool   `toml:"auto_migrate"`
+	MigrateOnStart bool   `toml:"migrate_on_start"` // Apply embedded goose migrations at startup
+
+	MaxOpenConns     int           `toml:"max_open_conns"`     // Connections open at once per process; default 100
+	MaxIdleConns     int           `toml:"max_idle_conns"`     // Idle connections kept for reuse; default 10
+	ConnMaxLifetime  time.Duration `toml:"conn_max_lifetime"`  // Connections are closed and reopened after this; default 1h
+	ConnMaxIdleTime  time.Duration `toml:"conn_max_idle_time"` // Idle connections are closed after this; 0 keeps them until conn_max_lifetime
+	StatementTimeout time.Duration `toml:"statement_timeout"`  // Postgres cancels statements running longer; 0 uses the server's setting
+	LockTimeout      time.Duration `toml:"lock_timeout"`       // Postgres gives up on statements waiting longer for a lock; 0 uses the server's setting
+
+	SlowQueryThreshold time.Duration `toml:"slow_query_threshold"` // Queries taking longer are logged with their SQL; 0 turns the log off
 }
 
+// Redis deployment modes
+const (
+	RedisModeSingle   = "single"
+	RedisModeSentinel = "sentinel"
+	RedisModeCluster  = "cluster"
+)
+
 type RedisConfig struct {
-	Host     string `toml:"host"`
+	Mode     string `toml:"mode"` // "single" (default), "sentinel" or "cluster"
+	Host     string `toml:"host"` // single: the server
 	Port     int    `toml:"port"`
+	Addrs    string `toml:"addrs"`    // sentinel: comma-separated sentinel host:port list; cluster: seed nodes
+	Username string `toml:"username"` // ACL user; empty authenticates as default
 	Password string `toml:"password"`
-	DB       int    `toml:"db"`
+	DB       int    `toml:"db"` // Ignored in cluster mode, which only has database 0
+
+	MasterName       string `toml:"master_name"`       // sentinel: name of the monitored master
+	SentinelUsername string `toml:"sentinel_username"` // sentinel: credentials of the sentinels themselves, if they need any
+	SentinelPassword string `toml:"sentinel_password"`
+
+	TLS                   bool   `toml:"tls"`
+	TLSCAFile             string `toml:"tls_ca_file"`     // PEM bundle to verify the server with instead of the system roots
+	TLSServerName         string `toml:"tls_server_name"` // Name to verify when it differs from the host connected to
+	TLSInsecureSkipVerify bool   `toml:"tls_insecure_skip_verify"`
+
+	PoolSize     int           `toml:"pool_size"`      // Connections per node; default 10 per CPU
+	MinIdleConns int           `toml:"min_idle_conns"` // Connections kept open while idle
+	PoolTimeout  time.Duration `toml:"pool_timeout"`   // Wait for a free connection before failing; default read_timeout + 1s
+	DialTimeout  time.Duration `toml:"dial_timeout"`   // Default 5s
+	ReadTimeout  time.Duration `toml:"read_timeout"`   // Default 3s
+	WriteTimeout time.Duration `toml:"write_timeout"`  // Default read_timeout
 }
 
 type WorkerConfig struct {
-	MaxAsyncProcess int `toml:"max_async_process"`
+	MaxAsyncProcess int                        `toml:"max_async_process"`
+	Jobs            map[string]WorkerJobConfig `toml:"jobs"`          // Keyed by job: pending_notifications, priority_notifications, email_retries, webhook_deliveries, engagement, email_check, retention, digests
+	LockTTL         time.Duration              `toml:"lock_ttl"`      // Expiry of job and content locks held in Redis, renewed while held; frees the lock if a worker dies
+	AdminEnabled    bool                       `toml:"admin_enabled"` // Serve the admin API (job status, pause/resume, trigger, drain) using the scheduler credentials
+	AdminPort       int                        `toml:"admin_port"`
+	RetryInterval   time.Duration              `toml:"retry_interval"`   // How often failed emails are retried
+	RetryBatchSize  int                        `toml:"retry_batch_size"` // Failed emails loaded per query while retrying
+	SendTimeout     time.Duration              `toml:"send_timeout"`     // Longest one provider call may take before the send is abandoned as failed; default 1m
+	JobTimeout      time.Duration              `toml:"job_timeout"`      // Longest one run of a job may take before it is cancelled; default 30m
+	Redis           string                     `toml:"redis"`            // "optional" (default), "required" or "disabled"; see the WorkerRedis modes
+
+	// Share of concurrent sends only high priority content may use, so an urgent send finds slots free even while
+	// a large regular send is running; 0 reserves none
+	HighPriorityReserve float64 `toml:"high_priority_reserve"`
+
+	AdaptiveConcurrency AdaptiveConcurrencyConfig `toml:"adaptive_concurrency"`
+}
+
+// AdaptiveConcurrencyConfig lets the number of concurrent email sends follow provider health instead of staying
+// at max_async_process: it grows by one per healthy window and is cut by backoff when a window's sends were
+// slow, failed too often or waited for database connections
+type AdaptiveConcurrencyConfig struct {
+	Enabled       bool          `toml:"enabled"`
+	Min           int           `toml:"min"`            // Default 1
+	Max           int           `toml:"max"`            // Default max_async_process
+	Window        int           `toml:"window"`         // Sends judged together before each adjustment; default 20
+	LatencyTarget time.Duration `toml:"latency_target"` // Average provider call time above which a window counts as congested; default 2s
+	ErrorRate     float64       `toml:"error_rate"`     // Share of failed sends above which a window counts as congested; default 0.2
+	Backoff       float64       `toml:"backoff"`        // Factor the limit is multiplied by on congestion, below 1; default 0.5
+}
+
+// How the worker depends on Redis, set by [worker] redis
+const (
+	// WorkerRedisRequired refuses to start the worker without Redis
+	WorkerRedisRequired = "required"
+	// WorkerRedisOptional uses Redis for locks, send counts and the read cache while it answers, and falls back
+	// to process-local locks and counts while it doesn't, moving back once it reconnects
+	WorkerRedisOptional = "optional"
+	// WorkerRedisDisabled never connects; for a single worker replica
+	WorkerRedisDisabled = "disabled"
+)
+
+type WorkerJobConfig struct {
+	Enabled  *bool         `toml:"enabled"`  // Defaults to true when unset
+	Schedule string        `toml:"schedule"` // Cron expression ("*/5 * * * *"), descriptor ("@hourly") or "@every 30s"; defaults to the job's interval
+	Timeout  time.Duration `toml:"timeout"`  // Overrides job_timeout for this job
+}
+
+type GRPCConfig struct {
+	Enabled      bool   `toml:"enabled"`
+	Port         int    `toml:"port"`
+	ServiceToken string `toml:"service_token"` // Shared bearer token for internal callers (JWTs are also accepted)
+	Reflection   bool   `toml:"reflection"`    // Register the gRPC reflection service (grpcurl)
+}
+
+type WebhookConfig struct {
+	Timeout      time.Duration `toml:"timeout"`       // Per-request timeout when posting to an endpoint
+	MaxAttempts  int           `toml:"max_attempts"`  // Attempts before a delivery is marked failed
+	RetryBackoff time.Duration `toml:"retry_backoff"` // Delay before the first retry, doubled after each failure
+	PollInterval time.Duration `toml:"poll_interval"` // How often the worker retries due deliveries
+}
+
+type EventsConfig struct {
+	Driver            string `toml:"driver"`              // "inprocess", "kafka" or "nats"
+	KafkaBrokers      string `toml:"kafka_brokers"`       // Comma-separated host:port list
+	KafkaTopic        string `toml:"kafka_topic"`         // Topic receiving every event, keyed by event type
+	NATSURL           string `toml:"nats_url"`            // e.g. nats://localhost:4222
+	NATSSubjectPrefix string `toml:"nats_subject_prefix"` // Events are published on <prefix>.<event type>
+}
+
+// Actions the sunset policy can take on disengaged subscribers
+const (
+	SunsetActionFlag  = "flag"  // Only set sunset_at so the subscriber can be segmented
+	SunsetActionPause = "pause" // Also deactivate the subscriber so sends skip them
+)
+
+type EngagementConfig struct {
+	Interval         time.Duration `toml:"interval"`           // worker: how often scores are recalculated
+	ScoreWindow      int           `toml:"score_window"`       // Most recent sends considered for the open rate
+	RecencyHalfLife  time.Duration `toml:"recency_half_life"`  // Time for the recency part of the score to halve after the last open
+	SunsetAfterSends int           `toml:"sunset_after_sends"` // Consecutive unopened sends before the sunset policy applies (0 = disabled)
+	SunsetAction     string        `toml:"sunset_action"`      // "flag" or "pause"
+}
+
+type EmailCheckConfig struct {
+	CheckMX          bool          `toml:"check_mx"`          // Require an MX (or A/AAAA) record for the address domain
+	MXTimeout        time.Duration `toml:"mx_timeout"`        // DNS lookup timeout; lookups that time out never reject
+	MXCacheTTL       time.Duration `toml:"mx_cache_ttl"`      // How long lookup results are cached per domain
+	Async            bool          `toml:"async"`             // Defer MX checks to the worker; new subscribers start as "pending"
+	PollInterval     time.Duration `toml:"poll_interval"`     // worker: how often pending addresses are verified
+	DisposableAction string        `toml:"disposable_action"` // "reject" or "flag" addresses on disposable domains
+	BlockedDomains   string        `toml:"blocked_domains"`   // Comma-separated domains treated as disposable in addition to the built-in list
+}
+
+type LintConfig struct {
+	BlockPublish     bool          `toml:"block_publish"`       // Refuse to publish content whose lint report has errors
+	CheckLinks       bool          `toml:"check_links"`         // Request every http(s) link and image; missing pages are errors, other failures warnings
+	LinkTimeout      time.Duration `toml:"link_timeout"`        // Per-link request timeout
+	MinWordsPerImage int           `toml:"min_words_per_image"` // Warn when content has fewer words of text than this per image
+	SpamdAddr        string        `toml:"spamd_addr"`          // SpamAssassin spamd host:port; empty skips spam scoring
+	SpamdTimeout     time.Duration `toml:"spamd_timeout"`       // How long to wait for spamd before reporting the score as unknown
+	SpamThreshold    float64       `toml:"spam_threshold"`      // Scores at or above this are errors; 0 uses spamd's own threshold
+	CheckClients     bool          `toml:"check_clients"`       // Flag CSS, elements and image sizes that break in common email clients
+	// Email client preview service (Litmus or Email on Acid style) the rendered email is posted to; empty skips it
+	ClientPreviewURL     string        `toml:"client_preview_url"`
+	ClientPreviewToken   string        `toml:"client_preview_token"`   // Sent as a bearer token
+	ClientPreviewTimeout time.Duration `toml:"client_preview_timeout"` // Rendering in many clients takes a while
+}
+
+// ApprovalConfig configures the review of content before it is published. Actors are basic auth usernames,
+// token subjects and API key prefixes, as recorded in the audit log.
+type ApprovalConfig struct {
+	Enabled    bool     `toml:"enabled"`    // Refuse to publish content that hasn't been approved
+	Reviewers  []string `toml:"reviewers"`  // Who may approve or reject submitted content; empty allows anyone
+	Publishers []string `toml:"publishers"` // Who may publish approved content; empty allows anyone
+}
+
+// AssetsConfig configures image uploads for content, which are served publicly from /assets/:key
+type AssetsConfig struct {
+	Storage   string         `toml:"storage"`    // "local" (default) or "s3"
+	Dir       string         `toml:"dir"`        // local: directory uploads are written to (default "data/assets")
+	PublicURL string         `toml:"public_url"` // Base URL asset links start with, e.g. "https://news.example.com"; empty uses the request's host
+	MaxSize   int64          `toml:"max_size"`   // Largest accepted upload in bytes (default 5 MiB)
+	S3        S3AssetsConfig `toml:"s3"`
+}
+
+// Email template sources
+const (
+	TemplateSourceBuiltin  = "builtin"
+	TemplateSourceFile     = "file"
+	TemplateSourceDatabase = "database"
+	TemplateSourceS3       = "s3"
+)
+
+// TemplatesConfig chooses where the HTML and plain text email templates (email.html, email.txt) are read from.
+// A template the source doesn't have, or one that fails to parse, falls back to the built-in one.
+type TemplatesConfig struct {
+	Source          string         `toml:"source"`           // "builtin" (default), "file", "database" or "s3"
+	Dir             string         `toml:"dir"`              // file: directory holding the templates (default "templates")
+	S3              S3AssetsConfig `toml:"s3"`               // s3: bucket and prefix holding the templates
+	RefreshInterval time.Duration  `toml:"refresh_interval"` // Templates are re-read this often; 0 only on reload
+}
+
+type S3AssetsConfig struct {
+	Bucket   string `toml:"bucket"`
+	Region   string `toml:"region"`
+	Endpoint string `toml:"endpoint"` // S3 compatible storage such as MinIO, addressed path-style; empty uses AWS
+	Prefix   string `toml:"prefix"`   // Prepended to object keys, e.g. "assets/"
+}
+
+type SubscribersConfig struct {
+	AutoCreateTopics bool   `toml:"auto_create_topics"` // Create unknown topics named on subscriber create instead of rejecting the request
+	DefaultTimezone  string `toml:"default_timezone"`   // IANA zone for subscribers without one when content is sent at a local time (default UTC)
+}
+
+// ReferralsConfig configures referral links. Readers follow a subscriber's link to signup_url, which posts
+// to /referrals/redeem; the referrer is credited once the new reader confirms through the emailed link.
+type ReferralsConfig struct {
+	Enabled         bool          `toml:"enabled"`
+	SignupURL       string        `toml:"signup_url"`       // Signup page referral links point to; "?ref=<code>" is added
+	PublicURL       string        `toml:"public_url"`       // Base URL of this service, which confirmation links start with
+	ConfirmWithin   time.Duration `toml:"confirm_within"`   // Confirmation links expire after this (default 7 days)
+	PollInterval    time.Duration `toml:"poll_interval"`    // worker: how often confirmation emails are sent
+	LeaderboardSize int           `toml:"leaderboard_size"` // Subscribers listed by the leaderboard when no limit is given
+}
+
+// LandingConfig configures the signup pages served at /topics/<slug>. A template file replaces the built-in
+// page; it is parsed once at startup, and one that doesn't parse or render stops the service from starting.
+type LandingConfig struct {
+	Enabled      bool   `toml:"enabled"`
+	TemplateFile string `toml:"template_file"` // html/template file rendering the page; empty uses the built-in one
+	RecentIssues int    `toml:"recent_issues"` // Sent issues listed on the page, newest first (default 5)
+}
+
+// BillingConfig configures paid plans sold through Stripe Checkout. Stripe posts subscription changes to
+// /billing/stripe/webhook, which only accepts events signed with webhook_secret.
+type BillingConfig struct {
+	Enabled          bool            `toml:"enabled"`
+	SecretKey        string          `toml:"secret_key"`        // Stripe secret API key
+	WebhookSecret    string          `toml:"webhook_secret"`    // Signing secret of the Stripe webhook endpoint
+	WebhookTolerance time.Duration   `toml:"webhook_tolerance"` // Events signed longer ago than this are rejected as replays (default 5m)
+	SuccessURL       string          `toml:"success_url"`       // Where Checkout sends subscribers after paying
+	CancelURL        string          `toml:"cancel_url"`        // Where Checkout sends subscribers who back out
+	PortalURL        string          `toml:"portal_url"`        // Page where subscribers update their card, linked from dunning emails
+	DunningSchedule  []time.Duration `toml:"dunning_schedule"`  // After a failed payment, one email is sent at each of these delays
+	PollInterval     time.Duration   `toml:"poll_interval"`     // worker: how often due dunning emails are sent
+}
+
+// PollsConfig configures the polls embedded in content. Each option is a link carrying a token signed with
+// secret, so a click answers for the subscriber the email went to without them signing in.
+type PollsConfig struct {
+	Enabled   bool   `toml:"enabled"`
+	PublicURL string `toml:"public_url"` // Base URL of this service, which answer links start with
+	Secret    string `toml:"secret"`     // Signs answer links; changing it breaks the links of emails already sent
+}
+
+// SendReportsConfig configures the summary made of each content's delivery once it completes
+type SendReportsConfig struct {
+	Enabled    bool     `toml:"enabled"`
+	Recipients []string `toml:"recipients"` // Admin addresses the report is emailed to
+	Webhook    bool     `toml:"webhook"`    // Also deliver it to webhooks subscribed to send.report
+}
+
+// EmailLogsConfig configures how email logs keep what was sent
+type EmailLogsConfig struct {
+	BodyStorage      string        `toml:"body_storage"`       // "inline" (default) or "shared"; see the EmailLogBody modes
+	ArchiveAfter     time.Duration `toml:"archive_after"`      // Move logs older than this to email_logs_archive (0 = never)
+	ArchiveInterval  time.Duration `toml:"archive_interval"`   // worker: how often logs are archived
+	ArchiveBatchSize int           `toml:"archive_batch_size"` // Logs moved per statement
+}
+
+// Where email logs keep the body, set by [email_logs] body_storage
+const (
+	// EmailLogBodyInline keeps each recipient's copy of the body in their log row
+	EmailLogBodyInline = "inline"
+	// EmailLogBodyShared keeps each distinct body once, in email_log_bodies, and gives logs a reference to it. A
+	// content sent to a large list then stores its body once rather than per recipient; logs are read without
+	// their body, which is loaded when a single log is fetched or resent.
+	EmailLogBodyShared = "shared"
+)
+
+type RetentionConfig struct {
+	Interval  time.Duration              `toml:"interval"`   // worker: how often policies are enforced
+	DryRun    bool                       `toml:"dry_run"`    // Only report how many rows would be anonymized or deleted
+	BatchSize int                        `toml:"batch_size"` // Rows changed per statement
+	Tables    map[string]RetentionPolicy `toml:"tables"`     // Keyed by table: email_logs, email_logs_archive, webhook_deliveries, audit_logs, or topics, subscribers and contents (soft-deleted rows)
+}
+
+type RetentionPolicy struct {
+	AnonymizeAfter time.Duration `toml:"anonymize_after"` // Scrub personal data from rows older than this (0 = never)
+	DeleteAfter    time.Duration `toml:"delete_after"`    // Permanently delete rows older than this, or deleted longer ago for soft-deleted tables (0 = never)
+}
+
+type IdempotencyConfig struct {
+	Enabled bool          `toml:"enabled"`
+	TTL     time.Duration `toml:"ttl"`      // How long responses are replayed for a key
+	LockTTL time.Duration `toml:"lock_ttl"` // How long a key stays locked while its first request runs
+}
+
+type TracingConfig struct {
+	Enabled     bool    `toml:"enabled"`
+	Exporter    string  `toml:"exporter"` // "otlp" or "stdout"
+	Endpoint    string  `toml:"endpoint"` // OTLP/HTTP collector host:port
+	Insecure    bool    `toml:"insecure"` // Disable TLS towards the collector
+	SampleRatio float64 `toml:"sample_ratio"`
+}
+
+// TimeoutsConfig bounds how long the API works on a request. The deadline is set on the request context, so
+// database and Redis calls made for a request are cancelled with it instead of running on behind a client that
+// gave up.
+type TimeoutsConfig struct {
+	Request time.Duration            `toml:"request"` // Default for every route; 0 leaves requests unbounded
+	Routes  map[string]time.Duration `toml:"routes"`  // Overrides keyed by "METHOD:route" with the route as registered, e.g. "GET:/api/v1/email-logs"; 0 leaves the route unbounded
 }
 
 type RateLimitConfig struct {
-	Enabled     bool                     `toml:"enabled"`
-	Storage     string                   `toml:"storage"` // "redis" or "memory"
-	DefaultRule RateLimitRule            `toml:"default"`
-	Routes      map[string]RateLimitRule `toml:"routes"`
+	Enabled         bool                     `toml:"enabled"`
+	Storage         string                   `toml:"storage"`          // "redis" or "memory"
+	CleanupInterval time.Duration            `toml:"cleanup_interval"` // memory: how often expired entries are swept
+	MaxEntries      int                      `toml:"max_entries"`      // memory: LRU cap on tracked clients (0 = unbounded)
+	DefaultRule     RateLimitRule            `toml:"default"`
+	Routes          map[string]RateLimitRule `toml:"routes"`
 }
 
+// Rate limiting strategies selectable per rule
+const (
+	RateLimitStrategyTokenBucket   = "token_bucket"
+	RateLimitStrategySlidingWindow = "sliding_window"
+	RateLimitStrategyConcurrency   = "concurrency"
+)
+
 type RateLimitRule struct {
+	Strategy       string        `toml:"strategy"`        // "token_bucket" (default), "sliding_window" or "concurrency"
 	BucketSize     int           `toml:"bucket_size"`     // Maximum tokens in bucket
 	RefillSize     int           `toml:"refill_size"`     // Tokens added per refill
 	RefillDuration time.Duration `toml:"refill_duration"` // How often to refill
+	Limit          int           `toml:"limit"`           // sliding_window: requests allowed per window
+	Window         time.Duration `toml:"window"`          // sliding_window: window length
+	MaxConcurrent  int           `toml:"max_concurrent"`  // concurrency: requests in flight at once
 	IdentifyBy     string        `toml:"identify_by"`     // "ip" or "api_key"
 	Enabled        bool          `toml:"enabled"`
 }
 
 type ProvidersConfig struct {
+	Enabled        []string                      `toml:"enabled"`
+	LoadBalancing  string                        `toml:"load_balancing"` // "round_robin", "weighted", "least_load", "weighted_random"
+	Weights        map[string]int                `toml:"weights"`        // Traffic share per provider name for "weighted_random"
+	SMTP           map[string]SMTPProviderConfig `toml:"smtp"`
+	API            map[string]APIProviderConfig  `toml:"api"`
+	Warmup         map[string]WarmupConfig       `toml:"warmup"` // Keyed by provider name or sending domain
+	DomainThrottle DomainThrottleConfig          `toml:"domain_throttle"`
+	Local          LocalProviderConfig           `toml:"local"`         // Used when "local" is enabled
+	Organizations  map[string][]string           `toml:"organizations"` // Enabled providers per organization ID, overriding enabled
+}
+
+// SMSConfig configures the SMS channel. Providers are chosen with the same load balancing strategies as
+// email providers; with none enabled, content sent on the sms channel only goes out by email.
+type SMSConfig struct {
+	Enabled       []string                   `toml:"enabled"`
+	LoadBalancing string                     `toml:"load_balancing"` // Same strategies as [providers]
+	Weights       map[string]int             `toml:"weights"`
+	MaxLength     int                        `toml:"max_length"` // Longer messages are cut, default 320 (two segments)
+	Twilio        map[string]TwilioSMSConfig `toml:"twilio"`
+	SNS           map[string]SNSSMSConfig    `toml:"sns"`
+}
+
+// TwilioSMSConfig configures an SMS provider sending through the Twilio Messages API
+type TwilioSMSConfig struct {
+	AccountSID          string `toml:"account_sid"`
+	AuthToken           string `toml:"auth_token"`
+	From                string `toml:"from"`                  // Sending number in E.164 format
+	MessagingServiceSID string `toml:"messaging_service_sid"` // Used instead of from when set
+	Priority            int    `toml:"priority"`
+	MaxPerHour          int    `toml:"max_per_hour"`
+}
+
+// SNSSMSConfig configures an SMS provider publishing through Amazon SNS. Credentials come from the default
+// AWS chain (environment, shared config or instance role).
+type SNSSMSConfig struct {
+	Region     string `toml:"region"`
+	SenderID   string `toml:"sender_id"` // Alphanumeric sender ID, where the destination country allows it
+	SMSType    string `toml:"sms_type"`  // "Transactional" or "Promotional" (default)
+	Priority   int    `toml:"priority"`
+	MaxPerHour int    `toml:"max_per_hour"`
+}
+
+// PushConfig configures the push channel, which notifies registered devices of content on topics where the
+// subscriber turned push on. Providers are load balanced like email providers.
+type PushConfig struct {
+	Enabled       []string                 `toml:"enabled"`
+	LoadBalancing string                   `toml:"load_balancing"`
+	Weights       map[string]int           `toml:"weights"`
+	FCM           map[string]FCMPushConfig `toml:"fcm"`
+	WebPush       map[string]WebPushConfig `toml:"webpush"`
+}
+
+// FCMPushConfig configures a push provider sending to Android, iOS and web devices through the Firebase
+// Cloud Messaging HTTP v1 API
+type FCMPushConfig struct {
+	CredentialsFile string `toml:"credentials_file"` // Service account JSON key
+	ProjectID       string `toml:"project_id"`       // Defaults to the service account's project
+	Priority        int    `toml:"priority"`
+	MaxPerHour      int    `toml:"max_per_hour"`
+}
+
+// WebPushConfig configures a push provider sending standard Web Push (RFC 8030) to browser subscriptions,
+// signed with VAPID keys
+type WebPushConfig struct {
+	VAPIDPublicKey  string        `toml:"vapid_public_key"`  // Base64url uncompressed P-256 point, as given to PushManager.subscribe
+	VAPIDPrivateKey string        `toml:"vapid_private_key"` // Base64url P-256 private scalar
+	Subject         string        `toml:"subject"`           // mailto: or https: contact for push services
+	TTL             time.Duration `toml:"ttl"`               // How long push services hold undelivered messages, default 24h
+	Priority        int           `toml:"priority"`
+	MaxPerHour      int           `toml:"max_per_hour"`
+}
+
+// ChatConfig configures chat destinations that receive a summary of each content once it has been sent.
+// Every destination lists the topics it follows; an empty list follows all topics.
+type ChatConfig struct {
 	Enabled       []string                      `toml:"enabled"`
-	LoadBalancing string                        `toml:"load_balancing"` // "round_robin", "weighted", "least_load"
-	SMTP          map[string]SMTPProviderConfig `toml:"smtp"`
-	API           map[string]APIProviderConfig  `toml:"api"`
+	SummaryLength int                           `toml:"summary_length"` // Characters of the body posted, default 280
+	Timeout       time.Duration                 `toml:"timeout"`        // Per-post request timeout, default 10s
+	Slack         map[string]SlackChatConfig    `toml:"slack"`
+	Discord       map[string]DiscordChatConfig  `toml:"discord"`
+	Telegram      map[string]TelegramChatConfig `toml:"telegram"`
+}
+
+// SlackChatConfig posts to a Slack channel through an incoming webhook
+type SlackChatConfig struct {
+	WebhookURL string `toml:"webhook_url"`
+	Topics     []uint `toml:"topics"`
+}
+
+// DiscordChatConfig posts to a Discord channel through a channel webhook
+type DiscordChatConfig struct {
+	WebhookURL string `toml:"webhook_url"`
+	Username   string `toml:"username"` // Overrides the webhook's default name
+	Topics     []uint `toml:"topics"`
+}
+
+// TelegramChatConfig posts to a Telegram chat or channel through a bot that is a member of it
+type TelegramChatConfig struct {
+	BotToken string `toml:"bot_token"`
+	ChatID   string `toml:"chat_id"` // Numeric ID, or @channelusername for public channels
+	Topics   []uint `toml:"topics"`
+}
+
+// LocalProviderConfig configures the development provider, which keeps emails on the local machine
+type LocalProviderConfig struct {
+	Mode     string `toml:"mode"`      // "file" (default) writes .eml files, "maildir" delivers into a maildir, "smtp" relays to MailHog
+	Dir      string `toml:"dir"`       // Output directory for file and maildir modes, default tmp/mail
+	SMTPHost string `toml:"smtp_host"` // smtp mode, default localhost
+	SMTPPort int    `toml:"smtp_port"` // smtp mode, default 1025
+	From     string `toml:"from"`
+	Priority int    `toml:"priority"`
+}
+
+// DomainThrottleConfig limits how fast emails are sent to each recipient domain
+type DomainThrottleConfig struct {
+	Enabled          bool           `toml:"enabled"`
+	DefaultPerMinute int            `toml:"default_per_minute"` // 0 leaves unlisted domains unthrottled
+	PerMinute        map[string]int `toml:"per_minute"`         // Keyed by recipient domain, e.g. "gmail.com"
+}
+
+// WarmupConfig ramps up the daily send cap of a new provider or sending domain
+type WarmupConfig struct {
+	StartDate string `toml:"start_date"` // YYYY-MM-DD (UTC), day 1 of the ramp
+	DailyCaps []int  `toml:"daily_caps"` // Cap for day 1, day 2, ...; sends are uncapped after the last day
 }
 
 type SMTPConfig struct {
@@ -92,6 +541,14 @@ type SMTPProviderConfig struct {
 	From             string `toml:"from"`
 	Priority         int    `toml:"priority"`
 	MaxEmailsPerHour int    `toml:"max_emails_per_hour"`
+
+	// Connection handling; the pool is reused across messages and batches
+	TLSMode            string        `toml:"tls_mode"` // "starttls", "implicit" or "none"; empty uses STARTTLS when offered
+	InsecureSkipVerify bool          `toml:"insecure_skip_verify"`
+	PoolSize           int           `toml:"pool_size"`    // Connections kept open, default 4
+	DialTimeout        time.Duration `toml:"dial_timeout"` // Default 10s
+	SendTimeout        time.Duration `toml:"send_timeout"` // Per message, default 30s
+	IdleTimeout        time.Duration `toml:"idle_timeout"` // Idle connections older than this are redialed, default 1m
 }
 
 type APIProviderConfig struct {
@@ -102,25 +559,47 @@ type APIProviderConfig struct {
 	MaxEmailsPerHour int    `toml:"max_emails_per_hour"`
 	BulkEnabled      bool   `toml:"bulk_enabled"`
 	MaxBatchSize     int    `toml:"max_batch_size"`
+
+	// Request shape; unset fields fit the Mailtrap send API
+	Method          string            `toml:"method"`            // Default POST
+	AuthScheme      string            `toml:"auth_scheme"`       // "bearer" (default), "basic", "header" or "none"
+	AuthHeader      string            `toml:"auth_header"`       // Header carrying the token for the header scheme
+	Headers         map[string]string `toml:"headers"`           // Extra static request headers
+	PayloadTemplate string            `toml:"payload_template"`  // Go template rendering the JSON body
+	SuccessStatus   []int             `toml:"success_status"`    // Default any 2xx
+	Timeout         time.Duration     `toml:"timeout"`           // Default 30s
+	MessageIDPath   string            `toml:"message_id_path"`   // Dot path to the message ID(s) in the JSON response
+	MessageIDHeader string            `toml:"message_id_header"` // Response header holding the message ID, e.g. X-Message-Id
+	BulkStrategy    string            `toml:"bulk_strategy"`     // "individual" (default), "personalizations" or "bcc"
 }
 
+// DefaultConfigPath is the TOML file loaded (and watched for reloads) at startup
+const DefaultConfigPath = "env/default.toml"
+
 // LoadDefaultConfig loads default config from env/default.toml
 func LoadDefaultConfig() (*Config, error) {
 	var config Config
-	if _, err := toml.DecodeFile("env/default.toml", &config); err != nil {
+	if _, err := toml.DecodeFile(DefaultConfigPath, &config); err != nil {
 		return nil, err
 	}
 	return &config, nil
 }
 
-// LoadConfig loads config from default TOML and overrides from environment variables
+// LoadConfig loads config from the default TOML, the profile's TOML layered over it, and overrides from
+// environment variables
 func LoadConfig() (*Config, error) {
-	cfg, err := LoadDefaultConfig()
+	cfg, err := loadProfileConfig()
 	if err != nil {
 		return nil, err
 	}
 
 	UpdateEnvConfig(cfg)
+	updateNestedFromEnv(cfg)
+
+	// Replace vault://, awssm:// and file:// references with their secret values
+	if err := ResolveSecrets(context.Background(), cfg); err != nil {
+		return nil, err
+	}
 	return cfg, nil
 }
 
@@ -166,12 +645,135 @@ func updateSectionFromEnv(val reflect.Value, sectionName string) {
 	}
 }
 
+// envPathSeparator splits the levels of an override reaching into nested tables and maps, e.g.
+// PROVIDERS__SMTP__SMTP_PRIMARY__HOST or RATE_LIMIT__ROUTES__POST_AUTH_LOGIN__BUCKET_SIZE
+const envPathSeparator = "__"
+
+// updateNestedFromEnv applies overrides whose names hold envPathSeparator. Each level matches a toml tag or
+// map key compared by envKeyName; a map key that doesn't exist yet is added in lower case, so routes and
+// other keys that aren't valid in variable names must already be in a TOML file to be overridden.
+func updateNestedFromEnv(cfg *Config) {
+	root := reflect.ValueOf(cfg).Elem()
+	for _, env := range os.Environ() {
+		name, envVal, _ := strings.Cut(env, "=")
+		if !strings.Contains(name, envPathSeparator) {
+			continue
+		}
+		path := strings.Split(name, envPathSeparator)
+		if tomlField(root, path[0]) == -1 {
+			continue // Not one of ours
+		}
+		if !updatePathFromEnv(root, path, envVal) {
+			log.Printf("Warning: %s does not match a config setting", name)
+		}
+	}
+}
+
+// updatePathFromEnv sets the value found by following path down from val and reports whether path led to one
+func updatePathFromEnv(val reflect.Value, path []string, envVal string) bool {
+	if val.Kind() == reflect.Pointer {
+		// Optional settings such as [worker.jobs.<name>] enabled; only allocated when the path matches
+		elem := reflect.New(val.Type().Elem())
+		if !val.IsNil() {
+			elem.Elem().Set(val.Elem())
+		}
+		if !val.CanSet() || !updatePathFromEnv(elem.Elem(), path, envVal) {
+			return false
+		}
+		val.Set(elem)
+		return true
+	}
+
+	if len(path) == 0 {
+		if !val.CanSet() || val.Kind() == reflect.Struct || val.Kind() == reflect.Map {
+			return false
+		}
+		updateFieldValue(val, envVal)
+		return true
+	}
+
+	switch val.Kind() {
+	case reflect.Struct:
+		i := tomlField(val, path[0])
+		if i == -1 {
+			return false
+		}
+		return updatePathFromEnv(val.Field(i), path[1:], envVal)
+	case reflect.Map:
+		if val.Type().Key().Kind() != reflect.String {
+			return false
+		}
+		key := reflect.ValueOf(strings.ToLower(path[0])).Convert(val.Type().Key())
+		for _, existing := range val.MapKeys() {
+			if envKeyName(existing.String()) == path[0] {
+				key = existing
+				break
+			}
+		}
+		// Map values can't be set in place, so update a copy and store it back
+		elem := reflect.New(val.Type().Elem()).Elem()
+		if existing := val.MapIndex(key); existing.IsValid() {
+			elem.Set(existing)
+		}
+		if !updatePathFromEnv(elem, path[1:], envVal) {
+			return false
+		}
+		if val.IsNil() {
+			val.Set(reflect.MakeMap(val.Type()))
+		}
+		val.SetMapIndex(key, elem)
+		return true
+	}
+	return false
+}
+
+// tomlField returns the index of the struct field whose toml tag matches the variable name part, or -1
+func tomlField(val reflect.Value, name string) int {
+	t := val.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if tag := t.Field(i).Tag.Get("toml"); tag != "" && envKeyName(tag) == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// envKeyName is how a toml tag or map key is spelled in a variable name: upper case, with each run of other
+// characters replaced by one underscore, so "POST:/auth/login" becomes POST_AUTH_LOGIN
+func envKeyName(key string) string {
+	var b strings.Builder
+	underscore := false
+	for _, r := range strings.ToUpper(key) {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			if underscore && b.Len() > 0 {
+				b.WriteByte('_')
+			}
+			underscore = false
+			b.WriteRune(r)
+		} else {
+			underscore = true
+		}
+	}
+	return b.String()
+}
+
 func updateFieldValue(field reflect.Value, envVal string) {
 	if !field.CanSet() {
 		return
 	}
 
 	switch field.Kind() {
+	case reflect.Slice:
+		// Comma-separated, e.g. PROVIDERS_ENABLED=smtp_primary,mailtrap
+		var parts []string
+		if envVal != "" {
+			parts = strings.Split(envVal, ",")
+		}
+		list := reflect.MakeSlice(field.Type(), len(parts), len(parts))
+		for i, part := range parts {
+			updateFieldValue(list.Index(i), strings.TrimSpace(part))
+		}
+		field.Set(list)
 	case reflect.String:
 		field.SetString(envVal)
 	case reflect.Int:
@@ -180,6 +782,12 @@ func updateFieldValue(field reflect.Value, envVal string) {
 		} else {
 			log.Printf("Warning: invalid integer for env value: %s", envVal)
 		}
+	case reflect.Float64:
+		if floatVal, err := strconv.ParseFloat(envVal, 64); err == nil {
+			field.SetFloat(floatVal)
+		} else {
+			log.Printf("Warning: invalid float for env value: %s", envVal)
+		}
 	case reflect.Bool:
 		if boolVal, err := strconv.ParseBool(envVal); err == nil {
 			field.SetBool(boolVal)
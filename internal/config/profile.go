@@ -0,0 +1,83 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/BurntSushi/toml"
+)
+
+// ProfileEnvVar names the profile whose TOML file is layered over the defaults, e.g. ENV=production loads
+// env/production.toml. Without it the env value in env/default.toml picks the profile.
+const ProfileEnvVar = "ENV"
+
+// ProfileConfigPath returns the TOML file holding a profile's settings, next to the default file
+func ProfileConfigPath(profile string) string {
+	return filepath.Join(filepath.Dir(DefaultConfigPath), profile+".toml")
+}
+
+// loadProfileConfig decodes env/default.toml with env/<profile>.toml deep-merged over it. Tables merge key by
+// key at every depth, including map sections such as [providers.smtp.<name>] and [rate_limit.routes."<route>"],
+// so a profile only lists what it changes; arrays and other values replace the default outright. A profile
+// without a file just uses the defaults.
+func loadProfileConfig() (*Config, error) {
+	settings, err := decodeTable(DefaultConfigPath)
+	if err != nil {
+		return nil, err
+	}
+
+	profile, ok := os.LookupEnv(ProfileEnvVar)
+	if !ok {
+		profile, _ = settings["env"].(string)
+	}
+	if profile != "" {
+		path := ProfileConfigPath(profile)
+		overrides, err := decodeTable(path)
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+		case err != nil:
+			return nil, err
+		default:
+			mergeTables(settings, overrides)
+			log.Printf("Config profile %s layered over %s", path, DefaultConfigPath)
+		}
+	}
+
+	// Round-trip the merged tables through TOML so they decode with the same rules as a single file
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(settings); err != nil {
+		return nil, fmt.Errorf("failed to merge config profile %s: %w", profile, err)
+	}
+	var cfg Config
+	if _, err := toml.Decode(buf.String(), &cfg); err != nil {
+		return nil, fmt.Errorf("failed to merge config profile %s: %w", profile, err)
+	}
+	return &cfg, nil
+}
+
+// decodeTable reads a TOML file into nested tables
+func decodeTable(path string) (map[string]any, error) {
+	settings := make(map[string]any)
+	if _, err := toml.DecodeFile(path, &settings); err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", path, err)
+	}
+	return settings, nil
+}
+
+// mergeTables copies src into dst, descending into tables both hold
+func mergeTables(dst, src map[string]any) {
+	for key, value := range src {
+		srcTable, srcIsTable := value.(map[string]any)
+		dstTable, dstIsTable := dst[key].(map[string]any)
+		if srcIsTable && dstIsTable {
+			mergeTables(dstTable, srcTable)
+			continue
+		}
+		dst[key] = value
+	}
+}
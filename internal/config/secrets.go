@@ -0,0 +1,206 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+)
+
+// secretResolveTimeout bounds the total time spent fetching secrets while loading config
+const secretResolveTimeout = 30 * time.Second
+
+// SecretResolver fetches the value behind a secret reference. ref is the part after "scheme://".
+type SecretResolver interface {
+	Resolve(ctx context.Context, ref string) (string, error)
+}
+
+// secretResolvers maps reference schemes to their resolvers:
+//
+//	file:///run/secrets/db_password          contents of the file
+//	vault://secret/data/newsletter#password  key from a Vault KV secret (VAULT_ADDR, VAULT_TOKEN)
+//	awssm://newsletter/prod#password         AWS Secrets Manager secret, optionally a JSON key
+var secretResolvers = map[string]SecretResolver{
+	"file":  fileSecretResolver{},
+	"vault": &vaultSecretResolver{},
+	"awssm": &awsSecretsManagerResolver{},
+}
+
+// ResolveSecrets replaces every string setting that holds a secret reference with the secret value
+func ResolveSecrets(ctx context.Context, cfg *Config) error {
+	ctx, cancel := context.WithTimeout(ctx, secretResolveTimeout)
+	defer cancel()
+	return resolveSecretsIn(ctx, reflect.ValueOf(cfg).Elem(), "")
+}
+
+func resolveSecretsIn(ctx context.Context, val reflect.Value, path string) error {
+	switch val.Kind() {
+	case reflect.Struct:
+		t := val.Type()
+		for i := 0; i < val.NumField(); i++ {
+			if !t.Field(i).IsExported() {
+				continue
+			}
+			if err := resolveSecretsIn(ctx, val.Field(i), joinSecretPath(path, t.Field(i).Tag.Get("toml"))); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		// Map values are not addressable, so resolve a copy and write it back
+		for _, key := range val.MapKeys() {
+			elem := reflect.New(val.Type().Elem()).Elem()
+			elem.Set(val.MapIndex(key))
+			if err := resolveSecretsIn(ctx, elem, joinSecretPath(path, fmt.Sprint(key.Interface()))); err != nil {
+				return err
+			}
+			val.SetMapIndex(key, elem)
+		}
+	case reflect.String:
+		scheme, ref, found := strings.Cut(val.String(), "://")
+		if !found {
+			return nil
+		}
+		resolver, supported := secretResolvers[scheme]
+		if !supported {
+			return nil
+		}
+		secret, err := resolver.Resolve(ctx, ref)
+		if err != nil {
+			return fmt.Errorf("failed to resolve secret for %s: %w", path, err)
+		}
+		if val.CanSet() {
+			val.SetString(secret)
+		}
+	}
+	return nil
+}
+
+func joinSecretPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// splitSecretKey splits "location#key" into its parts; key is empty when absent
+func splitSecretKey(ref string) (string, string) {
+	location, key, _ := strings.Cut(ref, "#")
+	return location, key
+}
+
+// fileSecretResolver reads secrets from mounted files (Docker/Kubernetes secrets)
+type fileSecretResolver struct{}
+
+func (fileSecretResolver) Resolve(ctx context.Context, ref string) (string, error) {
+	data, err := os.ReadFile(ref)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// vaultSecretResolver reads secrets from HashiCorp Vault's HTTP API (KV v1 and v2)
+type vaultSecretResolver struct{}
+
+func (vaultSecretResolver) Resolve(ctx context.Context, ref string) (string, error) {
+	addr := os.Getenv("VAULT_ADDR")
+	token := os.Getenv("VAULT_TOKEN")
+	if addr == "" || token == "" {
+		return "", fmt.Errorf("VAULT_ADDR and VAULT_TOKEN must be set to resolve vault:// references")
+	}
+
+	secretPath, key := splitSecretKey(ref)
+	if key == "" {
+		return "", fmt.Errorf("vault reference %q must name a key, e.g. vault://secret/data/app#password", ref)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimRight(addr, "/")+"/v1/"+strings.TrimLeft(secretPath, "/"), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Vault-Token", token)
+	if namespace := os.Getenv("VAULT_NAMESPACE"); namespace != "" {
+		req.Header.Set("X-Vault-Namespace", namespace)
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("vault returned status %d for %s", resp.StatusCode, secretPath)
+	}
+
+	var body struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("failed to decode vault response: %w", err)
+	}
+
+	// KV v2 nests the secret under data.data
+	data := body.Data
+	if nested, ok := data["data"].(map[string]interface{}); ok {
+		data = nested
+	}
+
+	value, ok := data[key]
+	if !ok {
+		return "", fmt.Errorf("key %q not found in vault secret %s", key, secretPath)
+	}
+	return fmt.Sprint(value), nil
+}
+
+// awsSecretsManagerResolver reads secrets from AWS Secrets Manager using the default credential chain
+type awsSecretsManagerResolver struct {
+	once   sync.Once
+	client *secretsmanager.Client
+	err    error
+}
+
+func (r *awsSecretsManagerResolver) Resolve(ctx context.Context, ref string) (string, error) {
+	r.once.Do(func() {
+		awsCfg, err := awsconfig.LoadDefaultConfig(ctx)
+		if err != nil {
+			r.err = fmt.Errorf("failed to load AWS config: %w", err)
+			return
+		}
+		r.client = secretsmanager.NewFromConfig(awsCfg)
+	})
+	if r.err != nil {
+		return "", r.err
+	}
+
+	secretID, key := splitSecretKey(ref)
+	out, err := r.client.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{SecretId: &secretID})
+	if err != nil {
+		return "", err
+	}
+	if out.SecretString == nil {
+		return "", fmt.Errorf("secret %s has no string value", secretID)
+	}
+	if key == "" {
+		return *out.SecretString, nil
+	}
+
+	var values map[string]interface{}
+	if err := json.Unmarshal([]byte(*out.SecretString), &values); err != nil {
+		return "", fmt.Errorf("secret %s is not a JSON object: %w", secretID, err)
+	}
+	value, ok := values[key]
+	if !ok {
+		return "", fmt.Errorf("key %q not found in secret %s", key, secretID)
+	}
+	return fmt.Sprint(value), nil
+}
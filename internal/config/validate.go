@@ -0,0 +1,434 @@
+package config
+
+import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// Email, SMS and push load balancing strategies
+const (
+	LoadBalancingRoundRobin     = "round_robin"
+	LoadBalancingWeighted       = "weighted"
+	LoadBalancingLeastLoad      = "least_load"
+	LoadBalancingWeightedRandom = "weighted_random"
+)
+
+// localProviderName is the development provider, enabled without a section of its own
+const localProviderName = "local"
+
+// minPollSecretLength keeps poll answer links from being signed with a guessable secret
+const minPollSecretLength = 32
+
+// minJWTSecretLength keeps access tokens from being signed, and so forged, with a short secret
+const minJWTSecretLength = 32
+
+// ValidationError lists every problem found in a configuration, each prefixed with the setting at fault
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid configuration (%d problems):\n  - %s", len(e.Problems), strings.Join(e.Problems, "\n  - "))
+}
+
+// validator collects problems so a single run reports all of them
+type validator struct {
+	problems []string
+}
+
+func (v *validator) addf(setting, format string, args ...any) {
+	v.problems = append(v.problems, setting+": "+fmt.Sprintf(format, args...))
+}
+
+// Validate checks the settings that would otherwise only fail at the first send or request: credentials of
+// enabled providers, hourly limits, load balancing strategies and rate limit rules. It returns a
+// *ValidationError listing every problem, or nil.
+func (c *Config) Validate() error {
+	v := &validator{}
+	c.validateProviders(v)
+	c.validateSMS(v)
+	c.validatePush(v)
+	c.validateRateLimit(v)
+
+	switch c.Worker.Redis {
+	case "", WorkerRedisRequired, WorkerRedisOptional, WorkerRedisDisabled:
+	default:
+		v.addf("worker.redis", "%q is not required, optional or disabled", c.Worker.Redis)
+	}
+	if r := c.Worker.HighPriorityReserve; r < 0 || r >= 1 {
+		v.addf("worker.high_priority_reserve", "%v is not a share between 0 and 1", r)
+	}
+
+	switch c.Templates.Source {
+	case "", TemplateSourceBuiltin, TemplateSourceFile, TemplateSourceDatabase:
+	case TemplateSourceS3:
+		if c.Templates.S3.Bucket == "" || c.Templates.S3.Region == "" {
+			v.addf("templates.s3", "bucket and region are required when source is s3")
+		}
+	default:
+		v.addf("templates.source", "%q is not builtin, file, database or s3", c.Templates.Source)
+	}
+	if c.Templates.RefreshInterval < 0 {
+		v.addf("templates.refresh_interval", "must not be negative")
+	}
+	if c.Lint.ClientPreviewURL != "" && !isHTTPURL(c.Lint.ClientPreviewURL) {
+		v.addf("lint.client_preview_url", "%q is not an http(s) URL", c.Lint.ClientPreviewURL)
+	}
+	c.validateReferrals(v)
+	if c.Landing.RecentIssues < 0 {
+		v.addf("landing_pages.recent_issues", "must not be negative")
+	}
+	c.validateBilling(v)
+	c.validatePolls(v)
+	if len(c.Auth.JWTSecret) < minJWTSecretLength {
+		v.addf("auth.jwt_secret", "must be at least %d characters", minJWTSecretLength)
+	}
+	c.validateSendReports(v)
+
+	switch c.EmailLogs.BodyStorage {
+	case "", EmailLogBodyInline, EmailLogBodyShared:
+	default:
+		v.addf("email_logs.body_storage", "%q is not inline or shared", c.EmailLogs.BodyStorage)
+	}
+	if c.EmailLogs.ArchiveAfter < 0 {
+		v.addf("email_logs.archive_after", "must not be negative")
+	}
+	if c.EmailLogs.ArchiveBatchSize < 0 {
+		v.addf("email_logs.archive_batch_size", "must not be negative")
+	}
+
+	if len(v.problems) == 0 {
+		return nil
+	}
+	return &ValidationError{Problems: v.problems}
+}
+
+func (c *Config) validateProviders(v *validator) {
+	p := &c.Providers
+	validateLoadBalancing(v, "providers", p.LoadBalancing, p.Weights, p.Enabled)
+
+	for name := range p.SMTP {
+		if _, ok := p.API[name]; ok {
+			v.addf("providers", "%s is defined under both [providers.smtp] and [providers.api]", name)
+		}
+	}
+
+	checked := make(map[string]bool)
+	check := func(setting, name string) {
+		if checked[name] {
+			return
+		}
+		checked[name] = true
+		if name == localProviderName {
+			return
+		}
+		if smtp, ok := p.SMTP[name]; ok {
+			validateSMTPProvider(v, "providers.smtp."+name, smtp)
+			return
+		}
+		if api, ok := p.API[name]; ok {
+			validateAPIProvider(v, "providers.api."+name, api)
+			return
+		}
+		v.addf(setting, "provider %s has no [providers.smtp.%s] or [providers.api.%s] section", name, name, name)
+	}
+	for _, name := range p.Enabled {
+		check("providers.enabled", name)
+	}
+	for _, org := range sortedKeys(p.Organizations) {
+		for _, name := range p.Organizations[org] {
+			check("providers.organizations."+org, name)
+		}
+	}
+}
+
+func validateSMTPProvider(v *validator, setting string, cfg SMTPProviderConfig) {
+	if cfg.Host == "" {
+		v.addf(setting, "host is required")
+	}
+	if cfg.Port <= 0 {
+		v.addf(setting, "port must be positive")
+	}
+	if cfg.Username != "" && cfg.Password == "" {
+		v.addf(setting, "password is required when username is set")
+	}
+	if cfg.From == "" && cfg.Username == "" {
+		v.addf(setting, "from is required when there is no username to send as")
+	}
+	// An unset limit would otherwise fall back silently to the provider's built-in 1000 an hour
+	if cfg.MaxEmailsPerHour <= 0 {
+		v.addf(setting, "max_emails_per_hour must be positive")
+	}
+	switch cfg.TLSMode {
+	case "", "starttls", "implicit", "none":
+	default:
+		v.addf(setting, "tls_mode %q is not starttls, implicit or none", cfg.TLSMode)
+	}
+}
+
+func validateAPIProvider(v *validator, setting string, cfg APIProviderConfig) {
+	if !isHTTPURL(cfg.Endpoint) {
+		v.addf(setting, "endpoint %q is not an http(s) URL", cfg.Endpoint)
+	}
+	if cfg.Token == "" && cfg.AuthScheme != "none" {
+		v.addf(setting, "token is required unless auth_scheme is none")
+	}
+	if cfg.From == "" {
+		v.addf(setting, "from is required")
+	}
+	// Load balancers see a provider without an hourly limit as having no capacity
+	if cfg.MaxEmailsPerHour <= 0 {
+		v.addf(setting, "max_emails_per_hour must be positive")
+	}
+	if cfg.BulkEnabled && cfg.MaxBatchSize <= 0 {
+		v.addf(setting, "max_batch_size must be positive when bulk_enabled is set")
+	}
+}
+
+func (c *Config) validateSMS(v *validator) {
+	s := &c.SMS
+	if len(s.Enabled) == 0 {
+		return
+	}
+	validateLoadBalancing(v, "sms", s.LoadBalancing, s.Weights, s.Enabled)
+	for _, name := range s.Enabled {
+		if twilio, ok := s.Twilio[name]; ok {
+			setting := "sms.twilio." + name
+			if twilio.AccountSID == "" || twilio.AuthToken == "" {
+				v.addf(setting, "account_sid and auth_token are required")
+			}
+			if twilio.From == "" && twilio.MessagingServiceSID == "" {
+				v.addf(setting, "from or messaging_service_sid is required")
+			}
+			continue
+		}
+		if sns, ok := s.SNS[name]; ok {
+			if sns.Region == "" {
+				v.addf("sms.sns."+name, "region is required")
+			}
+			continue
+		}
+		v.addf("sms.enabled", "provider %s has no [sms.twilio.%s] or [sms.sns.%s] section", name, name, name)
+	}
+}
+
+func (c *Config) validatePush(v *validator) {
+	p := &c.Push
+	if len(p.Enabled) == 0 {
+		return
+	}
+	validateLoadBalancing(v, "push", p.LoadBalancing, p.Weights, p.Enabled)
+	for _, name := range p.Enabled {
+		if fcm, ok := p.FCM[name]; ok {
+			if fcm.CredentialsFile == "" {
+				v.addf("push.fcm."+name, "credentials_file is required")
+			}
+			continue
+		}
+		if webPush, ok := p.WebPush[name]; ok {
+			if webPush.VAPIDPublicKey == "" || webPush.VAPIDPrivateKey == "" {
+				v.addf("push.webpush."+name, "vapid_public_key and vapid_private_key are required")
+			}
+			if webPush.Subject == "" {
+				v.addf("push.webpush."+name, "subject is required")
+			}
+			continue
+		}
+		v.addf("push.enabled", "provider %s has no [push.fcm.%s] or [push.webpush.%s] section", name, name, name)
+	}
+}
+
+// validateLoadBalancing checks a channel's strategy and, for weighted_random, that an enabled provider has traffic
+func validateLoadBalancing(v *validator, section, strategy string, weights map[string]int, enabled []string) {
+	switch strategy {
+	case "", LoadBalancingRoundRobin, LoadBalancingWeighted, LoadBalancingLeastLoad:
+	case LoadBalancingWeightedRandom:
+		total := 0
+		for _, name := range enabled {
+			total += max(weights[name], 0)
+		}
+		if total == 0 {
+			v.addf(section+".weights", "weighted_random needs a positive weight for an enabled provider")
+		}
+	default:
+		v.addf(section+".load_balancing", "%q is not round_robin, weighted, least_load or weighted_random", strategy)
+	}
+	for _, name := range sortedKeys(weights) {
+		if weights[name] < 0 {
+			v.addf(section+".weights", "weight for %s must not be negative", name)
+		}
+	}
+}
+
+func (c *Config) validateRateLimit(v *validator) {
+	r := &c.RateLimit
+	if !r.Enabled {
+		return
+	}
+	switch r.Storage {
+	case "", "redis", "memory":
+	default:
+		v.addf("rate_limit.storage", "%q is not redis or memory", r.Storage)
+	}
+
+	if r.DefaultRule.Enabled {
+		validateRateLimitRule(v, "rate_limit.default", r.DefaultRule)
+	}
+	for _, route := range sortedKeys(r.Routes) {
+		rule := r.Routes[route]
+		setting := fmt.Sprintf("rate_limit.routes.%q", route)
+		// Rules are looked up by the request path, so keys must name a concrete path
+		method, path, ok := strings.Cut(route, ":")
+		if !ok || method == "" || method != strings.ToUpper(method) || !strings.HasPrefix(path, "/") {
+			v.addf(setting, "key must be METHOD:/path, e.g. POST:/auth/login")
+		} else if strings.Contains(path, "/:") || strings.Contains(path, "*") {
+			v.addf(setting, "route parameters never match; rules apply to request paths such as /api/v1/topics/42")
+		}
+		if rule.Enabled {
+			validateRateLimitRule(v, setting, rule)
+		}
+	}
+}
+
+// validateRateLimitRule checks a rule has the settings its strategy needs and none belonging to another
+// strategy, which would suggest the rule isn't limiting the way its author meant
+func validateRateLimitRule(v *validator, setting string, rule RateLimitRule) {
+	tokenBucket := rule.BucketSize != 0 || rule.RefillSize != 0 || rule.RefillDuration != 0
+	slidingWindow := rule.Limit != 0 || rule.Window != 0
+	concurrency := rule.MaxConcurrent != 0
+
+	switch rule.Strategy {
+	case "", RateLimitStrategyTokenBucket:
+		if rule.BucketSize <= 0 || rule.RefillSize <= 0 || rule.RefillDuration <= 0 {
+			v.addf(setting, "token_bucket needs positive bucket_size, refill_size and refill_duration")
+		}
+		if slidingWindow || concurrency {
+			v.addf(setting, "limit, window and max_concurrent conflict with the token_bucket strategy")
+		}
+	case RateLimitStrategySlidingWindow:
+		if rule.Limit <= 0 || rule.Window < 0 {
+			v.addf(setting, "sliding_window needs a positive limit (and window, default 1m)")
+		}
+		if tokenBucket || concurrency {
+			v.addf(setting, "bucket_size, refill_size, refill_duration and max_concurrent conflict with the sliding_window strategy")
+		}
+	case RateLimitStrategyConcurrency:
+		if rule.MaxConcurrent <= 0 {
+			v.addf(setting, "concurrency needs a positive max_concurrent")
+		}
+		if tokenBucket || slidingWindow {
+			v.addf(setting, "bucket_size, refill_size, refill_duration, limit and window conflict with the concurrency strategy")
+		}
+	default:
+		v.addf(setting, "strategy %q is not token_bucket, sliding_window or concurrency", rule.Strategy)
+	}
+
+	switch rule.IdentifyBy {
+	case "", "ip", "api_key":
+	default:
+		v.addf(setting, "identify_by %q is not ip or api_key", rule.IdentifyBy)
+	}
+}
+
+// validateReferrals checks the URLs referral and confirmation links are built from, which emails would
+// otherwise carry broken
+func (c *Config) validateReferrals(v *validator) {
+	r := &c.Referrals
+	if r.ConfirmWithin < 0 {
+		v.addf("referrals.confirm_within", "must not be negative")
+	}
+	if r.LeaderboardSize < 0 {
+		v.addf("referrals.leaderboard_size", "must not be negative")
+	}
+	if !r.Enabled {
+		return
+	}
+	if !isHTTPURL(r.SignupURL) {
+		v.addf("referrals.signup_url", "%q is not an http(s) URL", r.SignupURL)
+	}
+	if !isHTTPURL(r.PublicURL) {
+		v.addf("referrals.public_url", "%q is not an http(s) URL", r.PublicURL)
+	}
+}
+
+// validateBilling checks the Stripe credentials and the URLs Checkout and dunning emails send subscribers to
+func (c *Config) validateBilling(v *validator) {
+	b := &c.Billing
+	if b.WebhookTolerance < 0 {
+		v.addf("billing.webhook_tolerance", "must not be negative")
+	}
+	for i, delay := range b.DunningSchedule {
+		if delay < 0 {
+			v.addf(fmt.Sprintf("billing.dunning_schedule[%d]", i), "must not be negative")
+		}
+		if i > 0 && delay <= b.DunningSchedule[i-1] {
+			v.addf(fmt.Sprintf("billing.dunning_schedule[%d]", i), "must be later than the delay before it")
+		}
+	}
+	if !b.Enabled {
+		return
+	}
+	if b.SecretKey == "" {
+		v.addf("billing.secret_key", "is required when billing is enabled")
+	}
+	if b.WebhookSecret == "" {
+		v.addf("billing.webhook_secret", "is required when billing is enabled")
+	}
+	if !isHTTPURL(b.SuccessURL) {
+		v.addf("billing.success_url", "%q is not an http(s) URL", b.SuccessURL)
+	}
+	if !isHTTPURL(b.CancelURL) {
+		v.addf("billing.cancel_url", "%q is not an http(s) URL", b.CancelURL)
+	}
+	if b.PortalURL != "" && !isHTTPURL(b.PortalURL) {
+		v.addf("billing.portal_url", "%q is not an http(s) URL", b.PortalURL)
+	}
+}
+
+// validatePolls checks what answer links are built and signed with
+func (c *Config) validatePolls(v *validator) {
+	p := &c.Polls
+	if !p.Enabled {
+		return
+	}
+	if !isHTTPURL(p.PublicURL) {
+		v.addf("polls.public_url", "%q is not an http(s) URL", p.PublicURL)
+	}
+	if len(p.Secret) < minPollSecretLength {
+		v.addf("polls.secret", "must be at least %d characters", minPollSecretLength)
+	}
+}
+
+func (c *Config) validateSendReports(v *validator) {
+	r := &c.SendReports
+	if !r.Enabled {
+		return
+	}
+	if len(r.Recipients) == 0 && !r.Webhook {
+		v.addf("send_reports", "enabled without recipients or webhook, reports would go nowhere")
+	}
+	for i, recipient := range r.Recipients {
+		if _, err := mail.ParseAddress(recipient); err != nil {
+			v.addf(fmt.Sprintf("send_reports.recipients[%d]", i), "%q is not an email address", recipient)
+		}
+	}
+}
+
+// isHTTPURL reports whether raw is an absolute http or https URL
+func isHTTPURL(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// sortedKeys returns a map's keys in order, so problems are reported the same way on every run
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,141 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// reloadDebounce coalesces the burst of events editors emit for a single save
+const reloadDebounce = 250 * time.Millisecond
+
+// Provider exposes the live configuration. Read Current() on each use instead of
+// caching the result so runtime reloads are observed.
+type Provider interface {
+	Current() *Config
+}
+
+// Store holds the active configuration and atomically swaps it on reload.
+// Only settings read through Current() or OnReload listeners (rate limits,
+// providers, worker concurrency) take effect without a restart.
+type Store struct {
+	current   atomic.Pointer[Config]
+	path      string
+	mu        sync.Mutex
+	listeners []func(*Config)
+}
+
+// NewStore creates a store seeded with cfg that reloads from the default and profile TOML files
+func NewStore(cfg *Config) *Store {
+	s := &Store{path: DefaultConfigPath}
+	s.current.Store(cfg)
+	return s
+}
+
+// Current returns the active configuration
+func (s *Store) Current() *Config {
+	return s.current.Load()
+}
+
+// OnReload registers fn to be called with the new configuration after each successful reload
+func (s *Store) OnReload(fn func(*Config)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.listeners = append(s.listeners, fn)
+}
+
+// Reload re-reads the TOML files and environment overrides and swaps the active configuration.
+// The previous configuration is kept if loading or validation fails.
+func (s *Store) Reload() error {
+	cfg, err := LoadConfig()
+	if err == nil {
+		err = cfg.Validate()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to reload config: %w", err)
+	}
+
+	s.current.Store(cfg)
+
+	s.mu.Lock()
+	listeners := append([]func(*Config){}, s.listeners...)
+	s.mu.Unlock()
+
+	for _, fn := range listeners {
+		fn(cfg)
+	}
+	return nil
+}
+
+// Watch reloads the configuration whenever a TOML file in the config directory changes,
+// which covers the defaults and every profile, or the process receives SIGHUP, until ctx is cancelled
+func (s *Store) Watch(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create config watcher: %w", err)
+	}
+
+	// Watch the directory rather than the file so atomic renames (editors, k8s ConfigMaps) are seen
+	if err := watcher.Add(filepath.Dir(s.path)); err != nil {
+		watcher.Close()
+		return fmt.Errorf("failed to watch config directory: %w", err)
+	}
+
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+
+	go func() {
+		defer watcher.Close()
+		defer signal.Stop(hup)
+
+		var debounce <-chan time.Time
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if s.watches(event.Name) && event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) != 0 {
+					debounce = time.After(reloadDebounce)
+				}
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Printf("Warning: config watcher error: %v", err)
+			case <-hup:
+				s.reloadAndLog("SIGHUP")
+			case <-debounce:
+				debounce = nil
+				s.reloadAndLog("file change")
+			}
+		}
+	}()
+
+	log.Printf("Watching %s for configuration changes (SIGHUP also triggers reload)", filepath.Join(filepath.Dir(s.path), "*.toml"))
+	return nil
+}
+
+// watches reports whether a change to name should reload the configuration
+func (s *Store) watches(name string) bool {
+	return filepath.Dir(filepath.Clean(name)) == filepath.Dir(filepath.Clean(s.path)) && filepath.Ext(name) == ".toml"
+}
+
+func (s *Store) reloadAndLog(trigger string) {
+	if err := s.Reload(); err != nil {
+		log.Printf("Warning: %v (trigger: %s), keeping previous configuration", err, trigger)
+		return
+	}
+	log.Printf("Configuration reloaded (trigger: %s)", trigger)
+}
@@ -10,10 +10,27 @@ import (
 	"gorm.io/gorm/logger"
 
 	"newsletter-service/internal/config"
+	"newsletter-service/internal/metrics"
+	"newsletter-service/internal/services/apikey"
+	"newsletter-service/internal/services/approval"
+	"newsletter-service/internal/services/asset"
+	"newsletter-service/internal/services/audit"
+	"newsletter-service/internal/services/billing"
 	"newsletter-service/internal/services/content"
+	"newsletter-service/internal/services/emailtemplate"
 	"newsletter-service/internal/services/notification"
+	"newsletter-service/internal/services/organization"
+	"newsletter-service/internal/services/poll"
+	"newsletter-service/internal/services/preference"
+	"newsletter-service/internal/services/push"
+	"newsletter-service/internal/services/referral"
+	"newsletter-service/internal/services/snippet"
+	"newsletter-service/internal/services/sponsor"
 	"newsletter-service/internal/services/subscriber"
 	"newsletter-service/internal/services/topic"
+	"newsletter-service/internal/services/webhook"
+	"newsletter-service/internal/tenant"
+	"newsletter-service/internal/tracing"
 )
 
 func NewPostgresDB(cfg config.DatabaseConfig) (*gorm.DB, error) {
@@ -21,17 +38,41 @@ func NewPostgresDB(cfg config.DatabaseConfig) (*gorm.DB, error) {
 		"host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
 		cfg.Host, cfg.Port, cfg.User, cfg.Password, cfg.Name, cfg.SSLMode,
 	)
+	// Timeouts are sent as session settings when each connection starts, in milliseconds
+	if cfg.StatementTimeout > 0 {
+		dsn += fmt.Sprintf(" statement_timeout=%d", cfg.StatementTimeout.Milliseconds())
+	}
+	if cfg.LockTimeout > 0 {
+		dsn += fmt.Sprintf(" lock_timeout=%d", cfg.LockTimeout.Milliseconds())
+	}
 
 	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
 		Logger: logger.Default.LogMode(logger.Info),
 		NowFunc: func() time.Time {
 			return time.Now().UTC()
 		},
+		// Report unique violations as gorm.ErrDuplicatedKey so services can turn them into conflicts
+		TranslateError: true,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
+	// Trace queries through the global tracer provider (no-op when tracing is disabled)
+	if err := db.Use(tracing.NewGormPlugin()); err != nil {
+		return nil, fmt.Errorf("failed to register tracing plugin: %w", err)
+	}
+
+	// Export query durations and log slow queries
+	if err := db.Use(metrics.NewGormPlugin(cfg.SlowQueryThreshold)); err != nil {
+		return nil, fmt.Errorf("failed to register metrics plugin: %w", err)
+	}
+
+	// Scope queries to the organization of the request's context
+	if err := db.Use(tenant.NewGormPlugin()); err != nil {
+		return nil, fmt.Errorf("failed to register tenant plugin: %w", err)
+	}
+
 	// Configure connection pool
 	sqlDB, err := db.DB()
 	if err != nil {
@@ -39,9 +80,26 @@ func NewPostgresDB(cfg config.DatabaseConfig) (*gorm.DB, error) {
 	}
 
 	// Set connection pool settings
-	sqlDB.SetMaxIdleConns(10)
-	sqlDB.SetMaxOpenConns(100)
-	sqlDB.SetConnMaxLifetime(time.Hour)
+	maxOpen := cfg.MaxOpenConns
+	if maxOpen <= 0 {
+		maxOpen = 100
+	}
+	maxIdle := cfg.MaxIdleConns
+	if maxIdle <= 0 {
+		maxIdle = 10
+	}
+	maxIdle = min(maxIdle, maxOpen)
+	maxLifetime := cfg.ConnMaxLifetime
+	if maxLifetime <= 0 {
+		maxLifetime = time.Hour
+	}
+	sqlDB.SetMaxOpenConns(maxOpen)
+	sqlDB.SetMaxIdleConns(maxIdle)
+	sqlDB.SetConnMaxLifetime(maxLifetime)
+	sqlDB.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+	log.Printf("Database pool: max_open_conns=%d max_idle_conns=%d conn_max_lifetime=%s conn_max_idle_time=%s statement_timeout=%s lock_timeout=%s",
+		maxOpen, maxIdle, maxLifetime, durationOrDefault(cfg.ConnMaxIdleTime, "none"),
+		durationOrDefault(cfg.StatementTimeout, "server default"), durationOrDefault(cfg.LockTimeout, "server default"))
 
 	// Test the connection
 	if err := sqlDB.Ping(); err != nil {
@@ -69,16 +127,53 @@ func autoMigrate(db *gorm.DB) error {
 	log.Println("Running auto-migrations...")
 
 	err := db.AutoMigrate(
+		&organization.Organization{},
 		&topic.Topic{},
 		&subscriber.Subscriber{},
 		&subscriber.Subscription{},
 		&content.Content{},
+		&content.Translation{},
 		&notification.EmailLog{},
+		&notification.WarmupCounter{},
+		&notification.ProviderHourlyStat{},
+		&notification.ProviderPause{},
+		&notification.EmailLogBody{},
+		&audit.AuditLog{},
+		&apikey.APIKey{},
+		&webhook.Webhook{},
+		&webhook.Delivery{},
+		&push.Device{},
+		&preference.Preference{},
+		&asset.Asset{},
+		&snippet.Snippet{},
+		&emailtemplate.EmailTemplate{},
+		&approval.Event{},
+		&referral.Referral{},
+		&billing.Plan{},
+		&billing.Subscription{},
+		&sponsor.Sponsor{},
+		&sponsor.Slot{},
+		&poll.Poll{},
+		&poll.Option{},
+		&poll.Response{},
 	)
 	if err != nil {
 		return fmt.Errorf("auto-migration failed: %w", err)
 	}
 
+	// The archive mirrors email_logs, so it is created from the migrated table rather than a model
+	if err := db.Exec("CREATE TABLE IF NOT EXISTS email_logs_archive (LIKE email_logs INCLUDING ALL)").Error; err != nil {
+		return fmt.Errorf("auto-migration failed: %w", err)
+	}
+
 	log.Println("Auto-migrations completed successfully")
 	return nil
 }
+
+// durationOrDefault describes a setting that is left to its default when zero
+func durationOrDefault(d time.Duration, unset string) string {
+	if d <= 0 {
+		return unset
+	}
+	return d.String()
+}
@@ -2,8 +2,12 @@ package connections
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"log"
+	"os"
+	"strings"
 
 	"github.com/go-redis/redis/v8"
 
@@ -12,20 +16,118 @@ import (
 
 var ctx = context.Background()
 
-func NewRedisClient(cfg config.RedisConfig) (*redis.Client, error) {
-	client := redis.NewClient(&redis.Options{
-		Addr:     fmt.Sprintf("%s:%d", cfg.Host, cfg.Port),
-		Password: cfg.Password,
-		DB:       cfg.DB,
-	})
+// NewRedisClient connects to a single Redis server, a Sentinel-monitored master or a cluster, by cfg.Mode. The
+// client behaves the same in every mode; Lua scripts used by the service touch one key or keys sharing a hash
+// tag, so they also run on a cluster.
+func NewRedisClient(cfg config.RedisConfig) (redis.UniversalClient, error) {
+	client, target, err := openRedisClient(cfg)
+	if err != nil {
+		return nil, err
+	}
 
 	// Test connection
 	pong, err := client.Ping(ctx).Result()
 	if err != nil {
-		log.Printf("Failed to connect to Redis: %v\n", err)
+		log.Printf("Failed to connect to Redis (%s): %v\n", target, err)
+		client.Close()
 		return nil, err
 	}
 
-	log.Printf("Connected to Redis: %s\n", pong)
+	log.Printf("Connected to Redis (%s, tls=%t): %s\n", target, cfg.TLS, pong)
 	return client, nil
 }
+
+// OpenRedisClient creates the client like NewRedisClient but doesn't wait for Redis to answer. Connections are
+// made on first use and remade after failures, so the caller can start while Redis is down and pick it up later.
+func OpenRedisClient(cfg config.RedisConfig) (redis.UniversalClient, error) {
+	client, _, err := openRedisClient(cfg)
+	return client, err
+}
+
+// openRedisClient builds the client for cfg.Mode and describes its target for logs
+func openRedisClient(cfg config.RedisConfig) (redis.UniversalClient, string, error) {
+	opts := &redis.UniversalOptions{
+		Username:     cfg.Username,
+		Password:     cfg.Password,
+		DB:           cfg.DB,
+		PoolSize:     cfg.PoolSize,
+		MinIdleConns: cfg.MinIdleConns,
+		PoolTimeout:  cfg.PoolTimeout,
+		DialTimeout:  cfg.DialTimeout,
+		ReadTimeout:  cfg.ReadTimeout,
+		WriteTimeout: cfg.WriteTimeout,
+	}
+	if cfg.TLS {
+		tlsConfig, err := redisTLSConfig(cfg)
+		if err != nil {
+			return nil, "", err
+		}
+		opts.TLSConfig = tlsConfig
+	}
+
+	var client redis.UniversalClient
+	var target string
+	switch cfg.Mode {
+	case "", config.RedisModeSingle:
+		opts.Addrs = []string{fmt.Sprintf("%s:%d", cfg.Host, cfg.Port)}
+		client = redis.NewClient(opts.Simple())
+		target = opts.Addrs[0]
+	case config.RedisModeSentinel:
+		if cfg.MasterName == "" {
+			return nil, "", fmt.Errorf("redis.master_name is required in sentinel mode")
+		}
+		if opts.Addrs = splitAddrs(cfg.Addrs); len(opts.Addrs) == 0 {
+			return nil, "", fmt.Errorf("redis.addrs must list the sentinels in sentinel mode")
+		}
+		opts.MasterName = cfg.MasterName
+		opts.SentinelUsername = cfg.SentinelUsername
+		opts.SentinelPassword = cfg.SentinelPassword
+		client = redis.NewFailoverClient(opts.Failover())
+		target = fmt.Sprintf("master %s via sentinels %s", cfg.MasterName, strings.Join(opts.Addrs, ","))
+	case config.RedisModeCluster:
+		if opts.Addrs = splitAddrs(cfg.Addrs); len(opts.Addrs) == 0 {
+			return nil, "", fmt.Errorf("redis.addrs must list seed nodes in cluster mode")
+		}
+		if cfg.DB != 0 {
+			log.Printf("Warning: redis.db = %d is ignored in cluster mode", cfg.DB)
+		}
+		client = redis.NewClusterClient(opts.Cluster())
+		target = "cluster " + strings.Join(opts.Addrs, ",")
+	default:
+		return nil, "", fmt.Errorf("unknown redis.mode %q: expected single, sentinel or cluster", cfg.Mode)
+	}
+
+	return client, target, nil
+}
+
+// redisTLSConfig verifies the server against cfg.TLSCAFile when set, or the system roots otherwise
+func redisTLSConfig(cfg config.RedisConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         cfg.TLSServerName,
+		InsecureSkipVerify: cfg.TLSInsecureSkipVerify,
+	}
+	if cfg.TLSCAFile != "" {
+		pem, err := os.ReadFile(cfg.TLSCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read redis.tls_ca_file: %w", err)
+		}
+		roots := x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("redis.tls_ca_file %s holds no PEM certificates", cfg.TLSCAFile)
+		}
+		tlsConfig.RootCAs = roots
+	}
+	return tlsConfig, nil
+}
+
+// splitAddrs splits a comma-separated host:port list, dropping empty entries
+func splitAddrs(addrs string) []string {
+	var list []string
+	for _, addr := range strings.Split(addrs, ",") {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			list = append(list, addr)
+		}
+	}
+	return list
+}
@@ -12,6 +12,8 @@ const (
 	ProviderSMTP = "smtp"
 	
 	NotificationTypeEmail = "email"
+	NotificationTypeSMS   = "sms"
+	NotificationTypePush  = "push"
 )
 
 // Subscriber status constants
@@ -48,17 +50,76 @@ const (
 
 // Authentication
 const (
-	BasicAuthRealm     = "Newsletter Service API"
-	SchedulerAuthRealm = "Newsletter Scheduler API"
+	BasicAuthRealm         = "Newsletter Service API"
+	SchedulerAuthRealm     = "Newsletter Scheduler API"
+	BearerTokenType        = "Bearer"
+	AuthMethodBasic        = "basic"
+	AuthMethodJWT          = "jwt"
+	AuthMethodServiceToken = "service_token"
+	AuthMethodAPIKey       = "api_key"
+	GRPCServiceActor       = "internal-service" // Audit actor for calls made with the gRPC service token
+)
+
+// Tenancy headers
+const (
+	HeaderAPIKey         = "X-API-Key"
+	HeaderOrganizationID = "X-Organization-ID"
+)
+
+// Idempotency headers
+const (
+	HeaderIdempotencyKey     = "Idempotency-Key"
+	HeaderIdempotentReplayed = "Idempotent-Replayed"
+)
+
+// Conditional request headers
+const (
+	HeaderETag        = "ETag"
+	HeaderIfMatch     = "If-Match"
+	HeaderIfNoneMatch = "If-None-Match"
+)
+
+// API versions. Clients pick one by path, or ask for v2 on a v1 path with the v2 media type in Accept.
+const (
+	APIVersion1      = "v1"
+	APIVersion2      = "v2"
+	HeaderAPIVersion = "API-Version"
+	MediaTypeV2      = "application/vnd.newsletter.v2+json"
+)
+
+// Webhook delivery headers
+const (
+	HeaderWebhookID        = "X-Webhook-ID"
+	HeaderWebhookEvent     = "X-Webhook-Event"
+	HeaderWebhookTimestamp = "X-Webhook-Timestamp"
+	HeaderWebhookSignature = "X-Webhook-Signature"
+	WebhookUserAgent       = "newsletter-service-webhooks/1.0"
+)
+
+// LintUserAgent identifies the requests made to check links while linting content
+const LintUserAgent = "newsletter-service-lint/1.0"
+
+// Gin context keys
+const (
+	ContextKeyAuthMethod     = "auth_method"
+	ContextKeyOrganizationID = "organization_id"
+	ContextKeyAPIVersion     = "api_version"
+	ContextKeyAPIKeyID       = "api_key_id"
 )
 
 // Database table names
 const (
-	TableNameTopics        = "topics"
-	TableNameSubscribers   = "subscribers"
-	TableNameSubscriptions = "subscriptions"
-	TableNameContents      = "contents"
-	TableNameEmailLogs     = "email_logs"
+	TableNameTopics            = "topics"
+	TableNameSubscribers       = "subscribers"
+	TableNameSubscriptions     = "subscriptions"
+	TableNameContents          = "contents"
+	TableNameEmailLogs         = "email_logs"
+	TableNameEmailLogBodies    = "email_log_bodies"
+	TableNameEmailLogsArchive  = "email_logs_archive"
+	TableNameAuditLogs         = "audit_logs"
+	TableNameAPIKeys           = "api_keys"
+	TableNameWebhooks          = "webhooks"
+	TableNameWebhookDeliveries = "webhook_deliveries"
 )
 
 // API response messages
@@ -66,43 +127,168 @@ const (
 	MsgTopicCreatedSuccessfully          = "Topic created successfully"
 	MsgTopicUpdatedSuccessfully          = "Topic updated successfully"
 	MsgTopicDeletedSuccessfully          = "Topic deleted successfully"
+	MsgTopicRestoredSuccessfully         = "Topic restored successfully"
+	MsgTopicArchivedSuccessfully         = "Topic archived successfully"
+	MsgTopicUnarchivedSuccessfully       = "Topic unarchived successfully"
 	MsgSubscriberCreatedSuccessfully     = "Subscriber created successfully"
 	MsgSubscriberUpdatedSuccessfully     = "Subscriber updated successfully"
 	MsgSubscriberDeletedSuccessfully     = "Subscriber deleted successfully"
+	MsgSubscriberRestoredSuccessfully    = "Subscriber restored successfully"
 	MsgSubscriptionCreatedSuccessfully   = "Subscription created successfully"
+	MsgAlreadySubscribed                 = "Subscriber is already subscribed to this topic"
 	MsgSubscriptionDeletedSuccessfully   = "Subscription deleted successfully"
+	MsgSubscriptionUpdatedSuccessfully   = "Subscription updated successfully"
+	MsgPushDeviceDeletedSuccessfully     = "Push device deleted successfully"
 	MsgContentCreatedSuccessfully        = "Content created successfully"
 	MsgContentUpdatedSuccessfully        = "Content updated successfully"
 	MsgContentDeletedSuccessfully        = "Content deleted successfully"
+	MsgContentRestoredSuccessfully       = "Content restored successfully"
+	MsgTranslationDeletedSuccessfully    = "Translation deleted successfully"
 	MsgContentPublishedSuccessfully      = "Content published successfully"
+	MsgCommentAddedSuccessfully          = "Comment added successfully"
 	MsgNotificationsSentSuccessfully     = "Notifications sent successfully"
+	MsgNotificationsQueued               = "Notifications queued; the worker sends them on its next run"
 	MsgFailedNotificationsRetryInitiated = "Failed notifications retry initiated"
+	MsgLoggedOutSuccessfully             = "Logged out successfully"
+	MsgAPIKeyRevokedSuccessfully         = "API key revoked successfully"
+	MsgWebhookDeletedSuccessfully        = "Webhook deleted successfully"
+	MsgSnippetDeletedSuccessfully        = "Snippet deleted successfully"
+	MsgWorkerPaused                      = "Worker paused; scheduled runs are skipped until resumed"
+	MsgWorkerResumed                     = "Worker resumed"
+	MsgWorkerJobTriggered                = "Worker job triggered"
+	MsgWorkerDraining                    = "Worker draining; it exits once running jobs finish"
+	MsgReferralConfirmationSent          = "Check your inbox for a link to confirm your subscription"
+	MsgPlanDeletedSuccessfully           = "Plan deleted successfully"
+	MsgSponsorDeletedSuccessfully        = "Sponsor deleted successfully"
+	MsgSponsorSlotCanceled               = "Sponsor slot canceled"
+	MsgPollDeletedSuccessfully           = "Poll deleted successfully"
+	MsgProviderResumed                   = "Provider resumed; new emails are routed to it again"
 )
 
 // Error messages
 const (
 	ErrInvalidRequestBody      = "Invalid request body"
 	ErrInvalidPaginationParams = "Invalid pagination parameters"
+	ErrInvalidFieldSelection   = "Invalid fields or include parameter"
+	ErrInvalidIDs              = "Invalid ids parameter"
 	ErrInvalidTopicID          = "Invalid topic ID"
 	ErrInvalidSubscriberID     = "Invalid subscriber ID"
 	ErrInvalidSubscriptionID   = "Invalid subscription ID"
 	ErrInvalidContentID        = "Invalid content ID"
 	ErrInvalidEmailLogID       = "Invalid email log ID"
 	ErrInvalidSendTimeFormat   = "Invalid send_time format"
+	ErrInvalidLocalSendTime    = "Invalid local_send_time"
 	ErrTopicNotFound           = "Topic not found"
+	ErrTopicNameExists         = "A topic with this name already exists"
+	ErrTopicSlugExists         = "A topic with this slug already exists"
+	ErrInvalidTopicSlug        = "slug may only contain lowercase letters, digits and single hyphens"
+	ErrPreconditionFailed      = "The resource has changed since it was fetched; fetch it again and retry with its new ETag"
 	ErrSubscriberNotFound      = "Subscriber not found"
 	ErrSubscriptionNotFound    = "Subscription not found"
 	ErrContentNotFound         = "Content not found"
 	ErrEmailLogNotFound        = "Email log not found"
+	ErrContentLintFailed       = "Content has lint errors and cannot be published"
+	ErrAssetFileRequired       = "An image is required in the file form field"
+	ErrAssetTooLarge           = "Image exceeds the maximum upload size"
+	ErrAssetUnsupportedType    = "Only PNG, JPEG, GIF and WebP images can be uploaded"
+	ErrAssetNotFound           = "Asset not found"
+	ErrInvalidLocale           = "Invalid locale; use a language tag such as de or pt-BR"
+	ErrTranslationNotFound     = "Translation not found"
+	ErrInvalidSnippetID        = "Invalid snippet ID"
+	ErrSnippetNotFound         = "Snippet not found"
+	ErrSnippetNameExists       = "A snippet with this name already exists"
+	ErrInvalidSnippetName      = "Snippet names may only contain letters, digits, '-', '_' and '.'"
+	ErrUnknownEmailTemplate    = "Unknown email template; use email.html or email.txt"
+	ErrInvalidEmailTemplate    = "Template does not parse or render"
+	ErrEmailTemplatesBuiltin   = "Built-in templates can't be edited; set [templates] source to file, database or s3"
+	ErrContentNotApproved      = "Content must be approved before it can be published"
+	ErrInvalidApprovalState    = "Content is not in a state that allows this"
+	ErrNotReviewer             = "Only reviewers can approve or reject content"
+	ErrNotAssignedReviewer     = "Content is assigned to another reviewer"
+	ErrNotPublisher            = "Only publishers can publish content"
+	ErrUnknownReviewer         = "The reviewer is not one of the configured reviewers"
+	ErrCommentRequired         = "A comment is required"
+	ErrTopicArchived           = "Topic is archived and takes no new content or subscriptions"
+	ErrInvalidMigrationTarget  = "Subscribers can only be migrated to another topic"
+	ErrTopicInUse              = "Topic has subscriptions or unsent content; delete with force=true to remove them"
 	ErrUnauthorized            = "Unauthorized"
 	ErrForbidden               = "Forbidden"
 	ErrTooManyRequests         = "Too many requests"
 	ErrInternalServerError     = "Internal server error"
+	ErrInvalidCredentials      = "Invalid username or password"
+	ErrInvalidToken            = "Invalid or expired token"
+	ErrInvalidAuditLogID       = "Invalid audit log ID"
+	ErrInvalidAuditLogFilter   = "Invalid audit log filter"
+	ErrInvalidEmailLogFilter   = "Invalid email log filter"
+	ErrAuditLogNotFound        = "Audit log not found"
+	ErrInvalidAPIKeyID         = "Invalid API key ID"
+	ErrAPIKeyNotFound          = "API key not found"
+	ErrInvalidWebhookID        = "Invalid webhook ID"
+	ErrWebhookNotFound         = "Webhook not found"
+	ErrInvalidPushDeviceID     = "Invalid push device ID"
+	ErrPushDeviceNotFound      = "Push device not found"
+	ErrPushKeysRequired        = "Web Push devices need keys.p256dh and keys.auth"
+	ErrNotSubscribedToTopic    = "Subscriber is not subscribed to the topic"
+	ErrDigestEmailOnly         = "Daily and weekly digests are only available for email"
+	ErrWorkerJobNotFound       = "Worker job not found"
+	ErrProviderNotFound        = "Provider not found"
+	ErrProviderNotPaused       = "Provider is not paused"
+	ErrInvalidStatsDays        = "days must be an integer between 1 and 365"
+	ErrInvalidStatsQuery       = "Invalid stats query"
+	ErrInvalidEngagementFilter = "Invalid engagement filter"
+	ErrUndeliverableEmail      = "Email address is undeliverable"
+	ErrSubscriberEmailExists   = "A subscriber with this email address already exists"
+	ErrSelfMerge               = "A subscriber can't be merged into itself"
+	ErrSubscribedTopicNotFound = "Subscribed topic not found"
+	ErrInvalidIdempotencyKey   = "Idempotency-Key must be at most 255 characters"
+	ErrIdempotencyInProgress    = "A request with this Idempotency-Key is already in progress"
+	ErrIdempotencyKeyReused    = "Idempotency-Key was already used with a different request body"
+	ErrInvalidAPIKey           = "Invalid or revoked API key"
+	ErrInvalidOrganizationID   = "Invalid organization ID"
+	ErrOrganizationNotFound    = "Organization not found"
+	ErrOrganizationMismatch    = "X-Organization-ID does not match the API key's organization"
+	ErrOperatorCredentialsOnly = "This endpoint requires operator credentials"
+	ErrReferralsDisabled       = "Referrals are not enabled"
+	ErrUnknownReferralCode     = "Referral code not found"
+	ErrAlreadySubscribed       = "This address is already subscribed"
+	ErrInvalidLeaderboardQuery = "limit must be an integer between 1 and 100"
+	ErrInvalidSignupFilter     = "Invalid signup filter"
+	ErrLandingPageNotFound     = "Landing page not found"
+	ErrInvalidPlanID           = "Invalid plan ID"
+	ErrPlanNotFound            = "Plan not found"
+	ErrPlanPriceExists         = "A plan with this Stripe price already exists"
+	ErrPlanInactive            = "This plan is no longer sold"
+	ErrAlreadyEntitled         = "The subscriber already pays for this topic"
+	ErrBillingDisabled         = "Billing is not enabled"
+	ErrInvalidBillingFilter    = "Invalid billing subscription filter"
+	ErrInvalidStripeSignature  = "Invalid Stripe signature"
+	ErrInvalidStripeEvent      = "Invalid Stripe event"
+	ErrInvalidSponsorID        = "Invalid sponsor ID"
+	ErrSponsorNotFound         = "Sponsor not found"
+	ErrInvalidSponsorSlotID    = "Invalid sponsor slot ID"
+	ErrSponsorSlotNotFound     = "Sponsor slot not found"
+	ErrInvalidSponsorSlot      = "Book a slot in either a content_id or a topic_id on a date"
+	ErrSponsorSlotTaken        = "This content or date already has a sponsor"
+	ErrSponsorSlotServed       = "This slot's content has already been sent"
+	ErrSponsorSlotDatePassed   = "The slot's date has passed"
+	ErrContentAlreadySent      = "This content has already been sent"
+	ErrInvalidSponsorFilter    = "Invalid sponsor slot filter"
+	ErrInvalidPollID           = "Invalid poll ID"
+	ErrPollNotFound            = "Poll not found"
+	ErrDeliveryNotInProgress   = "This content's delivery is not in progress"
+	ErrContentSendInProgress   = "This content is already being sent"
+	ErrContentNotPublished     = "Content must be published before it is sent"
 )
 
 // Health check responses
 const (
 	HealthStatusHealthy  = "healthy"
+	HealthStatusReady    = "ready"
+	HealthStatusNotReady = "not_ready"
 	ServiceNameMain      = "newsletter-service"
 	ServiceNameScheduler = "newsletter-scheduler"
+	ServiceNameWorker    = "newsletter-worker"
 )
+
+// MigrationDir is where goose SQL migrations live, relative to the working directory
+const MigrationDir = "migration/sql"
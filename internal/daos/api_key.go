@@ -0,0 +1,24 @@
+package daos
+
+import "time"
+
+// APIKey is an issued API key. Only the SHA-256 hash of the key is stored.
+// Rate limit fields override the configured rule for requests carrying the key.
+type APIKey struct {
+	ID                     uint       `json:"id" gorm:"primarykey"`
+	Name                   string     `json:"name" gorm:"size:100;not null"`
+	KeyPrefix              string     `json:"key_prefix" gorm:"size:16;not null"`
+	KeyHash                string     `json:"-" gorm:"uniqueIndex;size:64;not null"`
+	RateLimitBucketSize    *int       `json:"rate_limit_bucket_size"`
+	RateLimitRefillSize    *int       `json:"rate_limit_refill_size"`
+	RateLimitRefillSeconds *int       `json:"rate_limit_refill_seconds"`
+	RevokedAt              *time.Time `json:"revoked_at"`
+	CreatedAt              time.Time  `json:"created_at"`
+	UpdatedAt              time.Time  `json:"updated_at"`
+	OrganizationID         uint       `json:"organization_id" gorm:"not null;default:1;index"` // Requests made with the key are scoped to it
+}
+
+// TableName returns the table name for APIKey
+func (APIKey) TableName() string {
+	return "api_keys"
+}
@@ -0,0 +1,41 @@
+package daos
+
+import "time"
+
+// Approval states content moves through: drafts are submitted for review, approved or sent back to draft by a
+// reviewer, and published once approved. Editing submitted or approved content returns it to draft.
+const (
+	ApprovalStateDraft     = "draft"
+	ApprovalStateInReview  = "in_review"
+	ApprovalStateApproved  = "approved"
+	ApprovalStatePublished = "published"
+)
+
+// Actions recorded in a content's approval log
+const (
+	ApprovalActionSubmit  = "submit"
+	ApprovalActionAssign  = "assign"
+	ApprovalActionApprove = "approve"
+	ApprovalActionReject  = "reject"
+	ApprovalActionComment = "comment"
+	ApprovalActionReopen  = "reopen" // Edited after it was submitted
+	ApprovalActionPublish = "publish"
+)
+
+// ApprovalEvent is an entry in a content's approval log: a change of state, a reviewer assignment or a comment
+type ApprovalEvent struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	ContentID uint      `json:"content_id" gorm:"not null;index"`
+	Action    string    `json:"action" gorm:"size:20;not null"`
+	FromState string    `json:"from_state,omitempty" gorm:"size:20;not null;default:''"`
+	ToState   string    `json:"to_state,omitempty" gorm:"size:20;not null;default:''"`
+	Actor     string    `json:"actor" gorm:"size:255;not null"`
+	Reviewer  string    `json:"reviewer,omitempty" gorm:"size:255;not null;default:''"` // Reviewer assigned by the event
+	Comment   string    `json:"comment,omitempty" gorm:"type:text;not null;default:''"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TableName returns the table name for ApprovalEvent
+func (ApprovalEvent) TableName() string {
+	return "content_approval_events"
+}
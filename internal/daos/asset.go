@@ -0,0 +1,20 @@
+package daos
+
+import "time"
+
+// Asset is an image uploaded for use in content. It is stored and served publicly under Key, which is random so
+// one organization's assets can't be found by counting through another's.
+type Asset struct {
+	ID             uint      `json:"id" gorm:"primarykey"`
+	OrganizationID uint      `json:"organization_id" gorm:"not null;default:1;index"`
+	Key            string    `json:"key" gorm:"uniqueIndex;size:64;not null"`
+	Filename       string    `json:"filename" gorm:"size:255;not null;default:''"` // Name of the uploaded file
+	ContentType    string    `json:"content_type" gorm:"size:100;not null"`
+	Size           int64     `json:"size" gorm:"not null"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// TableName returns the table name for Asset
+func (Asset) TableName() string {
+	return "assets"
+}
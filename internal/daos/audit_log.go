@@ -0,0 +1,24 @@
+package daos
+
+import "time"
+
+// AuditLog records who changed what and when for mutating admin operations
+type AuditLog struct {
+	ID          uint      `json:"id" gorm:"primarykey"`
+	Actor       string    `json:"actor" gorm:"size:255;not null;index"`
+	ActorType   string    `json:"actor_type" gorm:"size:50;not null"`
+	Action      string    `json:"action" gorm:"size:50;not null;index"`
+	EntityType  string    `json:"entity_type" gorm:"size:50;not null;index:idx_audit_logs_entity"`
+	EntityID    uint      `json:"entity_id" gorm:"not null;index:idx_audit_logs_entity"`
+	BeforeState *string   `json:"before_state" gorm:"type:jsonb"`
+	AfterState  *string   `json:"after_state" gorm:"type:jsonb"`
+	Changes     *string   `json:"changes" gorm:"type:jsonb"`
+	IPAddress   string    `json:"ip_address" gorm:"size:64"`
+	RequestID   string    `json:"request_id" gorm:"size:64"`
+	CreatedAt   time.Time `json:"created_at" gorm:"index"`
+}
+
+// TableName returns the table name for AuditLog
+func (AuditLog) TableName() string {
+	return "audit_logs"
+}
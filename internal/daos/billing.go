@@ -0,0 +1,80 @@
+package daos
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Billing subscription statuses, as Stripe names them
+const (
+	BillingStatusIncomplete = "incomplete" // The first payment hasn't gone through yet
+	BillingStatusTrialing   = "trialing"
+	BillingStatusActive     = "active"
+	BillingStatusPastDue    = "past_due" // A renewal failed and Stripe is retrying it
+	BillingStatusUnpaid     = "unpaid"   // Stripe stopped retrying but left the subscription open
+	BillingStatusCanceled   = "canceled"
+)
+
+// EntitledBillingStatuses are the statuses whose subscribers receive a topic's premium content. Past-due
+// subscribers keep it while Stripe retries the payment; once Stripe gives up the subscription turns unpaid or
+// canceled and the content stops.
+var EntitledBillingStatuses = []string{BillingStatusTrialing, BillingStatusActive, BillingStatusPastDue}
+
+// Plan is a paid tier of a topic, sold through a Stripe price. Subscribers paying for any of a topic's plans
+// receive its premium content.
+type Plan struct {
+	ID            uint   `json:"id" gorm:"primarykey"`
+	TopicID       uint   `json:"topic_id" gorm:"not null;index"`
+	Name          string `json:"name" gorm:"size:100;not null"`
+	Description   string `json:"description" gorm:"type:text;not null;default:''"`
+	StripePriceID string `json:"stripe_price_id" gorm:"size:255;not null;uniqueIndex"`
+
+	// What the price charges, for display; Stripe bills whatever the price says
+	AmountCents int64  `json:"amount_cents" gorm:"not null;default:0"`
+	Currency    string `json:"currency" gorm:"size:3;not null;default:''"`
+	Interval    string `json:"interval" gorm:"size:10;not null;default:''"` // month or year
+
+	// Inactive plans can't be bought; subscriptions already paying for them carry on. No GORM default, which
+	// would turn a plan created inactive into an active one.
+	Active bool `json:"active" gorm:"not null"`
+
+	OrganizationID uint           `json:"organization_id" gorm:"not null;default:1;index"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+// TableName returns the table name for Plan
+func (Plan) TableName() string {
+	return "plans"
+}
+
+// BillingSubscription is a subscriber paying for a plan, kept in step with its Stripe subscription through
+// Stripe's webhook events
+type BillingSubscription struct {
+	ID           uint `json:"id" gorm:"primarykey"`
+	SubscriberID uint `json:"subscriber_id" gorm:"not null;index"`
+	PlanID       uint `json:"plan_id" gorm:"not null;index"`
+	TopicID      uint `json:"topic_id" gorm:"not null;index"` // The plan's, so sends check entitlement without a join
+
+	StripeCustomerID     string     `json:"stripe_customer_id" gorm:"size:255;not null;default:'';index"`
+	StripeSubscriptionID string     `json:"stripe_subscription_id" gorm:"size:255;not null;uniqueIndex"`
+	Status               string     `json:"status" gorm:"size:20;not null;index"`
+	CurrentPeriodEnd     *time.Time `json:"current_period_end"`
+	CancelAtPeriodEnd    bool       `json:"cancel_at_period_end" gorm:"not null;default:false"`
+
+	// Dunning: set by a failed payment and cleared by the next one that succeeds
+	PaymentFailedAt    *time.Time `json:"payment_failed_at" gorm:"index"`
+	DunningEmailsSent  int        `json:"dunning_emails_sent" gorm:"not null;default:0"`
+	LastDunningEmailAt *time.Time `json:"last_dunning_email_at"`
+
+	OrganizationID uint      `json:"organization_id" gorm:"not null;default:1;index"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// TableName returns the table name for BillingSubscription
+func (BillingSubscription) TableName() string {
+	return "billing_subscriptions"
+}
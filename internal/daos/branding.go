@@ -0,0 +1,46 @@
+package daos
+
+// Branding is the sender identity and look of the emails an organization or topic sends. Empty fields fall
+// back: a topic's to its organization's, an organization's to the provider configuration and built-in template.
+type Branding struct {
+	FromName     string `json:"from_name" gorm:"size:100;not null;default:''"`
+	FromAddress  string `json:"from_address" gorm:"size:255;not null;default:''"`
+	ReplyTo      string `json:"reply_to" gorm:"size:255;not null;default:''"`
+	LogoURL      string `json:"logo_url" gorm:"size:2048;not null;default:''"`
+	PrimaryColor string `json:"primary_color" gorm:"size:7;not null;default:''"` // Hex, e.g. #007bff
+	FooterText   string `json:"footer_text" gorm:"type:text;not null;default:''"`
+
+	// Theme of the template beyond the primary color and logo; colors are hex like PrimaryColor
+	DarkLogoURL         string `json:"dark_logo_url" gorm:"size:2048;not null;default:''"` // Shown instead of the logo in dark mode
+	BackgroundColor     string `json:"background_color" gorm:"size:7;not null;default:''"`
+	TextColor           string `json:"text_color" gorm:"size:7;not null;default:''"`
+	FontFamily          string `json:"font_family" gorm:"size:200;not null;default:''"` // CSS font stack, e.g. Georgia, serif
+	DarkPrimaryColor    string `json:"dark_primary_color" gorm:"size:7;not null;default:''"`
+	DarkBackgroundColor string `json:"dark_background_color" gorm:"size:7;not null;default:''"`
+	DarkTextColor       string `json:"dark_text_color" gorm:"size:7;not null;default:''"`
+}
+
+// Merge returns b with its empty fields taken from fallback
+func (b Branding) Merge(fallback Branding) Branding {
+	pick := func(value, fallback string) string {
+		if value != "" {
+			return value
+		}
+		return fallback
+	}
+	return Branding{
+		FromName:            pick(b.FromName, fallback.FromName),
+		FromAddress:         pick(b.FromAddress, fallback.FromAddress),
+		ReplyTo:             pick(b.ReplyTo, fallback.ReplyTo),
+		LogoURL:             pick(b.LogoURL, fallback.LogoURL),
+		PrimaryColor:        pick(b.PrimaryColor, fallback.PrimaryColor),
+		FooterText:          pick(b.FooterText, fallback.FooterText),
+		DarkLogoURL:         pick(b.DarkLogoURL, fallback.DarkLogoURL),
+		BackgroundColor:     pick(b.BackgroundColor, fallback.BackgroundColor),
+		TextColor:           pick(b.TextColor, fallback.TextColor),
+		FontFamily:          pick(b.FontFamily, fallback.FontFamily),
+		DarkPrimaryColor:    pick(b.DarkPrimaryColor, fallback.DarkPrimaryColor),
+		DarkBackgroundColor: pick(b.DarkBackgroundColor, fallback.DarkBackgroundColor),
+		DarkTextColor:       pick(b.DarkTextColor, fallback.DarkTextColor),
+	}
+}
@@ -0,0 +1,30 @@
+package daos
+
+import (
+	"strings"
+
+	"newsletter-service/internal/constants"
+)
+
+// EncodeChannels joins notification channels for storage, e.g. "email,sms"
+func EncodeChannels(channels []string) string {
+	return strings.Join(channels, ",")
+}
+
+// DecodeChannels splits stored channels. Rows without any are email only.
+func DecodeChannels(channels string) []string {
+	if channels == "" {
+		return []string{constants.NotificationTypeEmail}
+	}
+	return strings.Split(channels, ",")
+}
+
+// HasChannel reports whether stored channels include channel
+func HasChannel(channels, channel string) bool {
+	for _, c := range DecodeChannels(channels) {
+		if c == channel {
+			return true
+		}
+	}
+	return false
+}
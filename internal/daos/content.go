@@ -6,19 +6,73 @@ import (
 	"gorm.io/gorm"
 )
 
+// Send priorities of content, from announcements that can't wait to sends that can
+const (
+	ContentPriorityHigh   = "high"
+	ContentPriorityNormal = "normal"
+	ContentPriorityLow    = "low"
+)
+
+// Where a content's delivery stands once its notifications went out. Content that wasn't sent yet has none.
+const (
+	DeliveryStateSending        = "sending"         // Some recipients are still queued or have retries left
+	DeliveryStateComplete       = "complete"        // Every recipient was sent to or failed for good
+	DeliveryStateForceCompleted = "force_completed" // Closed through the API; outstanding emails were cancelled
+)
+
 // Content represents newsletter content in the database
 type Content struct {
 	ID                  uint           `json:"id" gorm:"primarykey"`
 	TopicID             uint           `json:"topic_id" gorm:"not null;index"`
 	Title               string         `json:"title" gorm:"size:255;not null"`
 	Body                string         `json:"body" gorm:"type:text;not null"`
+	PreviewText         string         `json:"preview_text" gorm:"type:text;not null;default:''"` // Inbox preview (preheader) shown after the subject
 	IsPublished         bool           `json:"is_published" gorm:"default:false;index"`
 	PublishedAt         *time.Time     `json:"published_at"`
 	NotificationsSent   bool           `json:"notifications_sent" gorm:"default:false;index"`
 	NotificationsSentAt *time.Time     `json:"notifications_sent_at"`
+	SendAt              *time.Time     `json:"send_at" gorm:"index"`          // Published content isn't sent before this; also the date local-time sends use
+	LocalSendTime       string         `json:"local_send_time" gorm:"size:5"` // "HH:MM": each subscriber receives it at this time in their own time zone
 	CreatedAt           time.Time      `json:"created_at"`
 	UpdatedAt           time.Time      `json:"updated_at"`
 	DeletedAt           gorm.DeletedAt `json:"-" gorm:"index"`
+	OrganizationID      uint           `json:"organization_id" gorm:"not null;default:1;index"`
+
+	// Channels it is delivered on, comma-separated: "email", "sms", "push"
+	Channels string `json:"channels" gorm:"size:50;default:email;not null"`
+
+	// Overrides the topic's link tags
+	UTM UTM `json:"utm" gorm:"embedded;embeddedPrefix:utm_"`
+
+	// Language the title and body are written in, e.g. "en"; empty is unspecified. Subscribers preferring
+	// another language get a matching translation when there is one.
+	Locale       string               `json:"locale" gorm:"size:35;not null;default:''"`
+	Translations []ContentTranslation `json:"translations,omitempty" gorm:"foreignKey:ContentID"`
+
+	// Only sent to subscribers paying for one of the topic's plans
+	Premium bool `json:"premium" gorm:"not null;default:false"`
+
+	// How urgently it is sent, see ContentPriority*: higher priorities are dispatched first and take send
+	// capacity ahead of lower ones
+	Priority string `json:"priority" gorm:"size:10;not null;default:'normal';index"`
+
+	// Where the content is in its review, see ApprovalState*, and who is asked to review it
+	ApprovalState string `json:"approval_state" gorm:"size:20;not null;default:'draft';index"`
+	Reviewer      string `json:"reviewer" gorm:"size:255;not null;default:''"`
+
+	// Delivery after the notifications went out, see DeliveryState*. The counts are of the content's email, SMS
+	// and push logs, refreshed by the worker until no recipient is outstanding.
+	DeliveryState       string     `json:"delivery_state" gorm:"size:20;not null;default:'';index"`
+	DeliverySent        int64      `json:"delivery_sent" gorm:"not null;default:0"`
+	DeliveryFailed      int64      `json:"delivery_failed" gorm:"not null;default:0"`
+	DeliveryOutstanding int64      `json:"delivery_outstanding" gorm:"not null;default:0"`
+	DeliveryCompletedAt *time.Time `json:"delivery_completed_at"`
+
+	// Email recipients its sends were dispatched to, logged or not; see expectRecipients
+	DeliveryRecipients int64 `json:"delivery_recipients" gorm:"not null;default:0"`
+
+	// Whether its send report goes out when the delivery completes; content sent before reports existed has none
+	DeliveryReportDue bool `json:"-" gorm:"not null;default:false"`
 
 	// Relationships
 	Topic     *Topic     `json:"topic,omitempty" gorm:"foreignKey:TopicID"`
@@ -0,0 +1,21 @@
+package daos
+
+import "time"
+
+// ContentTranslation is a content's title, body and preview text in another language. Subscribers get the
+// translation best matching their locale, and the content itself when none does.
+type ContentTranslation struct {
+	ID          uint      `json:"id" gorm:"primarykey"`
+	ContentID   uint      `json:"content_id" gorm:"uniqueIndex:idx_content_translations_content_locale,priority:1;not null"`
+	Locale      string    `json:"locale" gorm:"uniqueIndex:idx_content_translations_content_locale,priority:2;size:35;not null"`
+	Title       string    `json:"title" gorm:"size:255;not null"`
+	Body        string    `json:"body" gorm:"type:text;not null"`
+	PreviewText string    `json:"preview_text" gorm:"type:text;not null;default:''"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// TableName returns the table name for ContentTranslation
+func (ContentTranslation) TableName() string {
+	return "content_translations"
+}
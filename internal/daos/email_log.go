@@ -8,19 +8,32 @@ import (
 
 // EmailLog represents an email delivery log in the database
 type EmailLog struct {
-	ID           uint           `json:"id" gorm:"primarykey"`
-	SubscriberID uint           `json:"subscriber_id" gorm:"not null;index"`
-	ContentID    uint           `json:"content_id" gorm:"not null;index"`
-	EmailAddress string         `json:"email_address" gorm:"size:255;not null"`
-	Subject      string         `json:"subject" gorm:"size:255;not null"`
-	Body         string         `json:"body" gorm:"type:text;not null"`
-	Status       string         `json:"status" gorm:"size:20;not null;index"`
-	SentAt       *time.Time     `json:"sent_at"`
-	ErrorMessage *string        `json:"error_message" gorm:"type:text"`
-	RetryCount   int            `json:"retry_count" gorm:"default:0"`
-	CreatedAt    time.Time      `json:"created_at"`
-	UpdatedAt    time.Time      `json:"updated_at"`
-	DeletedAt    gorm.DeletedAt `json:"-" gorm:"index"`
+	ID                uint           `json:"id" gorm:"primarykey"`
+	SubscriberID      uint           `json:"subscriber_id" gorm:"not null;index"`
+	ContentID         uint           `json:"content_id" gorm:"not null;index"`
+	EmailAddress      string         `json:"email_address" gorm:"size:255;not null"`
+	Subject           string         `json:"subject" gorm:"size:255;not null"`
+	Body              string         `json:"body" gorm:"type:text;not null"`
+	BodyHash          string         `json:"body_hash,omitempty" gorm:"size:64;not null;default:'';index"` // Set instead of Body when the body is in email_log_bodies
+	PreviewText       string         `json:"preview_text" gorm:"type:text;not null;default:''"`
+	Locale            string         `json:"locale,omitempty" gorm:"size:35;not null;default:''"` // Language of the template's own text, such as the unsubscribe link
+	Status            string         `json:"status" gorm:"size:20;not null;index"`
+	SentAt            *time.Time     `json:"sent_at"`
+	ErrorMessage      *string        `json:"error_message" gorm:"type:text"`
+	RetryCount        int            `json:"retry_count" gorm:"default:0"`
+	OpenedAt          *time.Time     `json:"opened_at" gorm:"index"` // First time the tracking pixel was loaded
+	Provider          string         `json:"provider" gorm:"size:100;index"`
+	ProviderMessageID string         `json:"provider_message_id" gorm:"size:255;index"` // Matches bounce and webhook events to this log
+	CreatedAt         time.Time      `json:"created_at"`
+	UpdatedAt         time.Time      `json:"updated_at"`
+	DeletedAt         gorm.DeletedAt `json:"-" gorm:"index"`
+
+	// Delivery channel, "email", "sms" or "push". SMS logs hold the phone number in EmailAddress, push logs
+	// the device, e.g. "webpush device 12".
+	Channel string `json:"channel" gorm:"size:20;default:email;not null;index"`
+
+	// The content's organization
+	OrganizationID uint `json:"organization_id" gorm:"not null;default:1;index"`
 
 	// Relationships
 	Subscriber *Subscriber `json:"subscriber,omitempty" gorm:"foreignKey:SubscriberID"`
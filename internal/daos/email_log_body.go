@@ -0,0 +1,16 @@
+package daos
+
+import "time"
+
+// EmailLogBody is an email body shared by the logs of every recipient it was sent to, stored once under the
+// SHA-256 of its text when [email_logs] body_storage is "shared"
+type EmailLogBody struct {
+	Hash      string    `json:"hash" gorm:"primaryKey;size:64"`
+	Body      string    `json:"body" gorm:"type:text;not null"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TableName returns the table name for EmailLogBody
+func (EmailLogBody) TableName() string {
+	return "email_log_bodies"
+}
@@ -0,0 +1,17 @@
+package daos
+
+import "time"
+
+// EmailTemplate overrides a built-in email template (email.html or email.txt) when [templates] source is
+// "database". Templates apply to every organization; branding covers what differs between them.
+type EmailTemplate struct {
+	Name      string    `json:"name" gorm:"primarykey;size:50"`
+	Body      string    `json:"body" gorm:"type:text;not null"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName returns the table name for EmailTemplate
+func (EmailTemplate) TableName() string {
+	return "email_templates"
+}
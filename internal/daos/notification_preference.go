@@ -0,0 +1,31 @@
+package daos
+
+import "time"
+
+// Notification frequencies a subscriber can choose per topic and channel
+const (
+	FrequencyInstant = "instant" // Sent as soon as content goes out (default without a preference)
+	FrequencyDaily   = "daily"   // Collected into a daily email digest
+	FrequencyWeekly  = "weekly"  // Collected into a weekly email digest
+	FrequencyOff     = "off"     // Not sent on the channel
+)
+
+// NotificationPreference is how often a subscriber receives a topic's content on one channel. Subscribers
+// without a preference for a topic and channel receive it instantly.
+type NotificationPreference struct {
+	ID           uint      `json:"id" gorm:"primarykey"`
+	SubscriberID uint      `json:"subscriber_id" gorm:"not null;uniqueIndex:idx_notification_preferences_unique"`
+	TopicID      uint      `json:"topic_id" gorm:"not null;uniqueIndex:idx_notification_preferences_unique;index"`
+	Channel      string    `json:"channel" gorm:"size:20;not null;uniqueIndex:idx_notification_preferences_unique"`
+	Frequency    string    `json:"frequency" gorm:"size:20;not null"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+
+	// When the last digest covering this preference was queued; digests include content sent since then
+	LastDigestAt *time.Time `json:"last_digest_at"`
+}
+
+// TableName returns the table name for NotificationPreference
+func (NotificationPreference) TableName() string {
+	return "notification_preferences"
+}
@@ -0,0 +1,18 @@
+package daos
+
+import "time"
+
+// Organization is a workspace running its own newsletter. Topics, subscribers, contents, email logs and API keys
+// each belong to one organization.
+type Organization struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	Name      string    `json:"name" gorm:"uniqueIndex;size:100;not null"`
+	Branding  Branding  `json:"branding" gorm:"embedded;embeddedPrefix:branding_"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName returns the table name for Organization
+func (Organization) TableName() string {
+	return "organizations"
+}
@@ -0,0 +1,58 @@
+package daos
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Poll is a question embedded in a content with {{poll <id>}}. Each option is a link that answers it in one
+// click.
+type Poll struct {
+	ID        uint         `json:"id" gorm:"primarykey"`
+	ContentID uint         `json:"content_id" gorm:"not null;index"` // Only this content can embed it
+	Question  string       `json:"question" gorm:"size:255;not null"`
+	ClosesAt  *time.Time   `json:"closes_at"` // Answers after this are turned away; nil keeps it open
+	Options   []PollOption `json:"options,omitempty" gorm:"foreignKey:PollID"`
+
+	OrganizationID uint           `json:"organization_id" gorm:"not null;default:1;index"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+// TableName returns the table name for Poll
+func (Poll) TableName() string {
+	return "polls"
+}
+
+// PollOption is one answer to a poll, shown in Position order
+type PollOption struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	PollID    uint      `json:"poll_id" gorm:"not null;index"`
+	Label     string    `json:"label" gorm:"size:100;not null"`
+	Position  int       `json:"position" gorm:"not null;default:0"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TableName returns the table name for PollOption
+func (PollOption) TableName() string {
+	return "poll_options"
+}
+
+// PollResponse is a subscriber's answer to a poll. A later click on another option changes it.
+type PollResponse struct {
+	ID           uint `json:"id" gorm:"primarykey"`
+	PollID       uint `json:"poll_id" gorm:"not null;uniqueIndex:idx_poll_responses_poll_subscriber,priority:1"`
+	SubscriberID uint `json:"subscriber_id" gorm:"not null;uniqueIndex:idx_poll_responses_poll_subscriber,priority:2;index"`
+	OptionID     uint `json:"option_id" gorm:"not null;index"`
+
+	OrganizationID uint      `json:"organization_id" gorm:"not null;default:1;index"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// TableName returns the table name for PollResponse
+func (PollResponse) TableName() string {
+	return "poll_responses"
+}
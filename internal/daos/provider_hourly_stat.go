@@ -0,0 +1,17 @@
+package daos
+
+import "time"
+
+// ProviderHourlyStat counts one provider's send attempts in one hour, shared by every replica
+type ProviderHourlyStat struct {
+	Provider  string    `json:"provider" gorm:"primaryKey;size:100"`
+	Hour      time.Time `json:"hour" gorm:"primaryKey"` // Start of the hour, UTC
+	Sent      int       `json:"sent" gorm:"not null;default:0"`
+	Failed    int       `json:"failed" gorm:"not null;default:0"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName returns the table name for ProviderHourlyStat
+func (ProviderHourlyStat) TableName() string {
+	return "provider_hourly_stats"
+}
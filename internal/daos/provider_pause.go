@@ -0,0 +1,19 @@
+package daos
+
+import "time"
+
+// ProviderPause takes an email provider out of rotation, e.g. during an incident at the provider, until it is
+// resumed. Providers are shared by every organization, so pauses have no organization either.
+type ProviderPause struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	Provider  string    `json:"provider" gorm:"size:100;not null;uniqueIndex"`
+	Reason    string    `json:"reason" gorm:"type:text;not null;default:''"`
+	PausedBy  string    `json:"paused_by" gorm:"size:255;not null;default:''"`
+	CreatedAt time.Time `json:"created_at"` // When the provider was paused
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName returns the table name for ProviderPause
+func (ProviderPause) TableName() string {
+	return "provider_pauses"
+}
@@ -0,0 +1,27 @@
+package daos
+
+import "time"
+
+// Push device platforms
+const (
+	PushPlatformFCM     = "fcm"     // Android, iOS or web app registered with Firebase Cloud Messaging
+	PushPlatformWebPush = "webpush" // Browser Web Push subscription
+)
+
+// PushDevice is a device a subscriber registered for push notifications. Token is the FCM registration token,
+// or the Web Push subscription endpoint, whose keys are in P256dh and Auth.
+type PushDevice struct {
+	ID           uint      `json:"id" gorm:"primarykey"`
+	SubscriberID uint      `json:"subscriber_id" gorm:"not null;index"`
+	Platform     string    `json:"platform" gorm:"size:20;not null"`
+	Token        string    `json:"token" gorm:"uniqueIndex;size:2048;not null"`
+	P256dh       string    `json:"-" gorm:"size:128"`
+	Auth         string    `json:"-" gorm:"size:64"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// TableName returns the table name for PushDevice
+func (PushDevice) TableName() string {
+	return "push_devices"
+}
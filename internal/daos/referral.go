@@ -0,0 +1,45 @@
+package daos
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+)
+
+// Referral is a reader who signed up through a subscriber's referral link. The reader only becomes a
+// subscriber, and the referrer is only credited, once the reader confirms through the emailed link.
+type Referral struct {
+	ID         uint   `json:"id" gorm:"primarykey"`
+	ReferrerID uint   `json:"referrer_id" gorm:"not null;index"`
+	Email      string `json:"email" gorm:"size:255;not null"`
+	Name       string `json:"name" gorm:"size:100;not null"`
+
+	Token              string     `json:"-" gorm:"size:64;not null;uniqueIndex"` // In the confirmation link
+	Status             string     `json:"status" gorm:"size:20;not null;default:pending;index"`
+	ConfirmationSentAt *time.Time `json:"confirmation_sent_at" gorm:"index"`
+	ConfirmedAt        *time.Time `json:"confirmed_at"`
+	SubscriberID       *uint      `json:"subscriber_id,omitempty"` // Created on confirmation
+
+	// The referrer's organization, which the new subscriber joins
+	OrganizationID uint `json:"organization_id" gorm:"not null;default:1;index"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName returns the table name for Referral
+func (Referral) TableName() string {
+	return "referrals"
+}
+
+// referralCodeBytes gives codes 64 random bits, so they don't collide across millions of subscribers
+const referralCodeBytes = 8
+
+// NewReferralCode returns a random code for a subscriber's referral link
+func NewReferralCode() (string, error) {
+	b := make([]byte, referralCodeBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,68 @@
+package daos
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Signup sources, recorded in Subscriber.SignupSource
+const (
+	SignupSourceAPI      = "api"      // POST /subscribers
+	SignupSourceBulk     = "bulk"     // POST /subscribers/bulk
+	SignupSourceGRPC     = "grpc"     // The internal gRPC API
+	SignupSourceReferral = "referral" // A confirmed referral
+	SignupSourceLanding  = "landing"  // A topic's landing page, with the topic's slug as the form
+)
+
+// SignupFilter narrows subscribers down by how they signed up. Zero fields match everything.
+type SignupFilter struct {
+	Source       string
+	Form         string
+	UTMSource    string
+	UTMMedium    string
+	UTMCampaign  string
+	Country      string
+	APIKeyID     *uint
+	ReferredByID *uint
+	After        *time.Time // Signed up at or after
+	Before       *time.Time // Signed up before
+}
+
+// IsZero reports whether the filter matches every subscriber
+func (f SignupFilter) IsZero() bool {
+	return f == SignupFilter{}
+}
+
+// Apply adds the filter's conditions to a query on subscribers
+func (f SignupFilter) Apply(query *gorm.DB) *gorm.DB {
+	conditions := []struct {
+		column string
+		value  string
+	}{
+		{"signup_source", f.Source},
+		{"signup_form", f.Form},
+		{"utm_source", f.UTMSource},
+		{"utm_medium", f.UTMMedium},
+		{"utm_campaign", f.UTMCampaign},
+		{"signup_country", f.Country},
+	}
+	for _, condition := range conditions {
+		if condition.value != "" {
+			query = query.Where(condition.column+" = ?", condition.value)
+		}
+	}
+	if f.APIKeyID != nil {
+		query = query.Where("signup_api_key_id = ?", *f.APIKeyID)
+	}
+	if f.ReferredByID != nil {
+		query = query.Where("referred_by_id = ?", *f.ReferredByID)
+	}
+	if f.After != nil {
+		query = query.Where("subscribers.created_at >= ?", *f.After)
+	}
+	if f.Before != nil {
+		query = query.Where("subscribers.created_at < ?", *f.Before)
+	}
+	return query
+}
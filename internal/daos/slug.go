@@ -0,0 +1,61 @@
+package daos
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// MaxSlugLength is the size of the topics.slug column
+const MaxSlugLength = 100
+
+// slugBaseLength leaves room for the number AvailableSlug appends
+const slugBaseLength = 90
+
+var (
+	slugPattern   = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+	slugSeparator = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+// ValidSlug reports whether slug is lowercase letters and digits in hyphen-separated words
+func ValidSlug(slug string) bool {
+	return len(slug) <= MaxSlugLength && slugPattern.MatchString(slug)
+}
+
+// Slugify turns a topic name into a slug: "Product News & Updates" becomes "product-news-updates". A name
+// without letters or digits gives "topic".
+func Slugify(name string) string {
+	slug := slugSeparator.ReplaceAllString(strings.ToLower(name), "-")
+	if len(slug) > slugBaseLength {
+		slug = slug[:slugBaseLength]
+	}
+	if slug = strings.Trim(slug, "-"); slug == "" {
+		return "topic"
+	}
+	return slug
+}
+
+// AvailableSlug returns the slug of name, numbered from 2 when a topic of any organization, deleted ones
+// included, already has it. The lookup is raw SQL so it isn't narrowed to the organization of tx's context.
+func AvailableSlug(tx *gorm.DB, name string) (string, error) {
+	base := Slugify(name)
+	var taken []string
+	err := tx.Session(&gorm.Session{NewDB: true}).
+		Raw("SELECT slug FROM topics WHERE slug = ? OR slug LIKE ?", base, base+"-%").
+		Scan(&taken).Error
+	if err != nil {
+		return "", fmt.Errorf("failed to check topic slugs: %w", err)
+	}
+
+	used := make(map[string]bool, len(taken))
+	for _, slug := range taken {
+		used[slug] = true
+	}
+	slug := base
+	for n := 2; used[slug]; n++ {
+		slug = fmt.Sprintf("%s-%d", base, n)
+	}
+	return slug, nil
+}
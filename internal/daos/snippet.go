@@ -0,0 +1,29 @@
+package daos
+
+import "time"
+
+// Snippet formats
+const (
+	SnippetFormatHTML     = "html"
+	SnippetFormatMarkdown = "markdown"
+)
+
+// Snippet is a named block of HTML or Markdown, such as a sponsor block or signature, that content bodies
+// include with {{snippet "name"}}. Including bodies pick up edits the next time they are sent.
+type Snippet struct {
+	ID          uint      `json:"id" gorm:"primarykey"`
+	Name        string    `json:"name" gorm:"uniqueIndex:idx_snippets_organization_name,priority:2;size:100;not null"`
+	Format      string    `json:"format" gorm:"size:20;not null;default:'html'"`
+	Body        string    `json:"body" gorm:"type:text;not null"`
+	Description string    `json:"description" gorm:"size:255;not null;default:''"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+
+	// Names are unique within the organization
+	OrganizationID uint `json:"organization_id" gorm:"uniqueIndex:idx_snippets_organization_name,priority:1;not null;default:1"`
+}
+
+// TableName returns the table name for Snippet
+func (Snippet) TableName() string {
+	return "snippets"
+}
@@ -0,0 +1,61 @@
+package daos
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Positions of a sponsor block in the content body
+const (
+	SponsorPositionTop    = "top"
+	SponsorPositionBottom = "bottom"
+)
+
+// Sponsor is an advertiser whose block paid placements put into content
+type Sponsor struct {
+	ID           uint   `json:"id" gorm:"primarykey"`
+	Name         string `json:"name" gorm:"size:100;not null"`
+	URL          string `json:"url" gorm:"size:2048;not null"`
+	LogoURL      string `json:"logo_url" gorm:"size:2048;not null;default:''"`
+	Message      string `json:"message" gorm:"type:text;not null;default:''"` // Copy of the block, unless a slot has its own
+	ContactEmail string `json:"contact_email" gorm:"size:255;not null;default:''"`
+
+	OrganizationID uint           `json:"organization_id" gorm:"not null;default:1;index"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+// TableName returns the table name for Sponsor
+func (Sponsor) TableName() string {
+	return "sponsors"
+}
+
+// SponsorSlot is a placement a sponsor booked, either in one content or in the first content a topic sends on
+// a date. A dated slot is given the content it appears in when that content is sent.
+type SponsorSlot struct {
+	ID        uint       `json:"id" gorm:"primarykey"`
+	SponsorID uint       `json:"sponsor_id" gorm:"not null;index"`
+	TopicID   uint       `json:"topic_id" gorm:"not null;uniqueIndex:idx_sponsor_slots_topic_date,priority:1"`
+	ContentID *uint      `json:"content_id" gorm:"uniqueIndex"`                                             // At most one sponsor per content
+	Date      *time.Time `json:"date" gorm:"type:date;uniqueIndex:idx_sponsor_slots_topic_date,priority:2"` // Nil for slots booked in a content
+	Position  string     `json:"position" gorm:"size:10;not null;default:'top'"`
+	Message   string     `json:"message" gorm:"type:text;not null;default:''"` // Replaces the sponsor's message in this placement
+
+	// What the sponsor pays for the placement, for reports
+	PriceCents int64  `json:"price_cents" gorm:"not null;default:0"`
+	Currency   string `json:"currency" gorm:"size:3;not null;default:''"`
+
+	// When the content carrying the block was first sent; served slots can't be canceled
+	ServedAt *time.Time `json:"served_at"`
+
+	OrganizationID uint      `json:"organization_id" gorm:"not null;default:1;index"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// TableName returns the table name for SponsorSlot
+func (SponsorSlot) TableName() string {
+	return "sponsor_slots"
+}
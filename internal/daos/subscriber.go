@@ -10,12 +10,54 @@ import (
 type Subscriber struct {
 	ID        uint           `json:"id" gorm:"primarykey"`
 	Name      string         `json:"name" gorm:"size:100;not null"`
-	Email     string         `json:"email" gorm:"uniqueIndex;size:255;not null"`
+	Email     string         `json:"email" gorm:"uniqueIndex:idx_subscribers_organization_email,priority:2;size:255;not null"`
 	IsActive  bool           `json:"is_active" gorm:"default:true;not null"`
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
 
+	// Addresses are unique within the organization
+	OrganizationID uint `json:"organization_id" gorm:"uniqueIndex:idx_subscribers_organization_email,priority:1;not null;default:1"`
+
+	// Lowercased with any +tag removed from the local part; used to detect duplicate addresses
+	NormalizedEmail string `json:"-" gorm:"size:255;index"`
+
+	// IANA time zone (e.g. "Europe/Berlin") used for local-time sends; empty uses [subscribers] default_timezone
+	Timezone string `json:"timezone" gorm:"size:64"`
+
+	// BCP 47 language tag (e.g. "pt-BR") choosing which of a content's translations the subscriber receives
+	Locale string `json:"locale" gorm:"size:35;not null;default:''"`
+
+	// Phone number in E.164 format (e.g. "+4915112345678"), needed for SMS
+	Phone string `json:"phone" gorm:"size:20"`
+
+	// Channels the subscriber accepts, comma-separated: "email", "sms"
+	Channels string `json:"channels" gorm:"size:50;default:email;not null"`
+
+	// Deliverability of the address, set by the email check on create
+	EmailStatus    string     `json:"email_status" gorm:"size:20;default:unverified;index"` // unverified, pending, valid, risky or invalid
+	EmailCheckedAt *time.Time `json:"email_checked_at"`
+
+	// Engagement, recalculated by the worker
+	EngagementScore float64    `json:"engagement_score" gorm:"default:0;index"` // 0-100, from open rate and recency
+	LastEngagedAt   *time.Time `json:"last_engaged_at"`
+	SunsetAt        *time.Time `json:"sunset_at" gorm:"index"` // Set while the sunset policy has flagged or paused the subscriber
+
+	// Referrals: the code in the subscriber's referral link, how many readers it brought in that confirmed, and
+	// who referred the subscriber
+	ReferralCode  string `json:"referral_code" gorm:"size:16;uniqueIndex"`
+	ReferralCount int    `json:"referral_count" gorm:"default:0;not null;index"`
+	ReferredByID  *uint  `json:"referred_by_id,omitempty" gorm:"index"`
+
+	// Attribution recorded when the subscriber signed up, so growth experiments can be measured. UTM holds the
+	// campaign tagging of the signup page; empty fields weren't reported.
+	SignupSource   string `json:"signup_source" gorm:"size:32;not null;default:'';index"` // How the subscriber was created: api, bulk, grpc or referral
+	SignupForm     string `json:"signup_form" gorm:"size:100;not null;default:'';index"`  // Form or placement named by the client, e.g. "homepage-footer"
+	SignupAPIKeyID *uint  `json:"signup_api_key_id,omitempty" gorm:"index"`               // API key the signup was made with
+	SignupReferrer string `json:"signup_referrer" gorm:"size:500;not null;default:''"`    // Page the reader came from
+	SignupCountry  string `json:"signup_country" gorm:"size:2;not null;default:'';index"` // ISO 3166-1 alpha-2 code of the reader's IP address
+	UTM            UTM    `json:"utm" gorm:"embedded;embeddedPrefix:utm_"`
+
 	// Relationships
 	Subscriptions []Subscription `json:"subscriptions,omitempty" gorm:"foreignKey:SubscriberID"`
 	EmailLogs     []EmailLog     `json:"email_logs,omitempty" gorm:"foreignKey:SubscriberID"`
@@ -25,3 +67,16 @@ type Subscriber struct {
 func (Subscriber) TableName() string {
 	return "subscribers"
 }
+
+// BeforeCreate gives every new subscriber a referral code, however it is created
+func (s *Subscriber) BeforeCreate(tx *gorm.DB) error {
+	if s.ReferralCode != "" {
+		return nil
+	}
+	code, err := NewReferralCode()
+	if err != nil {
+		return err
+	}
+	s.ReferralCode = code
+	return nil
+}
@@ -6,15 +6,22 @@ import (
 	"gorm.io/gorm"
 )
 
-// Subscription represents a subscription relationship between subscriber and topic
+// Subscription represents a subscription relationship between subscriber and topic. A subscriber has at most
+// one live subscription per topic; unsubscribing soft-deletes it, so resubscribing creates a new one.
 type Subscription struct {
 	ID           uint           `json:"id" gorm:"primarykey"`
-	SubscriberID uint           `json:"subscriber_id" gorm:"not null;index"`
-	TopicID      uint           `json:"topic_id" gorm:"not null;index"`
+	SubscriberID uint           `json:"subscriber_id" gorm:"not null;index;uniqueIndex:idx_subscriptions_subscriber_topic,priority:1,where:deleted_at IS NULL"`
+	TopicID      uint           `json:"topic_id" gorm:"not null;index;uniqueIndex:idx_subscriptions_subscriber_topic,priority:2,where:deleted_at IS NULL"`
 	CreatedAt    time.Time      `json:"created_at"`
 	UpdatedAt    time.Time      `json:"updated_at"`
 	DeletedAt    gorm.DeletedAt `json:"-" gorm:"index"`
 
+	// Same as the subscriber's and topic's, so subscriptions can be listed per organization
+	OrganizationID uint `json:"organization_id" gorm:"not null;default:1;index"`
+
+	// Whether content on the topic is also pushed to the subscriber's registered devices
+	Push bool `json:"push" gorm:"default:false;not null"`
+
 	// Relationships
 	Subscriber *Subscriber `json:"subscriber,omitempty" gorm:"foreignKey:SubscriberID"`
 	Topic      *Topic      `json:"topic,omitempty" gorm:"foreignKey:TopicID"`
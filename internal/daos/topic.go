@@ -9,12 +9,25 @@ import (
 // Topic represents a newsletter topic in the database
 type Topic struct {
 	ID          uint           `json:"id" gorm:"primarykey"`
-	Name        string         `json:"name" gorm:"uniqueIndex;size:100;not null"`
+	Name        string         `json:"name" gorm:"uniqueIndex:idx_topics_organization_name,priority:2;size:100;not null"`
+	Slug        string         `json:"slug" gorm:"uniqueIndex:idx_topics_slug;size:100;not null"` // Unique across organizations; addresses the landing page
 	Description string         `json:"description" gorm:"type:text"`
 	CreatedAt   time.Time      `json:"created_at"`
 	UpdatedAt   time.Time      `json:"updated_at"`
 	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
 
+	// Archived topics keep their subscriptions, content and logs but take no new content or subscribers
+	ArchivedAt *time.Time `json:"archived_at,omitempty" gorm:"index"`
+
+	// Names are unique within the organization
+	OrganizationID uint `json:"organization_id" gorm:"uniqueIndex:idx_topics_organization_name,priority:1;not null;default:1"`
+
+	// Overrides the organization's branding for this topic's emails
+	Branding Branding `json:"branding" gorm:"embedded;embeddedPrefix:branding_"`
+
+	// Tags the links of the topic's content
+	UTM UTM `json:"utm" gorm:"embedded;embeddedPrefix:utm_"`
+
 	// Relationships
 	Contents      []Content      `json:"contents,omitempty" gorm:"foreignKey:TopicID"`
 	Subscriptions []Subscription `json:"subscriptions,omitempty" gorm:"foreignKey:TopicID"`
@@ -24,3 +37,16 @@ type Topic struct {
 func (Topic) TableName() string {
 	return "topics"
 }
+
+// BeforeCreate gives a topic created without a slug one made from its name, however it is created
+func (t *Topic) BeforeCreate(tx *gorm.DB) error {
+	if t.Slug != "" {
+		return nil
+	}
+	slug, err := AvailableSlug(tx, t.Name)
+	if err != nil {
+		return err
+	}
+	t.Slug = slug
+	return nil
+}
@@ -0,0 +1,23 @@
+package daos
+
+// UTM is the campaign tagging added to the links in emails, so analytics attribute the traffic they bring.
+// A content's fields override its topic's; links already carrying a parameter keep their own value.
+type UTM struct {
+	Source   string `json:"source" gorm:"size:100;not null;default:''"`
+	Medium   string `json:"medium" gorm:"size:100;not null;default:''"`
+	Campaign string `json:"campaign" gorm:"size:100;not null;default:''"`
+}
+
+// Merge returns u with its empty fields taken from fallback
+func (u UTM) Merge(fallback UTM) UTM {
+	if u.Source == "" {
+		u.Source = fallback.Source
+	}
+	if u.Medium == "" {
+		u.Medium = fallback.Medium
+	}
+	if u.Campaign == "" {
+		u.Campaign = fallback.Campaign
+	}
+	return u
+}
@@ -0,0 +1,16 @@
+package daos
+
+import "time"
+
+// WarmupCounter counts the sends made under one warm-up policy on one UTC day
+type WarmupCounter struct {
+	PolicyKey string    `json:"policy_key" gorm:"primaryKey;size:255"`
+	Day       time.Time `json:"day" gorm:"primaryKey;type:date"`
+	Sent      int       `json:"sent" gorm:"not null;default:0"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName returns the table name for WarmupCounter
+func (WarmupCounter) TableName() string {
+	return "warmup_counters"
+}
@@ -0,0 +1,49 @@
+package daos
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Webhook is an admin-registered endpoint that receives signed domain events.
+// Events is a comma-separated list of subscribed event types.
+type Webhook struct {
+	ID          uint           `json:"id" gorm:"primarykey"`
+	URL         string         `json:"url" gorm:"size:2048;not null"`
+	Secret      string         `json:"-" gorm:"size:128;not null"`
+	Events      string         `json:"events" gorm:"type:text;not null"`
+	Description string         `json:"description" gorm:"size:255"`
+	IsActive    bool           `json:"is_active" gorm:"default:true;index"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+// TableName returns the table name for Webhook
+func (Webhook) TableName() string {
+	return "webhooks"
+}
+
+// WebhookDelivery is one attempt series to deliver an event to a webhook
+type WebhookDelivery struct {
+	ID             uint       `json:"id" gorm:"primarykey"`
+	WebhookID      uint       `json:"webhook_id" gorm:"not null;index"`
+	EventID        string     `json:"event_id" gorm:"size:64;not null;index"`
+	EventType      string     `json:"event_type" gorm:"size:100;not null"`
+	Payload        string     `json:"payload" gorm:"type:jsonb;not null"`
+	Status         string     `json:"status" gorm:"size:20;not null;index:idx_webhook_deliveries_due"`
+	Attempts       int        `json:"attempts" gorm:"default:0"`
+	ResponseStatus *int       `json:"response_status"`
+	ResponseBody   *string    `json:"response_body" gorm:"type:text"`
+	ErrorMessage   *string    `json:"error_message" gorm:"type:text"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at" gorm:"index:idx_webhook_deliveries_due"`
+	DeliveredAt    *time.Time `json:"delivered_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// TableName returns the table name for WebhookDelivery
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
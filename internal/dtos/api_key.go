@@ -0,0 +1,37 @@
+package dtos
+
+import "time"
+
+// APIKeyRateLimit is a per-key token bucket overriding the configured route rules
+type APIKeyRateLimit struct {
+	BucketSize    int `json:"bucket_size" validate:"required,min=1"`
+	RefillSize    int `json:"refill_size" validate:"omitempty,min=1"`    // Defaults to bucket_size
+	RefillSeconds int `json:"refill_seconds" validate:"omitempty,min=1"` // Defaults to 60
+}
+
+type CreateAPIKeyRequest struct {
+	Name      string           `json:"name" validate:"required,max=100"`
+	RateLimit *APIKeyRateLimit `json:"rate_limit"`
+}
+
+type UpdateAPIKeyRequest struct {
+	Name           string           `json:"name" validate:"omitempty,max=100"`
+	RateLimit      *APIKeyRateLimit `json:"rate_limit"`
+	ClearRateLimit bool             `json:"clear_rate_limit"` // Revert the key to the configured rules
+}
+
+type APIKeyResponse struct {
+	ID        uint             `json:"id"`
+	Name      string           `json:"name"`
+	KeyPrefix string           `json:"key_prefix"`
+	RateLimit *APIKeyRateLimit `json:"rate_limit,omitempty"`
+	RevokedAt *time.Time       `json:"revoked_at,omitempty"`
+	CreatedAt time.Time        `json:"created_at"`
+	UpdatedAt time.Time        `json:"updated_at"`
+}
+
+// CreateAPIKeyResponse includes the plaintext key, which is only ever returned once
+type CreateAPIKeyResponse struct {
+	APIKeyResponse
+	Key string `json:"key"`
+}
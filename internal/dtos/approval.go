@@ -0,0 +1,38 @@
+package dtos
+
+import "time"
+
+type SubmitContentRequest struct {
+	Reviewer string `json:"reviewer" validate:"omitempty,max=255"` // Optional; any reviewer may decide when unset
+	Comment  string `json:"comment"`
+}
+
+type AssignReviewerRequest struct {
+	Reviewer string `json:"reviewer" validate:"required,max=255"`
+}
+
+type ApprovalDecisionRequest struct {
+	Comment string `json:"comment"` // Required to reject
+}
+
+type ApprovalCommentRequest struct {
+	Comment string `json:"comment" validate:"required"`
+}
+
+// ApprovalStateResponse is where a content is in its review after a submission, assignment or decision
+type ApprovalStateResponse struct {
+	ContentID     uint   `json:"content_id"`
+	ApprovalState string `json:"approval_state"`
+	Reviewer      string `json:"reviewer,omitempty"`
+}
+
+type ApprovalEventResponse struct {
+	ID        uint      `json:"id"`
+	Action    string    `json:"action"`
+	FromState string    `json:"from_state,omitempty"`
+	ToState   string    `json:"to_state,omitempty"`
+	Actor     string    `json:"actor"`
+	Reviewer  string    `json:"reviewer,omitempty"`
+	Comment   string    `json:"comment,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
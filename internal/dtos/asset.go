@@ -0,0 +1,14 @@
+package dtos
+
+import "time"
+
+// AssetResponse describes an uploaded image; URL is what content links to
+type AssetResponse struct {
+	ID          uint      `json:"id"`
+	Key         string    `json:"key"`
+	URL         string    `json:"url"`
+	Filename    string    `json:"filename"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	CreatedAt   time.Time `json:"created_at"`
+}
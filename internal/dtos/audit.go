@@ -0,0 +1,12 @@
+package dtos
+
+// AuditLogQuery represents filters for listing audit logs
+type AuditLogQuery struct {
+	PaginationRequest
+	Actor      string `form:"actor"`
+	Action     string `form:"action" binding:"omitempty,oneof=create update delete publish revoke restore"`
+	EntityType string `form:"entity_type"`
+	EntityID   uint   `form:"entity_id"`
+	From       string `form:"from"` // RFC3339 timestamp
+	To         string `form:"to"`   // RFC3339 timestamp
+}
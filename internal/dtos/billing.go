@@ -0,0 +1,79 @@
+package dtos
+
+import "time"
+
+type CreatePlanRequest struct {
+	TopicID       uint   `json:"topic_id" validate:"required"`
+	Name          string `json:"name" validate:"required,max=100"`
+	Description   string `json:"description" validate:"omitempty,max=1000"`
+	StripePriceID string `json:"stripe_price_id" validate:"required,max=255"` // The recurring Stripe price Checkout charges, "price_..."
+	// What the price charges, shown to readers choosing a plan; Stripe bills whatever the price says
+	AmountCents int64  `json:"amount_cents" validate:"min=0"`
+	Currency    string `json:"currency" validate:"omitempty,len=3"`
+	Interval    string `json:"interval" validate:"omitempty,oneof=day week month year"`
+	Active      *bool  `json:"active"` // Defaults to true
+}
+
+type UpdatePlanRequest struct {
+	Name          string  `json:"name" validate:"omitempty,max=100"`
+	Description   *string `json:"description" validate:"omitempty,max=1000"`
+	StripePriceID string  `json:"stripe_price_id" validate:"omitempty,max=255"` // Only new checkouts pay the new price
+	AmountCents   *int64  `json:"amount_cents" validate:"omitempty,min=0"`
+	Currency      string  `json:"currency" validate:"omitempty,len=3"`
+	Interval      string  `json:"interval" validate:"omitempty,oneof=day week month year"`
+	Active        *bool   `json:"active"` // false stops new checkouts; subscribers already paying stay entitled
+}
+
+type PlanResponse struct {
+	ID            uint      `json:"id"`
+	TopicID       uint      `json:"topic_id"`
+	Name          string    `json:"name"`
+	Description   string    `json:"description"`
+	StripePriceID string    `json:"stripe_price_id"`
+	AmountCents   int64     `json:"amount_cents"`
+	Currency      string    `json:"currency"`
+	Interval      string    `json:"interval"`
+	Active        bool      `json:"active"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// PlanQuery lists plans, those of one topic when topic_id is set
+type PlanQuery struct {
+	PaginationRequest
+	TopicID uint `form:"topic_id"`
+}
+
+type CreateCheckoutRequest struct {
+	SubscriberID uint `json:"subscriber_id" validate:"required"`
+}
+
+type CheckoutResponse struct {
+	SessionID string `json:"session_id"`
+	URL       string `json:"url"` // Send the subscriber here to pay
+}
+
+// BillingSubscriptionQuery filters the paid subscriptions listed
+type BillingSubscriptionQuery struct {
+	PaginationRequest
+	SubscriberID uint   `form:"subscriber_id"`
+	TopicID      uint   `form:"topic_id"`
+	Status       string `form:"status" binding:"omitempty,oneof=incomplete trialing active past_due unpaid canceled"`
+}
+
+type BillingSubscriptionResponse struct {
+	ID                   uint       `json:"id"`
+	SubscriberID         uint       `json:"subscriber_id"`
+	PlanID               uint       `json:"plan_id"`
+	TopicID              uint       `json:"topic_id"`
+	StripeCustomerID     string     `json:"stripe_customer_id"`
+	StripeSubscriptionID string     `json:"stripe_subscription_id"`
+	Status               string     `json:"status"`
+	Entitled             bool       `json:"entitled"` // Receives the topic's premium content
+	CurrentPeriodEnd     *time.Time `json:"current_period_end"`
+	CancelAtPeriodEnd    bool       `json:"cancel_at_period_end"`
+	PaymentFailedAt      *time.Time `json:"payment_failed_at"`
+	DunningEmailsSent    int        `json:"dunning_emails_sent"`
+	CreatedAt            time.Time  `json:"created_at"`
+	UpdatedAt            time.Time  `json:"updated_at"`
+}
@@ -0,0 +1,43 @@
+package dtos
+
+// Branding is the sender identity and look of an organization's or topic's emails. Empty fields fall back to
+// the organization's branding for topics, and to the provider's from address and the default template.
+type Branding struct {
+	FromName     string `json:"from_name" validate:"omitempty,max=100"`
+	FromAddress  string `json:"from_address" validate:"omitempty,email,max=255"`
+	ReplyTo      string `json:"reply_to" validate:"omitempty,email,max=255"`
+	LogoURL      string `json:"logo_url" validate:"omitempty,url,max=2048"`
+	PrimaryColor string `json:"primary_color" validate:"omitempty,hexcolor,max=7"`
+	FooterText   string `json:"footer_text" validate:"omitempty,max=1000"`
+
+	// Theme; dark mode values apply in clients set to a dark color scheme
+	DarkLogoURL         string `json:"dark_logo_url" validate:"omitempty,url,max=2048"`
+	BackgroundColor     string `json:"background_color" validate:"omitempty,hexcolor,max=7"`
+	TextColor           string `json:"text_color" validate:"omitempty,hexcolor,max=7"`
+	FontFamily          string `json:"font_family" validate:"omitempty,max=200,excludesall=;:{}()<>\\/"`
+	DarkPrimaryColor    string `json:"dark_primary_color" validate:"omitempty,hexcolor,max=7"`
+	DarkBackgroundColor string `json:"dark_background_color" validate:"omitempty,hexcolor,max=7"`
+	DarkTextColor       string `json:"dark_text_color" validate:"omitempty,hexcolor,max=7"`
+}
+
+// Theme is the look of a topic's emails: the visual part of its branding. Empty fields use the organization's
+// theme, then the template's defaults.
+type Theme struct {
+	LogoURL             string `json:"logo_url" validate:"omitempty,url,max=2048"`
+	DarkLogoURL         string `json:"dark_logo_url" validate:"omitempty,url,max=2048"`
+	PrimaryColor        string `json:"primary_color" validate:"omitempty,hexcolor,max=7"`
+	BackgroundColor     string `json:"background_color" validate:"omitempty,hexcolor,max=7"`
+	TextColor           string `json:"text_color" validate:"omitempty,hexcolor,max=7"`
+	FontFamily          string `json:"font_family" validate:"omitempty,max=200,excludesall=;:{}()<>\\/"`
+	DarkPrimaryColor    string `json:"dark_primary_color" validate:"omitempty,hexcolor,max=7"`
+	DarkBackgroundColor string `json:"dark_background_color" validate:"omitempty,hexcolor,max=7"`
+	DarkTextColor       string `json:"dark_text_color" validate:"omitempty,hexcolor,max=7"`
+}
+
+// ThemeResponse is a topic's own theme and the one its emails render with, after the organization's theme and
+// the defaults fill the gaps
+type ThemeResponse struct {
+	TopicID   uint  `json:"topic_id"`
+	Theme     Theme `json:"theme"`
+	Effective Theme `json:"effective"`
+}
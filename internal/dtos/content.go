@@ -3,24 +3,82 @@ package dtos
 import "time"
 
 type CreateContentRequest struct {
-	TopicID uint   `json:"topic_id" validate:"required"`
-	Title   string `json:"title" validate:"required,max=255"`
-	Body    string `json:"body" validate:"required"`
+	TopicID       uint       `json:"topic_id" validate:"required"`
+	Title         string     `json:"title" validate:"required,max=255"`
+	Body          string     `json:"body" validate:"required"`
+	PreviewText   string     `json:"preview_text" validate:"omitempty,max=255"`           // Inbox preview shown after the subject, hidden in the email
+	Locale        string     `json:"locale" validate:"omitempty,max=35"`                  // Language of the title and body, such as en or pt-BR
+	SendAt        *time.Time `json:"send_at" validate:"omitempty"`                        // Not sent before this, even once published
+	LocalSendTime string     `json:"local_send_time" validate:"omitempty,datetime=15:04"` // Deliver at this time in each subscriber's time zone, on the send_at date
+	// Channels to deliver on, email when omitted. Push goes to subscribers who turned it on for the topic.
+	Channels []string `json:"channels" validate:"omitempty,dive,oneof=email sms push"`
+	// Link tags overriding the topic's field by field
+	UTM *UTM `json:"utm"`
+	// Only sent to subscribers paying for one of the topic's plans
+	Premium bool `json:"premium"`
+	// How urgently it is sent, normal when omitted: high priority content is dispatched ahead of the rest
+	Priority string `json:"priority" validate:"omitempty,oneof=high normal low"`
 }
 
 type UpdateContentRequest struct {
-	TopicID uint   `json:"topic_id" validate:"omitempty"`
-	Title   string `json:"title" validate:"omitempty,max=255"`
-	Body    string `json:"body" validate:"omitempty"`
+	TopicID       uint       `json:"topic_id" validate:"omitempty"`
+	Title         string     `json:"title" validate:"omitempty,max=255"`
+	Body          string     `json:"body" validate:"omitempty"`
+	PreviewText   *string    `json:"preview_text" validate:"omitempty,max=255"` // Empty clears it
+	Locale        string     `json:"locale" validate:"omitempty,max=35"`
+	SendAt        *time.Time `json:"send_at" validate:"omitempty"`
+	LocalSendTime string     `json:"local_send_time" validate:"omitempty,datetime=15:04"`
+	Channels      []string   `json:"channels" validate:"omitempty,dive,oneof=email sms push"`
+	UTM           *UTM       `json:"utm"` // Replaces the content's link tags
+	Premium       *bool      `json:"premium"`
+	Priority      string     `json:"priority" validate:"omitempty,oneof=high normal low"`
 }
 
 type ContentResponse struct {
-	ID          uint       `json:"id"`
-	TopicID     uint       `json:"topic_id"`
-	Title       string     `json:"title"`
-	Body        string     `json:"body"`
-	IsPublished bool       `json:"is_published"`
-	PublishedAt *time.Time `json:"published_at"`
-	CreatedAt   time.Time  `json:"created_at"`
-	UpdatedAt   time.Time  `json:"updated_at"`
+	ID            uint              `json:"id"`
+	TopicID       uint              `json:"topic_id"`
+	Title         string            `json:"title"`
+	Body          string            `json:"body"`
+	PreviewText   string            `json:"preview_text,omitempty"`
+	Locale        string            `json:"locale,omitempty"`
+	IsPublished   bool              `json:"is_published"`
+	PublishedAt   *time.Time        `json:"published_at"`
+	ApprovalState string            `json:"approval_state"`
+	Reviewer      string            `json:"reviewer,omitempty"`
+	SendAt        *time.Time        `json:"send_at,omitempty"`
+	LocalSendTime string            `json:"local_send_time,omitempty"`
+	Channels      []string          `json:"channels"`
+	UTM           UTM               `json:"utm"`
+	Premium       bool              `json:"premium"`
+	Priority      string            `json:"priority"`
+	Delivery      *DeliveryResponse `json:"delivery,omitempty"` // Once the content was sent
+	Topic         *TopicResponse    `json:"topic,omitempty"`    // Only with include=topic
+	CreatedAt     time.Time         `json:"created_at"`
+	UpdatedAt     time.Time         `json:"updated_at"`
+	DeletedAt     *time.Time        `json:"deleted_at,omitempty"`
+}
+
+// DeliveryResponse is a sent content's delivery as last counted by the worker
+type DeliveryResponse struct {
+	State       string     `json:"state"`
+	Sent        int64      `json:"sent"`
+	Failed      int64      `json:"failed"`
+	Outstanding int64      `json:"outstanding"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+// SetContentTranslationRequest is a content's title, body and preview text in the locale named in the path
+type SetContentTranslationRequest struct {
+	Title       string `json:"title" validate:"required,max=255"`
+	Body        string `json:"body" validate:"required"`
+	PreviewText string `json:"preview_text" validate:"omitempty,max=255"`
+}
+
+type ContentTranslationResponse struct {
+	Locale      string    `json:"locale"`
+	Title       string    `json:"title"`
+	Body        string    `json:"body"`
+	PreviewText string    `json:"preview_text,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
 }
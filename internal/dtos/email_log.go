@@ -0,0 +1,18 @@
+package dtos
+
+// EmailLogQuery represents filters and sorting for listing email logs
+type EmailLogQuery struct {
+	PaginationRequest
+	Status       string `form:"status" binding:"omitempty,oneof=pending sent failed"`
+	ContentID    uint   `form:"content_id"`
+	SubscriberID uint   `form:"subscriber_id"`
+	Email        string `form:"email" binding:"omitempty,max=255"`
+	Provider     string `form:"provider" binding:"omitempty,max=100"`
+	MessageID    string `form:"message_id" binding:"omitempty,max=255"`
+	Channel      string `form:"channel" binding:"omitempty,oneof=email sms push"`
+	From         string `form:"from"` // RFC3339 timestamp
+	To           string `form:"to"`   // RFC3339 timestamp
+	Sort         string `form:"sort" binding:"omitempty,oneof=id created_at sent_at status retry_count"`
+	Order        string `form:"order" binding:"omitempty,oneof=asc desc"`
+	Archived     bool   `form:"archived"` // List logs moved to the archive by the email_log_archive job
+}
@@ -0,0 +1,5 @@
+package dtos
+
+type UpdateEmailTemplateRequest struct {
+	Body string `json:"body" validate:"required"` // Go html/template text using the fields of the built-in template
+}
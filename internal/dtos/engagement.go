@@ -0,0 +1,22 @@
+package dtos
+
+import "time"
+
+// EngagementSegmentQuery represents filters for listing subscribers by engagement
+type EngagementSegmentQuery struct {
+	PaginationRequest
+	MinScore *float64 `form:"min_score" binding:"omitempty,min=0,max=100"`
+	MaxScore *float64 `form:"max_score" binding:"omitempty,min=0,max=100"`
+	Sunset   *bool    `form:"sunset"` // true: flagged by the sunset policy, false: not flagged
+	SignupFilterQuery
+}
+
+type SubscriberEngagementResponse struct {
+	ID              uint       `json:"id"`
+	Email           string     `json:"email"`
+	Name            string     `json:"name"`
+	IsActive        bool       `json:"is_active"`
+	EngagementScore float64    `json:"engagement_score"`
+	LastEngagedAt   *time.Time `json:"last_engaged_at"`
+	SunsetAt        *time.Time `json:"sunset_at"`
+}
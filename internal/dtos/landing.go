@@ -0,0 +1,12 @@
+package dtos
+
+// LandingSignupForm is posted by the subscribe form of a topic's landing page
+type LandingSignupForm struct {
+	Email       string `form:"email" binding:"required,email,max=255"`
+	Name        string `form:"name" binding:"max=100"`
+	Referrer    string `form:"referrer"` // Page the reader came from before the landing page
+	UTMSource   string `form:"utm_source"`
+	UTMMedium   string `form:"utm_medium"`
+	UTMCampaign string `form:"utm_campaign"`
+	Website     string `form:"website"` // Honeypot: hidden from readers, so only bots fill it in
+}
@@ -0,0 +1,22 @@
+package dtos
+
+import "time"
+
+type CreateOrganizationRequest struct {
+	Name     string    `json:"name" validate:"required,max=100"`
+	Branding *Branding `json:"branding"`
+}
+
+// UpdateOrganizationRequest renames an organization and/or replaces its branding
+type UpdateOrganizationRequest struct {
+	Name     string    `json:"name" validate:"omitempty,max=100"`
+	Branding *Branding `json:"branding"`
+}
+
+type OrganizationResponse struct {
+	ID        uint      `json:"id"`
+	Name      string    `json:"name"`
+	Branding  Branding  `json:"branding"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -1,6 +1,10 @@
 package dtos
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
+
 	"newsletter-service/internal/constants"
 )
 
@@ -10,6 +14,40 @@ type PaginationRequest struct {
 	PageSize int `form:"page_size" json:"page_size" binding:"omitempty,min=1,max=100"` // Items per page (max 100)
 }
 
+// ListQuery represents list parameters for resources that are soft-deleted
+type ListQuery struct {
+	PaginationRequest
+	IncludeDeleted bool   `form:"include_deleted" json:"include_deleted"` // Also list soft-deleted rows (always paginated)
+	IDs            string `form:"ids" json:"ids"`                         // Comma-separated IDs to fetch in one request
+}
+
+// ErrInvalidIDs is returned for an ids parameter that isn't a list of at most MaxPageSize positive integers
+var ErrInvalidIDs = fmt.Errorf("ids must be a comma-separated list of at most %d positive integers", constants.MaxPageSize)
+
+// ParseIDs returns the IDs asked for, without repeats and in the order given, or nil when none were
+func (q *ListQuery) ParseIDs() ([]uint, error) {
+	if strings.TrimSpace(q.IDs) == "" {
+		return nil, nil
+	}
+
+	var ids []uint
+	seen := make(map[uint]bool)
+	for _, part := range strings.Split(q.IDs, ",") {
+		id, err := strconv.ParseUint(strings.TrimSpace(part), 10, 32)
+		if err != nil || id == 0 {
+			return nil, fmt.Errorf("%w: invalid id %q", ErrInvalidIDs, part)
+		}
+		if !seen[uint(id)] {
+			seen[uint(id)] = true
+			ids = append(ids, uint(id))
+		}
+	}
+	if len(ids) > constants.MaxPageSize {
+		return nil, ErrInvalidIDs
+	}
+	return ids, nil
+}
+
 // PaginationResponse represents pagination metadata
 type PaginationResponse struct {
 	Page       int   `json:"page"`        // Current page number
@@ -0,0 +1,46 @@
+package dtos
+
+import "time"
+
+type CreatePollRequest struct {
+	Question string     `json:"question" validate:"required,max=255"`
+	Options  []string   `json:"options" validate:"required,min=2,max=10,dive,required,max=100"` // Labels, in the order shown
+	ClosesAt *time.Time `json:"closes_at"`                                                      // Answers after this are turned away
+}
+
+type UpdatePollRequest struct {
+	Question string     `json:"question" validate:"omitempty,max=255"`
+	ClosesAt *time.Time `json:"closes_at"`
+}
+
+type PollOptionResponse struct {
+	ID       uint   `json:"id"`
+	Label    string `json:"label"`
+	Position int    `json:"position"`
+}
+
+type PollResponse struct {
+	ID        uint                 `json:"id"`
+	ContentID uint                 `json:"content_id"`
+	Question  string               `json:"question"`
+	Include   string               `json:"include"` // What to write in the content's body where the poll goes
+	ClosesAt  *time.Time           `json:"closes_at"`
+	Options   []PollOptionResponse `json:"options"`
+	CreatedAt time.Time            `json:"created_at"`
+	UpdatedAt time.Time            `json:"updated_at"`
+}
+
+type PollOptionResultResponse struct {
+	PollOptionResponse
+	Responses int64 `json:"responses"`
+}
+
+// PollResultsResponse is a poll with how many subscribers chose each option
+type PollResultsResponse struct {
+	ID        uint                       `json:"id"`
+	ContentID uint                       `json:"content_id"`
+	Question  string                     `json:"question"`
+	ClosesAt  *time.Time                 `json:"closes_at"`
+	Options   []PollOptionResultResponse `json:"options"`
+	Responses int64                      `json:"responses"`
+}
@@ -0,0 +1,26 @@
+package dtos
+
+// UpdatePreferencesRequest sets how often a subscriber receives topics on each channel. Topics and channels
+// left out keep their frequency.
+type UpdatePreferencesRequest struct {
+	Preferences []PreferenceItem `json:"preferences" validate:"required,min=1,dive"`
+}
+
+// PreferenceItem is the frequency for one topic on one channel. Daily and weekly digests are email only.
+type PreferenceItem struct {
+	TopicID   uint   `json:"topic_id" validate:"required"`
+	Channel   string `json:"channel" validate:"required,oneof=email sms push"`
+	Frequency string `json:"frequency" validate:"required,oneof=instant daily weekly off"`
+}
+
+type PreferenceResponse struct {
+	TopicID   uint   `json:"topic_id"`
+	TopicName string `json:"topic_name"`
+	Channel   string `json:"channel"`
+	Frequency string `json:"frequency"`
+}
+
+type PreferencesResponse struct {
+	SubscriberID uint                 `json:"subscriber_id"`
+	Preferences  []PreferenceResponse `json:"preferences"`
+}
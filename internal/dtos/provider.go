@@ -0,0 +1,16 @@
+package dtos
+
+import "time"
+
+// PauseProviderRequest is why an email provider is paused; the body is optional
+type PauseProviderRequest struct {
+	Reason string `json:"reason" validate:"omitempty,max=1000"`
+}
+
+type ProviderPauseResponse struct {
+	Provider  string    `json:"provider"`
+	Reason    string    `json:"reason,omitempty"`
+	PausedBy  string    `json:"paused_by,omitempty"`
+	PausedAt  time.Time `json:"paused_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,25 @@
+package dtos
+
+import "time"
+
+// RegisterPushDeviceRequest registers an FCM registration token, or a browser's Web Push subscription: its
+// endpoint as the token and its keys as returned by PushSubscription.toJSON()
+type RegisterPushDeviceRequest struct {
+	Platform string          `json:"platform" validate:"required,oneof=fcm webpush"`
+	Token    string          `json:"token" validate:"required,max=2048"`
+	Keys     *PushDeviceKeys `json:"keys" validate:"omitempty"`
+}
+
+type PushDeviceKeys struct {
+	P256dh string `json:"p256dh" validate:"required,max=128"`
+	Auth   string `json:"auth" validate:"required,max=64"`
+}
+
+type PushDeviceResponse struct {
+	ID           uint      `json:"id"`
+	SubscriberID uint      `json:"subscriber_id"`
+	Platform     string    `json:"platform"`
+	Token        string    `json:"token"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
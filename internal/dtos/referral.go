@@ -0,0 +1,25 @@
+package dtos
+
+// RedeemReferralRequest signs a reader up through a subscriber's referral link
+type RedeemReferralRequest struct {
+	Code  string `json:"code" validate:"required,max=16"`
+	Email string `json:"email" validate:"required,email,max=255"`
+	Name  string `json:"name" validate:"required,max=100"`
+}
+
+// LeaderboardQuery limits the referral leaderboard; 0 uses [referrals] leaderboard_size
+type LeaderboardQuery struct {
+	Limit int `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+type LeaderboardEntryResponse struct {
+	Rank          int    `json:"rank"` // Subscribers with the same count share a rank
+	SubscriberID  uint   `json:"subscriber_id"`
+	Name          string `json:"name"`
+	Email         string `json:"email"`
+	ReferralCount int    `json:"referral_count"`
+}
+
+type LeaderboardResponse struct {
+	Entries []LeaderboardEntryResponse `json:"entries"`
+}
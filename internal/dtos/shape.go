@@ -0,0 +1,163 @@
+package dtos
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ShapeQuery lets a read request trim and extend its response. Fields is a comma-separated list of the top-level
+// fields to return, all of them when empty; Include names related resources to embed, e.g. include=topics.
+type ShapeQuery struct {
+	Fields  string `form:"fields" json:"fields"`
+	Include string `form:"include" json:"include"`
+}
+
+// ErrUnknownField is returned for a fields or include entry the response doesn't have
+var ErrUnknownField = errors.New("unknown field")
+
+// Shape is a ShapeQuery checked against one response type
+type Shape struct {
+	fields   map[string]bool // nil when every field is returned
+	includes map[string]bool
+}
+
+// Parse checks the query against the JSON fields of response, a value of the response DTO, and includes, which
+// maps each include the endpoint offers to the response field it fills. Included fields are always returned,
+// even when fields doesn't list them.
+func (q ShapeQuery) Parse(response interface{}, includes map[string]string) (Shape, error) {
+	var shape Shape
+
+	for _, name := range splitList(q.Include) {
+		if _, ok := includes[name]; !ok {
+			return Shape{}, fmt.Errorf("%w %q in include; available: %s", ErrUnknownField, name, strings.Join(sortedKeys(includes), ", "))
+		}
+		if shape.includes == nil {
+			shape.includes = make(map[string]bool)
+		}
+		shape.includes[name] = true
+	}
+
+	requested := splitList(q.Fields)
+	if len(requested) == 0 {
+		return shape, nil
+	}
+	available := jsonFields(reflect.TypeOf(response))
+	shape.fields = make(map[string]bool, len(requested))
+	for _, name := range requested {
+		if !available[name] {
+			return Shape{}, fmt.Errorf("%w %q in fields; available: %s", ErrUnknownField, name, strings.Join(sortedKeys(available), ", "))
+		}
+		shape.fields[name] = true
+	}
+	for name := range shape.includes {
+		shape.fields[includes[name]] = true
+	}
+	return shape, nil
+}
+
+// Includes reports whether the related resource name should be embedded
+func (s Shape) Includes(name string) bool {
+	return s.includes[name]
+}
+
+// Select returns item with only the selected fields, or item itself when no fields were selected
+func (s Shape) Select(item interface{}) (interface{}, error) {
+	if s.fields == nil {
+		return item, nil
+	}
+
+	data, err := json.Marshal(item)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal response: %w", err)
+	}
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, fmt.Errorf("failed to select response fields: %w", err)
+	}
+
+	selected := make(map[string]json.RawMessage, len(s.fields))
+	for name, value := range all {
+		if s.fields[name] {
+			selected[name] = value
+		}
+	}
+	return selected, nil
+}
+
+// SelectAll applies Select to each item of a list. A nil list stays nil, so it's still written as null.
+func SelectAll[T any](s Shape, items []T) ([]interface{}, error) {
+	if items == nil {
+		return nil, nil
+	}
+	selected := make([]interface{}, len(items))
+	for i, item := range items {
+		value, err := s.Select(item)
+		if err != nil {
+			return nil, err
+		}
+		selected[i] = value
+	}
+	return selected, nil
+}
+
+// jsonFields returns the names a struct type is marshalled with, following embedded structs the way
+// encoding/json does
+func jsonFields(t reflect.Type) map[string]bool {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	fields := make(map[string]bool)
+	if t.Kind() != reflect.Struct {
+		return fields
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" {
+			for embedded := range jsonFields(field.Type) {
+				fields[embedded] = true
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = true
+	}
+	return fields
+}
+
+// splitList splits a comma-separated query value, dropping blanks
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	if len(keys) == 0 {
+		return []string{"none"}
+	}
+	return keys
+}
@@ -0,0 +1,27 @@
+package dtos
+
+import "time"
+
+type CreateSnippetRequest struct {
+	Name        string `json:"name" validate:"required,max=100"` // Included in content as {{snippet "name"}}
+	Format      string `json:"format" validate:"omitempty,oneof=html markdown"`
+	Body        string `json:"body" validate:"required"`
+	Description string `json:"description" validate:"omitempty,max=255"`
+}
+
+type UpdateSnippetRequest struct {
+	Name        string  `json:"name" validate:"omitempty,max=100"` // Content including the old name no longer gets the snippet
+	Format      string  `json:"format" validate:"omitempty,oneof=html markdown"`
+	Body        string  `json:"body"`
+	Description *string `json:"description" validate:"omitempty,max=255"`
+}
+
+type SnippetResponse struct {
+	ID          uint      `json:"id"`
+	Name        string    `json:"name"`
+	Format      string    `json:"format"`
+	Body        string    `json:"body"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
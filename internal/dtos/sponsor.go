@@ -0,0 +1,78 @@
+package dtos
+
+import "time"
+
+type CreateSponsorRequest struct {
+	Name         string `json:"name" validate:"required,max=100"`
+	URL          string `json:"url" validate:"required,url,max=2048"` // Where the block's links go
+	LogoURL      string `json:"logo_url" validate:"omitempty,url,max=2048"`
+	Message      string `json:"message" validate:"omitempty,max=1000"` // Plain text shown after the sponsor's name
+	ContactEmail string `json:"contact_email" validate:"omitempty,email,max=255"`
+}
+
+type UpdateSponsorRequest struct {
+	Name         string  `json:"name" validate:"omitempty,max=100"`
+	URL          string  `json:"url" validate:"omitempty,url,max=2048"`
+	LogoURL      *string `json:"logo_url" validate:"omitempty,max=2048"` // "" removes the logo
+	Message      *string `json:"message" validate:"omitempty,max=1000"`
+	ContactEmail *string `json:"contact_email" validate:"omitempty,max=255"`
+}
+
+type SponsorResponse struct {
+	ID           uint      `json:"id"`
+	Name         string    `json:"name"`
+	URL          string    `json:"url"`
+	LogoURL      string    `json:"logo_url"`
+	Message      string    `json:"message"`
+	ContactEmail string    `json:"contact_email"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// BookSponsorSlotRequest books a placement in content_id, or in the first content topic_id sends on date
+type BookSponsorSlotRequest struct {
+	ContentID  *uint  `json:"content_id"`
+	TopicID    uint   `json:"topic_id"`
+	Date       string `json:"date" validate:"omitempty,datetime=2006-01-02"`
+	Position   string `json:"position" validate:"omitempty,oneof=top bottom"` // Defaults to top
+	Message    string `json:"message" validate:"omitempty,max=1000"`          // Replaces the sponsor's message in this placement
+	PriceCents int64  `json:"price_cents" validate:"min=0"`
+	Currency   string `json:"currency" validate:"omitempty,len=3"`
+}
+
+type SponsorSlotResponse struct {
+	ID         uint       `json:"id"`
+	SponsorID  uint       `json:"sponsor_id"`
+	TopicID    uint       `json:"topic_id"`
+	ContentID  *uint      `json:"content_id"`
+	Date       *string    `json:"date"`
+	Position   string     `json:"position"`
+	Message    string     `json:"message"`
+	PriceCents int64      `json:"price_cents"`
+	Currency   string     `json:"currency"`
+	ServedAt   *time.Time `json:"served_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// SponsorSlotQuery filters the slots listed
+type SponsorSlotQuery struct {
+	PaginationRequest
+	SponsorID uint  `form:"sponsor_id"`
+	TopicID   uint  `form:"topic_id"`
+	Served    *bool `form:"served"`
+}
+
+// SponsorSlotStatsResponse is a slot with the sends and opens of the content it was served in
+type SponsorSlotStatsResponse struct {
+	SponsorSlotResponse
+	Sends       int64 `json:"sends"`
+	Impressions int64 `json:"impressions"`
+}
+
+type SponsorReportResponse struct {
+	Sponsor     SponsorResponse            `json:"sponsor"`
+	Slots       []SponsorSlotStatsResponse `json:"slots"`
+	Sends       int64                      `json:"sends"`
+	Impressions int64                      `json:"impressions"`
+}
@@ -0,0 +1,29 @@
+package dtos
+
+// TimeseriesQuery represents parameters for the stats timeseries endpoint
+type TimeseriesQuery struct {
+	Metric   string `form:"metric" binding:"required,oneof=sends failures opens subscriptions unsubscribes signups"`
+	Interval string `form:"interval" binding:"omitempty,oneof=day week month"`
+	From     string `form:"from"` // RFC3339 timestamp, defaults to 30 days before to
+	To       string `form:"to"`   // RFC3339 timestamp, defaults to now
+}
+
+// ProviderStatsQuery represents parameters for the per-provider send stats endpoint
+type ProviderStatsQuery struct {
+	Range string `form:"range"` // Hours or days, e.g. 24h or 7d; defaults to 24h
+}
+
+// DomainStatsQuery represents parameters for the per-domain delivery stats endpoint
+type DomainStatsQuery struct {
+	Days  int `form:"days" binding:"omitempty,min=1,max=90"`   // Defaults to 7
+	Limit int `form:"limit" binding:"omitempty,min=1,max=100"` // Defaults to 20
+}
+
+// SignupStatsQuery represents parameters for the signups-by-source stats endpoint
+type SignupStatsQuery struct {
+	By       string `form:"by" binding:"required,oneof=source form utm_source utm_medium utm_campaign country referrer api_key"`
+	Interval string `form:"interval" binding:"omitempty,oneof=day week month"`
+	From     string `form:"from"`                                   // RFC3339 timestamp, defaults to 30 days before to
+	To       string `form:"to"`                                     // RFC3339 timestamp, defaults to now
+	Limit    int    `form:"limit" binding:"omitempty,min=1,max=50"` // Values listed, most signups first; defaults to 10
+}
@@ -6,6 +6,16 @@ type CreateSubscriberRequest struct {
 	Name             string   `json:"name" validate:"required,max=100"`
 	Email            string   `json:"email" validate:"required,email,max=255"`
 	SubscribedTopics []string `json:"subscribed_topics" validate:"omitempty,dive,min=1"`
+	Timezone         string   `json:"timezone" validate:"omitempty,timezone"`                // IANA name; detected from the Time-Zone header when omitted
+	Locale           string   `json:"locale" validate:"omitempty,max=35,bcp47_language_tag"` // Detected from the Accept-Language header when omitted
+	Phone            string   `json:"phone" validate:"omitempty,e164"`
+	Channels         []string `json:"channels" validate:"omitempty,dive,oneof=email sms"`
+
+	// Signup attribution; it can't be changed once the subscriber exists
+	Form     string `json:"form" validate:"omitempty,max=100"`             // Signup form or placement, e.g. "homepage-footer"
+	Referrer string `json:"referrer" validate:"omitempty,url,max=500"`     // Page the reader came from; taken from the Referer header when omitted
+	Country  string `json:"country" validate:"omitempty,iso3166_1_alpha2"` // Detected from CDN country headers when omitted
+	UTM      *UTM   `json:"utm"`                                           // Campaign tagging of the signup page
 }
 
 type UpdateSubscriberRequest struct {
@@ -13,16 +23,60 @@ type UpdateSubscriberRequest struct {
 	Name             string   `json:"name" validate:"omitempty,max=100"`
 	IsActive         *bool    `json:"is_active" validate:"omitempty"`
 	SubscribedTopics []string `json:"subscribed_topics" validate:"omitempty,dive,min=1"`
+	Timezone         string   `json:"timezone" validate:"omitempty,timezone"`
+	Locale           string   `json:"locale" validate:"omitempty,max=35,bcp47_language_tag"`
+	Phone            string   `json:"phone" validate:"omitempty,e164"`
+	Channels         []string `json:"channels" validate:"omitempty,dive,oneof=email sms"`
 }
 
 type SubscriberResponse struct {
-	ID               uint      `json:"id"`
-	Email            string    `json:"email"`
-	Name             string    `json:"name"`
-	IsActive         bool      `json:"is_active"`
-	SubscribedTopics []string  `json:"subscribed_topics"`
-	CreatedAt        time.Time `json:"created_at"`
-	UpdatedAt        time.Time `json:"updated_at"`
+	ID               uint              `json:"id"`
+	Email            string            `json:"email"`
+	Name             string            `json:"name"`
+	IsActive         bool              `json:"is_active"`
+	EmailStatus      string            `json:"email_status,omitempty"` // unverified, pending, valid, risky or invalid
+	Timezone         string            `json:"timezone,omitempty"`
+	Locale           string            `json:"locale,omitempty"`
+	Phone            string            `json:"phone,omitempty"`
+	Channels         []string          `json:"channels"`
+	SubscribedTopics []string          `json:"subscribed_topics"`
+	ReferralCode     string            `json:"referral_code,omitempty"`
+	ReferralCount    int               `json:"referral_count"`           // Readers who confirmed through the subscriber's referral link
+	ReferredByID     *uint             `json:"referred_by_id,omitempty"` // Subscriber whose referral link this one signed up through
+	Signup           SignupAttribution `json:"signup"`
+	CreatedAt        time.Time         `json:"created_at"`
+	UpdatedAt        time.Time         `json:"updated_at"`
+	DeletedAt        *time.Time        `json:"deleted_at,omitempty"`
+}
+
+// SignupAttribution is where a subscriber signed up from
+type SignupAttribution struct {
+	Source   string `json:"source"` // api, bulk, grpc or referral; empty for subscribers created before it was recorded
+	Form     string `json:"form,omitempty"`
+	APIKeyID *uint  `json:"api_key_id,omitempty"`
+	Referrer string `json:"referrer,omitempty"`
+	Country  string `json:"country,omitempty"`
+	UTM      UTM    `json:"utm"`
+}
+
+// SignupFilterQuery filters subscribers by their signup attribution
+type SignupFilterQuery struct {
+	SignupSource   string `form:"signup_source" binding:"omitempty,max=32"`
+	SignupForm     string `form:"signup_form" binding:"omitempty,max=100"`
+	UTMSource      string `form:"utm_source" binding:"omitempty,max=100"`
+	UTMMedium      string `form:"utm_medium" binding:"omitempty,max=100"`
+	UTMCampaign    string `form:"utm_campaign" binding:"omitempty,max=100"`
+	Country        string `form:"country" binding:"omitempty,iso3166_1_alpha2"`
+	APIKeyID       *uint  `form:"api_key_id"`
+	ReferredByID   *uint  `form:"referred_by_id"`
+	SignedUpAfter  string `form:"signed_up_after"`  // RFC3339 timestamp
+	SignedUpBefore string `form:"signed_up_before"` // RFC3339 timestamp
+}
+
+// SubscriberListQuery represents parameters for listing subscribers
+type SubscriberListQuery struct {
+	ListQuery
+	SignupFilterQuery
 }
 
 type CreateSubscriptionRequest struct {
@@ -30,9 +84,25 @@ type CreateSubscriptionRequest struct {
 	TopicID      uint `json:"topic_id" validate:"required"`
 }
 
+// UpdateSubscriptionRequest sets the subscriber's preferences for one topic
+type UpdateSubscriptionRequest struct {
+	Push *bool `json:"push" validate:"required"` // Also push the topic's content to the subscriber's devices
+}
+
 type SubscriptionResponse struct {
 	ID           uint      `json:"id"`
 	SubscriberID uint      `json:"subscriber_id"`
 	TopicID      uint      `json:"topic_id"`
+	Push         bool      `json:"push"`
 	CreatedAt    time.Time `json:"created_at"`
 }
+
+type MergeSubscribersRequest struct {
+	SourceID uint `json:"source_id" validate:"required"`                  // Subscriber folded in and then deleted
+	TargetID uint `json:"target_id" validate:"required,nefield=SourceID"` // Subscriber that is kept
+}
+
+type DuplicateSubscribersResponse struct {
+	NormalizedEmail string               `json:"normalized_email"`
+	Subscribers     []SubscriberResponse `json:"subscribers"`
+}
@@ -3,19 +3,51 @@ package dtos
 import "time"
 
 type CreateTopicRequest struct {
-	Name        string `json:"name" validate:"required,max=100"`
-	Description string `json:"description"`
+	Name        string    `json:"name" validate:"required,max=100"`
+	Slug        string    `json:"slug" validate:"omitempty,max=100"` // Addresses the landing page; made from the name when left out
+	Description string    `json:"description"`
+	Branding    *Branding `json:"branding"` // Overrides the organization's branding for this topic
+	UTM         *UTM      `json:"utm"`      // Tags the links of the topic's content
 }
 
 type UpdateTopicRequest struct {
-	Name        string `json:"name" validate:"omitempty,max=100"`
-	Description string `json:"description" validate:"omitempty"`
+	Name        string    `json:"name" validate:"omitempty,max=100"`
+	Slug        string    `json:"slug" validate:"omitempty,max=100"` // Renaming a topic keeps its slug unless this changes it
+	Description string    `json:"description" validate:"omitempty"`
+	Branding    *Branding `json:"branding"` // Replaces the topic's branding; empty fields use the organization's
+	UTM         *UTM      `json:"utm"`      // Replaces the topic's link tags
+}
+
+// DeleteTopicQuery holds the query parameters of a topic delete
+type DeleteTopicQuery struct {
+	Force bool `form:"force"` // Also remove subscriptions and unpublish unsent content instead of refusing
+}
+
+// MigrateSubscribersRequest names where the subscribers of the topic in the path go. A move also unsubscribes
+// them from that topic; a copy leaves them on both.
+type MigrateSubscribersRequest struct {
+	TargetTopicID uint   `json:"target_topic_id" validate:"required"`
+	Mode          string `json:"mode" validate:"required,oneof=move copy"`
+}
+
+type MigrateSubscribersResponse struct {
+	SourceTopicID     uint   `json:"source_topic_id"`
+	TargetTopicID     uint   `json:"target_topic_id"`
+	Mode              string `json:"mode"`
+	Migrated          int64  `json:"migrated"`
+	AlreadySubscribed int64  `json:"already_subscribed"`
+	Removed           int64  `json:"removed"`
 }
 
 type TopicResponse struct {
-	ID          uint      `json:"id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID          uint       `json:"id"`
+	Name        string     `json:"name"`
+	Slug        string     `json:"slug"`
+	Description string     `json:"description"`
+	Branding    Branding   `json:"branding"`
+	UTM         UTM        `json:"utm"`
+	ArchivedAt  *time.Time `json:"archived_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
 }
@@ -0,0 +1,8 @@
+package dtos
+
+// UTM is the campaign tagging added to email links. On content, empty fields use the topic's.
+type UTM struct {
+	Source   string `json:"source" validate:"omitempty,max=100"`
+	Medium   string `json:"medium" validate:"omitempty,max=100"`
+	Campaign string `json:"campaign" validate:"omitempty,max=100"`
+}
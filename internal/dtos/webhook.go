@@ -0,0 +1,32 @@
+package dtos
+
+import "time"
+
+type CreateWebhookRequest struct {
+	URL         string   `json:"url" validate:"required,url,max=2048"`
+	Events      []string `json:"events" validate:"required,min=1,dive,oneof=subscriber.created subscriber.unsubscribed content.published send.completed email.bounced"`
+	Description string   `json:"description" validate:"omitempty,max=255"`
+}
+
+type UpdateWebhookRequest struct {
+	URL         string   `json:"url" validate:"omitempty,url,max=2048"`
+	Events      []string `json:"events" validate:"omitempty,min=1,dive,oneof=subscriber.created subscriber.unsubscribed content.published send.completed email.bounced"`
+	Description *string  `json:"description" validate:"omitempty,max=255"`
+	IsActive    *bool    `json:"is_active"`
+}
+
+type WebhookResponse struct {
+	ID          uint      `json:"id"`
+	URL         string    `json:"url"`
+	Events      []string  `json:"events"`
+	Description string    `json:"description"`
+	IsActive    bool      `json:"is_active"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// CreateWebhookResponse includes the signing secret, which is only ever returned once
+type CreateWebhookResponse struct {
+	WebhookResponse
+	Secret string `json:"secret"`
+}
@@ -0,0 +1,41 @@
+package errors
+
+import (
+	"context"
+	stderrors "errors"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+)
+
+// internalMessage is all clients learn about an internal error; the cause is only logged
+const internalMessage = "An internal error occurred"
+
+// Classify turns an error returned by a service into the AppError to answer with. AppErrors anywhere in the
+// chain are kept, a missing record is not found, unique and foreign key violations are conflicts, failed
+// struct validation is a validation error and a passed deadline, usually the request's, is a timeout. Anything
+// else is internal, and its message, which may hold SQL or other details, is not passed on.
+func Classify(err error) *AppError {
+	var appErr *AppError
+	if stderrors.As(err, &appErr) {
+		return appErr
+	}
+
+	var validationErrs validator.ValidationErrors
+	switch {
+	case stderrors.Is(err, gorm.ErrRecordNotFound):
+		return &AppError{Code: CodeNotFound, Message: "Resource not found", StatusCode: http.StatusNotFound, Err: err}
+	case stderrors.Is(err, gorm.ErrDuplicatedKey):
+		return NewConflictError("A resource with the same unique values already exists", err)
+	case stderrors.Is(err, gorm.ErrForeignKeyViolated):
+		return NewConflictError("The resource refers to, or is referred to by, other resources", err)
+	case stderrors.Is(err, context.DeadlineExceeded):
+		return NewTimeoutError("The request took too long and was cancelled", err)
+	case stderrors.As(err, &validationErrs):
+		appErr := NewValidationError("Validation failed", err)
+		appErr.Details = validationErrs.Error()
+		return appErr
+	}
+	return NewInternalError(internalMessage, err)
+}
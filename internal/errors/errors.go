@@ -3,12 +3,49 @@ package errors
 import (
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 
 	"newsletter-service/internal/logger"
 )
 
+// Error codes clients can branch on
+const (
+	CodeValidation      = "VALIDATION_ERROR"
+	CodeNotFound        = "RESOURCE_NOT_FOUND"
+	CodeConflict        = "RESOURCE_CONFLICT"
+	CodeInternal        = "INTERNAL_ERROR"
+	CodeUnauthorized    = "UNAUTHORIZED"
+	CodeForbidden       = "FORBIDDEN"
+	CodeTooManyRequests = "TOO_MANY_REQUESTS"
+	CodeTimeout         = "TIMEOUT"
+)
+
+// CodeForStatus returns the error code for a response status, for error bodies that weren't built from an
+// AppError. Statuses without a code of their own get their status text, e.g. PRECONDITION_FAILED.
+func CodeForStatus(status int) string {
+	switch {
+	case status == http.StatusBadRequest:
+		return CodeValidation
+	case status == http.StatusUnauthorized:
+		return CodeUnauthorized
+	case status == http.StatusForbidden:
+		return CodeForbidden
+	case status == http.StatusNotFound:
+		return CodeNotFound
+	case status == http.StatusConflict:
+		return CodeConflict
+	case status == http.StatusTooManyRequests:
+		return CodeTooManyRequests
+	case status == http.StatusGatewayTimeout:
+		return CodeTimeout
+	case status >= http.StatusInternalServerError:
+		return CodeInternal
+	}
+	return strings.ToUpper(strings.ReplaceAll(http.StatusText(status), " ", "_"))
+}
+
 // AppError represents a standardized application error
 type AppError struct {
 	Code       string `json:"code"`
@@ -32,7 +69,7 @@ func (e *AppError) Unwrap() error {
 // Error constructors
 func NewValidationError(message string, err error) *AppError {
 	return &AppError{
-		Code:       "VALIDATION_ERROR",
+		Code:       CodeValidation,
 		Message:    message,
 		StatusCode: http.StatusBadRequest,
 		Err:        err,
@@ -41,7 +78,7 @@ func NewValidationError(message string, err error) *AppError {
 
 func NewNotFoundError(resource string, id interface{}) *AppError {
 	return &AppError{
-		Code:       "RESOURCE_NOT_FOUND",
+		Code:       CodeNotFound,
 		Message:    fmt.Sprintf("%s with ID %v not found", resource, id),
 		StatusCode: http.StatusNotFound,
 	}
@@ -49,7 +86,7 @@ func NewNotFoundError(resource string, id interface{}) *AppError {
 
 func NewConflictError(message string, err error) *AppError {
 	return &AppError{
-		Code:       "RESOURCE_CONFLICT",
+		Code:       CodeConflict,
 		Message:    message,
 		StatusCode: http.StatusConflict,
 		Err:        err,
@@ -58,7 +95,7 @@ func NewConflictError(message string, err error) *AppError {
 
 func NewInternalError(message string, err error) *AppError {
 	return &AppError{
-		Code:       "INTERNAL_ERROR",
+		Code:       CodeInternal,
 		Message:    message,
 		StatusCode: http.StatusInternalServerError,
 		Err:        err,
@@ -67,7 +104,7 @@ func NewInternalError(message string, err error) *AppError {
 
 func NewUnauthorizedError(message string) *AppError {
 	return &AppError{
-		Code:       "UNAUTHORIZED",
+		Code:       CodeUnauthorized,
 		Message:    message,
 		StatusCode: http.StatusUnauthorized,
 	}
@@ -75,7 +112,7 @@ func NewUnauthorizedError(message string) *AppError {
 
 func NewForbiddenError(message string) *AppError {
 	return &AppError{
-		Code:       "FORBIDDEN",
+		Code:       CodeForbidden,
 		Message:    message,
 		StatusCode: http.StatusForbidden,
 	}
@@ -83,12 +120,21 @@ func NewForbiddenError(message string) *AppError {
 
 func NewTooManyRequestsError(message string) *AppError {
 	return &AppError{
-		Code:       "TOO_MANY_REQUESTS",
+		Code:       CodeTooManyRequests,
 		Message:    message,
 		StatusCode: http.StatusTooManyRequests,
 	}
 }
 
+func NewTimeoutError(message string, err error) *AppError {
+	return &AppError{
+		Code:       CodeTimeout,
+		Message:    message,
+		StatusCode: http.StatusGatewayTimeout,
+		Err:        err,
+	}
+}
+
 // ErrorHandler middleware for centralized error handling
 func ErrorHandler() gin.HandlerFunc {
 	return gin.HandlerFunc(func(c *gin.Context) {
@@ -104,8 +150,7 @@ func ErrorHandler() gin.HandlerFunc {
 
 			// Check if it's already an AppError
 			if appErr, ok = err.(*AppError); !ok {
-				// Convert generic error to AppError
-				appErr = NewInternalError("An internal error occurred", err)
+				appErr = Classify(err)
 			}
 
 			// Log the error
@@ -115,23 +160,26 @@ func ErrorHandler() gin.HandlerFunc {
 				logger.Warn(ctx, "Client error: %v", appErr.Error())
 			}
 
-			// Return structured error response
-			response := gin.H{
-				"error": gin.H{
-					"code":    appErr.Code,
-					"message": appErr.Message,
-				},
-			}
-
-			if appErr.Details != "" {
-				response["error"].(gin.H)["details"] = appErr.Details
+			// Errors passed to Abort have already been answered
+			if !c.Writer.Written() {
+				c.JSON(appErr.StatusCode, appErr.response())
 			}
-
-			c.JSON(appErr.StatusCode, response)
 		}
 	})
 }
 
+// response is the structured error body sent to clients
+func (e *AppError) response() gin.H {
+	body := gin.H{
+		"code":    e.Code,
+		"message": e.Message,
+	}
+	if e.Details != "" {
+		body["details"] = e.Details
+	}
+	return gin.H{"error": body}
+}
+
 // HandleError is a helper function to handle errors in handlers
 func HandleError(c *gin.Context, err error) {
 	if err != nil {
@@ -140,6 +188,13 @@ func HandleError(c *gin.Context, err error) {
 	}
 }
 
+// Abort answers with err's response straight away instead of leaving it to ErrorHandler, so middleware that
+// sees the response on its way out, such as idempotency key caching, gets the error. ErrorHandler still logs it.
+func Abort(c *gin.Context, err *AppError) {
+	c.Error(err)
+	c.AbortWithStatusJSON(err.StatusCode, err.response())
+}
+
 // Wrap wraps an error with additional context
 func Wrap(err error, message string) error {
 	if err == nil {
@@ -0,0 +1,119 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"newsletter-service/internal/config"
+	"newsletter-service/internal/logger"
+)
+
+// Event bus drivers selectable in the [events] config section
+const (
+	DriverInProcess = "inprocess"
+	DriverKafka     = "kafka"
+	DriverNATS      = "nats"
+)
+
+// Handler consumes events delivered in-process
+type Handler func(ctx context.Context, event Event)
+
+// Publisher sends events to an external message bus
+type Publisher interface {
+	Publish(ctx context.Context, event Event) error
+	Close() error
+}
+
+type subscription struct {
+	types   map[string]bool // nil matches every event
+	handler Handler
+}
+
+// Bus fans domain events out to in-process handlers and, when configured, an external publisher.
+// A nil *Bus is valid and drops every event, so emitters need no enabled checks.
+type Bus struct {
+	source        string
+	publisher     Publisher
+	mu            sync.RWMutex
+	subscriptions []subscription
+}
+
+// NewBus creates a bus for the configured driver. The in-process driver only notifies local handlers.
+func NewBus(cfg config.EventsConfig, source string) (*Bus, error) {
+	bus := &Bus{source: source}
+
+	switch strings.ToLower(cfg.Driver) {
+	case "", DriverInProcess:
+	case DriverKafka:
+		publisher, err := NewKafkaPublisher(cfg)
+		if err != nil {
+			return nil, err
+		}
+		bus.publisher = publisher
+	case DriverNATS:
+		publisher, err := NewNATSPublisher(cfg, source)
+		if err != nil {
+			return nil, err
+		}
+		bus.publisher = publisher
+	default:
+		return nil, fmt.Errorf("unknown event bus driver: %s", cfg.Driver)
+	}
+
+	return bus, nil
+}
+
+// Subscribe registers an in-process handler for the given event types, or for all events if none are given
+func (b *Bus) Subscribe(handler Handler, eventTypes ...string) {
+	if b == nil {
+		return
+	}
+
+	sub := subscription{handler: handler}
+	if len(eventTypes) > 0 {
+		sub.types = make(map[string]bool, len(eventTypes))
+		for _, eventType := range eventTypes {
+			sub.types[eventType] = true
+		}
+	}
+
+	b.mu.Lock()
+	b.subscriptions = append(b.subscriptions, sub)
+	b.mu.Unlock()
+}
+
+// Emit publishes an event to local handlers and the external bus.
+// Failures are logged rather than returned so emitting never fails the operation that caused it.
+func (b *Bus) Emit(ctx context.Context, eventType string, data interface{}) {
+	if b == nil {
+		return
+	}
+
+	event := NewEvent(b.source, eventType, data)
+
+	b.mu.RLock()
+	subscriptions := b.subscriptions
+	b.mu.RUnlock()
+
+	for _, sub := range subscriptions {
+		if sub.types == nil || sub.types[eventType] {
+			sub.handler(ctx, event)
+		}
+	}
+
+	if b.publisher != nil {
+		if err := b.publisher.Publish(ctx, event); err != nil {
+			logger.Warn(ctx, "Failed to publish %s event to message bus: %v", eventType, err)
+		}
+	}
+}
+
+// Close flushes and closes the external publisher
+func (b *Bus) Close() error {
+	if b == nil || b.publisher == nil {
+		return nil
+	}
+	return b.publisher.Close()
+}
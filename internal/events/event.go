@@ -0,0 +1,42 @@
+package events
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Domain event types
+const (
+	SubscriberCreated      = "subscriber.created"
+	SubscriberUpdated      = "subscriber.updated"
+	SubscriberDeleted      = "subscriber.deleted"
+	SubscriberRestored     = "subscriber.restored"
+	SubscriberUnsubscribed = "subscriber.unsubscribed"
+	ContentPublished       = "content.published"
+	SendCompleted          = "send.completed"
+	SendReport             = "send.report"
+	EmailSent              = "email.sent"
+	EmailFailed            = "email.failed"
+	EmailBounced           = "email.bounced"
+)
+
+// Event is a domain event as published to subscribers and the message bus
+type Event struct {
+	ID        string      `json:"id"`
+	Type      string      `json:"type"`
+	Source    string      `json:"source"`
+	CreatedAt time.Time   `json:"created_at"`
+	Data      interface{} `json:"data"`
+}
+
+// NewEvent creates an event with a fresh ID and timestamp
+func NewEvent(source, eventType string, data interface{}) Event {
+	return Event{
+		ID:        uuid.NewString(),
+		Type:      eventType,
+		Source:    source,
+		CreatedAt: time.Now().UTC(),
+		Data:      data,
+	}
+}
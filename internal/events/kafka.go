@@ -0,0 +1,61 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/segmentio/kafka-go"
+
+	"newsletter-service/internal/config"
+)
+
+type kafkaPublisher struct {
+	writer *kafka.Writer
+}
+
+// NewKafkaPublisher writes events as JSON to a single topic, keyed by event type so
+// each type stays ordered within its partition
+func NewKafkaPublisher(cfg config.EventsConfig) (Publisher, error) {
+	var brokers []string
+	for _, broker := range strings.Split(cfg.KafkaBrokers, ",") {
+		if broker = strings.TrimSpace(broker); broker != "" {
+			brokers = append(brokers, broker)
+		}
+	}
+	if len(brokers) == 0 {
+		return nil, fmt.Errorf("events.kafka_brokers is required for the kafka driver")
+	}
+	if cfg.KafkaTopic == "" {
+		return nil, fmt.Errorf("events.kafka_topic is required for the kafka driver")
+	}
+
+	writer := kafka.NewWriter(kafka.WriterConfig{
+		Brokers:  brokers,
+		Topic:    cfg.KafkaTopic,
+		Balancer: &kafka.Hash{},
+		Async:    true, // Never block the request path on broker acknowledgements
+	})
+	return &kafkaPublisher{writer: writer}, nil
+}
+
+func (p *kafkaPublisher) Publish(ctx context.Context, event Event) error {
+	value, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to serialize event: %w", err)
+	}
+
+	return p.writer.WriteMessages(ctx, kafka.Message{
+		Key:   []byte(event.Type),
+		Value: value,
+		Headers: []kafka.Header{
+			{Key: "event_id", Value: []byte(event.ID)},
+			{Key: "event_type", Value: []byte(event.Type)},
+		},
+	})
+}
+
+func (p *kafkaPublisher) Close() error {
+	return p.writer.Close()
+}
@@ -0,0 +1,48 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+
+	"newsletter-service/internal/config"
+)
+
+type natsPublisher struct {
+	conn          *nats.Conn
+	subjectPrefix string
+}
+
+// NewNATSPublisher publishes events as JSON on "<subject_prefix>.<event type>",
+// e.g. newsletter.subscriber.created, so consumers can subscribe with wildcards
+func NewNATSPublisher(cfg config.EventsConfig, source string) (Publisher, error) {
+	url := cfg.NATSURL
+	if url == "" {
+		url = nats.DefaultURL
+	}
+
+	conn, err := nats.Connect(url, nats.Name(source), nats.MaxReconnects(-1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to NATS: %w", err)
+	}
+
+	prefix := cfg.NATSSubjectPrefix
+	if prefix == "" {
+		prefix = "newsletter"
+	}
+	return &natsPublisher{conn: conn, subjectPrefix: prefix}, nil
+}
+
+func (p *natsPublisher) Publish(ctx context.Context, event Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to serialize event: %w", err)
+	}
+	return p.conn.Publish(p.subjectPrefix+"."+event.Type, data)
+}
+
+func (p *natsPublisher) Close() error {
+	return p.conn.Drain()
+}
@@ -0,0 +1,216 @@
+package graphqlapi
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/graph-gophers/dataloader"
+
+	"newsletter-service/internal/services/content"
+	"newsletter-service/internal/services/notification"
+	"newsletter-service/internal/services/subscriber"
+	"newsletter-service/internal/services/topic"
+)
+
+// batchWait is how long a loader collects keys before issuing its query
+const batchWait = 2 * time.Millisecond
+
+type loadersKey struct{}
+
+// loaders batch nested field lookups for one request. They are created per request so
+// cached results never leak between callers.
+type loaders struct {
+	topicByID              *dataloader.Loader
+	subscriberByID         *dataloader.Loader
+	contentByID            *dataloader.Loader
+	topicsBySubscriber     *dataloader.Loader
+	subscriberCountByTopic *dataloader.Loader
+	recentEmails           *dataloader.Loader
+}
+
+// WithLoaders attaches fresh request-scoped dataloaders to ctx
+func WithLoaders(ctx context.Context, services Services) context.Context {
+	l := &loaders{
+		topicByID: newLoader(batchByID(services.Topic.GetTopicsByIDs, func(t *topic.Topic) uint { return t.ID })),
+		subscriberByID: newLoader(batchByID(services.Subscriber.GetSubscribersByIDs, func(s *subscriber.Subscriber) uint {
+			return s.ID
+		})),
+		contentByID:            newLoader(batchByID(services.Content.GetContentsByIDs, func(c *content.Content) uint { return c.ID })),
+		topicsBySubscriber:     newLoader(batchTopicsBySubscriber(services)),
+		subscriberCountByTopic: newLoader(batchSubscriberCountByTopic(services)),
+		recentEmails:           newLoader(batchRecentEmails(services)),
+	}
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+func newLoader(batchFn dataloader.BatchFunc) *dataloader.Loader {
+	return dataloader.NewBatchedLoader(batchFn, dataloader.WithWait(batchWait))
+}
+
+func idKey(id uint) dataloader.Key {
+	return dataloader.StringKey(strconv.FormatUint(uint64(id), 10))
+}
+
+func keyIDs(keys dataloader.Keys) ([]uint, error) {
+	ids := make([]uint, len(keys))
+	for i, key := range keys {
+		id, err := strconv.ParseUint(key.String(), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid loader key %q: %w", key.String(), err)
+		}
+		ids[i] = uint(id)
+	}
+	return ids, nil
+}
+
+// errorResults fails every key in a batch with err
+func errorResults(keys dataloader.Keys, err error) []*dataloader.Result {
+	results := make([]*dataloader.Result, len(keys))
+	for i := range keys {
+		results[i] = &dataloader.Result{Error: err}
+	}
+	return results
+}
+
+// batchByID loads entities by primary key with one IN query. Missing IDs resolve to nil.
+func batchByID[T any](fetch func(ctx context.Context, ids []uint) ([]*T, error), idOf func(*T) uint) dataloader.BatchFunc {
+	return func(ctx context.Context, keys dataloader.Keys) []*dataloader.Result {
+		ids, err := keyIDs(keys)
+		if err != nil {
+			return errorResults(keys, err)
+		}
+
+		items, err := fetch(ctx, ids)
+		if err != nil {
+			return errorResults(keys, err)
+		}
+
+		byID := make(map[uint]*T, len(items))
+		for _, item := range items {
+			byID[idOf(item)] = item
+		}
+
+		results := make([]*dataloader.Result, len(ids))
+		for i, id := range ids {
+			results[i] = &dataloader.Result{Data: byID[id]}
+		}
+		return results
+	}
+}
+
+// batchTopicsBySubscriber resolves each subscriber's topics with two queries for the whole batch
+func batchTopicsBySubscriber(services Services) dataloader.BatchFunc {
+	return func(ctx context.Context, keys dataloader.Keys) []*dataloader.Result {
+		subscriberIDs, err := keyIDs(keys)
+		if err != nil {
+			return errorResults(keys, err)
+		}
+
+		subscriptions, err := services.Subscriber.GetSubscriptionsBySubscriberIDs(ctx, subscriberIDs)
+		if err != nil {
+			return errorResults(keys, err)
+		}
+
+		topicIDs := make([]uint, 0, len(subscriptions))
+		for _, sub := range subscriptions {
+			topicIDs = append(topicIDs, sub.TopicID)
+		}
+
+		topicsByID := make(map[uint]*topic.Topic)
+		if len(topicIDs) > 0 {
+			topics, err := services.Topic.GetTopicsByIDs(ctx, topicIDs)
+			if err != nil {
+				return errorResults(keys, err)
+			}
+			for _, t := range topics {
+				topicsByID[t.ID] = t
+			}
+		}
+
+		bySubscriber := make(map[uint][]*topic.Topic)
+		for _, sub := range subscriptions {
+			if t, ok := topicsByID[sub.TopicID]; ok {
+				bySubscriber[sub.SubscriberID] = append(bySubscriber[sub.SubscriberID], t)
+			}
+		}
+
+		results := make([]*dataloader.Result, len(subscriberIDs))
+		for i, id := range subscriberIDs {
+			results[i] = &dataloader.Result{Data: bySubscriber[id]}
+		}
+		return results
+	}
+}
+
+func batchSubscriberCountByTopic(services Services) dataloader.BatchFunc {
+	return func(ctx context.Context, keys dataloader.Keys) []*dataloader.Result {
+		topicIDs, err := keyIDs(keys)
+		if err != nil {
+			return errorResults(keys, err)
+		}
+
+		counts, err := services.Subscriber.CountSubscriptionsByTopicIDs(ctx, topicIDs)
+		if err != nil {
+			return errorResults(keys, err)
+		}
+
+		results := make([]*dataloader.Result, len(topicIDs))
+		for i, id := range topicIDs {
+			results[i] = &dataloader.Result{Data: counts[id]}
+		}
+		return results
+	}
+}
+
+// recentEmailsKey identifies a subscriber's recent emails for a given limit ("<subscriber_id>:<limit>")
+func recentEmailsKey(subscriberID uint, limit int) dataloader.Key {
+	return dataloader.StringKey(fmt.Sprintf("%d:%d", subscriberID, limit))
+}
+
+// batchRecentEmails issues one query per distinct limit in the batch
+func batchRecentEmails(services Services) dataloader.BatchFunc {
+	return func(ctx context.Context, keys dataloader.Keys) []*dataloader.Result {
+		type parsedKey struct {
+			subscriberID uint
+			limit        int
+		}
+
+		parsed := make([]parsedKey, len(keys))
+		idsByLimit := make(map[int][]uint)
+		for i, key := range keys {
+			idPart, limitPart, _ := strings.Cut(key.String(), ":")
+			id, err := strconv.ParseUint(idPart, 10, 32)
+			if err != nil {
+				return errorResults(keys, fmt.Errorf("invalid loader key %q: %w", key.String(), err))
+			}
+			limit, err := strconv.Atoi(limitPart)
+			if err != nil {
+				return errorResults(keys, fmt.Errorf("invalid loader key %q: %w", key.String(), err))
+			}
+			parsed[i] = parsedKey{subscriberID: uint(id), limit: limit}
+			idsByLimit[limit] = append(idsByLimit[limit], uint(id))
+		}
+
+		logsByLimit := make(map[int]map[uint][]*notification.EmailLog, len(idsByLimit))
+		for limit, ids := range idsByLimit {
+			logs, err := services.Notification.GetRecentEmailLogsBySubscriberIDs(ctx, ids, limit)
+			if err != nil {
+				return errorResults(keys, err)
+			}
+			logsByLimit[limit] = logs
+		}
+
+		results := make([]*dataloader.Result, len(parsed))
+		for i, key := range parsed {
+			results[i] = &dataloader.Result{Data: logsByLimit[key.limit][key.subscriberID]}
+		}
+		return results
+	}
+}
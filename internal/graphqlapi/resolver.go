@@ -0,0 +1,195 @@
+package graphqlapi
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/graph-gophers/graphql-go"
+	"gorm.io/gorm"
+
+	"newsletter-service/internal/constants"
+	"newsletter-service/internal/dtos"
+)
+
+// Resolver is the root query resolver
+type Resolver struct {
+	services Services
+}
+
+type pageArgs struct {
+	Page     int32
+	PageSize int32
+}
+
+type idArgs struct {
+	ID graphql.ID
+}
+
+// pagination validates page arguments the same way the REST list endpoints do
+func (a pageArgs) pagination() (page, pageSize, offset int, err error) {
+	p := dtos.PaginationRequest{Page: int(a.Page), PageSize: int(a.PageSize)}
+	if p.Page < 0 || p.PageSize < 0 || p.PageSize > 100 {
+		return 0, 0, 0, errors.New(constants.ErrInvalidPaginationParams)
+	}
+
+	page, pageSize = p.GetDefaults()
+	return page, pageSize, p.CalculateOffset(), nil
+}
+
+func (a idArgs) id() (uint, error) {
+	id, err := strconv.ParseUint(string(a.ID), 10, 32)
+	if err != nil {
+		return 0, errors.New("invalid id")
+	}
+	return uint(id), nil
+}
+
+// notFound reports whether err means the record doesn't exist, which GraphQL surfaces as null
+func notFound(err error) bool {
+	return errors.Is(err, gorm.ErrRecordNotFound)
+}
+
+func toID(id uint) graphql.ID {
+	return graphql.ID(strconv.FormatUint(uint64(id), 10))
+}
+
+func (r *Resolver) Subscribers(ctx context.Context, args pageArgs) (*subscriberPageResolver, error) {
+	page, pageSize, offset, err := args.pagination()
+	if err != nil {
+		return nil, err
+	}
+
+	subscribers, total, err := r.services.Subscriber.GetAllSubscribersWithPagination(ctx, offset, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*subscriberResolver, len(subscribers))
+	for i, s := range subscribers {
+		items[i] = &subscriberResolver{subscriber: s}
+	}
+	return &subscriberPageResolver{items: items, pagination: dtos.CreatePaginationResponse(page, pageSize, total)}, nil
+}
+
+func (r *Resolver) Subscriber(ctx context.Context, args idArgs) (*subscriberResolver, error) {
+	id, err := args.id()
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := r.services.Subscriber.GetSubscriberByID(ctx, id)
+	if notFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &subscriberResolver{subscriber: s}, nil
+}
+
+func (r *Resolver) Topics(ctx context.Context, args pageArgs) (*topicPageResolver, error) {
+	page, pageSize, offset, err := args.pagination()
+	if err != nil {
+		return nil, err
+	}
+
+	topics, total, err := r.services.Topic.GetAllTopicsWithPagination(ctx, offset, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*topicResolver, len(topics))
+	for i, t := range topics {
+		items[i] = &topicResolver{topic: t}
+	}
+	return &topicPageResolver{items: items, pagination: dtos.CreatePaginationResponse(page, pageSize, total)}, nil
+}
+
+func (r *Resolver) Topic(ctx context.Context, args idArgs) (*topicResolver, error) {
+	id, err := args.id()
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := r.services.Topic.GetTopicByID(ctx, id)
+	if notFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &topicResolver{topic: t}, nil
+}
+
+func (r *Resolver) Contents(ctx context.Context, args pageArgs) (*contentPageResolver, error) {
+	page, pageSize, offset, err := args.pagination()
+	if err != nil {
+		return nil, err
+	}
+
+	contents, total, err := r.services.Content.GetAllContentWithPagination(ctx, offset, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*contentResolver, len(contents))
+	for i, c := range contents {
+		items[i] = &contentResolver{content: c}
+	}
+	return &contentPageResolver{items: items, pagination: dtos.CreatePaginationResponse(page, pageSize, total)}, nil
+}
+
+func (r *Resolver) Content(ctx context.Context, args idArgs) (*contentResolver, error) {
+	id, err := args.id()
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := r.services.Content.GetContentByID(ctx, id)
+	if notFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &contentResolver{content: c}, nil
+}
+
+func (r *Resolver) EmailLogs(ctx context.Context, args pageArgs) (*emailLogPageResolver, error) {
+	page, pageSize, offset, err := args.pagination()
+	if err != nil {
+		return nil, err
+	}
+
+	logs, total, err := r.services.Notification.GetEmailLogsWithPagination(ctx, offset, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*emailLogResolver, len(logs))
+	for i, l := range logs {
+		items[i] = &emailLogResolver{log: l}
+	}
+	return &emailLogPageResolver{items: items, pagination: dtos.CreatePaginationResponse(page, pageSize, total)}, nil
+}
+
+func (r *Resolver) EmailLog(ctx context.Context, args idArgs) (*emailLogResolver, error) {
+	id, err := args.id()
+	if err != nil {
+		return nil, err
+	}
+
+	l, err := r.services.Notification.GetEmailLogByID(ctx, id)
+	if notFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &emailLogResolver{log: l}, nil
+}
+
+func (r *Resolver) Stats(ctx context.Context) (*statsResolver, error) {
+	return &statsResolver{services: r.services}, nil
+}
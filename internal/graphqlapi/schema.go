@@ -0,0 +1,33 @@
+package graphqlapi
+
+import (
+	_ "embed"
+
+	"github.com/graph-gophers/graphql-go"
+
+	"newsletter-service/internal/services/content"
+	"newsletter-service/internal/services/notification"
+	"newsletter-service/internal/services/subscriber"
+	"newsletter-service/internal/services/topic"
+)
+
+// maxQueryDepth bounds nesting so a single dashboard query can't fan out indefinitely
+const maxQueryDepth = 8
+
+//go:embed schema.graphql
+var schemaSDL string
+
+// Services holds the domain services queried by the GraphQL resolvers
+type Services struct {
+	Topic        topic.Service
+	Subscriber   subscriber.Service
+	Content      content.Service
+	Notification notification.Service
+}
+
+// NewSchema parses the embedded schema and binds it to the root resolver
+func NewSchema(services Services) *graphql.Schema {
+	return graphql.MustParseSchema(schemaSDL, &Resolver{services: services},
+		graphql.MaxDepth(maxQueryDepth),
+	)
+}
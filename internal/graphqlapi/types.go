@@ -0,0 +1,253 @@
+package graphqlapi
+
+import (
+	"context"
+
+	"github.com/graph-gophers/graphql-go"
+
+	"newsletter-service/internal/constants"
+	"newsletter-service/internal/dtos"
+	"newsletter-service/internal/services/content"
+	"newsletter-service/internal/services/notification"
+	"newsletter-service/internal/services/subscriber"
+	"newsletter-service/internal/services/topic"
+)
+
+// maxRecentEmails caps Subscriber.recentEmails(limit)
+const maxRecentEmails = 50
+
+type pageInfoResolver struct {
+	pagination dtos.PaginationResponse
+}
+
+func (r *pageInfoResolver) Page() int32       { return int32(r.pagination.Page) }
+func (r *pageInfoResolver) PageSize() int32   { return int32(r.pagination.PageSize) }
+func (r *pageInfoResolver) TotalItems() int32 { return int32(r.pagination.TotalItems) }
+func (r *pageInfoResolver) TotalPages() int32 { return int32(r.pagination.TotalPages) }
+func (r *pageInfoResolver) HasNext() bool     { return r.pagination.HasNext }
+func (r *pageInfoResolver) HasPrev() bool     { return r.pagination.HasPrev }
+
+type subscriberResolver struct {
+	subscriber *subscriber.Subscriber
+}
+
+func (r *subscriberResolver) ID() graphql.ID { return toID(r.subscriber.ID) }
+func (r *subscriberResolver) Name() string   { return r.subscriber.Name }
+func (r *subscriberResolver) Email() string  { return r.subscriber.Email }
+func (r *subscriberResolver) IsActive() bool { return r.subscriber.IsActive }
+func (r *subscriberResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: r.subscriber.CreatedAt}
+}
+func (r *subscriberResolver) UpdatedAt() graphql.Time {
+	return graphql.Time{Time: r.subscriber.UpdatedAt}
+}
+
+func (r *subscriberResolver) Topics(ctx context.Context) ([]*topicResolver, error) {
+	data, err := loadersFrom(ctx).topicsBySubscriber.Load(ctx, idKey(r.subscriber.ID))()
+	if err != nil {
+		return nil, err
+	}
+
+	topics, _ := data.([]*topic.Topic)
+	resolvers := make([]*topicResolver, len(topics))
+	for i, t := range topics {
+		resolvers[i] = &topicResolver{topic: t}
+	}
+	return resolvers, nil
+}
+
+func (r *subscriberResolver) RecentEmails(ctx context.Context, args struct{ Limit int32 }) ([]*emailLogResolver, error) {
+	limit := int(args.Limit)
+	if limit <= 0 {
+		return []*emailLogResolver{}, nil
+	}
+	if limit > maxRecentEmails {
+		limit = maxRecentEmails
+	}
+
+	data, err := loadersFrom(ctx).recentEmails.Load(ctx, recentEmailsKey(r.subscriber.ID, limit))()
+	if err != nil {
+		return nil, err
+	}
+
+	logs, _ := data.([]*notification.EmailLog)
+	resolvers := make([]*emailLogResolver, len(logs))
+	for i, l := range logs {
+		resolvers[i] = &emailLogResolver{log: l}
+	}
+	return resolvers, nil
+}
+
+type subscriberPageResolver struct {
+	items      []*subscriberResolver
+	pagination dtos.PaginationResponse
+}
+
+func (r *subscriberPageResolver) Items() []*subscriberResolver { return r.items }
+func (r *subscriberPageResolver) Pagination() *pageInfoResolver {
+	return &pageInfoResolver{pagination: r.pagination}
+}
+
+type topicResolver struct {
+	topic *topic.Topic
+}
+
+func (r *topicResolver) ID() graphql.ID          { return toID(r.topic.ID) }
+func (r *topicResolver) Name() string            { return r.topic.Name }
+func (r *topicResolver) Description() string     { return r.topic.Description }
+func (r *topicResolver) CreatedAt() graphql.Time { return graphql.Time{Time: r.topic.CreatedAt} }
+func (r *topicResolver) UpdatedAt() graphql.Time { return graphql.Time{Time: r.topic.UpdatedAt} }
+
+func (r *topicResolver) SubscriberCount(ctx context.Context) (int32, error) {
+	data, err := loadersFrom(ctx).subscriberCountByTopic.Load(ctx, idKey(r.topic.ID))()
+	if err != nil {
+		return 0, err
+	}
+	count, _ := data.(int64)
+	return int32(count), nil
+}
+
+type topicPageResolver struct {
+	items      []*topicResolver
+	pagination dtos.PaginationResponse
+}
+
+func (r *topicPageResolver) Items() []*topicResolver { return r.items }
+func (r *topicPageResolver) Pagination() *pageInfoResolver {
+	return &pageInfoResolver{pagination: r.pagination}
+}
+
+type contentResolver struct {
+	content *content.Content
+}
+
+func (r *contentResolver) ID() graphql.ID          { return toID(r.content.ID) }
+func (r *contentResolver) Title() string           { return r.content.Title }
+func (r *contentResolver) Body() string            { return r.content.Body }
+func (r *contentResolver) IsPublished() bool       { return r.content.IsPublished }
+func (r *contentResolver) NotificationsSent() bool { return r.content.NotificationsSent }
+func (r *contentResolver) CreatedAt() graphql.Time { return graphql.Time{Time: r.content.CreatedAt} }
+func (r *contentResolver) UpdatedAt() graphql.Time { return graphql.Time{Time: r.content.UpdatedAt} }
+func (r *contentResolver) PublishedAt() *graphql.Time {
+	if r.content.PublishedAt == nil {
+		return nil
+	}
+	return &graphql.Time{Time: *r.content.PublishedAt}
+}
+
+func (r *contentResolver) Topic(ctx context.Context) (*topicResolver, error) {
+	data, err := loadersFrom(ctx).topicByID.Load(ctx, idKey(r.content.TopicID))()
+	if err != nil {
+		return nil, err
+	}
+	t, _ := data.(*topic.Topic)
+	if t == nil {
+		return nil, nil
+	}
+	return &topicResolver{topic: t}, nil
+}
+
+type contentPageResolver struct {
+	items      []*contentResolver
+	pagination dtos.PaginationResponse
+}
+
+func (r *contentPageResolver) Items() []*contentResolver { return r.items }
+func (r *contentPageResolver) Pagination() *pageInfoResolver {
+	return &pageInfoResolver{pagination: r.pagination}
+}
+
+type emailLogResolver struct {
+	log *notification.EmailLog
+}
+
+func (r *emailLogResolver) ID() graphql.ID          { return toID(r.log.ID) }
+func (r *emailLogResolver) EmailAddress() string    { return r.log.EmailAddress }
+func (r *emailLogResolver) Subject() string         { return r.log.Subject }
+func (r *emailLogResolver) Status() string          { return r.log.Status }
+func (r *emailLogResolver) ErrorMessage() *string   { return r.log.ErrorMessage }
+func (r *emailLogResolver) RetryCount() int32       { return int32(r.log.RetryCount) }
+func (r *emailLogResolver) CreatedAt() graphql.Time { return graphql.Time{Time: r.log.CreatedAt} }
+func (r *emailLogResolver) SentAt() *graphql.Time {
+	if r.log.SentAt == nil {
+		return nil
+	}
+	return &graphql.Time{Time: *r.log.SentAt}
+}
+
+func (r *emailLogResolver) Subscriber(ctx context.Context) (*subscriberResolver, error) {
+	data, err := loadersFrom(ctx).subscriberByID.Load(ctx, idKey(r.log.SubscriberID))()
+	if err != nil {
+		return nil, err
+	}
+	s, _ := data.(*subscriber.Subscriber)
+	if s == nil {
+		return nil, nil
+	}
+	return &subscriberResolver{subscriber: s}, nil
+}
+
+func (r *emailLogResolver) Content(ctx context.Context) (*contentResolver, error) {
+	data, err := loadersFrom(ctx).contentByID.Load(ctx, idKey(r.log.ContentID))()
+	if err != nil {
+		return nil, err
+	}
+	c, _ := data.(*content.Content)
+	if c == nil {
+		return nil, nil
+	}
+	return &contentResolver{content: c}, nil
+}
+
+type emailLogPageResolver struct {
+	items      []*emailLogResolver
+	pagination dtos.PaginationResponse
+}
+
+func (r *emailLogPageResolver) Items() []*emailLogResolver { return r.items }
+func (r *emailLogPageResolver) Pagination() *pageInfoResolver {
+	return &pageInfoResolver{pagination: r.pagination}
+}
+
+// statsResolver computes each count only when the field is requested
+type statsResolver struct {
+	services Services
+}
+
+func (r *statsResolver) Subscribers(ctx context.Context) (int32, error) {
+	_, total, err := r.services.Subscriber.GetAllSubscribersWithPagination(ctx, 0, 1)
+	return int32(total), err
+}
+
+func (r *statsResolver) Topics(ctx context.Context) (int32, error) {
+	_, total, err := r.services.Topic.GetAllTopicsWithPagination(ctx, 0, 1)
+	return int32(total), err
+}
+
+func (r *statsResolver) Contents(ctx context.Context) (int32, error) {
+	_, total, err := r.services.Content.GetAllContentWithPagination(ctx, 0, 1)
+	return int32(total), err
+}
+
+func (r *statsResolver) Emails(ctx context.Context) (*emailStatsResolver, error) {
+	counts, err := r.services.Notification.CountEmailLogsByStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &emailStatsResolver{counts: counts}, nil
+}
+
+type emailStatsResolver struct {
+	counts map[string]int64
+}
+
+func (r *emailStatsResolver) Pending() int32 { return int32(r.counts[constants.StatusPending]) }
+func (r *emailStatsResolver) Sent() int32    { return int32(r.counts[constants.StatusSent]) }
+func (r *emailStatsResolver) Failed() int32  { return int32(r.counts[constants.StatusFailed]) }
+func (r *emailStatsResolver) Total() int32 {
+	var total int64
+	for _, count := range r.counts {
+		total += count
+	}
+	return int32(total)
+}
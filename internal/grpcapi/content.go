@@ -0,0 +1,211 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"newsletter-service/internal/constants"
+	"newsletter-service/internal/dtos"
+	"newsletter-service/internal/events"
+	"newsletter-service/internal/grpcapi/pb"
+	"newsletter-service/internal/logger"
+	"newsletter-service/internal/services/approval"
+	"newsletter-service/internal/services/audit"
+	"newsletter-service/internal/services/content"
+	"newsletter-service/internal/services/lint"
+)
+
+type contentServer struct {
+	pb.UnimplementedContentServiceServer
+	contentService  content.Service
+	lintService     lint.Service
+	approvalService approval.Service
+	auditService    audit.Service
+	eventBus        *events.Bus
+	validate        *validator.Validate
+}
+
+func (s *contentServer) CreateContent(ctx context.Context, req *pb.CreateContentRequest) (*pb.Content, error) {
+	dto := dtos.CreateContentRequest{TopicID: uint(req.GetTopicId()), Title: req.GetTitle(), Body: req.GetBody()}
+	if err := s.validate.Struct(dto); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	contentModel := &content.Content{
+		TopicID: dto.TopicID,
+		Title:   dto.Title,
+		Body:    dto.Body,
+	}
+	if err := s.contentService.CreateContent(ctx, contentModel); err != nil {
+		if errors.Is(err, content.ErrTopicArchived) {
+			return nil, status.Error(codes.FailedPrecondition, constants.ErrTopicArchived)
+		}
+		return nil, internalError(err)
+	}
+
+	recordAudit(ctx, s.auditService, audit.ActionCreate, audit.EntityContent, contentModel.ID, nil, contentModel)
+	return contentToProto(contentModel), nil
+}
+
+func (s *contentServer) GetContent(ctx context.Context, req *pb.IDRequest) (*pb.Content, error) {
+	contentModel, err := s.contentService.GetContentByID(ctx, uint(req.GetId()))
+	if err != nil {
+		return nil, status.Error(codes.NotFound, constants.ErrContentNotFound)
+	}
+	return contentToProto(contentModel), nil
+}
+
+func (s *contentServer) ListContents(ctx context.Context, req *pb.PageRequest) (*pb.ListContentsResponse, error) {
+	page, pageSize, offset, err := pagination(req)
+	if err != nil {
+		return nil, err
+	}
+
+	contents, total, err := s.contentService.GetAllContentWithPagination(ctx, offset, pageSize)
+	if err != nil {
+		return nil, internalError(err)
+	}
+
+	resp := &pb.ListContentsResponse{Page: pageInfo(page, pageSize, total)}
+	for _, c := range contents {
+		resp.Contents = append(resp.Contents, contentToProto(c))
+	}
+	return resp, nil
+}
+
+func (s *contentServer) UpdateContent(ctx context.Context, req *pb.UpdateContentRequest) (*pb.Content, error) {
+	dto := dtos.UpdateContentRequest{TopicID: uint(req.GetTopicId()), Title: req.GetTitle(), Body: req.GetBody()}
+	if err := s.validate.Struct(dto); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	id := uint(req.GetId())
+	before, err := s.contentService.GetContentByID(ctx, id)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, constants.ErrContentNotFound)
+	}
+
+	updates := make(map[string]interface{})
+	if req.TopicId != nil {
+		updates["topic_id"] = dto.TopicID
+	}
+	if req.Title != nil {
+		updates["title"] = dto.Title
+	}
+	if req.Body != nil {
+		updates["body"] = dto.Body
+	}
+
+	if err := s.contentService.UpdateContent(ctx, id, updates); err != nil {
+		if errors.Is(err, content.ErrTopicArchived) {
+			return nil, status.Error(codes.FailedPrecondition, constants.ErrTopicArchived)
+		}
+		return nil, internalError(err)
+	}
+
+	if err := s.approvalService.Reopen(ctx, id, actorFromContext(ctx)); err != nil {
+		logger.Warn(ctx, "Failed to return edited content %d to draft: %v", id, err)
+	}
+
+	after, err := s.contentService.GetContentByID(ctx, id)
+	if err != nil {
+		return nil, internalError(err)
+	}
+
+	recordAudit(ctx, s.auditService, audit.ActionUpdate, audit.EntityContent, id, before, after)
+	return contentToProto(after), nil
+}
+
+func (s *contentServer) DeleteContent(ctx context.Context, req *pb.IDRequest) (*emptypb.Empty, error) {
+	id := uint(req.GetId())
+	before, err := s.contentService.GetContentByID(ctx, id)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, constants.ErrContentNotFound)
+	}
+
+	if err := s.contentService.DeleteContent(ctx, id); err != nil {
+		return nil, internalError(err)
+	}
+
+	recordAudit(ctx, s.auditService, audit.ActionDelete, audit.EntityContent, id, before, nil)
+	return &emptypb.Empty{}, nil
+}
+
+func (s *contentServer) PublishContent(ctx context.Context, req *pb.IDRequest) (*pb.Content, error) {
+	id := uint(req.GetId())
+	before, err := s.contentService.GetContentByID(ctx, id)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, constants.ErrContentNotFound)
+	}
+
+	if err := s.approvalService.CheckPublish(ctx, id, actorFromContext(ctx)); err != nil {
+		switch {
+		case errors.Is(err, approval.ErrNotApproved):
+			return nil, status.Error(codes.FailedPrecondition, constants.ErrContentNotApproved)
+		case errors.Is(err, approval.ErrNotPublisher):
+			return nil, status.Error(codes.PermissionDenied, constants.ErrNotPublisher)
+		default:
+			return nil, internalError(err)
+		}
+	}
+
+	if report, err := s.lintService.PrepareForPublish(ctx, id); err != nil {
+		if errors.Is(err, lint.ErrPublishBlocked) {
+			return nil, status.Error(codes.FailedPrecondition, lintErrorMessage(report))
+		}
+		return nil, internalError(err)
+	}
+
+	if err := s.contentService.PublishContent(ctx, id); err != nil {
+		return nil, internalError(err)
+	}
+	if err := s.approvalService.MarkPublished(ctx, id, actorFromContext(ctx)); err != nil {
+		logger.Warn(ctx, "Failed to record approval state of published content %d: %v", id, err)
+	}
+
+	after, err := s.contentService.GetContentByID(ctx, id)
+	if err != nil {
+		return nil, internalError(err)
+	}
+
+	recordAudit(ctx, s.auditService, audit.ActionPublish, audit.EntityContent, id, before, after)
+	s.eventBus.Emit(ctx, events.ContentPublished, after)
+	return contentToProto(after), nil
+}
+
+// lintErrorMessage lists the errors blocking a publish, since the gRPC API has no lint report message
+func lintErrorMessage(report *lint.Report) string {
+	var errs []string
+	for _, issue := range report.Issues {
+		if issue.Severity != lint.SeverityError {
+			continue
+		}
+		if issue.Target != "" {
+			errs = append(errs, fmt.Sprintf("%s (%s)", issue.Message, issue.Target))
+		} else {
+			errs = append(errs, issue.Message)
+		}
+	}
+	return constants.ErrContentLintFailed + ": " + strings.Join(errs, "; ")
+}
+
+func contentToProto(c *content.Content) *pb.Content {
+	return &pb.Content{
+		Id:          uint32(c.ID),
+		TopicId:     uint32(c.TopicID),
+		Title:       c.Title,
+		Body:        c.Body,
+		IsPublished: c.IsPublished,
+		PublishedAt: timestamp(c.PublishedAt),
+		CreatedAt:   timestamppb.New(c.CreatedAt),
+		UpdatedAt:   timestamppb.New(c.UpdatedAt),
+	}
+}
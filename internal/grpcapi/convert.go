@@ -0,0 +1,98 @@
+package grpcapi
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"newsletter-service/internal/dtos"
+	apperrors "newsletter-service/internal/errors"
+	"newsletter-service/internal/grpcapi/pb"
+	"newsletter-service/internal/logger"
+	"newsletter-service/internal/services/audit"
+)
+
+// pagination converts a PageRequest into an offset/limit pair using the REST API defaults
+func pagination(req *pb.PageRequest) (page, pageSize, offset int, err error) {
+	p := dtos.PaginationRequest{Page: int(req.GetPage()), PageSize: int(req.GetPageSize())}
+	if p.Page < 0 || p.PageSize < 0 || p.PageSize > 100 {
+		return 0, 0, 0, status.Error(codes.InvalidArgument, "invalid pagination parameters")
+	}
+	page, pageSize = p.GetDefaults()
+	return page, pageSize, p.CalculateOffset(), nil
+}
+
+func pageInfo(page, pageSize int, total int64) *pb.PageInfo {
+	resp := dtos.CreatePaginationResponse(page, pageSize, total)
+	return &pb.PageInfo{
+		Page:       int32(resp.Page),
+		PageSize:   int32(resp.PageSize),
+		Total:      resp.TotalItems,
+		TotalPages: int32(resp.TotalPages),
+	}
+}
+
+func timestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+// internalError maps a service failure the RPC has no specific status for, classified the same way as the REST
+// API's errors. Internal failures are logged here and reach the client only as a generic message.
+func internalError(err error) error {
+	appErr := apperrors.Classify(err)
+	code := codes.Internal
+	switch appErr.Code {
+	case apperrors.CodeValidation:
+		code = codes.InvalidArgument
+	case apperrors.CodeNotFound:
+		code = codes.NotFound
+	case apperrors.CodeConflict:
+		code = codes.AlreadyExists
+	default:
+		logger.Error(context.Background(), "gRPC internal error: %v", err)
+	}
+	return status.Error(code, appErr.Message)
+}
+
+// recordAudit records an audit entry for a gRPC call, mirroring the REST handlers
+func recordAudit(ctx context.Context, auditService audit.Service, action, entityType string, entityID uint, before, after interface{}) {
+	if auditService == nil {
+		return
+	}
+
+	actorType, _ := ctx.Value(contextKeyAuthMethod).(string)
+	entry := audit.Entry{
+		Actor:      actorFromContext(ctx),
+		ActorType:  actorType,
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Before:     before,
+		After:      after,
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		entry.IPAddress = p.Addr.String()
+	}
+	if entry.ActorType == "" {
+		entry.ActorType = "unknown"
+	}
+
+	if err := auditService.Record(ctx, entry); err != nil {
+		logger.Warn(ctx, "Failed to record audit log for %s %s %d: %v", action, entityType, entityID, err)
+	}
+}
+
+// actorFromContext names the authenticated caller as the audit log and approval workflow record it
+func actorFromContext(ctx context.Context) string {
+	if actor, _ := ctx.Value(contextKeyActor).(string); actor != "" {
+		return actor
+	}
+	return "anonymous"
+}
@@ -0,0 +1,106 @@
+package grpcapi
+
+import (
+	"context"
+	"crypto/subtle"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"newsletter-service/internal/config"
+	"newsletter-service/internal/constants"
+	"newsletter-service/internal/logger"
+	"newsletter-service/internal/services/auth"
+	"newsletter-service/internal/tenant"
+)
+
+type contextKey string
+
+const (
+	contextKeyActor      contextKey = "grpc_actor"
+	contextKeyAuthMethod contextKey = "grpc_auth_method"
+)
+
+// AuthInterceptor authenticates calls with a bearer token in the "authorization" metadata.
+// Either a JWT access token issued by /auth/login or the configured service token is accepted.
+// Calls are scoped to the organization in the "x-organization-id" metadata, or the default organization.
+func AuthInterceptor(cfg config.GRPCConfig, authService auth.Service) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		token := bearerToken(ctx)
+		if token == "" {
+			return nil, status.Error(codes.Unauthenticated, constants.ErrUnauthorized)
+		}
+
+		organizationID, ok := organizationFromMetadata(ctx)
+		if !ok {
+			return nil, status.Error(codes.InvalidArgument, constants.ErrInvalidOrganizationID)
+		}
+		ctx = tenant.WithOrganization(ctx, organizationID)
+
+		if cfg.ServiceToken != "" && subtle.ConstantTimeCompare([]byte(token), []byte(cfg.ServiceToken)) == 1 {
+			ctx = context.WithValue(ctx, contextKeyActor, constants.GRPCServiceActor)
+			ctx = context.WithValue(ctx, contextKeyAuthMethod, constants.AuthMethodServiceToken)
+			return handler(ctx, req)
+		}
+
+		if authService == nil {
+			return nil, status.Error(codes.Unauthenticated, constants.ErrInvalidToken)
+		}
+		claims, err := authService.ValidateAccessToken(ctx, token)
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, constants.ErrInvalidToken)
+		}
+
+		ctx = context.WithValue(ctx, contextKeyActor, claims.Subject)
+		ctx = context.WithValue(ctx, contextKeyAuthMethod, constants.AuthMethodJWT)
+		return handler(ctx, req)
+	}
+}
+
+// LoggingInterceptor logs every call with its status code and latency
+func LoggingInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+
+		code := status.Code(err)
+		if err != nil && code != codes.NotFound && code != codes.InvalidArgument && code != codes.Unauthenticated {
+			logger.Error(ctx, "gRPC %s %s %v: %v", info.FullMethod, code, time.Since(start), err)
+		} else {
+			logger.Info(ctx, "gRPC %s %s %v", info.FullMethod, code, time.Since(start))
+		}
+		return resp, err
+	}
+}
+
+func bearerToken(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	for _, value := range md.Get("authorization") {
+		if strings.HasPrefix(value, constants.BearerTokenType+" ") {
+			return strings.TrimPrefix(value, constants.BearerTokenType+" ")
+		}
+	}
+	return ""
+}
+
+// organizationFromMetadata returns the organization a call names, false when the metadata value is malformed
+func organizationFromMetadata(ctx context.Context) (uint, bool) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(strings.ToLower(constants.HeaderOrganizationID))
+	if len(values) == 0 {
+		return tenant.DefaultOrganizationID, true
+	}
+	id, err := strconv.ParseUint(values[0], 10, 32)
+	if err != nil || id == 0 {
+		return 0, false
+	}
+	return uint(id), true
+}
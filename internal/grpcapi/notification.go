@@ -0,0 +1,92 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"newsletter-service/internal/constants"
+	"newsletter-service/internal/grpcapi/pb"
+	"newsletter-service/internal/schedulers"
+	"newsletter-service/internal/services/notification"
+)
+
+type notificationServer struct {
+	pb.UnimplementedNotificationServiceServer
+	notificationService notification.Service
+	scheduler           *schedulers.NotificationScheduler
+}
+
+func (s *notificationServer) SendNotifications(ctx context.Context, req *pb.SendNotificationsRequest) (*emptypb.Empty, error) {
+	if req.GetContentId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, constants.ErrInvalidContentID)
+	}
+
+	err := s.scheduler.QueueContent(ctx, uint(req.GetContentId()))
+	switch {
+	case errors.Is(err, schedulers.ErrContentLocked):
+		return nil, status.Error(codes.Aborted, constants.ErrContentSendInProgress)
+	case errors.Is(err, schedulers.ErrContentSent):
+		return nil, status.Error(codes.FailedPrecondition, constants.ErrContentAlreadySent)
+	case errors.Is(err, schedulers.ErrContentNotPublished):
+		return nil, status.Error(codes.FailedPrecondition, constants.ErrContentNotPublished)
+	case err != nil:
+		return nil, internalError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *notificationServer) RetryFailedNotifications(ctx context.Context, _ *emptypb.Empty) (*emptypb.Empty, error) {
+	if err := s.notificationService.RetryFailedEmails(ctx); err != nil {
+		return nil, internalError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *notificationServer) GetEmailLog(ctx context.Context, req *pb.IDRequest) (*pb.EmailLog, error) {
+	log, err := s.notificationService.GetEmailLogByID(ctx, uint(req.GetId()))
+	if err != nil {
+		return nil, status.Error(codes.NotFound, constants.ErrEmailLogNotFound)
+	}
+	return emailLogToProto(log), nil
+}
+
+func (s *notificationServer) ListEmailLogs(ctx context.Context, req *pb.PageRequest) (*pb.ListEmailLogsResponse, error) {
+	page, pageSize, offset, err := pagination(req)
+	if err != nil {
+		return nil, err
+	}
+
+	logs, total, err := s.notificationService.GetEmailLogsWithPagination(ctx, offset, pageSize)
+	if err != nil {
+		return nil, internalError(err)
+	}
+
+	resp := &pb.ListEmailLogsResponse{Page: pageInfo(page, pageSize, total)}
+	for _, l := range logs {
+		resp.EmailLogs = append(resp.EmailLogs, emailLogToProto(l))
+	}
+	return resp, nil
+}
+
+func emailLogToProto(l *notification.EmailLog) *pb.EmailLog {
+	resp := &pb.EmailLog{
+		Id:           uint32(l.ID),
+		SubscriberId: uint32(l.SubscriberID),
+		ContentId:    uint32(l.ContentID),
+		EmailAddress: l.EmailAddress,
+		Subject:      l.Subject,
+		Status:       l.Status,
+		SentAt:       timestamp(l.SentAt),
+		RetryCount:   int32(l.RetryCount),
+		CreatedAt:    timestamppb.New(l.CreatedAt),
+	}
+	if l.ErrorMessage != nil {
+		resp.ErrorMessage = *l.ErrorMessage
+	}
+	return resp
+}
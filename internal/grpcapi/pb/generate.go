@@ -0,0 +1,4 @@
+// Package pb contains the generated protobuf and gRPC code for proto/newsletter/v1.
+package pb
+
+//go:generate protoc -I ../../../proto --go_out=../../.. --go_opt=module=newsletter-service --go-grpc_out=../../.. --go-grpc_opt=module=newsletter-service newsletter/v1/newsletter.proto
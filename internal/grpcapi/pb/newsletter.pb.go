@@ -0,0 +1,1530 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: newsletter/v1/newsletter.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Pagination shared by list requests. page_size defaults to 10, max 100.
+type PageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PageRequest) Reset() {
+	*x = PageRequest{}
+	mi := &file_newsletter_v1_newsletter_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PageRequest) ProtoMessage() {}
+
+func (x *PageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_newsletter_v1_newsletter_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PageRequest.ProtoReflect.Descriptor instead.
+func (*PageRequest) Descriptor() ([]byte, []int) {
+	return file_newsletter_v1_newsletter_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PageRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *PageRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type PageInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Total         int64                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	TotalPages    int32                  `protobuf:"varint,4,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PageInfo) Reset() {
+	*x = PageInfo{}
+	mi := &file_newsletter_v1_newsletter_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PageInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PageInfo) ProtoMessage() {}
+
+func (x *PageInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_newsletter_v1_newsletter_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PageInfo.ProtoReflect.Descriptor instead.
+func (*PageInfo) Descriptor() ([]byte, []int) {
+	return file_newsletter_v1_newsletter_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PageInfo) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *PageInfo) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *PageInfo) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *PageInfo) GetTotalPages() int32 {
+	if x != nil {
+		return x.TotalPages
+	}
+	return 0
+}
+
+type IDRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IDRequest) Reset() {
+	*x = IDRequest{}
+	mi := &file_newsletter_v1_newsletter_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IDRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IDRequest) ProtoMessage() {}
+
+func (x *IDRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_newsletter_v1_newsletter_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IDRequest.ProtoReflect.Descriptor instead.
+func (*IDRequest) Descriptor() ([]byte, []int) {
+	return file_newsletter_v1_newsletter_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *IDRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type Topic struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Topic) Reset() {
+	*x = Topic{}
+	mi := &file_newsletter_v1_newsletter_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Topic) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Topic) ProtoMessage() {}
+
+func (x *Topic) ProtoReflect() protoreflect.Message {
+	mi := &file_newsletter_v1_newsletter_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Topic.ProtoReflect.Descriptor instead.
+func (*Topic) Descriptor() ([]byte, []int) {
+	return file_newsletter_v1_newsletter_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Topic) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Topic) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Topic) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Topic) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Topic) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type CreateTopicRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTopicRequest) Reset() {
+	*x = CreateTopicRequest{}
+	mi := &file_newsletter_v1_newsletter_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTopicRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTopicRequest) ProtoMessage() {}
+
+func (x *CreateTopicRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_newsletter_v1_newsletter_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTopicRequest.ProtoReflect.Descriptor instead.
+func (*CreateTopicRequest) Descriptor() ([]byte, []int) {
+	return file_newsletter_v1_newsletter_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CreateTopicRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateTopicRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type UpdateTopicRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          *string                `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	Description   *string                `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTopicRequest) Reset() {
+	*x = UpdateTopicRequest{}
+	mi := &file_newsletter_v1_newsletter_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTopicRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTopicRequest) ProtoMessage() {}
+
+func (x *UpdateTopicRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_newsletter_v1_newsletter_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTopicRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTopicRequest) Descriptor() ([]byte, []int) {
+	return file_newsletter_v1_newsletter_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UpdateTopicRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateTopicRequest) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *UpdateTopicRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+type ListTopicsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Topics        []*Topic               `protobuf:"bytes,1,rep,name=topics,proto3" json:"topics,omitempty"`
+	Page          *PageInfo              `protobuf:"bytes,2,opt,name=page,proto3" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTopicsResponse) Reset() {
+	*x = ListTopicsResponse{}
+	mi := &file_newsletter_v1_newsletter_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTopicsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTopicsResponse) ProtoMessage() {}
+
+func (x *ListTopicsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_newsletter_v1_newsletter_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTopicsResponse.ProtoReflect.Descriptor instead.
+func (*ListTopicsResponse) Descriptor() ([]byte, []int) {
+	return file_newsletter_v1_newsletter_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListTopicsResponse) GetTopics() []*Topic {
+	if x != nil {
+		return x.Topics
+	}
+	return nil
+}
+
+func (x *ListTopicsResponse) GetPage() *PageInfo {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+type Subscriber struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name             string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Email            string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	IsActive         bool                   `protobuf:"varint,4,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	SubscribedTopics []string               `protobuf:"bytes,5,rep,name=subscribed_topics,json=subscribedTopics,proto3" json:"subscribed_topics,omitempty"`
+	CreatedAt        *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt        *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Subscriber) Reset() {
+	*x = Subscriber{}
+	mi := &file_newsletter_v1_newsletter_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Subscriber) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Subscriber) ProtoMessage() {}
+
+func (x *Subscriber) ProtoReflect() protoreflect.Message {
+	mi := &file_newsletter_v1_newsletter_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Subscriber.ProtoReflect.Descriptor instead.
+func (*Subscriber) Descriptor() ([]byte, []int) {
+	return file_newsletter_v1_newsletter_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Subscriber) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Subscriber) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Subscriber) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *Subscriber) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+func (x *Subscriber) GetSubscribedTopics() []string {
+	if x != nil {
+		return x.SubscribedTopics
+	}
+	return nil
+}
+
+func (x *Subscriber) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Subscriber) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type CreateSubscriberRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Name             string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Email            string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	SubscribedTopics []string               `protobuf:"bytes,3,rep,name=subscribed_topics,json=subscribedTopics,proto3" json:"subscribed_topics,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *CreateSubscriberRequest) Reset() {
+	*x = CreateSubscriberRequest{}
+	mi := &file_newsletter_v1_newsletter_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSubscriberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSubscriberRequest) ProtoMessage() {}
+
+func (x *CreateSubscriberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_newsletter_v1_newsletter_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSubscriberRequest.ProtoReflect.Descriptor instead.
+func (*CreateSubscriberRequest) Descriptor() ([]byte, []int) {
+	return file_newsletter_v1_newsletter_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CreateSubscriberRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateSubscriberRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *CreateSubscriberRequest) GetSubscribedTopics() []string {
+	if x != nil {
+		return x.SubscribedTopics
+	}
+	return nil
+}
+
+type UpdateSubscriberRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name     *string                `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	Email    *string                `protobuf:"bytes,3,opt,name=email,proto3,oneof" json:"email,omitempty"`
+	IsActive *bool                  `protobuf:"varint,4,opt,name=is_active,json=isActive,proto3,oneof" json:"is_active,omitempty"`
+	// Replaces the subscriber's topics when non-empty
+	SubscribedTopics []string `protobuf:"bytes,5,rep,name=subscribed_topics,json=subscribedTopics,proto3" json:"subscribed_topics,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *UpdateSubscriberRequest) Reset() {
+	*x = UpdateSubscriberRequest{}
+	mi := &file_newsletter_v1_newsletter_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateSubscriberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSubscriberRequest) ProtoMessage() {}
+
+func (x *UpdateSubscriberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_newsletter_v1_newsletter_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSubscriberRequest.ProtoReflect.Descriptor instead.
+func (*UpdateSubscriberRequest) Descriptor() ([]byte, []int) {
+	return file_newsletter_v1_newsletter_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UpdateSubscriberRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateSubscriberRequest) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *UpdateSubscriberRequest) GetEmail() string {
+	if x != nil && x.Email != nil {
+		return *x.Email
+	}
+	return ""
+}
+
+func (x *UpdateSubscriberRequest) GetIsActive() bool {
+	if x != nil && x.IsActive != nil {
+		return *x.IsActive
+	}
+	return false
+}
+
+func (x *UpdateSubscriberRequest) GetSubscribedTopics() []string {
+	if x != nil {
+		return x.SubscribedTopics
+	}
+	return nil
+}
+
+type ListSubscribersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subscribers   []*Subscriber          `protobuf:"bytes,1,rep,name=subscribers,proto3" json:"subscribers,omitempty"`
+	Page          *PageInfo              `protobuf:"bytes,2,opt,name=page,proto3" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSubscribersResponse) Reset() {
+	*x = ListSubscribersResponse{}
+	mi := &file_newsletter_v1_newsletter_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSubscribersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSubscribersResponse) ProtoMessage() {}
+
+func (x *ListSubscribersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_newsletter_v1_newsletter_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSubscribersResponse.ProtoReflect.Descriptor instead.
+func (*ListSubscribersResponse) Descriptor() ([]byte, []int) {
+	return file_newsletter_v1_newsletter_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListSubscribersResponse) GetSubscribers() []*Subscriber {
+	if x != nil {
+		return x.Subscribers
+	}
+	return nil
+}
+
+func (x *ListSubscribersResponse) GetPage() *PageInfo {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+type SubscribeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SubscriberId  uint32                 `protobuf:"varint,1,opt,name=subscriber_id,json=subscriberId,proto3" json:"subscriber_id,omitempty"`
+	TopicId       uint32                 `protobuf:"varint,2,opt,name=topic_id,json=topicId,proto3" json:"topic_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeRequest) Reset() {
+	*x = SubscribeRequest{}
+	mi := &file_newsletter_v1_newsletter_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeRequest) ProtoMessage() {}
+
+func (x *SubscribeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_newsletter_v1_newsletter_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeRequest) Descriptor() ([]byte, []int) {
+	return file_newsletter_v1_newsletter_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SubscribeRequest) GetSubscriberId() uint32 {
+	if x != nil {
+		return x.SubscriberId
+	}
+	return 0
+}
+
+func (x *SubscribeRequest) GetTopicId() uint32 {
+	if x != nil {
+		return x.TopicId
+	}
+	return 0
+}
+
+type Content struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TopicId       uint32                 `protobuf:"varint,2,opt,name=topic_id,json=topicId,proto3" json:"topic_id,omitempty"`
+	Title         string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Body          string                 `protobuf:"bytes,4,opt,name=body,proto3" json:"body,omitempty"`
+	IsPublished   bool                   `protobuf:"varint,5,opt,name=is_published,json=isPublished,proto3" json:"is_published,omitempty"`
+	PublishedAt   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=published_at,json=publishedAt,proto3" json:"published_at,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Content) Reset() {
+	*x = Content{}
+	mi := &file_newsletter_v1_newsletter_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Content) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Content) ProtoMessage() {}
+
+func (x *Content) ProtoReflect() protoreflect.Message {
+	mi := &file_newsletter_v1_newsletter_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Content.ProtoReflect.Descriptor instead.
+func (*Content) Descriptor() ([]byte, []int) {
+	return file_newsletter_v1_newsletter_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Content) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Content) GetTopicId() uint32 {
+	if x != nil {
+		return x.TopicId
+	}
+	return 0
+}
+
+func (x *Content) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Content) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+func (x *Content) GetIsPublished() bool {
+	if x != nil {
+		return x.IsPublished
+	}
+	return false
+}
+
+func (x *Content) GetPublishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PublishedAt
+	}
+	return nil
+}
+
+func (x *Content) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Content) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type CreateContentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TopicId       uint32                 `protobuf:"varint,1,opt,name=topic_id,json=topicId,proto3" json:"topic_id,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Body          string                 `protobuf:"bytes,3,opt,name=body,proto3" json:"body,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateContentRequest) Reset() {
+	*x = CreateContentRequest{}
+	mi := &file_newsletter_v1_newsletter_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateContentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateContentRequest) ProtoMessage() {}
+
+func (x *CreateContentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_newsletter_v1_newsletter_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateContentRequest.ProtoReflect.Descriptor instead.
+func (*CreateContentRequest) Descriptor() ([]byte, []int) {
+	return file_newsletter_v1_newsletter_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CreateContentRequest) GetTopicId() uint32 {
+	if x != nil {
+		return x.TopicId
+	}
+	return 0
+}
+
+func (x *CreateContentRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreateContentRequest) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+type UpdateContentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TopicId       *uint32                `protobuf:"varint,2,opt,name=topic_id,json=topicId,proto3,oneof" json:"topic_id,omitempty"`
+	Title         *string                `protobuf:"bytes,3,opt,name=title,proto3,oneof" json:"title,omitempty"`
+	Body          *string                `protobuf:"bytes,4,opt,name=body,proto3,oneof" json:"body,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateContentRequest) Reset() {
+	*x = UpdateContentRequest{}
+	mi := &file_newsletter_v1_newsletter_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateContentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateContentRequest) ProtoMessage() {}
+
+func (x *UpdateContentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_newsletter_v1_newsletter_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateContentRequest.ProtoReflect.Descriptor instead.
+func (*UpdateContentRequest) Descriptor() ([]byte, []int) {
+	return file_newsletter_v1_newsletter_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *UpdateContentRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateContentRequest) GetTopicId() uint32 {
+	if x != nil && x.TopicId != nil {
+		return *x.TopicId
+	}
+	return 0
+}
+
+func (x *UpdateContentRequest) GetTitle() string {
+	if x != nil && x.Title != nil {
+		return *x.Title
+	}
+	return ""
+}
+
+func (x *UpdateContentRequest) GetBody() string {
+	if x != nil && x.Body != nil {
+		return *x.Body
+	}
+	return ""
+}
+
+type ListContentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Contents      []*Content             `protobuf:"bytes,1,rep,name=contents,proto3" json:"contents,omitempty"`
+	Page          *PageInfo              `protobuf:"bytes,2,opt,name=page,proto3" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListContentsResponse) Reset() {
+	*x = ListContentsResponse{}
+	mi := &file_newsletter_v1_newsletter_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListContentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListContentsResponse) ProtoMessage() {}
+
+func (x *ListContentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_newsletter_v1_newsletter_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListContentsResponse.ProtoReflect.Descriptor instead.
+func (*ListContentsResponse) Descriptor() ([]byte, []int) {
+	return file_newsletter_v1_newsletter_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListContentsResponse) GetContents() []*Content {
+	if x != nil {
+		return x.Contents
+	}
+	return nil
+}
+
+func (x *ListContentsResponse) GetPage() *PageInfo {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+type EmailLog struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	SubscriberId  uint32                 `protobuf:"varint,2,opt,name=subscriber_id,json=subscriberId,proto3" json:"subscriber_id,omitempty"`
+	ContentId     uint32                 `protobuf:"varint,3,opt,name=content_id,json=contentId,proto3" json:"content_id,omitempty"`
+	EmailAddress  string                 `protobuf:"bytes,4,opt,name=email_address,json=emailAddress,proto3" json:"email_address,omitempty"`
+	Subject       string                 `protobuf:"bytes,5,opt,name=subject,proto3" json:"subject,omitempty"`
+	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	SentAt        *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=sent_at,json=sentAt,proto3" json:"sent_at,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,8,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	RetryCount    int32                  `protobuf:"varint,9,opt,name=retry_count,json=retryCount,proto3" json:"retry_count,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmailLog) Reset() {
+	*x = EmailLog{}
+	mi := &file_newsletter_v1_newsletter_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmailLog) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmailLog) ProtoMessage() {}
+
+func (x *EmailLog) ProtoReflect() protoreflect.Message {
+	mi := &file_newsletter_v1_newsletter_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmailLog.ProtoReflect.Descriptor instead.
+func (*EmailLog) Descriptor() ([]byte, []int) {
+	return file_newsletter_v1_newsletter_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *EmailLog) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *EmailLog) GetSubscriberId() uint32 {
+	if x != nil {
+		return x.SubscriberId
+	}
+	return 0
+}
+
+func (x *EmailLog) GetContentId() uint32 {
+	if x != nil {
+		return x.ContentId
+	}
+	return 0
+}
+
+func (x *EmailLog) GetEmailAddress() string {
+	if x != nil {
+		return x.EmailAddress
+	}
+	return ""
+}
+
+func (x *EmailLog) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *EmailLog) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *EmailLog) GetSentAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SentAt
+	}
+	return nil
+}
+
+func (x *EmailLog) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *EmailLog) GetRetryCount() int32 {
+	if x != nil {
+		return x.RetryCount
+	}
+	return 0
+}
+
+func (x *EmailLog) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type SendNotificationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ContentId     uint32                 `protobuf:"varint,1,opt,name=content_id,json=contentId,proto3" json:"content_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendNotificationsRequest) Reset() {
+	*x = SendNotificationsRequest{}
+	mi := &file_newsletter_v1_newsletter_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendNotificationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendNotificationsRequest) ProtoMessage() {}
+
+func (x *SendNotificationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_newsletter_v1_newsletter_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendNotificationsRequest.ProtoReflect.Descriptor instead.
+func (*SendNotificationsRequest) Descriptor() ([]byte, []int) {
+	return file_newsletter_v1_newsletter_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SendNotificationsRequest) GetContentId() uint32 {
+	if x != nil {
+		return x.ContentId
+	}
+	return 0
+}
+
+type ListEmailLogsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EmailLogs     []*EmailLog            `protobuf:"bytes,1,rep,name=email_logs,json=emailLogs,proto3" json:"email_logs,omitempty"`
+	Page          *PageInfo              `protobuf:"bytes,2,opt,name=page,proto3" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEmailLogsResponse) Reset() {
+	*x = ListEmailLogsResponse{}
+	mi := &file_newsletter_v1_newsletter_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEmailLogsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEmailLogsResponse) ProtoMessage() {}
+
+func (x *ListEmailLogsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_newsletter_v1_newsletter_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEmailLogsResponse.ProtoReflect.Descriptor instead.
+func (*ListEmailLogsResponse) Descriptor() ([]byte, []int) {
+	return file_newsletter_v1_newsletter_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListEmailLogsResponse) GetEmailLogs() []*EmailLog {
+	if x != nil {
+		return x.EmailLogs
+	}
+	return nil
+}
+
+func (x *ListEmailLogsResponse) GetPage() *PageInfo {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+var File_newsletter_v1_newsletter_proto protoreflect.FileDescriptor
+
+const file_newsletter_v1_newsletter_proto_rawDesc = "" +
+	"\n" +
+	"\x1enewsletter/v1/newsletter.proto\x12\rnewsletter.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\">\n" +
+	"\vPageRequest\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\"r\n" +
+	"\bPageInfo\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x03R\x05total\x12\x1f\n" +
+	"\vtotal_pages\x18\x04 \x01(\x05R\n" +
+	"totalPages\"\x1b\n" +
+	"\tIDRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\"\xc3\x01\n" +
+	"\x05Topic\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"J\n" +
+	"\x12CreateTopicRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\"}\n" +
+	"\x12UpdateTopicRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x17\n" +
+	"\x04name\x18\x02 \x01(\tH\x00R\x04name\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x03 \x01(\tH\x01R\vdescription\x88\x01\x01B\a\n" +
+	"\x05_nameB\x0e\n" +
+	"\f_description\"o\n" +
+	"\x12ListTopicsResponse\x12,\n" +
+	"\x06topics\x18\x01 \x03(\v2\x14.newsletter.v1.TopicR\x06topics\x12+\n" +
+	"\x04page\x18\x02 \x01(\v2\x17.newsletter.v1.PageInfoR\x04page\"\x86\x02\n" +
+	"\n" +
+	"Subscriber\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12\x1b\n" +
+	"\tis_active\x18\x04 \x01(\bR\bisActive\x12+\n" +
+	"\x11subscribed_topics\x18\x05 \x03(\tR\x10subscribedTopics\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"p\n" +
+	"\x17CreateSubscriberRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12+\n" +
+	"\x11subscribed_topics\x18\x03 \x03(\tR\x10subscribedTopics\"\xcd\x01\n" +
+	"\x17UpdateSubscriberRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x17\n" +
+	"\x04name\x18\x02 \x01(\tH\x00R\x04name\x88\x01\x01\x12\x19\n" +
+	"\x05email\x18\x03 \x01(\tH\x01R\x05email\x88\x01\x01\x12 \n" +
+	"\tis_active\x18\x04 \x01(\bH\x02R\bisActive\x88\x01\x01\x12+\n" +
+	"\x11subscribed_topics\x18\x05 \x03(\tR\x10subscribedTopicsB\a\n" +
+	"\x05_nameB\b\n" +
+	"\x06_emailB\f\n" +
+	"\n" +
+	"_is_active\"\x83\x01\n" +
+	"\x17ListSubscribersResponse\x12;\n" +
+	"\vsubscribers\x18\x01 \x03(\v2\x19.newsletter.v1.SubscriberR\vsubscribers\x12+\n" +
+	"\x04page\x18\x02 \x01(\v2\x17.newsletter.v1.PageInfoR\x04page\"R\n" +
+	"\x10SubscribeRequest\x12#\n" +
+	"\rsubscriber_id\x18\x01 \x01(\rR\fsubscriberId\x12\x19\n" +
+	"\btopic_id\x18\x02 \x01(\rR\atopicId\"\xb6\x02\n" +
+	"\aContent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x19\n" +
+	"\btopic_id\x18\x02 \x01(\rR\atopicId\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12\x12\n" +
+	"\x04body\x18\x04 \x01(\tR\x04body\x12!\n" +
+	"\fis_published\x18\x05 \x01(\bR\visPublished\x12=\n" +
+	"\fpublished_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\vpublishedAt\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"[\n" +
+	"\x14CreateContentRequest\x12\x19\n" +
+	"\btopic_id\x18\x01 \x01(\rR\atopicId\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x12\n" +
+	"\x04body\x18\x03 \x01(\tR\x04body\"\x9a\x01\n" +
+	"\x14UpdateContentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x1e\n" +
+	"\btopic_id\x18\x02 \x01(\rH\x00R\atopicId\x88\x01\x01\x12\x19\n" +
+	"\x05title\x18\x03 \x01(\tH\x01R\x05title\x88\x01\x01\x12\x17\n" +
+	"\x04body\x18\x04 \x01(\tH\x02R\x04body\x88\x01\x01B\v\n" +
+	"\t_topic_idB\b\n" +
+	"\x06_titleB\a\n" +
+	"\x05_body\"w\n" +
+	"\x14ListContentsResponse\x122\n" +
+	"\bcontents\x18\x01 \x03(\v2\x16.newsletter.v1.ContentR\bcontents\x12+\n" +
+	"\x04page\x18\x02 \x01(\v2\x17.newsletter.v1.PageInfoR\x04page\"\xeb\x02\n" +
+	"\bEmailLog\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12#\n" +
+	"\rsubscriber_id\x18\x02 \x01(\rR\fsubscriberId\x12\x1d\n" +
+	"\n" +
+	"content_id\x18\x03 \x01(\rR\tcontentId\x12#\n" +
+	"\remail_address\x18\x04 \x01(\tR\femailAddress\x12\x18\n" +
+	"\asubject\x18\x05 \x01(\tR\asubject\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x123\n" +
+	"\asent_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x06sentAt\x12#\n" +
+	"\rerror_message\x18\b \x01(\tR\ferrorMessage\x12\x1f\n" +
+	"\vretry_count\x18\t \x01(\x05R\n" +
+	"retryCount\x129\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"9\n" +
+	"\x18SendNotificationsRequest\x12\x1d\n" +
+	"\n" +
+	"content_id\x18\x01 \x01(\rR\tcontentId\"|\n" +
+	"\x15ListEmailLogsResponse\x126\n" +
+	"\n" +
+	"email_logs\x18\x01 \x03(\v2\x17.newsletter.v1.EmailLogR\temailLogs\x12+\n" +
+	"\x04page\x18\x02 \x01(\v2\x17.newsletter.v1.PageInfoR\x04page2\xe8\x02\n" +
+	"\fTopicService\x12F\n" +
+	"\vCreateTopic\x12!.newsletter.v1.CreateTopicRequest\x1a\x14.newsletter.v1.Topic\x12:\n" +
+	"\bGetTopic\x12\x18.newsletter.v1.IDRequest\x1a\x14.newsletter.v1.Topic\x12K\n" +
+	"\n" +
+	"ListTopics\x12\x1a.newsletter.v1.PageRequest\x1a!.newsletter.v1.ListTopicsResponse\x12F\n" +
+	"\vUpdateTopic\x12!.newsletter.v1.UpdateTopicRequest\x1a\x14.newsletter.v1.Topic\x12?\n" +
+	"\vDeleteTopic\x12\x18.newsletter.v1.IDRequest\x1a\x16.google.protobuf.Empty2\xab\x04\n" +
+	"\x11SubscriberService\x12U\n" +
+	"\x10CreateSubscriber\x12&.newsletter.v1.CreateSubscriberRequest\x1a\x19.newsletter.v1.Subscriber\x12D\n" +
+	"\rGetSubscriber\x12\x18.newsletter.v1.IDRequest\x1a\x19.newsletter.v1.Subscriber\x12U\n" +
+	"\x0fListSubscribers\x12\x1a.newsletter.v1.PageRequest\x1a&.newsletter.v1.ListSubscribersResponse\x12U\n" +
+	"\x10UpdateSubscriber\x12&.newsletter.v1.UpdateSubscriberRequest\x1a\x19.newsletter.v1.Subscriber\x12D\n" +
+	"\x10DeleteSubscriber\x12\x18.newsletter.v1.IDRequest\x1a\x16.google.protobuf.Empty\x12D\n" +
+	"\tSubscribe\x12\x1f.newsletter.v1.SubscribeRequest\x1a\x16.google.protobuf.Empty\x12?\n" +
+	"\vUnsubscribe\x12\x18.newsletter.v1.IDRequest\x1a\x16.google.protobuf.Empty2\xc4\x03\n" +
+	"\x0eContentService\x12L\n" +
+	"\rCreateContent\x12#.newsletter.v1.CreateContentRequest\x1a\x16.newsletter.v1.Content\x12>\n" +
+	"\n" +
+	"GetContent\x12\x18.newsletter.v1.IDRequest\x1a\x16.newsletter.v1.Content\x12O\n" +
+	"\fListContents\x12\x1a.newsletter.v1.PageRequest\x1a#.newsletter.v1.ListContentsResponse\x12L\n" +
+	"\rUpdateContent\x12#.newsletter.v1.UpdateContentRequest\x1a\x16.newsletter.v1.Content\x12A\n" +
+	"\rDeleteContent\x12\x18.newsletter.v1.IDRequest\x1a\x16.google.protobuf.Empty\x12B\n" +
+	"\x0ePublishContent\x12\x18.newsletter.v1.IDRequest\x1a\x16.newsletter.v1.Content2\xcc\x02\n" +
+	"\x13NotificationService\x12T\n" +
+	"\x11SendNotifications\x12'.newsletter.v1.SendNotificationsRequest\x1a\x16.google.protobuf.Empty\x12J\n" +
+	"\x18RetryFailedNotifications\x12\x16.google.protobuf.Empty\x1a\x16.google.protobuf.Empty\x12@\n" +
+	"\vGetEmailLog\x12\x18.newsletter.v1.IDRequest\x1a\x17.newsletter.v1.EmailLog\x12Q\n" +
+	"\rListEmailLogs\x12\x1a.newsletter.v1.PageRequest\x1a$.newsletter.v1.ListEmailLogsResponseB+Z)newsletter-service/internal/grpcapi/pb;pbb\x06proto3"
+
+var (
+	file_newsletter_v1_newsletter_proto_rawDescOnce sync.Once
+	file_newsletter_v1_newsletter_proto_rawDescData []byte
+)
+
+func file_newsletter_v1_newsletter_proto_rawDescGZIP() []byte {
+	file_newsletter_v1_newsletter_proto_rawDescOnce.Do(func() {
+		file_newsletter_v1_newsletter_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_newsletter_v1_newsletter_proto_rawDesc), len(file_newsletter_v1_newsletter_proto_rawDesc)))
+	})
+	return file_newsletter_v1_newsletter_proto_rawDescData
+}
+
+var file_newsletter_v1_newsletter_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
+var file_newsletter_v1_newsletter_proto_goTypes = []any{
+	(*PageRequest)(nil),              // 0: newsletter.v1.PageRequest
+	(*PageInfo)(nil),                 // 1: newsletter.v1.PageInfo
+	(*IDRequest)(nil),                // 2: newsletter.v1.IDRequest
+	(*Topic)(nil),                    // 3: newsletter.v1.Topic
+	(*CreateTopicRequest)(nil),       // 4: newsletter.v1.CreateTopicRequest
+	(*UpdateTopicRequest)(nil),       // 5: newsletter.v1.UpdateTopicRequest
+	(*ListTopicsResponse)(nil),       // 6: newsletter.v1.ListTopicsResponse
+	(*Subscriber)(nil),               // 7: newsletter.v1.Subscriber
+	(*CreateSubscriberRequest)(nil),  // 8: newsletter.v1.CreateSubscriberRequest
+	(*UpdateSubscriberRequest)(nil),  // 9: newsletter.v1.UpdateSubscriberRequest
+	(*ListSubscribersResponse)(nil),  // 10: newsletter.v1.ListSubscribersResponse
+	(*SubscribeRequest)(nil),         // 11: newsletter.v1.SubscribeRequest
+	(*Content)(nil),                  // 12: newsletter.v1.Content
+	(*CreateContentRequest)(nil),     // 13: newsletter.v1.CreateContentRequest
+	(*UpdateContentRequest)(nil),     // 14: newsletter.v1.UpdateContentRequest
+	(*ListContentsResponse)(nil),     // 15: newsletter.v1.ListContentsResponse
+	(*EmailLog)(nil),                 // 16: newsletter.v1.EmailLog
+	(*SendNotificationsRequest)(nil), // 17: newsletter.v1.SendNotificationsRequest
+	(*ListEmailLogsResponse)(nil),    // 18: newsletter.v1.ListEmailLogsResponse
+	(*timestamppb.Timestamp)(nil),    // 19: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),            // 20: google.protobuf.Empty
+}
+var file_newsletter_v1_newsletter_proto_depIdxs = []int32{
+	19, // 0: newsletter.v1.Topic.created_at:type_name -> google.protobuf.Timestamp
+	19, // 1: newsletter.v1.Topic.updated_at:type_name -> google.protobuf.Timestamp
+	3,  // 2: newsletter.v1.ListTopicsResponse.topics:type_name -> newsletter.v1.Topic
+	1,  // 3: newsletter.v1.ListTopicsResponse.page:type_name -> newsletter.v1.PageInfo
+	19, // 4: newsletter.v1.Subscriber.created_at:type_name -> google.protobuf.Timestamp
+	19, // 5: newsletter.v1.Subscriber.updated_at:type_name -> google.protobuf.Timestamp
+	7,  // 6: newsletter.v1.ListSubscribersResponse.subscribers:type_name -> newsletter.v1.Subscriber
+	1,  // 7: newsletter.v1.ListSubscribersResponse.page:type_name -> newsletter.v1.PageInfo
+	19, // 8: newsletter.v1.Content.published_at:type_name -> google.protobuf.Timestamp
+	19, // 9: newsletter.v1.Content.created_at:type_name -> google.protobuf.Timestamp
+	19, // 10: newsletter.v1.Content.updated_at:type_name -> google.protobuf.Timestamp
+	12, // 11: newsletter.v1.ListContentsResponse.contents:type_name -> newsletter.v1.Content
+	1,  // 12: newsletter.v1.ListContentsResponse.page:type_name -> newsletter.v1.PageInfo
+	19, // 13: newsletter.v1.EmailLog.sent_at:type_name -> google.protobuf.Timestamp
+	19, // 14: newsletter.v1.EmailLog.created_at:type_name -> google.protobuf.Timestamp
+	16, // 15: newsletter.v1.ListEmailLogsResponse.email_logs:type_name -> newsletter.v1.EmailLog
+	1,  // 16: newsletter.v1.ListEmailLogsResponse.page:type_name -> newsletter.v1.PageInfo
+	4,  // 17: newsletter.v1.TopicService.CreateTopic:input_type -> newsletter.v1.CreateTopicRequest
+	2,  // 18: newsletter.v1.TopicService.GetTopic:input_type -> newsletter.v1.IDRequest
+	0,  // 19: newsletter.v1.TopicService.ListTopics:input_type -> newsletter.v1.PageRequest
+	5,  // 20: newsletter.v1.TopicService.UpdateTopic:input_type -> newsletter.v1.UpdateTopicRequest
+	2,  // 21: newsletter.v1.TopicService.DeleteTopic:input_type -> newsletter.v1.IDRequest
+	8,  // 22: newsletter.v1.SubscriberService.CreateSubscriber:input_type -> newsletter.v1.CreateSubscriberRequest
+	2,  // 23: newsletter.v1.SubscriberService.GetSubscriber:input_type -> newsletter.v1.IDRequest
+	0,  // 24: newsletter.v1.SubscriberService.ListSubscribers:input_type -> newsletter.v1.PageRequest
+	9,  // 25: newsletter.v1.SubscriberService.UpdateSubscriber:input_type -> newsletter.v1.UpdateSubscriberRequest
+	2,  // 26: newsletter.v1.SubscriberService.DeleteSubscriber:input_type -> newsletter.v1.IDRequest
+	11, // 27: newsletter.v1.SubscriberService.Subscribe:input_type -> newsletter.v1.SubscribeRequest
+	2,  // 28: newsletter.v1.SubscriberService.Unsubscribe:input_type -> newsletter.v1.IDRequest
+	13, // 29: newsletter.v1.ContentService.CreateContent:input_type -> newsletter.v1.CreateContentRequest
+	2,  // 30: newsletter.v1.ContentService.GetContent:input_type -> newsletter.v1.IDRequest
+	0,  // 31: newsletter.v1.ContentService.ListContents:input_type -> newsletter.v1.PageRequest
+	14, // 32: newsletter.v1.ContentService.UpdateContent:input_type -> newsletter.v1.UpdateContentRequest
+	2,  // 33: newsletter.v1.ContentService.DeleteContent:input_type -> newsletter.v1.IDRequest
+	2,  // 34: newsletter.v1.ContentService.PublishContent:input_type -> newsletter.v1.IDRequest
+	17, // 35: newsletter.v1.NotificationService.SendNotifications:input_type -> newsletter.v1.SendNotificationsRequest
+	20, // 36: newsletter.v1.NotificationService.RetryFailedNotifications:input_type -> google.protobuf.Empty
+	2,  // 37: newsletter.v1.NotificationService.GetEmailLog:input_type -> newsletter.v1.IDRequest
+	0,  // 38: newsletter.v1.NotificationService.ListEmailLogs:input_type -> newsletter.v1.PageRequest
+	3,  // 39: newsletter.v1.TopicService.CreateTopic:output_type -> newsletter.v1.Topic
+	3,  // 40: newsletter.v1.TopicService.GetTopic:output_type -> newsletter.v1.Topic
+	6,  // 41: newsletter.v1.TopicService.ListTopics:output_type -> newsletter.v1.ListTopicsResponse
+	3,  // 42: newsletter.v1.TopicService.UpdateTopic:output_type -> newsletter.v1.Topic
+	20, // 43: newsletter.v1.TopicService.DeleteTopic:output_type -> google.protobuf.Empty
+	7,  // 44: newsletter.v1.SubscriberService.CreateSubscriber:output_type -> newsletter.v1.Subscriber
+	7,  // 45: newsletter.v1.SubscriberService.GetSubscriber:output_type -> newsletter.v1.Subscriber
+	10, // 46: newsletter.v1.SubscriberService.ListSubscribers:output_type -> newsletter.v1.ListSubscribersResponse
+	7,  // 47: newsletter.v1.SubscriberService.UpdateSubscriber:output_type -> newsletter.v1.Subscriber
+	20, // 48: newsletter.v1.SubscriberService.DeleteSubscriber:output_type -> google.protobuf.Empty
+	20, // 49: newsletter.v1.SubscriberService.Subscribe:output_type -> google.protobuf.Empty
+	20, // 50: newsletter.v1.SubscriberService.Unsubscribe:output_type -> google.protobuf.Empty
+	12, // 51: newsletter.v1.ContentService.CreateContent:output_type -> newsletter.v1.Content
+	12, // 52: newsletter.v1.ContentService.GetContent:output_type -> newsletter.v1.Content
+	15, // 53: newsletter.v1.ContentService.ListContents:output_type -> newsletter.v1.ListContentsResponse
+	12, // 54: newsletter.v1.ContentService.UpdateContent:output_type -> newsletter.v1.Content
+	20, // 55: newsletter.v1.ContentService.DeleteContent:output_type -> google.protobuf.Empty
+	12, // 56: newsletter.v1.ContentService.PublishContent:output_type -> newsletter.v1.Content
+	20, // 57: newsletter.v1.NotificationService.SendNotifications:output_type -> google.protobuf.Empty
+	20, // 58: newsletter.v1.NotificationService.RetryFailedNotifications:output_type -> google.protobuf.Empty
+	16, // 59: newsletter.v1.NotificationService.GetEmailLog:output_type -> newsletter.v1.EmailLog
+	18, // 60: newsletter.v1.NotificationService.ListEmailLogs:output_type -> newsletter.v1.ListEmailLogsResponse
+	39, // [39:61] is the sub-list for method output_type
+	17, // [17:39] is the sub-list for method input_type
+	17, // [17:17] is the sub-list for extension type_name
+	17, // [17:17] is the sub-list for extension extendee
+	0,  // [0:17] is the sub-list for field type_name
+}
+
+func init() { file_newsletter_v1_newsletter_proto_init() }
+func file_newsletter_v1_newsletter_proto_init() {
+	if File_newsletter_v1_newsletter_proto != nil {
+		return
+	}
+	file_newsletter_v1_newsletter_proto_msgTypes[5].OneofWrappers = []any{}
+	file_newsletter_v1_newsletter_proto_msgTypes[9].OneofWrappers = []any{}
+	file_newsletter_v1_newsletter_proto_msgTypes[14].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_newsletter_v1_newsletter_proto_rawDesc), len(file_newsletter_v1_newsletter_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   19,
+			NumExtensions: 0,
+			NumServices:   4,
+		},
+		GoTypes:           file_newsletter_v1_newsletter_proto_goTypes,
+		DependencyIndexes: file_newsletter_v1_newsletter_proto_depIdxs,
+		MessageInfos:      file_newsletter_v1_newsletter_proto_msgTypes,
+	}.Build()
+	File_newsletter_v1_newsletter_proto = out.File
+	file_newsletter_v1_newsletter_proto_goTypes = nil
+	file_newsletter_v1_newsletter_proto_depIdxs = nil
+}
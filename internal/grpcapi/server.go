@@ -0,0 +1,71 @@
+package grpcapi
+
+import (
+	"fmt"
+	"log"
+	"net"
+
+	"github.com/go-playground/validator/v10"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+
+	"newsletter-service/internal/config"
+	"newsletter-service/internal/events"
+	"newsletter-service/internal/grpcapi/pb"
+	"newsletter-service/internal/schedulers"
+	"newsletter-service/internal/services/approval"
+	"newsletter-service/internal/services/audit"
+	"newsletter-service/internal/services/auth"
+	"newsletter-service/internal/services/content"
+	"newsletter-service/internal/services/lint"
+	"newsletter-service/internal/services/notification"
+	"newsletter-service/internal/services/subscriber"
+	"newsletter-service/internal/services/topic"
+)
+
+// Services holds the domain services exposed over gRPC
+type Services struct {
+	Topic        topic.Service
+	Subscriber   subscriber.Service
+	Content      content.Service
+	Lint         lint.Service
+	Approval     approval.Service
+	Notification notification.Service
+	Scheduler    *schedulers.NotificationScheduler // Sends content under the dispatch lock workers take
+	Auth         auth.Service
+	Audit        audit.Service
+	Events       *events.Bus
+}
+
+// NewServer creates a gRPC server with auth and logging interceptors and all services registered
+func NewServer(cfg config.GRPCConfig, services Services) *grpc.Server {
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			LoggingInterceptor(),
+			AuthInterceptor(cfg, services.Auth),
+		),
+	)
+
+	validate := validator.New()
+	pb.RegisterTopicServiceServer(server, &topicServer{topicService: services.Topic, auditService: services.Audit, validate: validate})
+	pb.RegisterSubscriberServiceServer(server, &subscriberServer{subscriberService: services.Subscriber, auditService: services.Audit, eventBus: services.Events, validate: validate})
+	pb.RegisterContentServiceServer(server, &contentServer{contentService: services.Content, lintService: services.Lint, approvalService: services.Approval, auditService: services.Audit, eventBus: services.Events, validate: validate})
+	pb.RegisterNotificationServiceServer(server, &notificationServer{notificationService: services.Notification, scheduler: services.Scheduler})
+
+	if cfg.Reflection {
+		reflection.Register(server)
+	}
+
+	return server
+}
+
+// Serve listens on the configured port and serves until the server is stopped
+func Serve(server *grpc.Server, port int) error {
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return fmt.Errorf("failed to listen on gRPC port %d: %w", port, err)
+	}
+
+	log.Printf("Starting gRPC server on port %d...", port)
+	return server.Serve(listener)
+}
@@ -0,0 +1,218 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-playground/validator/v10"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"newsletter-service/internal/constants"
+	"newsletter-service/internal/daos"
+	"newsletter-service/internal/dtos"
+	"newsletter-service/internal/events"
+	"newsletter-service/internal/grpcapi/pb"
+	"newsletter-service/internal/services/audit"
+	"newsletter-service/internal/services/emailcheck"
+	"newsletter-service/internal/services/subscriber"
+)
+
+type subscriberServer struct {
+	pb.UnimplementedSubscriberServiceServer
+	subscriberService subscriber.Service
+	auditService      audit.Service
+	eventBus          *events.Bus
+	validate          *validator.Validate
+}
+
+func (s *subscriberServer) CreateSubscriber(ctx context.Context, req *pb.CreateSubscriberRequest) (*pb.Subscriber, error) {
+	dto := dtos.CreateSubscriberRequest{Name: req.GetName(), Email: req.GetEmail(), SubscribedTopics: req.GetSubscribedTopics()}
+	if err := s.validate.Struct(dto); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	subscriberModel := &subscriber.Subscriber{
+		Email:        req.GetEmail(),
+		Name:         req.GetName(),
+		IsActive:     true,
+		SignupSource: daos.SignupSourceGRPC,
+	}
+
+	topicNames, err := s.subscriberService.CreateSubscriberWithTopics(ctx, subscriberModel, req.GetSubscribedTopics())
+	if errors.Is(err, emailcheck.ErrUndeliverable) || errors.Is(err, subscriber.ErrTopicsNotFound) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if errors.Is(err, subscriber.ErrTopicArchived) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if errors.Is(err, subscriber.ErrDuplicateEmail) {
+		return nil, status.Error(codes.AlreadyExists, err.Error())
+	}
+	if err != nil {
+		return nil, internalError(err)
+	}
+
+	resp := subscriberToProto(subscriberModel, topicNames)
+	recordAudit(ctx, s.auditService, audit.ActionCreate, audit.EntitySubscriber, subscriberModel.ID, nil, resp)
+	s.eventBus.Emit(ctx, events.SubscriberCreated, subscriberEventData(resp))
+	return resp, nil
+}
+
+func (s *subscriberServer) GetSubscriber(ctx context.Context, req *pb.IDRequest) (*pb.Subscriber, error) {
+	return s.getSubscriber(ctx, uint(req.GetId()))
+}
+
+func (s *subscriberServer) ListSubscribers(ctx context.Context, req *pb.PageRequest) (*pb.ListSubscribersResponse, error) {
+	page, pageSize, offset, err := pagination(req)
+	if err != nil {
+		return nil, err
+	}
+
+	subscribers, total, err := s.subscriberService.GetAllSubscribersWithPagination(ctx, offset, pageSize)
+	if err != nil {
+		return nil, internalError(err)
+	}
+
+	resp := &pb.ListSubscribersResponse{Page: pageInfo(page, pageSize, total)}
+	for _, sub := range subscribers {
+		resp.Subscribers = append(resp.Subscribers, subscriberToProto(sub, nil))
+	}
+	return resp, nil
+}
+
+func (s *subscriberServer) UpdateSubscriber(ctx context.Context, req *pb.UpdateSubscriberRequest) (*pb.Subscriber, error) {
+	dto := dtos.UpdateSubscriberRequest{Name: req.GetName(), Email: req.GetEmail(), IsActive: req.IsActive, SubscribedTopics: req.GetSubscribedTopics()}
+	if err := s.validate.Struct(dto); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	id := uint(req.GetId())
+	before, err := s.getSubscriber(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := make(map[string]interface{})
+	if req.Email != nil {
+		updates["email"] = req.GetEmail()
+	}
+	if req.Name != nil {
+		updates["name"] = req.GetName()
+	}
+	if req.IsActive != nil {
+		updates["is_active"] = req.GetIsActive()
+	}
+
+	if err := s.subscriberService.UpdateSubscriberWithTopics(ctx, id, updates, req.GetSubscribedTopics()); err != nil {
+		if errors.Is(err, subscriber.ErrTopicsNotFound) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if errors.Is(err, subscriber.ErrTopicArchived) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		if errors.Is(err, subscriber.ErrDuplicateEmail) {
+			return nil, status.Error(codes.AlreadyExists, constants.ErrSubscriberEmailExists)
+		}
+		return nil, internalError(err)
+	}
+
+	after, err := s.getSubscriber(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	recordAudit(ctx, s.auditService, audit.ActionUpdate, audit.EntitySubscriber, id, before, after)
+	s.eventBus.Emit(ctx, events.SubscriberUpdated, subscriberEventData(after))
+	return after, nil
+}
+
+func (s *subscriberServer) DeleteSubscriber(ctx context.Context, req *pb.IDRequest) (*emptypb.Empty, error) {
+	id := uint(req.GetId())
+	before, err := s.getSubscriber(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.subscriberService.DeleteSubscriber(ctx, id); err != nil {
+		return nil, internalError(err)
+	}
+
+	recordAudit(ctx, s.auditService, audit.ActionDelete, audit.EntitySubscriber, id, before, nil)
+	s.eventBus.Emit(ctx, events.SubscriberDeleted, map[string]interface{}{"subscriber_id": id})
+	return &emptypb.Empty{}, nil
+}
+
+func (s *subscriberServer) Subscribe(ctx context.Context, req *pb.SubscribeRequest) (*emptypb.Empty, error) {
+	dto := dtos.CreateSubscriptionRequest{SubscriberID: uint(req.GetSubscriberId()), TopicID: uint(req.GetTopicId())}
+	if err := s.validate.Struct(dto); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	before, err := s.getSubscriber(ctx, dto.SubscriberID)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := s.subscriberService.Subscribe(ctx, dto.SubscriberID, dto.TopicID)
+	if err != nil {
+		if errors.Is(err, subscriber.ErrTopicArchived) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, internalError(err)
+	}
+	if !created {
+		return &emptypb.Empty{}, nil
+	}
+
+	// A new subscription changes the subscriber's topic list, so audit it as a subscriber update
+	after, _ := s.getSubscriber(ctx, dto.SubscriberID)
+	recordAudit(ctx, s.auditService, audit.ActionUpdate, audit.EntitySubscriber, dto.SubscriberID, before, after)
+	return &emptypb.Empty{}, nil
+}
+
+func (s *subscriberServer) Unsubscribe(ctx context.Context, req *pb.IDRequest) (*emptypb.Empty, error) {
+	id := uint(req.GetId())
+	if err := s.subscriberService.Unsubscribe(ctx, id); err != nil {
+		return nil, internalError(err)
+	}
+
+	recordAudit(ctx, s.auditService, audit.ActionDelete, audit.EntitySubscription, id, nil, nil)
+	s.eventBus.Emit(ctx, events.SubscriberUnsubscribed, map[string]interface{}{"subscription_id": id})
+	return &emptypb.Empty{}, nil
+}
+
+func (s *subscriberServer) getSubscriber(ctx context.Context, id uint) (*pb.Subscriber, error) {
+	subscriberModel, topicNames, err := s.subscriberService.GetSubscriberByIDWithTopics(ctx, id)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, constants.ErrSubscriberNotFound)
+	}
+	return subscriberToProto(subscriberModel, topicNames), nil
+}
+
+// subscriberEventData converts a subscriber to the payload the REST API emits for the same event
+func subscriberEventData(sub *pb.Subscriber) dtos.SubscriberResponse {
+	return dtos.SubscriberResponse{
+		ID:               uint(sub.GetId()),
+		Email:            sub.GetEmail(),
+		Name:             sub.GetName(),
+		IsActive:         sub.GetIsActive(),
+		SubscribedTopics: sub.GetSubscribedTopics(),
+		CreatedAt:        sub.GetCreatedAt().AsTime(),
+		UpdatedAt:        sub.GetUpdatedAt().AsTime(),
+	}
+}
+
+func subscriberToProto(sub *subscriber.Subscriber, topicNames []string) *pb.Subscriber {
+	return &pb.Subscriber{
+		Id:               uint32(sub.ID),
+		Name:             sub.Name,
+		Email:            sub.Email,
+		IsActive:         sub.IsActive,
+		SubscribedTopics: topicNames,
+		CreatedAt:        timestamppb.New(sub.CreatedAt),
+		UpdatedAt:        timestamppb.New(sub.UpdatedAt),
+	}
+}
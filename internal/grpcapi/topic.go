@@ -0,0 +1,136 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-playground/validator/v10"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"newsletter-service/internal/constants"
+	"newsletter-service/internal/dtos"
+	"newsletter-service/internal/grpcapi/pb"
+	"newsletter-service/internal/services/audit"
+	"newsletter-service/internal/services/topic"
+)
+
+type topicServer struct {
+	pb.UnimplementedTopicServiceServer
+	topicService topic.Service
+	auditService audit.Service
+	validate     *validator.Validate
+}
+
+func (s *topicServer) CreateTopic(ctx context.Context, req *pb.CreateTopicRequest) (*pb.Topic, error) {
+	if err := s.validate.Struct(dtos.CreateTopicRequest{Name: req.GetName(), Description: req.GetDescription()}); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	topicModel := &topic.Topic{
+		Name:        req.GetName(),
+		Description: req.GetDescription(),
+	}
+	if err := s.topicService.CreateTopic(ctx, topicModel); err != nil {
+		if errors.Is(err, topic.ErrDuplicateName) {
+			return nil, status.Error(codes.AlreadyExists, constants.ErrTopicNameExists)
+		}
+		return nil, internalError(err)
+	}
+
+	recordAudit(ctx, s.auditService, audit.ActionCreate, audit.EntityTopic, topicModel.ID, nil, topicModel)
+	return topicToProto(topicModel), nil
+}
+
+func (s *topicServer) GetTopic(ctx context.Context, req *pb.IDRequest) (*pb.Topic, error) {
+	topicModel, err := s.topicService.GetTopicByID(ctx, uint(req.GetId()))
+	if err != nil {
+		return nil, status.Error(codes.NotFound, constants.ErrTopicNotFound)
+	}
+	return topicToProto(topicModel), nil
+}
+
+func (s *topicServer) ListTopics(ctx context.Context, req *pb.PageRequest) (*pb.ListTopicsResponse, error) {
+	page, pageSize, offset, err := pagination(req)
+	if err != nil {
+		return nil, err
+	}
+
+	topics, total, err := s.topicService.GetAllTopicsWithPagination(ctx, offset, pageSize)
+	if err != nil {
+		return nil, internalError(err)
+	}
+
+	resp := &pb.ListTopicsResponse{Page: pageInfo(page, pageSize, total)}
+	for _, t := range topics {
+		resp.Topics = append(resp.Topics, topicToProto(t))
+	}
+	return resp, nil
+}
+
+func (s *topicServer) UpdateTopic(ctx context.Context, req *pb.UpdateTopicRequest) (*pb.Topic, error) {
+	if err := s.validate.Struct(dtos.UpdateTopicRequest{Name: req.GetName(), Description: req.GetDescription()}); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	id := uint(req.GetId())
+	before, err := s.topicService.GetTopicByID(ctx, id)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, constants.ErrTopicNotFound)
+	}
+
+	updates := make(map[string]interface{})
+	if req.Name != nil {
+		updates["name"] = req.GetName()
+	}
+	if req.Description != nil {
+		updates["description"] = req.GetDescription()
+	}
+
+	if err := s.topicService.UpdateTopic(ctx, id, updates); err != nil {
+		if errors.Is(err, topic.ErrDuplicateName) {
+			return nil, status.Error(codes.AlreadyExists, constants.ErrTopicNameExists)
+		}
+		return nil, internalError(err)
+	}
+
+	after, err := s.topicService.GetTopicByID(ctx, id)
+	if err != nil {
+		return nil, internalError(err)
+	}
+
+	recordAudit(ctx, s.auditService, audit.ActionUpdate, audit.EntityTopic, id, before, after)
+	return topicToProto(after), nil
+}
+
+func (s *topicServer) DeleteTopic(ctx context.Context, req *pb.IDRequest) (*emptypb.Empty, error) {
+	id := uint(req.GetId())
+	before, err := s.topicService.GetTopicByID(ctx, id)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, constants.ErrTopicNotFound)
+	}
+
+	summary, err := s.topicService.DeleteTopic(ctx, id, false)
+	if err != nil {
+		if errors.Is(err, topic.ErrTopicInUse) {
+			return nil, status.Errorf(codes.FailedPrecondition, "%v: %d subscriptions, %d unsent content",
+				err, summary.Subscriptions, summary.ActiveContent)
+		}
+		return nil, internalError(err)
+	}
+
+	recordAudit(ctx, s.auditService, audit.ActionDelete, audit.EntityTopic, id, before, summary)
+	return &emptypb.Empty{}, nil
+}
+
+func topicToProto(t *topic.Topic) *pb.Topic {
+	return &pb.Topic{
+		Id:          uint32(t.ID),
+		Name:        t.Name,
+		Description: t.Description,
+		CreatedAt:   timestamppb.New(t.CreatedAt),
+		UpdatedAt:   timestamppb.New(t.UpdatedAt),
+	}
+}
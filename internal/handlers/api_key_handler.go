@@ -0,0 +1,211 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"newsletter-service/internal/constants"
+	"newsletter-service/internal/dtos"
+	"newsletter-service/internal/router/middleware"
+	"newsletter-service/internal/services/apikey"
+	"newsletter-service/internal/services/audit"
+)
+
+type APIKeyHandler struct {
+	apiKeyService apikey.Service
+	auditService  audit.Service
+}
+
+func NewAPIKeyHandler(apiKeyService apikey.Service, auditService audit.Service) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyService: apiKeyService,
+		auditService:  auditService,
+	}
+}
+
+// GetAPIKeys retrieves API keys with pagination
+func (h *APIKeyHandler) GetAPIKeys(c *gin.Context) {
+	var pagination dtos.PaginationRequest
+	if err := c.ShouldBindQuery(&pagination); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidPaginationParams})
+		return
+	}
+
+	page, pageSize := pagination.GetDefaults()
+	offset := pagination.CalculateOffset()
+
+	keys, total, err := h.apiKeyService.GetAPIKeysWithPagination(c.Request.Context(), offset, pageSize)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	response := make([]dtos.APIKeyResponse, 0, len(keys))
+	for _, key := range keys {
+		response = append(response, toAPIKeyResponse(key))
+	}
+
+	paginationResponse := dtos.CreatePaginationResponse(page, pageSize, total)
+	c.JSON(http.StatusOK, dtos.PaginatedResponse[dtos.APIKeyResponse]{
+		Data:       response,
+		Pagination: paginationResponse,
+	})
+}
+
+// CreateAPIKey issues a new API key, returning the plaintext key once
+func (h *APIKeyHandler) CreateAPIKey(c *gin.Context) {
+	var req dtos.CreateAPIKeyRequest
+	if !middleware.ValidateJSON(c, &req) {
+		return
+	}
+
+	key := &apikey.APIKey{Name: req.Name}
+	applyAPIKeyRateLimit(key, req.RateLimit)
+
+	rawKey, err := h.apiKeyService.CreateAPIKey(c.Request.Context(), key)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	recordAudit(c, h.auditService, audit.ActionCreate, audit.EntityAPIKey, key.ID, nil, key)
+
+	c.JSON(http.StatusCreated, dtos.CreateAPIKeyResponse{
+		APIKeyResponse: toAPIKeyResponse(key),
+		Key:            rawKey,
+	})
+}
+
+// GetAPIKeyByID retrieves an API key by ID
+func (h *APIKeyHandler) GetAPIKeyByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidAPIKeyID})
+		return
+	}
+
+	key, err := h.apiKeyService.GetAPIKeyByID(c.Request.Context(), uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrAPIKeyNotFound})
+		return
+	}
+
+	c.JSON(http.StatusOK, toAPIKeyResponse(key))
+}
+
+// UpdateAPIKey renames a key or changes its rate limit override
+func (h *APIKeyHandler) UpdateAPIKey(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidAPIKeyID})
+		return
+	}
+
+	var req dtos.UpdateAPIKeyRequest
+	if !middleware.ValidateJSON(c, &req) {
+		return
+	}
+
+	before, err := h.apiKeyService.GetAPIKeyByID(c.Request.Context(), uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrAPIKeyNotFound})
+		return
+	}
+
+	updates := make(map[string]interface{})
+	if req.Name != "" {
+		updates["name"] = req.Name
+	}
+	if req.ClearRateLimit {
+		updates["rate_limit_bucket_size"] = nil
+		updates["rate_limit_refill_size"] = nil
+		updates["rate_limit_refill_seconds"] = nil
+	} else if req.RateLimit != nil {
+		var key apikey.APIKey
+		applyAPIKeyRateLimit(&key, req.RateLimit)
+		updates["rate_limit_bucket_size"] = key.RateLimitBucketSize
+		updates["rate_limit_refill_size"] = key.RateLimitRefillSize
+		updates["rate_limit_refill_seconds"] = key.RateLimitRefillSeconds
+	}
+
+	if err := h.apiKeyService.UpdateAPIKey(c.Request.Context(), uint(id), updates); err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	after, err := h.apiKeyService.GetAPIKeyByID(c.Request.Context(), uint(id))
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	recordAudit(c, h.auditService, audit.ActionUpdate, audit.EntityAPIKey, uint(id), before, after)
+
+	c.JSON(http.StatusOK, toAPIKeyResponse(after))
+}
+
+// RevokeAPIKey revokes an API key; revoked keys fall back to the configured rate limits
+func (h *APIKeyHandler) RevokeAPIKey(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidAPIKeyID})
+		return
+	}
+
+	before, err := h.apiKeyService.GetAPIKeyByID(c.Request.Context(), uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrAPIKeyNotFound})
+		return
+	}
+
+	if err := h.apiKeyService.RevokeAPIKey(c.Request.Context(), uint(id)); err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	after, _ := h.apiKeyService.GetAPIKeyByID(c.Request.Context(), uint(id))
+	recordAudit(c, h.auditService, audit.ActionRevoke, audit.EntityAPIKey, uint(id), before, after)
+
+	c.JSON(http.StatusOK, gin.H{"message": constants.MsgAPIKeyRevokedSuccessfully})
+}
+
+// applyAPIKeyRateLimit copies a requested rate limit onto the key record
+func applyAPIKeyRateLimit(key *apikey.APIKey, rateLimit *dtos.APIKeyRateLimit) {
+	if rateLimit == nil {
+		return
+	}
+
+	bucketSize := rateLimit.BucketSize
+	key.RateLimitBucketSize = &bucketSize
+	if rateLimit.RefillSize > 0 {
+		refillSize := rateLimit.RefillSize
+		key.RateLimitRefillSize = &refillSize
+	}
+	if rateLimit.RefillSeconds > 0 {
+		refillSeconds := rateLimit.RefillSeconds
+		key.RateLimitRefillSeconds = &refillSeconds
+	}
+}
+
+func toAPIKeyResponse(key *apikey.APIKey) dtos.APIKeyResponse {
+	response := dtos.APIKeyResponse{
+		ID:        key.ID,
+		Name:      key.Name,
+		KeyPrefix: key.KeyPrefix,
+		RevokedAt: key.RevokedAt,
+		CreatedAt: key.CreatedAt,
+		UpdatedAt: key.UpdatedAt,
+	}
+
+	if key.RateLimitBucketSize != nil {
+		response.RateLimit = &dtos.APIKeyRateLimit{BucketSize: *key.RateLimitBucketSize}
+		if key.RateLimitRefillSize != nil {
+			response.RateLimit.RefillSize = *key.RateLimitRefillSize
+		}
+		if key.RateLimitRefillSeconds != nil {
+			response.RateLimit.RefillSeconds = *key.RateLimitRefillSeconds
+		}
+	}
+	return response
+}
@@ -0,0 +1,201 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"newsletter-service/internal/constants"
+	"newsletter-service/internal/dtos"
+	"newsletter-service/internal/router/middleware"
+	"newsletter-service/internal/services/approval"
+	"newsletter-service/internal/services/audit"
+)
+
+type ApprovalHandler struct {
+	approvalService approval.Service
+	auditService    audit.Service
+}
+
+func NewApprovalHandler(approvalService approval.Service, auditService audit.Service) *ApprovalHandler {
+	return &ApprovalHandler{
+		approvalService: approvalService,
+		auditService:    auditService,
+	}
+}
+
+// SubmitContent asks for a review of draft content, optionally naming the reviewer
+func (h *ApprovalHandler) SubmitContent(c *gin.Context) {
+	id, ok := contentIDParam(c)
+	if !ok {
+		return
+	}
+	// The body is optional
+	var req dtos.SubmitContentRequest
+	if c.Request.ContentLength != 0 && !middleware.ValidateJSON(c, &req) {
+		return
+	}
+
+	submitted, err := h.approvalService.Submit(c.Request.Context(), id, approvalActor(c), req.Reviewer, req.Comment)
+	h.respond(c, audit.ActionSubmit, submitted, err)
+}
+
+// AssignReviewer hands draft or submitted content to a reviewer; only they can then approve or reject it
+func (h *ApprovalHandler) AssignReviewer(c *gin.Context) {
+	id, ok := contentIDParam(c)
+	if !ok {
+		return
+	}
+	var req dtos.AssignReviewerRequest
+	if !middleware.ValidateJSON(c, &req) {
+		return
+	}
+
+	assigned, err := h.approvalService.Assign(c.Request.Context(), id, approvalActor(c), req.Reviewer)
+	h.respond(c, audit.ActionUpdate, assigned, err)
+}
+
+// ApproveContent accepts submitted content so it can be published
+func (h *ApprovalHandler) ApproveContent(c *gin.Context) {
+	id, ok := contentIDParam(c)
+	if !ok {
+		return
+	}
+	var req dtos.ApprovalDecisionRequest
+	if c.Request.ContentLength != 0 && !middleware.ValidateJSON(c, &req) {
+		return
+	}
+
+	approved, err := h.approvalService.Approve(c.Request.Context(), id, approvalActor(c), req.Comment)
+	h.respond(c, audit.ActionApprove, approved, err)
+}
+
+// RejectContent sends submitted content back to draft with a comment saying why
+func (h *ApprovalHandler) RejectContent(c *gin.Context) {
+	id, ok := contentIDParam(c)
+	if !ok {
+		return
+	}
+	var req dtos.ApprovalDecisionRequest
+	if !middleware.ValidateJSON(c, &req) {
+		return
+	}
+
+	rejected, err := h.approvalService.Reject(c.Request.Context(), id, approvalActor(c), req.Comment)
+	h.respond(c, audit.ActionReject, rejected, err)
+}
+
+// CommentOnContent adds a comment to the content's approval log
+func (h *ApprovalHandler) CommentOnContent(c *gin.Context) {
+	id, ok := contentIDParam(c)
+	if !ok {
+		return
+	}
+	var req dtos.ApprovalCommentRequest
+	if !middleware.ValidateJSON(c, &req) {
+		return
+	}
+
+	event, err := h.approvalService.Comment(c.Request.Context(), id, approvalActor(c), req.Comment)
+	if err != nil {
+		writeApprovalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toApprovalEventResponse(event))
+}
+
+// GetApprovalLog lists the content's submissions, assignments, decisions and comments, oldest first
+func (h *ApprovalHandler) GetApprovalLog(c *gin.Context) {
+	id, ok := contentIDParam(c)
+	if !ok {
+		return
+	}
+
+	events, err := h.approvalService.GetLog(c.Request.Context(), id)
+	if err != nil {
+		writeApprovalError(c, err)
+		return
+	}
+
+	response := make([]dtos.ApprovalEventResponse, len(events))
+	for i, event := range events {
+		response[i] = toApprovalEventResponse(event)
+	}
+	c.JSON(http.StatusOK, gin.H{"approvals": response})
+}
+
+// respond writes the content's new approval state after a transition, recording it in the audit log
+func (h *ApprovalHandler) respond(c *gin.Context, action string, updated *approval.Content, err error) {
+	if err != nil {
+		writeApprovalError(c, err)
+		return
+	}
+
+	response := dtos.ApprovalStateResponse{
+		ContentID:     updated.ID,
+		ApprovalState: updated.ApprovalState,
+		Reviewer:      updated.Reviewer,
+	}
+	recordAudit(c, h.auditService, action, audit.EntityContent, updated.ID, nil, response)
+	c.JSON(http.StatusOK, response)
+}
+
+// writeApprovalError responds to a refused approval step, including a publish the workflow blocks
+func writeApprovalError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrContentNotFound})
+	case errors.Is(err, approval.ErrInvalidTransition):
+		c.JSON(http.StatusConflict, gin.H{"error": constants.ErrInvalidApprovalState})
+	case errors.Is(err, approval.ErrNotApproved):
+		c.JSON(http.StatusConflict, gin.H{"error": constants.ErrContentNotApproved})
+	case errors.Is(err, approval.ErrNotReviewer):
+		c.JSON(http.StatusForbidden, gin.H{"error": constants.ErrNotReviewer})
+	case errors.Is(err, approval.ErrNotAssignedReviewer):
+		c.JSON(http.StatusForbidden, gin.H{"error": constants.ErrNotAssignedReviewer})
+	case errors.Is(err, approval.ErrNotPublisher):
+		c.JSON(http.StatusForbidden, gin.H{"error": constants.ErrNotPublisher})
+	case errors.Is(err, approval.ErrUnknownReviewer):
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrUnknownReviewer})
+	case errors.Is(err, approval.ErrCommentRequired):
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrCommentRequired})
+	default:
+		abortWithError(c, err)
+	}
+}
+
+// approvalActor names the caller the way the audit log does, so reviewer and publisher lists can use the same
+// names
+func approvalActor(c *gin.Context) string {
+	if actor := c.GetString(gin.AuthUserKey); actor != "" {
+		return actor
+	}
+	return "anonymous"
+}
+
+// contentIDParam parses the content ID in the path, responding with 400 when it isn't one
+func contentIDParam(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidContentID})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func toApprovalEventResponse(event *approval.Event) dtos.ApprovalEventResponse {
+	return dtos.ApprovalEventResponse{
+		ID:        event.ID,
+		Action:    event.Action,
+		FromState: event.FromState,
+		ToState:   event.ToState,
+		Actor:     event.Actor,
+		Reviewer:  event.Reviewer,
+		Comment:   event.Comment,
+		CreatedAt: event.CreatedAt,
+	}
+}
@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"newsletter-service/internal/constants"
+	"newsletter-service/internal/dtos"
+	"newsletter-service/internal/services/asset"
+	"newsletter-service/internal/services/audit"
+)
+
+// multipartOverhead is allowed on top of the maximum asset size for the form's boundaries and headers
+const multipartOverhead = 1 << 20
+
+type AssetHandler struct {
+	assetService asset.Service
+	auditService audit.Service
+}
+
+func NewAssetHandler(assetService asset.Service, auditService audit.Service) *AssetHandler {
+	return &AssetHandler{
+		assetService: assetService,
+		auditService: auditService,
+	}
+}
+
+// UploadAsset stores the image in the multipart "file" field and returns the public URL to use in content
+func (h *AssetHandler) UploadAsset(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.assetService.MaxSize()+multipartOverhead)
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": constants.ErrAssetTooLarge})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrAssetFileRequired})
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	defer file.Close()
+
+	created, err := h.assetService.Upload(c.Request.Context(), header.Filename, file)
+	if err != nil {
+		switch {
+		case errors.Is(err, asset.ErrTooLarge):
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": constants.ErrAssetTooLarge})
+		case errors.Is(err, asset.ErrUnsupportedType):
+			c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": constants.ErrAssetUnsupportedType})
+		default:
+			abortWithError(c, err)
+		}
+		return
+	}
+
+	recordAudit(c, h.auditService, audit.ActionCreate, audit.EntityAsset, created.ID, nil, created)
+	c.JSON(http.StatusCreated, dtos.AssetResponse{
+		ID:          created.ID,
+		Key:         created.Key,
+		URL:         h.assetURL(c, created.Key),
+		Filename:    created.Filename,
+		ContentType: created.ContentType,
+		Size:        created.Size,
+		CreatedAt:   created.CreatedAt,
+	})
+}
+
+// ServeAsset serves an uploaded image to email clients and browsers. Keys never change contents, so responses
+// are cached for as long as clients allow.
+func (h *AssetHandler) ServeAsset(c *gin.Context) {
+	found, body, err := h.assetService.Open(c.Request.Context(), c.Param("key"))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrAssetNotFound})
+			return
+		}
+		abortWithError(c, fmt.Errorf("failed to serve asset %s: %w", c.Param("key"), err))
+		return
+	}
+	defer body.Close()
+
+	c.DataFromReader(http.StatusOK, found.Size, found.ContentType, body, map[string]string{
+		"Cache-Control":          "public, max-age=31536000, immutable",
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
+// assetURL makes asset links absolute from the request when no public URL is configured, as emails can't
+// use relative ones
+func (h *AssetHandler) assetURL(c *gin.Context, key string) string {
+	url := h.assetService.URL(key)
+	if !strings.HasPrefix(url, "/") {
+		return url
+	}
+
+	scheme := "http"
+	if c.Request.TLS != nil || strings.EqualFold(c.GetHeader("X-Forwarded-Proto"), "https") {
+		scheme = "https"
+	}
+	return scheme + "://" + c.Request.Host + url
+}
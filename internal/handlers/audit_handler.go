@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"newsletter-service/internal/constants"
+	"newsletter-service/internal/dtos"
+	"newsletter-service/internal/logger"
+	"newsletter-service/internal/services/audit"
+)
+
+type AuditHandler struct {
+	auditService audit.Service
+}
+
+func NewAuditHandler(auditService audit.Service) *AuditHandler {
+	return &AuditHandler{
+		auditService: auditService,
+	}
+}
+
+// GetAuditLogs retrieves audit logs with optional filters
+func (h *AuditHandler) GetAuditLogs(c *gin.Context) {
+	var query dtos.AuditLogQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidAuditLogFilter, "details": err.Error()})
+		return
+	}
+
+	filter := audit.Filter{
+		Actor:      query.Actor,
+		Action:     query.Action,
+		EntityType: query.EntityType,
+		EntityID:   query.EntityID,
+	}
+
+	if query.From != "" {
+		from, err := time.Parse(time.RFC3339, query.From)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidAuditLogFilter, "details": "from must be RFC3339"})
+			return
+		}
+		filter.From = &from
+	}
+	if query.To != "" {
+		to, err := time.Parse(time.RFC3339, query.To)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidAuditLogFilter, "details": "to must be RFC3339"})
+			return
+		}
+		filter.To = &to
+	}
+
+	page, pageSize := query.GetDefaults()
+	offset := query.CalculateOffset()
+
+	logs, total, err := h.auditService.GetAuditLogsWithPagination(c.Request.Context(), filter, offset, pageSize)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	paginationResponse := dtos.CreatePaginationResponse(page, pageSize, total)
+	paginatedResponse := dtos.PaginatedResponse[*audit.AuditLog]{
+		Data:       logs,
+		Pagination: paginationResponse,
+	}
+
+	c.JSON(http.StatusOK, paginatedResponse)
+}
+
+// GetAuditLogByID retrieves an audit log by ID
+func (h *AuditHandler) GetAuditLogByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidAuditLogID})
+		return
+	}
+
+	log, err := h.auditService.GetAuditLogByID(c.Request.Context(), uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrAuditLogNotFound})
+		return
+	}
+
+	c.JSON(http.StatusOK, log)
+}
+
+// recordAudit stores an audit entry for a mutating operation without failing the request
+func recordAudit(c *gin.Context, auditService audit.Service, action, entityType string, entityID uint, before, after interface{}) {
+	if auditService == nil {
+		return
+	}
+
+	ctx := c.Request.Context()
+	requestID, _ := ctx.Value("request_id").(string)
+
+	entry := audit.Entry{
+		Actor:      c.GetString(gin.AuthUserKey),
+		ActorType:  c.GetString(constants.ContextKeyAuthMethod),
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Before:     before,
+		After:      after,
+		IPAddress:  c.ClientIP(),
+		RequestID:  requestID,
+	}
+	if entry.Actor == "" {
+		entry.Actor = "anonymous"
+	}
+	if entry.ActorType == "" {
+		entry.ActorType = "unknown"
+	}
+
+	if err := auditService.Record(ctx, entry); err != nil {
+		logger.Warn(ctx, "Failed to record audit log for %s %s %d: %v", action, entityType, entityID, err)
+	}
+}
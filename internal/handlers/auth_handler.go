@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"newsletter-service/internal/constants"
+	"newsletter-service/internal/dtos"
+	"newsletter-service/internal/router/middleware"
+	"newsletter-service/internal/services/auth"
+)
+
+type AuthHandler struct {
+	authService auth.Service
+}
+
+func NewAuthHandler(authService auth.Service) *AuthHandler {
+	return &AuthHandler{
+		authService: authService,
+	}
+}
+
+// Login issues an access/refresh token pair for valid credentials
+func (h *AuthHandler) Login(c *gin.Context) {
+	var req dtos.LoginRequest
+	if !middleware.ValidateJSON(c, &req) {
+		return
+	}
+
+	tokens, err := h.authService.Login(c.Request.Context(), req.Username, req.Password)
+	if err != nil {
+		if errors.Is(err, auth.ErrInvalidCredentials) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": constants.ErrInvalidCredentials})
+			return
+		}
+		abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newTokenResponse(tokens))
+}
+
+// Refresh exchanges a refresh token for a new token pair
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var req dtos.RefreshTokenRequest
+	if !middleware.ValidateJSON(c, &req) {
+		return
+	}
+
+	tokens, err := h.authService.Refresh(c.Request.Context(), req.RefreshToken)
+	if err != nil {
+		if errors.Is(err, auth.ErrInvalidToken) || errors.Is(err, auth.ErrTokenRevoked) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": constants.ErrInvalidToken})
+			return
+		}
+		abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newTokenResponse(tokens))
+}
+
+// Logout revokes the bearer access token and optionally the refresh token
+func (h *AuthHandler) Logout(c *gin.Context) {
+	accessToken := strings.TrimPrefix(c.GetHeader("Authorization"), constants.BearerTokenType+" ")
+	if accessToken == "" || accessToken == c.GetHeader("Authorization") {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": constants.ErrInvalidToken})
+		return
+	}
+
+	var req dtos.LogoutRequest
+	if c.Request.ContentLength > 0 && !middleware.ValidateJSON(c, &req) {
+		return
+	}
+
+	if err := h.authService.Logout(c.Request.Context(), accessToken, req.RefreshToken); err != nil {
+		if errors.Is(err, auth.ErrInvalidToken) || errors.Is(err, auth.ErrTokenRevoked) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": constants.ErrInvalidToken})
+			return
+		}
+		abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": constants.MsgLoggedOutSuccessfully})
+}
+
+func newTokenResponse(tokens *auth.TokenPair) dtos.TokenResponse {
+	return dtos.TokenResponse{
+		AccessToken:      tokens.AccessToken,
+		RefreshToken:     tokens.RefreshToken,
+		TokenType:        constants.BearerTokenType,
+		ExpiresIn:        int64(time.Until(tokens.AccessExpiresAt).Seconds()),
+		ExpiresAt:        tokens.AccessExpiresAt,
+		RefreshExpiresAt: tokens.RefreshExpiresAt,
+	}
+}
@@ -0,0 +1,320 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"newsletter-service/internal/constants"
+	"newsletter-service/internal/dtos"
+	apperrors "newsletter-service/internal/errors"
+	"newsletter-service/internal/router/middleware"
+	"newsletter-service/internal/services/audit"
+	"newsletter-service/internal/services/billing"
+)
+
+// maxStripeEventSize caps the webhook bodies read; Stripe events are a few kilobytes
+const maxStripeEventSize = 1 << 20
+
+type BillingHandler struct {
+	billingService billing.Service
+	auditService   audit.Service
+}
+
+func NewBillingHandler(billingService billing.Service, auditService audit.Service) *BillingHandler {
+	return &BillingHandler{
+		billingService: billingService,
+		auditService:   auditService,
+	}
+}
+
+// GetPlans lists plans with pagination, only those of topic_id when it is given
+func (h *BillingHandler) GetPlans(c *gin.Context) {
+	var query dtos.PlanQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidPaginationParams})
+		return
+	}
+
+	page, pageSize := query.GetDefaults()
+	offset := query.CalculateOffset()
+
+	plans, total, err := h.billingService.GetPlansWithPagination(c.Request.Context(), query.TopicID, offset, pageSize)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	response := make([]dtos.PlanResponse, 0, len(plans))
+	for _, p := range plans {
+		response = append(response, toPlanResponse(p))
+	}
+
+	c.JSON(http.StatusOK, dtos.PaginatedResponse[dtos.PlanResponse]{
+		Data:       response,
+		Pagination: dtos.CreatePaginationResponse(page, pageSize, total),
+	})
+}
+
+// CreatePlan sells a topic's premium content through a Stripe price
+func (h *BillingHandler) CreatePlan(c *gin.Context) {
+	var req dtos.CreatePlanRequest
+	if !middleware.ValidateJSON(c, &req) {
+		return
+	}
+
+	p := &billing.Plan{
+		TopicID:       req.TopicID,
+		Name:          req.Name,
+		Description:   req.Description,
+		StripePriceID: req.StripePriceID,
+		AmountCents:   req.AmountCents,
+		Currency:      req.Currency,
+		Interval:      req.Interval,
+		Active:        req.Active == nil || *req.Active,
+	}
+
+	if err := h.billingService.CreatePlan(c.Request.Context(), p); err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	recordAudit(c, h.auditService, audit.ActionCreate, audit.EntityPlan, p.ID, nil, p)
+
+	c.JSON(http.StatusCreated, toPlanResponse(p))
+}
+
+// GetPlanByID retrieves a plan by ID
+func (h *BillingHandler) GetPlanByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidPlanID})
+		return
+	}
+
+	p, err := h.billingService.GetPlanByID(c.Request.Context(), uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrPlanNotFound})
+		return
+	}
+
+	c.JSON(http.StatusOK, toPlanResponse(p))
+}
+
+// UpdatePlan changes a plan. Its topic can't change, since its subscribers' entitlements belong to the topic.
+func (h *BillingHandler) UpdatePlan(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidPlanID})
+		return
+	}
+
+	var req dtos.UpdatePlanRequest
+	if !middleware.ValidateJSON(c, &req) {
+		return
+	}
+
+	before, err := h.billingService.GetPlanByID(c.Request.Context(), uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrPlanNotFound})
+		return
+	}
+
+	updates := make(map[string]interface{})
+	if req.Name != "" {
+		updates["name"] = req.Name
+	}
+	if req.Description != nil {
+		updates["description"] = *req.Description
+	}
+	if req.StripePriceID != "" {
+		updates["stripe_price_id"] = req.StripePriceID
+	}
+	if req.AmountCents != nil {
+		updates["amount_cents"] = *req.AmountCents
+	}
+	if req.Currency != "" {
+		updates["currency"] = req.Currency
+	}
+	if req.Interval != "" {
+		updates["interval"] = req.Interval
+	}
+	if req.Active != nil {
+		updates["active"] = *req.Active
+	}
+
+	if err := h.billingService.UpdatePlan(c.Request.Context(), uint(id), updates); err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	after, err := h.billingService.GetPlanByID(c.Request.Context(), uint(id))
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	recordAudit(c, h.auditService, audit.ActionUpdate, audit.EntityPlan, uint(id), before, after)
+
+	c.JSON(http.StatusOK, toPlanResponse(after))
+}
+
+// DeletePlan stops selling a plan. Subscribers paying for it stay entitled until their Stripe subscription ends.
+func (h *BillingHandler) DeletePlan(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidPlanID})
+		return
+	}
+
+	before, err := h.billingService.GetPlanByID(c.Request.Context(), uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrPlanNotFound})
+		return
+	}
+
+	if err := h.billingService.DeletePlan(c.Request.Context(), uint(id)); err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	recordAudit(c, h.auditService, audit.ActionDelete, audit.EntityPlan, uint(id), before, nil)
+
+	c.JSON(http.StatusOK, gin.H{"message": constants.MsgPlanDeletedSuccessfully})
+}
+
+// CreateCheckout starts a Stripe Checkout session for a subscriber to buy the plan. The subscriber is sent to
+// the returned URL to pay; their subscription is recorded once Stripe reports the payment.
+func (h *BillingHandler) CreateCheckout(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidPlanID})
+		return
+	}
+
+	var req dtos.CreateCheckoutRequest
+	if !middleware.ValidateJSON(c, &req) {
+		return
+	}
+
+	checkout, err := h.billingService.CreateCheckout(c.Request.Context(), uint(id), req.SubscriberID)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dtos.CheckoutResponse{SessionID: checkout.SessionID, URL: checkout.URL})
+}
+
+// GetSubscriptions lists paid subscriptions with pagination, newest first
+func (h *BillingHandler) GetSubscriptions(c *gin.Context) {
+	var query dtos.BillingSubscriptionQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidBillingFilter, "details": err.Error()})
+		return
+	}
+
+	page, pageSize := query.GetDefaults()
+	offset := query.CalculateOffset()
+
+	filter := billing.SubscriptionFilter{
+		SubscriberID: query.SubscriberID,
+		TopicID:      query.TopicID,
+		Status:       query.Status,
+	}
+	subscriptions, total, err := h.billingService.GetSubscriptionsWithPagination(c.Request.Context(), filter, offset, pageSize)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	response := make([]dtos.BillingSubscriptionResponse, 0, len(subscriptions))
+	for _, s := range subscriptions {
+		response = append(response, dtos.BillingSubscriptionResponse{
+			ID:                   s.ID,
+			SubscriberID:         s.SubscriberID,
+			PlanID:               s.PlanID,
+			TopicID:              s.TopicID,
+			StripeCustomerID:     s.StripeCustomerID,
+			StripeSubscriptionID: s.StripeSubscriptionID,
+			Status:               s.Status,
+			Entitled:             billing.Entitled(s.Status),
+			CurrentPeriodEnd:     s.CurrentPeriodEnd,
+			CancelAtPeriodEnd:    s.CancelAtPeriodEnd,
+			PaymentFailedAt:      s.PaymentFailedAt,
+			DunningEmailsSent:    s.DunningEmailsSent,
+			CreatedAt:            s.CreatedAt,
+			UpdatedAt:            s.UpdatedAt,
+		})
+	}
+
+	c.JSON(http.StatusOK, dtos.PaginatedResponse[dtos.BillingSubscriptionResponse]{
+		Data:       response,
+		Pagination: dtos.CreatePaginationResponse(page, pageSize, total),
+	})
+}
+
+// StripeWebhook receives Stripe's events. The signature covers the exact bytes sent, so the body is read
+// as is rather than bound. Errors other than a bad request make Stripe retry the event later.
+func (h *BillingHandler) StripeWebhook(c *gin.Context) {
+	payload, err := io.ReadAll(io.LimitReader(c.Request.Body, maxStripeEventSize))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidRequestBody})
+		return
+	}
+
+	err = h.billingService.HandleWebhook(c.Request.Context(), payload, c.GetHeader("Stripe-Signature"))
+	switch {
+	case err == nil:
+		c.JSON(http.StatusOK, gin.H{"received": true})
+	case errors.Is(err, billing.ErrDisabled):
+		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrBillingDisabled})
+	case errors.Is(err, billing.ErrInvalidSignature):
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidStripeSignature})
+	case errors.Is(err, billing.ErrInvalidEvent):
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidStripeEvent})
+	default:
+		abortWithError(c, err)
+	}
+}
+
+// writeError responds to a failed plan change or checkout
+func (h *BillingHandler) writeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrPlanNotFound})
+	case errors.Is(err, billing.ErrTopicNotFound):
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrTopicNotFound})
+	case errors.Is(err, billing.ErrSubscriberNotFound):
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrSubscriberNotFound})
+	case errors.Is(err, billing.ErrDuplicatePrice):
+		c.JSON(http.StatusConflict, gin.H{"error": constants.ErrPlanPriceExists})
+	case errors.Is(err, billing.ErrDisabled):
+		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrBillingDisabled})
+	case errors.Is(err, billing.ErrPlanInactive):
+		c.JSON(http.StatusConflict, gin.H{"error": constants.ErrPlanInactive})
+	case errors.Is(err, billing.ErrAlreadyEntitled):
+		apperrors.Abort(c, apperrors.NewConflictError(constants.ErrAlreadyEntitled, err))
+	default:
+		abortWithError(c, err)
+	}
+}
+
+func toPlanResponse(p *billing.Plan) dtos.PlanResponse {
+	return dtos.PlanResponse{
+		ID:            p.ID,
+		TopicID:       p.TopicID,
+		Name:          p.Name,
+		Description:   p.Description,
+		StripePriceID: p.StripePriceID,
+		AmountCents:   p.AmountCents,
+		Currency:      p.Currency,
+		Interval:      p.Interval,
+		Active:        p.Active,
+		CreatedAt:     p.CreatedAt,
+		UpdatedAt:     p.UpdatedAt,
+	}
+}
@@ -1,63 +1,103 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 
 	"newsletter-service/internal/constants"
+	"newsletter-service/internal/daos"
 	"newsletter-service/internal/dtos"
+	"newsletter-service/internal/events"
+	"newsletter-service/internal/locale"
+	"newsletter-service/internal/logger"
+	"newsletter-service/internal/router/middleware"
+	"newsletter-service/internal/services/approval"
+	"newsletter-service/internal/services/audit"
 	"newsletter-service/internal/services/content"
+	"newsletter-service/internal/services/lint"
 )
 
 type ContentHandler struct {
-	contentService content.Service
+	contentService  content.Service
+	lintService     lint.Service
+	approvalService approval.Service
+	auditService    audit.Service
+	eventBus        *events.Bus
 }
 
-func NewContentHandler(contentService content.Service) *ContentHandler {
+func NewContentHandler(contentService content.Service, lintService lint.Service, approvalService approval.Service, auditService audit.Service, eventBus *events.Bus) *ContentHandler {
 	return &ContentHandler{
-		contentService: contentService,
+		contentService:  contentService,
+		lintService:     lintService,
+		approvalService: approvalService,
+		auditService:    auditService,
+		eventBus:        eventBus,
 	}
 }
 
 // GetContents retrieves all content
 func (h *ContentHandler) GetContents(c *gin.Context) {
-	var pagination dtos.PaginationRequest
+	var pagination dtos.ListQuery
 	if err := c.ShouldBindQuery(&pagination); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidPaginationParams})
 		return
 	}
+	shape, ok := bindShape(c, dtos.ContentResponse{}, contentIncludes)
+	if !ok {
+		return
+	}
+
+	ids, err := pagination.ParseIDs()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidIDs, "details": err.Error()})
+		return
+	}
+	if ids != nil {
+		contents, err := h.contentService.GetContentsByIDs(c.Request.Context(), ids)
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+		data, ok := h.contentList(c, shape, orderByIDs(contents, ids, func(contentModel *content.Content) uint { return contentModel.ID }))
+		if !ok {
+			return
+		}
+		if data == nil {
+			data = []interface{}{}
+		}
+		c.JSON(http.StatusOK, data)
+		return
+	}
 
-	// Check if pagination parameters were provided
-	if pagination.Page > 0 || pagination.PageSize > 0 {
+	// Check if pagination parameters were provided; soft-deleted rows are only listed paginated
+	if pagination.Page > 0 || pagination.PageSize > 0 || pagination.IncludeDeleted {
 		// Use paginated response
 		page, pageSize := pagination.GetDefaults()
 		offset := pagination.CalculateOffset()
 
-		contents, total, err := h.contentService.GetAllContentWithPagination(c.Request.Context(), offset, pageSize)
+		listPage := h.contentService.GetAllContentWithPagination
+		if pagination.IncludeDeleted {
+			listPage = h.contentService.GetAllContentIncludingDeletedWithPagination
+		}
+
+		contents, total, err := listPage(c.Request.Context(), offset, pageSize)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			abortWithError(c, err)
 			return
 		}
 
-		var response []dtos.ContentResponse
-		for _, content := range contents {
-			response = append(response, dtos.ContentResponse{
-				ID:          content.ID,
-				TopicID:     content.TopicID,
-				Title:       content.Title,
-				Body:        content.Body,
-				IsPublished: content.IsPublished,
-				PublishedAt: content.PublishedAt,
-				CreatedAt:   content.CreatedAt,
-				UpdatedAt:   content.UpdatedAt,
-			})
+		data, ok := h.contentList(c, shape, contents)
+		if !ok {
+			return
 		}
 
 		paginationResponse := dtos.CreatePaginationResponse(page, pageSize, total)
-		paginatedResponse := dtos.PaginatedResponse[dtos.ContentResponse]{
-			Data:       response,
+		paginatedResponse := dtos.PaginatedResponse[interface{}]{
+			Data:       data,
 			Pagination: paginationResponse,
 		}
 
@@ -66,63 +106,112 @@ func (h *ContentHandler) GetContents(c *gin.Context) {
 		// Use non-paginated response for backward compatibility
 		contents, err := h.contentService.GetAllContent(c.Request.Context())
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			abortWithError(c, err)
 			return
 		}
 
-		var response []dtos.ContentResponse
-		for _, content := range contents {
-			response = append(response, dtos.ContentResponse{
-				ID:          content.ID,
-				TopicID:     content.TopicID,
-				Title:       content.Title,
-				Body:        content.Body,
-				IsPublished: content.IsPublished,
-				PublishedAt: content.PublishedAt,
-				CreatedAt:   content.CreatedAt,
-				UpdatedAt:   content.UpdatedAt,
-			})
+		data, ok := h.contentList(c, shape, contents)
+		if !ok {
+			return
+		}
+
+		c.JSON(http.StatusOK, data)
+	}
+}
+
+// contentIncludes are the embeds content responses offer
+var contentIncludes = map[string]string{"topic": "topic"}
+
+// contentList builds the items of a content list, with each content's topic when include=topic asked for it
+func (h *ContentHandler) contentList(c *gin.Context, shape dtos.Shape, contents []*content.Content) ([]interface{}, bool) {
+	if shape.Includes("topic") {
+		if err := h.contentService.LoadTopics(c.Request.Context(), contents); err != nil {
+			abortWithError(c, err)
+			return nil, false
 		}
+	}
 
-		c.JSON(http.StatusOK, response)
+	var response []dtos.ContentResponse
+	for _, contentModel := range contents {
+		response = append(response, *toContentResponse(contentModel))
 	}
+	data, err := dtos.SelectAll(shape, response)
+	if err != nil {
+		abortWithError(c, err)
+		return nil, false
+	}
+	return data, true
 }
 
 // CreateContent creates new content
 func (h *ContentHandler) CreateContent(c *gin.Context) {
 	var req dtos.CreateContentRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidRequestBody})
+	if !middleware.ValidateJSON(c, &req) {
+		return
+	}
+
+	if err := content.ValidateLocalSendTime(req.LocalSendTime); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidLocalSendTime, "details": err.Error()})
+		return
+	}
+
+	if req.Locale != "" && !locale.Valid(req.Locale) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidLocale})
 		return
 	}
 
 	contentModel := &content.Content{
-		TopicID:     req.TopicID,
-		Title:       req.Title,
-		Body:        req.Body,
-		IsPublished: false,
+		TopicID:       req.TopicID,
+		Title:         req.Title,
+		Body:          req.Body,
+		PreviewText:   req.PreviewText,
+		Locale:        locale.Normalize(req.Locale),
+		IsPublished:   false,
+		ApprovalState: daos.ApprovalStateDraft,
+		SendAt:        req.SendAt,
+		LocalSendTime: req.LocalSendTime,
+		Channels:      daos.EncodeChannels(req.Channels),
+		UTM:           toUTMModel(req.UTM),
+		Premium:       req.Premium,
+		Priority:      req.Priority,
 	}
 
 	if err := h.contentService.CreateContent(c.Request.Context(), contentModel); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		if errors.Is(err, content.ErrTopicArchived) {
+			c.JSON(http.StatusConflict, gin.H{"error": constants.ErrTopicArchived})
+			return
+		}
+		abortWithError(c, err)
 		return
 	}
 
+	recordAudit(c, h.auditService, audit.ActionCreate, audit.EntityContent, contentModel.ID, nil, contentModel)
+
 	response := dtos.ContentResponse{
-		ID:          contentModel.ID,
-		TopicID:     contentModel.TopicID,
-		Title:       contentModel.Title,
-		Body:        contentModel.Body,
-		IsPublished: contentModel.IsPublished,
-		PublishedAt: contentModel.PublishedAt,
-		CreatedAt:   contentModel.CreatedAt,
-		UpdatedAt:   contentModel.UpdatedAt,
+		ID:            contentModel.ID,
+		TopicID:       contentModel.TopicID,
+		Title:         contentModel.Title,
+		Body:          contentModel.Body,
+		PreviewText:   contentModel.PreviewText,
+		Locale:        contentModel.Locale,
+		IsPublished:   contentModel.IsPublished,
+		PublishedAt:   contentModel.PublishedAt,
+		ApprovalState: contentModel.ApprovalState,
+		Reviewer:      contentModel.Reviewer,
+		SendAt:        contentModel.SendAt,
+		LocalSendTime: contentModel.LocalSendTime,
+		Channels:      daos.DecodeChannels(contentModel.Channels),
+		UTM:           toUTMResponse(contentModel.UTM),
+		Premium:       contentModel.Premium,
+		Priority:      contentModel.Priority,
+		CreatedAt:     contentModel.CreatedAt,
+		UpdatedAt:     contentModel.UpdatedAt,
 	}
 
 	c.JSON(http.StatusCreated, response)
 }
 
-// GetContentByID retrieves content by ID
+// GetContentByID retrieves content by ID, answering 304 when the client's copy is current
 func (h *ContentHandler) GetContentByID(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
@@ -130,24 +219,28 @@ func (h *ContentHandler) GetContentByID(c *gin.Context) {
 		return
 	}
 
+	shape, ok := bindShape(c, dtos.ContentResponse{}, contentIncludes)
+	if !ok {
+		return
+	}
+
 	contentModel, err := h.contentService.GetContentByID(c.Request.Context(), uint(id))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrContentNotFound})
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrContentNotFound})
+			return
+		}
+		abortWithError(c, err)
 		return
 	}
-
-	response := dtos.ContentResponse{
-		ID:          contentModel.ID,
-		TopicID:     contentModel.TopicID,
-		Title:       contentModel.Title,
-		Body:        contentModel.Body,
-		IsPublished: contentModel.IsPublished,
-		PublishedAt: contentModel.PublishedAt,
-		CreatedAt:   contentModel.CreatedAt,
-		UpdatedAt:   contentModel.UpdatedAt,
+	if shape.Includes("topic") {
+		if err := h.contentService.LoadTopics(c.Request.Context(), []*content.Content{contentModel}); err != nil {
+			abortWithError(c, err)
+			return
+		}
 	}
 
-	c.JSON(http.StatusOK, response)
+	respondShaped(c, shape, toContentResponse(contentModel), contentETag(contentModel))
 }
 
 // UpdateContent updates content
@@ -159,8 +252,7 @@ func (h *ContentHandler) UpdateContent(c *gin.Context) {
 	}
 
 	var req dtos.UpdateContentRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidRequestBody})
+	if !middleware.ValidateJSON(c, &req) {
 		return
 	}
 
@@ -174,15 +266,100 @@ func (h *ContentHandler) UpdateContent(c *gin.Context) {
 	if req.Body != "" {
 		updates["body"] = req.Body
 	}
+	if req.PreviewText != nil {
+		updates["preview_text"] = *req.PreviewText
+	}
+	if req.Locale != "" {
+		if !locale.Valid(req.Locale) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidLocale})
+			return
+		}
+		updates["locale"] = locale.Normalize(req.Locale)
+	}
+	if req.SendAt != nil {
+		updates["send_at"] = *req.SendAt
+	}
+	if req.LocalSendTime != "" {
+		if err := content.ValidateLocalSendTime(req.LocalSendTime); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidLocalSendTime, "details": err.Error()})
+			return
+		}
+		updates["local_send_time"] = req.LocalSendTime
+	}
+	if len(req.Channels) > 0 {
+		updates["channels"] = daos.EncodeChannels(req.Channels)
+	}
+	if req.UTM != nil {
+		utmUpdates(updates, req.UTM)
+	}
+	if req.Premium != nil {
+		updates["premium"] = *req.Premium
+	}
+	if req.Priority != "" {
+		updates["priority"] = req.Priority
+	}
+
+	before, _ := h.contentService.GetContentByID(c.Request.Context(), uint(id))
+	if !checkIfMatch(c, contentETag(before)) {
+		return
+	}
 
 	if err := h.contentService.UpdateContent(c.Request.Context(), uint(id), updates); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		if errors.Is(err, content.ErrTopicArchived) {
+			c.JSON(http.StatusConflict, gin.H{"error": constants.ErrTopicArchived})
+			return
+		}
+		abortWithError(c, err)
 		return
 	}
 
+	h.reopen(c, uint(id))
+
+	after, _ := h.contentService.GetContentByID(c.Request.Context(), uint(id))
+	recordAudit(c, h.auditService, audit.ActionUpdate, audit.EntityContent, uint(id), before, after)
+
+	setETag(c, contentETag(after))
 	c.JSON(http.StatusOK, gin.H{"message": constants.MsgContentUpdatedSuccessfully})
 }
 
+// contentETag returns the ETag of a content, or "" for a nil one
+func contentETag(contentModel *content.Content) string {
+	if contentModel == nil {
+		return ""
+	}
+	return resourceETag(contentModel.ID, contentModel.UpdatedAt)
+}
+
+// toContentResponse is content as GET /contents/:id returns it, nil for nil content
+func toContentResponse(contentModel *content.Content) *dtos.ContentResponse {
+	if contentModel == nil {
+		return nil
+	}
+	return &dtos.ContentResponse{
+		ID:            contentModel.ID,
+		TopicID:       contentModel.TopicID,
+		Title:         contentModel.Title,
+		Body:          contentModel.Body,
+		PreviewText:   contentModel.PreviewText,
+		Locale:        contentModel.Locale,
+		IsPublished:   contentModel.IsPublished,
+		PublishedAt:   contentModel.PublishedAt,
+		ApprovalState: contentModel.ApprovalState,
+		Reviewer:      contentModel.Reviewer,
+		SendAt:        contentModel.SendAt,
+		LocalSendTime: contentModel.LocalSendTime,
+		Channels:      daos.DecodeChannels(contentModel.Channels),
+		UTM:           toUTMResponse(contentModel.UTM),
+		Premium:       contentModel.Premium,
+		Priority:      contentModel.Priority,
+		Delivery:      toDeliveryResponse(contentModel),
+		Topic:         toTopicResponse(contentModel.Topic),
+		CreatedAt:     contentModel.CreatedAt,
+		UpdatedAt:     contentModel.UpdatedAt,
+		DeletedAt:     deletedAt(contentModel.DeletedAt),
+	}
+}
+
 // DeleteContent deletes content
 func (h *ContentHandler) DeleteContent(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
@@ -191,14 +368,41 @@ func (h *ContentHandler) DeleteContent(c *gin.Context) {
 		return
 	}
 
+	before, _ := h.contentService.GetContentByID(c.Request.Context(), uint(id))
+
 	if err := h.contentService.DeleteContent(c.Request.Context(), uint(id)); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		abortWithError(c, err)
 		return
 	}
 
+	recordAudit(c, h.auditService, audit.ActionDelete, audit.EntityContent, uint(id), before, nil)
+
 	c.JSON(http.StatusOK, gin.H{"message": constants.MsgContentDeletedSuccessfully})
 }
 
+// RestoreContent undoes the soft delete of content
+func (h *ContentHandler) RestoreContent(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidContentID})
+		return
+	}
+
+	if err := h.contentService.RestoreContent(c.Request.Context(), uint(id)); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrContentNotFound})
+			return
+		}
+		abortWithError(c, err)
+		return
+	}
+
+	after, _ := h.contentService.GetContentByID(c.Request.Context(), uint(id))
+	recordAudit(c, h.auditService, audit.ActionRestore, audit.EntityContent, uint(id), nil, after)
+
+	c.JSON(http.StatusOK, gin.H{"message": constants.MsgContentRestoredSuccessfully})
+}
+
 // PublishContent publishes content
 func (h *ContentHandler) PublishContent(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
@@ -207,20 +411,185 @@ func (h *ContentHandler) PublishContent(c *gin.Context) {
 		return
 	}
 
+	// When the approval workflow is enabled only approved content is published, and only by publishers
+	if err := h.approvalService.CheckPublish(c.Request.Context(), uint(id), approvalActor(c)); err != nil {
+		writeApprovalError(c, err)
+		return
+	}
+
+	before, _ := h.contentService.GetContentByID(c.Request.Context(), uint(id))
+
+	// Unsafe HTML is removed before publishing; lint errors block it when configured to
+	if report, err := h.lintService.PrepareForPublish(c.Request.Context(), uint(id)); err != nil {
+		switch {
+		case errors.Is(err, lint.ErrPublishBlocked):
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": constants.ErrContentLintFailed, "lint": report})
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrContentNotFound})
+		default:
+			abortWithError(c, err)
+		}
+		return
+	}
+
 	if err := h.contentService.PublishContent(c.Request.Context(), uint(id)); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		abortWithError(c, err)
 		return
 	}
+	if err := h.approvalService.MarkPublished(c.Request.Context(), uint(id), approvalActor(c)); err != nil {
+		logger.Warn(c.Request.Context(), "Failed to record approval state of published content %d: %v", id, err)
+	}
+
+	after, _ := h.contentService.GetContentByID(c.Request.Context(), uint(id))
+	recordAudit(c, h.auditService, audit.ActionPublish, audit.EntityContent, uint(id), before, after)
+	if after != nil {
+		h.eventBus.Emit(c.Request.Context(), events.ContentPublished, after)
+	}
 
 	c.JSON(http.StatusOK, gin.H{"message": constants.MsgContentPublishedSuccessfully})
 }
 
+// LintContent reports unsafe HTML, broken links, image problems and the spam score of content without
+// changing it
+func (h *ContentHandler) LintContent(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidContentID})
+		return
+	}
+
+	report, err := h.lintService.LintContent(c.Request.Context(), uint(id))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrContentNotFound})
+			return
+		}
+		abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// GetContentTranslations lists the content's translations by locale
+func (h *ContentHandler) GetContentTranslations(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidContentID})
+		return
+	}
+
+	translations, err := h.contentService.GetTranslations(c.Request.Context(), uint(id))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrContentNotFound})
+			return
+		}
+		abortWithError(c, err)
+		return
+	}
+
+	response := make([]dtos.ContentTranslationResponse, len(translations))
+	for i, translation := range translations {
+		response[i] = toTranslationResponse(translation)
+	}
+	c.JSON(http.StatusOK, gin.H{"translations": response})
+}
+
+// SetContentTranslation creates or replaces the content's translation into the locale in the path.
+// Subscribers preferring that locale receive it instead of the content's own title and body.
+func (h *ContentHandler) SetContentTranslation(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidContentID})
+		return
+	}
+	if !locale.Valid(c.Param("locale")) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidLocale})
+		return
+	}
+
+	var req dtos.SetContentTranslationRequest
+	if !middleware.ValidateJSON(c, &req) {
+		return
+	}
+
+	translation := &content.Translation{
+		ContentID:   uint(id),
+		Locale:      locale.Normalize(c.Param("locale")),
+		Title:       req.Title,
+		Body:        req.Body,
+		PreviewText: req.PreviewText,
+	}
+	if err := h.contentService.SetTranslation(c.Request.Context(), translation); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrContentNotFound})
+			return
+		}
+		abortWithError(c, err)
+		return
+	}
+
+	h.reopen(c, uint(id))
+	recordAudit(c, h.auditService, audit.ActionUpdate, audit.EntityContent, uint(id), nil, translation)
+	c.JSON(http.StatusOK, toTranslationResponse(translation))
+}
+
+// DeleteContentTranslation removes the content's translation into the locale in the path
+func (h *ContentHandler) DeleteContentTranslation(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidContentID})
+		return
+	}
+	if !locale.Valid(c.Param("locale")) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidLocale})
+		return
+	}
+
+	deleted, err := h.contentService.DeleteTranslation(c.Request.Context(), uint(id), locale.Normalize(c.Param("locale")))
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrContentNotFound})
+		case errors.Is(err, content.ErrTranslationNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrTranslationNotFound})
+		default:
+			abortWithError(c, err)
+		}
+		return
+	}
+
+	h.reopen(c, uint(id))
+	recordAudit(c, h.auditService, audit.ActionUpdate, audit.EntityContent, uint(id), deleted, nil)
+	c.JSON(http.StatusOK, gin.H{"message": constants.MsgTranslationDeletedSuccessfully})
+}
+
+// reopen returns edited content that was submitted or approved to draft, so the edit is reviewed before it
+// goes out. The edit itself has been saved, so failing to reopen is only logged.
+func (h *ContentHandler) reopen(c *gin.Context, id uint) {
+	if err := h.approvalService.Reopen(c.Request.Context(), id, approvalActor(c)); err != nil {
+		logger.Warn(c.Request.Context(), "Failed to return edited content %d to draft: %v", id, err)
+	}
+}
+
+func toTranslationResponse(translation *content.Translation) dtos.ContentTranslationResponse {
+	return dtos.ContentTranslationResponse{
+		Locale:      translation.Locale,
+		Title:       translation.Title,
+		Body:        translation.Body,
+		PreviewText: translation.PreviewText,
+		CreatedAt:   translation.CreatedAt,
+		UpdatedAt:   translation.UpdatedAt,
+	}
+}
+
 // GetPendingNotifications gets content that needs notifications sent
 func (h *ContentHandler) GetPendingNotifications(c *gin.Context) {
 	// Get contents that are published but haven't been sent yet
 	pendingContents, err := h.contentService.GetPendingNotifications(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		abortWithError(c, err)
 		return
 	}
 
@@ -229,3 +598,17 @@ func (h *ContentHandler) GetPendingNotifications(c *gin.Context) {
 		"content_ids":           pendingContents,
 	})
 }
+
+// toDeliveryResponse returns nil for content that hasn't been sent
+func toDeliveryResponse(contentModel *content.Content) *dtos.DeliveryResponse {
+	if contentModel.DeliveryState == "" {
+		return nil
+	}
+	return &dtos.DeliveryResponse{
+		State:       contentModel.DeliveryState,
+		Sent:        contentModel.DeliverySent,
+		Failed:      contentModel.DeliveryFailed,
+		Outstanding: contentModel.DeliveryOutstanding,
+		CompletedAt: contentModel.DeliveryCompletedAt,
+	}
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+
+	newsletterservice "newsletter-service"
+)
+
+// swaggerUIPage renders Swagger UI against /openapi.json using the public swagger-ui-dist bundle
+const swaggerUIPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>Newsletter Service API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js" crossorigin></script>
+  <script>
+    window.onload = function () {
+      window.ui = SwaggerUIBundle({ url: "/openapi.json", dom_id: "#swagger-ui" });
+    };
+  </script>
+</body>
+</html>`
+
+type DocsHandler struct {
+	once     sync.Once
+	specJSON []byte
+	specErr  error
+}
+
+func NewDocsHandler() *DocsHandler {
+	return &DocsHandler{}
+}
+
+// OpenAPIJSON serves the OpenAPI specification as JSON
+func (h *DocsHandler) OpenAPIJSON(c *gin.Context) {
+	h.once.Do(func() {
+		h.specJSON, h.specErr = newsletterservice.APIDocsJSON()
+	})
+	if h.specErr != nil {
+		abortWithError(c, fmt.Errorf("failed to render OpenAPI spec: %w", h.specErr))
+		return
+	}
+
+	c.Data(http.StatusOK, "application/json; charset=utf-8", h.specJSON)
+}
+
+// OpenAPIYAML serves the OpenAPI specification as it is maintained in the repository
+func (h *DocsHandler) OpenAPIYAML(c *gin.Context) {
+	c.Data(http.StatusOK, "application/yaml; charset=utf-8", newsletterservice.APIDocsYAML)
+}
+
+// SwaggerUI serves an interactive API explorer
+func (h *DocsHandler) SwaggerUI(c *gin.Context) {
+	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(swaggerUIPage))
+}
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"newsletter-service/internal/constants"
+	"newsletter-service/internal/dtos"
+	"newsletter-service/internal/providers/templates"
+	"newsletter-service/internal/router/middleware"
+	"newsletter-service/internal/services/audit"
+	"newsletter-service/internal/services/emailtemplate"
+)
+
+type EmailTemplateHandler struct {
+	emailTemplateService emailtemplate.Service
+	auditService         audit.Service
+}
+
+func NewEmailTemplateHandler(emailTemplateService emailtemplate.Service, auditService audit.Service) *EmailTemplateHandler {
+	return &EmailTemplateHandler{
+		emailTemplateService: emailTemplateService,
+		auditService:         auditService,
+	}
+}
+
+// GetEmailTemplates reports the template source and whether each template came from it or is built in
+func (h *EmailTemplateHandler) GetEmailTemplates(c *gin.Context) {
+	c.JSON(http.StatusOK, h.emailTemplateService.GetStatus())
+}
+
+// GetEmailTemplate returns a template's text as stored, or the built-in text when the source has none
+func (h *EmailTemplateHandler) GetEmailTemplate(c *gin.Context) {
+	template, err := h.emailTemplateService.GetTemplate(c.Request.Context(), c.Param("name"))
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, template)
+}
+
+// UpdateEmailTemplate stores a template once it renders a sample email, and starts using it in this process.
+// Other web and worker replicas pick it up on their next refresh or reload.
+func (h *EmailTemplateHandler) UpdateEmailTemplate(c *gin.Context) {
+	var req dtos.UpdateEmailTemplateRequest
+	if !middleware.ValidateJSON(c, &req) {
+		return
+	}
+
+	name := c.Param("name")
+	before, err := h.emailTemplateService.GetTemplate(c.Request.Context(), name)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	status, err := h.emailTemplateService.SaveTemplate(c.Request.Context(), name, req.Body)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+	recordAudit(c, h.auditService, audit.ActionUpdate, audit.EntityEmailTemplate, 0, before, &emailtemplate.Template{Name: name, Body: req.Body})
+
+	c.JSON(http.StatusOK, status)
+}
+
+// ReloadEmailTemplates re-reads the templates from their source, e.g. after editing the files or bucket
+func (h *EmailTemplateHandler) ReloadEmailTemplates(c *gin.Context) {
+	status, err := h.emailTemplateService.Reload(c.Request.Context())
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, status)
+}
+
+// writeError responds to a failed template lookup, update or reload
+func (h *EmailTemplateHandler) writeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, emailtemplate.ErrUnknownTemplate):
+		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrUnknownEmailTemplate})
+	case errors.Is(err, emailtemplate.ErrReadOnlySource):
+		c.JSON(http.StatusConflict, gin.H{"error": constants.ErrEmailTemplatesBuiltin})
+	case errors.Is(err, templates.ErrInvalidTemplate):
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidEmailTemplate, "details": err.Error()})
+	default:
+		abortWithError(c, err)
+	}
+}
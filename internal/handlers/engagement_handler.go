@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"newsletter-service/internal/constants"
+	"newsletter-service/internal/dtos"
+	"newsletter-service/internal/services/engagement"
+)
+
+type EngagementHandler struct {
+	engagementService engagement.Service
+}
+
+func NewEngagementHandler(engagementService engagement.Service) *EngagementHandler {
+	return &EngagementHandler{
+		engagementService: engagementService,
+	}
+}
+
+// GetEngagementSegment lists subscribers filtered by engagement score and sunset state, most engaged first
+func (h *EngagementHandler) GetEngagementSegment(c *gin.Context) {
+	var query dtos.EngagementSegmentQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidEngagementFilter, "details": err.Error()})
+		return
+	}
+	signup, ok := signupFilter(c, query.SignupFilterQuery)
+	if !ok {
+		return
+	}
+
+	segment := engagement.Segment{
+		MinScore: query.MinScore,
+		MaxScore: query.MaxScore,
+		Sunset:   query.Sunset,
+		Signup:   signup,
+	}
+
+	page, pageSize := query.GetDefaults()
+	offset := query.CalculateOffset()
+
+	subscribers, total, err := h.engagementService.GetSegmentWithPagination(c.Request.Context(), segment, offset, pageSize)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	response := make([]dtos.SubscriberEngagementResponse, 0, len(subscribers))
+	for _, sub := range subscribers {
+		response = append(response, dtos.SubscriberEngagementResponse{
+			ID:              sub.ID,
+			Email:           sub.Email,
+			Name:            sub.Name,
+			IsActive:        sub.IsActive,
+			EngagementScore: sub.EngagementScore,
+			LastEngagedAt:   sub.LastEngagedAt,
+			SunsetAt:        sub.SunsetAt,
+		})
+	}
+
+	paginationResponse := dtos.CreatePaginationResponse(page, pageSize, total)
+	c.JSON(http.StatusOK, dtos.PaginatedResponse[dtos.SubscriberEngagementResponse]{
+		Data:       response,
+		Pagination: paginationResponse,
+	})
+}
@@ -0,0 +1,14 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+
+	apperrors "newsletter-service/internal/errors"
+)
+
+// abortWithError answers a request that failed with an error the handler has no specific response for. The
+// error is classified into an AppError; internal errors are logged by the error middleware and reach the
+// client only as a generic message.
+func abortWithError(c *gin.Context, err error) {
+	apperrors.Abort(c, apperrors.Classify(err))
+}
@@ -0,0 +1,245 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	apperrors "newsletter-service/internal/errors"
+	"newsletter-service/internal/services/content"
+	"newsletter-service/internal/services/subscriber"
+	"newsletter-service/internal/services/topic"
+)
+
+// sqlErr stands for a database error whose message must not reach clients
+var sqlErr = errors.New(`pq: relation "subscribers" does not exist`)
+
+// errorCase is a request answered by a service failing with err. wantCode is the AppError code of the body,
+// empty for the endpoint-specific {"error": message} bodies.
+type errorCase struct {
+	name       string
+	method     string
+	path       string
+	body       string
+	err        error
+	wantStatus int
+	wantCode   string
+}
+
+// fakeSubscriberService fails every call a subscriber endpoint makes with err. Calls the tests don't expect
+// reach the nil embedded Service and panic.
+type fakeSubscriberService struct {
+	subscriber.Service
+	err error
+}
+
+func (f *fakeSubscriberService) CreateSubscriberWithTopics(ctx context.Context, sub *subscriber.Subscriber, topicNames []string) ([]string, error) {
+	return nil, f.err
+}
+
+func (f *fakeSubscriberService) GetSubscriberByIDWithTopics(ctx context.Context, id uint) (*subscriber.Subscriber, []string, error) {
+	return nil, nil, f.err
+}
+
+func (f *fakeSubscriberService) UpdateSubscriberWithTopics(ctx context.Context, id uint, updates map[string]interface{}, topicNames []string) error {
+	return f.err
+}
+
+func (f *fakeSubscriberService) DeleteSubscriber(ctx context.Context, id uint) error {
+	return f.err
+}
+
+// fakeTopicService fails every call a topic endpoint makes with err
+type fakeTopicService struct {
+	topic.Service
+	err error
+}
+
+func (f *fakeTopicService) CreateTopic(ctx context.Context, t *topic.Topic) error {
+	return f.err
+}
+
+func (f *fakeTopicService) GetTopicByID(ctx context.Context, id uint) (*topic.Topic, error) {
+	return nil, f.err
+}
+
+func (f *fakeTopicService) UpdateTopic(ctx context.Context, id uint, updates map[string]interface{}) error {
+	return f.err
+}
+
+func (f *fakeTopicService) DeleteTopic(ctx context.Context, id uint, force bool) (*topic.DeleteSummary, error) {
+	return nil, f.err
+}
+
+// fakeContentService fails every call a content endpoint makes with err
+type fakeContentService struct {
+	content.Service
+	err error
+}
+
+func (f *fakeContentService) CreateContent(ctx context.Context, c *content.Content) error {
+	return f.err
+}
+
+func (f *fakeContentService) GetContentByID(ctx context.Context, id uint) (*content.Content, error) {
+	return nil, f.err
+}
+
+func (f *fakeContentService) UpdateContent(ctx context.Context, id uint, updates map[string]interface{}) error {
+	return f.err
+}
+
+func (f *fakeContentService) DeleteContent(ctx context.Context, id uint) error {
+	return f.err
+}
+
+func (f *fakeContentService) RestoreContent(ctx context.Context, id uint) error {
+	return f.err
+}
+
+func TestSubscriberErrorStatus(t *testing.T) {
+	tests := []errorCase{
+		{name: "get missing", method: http.MethodGet, path: "/subscribers/7", err: gorm.ErrRecordNotFound, wantStatus: http.StatusNotFound},
+		{name: "get database error", method: http.MethodGet, path: "/subscribers/7", err: sqlErr, wantStatus: http.StatusInternalServerError, wantCode: apperrors.CodeInternal},
+		{name: "get invalid id", method: http.MethodGet, path: "/subscribers/abc", wantStatus: http.StatusBadRequest},
+		{name: "create invalid email", method: http.MethodPost, path: "/subscribers", body: `{"name":"Ada","email":"not-an-address"}`, wantStatus: http.StatusBadRequest},
+		{name: "create missing name", method: http.MethodPost, path: "/subscribers", body: `{"email":"ada@example.com"}`, wantStatus: http.StatusBadRequest},
+		{name: "create unknown topic", method: http.MethodPost, path: "/subscribers", body: `{"name":"Ada","email":"ada@example.com","subscribed_topics":["Nope"]}`, err: subscriber.ErrTopicsNotFound, wantStatus: http.StatusBadRequest},
+		{name: "create duplicate email", method: http.MethodPost, path: "/subscribers", body: `{"name":"Ada","email":"ada@example.com"}`, err: subscriber.ErrDuplicateEmail, wantStatus: http.StatusConflict, wantCode: apperrors.CodeConflict},
+		{name: "create archived topic", method: http.MethodPost, path: "/subscribers", body: `{"name":"Ada","email":"ada@example.com","subscribed_topics":["Old"]}`, err: subscriber.ErrTopicArchived, wantStatus: http.StatusConflict},
+		{name: "update missing", method: http.MethodPut, path: "/subscribers/7", body: `{"name":"Ada"}`, err: fmt.Errorf("update subscriber 7: %w", gorm.ErrRecordNotFound), wantStatus: http.StatusNotFound, wantCode: apperrors.CodeNotFound},
+		{name: "update duplicate email", method: http.MethodPut, path: "/subscribers/7", body: `{"email":"grace@example.com"}`, err: subscriber.ErrDuplicateEmail, wantStatus: http.StatusConflict, wantCode: apperrors.CodeConflict},
+		{name: "delete database error", method: http.MethodDelete, path: "/subscribers/7", err: sqlErr, wantStatus: http.StatusInternalServerError, wantCode: apperrors.CodeInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewSubscriberHandler(&fakeSubscriberService{err: tt.err}, nil, nil)
+			router := testRouter()
+			router.POST("/subscribers", h.CreateSubscriber)
+			router.GET("/subscribers/:id", h.GetSubscriberByID)
+			router.PUT("/subscribers/:id", h.UpdateSubscriber)
+			router.DELETE("/subscribers/:id", h.DeleteSubscriber)
+
+			checkErrorResponse(t, router, tt)
+		})
+	}
+}
+
+func TestTopicErrorStatus(t *testing.T) {
+	tests := []errorCase{
+		{name: "get missing", method: http.MethodGet, path: "/topics/7", err: gorm.ErrRecordNotFound, wantStatus: http.StatusNotFound},
+		{name: "get database error", method: http.MethodGet, path: "/topics/7", err: sqlErr, wantStatus: http.StatusInternalServerError, wantCode: apperrors.CodeInternal},
+		{name: "get invalid id", method: http.MethodGet, path: "/topics/abc", wantStatus: http.StatusBadRequest},
+		{name: "create missing name", method: http.MethodPost, path: "/topics", body: `{"description":"News"}`, wantStatus: http.StatusBadRequest},
+		{name: "create malformed body", method: http.MethodPost, path: "/topics", body: `{"name":`, wantStatus: http.StatusBadRequest},
+		{name: "create invalid slug", method: http.MethodPost, path: "/topics", body: `{"name":"News","slug":"Not A Slug"}`, err: topic.ErrInvalidSlug, wantStatus: http.StatusBadRequest},
+		{name: "create duplicate name", method: http.MethodPost, path: "/topics", body: `{"name":"News"}`, err: topic.ErrDuplicateName, wantStatus: http.StatusConflict, wantCode: apperrors.CodeConflict},
+		{name: "update duplicate slug", method: http.MethodPut, path: "/topics/7", body: `{"slug":"news"}`, err: topic.ErrDuplicateSlug, wantStatus: http.StatusConflict, wantCode: apperrors.CodeConflict},
+		{name: "update missing", method: http.MethodPut, path: "/topics/7", body: `{"name":"News"}`, err: gorm.ErrRecordNotFound, wantStatus: http.StatusNotFound, wantCode: apperrors.CodeNotFound},
+		{name: "update database error", method: http.MethodPut, path: "/topics/7", body: `{"name":"News"}`, err: sqlErr, wantStatus: http.StatusInternalServerError, wantCode: apperrors.CodeInternal},
+		{name: "update theme missing", method: http.MethodPut, path: "/topics/7/theme", body: `{"primary_color":"#112233"}`, err: gorm.ErrRecordNotFound, wantStatus: http.StatusNotFound},
+		{name: "update theme database error", method: http.MethodPut, path: "/topics/7/theme", body: `{"primary_color":"#112233"}`, err: sqlErr, wantStatus: http.StatusInternalServerError, wantCode: apperrors.CodeInternal},
+		{name: "delete missing", method: http.MethodDelete, path: "/topics/7", err: gorm.ErrRecordNotFound, wantStatus: http.StatusNotFound},
+		{name: "delete in use", method: http.MethodDelete, path: "/topics/7", err: topic.ErrTopicInUse, wantStatus: http.StatusConflict},
+		{name: "delete database error", method: http.MethodDelete, path: "/topics/7", err: sqlErr, wantStatus: http.StatusInternalServerError, wantCode: apperrors.CodeInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewTopicHandler(&fakeTopicService{err: tt.err}, nil)
+			router := testRouter()
+			router.POST("/topics", h.CreateTopic)
+			router.GET("/topics/:id", h.GetTopicByID)
+			router.PUT("/topics/:id", h.UpdateTopic)
+			router.PUT("/topics/:id/theme", h.UpdateTopicTheme)
+			router.DELETE("/topics/:id", h.DeleteTopic)
+
+			checkErrorResponse(t, router, tt)
+		})
+	}
+}
+
+func TestContentErrorStatus(t *testing.T) {
+	tests := []errorCase{
+		{name: "get missing", method: http.MethodGet, path: "/contents/7", err: gorm.ErrRecordNotFound, wantStatus: http.StatusNotFound},
+		{name: "get database error", method: http.MethodGet, path: "/contents/7", err: sqlErr, wantStatus: http.StatusInternalServerError, wantCode: apperrors.CodeInternal},
+		{name: "get invalid id", method: http.MethodGet, path: "/contents/abc", wantStatus: http.StatusBadRequest},
+		{name: "create missing title", method: http.MethodPost, path: "/contents", body: `{"topic_id":1,"body":"<p>Hi</p>"}`, wantStatus: http.StatusBadRequest},
+		{name: "create invalid local send time", method: http.MethodPost, path: "/contents", body: `{"topic_id":1,"title":"Weekly","body":"<p>Hi</p>","local_send_time":"25:00"}`, wantStatus: http.StatusBadRequest},
+		{name: "create unknown topic", method: http.MethodPost, path: "/contents", body: `{"topic_id":99,"title":"Weekly","body":"<p>Hi</p>"}`, err: gorm.ErrForeignKeyViolated, wantStatus: http.StatusConflict, wantCode: apperrors.CodeConflict},
+		{name: "create archived topic", method: http.MethodPost, path: "/contents", body: `{"topic_id":1,"title":"Weekly","body":"<p>Hi</p>"}`, err: content.ErrTopicArchived, wantStatus: http.StatusConflict},
+		{name: "update missing", method: http.MethodPut, path: "/contents/7", body: `{"title":"Weekly"}`, err: gorm.ErrRecordNotFound, wantStatus: http.StatusNotFound, wantCode: apperrors.CodeNotFound},
+		{name: "update invalid locale", method: http.MethodPut, path: "/contents/7", body: `{"locale":"not a locale"}`, wantStatus: http.StatusBadRequest},
+		{name: "delete missing", method: http.MethodDelete, path: "/contents/7", err: gorm.ErrRecordNotFound, wantStatus: http.StatusNotFound, wantCode: apperrors.CodeNotFound},
+		{name: "restore missing", method: http.MethodPost, path: "/contents/7/restore", err: gorm.ErrRecordNotFound, wantStatus: http.StatusNotFound},
+		{name: "delete database error", method: http.MethodDelete, path: "/contents/7", err: sqlErr, wantStatus: http.StatusInternalServerError, wantCode: apperrors.CodeInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewContentHandler(&fakeContentService{err: tt.err}, nil, nil, nil, nil)
+			router := testRouter()
+			router.POST("/contents", h.CreateContent)
+			router.GET("/contents/:id", h.GetContentByID)
+			router.PUT("/contents/:id", h.UpdateContent)
+			router.DELETE("/contents/:id", h.DeleteContent)
+			router.POST("/contents/:id/restore", h.RestoreContent)
+
+			checkErrorResponse(t, router, tt)
+		})
+	}
+}
+
+// testRouter is a gin engine with the error middleware the API routes run behind
+func testRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(apperrors.ErrorHandler())
+	return router
+}
+
+// checkErrorResponse sends tt's request and checks the status and error body, and that a database error's
+// message isn't passed on
+func checkErrorResponse(t *testing.T, router *gin.Engine, tt errorCase) {
+	t.Helper()
+
+	req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != tt.wantStatus {
+		t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body)
+	}
+	if strings.Contains(w.Body.String(), "pq:") {
+		t.Errorf("response passes on the database error: %s", w.Body)
+	}
+
+	var response struct {
+		Error json.RawMessage `json:"error"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil || len(response.Error) == 0 {
+		t.Fatalf("response has no error: %s", w.Body)
+	}
+	if tt.wantCode == "" {
+		return
+	}
+	var appErr struct {
+		Code string `json:"code"`
+	}
+	if err := json.Unmarshal(response.Error, &appErr); err != nil {
+		t.Fatalf("error is not an AppError body: %s", w.Body)
+	}
+	if appErr.Code != tt.wantCode {
+		t.Errorf("error code = %q, want %q", appErr.Code, tt.wantCode)
+	}
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"newsletter-service/internal/constants"
+)
+
+// resourceETag returns a strong ETag for a version of a resource: its ID, when its row was last updated and
+// any state kept outside the row, such as a subscriber's topics. The tag names the resource rather than a
+// body, so every representation of it, whatever the fields selected or the API version, gets the same one.
+func resourceETag(id uint, updatedAt time.Time, state ...interface{}) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%d:%d", id, updatedAt.UnixNano())
+	for _, s := range state {
+		fmt.Fprintf(h, ":%v", s)
+	}
+	return `"` + hex.EncodeToString(h.Sum(nil)[:16]) + `"`
+}
+
+// respondWithETag writes a resource with its ETag, or just 304 Not Modified when the client's If-None-Match
+// already names it
+func respondWithETag(c *gin.Context, etag string, representation interface{}) {
+	c.Header(constants.HeaderETag, etag)
+	if etagListed(c.GetHeader(constants.HeaderIfNoneMatch), etag, false) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.JSON(http.StatusOK, representation)
+}
+
+// checkIfMatch enforces an update's If-Match header against the resource's current ETag, "" when it doesn't
+// exist. It answers 412 Precondition Failed and returns false when the client's copy is out of date. Requests
+// without If-Match always pass.
+func checkIfMatch(c *gin.Context, current string) bool {
+	ifMatch := c.GetHeader(constants.HeaderIfMatch)
+	if ifMatch == "" {
+		return true
+	}
+	if current != "" && etagListed(ifMatch, current, true) {
+		return true
+	}
+	c.JSON(http.StatusPreconditionFailed, gin.H{"error": constants.ErrPreconditionFailed})
+	return false
+}
+
+// setETag sends a resource's ETag after it was changed, so the client can make its next conditional update
+// without fetching it again
+func setETag(c *gin.Context, etag string) {
+	if etag != "" {
+		c.Header(constants.HeaderETag, etag)
+	}
+}
+
+// etagListed reports whether an If-Match or If-None-Match header names etag or is "*". If-None-Match compares
+// weakly, ignoring W/ prefixes; If-Match compares strongly, so a weak tag never matches.
+func etagListed(header, etag string, strong bool) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" {
+			return true
+		}
+		if strings.HasPrefix(candidate, "W/") {
+			if strong {
+				continue
+			}
+			candidate = candidate[len("W/"):]
+		}
+		if candidate == etag {
+			return true
+		}
+	}
+	return false
+}
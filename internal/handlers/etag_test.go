@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"newsletter-service/internal/constants"
+	"newsletter-service/internal/services/topic"
+)
+
+// storedTopicService holds a single topic; updates bump its updated_at
+type storedTopicService struct {
+	topic.Service
+	topic topic.Topic
+}
+
+func (s *storedTopicService) GetTopicByID(ctx context.Context, id uint) (*topic.Topic, error) {
+	stored := s.topic
+	return &stored, nil
+}
+
+func (s *storedTopicService) UpdateTopic(ctx context.Context, id uint, updates map[string]interface{}) error {
+	s.topic.UpdatedAt = s.topic.UpdatedAt.Add(time.Second)
+	return nil
+}
+
+// TestTopicETagIgnoresRepresentation checks that the ETag of a topic fetched with ?fields= is accepted by
+// If-Match, and refused once the topic has changed since
+func TestTopicETagIgnoresRepresentation(t *testing.T) {
+	service := &storedTopicService{topic: topic.Topic{
+		ID:        1,
+		Name:      "weekly",
+		UpdatedAt: time.Date(2025, 12, 1, 9, 0, 0, 0, time.UTC),
+	}}
+	h := NewTopicHandler(service, nil)
+	router := testRouter()
+	router.GET("/topics/:id", h.GetTopicByID)
+	router.PUT("/topics/:id", h.UpdateTopic)
+
+	get := func(query string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/topics/1"+query, nil))
+		return w
+	}
+	update := func(ifMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPut, "/topics/1", strings.NewReader(`{"description":"Every Monday"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(constants.HeaderIfMatch, ifMatch)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	full, shaped := get(""), get("?fields=name")
+	if shaped.Code != http.StatusOK {
+		t.Fatalf("GET ?fields=name status = %d, want %d: %s", shaped.Code, http.StatusOK, shaped.Body)
+	}
+	etag := shaped.Header().Get(constants.HeaderETag)
+	if etag == "" || etag != full.Header().Get(constants.HeaderETag) {
+		t.Fatalf("ETag with ?fields= = %q, without = %q, want the same tag", etag, full.Header().Get(constants.HeaderETag))
+	}
+
+	if w := update(etag); w.Code != http.StatusOK {
+		t.Fatalf("update with the current ETag: status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+	if w := update(etag); w.Code != http.StatusPreconditionFailed {
+		t.Errorf("update with an outdated ETag: status = %d, want %d", w.Code, http.StatusPreconditionFailed)
+	}
+}
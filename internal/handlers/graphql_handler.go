@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/graph-gophers/graphql-go"
+
+	"newsletter-service/internal/constants"
+	"newsletter-service/internal/graphqlapi"
+)
+
+type GraphQLHandler struct {
+	schema   *graphql.Schema
+	services graphqlapi.Services
+}
+
+func NewGraphQLHandler(services graphqlapi.Services) *GraphQLHandler {
+	return &GraphQLHandler{
+		schema:   graphqlapi.NewSchema(services),
+		services: services,
+	}
+}
+
+// Query executes a GraphQL query for admin dashboards
+func (h *GraphQLHandler) Query(c *gin.Context) {
+	var req struct {
+		Query         string                 `json:"query" binding:"required"`
+		OperationName string                 `json:"operationName"`
+		Variables     map[string]interface{} `json:"variables"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidRequestBody})
+		return
+	}
+
+	ctx := graphqlapi.WithLoaders(c.Request.Context(), h.services)
+	response := h.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+
+	// Per the GraphQL over HTTP convention, field errors are reported in the body with a 200
+	c.JSON(http.StatusOK, response)
+}
@@ -1,20 +1,71 @@
 package handlers
 
 import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"newsletter-service/internal/daos"
+	"newsletter-service/internal/dtos"
+	"newsletter-service/internal/events"
+	"newsletter-service/internal/graphqlapi"
+	"newsletter-service/internal/schedulers"
+	"newsletter-service/internal/services/apikey"
+	"newsletter-service/internal/services/approval"
+	"newsletter-service/internal/services/asset"
+	"newsletter-service/internal/services/audit"
+	"newsletter-service/internal/services/auth"
+	"newsletter-service/internal/services/billing"
 	"newsletter-service/internal/services/content"
+	"newsletter-service/internal/services/emailtemplate"
+	"newsletter-service/internal/services/engagement"
+	"newsletter-service/internal/services/health"
+	"newsletter-service/internal/services/landing"
+	"newsletter-service/internal/services/lint"
 	"newsletter-service/internal/services/notification"
+	"newsletter-service/internal/services/organization"
+	"newsletter-service/internal/services/poll"
+	"newsletter-service/internal/services/preference"
+	"newsletter-service/internal/services/push"
+	"newsletter-service/internal/services/referral"
+	"newsletter-service/internal/services/retention"
+	"newsletter-service/internal/services/snippet"
+	"newsletter-service/internal/services/sponsor"
+	"newsletter-service/internal/services/stats"
 	"newsletter-service/internal/services/subscriber"
 	"newsletter-service/internal/services/topic"
+	"newsletter-service/internal/services/webhook"
 )
 
 // Handler aggregates all individual handlers
 type Handler struct {
-	Topic        *TopicHandler
-	Subscriber   *SubscriberHandler
-	Content      *ContentHandler
-	Notification *NotificationHandler
-	Health       *HealthHandler
-	Unsubscribe  *UnsubscribeHandler
+	Topic         *TopicHandler
+	Subscriber    *SubscriberHandler
+	Content       *ContentHandler
+	Notification  *NotificationHandler
+	Health        *HealthHandler
+	Unsubscribe   *UnsubscribeHandler
+	Auth          *AuthHandler
+	Audit         *AuditHandler
+	APIKey        *APIKeyHandler
+	Docs          *DocsHandler
+	GraphQL       *GraphQLHandler
+	Webhook       *WebhookHandler
+	Stats         *StatsHandler
+	Engagement    *EngagementHandler
+	Retention     *RetentionHandler
+	Push          *PushHandler
+	Preference    *PreferenceHandler
+	Organization  *OrganizationHandler
+	Asset         *AssetHandler
+	Snippet       *SnippetHandler
+	Approval      *ApprovalHandler
+	EmailTemplate *EmailTemplateHandler
+	Referral      *ReferralHandler
+	Landing       *LandingHandler
+	Billing       *BillingHandler
+	Sponsor       *SponsorHandler
+	Poll          *PollHandler
 }
 
 // NewHandler creates a new handler with all service handlers
@@ -23,13 +74,168 @@ func NewHandler(
 	subscriberService subscriber.Service,
 	contentService content.Service,
 	notificationService notification.Service,
+	authService auth.Service,
+	auditService audit.Service,
+	healthService health.Service,
+	apiKeyService apikey.Service,
+	webhookService webhook.Service,
+	statsService stats.Service,
+	engagementService engagement.Service,
+	retentionService retention.Service,
+	pushService push.Service,
+	preferenceService preference.Service,
+	organizationService organization.Service,
+	lintService lint.Service,
+	assetService asset.Service,
+	snippetService snippet.Service,
+	approvalService approval.Service,
+	emailTemplateService emailtemplate.Service,
+	referralService referral.Service,
+	landingService landing.Service,
+	billingService billing.Service,
+	sponsorService sponsor.Service,
+	pollService poll.Service,
+	eventBus *events.Bus,
+	notificationScheduler *schedulers.NotificationScheduler,
 ) *Handler {
 	return &Handler{
-		Topic:        NewTopicHandler(topicService),
-		Subscriber:   NewSubscriberHandler(subscriberService),
-		Content:      NewContentHandler(contentService),
-		Notification: NewNotificationHandler(notificationService),
-		Health:       NewHealthHandler(),
-		Unsubscribe:  NewUnsubscribeHandler(subscriberService),
+		Topic:        NewTopicHandler(topicService, auditService),
+		Subscriber:   NewSubscriberHandler(subscriberService, auditService, eventBus),
+		Content:      NewContentHandler(contentService, lintService, approvalService, auditService, eventBus),
+		Notification: NewNotificationHandler(notificationService, auditService, notificationScheduler),
+		Health:       NewHealthHandler(healthService),
+		Unsubscribe:  NewUnsubscribeHandler(subscriberService, eventBus),
+		Auth:         NewAuthHandler(authService),
+		Audit:        NewAuditHandler(auditService),
+		APIKey:       NewAPIKeyHandler(apiKeyService, auditService),
+		Docs:         NewDocsHandler(),
+		GraphQL: NewGraphQLHandler(graphqlapi.Services{
+			Topic:        topicService,
+			Subscriber:   subscriberService,
+			Content:      contentService,
+			Notification: notificationService,
+		}),
+		Webhook:       NewWebhookHandler(webhookService, auditService),
+		Stats:         NewStatsHandler(statsService),
+		Engagement:    NewEngagementHandler(engagementService),
+		Retention:     NewRetentionHandler(retentionService),
+		Push:          NewPushHandler(pushService, subscriberService),
+		Preference:    NewPreferenceHandler(preferenceService, subscriberService, auditService),
+		Organization:  NewOrganizationHandler(organizationService, auditService),
+		Asset:         NewAssetHandler(assetService, auditService),
+		Snippet:       NewSnippetHandler(snippetService, auditService),
+		Approval:      NewApprovalHandler(approvalService, auditService),
+		EmailTemplate: NewEmailTemplateHandler(emailTemplateService, auditService),
+		Referral:      NewReferralHandler(referralService, eventBus),
+		Landing:       NewLandingHandler(landingService, eventBus),
+		Billing:       NewBillingHandler(billingService, auditService),
+		Sponsor:       NewSponsorHandler(sponsorService, auditService),
+		Poll:          NewPollHandler(pollService, auditService),
+	}
+}
+
+// deletedAt exposes a soft-delete timestamp in responses, nil for live rows
+func deletedAt(d gorm.DeletedAt) *time.Time {
+	if !d.Valid {
+		return nil
+	}
+	return &d.Time
+}
+
+// toBrandingModel converts requested branding for storage; nil leaves every field empty
+func toBrandingModel(b *dtos.Branding) daos.Branding {
+	if b == nil {
+		return daos.Branding{}
+	}
+	return daos.Branding{
+		FromName:            b.FromName,
+		FromAddress:         b.FromAddress,
+		ReplyTo:             b.ReplyTo,
+		LogoURL:             b.LogoURL,
+		PrimaryColor:        b.PrimaryColor,
+		FooterText:          b.FooterText,
+		DarkLogoURL:         b.DarkLogoURL,
+		BackgroundColor:     b.BackgroundColor,
+		TextColor:           b.TextColor,
+		FontFamily:          b.FontFamily,
+		DarkPrimaryColor:    b.DarkPrimaryColor,
+		DarkBackgroundColor: b.DarkBackgroundColor,
+		DarkTextColor:       b.DarkTextColor,
+	}
+}
+
+// brandingUpdates sets every branding column, so fields left out of a request are cleared
+func brandingUpdates(updates map[string]interface{}, b *dtos.Branding) {
+	branding := toBrandingModel(b)
+	updates["branding_from_name"] = branding.FromName
+	updates["branding_from_address"] = branding.FromAddress
+	updates["branding_reply_to"] = branding.ReplyTo
+	updates["branding_footer_text"] = branding.FooterText
+	themeUpdates(updates, toThemeResponse(branding))
+}
+
+func toBrandingResponse(b daos.Branding) dtos.Branding {
+	return dtos.Branding{
+		FromName:            b.FromName,
+		FromAddress:         b.FromAddress,
+		ReplyTo:             b.ReplyTo,
+		LogoURL:             b.LogoURL,
+		PrimaryColor:        b.PrimaryColor,
+		FooterText:          b.FooterText,
+		DarkLogoURL:         b.DarkLogoURL,
+		BackgroundColor:     b.BackgroundColor,
+		TextColor:           b.TextColor,
+		FontFamily:          b.FontFamily,
+		DarkPrimaryColor:    b.DarkPrimaryColor,
+		DarkBackgroundColor: b.DarkBackgroundColor,
+		DarkTextColor:       b.DarkTextColor,
 	}
 }
+
+// themeUpdates sets every theme column of the branding, leaving the sender identity alone
+func themeUpdates(updates map[string]interface{}, t dtos.Theme) {
+	updates["branding_logo_url"] = t.LogoURL
+	updates["branding_dark_logo_url"] = t.DarkLogoURL
+	updates["branding_primary_color"] = t.PrimaryColor
+	updates["branding_background_color"] = t.BackgroundColor
+	updates["branding_text_color"] = t.TextColor
+	updates["branding_font_family"] = t.FontFamily
+	updates["branding_dark_primary_color"] = t.DarkPrimaryColor
+	updates["branding_dark_background_color"] = t.DarkBackgroundColor
+	updates["branding_dark_text_color"] = t.DarkTextColor
+}
+
+// toThemeResponse is the theme part of a branding
+func toThemeResponse(b daos.Branding) dtos.Theme {
+	return dtos.Theme{
+		LogoURL:             b.LogoURL,
+		DarkLogoURL:         b.DarkLogoURL,
+		PrimaryColor:        b.PrimaryColor,
+		BackgroundColor:     b.BackgroundColor,
+		TextColor:           b.TextColor,
+		FontFamily:          b.FontFamily,
+		DarkPrimaryColor:    b.DarkPrimaryColor,
+		DarkBackgroundColor: b.DarkBackgroundColor,
+		DarkTextColor:       b.DarkTextColor,
+	}
+}
+
+// toUTMModel converts requested link tags for storage; nil leaves every field empty
+func toUTMModel(u *dtos.UTM) daos.UTM {
+	if u == nil {
+		return daos.UTM{}
+	}
+	return daos.UTM{Source: u.Source, Medium: u.Medium, Campaign: u.Campaign}
+}
+
+// utmUpdates sets every link tag column, clearing the fields left out of a request
+func utmUpdates(updates map[string]interface{}, u *dtos.UTM) {
+	utm := toUTMModel(u)
+	updates["utm_source"] = utm.Source
+	updates["utm_medium"] = utm.Medium
+	updates["utm_campaign"] = utm.Campaign
+}
+
+func toUTMResponse(u daos.UTM) dtos.UTM {
+	return dtos.UTM{Source: u.Source, Medium: u.Medium, Campaign: u.Campaign}
+}
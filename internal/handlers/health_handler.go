@@ -6,12 +6,17 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"newsletter-service/internal/constants"
+	"newsletter-service/internal/services/health"
 )
 
-type HealthHandler struct{}
+type HealthHandler struct {
+	healthService health.Service
+}
 
-func NewHealthHandler() *HealthHandler {
-	return &HealthHandler{}
+func NewHealthHandler(healthService health.Service) *HealthHandler {
+	return &HealthHandler{
+		healthService: healthService,
+	}
 }
 
 // Health endpoint for main service health check
@@ -22,6 +27,33 @@ func (h *HealthHandler) Health(c *gin.Context) {
 	})
 }
 
+// Live endpoint for Kubernetes liveness probes
+func (h *HealthHandler) Live(c *gin.Context) {
+	report := h.healthService.Liveness(c.Request.Context())
+	c.JSON(http.StatusOK, gin.H{
+		"status":  report.Status,
+		"service": constants.ServiceNameMain,
+	})
+}
+
+// Ready endpoint for Kubernetes readiness probes, reporting each dependency
+func (h *HealthHandler) Ready(c *gin.Context) {
+	report := h.healthService.Readiness(c.Request.Context())
+
+	status := http.StatusOK
+	readiness := constants.HealthStatusReady
+	if !report.Healthy() {
+		status = http.StatusServiceUnavailable
+		readiness = constants.HealthStatusNotReady
+	}
+
+	c.JSON(status, gin.H{
+		"status":  readiness,
+		"service": constants.ServiceNameMain,
+		"checks":  report.Checks,
+	})
+}
+
 // SchedulerHealth endpoint for scheduler service health check
 func (h *HealthHandler) SchedulerHealth(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
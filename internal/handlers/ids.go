@@ -0,0 +1,17 @@
+package handlers
+
+// orderByIDs returns items in the order their IDs were asked for; a query by IDs returns them in whatever
+// order the database picks
+func orderByIDs[T any](items []T, ids []uint, id func(T) uint) []T {
+	byID := make(map[uint]T, len(items))
+	for _, item := range items {
+		byID[id(item)] = item
+	}
+	var ordered []T
+	for _, want := range ids {
+		if item, ok := byID[want]; ok {
+			ordered = append(ordered, item)
+		}
+	}
+	return ordered
+}
@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"newsletter-service/internal/constants"
+	"newsletter-service/internal/daos"
+	"newsletter-service/internal/dtos"
+	"newsletter-service/internal/events"
+	"newsletter-service/internal/services/emailcheck"
+	"newsletter-service/internal/services/landing"
+	"newsletter-service/internal/services/subscriber"
+	"newsletter-service/internal/tenant"
+)
+
+// Limits of the attribution carried through a landing page's hidden fields; longer values are dropped
+const (
+	maxLandingReferrerLength = 500
+	maxLandingUTMLength      = 100
+)
+
+type LandingHandler struct {
+	landingService landing.Service
+	eventBus       *events.Bus
+}
+
+func NewLandingHandler(landingService landing.Service, eventBus *events.Bus) *LandingHandler {
+	return &LandingHandler{
+		landingService: landingService,
+		eventBus:       eventBus,
+	}
+}
+
+// GetLandingPage renders the public signup page of the topic in the path. The page the reader came from and
+// the UTM tags of their link go into the form, so the signup is credited to them rather than to the page itself.
+func (h *LandingHandler) GetLandingPage(c *gin.Context) {
+	page, ok := h.page(c)
+	if !ok {
+		return
+	}
+	h.render(c, http.StatusOK, page, landing.Form{
+		Referrer: landingReferrer(c.GetHeader("Referer")),
+		UTM:      landingUTM(c.Query("utm_source"), c.Query("utm_medium"), c.Query("utm_campaign")),
+	})
+}
+
+// SubscribeFromLandingPage handles the landing page's form. The reader is thanked whether or not the address
+// was new, and posts that fill in the honeypot field are thanked without subscribing anyone.
+func (h *LandingHandler) SubscribeFromLandingPage(c *gin.Context) {
+	var req dtos.LandingSignupForm
+	bindErr := c.ShouldBind(&req)
+	form := landing.Form{
+		Email:    strings.TrimSpace(req.Email),
+		Name:     strings.TrimSpace(req.Name),
+		Referrer: landingReferrer(req.Referrer),
+		UTM:      landingUTM(req.UTMSource, req.UTMMedium, req.UTMCampaign),
+	}
+
+	if req.Website != "" || bindErr != nil {
+		page, ok := h.page(c)
+		if !ok {
+			return
+		}
+		if req.Website != "" {
+			form.Subscribed = true
+			h.render(c, http.StatusOK, page, form)
+			return
+		}
+		form.Error = "Enter a valid email address to subscribe."
+		h.render(c, http.StatusBadRequest, page, form)
+		return
+	}
+
+	sub := &subscriber.Subscriber{
+		Email:    form.Email,
+		Name:     form.Name,
+		IsActive: true,
+		Timezone: subscriberTimezone(c, ""),
+		Locale:   subscriberLocale(c, ""),
+		Channels: daos.EncodeChannels(nil),
+	}
+	recordSignup(c, sub, daos.SignupSourceLanding, dtos.CreateSubscriberRequest{
+		Form: c.Param("slug"),
+		UTM:  &dtos.UTM{Source: form.UTM.Source, Medium: form.UTM.Medium, Campaign: form.UTM.Campaign},
+	})
+	// The post's own Referer is the landing page; the form carries the page before it
+	sub.SignupReferrer = form.Referrer
+
+	signup, err := h.landingService.Subscribe(c.Request.Context(), c.Param("slug"), sub)
+	if errors.Is(err, landing.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrLandingPageNotFound})
+		return
+	}
+	if errors.Is(err, emailcheck.ErrUndeliverable) {
+		page, ok := h.page(c)
+		if !ok {
+			return
+		}
+		form.Error = "This address can't receive email. Check it for typos and try again."
+		h.render(c, http.StatusBadRequest, page, form)
+		return
+	}
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	if created := signup.Subscriber; created != nil {
+		response := dtos.SubscriberResponse{
+			ID:               created.ID,
+			Email:            created.Email,
+			Name:             created.Name,
+			IsActive:         created.IsActive,
+			EmailStatus:      created.EmailStatus,
+			Timezone:         created.Timezone,
+			Locale:           created.Locale,
+			Phone:            created.Phone,
+			Channels:         daos.DecodeChannels(created.Channels),
+			ReferralCode:     created.ReferralCode,
+			ReferralCount:    created.ReferralCount,
+			ReferredByID:     created.ReferredByID,
+			Signup:           signupAttribution(created),
+			SubscribedTopics: signup.TopicNames,
+			CreatedAt:        created.CreatedAt,
+			UpdatedAt:        created.UpdatedAt,
+		}
+		ctx := tenant.WithOrganization(c.Request.Context(), created.OrganizationID)
+		h.eventBus.Emit(ctx, events.SubscriberCreated, response)
+	}
+
+	form.Subscribed = true
+	h.render(c, http.StatusOK, signup.Page, form)
+}
+
+// page loads the landing page of the topic in the path, responding with an error if there is none
+func (h *LandingHandler) page(c *gin.Context) (*landing.Page, bool) {
+	page, err := h.landingService.GetPage(c.Request.Context(), c.Param("slug"))
+	if errors.Is(err, landing.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrLandingPageNotFound})
+		return nil, false
+	}
+	if err != nil {
+		abortWithError(c, err)
+		return nil, false
+	}
+	return page, true
+}
+
+func (h *LandingHandler) render(c *gin.Context, status int, page *landing.Page, form landing.Form) {
+	c.Header("Content-Type", "text/html")
+	c.Status(status)
+	h.landingService.Render(c.Writer, page, form)
+}
+
+// landingReferrer keeps a referring page that is an absolute URL short enough to record
+func landingReferrer(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" || len(raw) > maxLandingReferrerLength {
+		return ""
+	}
+	return raw
+}
+
+// landingUTM keeps the tags that fit their columns
+func landingUTM(source, medium, campaign string) daos.UTM {
+	keep := func(value string) string {
+		if len(value) > maxLandingUTMLength {
+			return ""
+		}
+		return value
+	}
+	return daos.UTM{Source: keep(source), Medium: keep(medium), Campaign: keep(campaign)}
+}
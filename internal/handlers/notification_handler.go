@@ -1,63 +1,103 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 
 	"newsletter-service/internal/constants"
 	"newsletter-service/internal/dtos"
+	"newsletter-service/internal/logger"
+	"newsletter-service/internal/router/middleware"
+	"newsletter-service/internal/schedulers"
+	"newsletter-service/internal/services/audit"
 	"newsletter-service/internal/services/notification"
 )
 
 type NotificationHandler struct {
 	notificationService notification.Service
+	auditService        audit.Service
+	scheduler           *schedulers.NotificationScheduler // Queues content for the workers, checking the claim they take
 }
 
-func NewNotificationHandler(notificationService notification.Service) *NotificationHandler {
+func NewNotificationHandler(notificationService notification.Service, auditService audit.Service, scheduler *schedulers.NotificationScheduler) *NotificationHandler {
 	return &NotificationHandler{
 		notificationService: notificationService,
+		auditService:        auditService,
+		scheduler:           scheduler,
 	}
 }
 
-// GetEmailLogs retrieves all email logs
+// GetEmailLogs retrieves email logs, optionally filtered and sorted
 func (h *NotificationHandler) GetEmailLogs(c *gin.Context) {
-	var pagination dtos.PaginationRequest
-	if err := c.ShouldBindQuery(&pagination); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidPaginationParams})
+	var query dtos.EmailLogQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidEmailLogFilter, "details": err.Error()})
 		return
 	}
 
-	// Check if pagination parameters were provided
-	if pagination.Page > 0 || pagination.PageSize > 0 {
-		// Use paginated response
-		page, pageSize := pagination.GetDefaults()
-		offset := pagination.CalculateOffset()
-
-		logs, total, err := h.notificationService.GetEmailLogsWithPagination(c.Request.Context(), offset, pageSize)
+	// Use non-paginated response for backward compatibility when no parameters were provided
+	if query == (dtos.EmailLogQuery{}) {
+		logs, err := h.notificationService.GetEmailLogs(c.Request.Context())
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			abortWithError(c, err)
 			return
 		}
 
-		paginationResponse := dtos.CreatePaginationResponse(page, pageSize, total)
-		paginatedResponse := dtos.PaginatedResponse[*notification.EmailLog]{
-			Data:       logs,
-			Pagination: paginationResponse,
-		}
+		c.JSON(http.StatusOK, logs)
+		return
+	}
 
-		c.JSON(http.StatusOK, paginatedResponse)
-	} else {
-		// Use non-paginated response for backward compatibility
-		logs, err := h.notificationService.GetEmailLogs(c.Request.Context())
+	filter := notification.EmailLogFilter{
+		Status:       query.Status,
+		ContentID:    query.ContentID,
+		SubscriberID: query.SubscriberID,
+		EmailAddress: query.Email,
+		Provider:     query.Provider,
+		MessageID:    query.MessageID,
+		Channel:      query.Channel,
+		SortBy:       query.Sort,
+		Ascending:    query.Order == "asc",
+		Archived:     query.Archived,
+	}
+
+	if query.From != "" {
+		from, err := time.Parse(time.RFC3339, query.From)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidEmailLogFilter, "details": "from must be RFC3339"})
 			return
 		}
+		filter.From = &from
+	}
+	if query.To != "" {
+		to, err := time.Parse(time.RFC3339, query.To)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidEmailLogFilter, "details": "to must be RFC3339"})
+			return
+		}
+		filter.To = &to
+	}
 
-		c.JSON(http.StatusOK, logs)
+	page, pageSize := query.GetDefaults()
+	offset := query.CalculateOffset()
+
+	logs, total, err := h.notificationService.GetFilteredEmailLogsWithPagination(c.Request.Context(), filter, offset, pageSize)
+	if err != nil {
+		abortWithError(c, err)
+		return
 	}
+
+	paginationResponse := dtos.CreatePaginationResponse(page, pageSize, total)
+	paginatedResponse := dtos.PaginatedResponse[*notification.EmailLog]{
+		Data:       logs,
+		Pagination: paginationResponse,
+	}
+
+	c.JSON(http.StatusOK, paginatedResponse)
 }
 
 // GetEmailLogByID retrieves an email log by ID
@@ -77,7 +117,221 @@ func (h *NotificationHandler) GetEmailLogByID(c *gin.Context) {
 	c.JSON(http.StatusOK, log)
 }
 
-// SendNotifications sends notifications for specific content (Scheduler endpoint)
+// GetContentEmailLogSummary aggregates a content's email logs by status, failure class and recipient domain
+func (h *NotificationHandler) GetContentEmailLogSummary(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidContentID})
+		return
+	}
+
+	summary, err := h.notificationService.GetContentEmailLogSummary(c.Request.Context(), uint(id), notification.DefaultTopFailingDomains)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrContentNotFound})
+			return
+		}
+		abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
+
+// GetContentDelivery counts where the recipients of a content stand: sent, failed for good, or outstanding
+func (h *NotificationHandler) GetContentDelivery(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidContentID})
+		return
+	}
+
+	progress, err := h.notificationService.GetDeliveryProgress(c.Request.Context(), uint(id))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrContentNotFound})
+			return
+		}
+		abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, progress)
+}
+
+// CompleteContentDelivery force-completes a delivery that is still sending, cancelling its outstanding emails
+func (h *NotificationHandler) CompleteContentDelivery(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidContentID})
+		return
+	}
+
+	progress, err := h.notificationService.ForceCompleteDelivery(c.Request.Context(), uint(id))
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrContentNotFound})
+		case errors.Is(err, notification.ErrDeliveryNotInProgress):
+			c.JSON(http.StatusConflict, gin.H{"error": constants.ErrDeliveryNotInProgress})
+		default:
+			abortWithError(c, err)
+		}
+		return
+	}
+
+	recordAudit(c, h.auditService, audit.ActionComplete, audit.EntityContent, uint(id), nil, progress)
+
+	c.JSON(http.StatusOK, progress)
+}
+
+// GetContentSendReport summarises a content's delivery the way the send report admins are emailed does
+func (h *NotificationHandler) GetContentSendReport(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidContentID})
+		return
+	}
+
+	report, err := h.notificationService.GetSendReport(c.Request.Context(), uint(id))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrContentNotFound})
+			return
+		}
+		abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// GetProviderStatus reports each enabled email provider's health, load and warm-up progress
+func (h *NotificationHandler) GetProviderStatus(c *gin.Context) {
+	statuses, err := h.notificationService.GetProviderStatuses(c.Request.Context())
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"providers": statuses})
+}
+
+// GetProviderStats returns a provider's persisted hourly send and failure counts over a range
+func (h *NotificationHandler) GetProviderStats(c *gin.Context) {
+	var query dtos.ProviderStatsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidStatsQuery, "details": err.Error()})
+		return
+	}
+
+	window, err := notification.ParseProviderStatsRange(query.Range)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidStatsQuery, "details": err.Error()})
+		return
+	}
+
+	report, err := h.notificationService.GetProviderStats(c.Request.Context(), c.Param("name"), window)
+	if err != nil {
+		if errors.Is(err, notification.ErrProviderNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrProviderNotFound})
+			return
+		}
+		abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// PauseProvider stops routing new emails to a provider, e.g. during an incident at the provider, while the
+// other providers take its share. The worker picks the pause up within seconds and keeps it across restarts.
+func (h *NotificationHandler) PauseProvider(c *gin.Context) {
+	var req dtos.PauseProviderRequest
+	if c.Request.ContentLength != 0 && !middleware.ValidateJSON(c, &req) {
+		return
+	}
+
+	pause, err := h.notificationService.PauseProvider(c.Request.Context(), c.Param("name"), req.Reason, c.GetString(gin.AuthUserKey))
+	if err != nil {
+		if errors.Is(err, notification.ErrProviderNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrProviderNotFound})
+			return
+		}
+		abortWithError(c, err)
+		return
+	}
+
+	recordAudit(c, h.auditService, audit.ActionPause, audit.EntityProvider, pause.ID, nil, pause)
+
+	c.JSON(http.StatusOK, toProviderPauseResponse(pause))
+}
+
+// ResumeProvider routes new emails to a paused provider again
+func (h *NotificationHandler) ResumeProvider(c *gin.Context) {
+	pause, err := h.notificationService.ResumeProvider(c.Request.Context(), c.Param("name"))
+	if err != nil {
+		if errors.Is(err, notification.ErrProviderNotPaused) {
+			c.JSON(http.StatusConflict, gin.H{"error": constants.ErrProviderNotPaused})
+			return
+		}
+		abortWithError(c, err)
+		return
+	}
+
+	recordAudit(c, h.auditService, audit.ActionResume, audit.EntityProvider, pause.ID, pause, nil)
+
+	c.JSON(http.StatusOK, gin.H{"message": constants.MsgProviderResumed})
+}
+
+// GetProviderPauses lists the paused providers
+func (h *NotificationHandler) GetProviderPauses(c *gin.Context) {
+	pauses, err := h.notificationService.GetProviderPauses(c.Request.Context())
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	response := make([]dtos.ProviderPauseResponse, 0, len(pauses))
+	for _, pause := range pauses {
+		response = append(response, toProviderPauseResponse(pause))
+	}
+	c.JSON(http.StatusOK, gin.H{"pauses": response})
+}
+
+func toProviderPauseResponse(pause *notification.ProviderPause) dtos.ProviderPauseResponse {
+	return dtos.ProviderPauseResponse{
+		Provider:  pause.Provider,
+		Reason:    pause.Reason,
+		PausedBy:  pause.PausedBy,
+		PausedAt:  pause.CreatedAt,
+		UpdatedAt: pause.UpdatedAt,
+	}
+}
+
+// ResendFailedNotifications requeues a content's failed email deliveries; the worker resends them
+func (h *NotificationHandler) ResendFailedNotifications(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidContentID})
+		return
+	}
+
+	result, err := h.notificationService.RequeueFailedEmailsByContentID(c.Request.Context(), uint(id))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrContentNotFound})
+			return
+		}
+		abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// SendNotifications queues specific content for the worker to send on its next run (Scheduler endpoint). It
+// answers 409 while a worker is sending the content or once it was sent.
 func (h *NotificationHandler) SendNotifications(c *gin.Context) {
 	var req struct {
 		ContentID uint `json:"content_id" binding:"required"`
@@ -88,20 +342,53 @@ func (h *NotificationHandler) SendNotifications(c *gin.Context) {
 		return
 	}
 
-	if err := h.notificationService.SendNotificationsByContentID(c.Request.Context(), req.ContentID); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.scheduler.QueueContent(c.Request.Context(), req.ContentID); err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrContentNotFound})
+		case errors.Is(err, schedulers.ErrContentLocked):
+			c.JSON(http.StatusConflict, gin.H{"error": constants.ErrContentSendInProgress})
+		case errors.Is(err, schedulers.ErrContentSent):
+			c.JSON(http.StatusConflict, gin.H{"error": constants.ErrContentAlreadySent})
+		case errors.Is(err, schedulers.ErrContentNotPublished):
+			c.JSON(http.StatusConflict, gin.H{"error": constants.ErrContentNotPublished})
+		default:
+			abortWithError(c, err)
+		}
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": constants.MsgNotificationsSentSuccessfully})
+	c.JSON(http.StatusAccepted, gin.H{"message": constants.MsgNotificationsQueued})
 }
 
 // RetryFailedNotifications retries failed email deliveries (Scheduler endpoint)
 func (h *NotificationHandler) RetryFailedNotifications(c *gin.Context) {
 	if err := h.notificationService.RetryFailedEmails(c.Request.Context()); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		abortWithError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": constants.MsgFailedNotificationsRetryInitiated})
 }
+
+// transparentGIF is a 1x1 transparent GIF served by the open tracking pixel
+var transparentGIF = []byte{
+	0x47, 0x49, 0x46, 0x38, 0x39, 0x61, 0x01, 0x00, 0x01, 0x00, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0xff, 0xff, 0xff, 0x21, 0xf9, 0x04, 0x01, 0x00, 0x00, 0x00, 0x00, 0x2c, 0x00, 0x00, 0x00, 0x00,
+	0x01, 0x00, 0x01, 0x00, 0x00, 0x02, 0x02, 0x44, 0x01, 0x00, 0x3b,
+}
+
+// TrackOpen records that a subscriber opened an email. The pixel is always served so
+// mail clients never show a broken image, even when the parameters are invalid.
+func (h *NotificationHandler) TrackOpen(c *gin.Context) {
+	subscriberID, subErr := strconv.ParseUint(c.Query("subscriber"), 10, 32)
+	contentID, contentErr := strconv.ParseUint(c.Query("content"), 10, 32)
+	if subErr == nil && contentErr == nil {
+		if err := h.notificationService.RecordOpen(c.Request.Context(), uint(subscriberID), uint(contentID)); err != nil {
+			logger.Warn(c.Request.Context(), "Failed to record open for subscriber %d content %d: %v", subscriberID, contentID, err)
+		}
+	}
+
+	c.Header("Cache-Control", "no-store, no-cache, must-revalidate, max-age=0")
+	c.Data(http.StatusOK, "image/gif", transparentGIF)
+}
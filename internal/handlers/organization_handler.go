@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"newsletter-service/internal/constants"
+	"newsletter-service/internal/dtos"
+	"newsletter-service/internal/router/middleware"
+	"newsletter-service/internal/services/audit"
+	"newsletter-service/internal/services/organization"
+)
+
+type OrganizationHandler struct {
+	organizationService organization.Service
+	auditService        audit.Service
+}
+
+func NewOrganizationHandler(organizationService organization.Service, auditService audit.Service) *OrganizationHandler {
+	return &OrganizationHandler{
+		organizationService: organizationService,
+		auditService:        auditService,
+	}
+}
+
+// GetOrganizations retrieves organizations with pagination
+func (h *OrganizationHandler) GetOrganizations(c *gin.Context) {
+	var pagination dtos.PaginationRequest
+	if err := c.ShouldBindQuery(&pagination); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidPaginationParams})
+		return
+	}
+
+	page, pageSize := pagination.GetDefaults()
+	offset := pagination.CalculateOffset()
+
+	organizations, total, err := h.organizationService.GetOrganizationsWithPagination(c.Request.Context(), offset, pageSize)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	response := make([]dtos.OrganizationResponse, 0, len(organizations))
+	for _, org := range organizations {
+		response = append(response, toOrganizationResponse(org))
+	}
+
+	paginationResponse := dtos.CreatePaginationResponse(page, pageSize, total)
+	c.JSON(http.StatusOK, dtos.PaginatedResponse[dtos.OrganizationResponse]{
+		Data:       response,
+		Pagination: paginationResponse,
+	})
+}
+
+// CreateOrganization adds an organization; its data is reached with the X-Organization-ID header or its API keys
+func (h *OrganizationHandler) CreateOrganization(c *gin.Context) {
+	var req dtos.CreateOrganizationRequest
+	if !middleware.ValidateJSON(c, &req) {
+		return
+	}
+
+	org := &organization.Organization{Name: req.Name, Branding: toBrandingModel(req.Branding)}
+	if err := h.organizationService.CreateOrganization(c.Request.Context(), org); err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	recordAudit(c, h.auditService, audit.ActionCreate, audit.EntityOrganization, org.ID, nil, org)
+
+	c.JSON(http.StatusCreated, toOrganizationResponse(org))
+}
+
+// GetOrganizationByID retrieves an organization by ID
+func (h *OrganizationHandler) GetOrganizationByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidOrganizationID})
+		return
+	}
+
+	org, err := h.organizationService.GetOrganizationByID(c.Request.Context(), uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrOrganizationNotFound})
+		return
+	}
+
+	c.JSON(http.StatusOK, toOrganizationResponse(org))
+}
+
+// UpdateOrganization renames an organization and/or replaces its branding
+func (h *OrganizationHandler) UpdateOrganization(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidOrganizationID})
+		return
+	}
+
+	var req dtos.UpdateOrganizationRequest
+	if !middleware.ValidateJSON(c, &req) {
+		return
+	}
+
+	before, err := h.organizationService.GetOrganizationByID(c.Request.Context(), uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrOrganizationNotFound})
+		return
+	}
+
+	updates := make(map[string]interface{})
+	if req.Name != "" {
+		updates["name"] = req.Name
+	}
+	if req.Branding != nil {
+		brandingUpdates(updates, req.Branding)
+	}
+	if len(updates) > 0 {
+		if err := h.organizationService.UpdateOrganization(c.Request.Context(), uint(id), updates); err != nil {
+			abortWithError(c, err)
+			return
+		}
+	}
+
+	after, err := h.organizationService.GetOrganizationByID(c.Request.Context(), uint(id))
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	recordAudit(c, h.auditService, audit.ActionUpdate, audit.EntityOrganization, uint(id), before, after)
+
+	c.JSON(http.StatusOK, toOrganizationResponse(after))
+}
+
+func toOrganizationResponse(org *organization.Organization) dtos.OrganizationResponse {
+	return dtos.OrganizationResponse{
+		ID:        org.ID,
+		Name:      org.Name,
+		Branding:  toBrandingResponse(org.Branding),
+		CreatedAt: org.CreatedAt,
+		UpdatedAt: org.UpdatedAt,
+	}
+}
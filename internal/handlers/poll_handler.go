@@ -0,0 +1,313 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"newsletter-service/internal/constants"
+	"newsletter-service/internal/dtos"
+	"newsletter-service/internal/router/middleware"
+	"newsletter-service/internal/services/audit"
+	"newsletter-service/internal/services/poll"
+)
+
+type PollHandler struct {
+	pollService  poll.Service
+	auditService audit.Service
+}
+
+func NewPollHandler(pollService poll.Service, auditService audit.Service) *PollHandler {
+	return &PollHandler{
+		pollService:  pollService,
+		auditService: auditService,
+	}
+}
+
+// CreatePoll adds a poll to the content. The content shows it where its body includes the poll's include.
+func (h *PollHandler) CreatePoll(c *gin.Context) {
+	contentID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidContentID})
+		return
+	}
+
+	var req dtos.CreatePollRequest
+	if !middleware.ValidateJSON(c, &req) {
+		return
+	}
+
+	p := &poll.Poll{
+		ContentID: uint(contentID),
+		Question:  req.Question,
+		ClosesAt:  req.ClosesAt,
+	}
+	for _, label := range req.Options {
+		p.Options = append(p.Options, poll.Option{Label: label})
+	}
+
+	if err := h.pollService.CreatePoll(c.Request.Context(), p); err != nil {
+		if errors.Is(err, poll.ErrContentNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrContentNotFound})
+			return
+		}
+		abortWithError(c, err)
+		return
+	}
+
+	recordAudit(c, h.auditService, audit.ActionCreate, audit.EntityPoll, p.ID, nil, p)
+
+	c.JSON(http.StatusCreated, toPollResponse(p))
+}
+
+// GetContentPolls returns the results of every poll in the content
+func (h *PollHandler) GetContentPolls(c *gin.Context) {
+	contentID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidContentID})
+		return
+	}
+
+	results, err := h.pollService.GetContentResults(c.Request.Context(), uint(contentID))
+	if errors.Is(err, poll.ErrContentNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrContentNotFound})
+		return
+	}
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	response := make([]dtos.PollResultsResponse, 0, len(results))
+	for _, r := range results {
+		response = append(response, toPollResultsResponse(r))
+	}
+	c.JSON(http.StatusOK, gin.H{"polls": response})
+}
+
+// GetPollByID retrieves a poll by ID
+func (h *PollHandler) GetPollByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidPollID})
+		return
+	}
+
+	p, err := h.pollService.GetPollByID(c.Request.Context(), uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrPollNotFound})
+		return
+	}
+
+	c.JSON(http.StatusOK, toPollResponse(p))
+}
+
+// UpdatePoll changes a poll's question or closing time. Its options are fixed once created, as answers already
+// given point at them.
+func (h *PollHandler) UpdatePoll(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidPollID})
+		return
+	}
+
+	var req dtos.UpdatePollRequest
+	if !middleware.ValidateJSON(c, &req) {
+		return
+	}
+
+	before, err := h.pollService.GetPollByID(c.Request.Context(), uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrPollNotFound})
+		return
+	}
+
+	updates := make(map[string]interface{})
+	if req.Question != "" {
+		updates["question"] = req.Question
+	}
+	if req.ClosesAt != nil {
+		updates["closes_at"] = *req.ClosesAt
+	}
+
+	if err := h.pollService.UpdatePoll(c.Request.Context(), uint(id), updates); err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	after, err := h.pollService.GetPollByID(c.Request.Context(), uint(id))
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	recordAudit(c, h.auditService, audit.ActionUpdate, audit.EntityPoll, uint(id), before, after)
+
+	c.JSON(http.StatusOK, toPollResponse(after))
+}
+
+// DeletePoll deletes a poll. Content sent from then on leaves it out, and answer links already sent stop working.
+func (h *PollHandler) DeletePoll(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidPollID})
+		return
+	}
+
+	before, err := h.pollService.GetPollByID(c.Request.Context(), uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrPollNotFound})
+		return
+	}
+
+	if err := h.pollService.DeletePoll(c.Request.Context(), uint(id)); err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	recordAudit(c, h.auditService, audit.ActionDelete, audit.EntityPoll, uint(id), before, nil)
+
+	c.JSON(http.StatusOK, gin.H{"message": constants.MsgPollDeletedSuccessfully})
+}
+
+// GetResults returns how many subscribers chose each option of the poll
+func (h *PollHandler) GetResults(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidPollID})
+		return
+	}
+
+	results, err := h.pollService.GetResults(c.Request.Context(), uint(id))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrPollNotFound})
+		return
+	}
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toPollResultsResponse(results))
+}
+
+var pollRespondTemplate = template.Must(template.New("poll-respond").Parse(`<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>{{.Title}} - Newsletter Service</title>
+    <style>
+        body {
+            font-family: Arial, sans-serif;
+            line-height: 1.6;
+            color: #333;
+            max-width: 600px;
+            margin: 50px auto;
+            padding: 20px;
+            background-color: #f4f4f4;
+        }
+        .container {
+            background-color: white;
+            padding: 40px;
+            border-radius: 10px;
+            box-shadow: 0 2px 5px rgba(0,0,0,0.1);
+            text-align: center;
+        }
+        h1 {
+            color: {{if .OK}}#28a745{{else}}#dc3545{{end}};
+            margin-bottom: 20px;
+        }
+        .answer {
+            font-weight: bold;
+        }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <h1>{{.Title}}</h1>
+        <p>{{.Message}}</p>
+        {{if .Answer}}<p>{{.Question}}<br><span class="answer">{{.Answer}}</span></p>{{end}}
+    </div>
+</body>
+</html>`))
+
+// Respond handles an answer link from an email: the token says which subscriber chose which option, so one
+// click records the answer. Clicking another option of the same poll changes it.
+func (h *PollHandler) Respond(c *gin.Context) {
+	answer, err := h.pollService.Respond(c.Request.Context(), c.Query("token"))
+	switch {
+	case errors.Is(err, poll.ErrInvalidToken):
+		renderPollRespond(c, http.StatusBadRequest, "Invalid Link", "This answer link is incomplete or invalid. Use the link in the email to answer.", nil)
+	case errors.Is(err, poll.ErrPollNotFound), errors.Is(err, poll.ErrSubscriberNotFound):
+		renderPollRespond(c, http.StatusNotFound, "Poll Not Found", "This poll is no longer available.", nil)
+	case errors.Is(err, poll.ErrPollClosed):
+		renderPollRespond(c, http.StatusGone, "Poll Closed", "This poll has closed and no longer takes answers.", nil)
+	case err != nil:
+		renderPollRespond(c, http.StatusInternalServerError, "Something Went Wrong", "We couldn't record your answer. Please try again later.", nil)
+	default:
+		renderPollRespond(c, http.StatusOK, "Thanks for Answering", "Your answer has been recorded. Choose another option in the email to change it.", answer)
+	}
+}
+
+func renderPollRespond(c *gin.Context, status int, title, message string, answer *poll.Answer) {
+	data := gin.H{
+		"OK":      answer != nil,
+		"Title":   title,
+		"Message": message,
+	}
+	if answer != nil {
+		data["Question"] = answer.Poll.Question
+		data["Answer"] = answer.Option.Label
+	}
+	c.Header("Content-Type", "text/html")
+	c.Status(status)
+	pollRespondTemplate.Execute(c.Writer, data)
+}
+
+func toPollResponse(p *poll.Poll) dtos.PollResponse {
+	response := dtos.PollResponse{
+		ID:        p.ID,
+		ContentID: p.ContentID,
+		Question:  p.Question,
+		Include:   fmt.Sprintf("{{poll %d}}", p.ID),
+		ClosesAt:  p.ClosesAt,
+		Options:   make([]dtos.PollOptionResponse, 0, len(p.Options)),
+		CreatedAt: p.CreatedAt,
+		UpdatedAt: p.UpdatedAt,
+	}
+	for _, o := range p.Options {
+		response.Options = append(response.Options, toPollOptionResponse(o))
+	}
+	return response
+}
+
+func toPollOptionResponse(o poll.Option) dtos.PollOptionResponse {
+	return dtos.PollOptionResponse{
+		ID:       o.ID,
+		Label:    o.Label,
+		Position: o.Position,
+	}
+}
+
+func toPollResultsResponse(r *poll.Results) dtos.PollResultsResponse {
+	response := dtos.PollResultsResponse{
+		ID:        r.Poll.ID,
+		ContentID: r.Poll.ContentID,
+		Question:  r.Poll.Question,
+		ClosesAt:  r.Poll.ClosesAt,
+		Options:   make([]dtos.PollOptionResultResponse, 0, len(r.Options)),
+		Responses: r.Responses,
+	}
+	for _, o := range r.Options {
+		response.Options = append(response.Options, dtos.PollOptionResultResponse{
+			PollOptionResponse: toPollOptionResponse(o.Option),
+			Responses:          o.Responses,
+		})
+	}
+	return response
+}
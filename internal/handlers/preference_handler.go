@@ -0,0 +1,356 @@
+package handlers
+
+import (
+	"errors"
+	"html/template"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"newsletter-service/internal/constants"
+	"newsletter-service/internal/dtos"
+	"newsletter-service/internal/router/middleware"
+	"newsletter-service/internal/services/audit"
+	"newsletter-service/internal/services/preference"
+	"newsletter-service/internal/services/subscriber"
+)
+
+type PreferenceHandler struct {
+	preferenceService preference.Service
+	subscriberService subscriber.Service
+	auditService      audit.Service
+}
+
+func NewPreferenceHandler(preferenceService preference.Service, subscriberService subscriber.Service, auditService audit.Service) *PreferenceHandler {
+	return &PreferenceHandler{
+		preferenceService: preferenceService,
+		subscriberService: subscriberService,
+		auditService:      auditService,
+	}
+}
+
+// GetPreferences lists a subscriber's frequency for every subscribed topic and channel
+func (h *PreferenceHandler) GetPreferences(c *gin.Context) {
+	subscriberID, ok := h.subscriberID(c)
+	if !ok {
+		return
+	}
+
+	preferences, err := h.preferenceService.GetPreferences(c.Request.Context(), subscriberID)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toPreferencesResponse(subscriberID, preferences))
+}
+
+// UpdatePreferences sets a subscriber's frequencies and returns the resulting preferences
+func (h *PreferenceHandler) UpdatePreferences(c *gin.Context) {
+	subscriberID, ok := h.subscriberID(c)
+	if !ok {
+		return
+	}
+
+	var req dtos.UpdatePreferencesRequest
+	if !middleware.ValidateJSON(c, &req) {
+		return
+	}
+
+	updates := make([]preference.TopicPreference, 0, len(req.Preferences))
+	for _, item := range req.Preferences {
+		updates = append(updates, preference.TopicPreference{
+			TopicID:   item.TopicID,
+			Channel:   item.Channel,
+			Frequency: item.Frequency,
+		})
+	}
+
+	before, _ := h.preferenceService.GetPreferences(c.Request.Context(), subscriberID)
+	if !h.update(c, subscriberID, updates) {
+		return
+	}
+
+	after, err := h.preferenceService.GetPreferences(c.Request.Context(), subscriberID)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	recordAudit(c, h.auditService, audit.ActionUpdate, audit.EntitySubscriber, subscriberID, before, after)
+
+	c.JSON(http.StatusOK, toPreferencesResponse(subscriberID, after))
+}
+
+// update saves preferences, responding with an error if they are rejected
+func (h *PreferenceHandler) update(c *gin.Context, subscriberID uint, updates []preference.TopicPreference) bool {
+	err := h.preferenceService.UpdatePreferences(c.Request.Context(), subscriberID, updates)
+	switch {
+	case err == nil:
+		return true
+	case errors.Is(err, preference.ErrNotSubscribed):
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrNotSubscribedToTopic})
+	case errors.Is(err, preference.ErrDigestEmailOnly):
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrDigestEmailOnly})
+	default:
+		abortWithError(c, err)
+	}
+	return false
+}
+
+// subscriberID parses the subscriber in the path and checks it exists, responding with an error if not
+func (h *PreferenceHandler) subscriberID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidSubscriberID})
+		return 0, false
+	}
+	if _, err := h.subscriberService.GetSubscriberByID(c.Request.Context(), uint(id)); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrSubscriberNotFound})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func toPreferencesResponse(subscriberID uint, preferences []preference.TopicPreference) dtos.PreferencesResponse {
+	response := dtos.PreferencesResponse{
+		SubscriberID: subscriberID,
+		Preferences:  make([]dtos.PreferenceResponse, 0, len(preferences)),
+	}
+	for _, p := range preferences {
+		response.Preferences = append(response.Preferences, dtos.PreferenceResponse{
+			TopicID:   p.TopicID,
+			TopicName: p.TopicName,
+			Channel:   p.Channel,
+			Frequency: p.Frequency,
+		})
+	}
+	return response
+}
+
+// preferenceCenterTopic is one row of the preference center: a topic with its frequency per channel
+type preferenceCenterTopic struct {
+	ID    uint
+	Name  string
+	Email string
+	SMS   string
+	Push  string
+}
+
+var preferenceCenterTemplate = template.Must(template.New("preferences").Parse(`<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Notification Preferences - Newsletter Service</title>
+    <style>
+        body {
+            font-family: Arial, sans-serif;
+            line-height: 1.6;
+            color: #333;
+            max-width: 700px;
+            margin: 50px auto;
+            padding: 20px;
+            background-color: #f4f4f4;
+        }
+        .container {
+            background-color: white;
+            padding: 40px;
+            border-radius: 10px;
+            box-shadow: 0 2px 5px rgba(0,0,0,0.1);
+        }
+        h1 {
+            color: #007bff;
+            margin-bottom: 20px;
+            text-align: center;
+        }
+        .notice {
+            background-color: #d4edda;
+            color: #155724;
+            padding: 10px 15px;
+            border-radius: 5px;
+            margin-bottom: 20px;
+        }
+        table {
+            width: 100%;
+            border-collapse: collapse;
+            margin: 20px 0;
+        }
+        th, td {
+            padding: 8px;
+            text-align: left;
+            border-bottom: 1px solid #eee;
+        }
+        .btn {
+            display: inline-block;
+            padding: 10px 20px;
+            border: none;
+            border-radius: 5px;
+            cursor: pointer;
+            font-size: 16px;
+            background-color: #007bff;
+            color: white;
+        }
+        .btn:hover {
+            opacity: 0.8;
+        }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <h1>Notification Preferences</h1>
+        {{if .Saved}}<div class="notice">Your preferences have been saved.</div>{{end}}
+        <p>Choose how often you hear about each topic, <strong>{{.Email}}</strong>.</p>
+        {{if .Topics}}
+        <form method="POST" action="/preferences">
+            <input type="hidden" name="subscriber" value="{{.SubscriberID}}">
+            <table>
+                <tr><th>Topic</th><th>Email</th><th>SMS</th><th>Push</th></tr>
+                {{range .Topics}}
+                <tr>
+                    <td>{{.Name}}</td>
+                    <td><select name="topic_{{.ID}}_email">
+                        <option value="instant"{{if eq .Email "instant"}} selected{{end}}>Instantly</option>
+                        <option value="daily"{{if eq .Email "daily"}} selected{{end}}>Daily digest</option>
+                        <option value="weekly"{{if eq .Email "weekly"}} selected{{end}}>Weekly digest</option>
+                        <option value="off"{{if eq .Email "off"}} selected{{end}}>Off</option>
+                    </select></td>
+                    <td><select name="topic_{{.ID}}_sms">
+                        <option value="instant"{{if eq .SMS "instant"}} selected{{end}}>On</option>
+                        <option value="off"{{if eq .SMS "off"}} selected{{end}}>Off</option>
+                    </select></td>
+                    <td><select name="topic_{{.ID}}_push">
+                        <option value="instant"{{if eq .Push "instant"}} selected{{end}}>On</option>
+                        <option value="off"{{if eq .Push "off"}} selected{{end}}>Off</option>
+                    </select></td>
+                </tr>
+                {{end}}
+            </table>
+            <button type="submit" class="btn">Save preferences</button>
+        </form>
+        {{else}}
+        <p>You are not subscribed to any topics.</p>
+        {{end}}
+        <p style="margin-top: 30px; font-size: 12px; color: #666;">
+            To stop all newsletters, <a href="/unsubscribe?subscriber={{.SubscriberID}}">unsubscribe</a>.
+        </p>
+    </div>
+</body>
+</html>`))
+
+// PreferenceCenterGet renders the page where subscribers choose how often they receive each topic
+func (h *PreferenceHandler) PreferenceCenterGet(c *gin.Context) {
+	h.renderPreferenceCenter(c, c.Query("subscriber"), false)
+}
+
+// PreferenceCenterPost saves the preference center form. Fields are named topic_<id>_<channel>.
+func (h *PreferenceHandler) PreferenceCenterPost(c *gin.Context) {
+	subscriberIDStr := c.PostForm("subscriber")
+	subscriberID, err := strconv.ParseUint(subscriberIDStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidSubscriberID})
+		return
+	}
+
+	if err := c.Request.ParseForm(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidRequestBody})
+		return
+	}
+
+	var updates []preference.TopicPreference
+	for field, values := range c.Request.PostForm {
+		parts := strings.Split(field, "_")
+		if len(parts) != 3 || parts[0] != "topic" || len(values) == 0 {
+			continue
+		}
+		topicID, err := strconv.ParseUint(parts[1], 10, 32)
+		if err != nil {
+			continue
+		}
+		updates = append(updates, preference.TopicPreference{
+			TopicID:   uint(topicID),
+			Channel:   parts[2],
+			Frequency: values[0],
+		})
+	}
+	if !validFormPreferences(updates) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidRequestBody})
+		return
+	}
+
+	if !h.update(c, uint(subscriberID), updates) {
+		return
+	}
+	h.renderPreferenceCenter(c, subscriberIDStr, true)
+}
+
+// validFormPreferences checks the channels and frequencies posted by the form, which the JSON API validates
+// through its request binding
+func validFormPreferences(updates []preference.TopicPreference) bool {
+	for _, p := range updates {
+		switch p.Channel {
+		case constants.NotificationTypeEmail, constants.NotificationTypeSMS, constants.NotificationTypePush:
+		default:
+			return false
+		}
+		switch p.Frequency {
+		case preference.FrequencyInstant, preference.FrequencyDaily, preference.FrequencyWeekly, preference.FrequencyOff:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func (h *PreferenceHandler) renderPreferenceCenter(c *gin.Context, subscriberIDStr string, saved bool) {
+	if subscriberIDStr == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Subscriber ID is required"})
+		return
+	}
+	subscriberID, err := strconv.ParseUint(subscriberIDStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidSubscriberID})
+		return
+	}
+
+	sub, err := h.subscriberService.GetSubscriberByID(c.Request.Context(), uint(subscriberID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrSubscriberNotFound})
+		return
+	}
+
+	preferences, err := h.preferenceService.GetPreferences(c.Request.Context(), sub.ID)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	var topics []*preferenceCenterTopic
+	byID := make(map[uint]*preferenceCenterTopic)
+	for _, p := range preferences {
+		topic, ok := byID[p.TopicID]
+		if !ok {
+			topic = &preferenceCenterTopic{ID: p.TopicID, Name: p.TopicName}
+			byID[p.TopicID] = topic
+			topics = append(topics, topic)
+		}
+		switch p.Channel {
+		case constants.NotificationTypeEmail:
+			topic.Email = p.Frequency
+		case constants.NotificationTypeSMS:
+			topic.SMS = p.Frequency
+		case constants.NotificationTypePush:
+			topic.Push = p.Frequency
+		}
+	}
+
+	c.Header("Content-Type", "text/html")
+	c.Status(http.StatusOK)
+	preferenceCenterTemplate.Execute(c.Writer, gin.H{
+		"SubscriberID": sub.ID,
+		"Email":        sub.Email,
+		"Topics":       topics,
+		"Saved":        saved,
+	})
+}
@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"newsletter-service/internal/constants"
+	"newsletter-service/internal/dtos"
+	"newsletter-service/internal/router/middleware"
+	"newsletter-service/internal/services/push"
+	"newsletter-service/internal/services/subscriber"
+)
+
+type PushHandler struct {
+	pushService       push.Service
+	subscriberService subscriber.Service
+}
+
+func NewPushHandler(pushService push.Service, subscriberService subscriber.Service) *PushHandler {
+	return &PushHandler{
+		pushService:       pushService,
+		subscriberService: subscriberService,
+	}
+}
+
+// RegisterDevice registers one of a subscriber's devices for push notifications
+func (h *PushHandler) RegisterDevice(c *gin.Context) {
+	subscriberID, ok := h.subscriberID(c)
+	if !ok {
+		return
+	}
+
+	var req dtos.RegisterPushDeviceRequest
+	if !middleware.ValidateJSON(c, &req) {
+		return
+	}
+
+	device := &push.Device{
+		SubscriberID: subscriberID,
+		Platform:     req.Platform,
+		Token:        req.Token,
+	}
+	if req.Keys != nil {
+		device.P256dh = req.Keys.P256dh
+		device.Auth = req.Keys.Auth
+	}
+
+	if err := h.pushService.RegisterDevice(c.Request.Context(), device); err != nil {
+		if errors.Is(err, push.ErrMissingKeys) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrPushKeysRequired})
+			return
+		}
+		abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toPushDeviceResponse(device))
+}
+
+// GetDevices lists a subscriber's registered push devices
+func (h *PushHandler) GetDevices(c *gin.Context) {
+	subscriberID, ok := h.subscriberID(c)
+	if !ok {
+		return
+	}
+
+	devices, err := h.pushService.GetDevicesBySubscriberID(c.Request.Context(), subscriberID)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	response := make([]dtos.PushDeviceResponse, 0, len(devices))
+	for _, device := range devices {
+		response = append(response, toPushDeviceResponse(device))
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// DeleteDevice unregisters one of a subscriber's push devices
+func (h *PushHandler) DeleteDevice(c *gin.Context) {
+	subscriberID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidSubscriberID})
+		return
+	}
+	deviceID, err := strconv.ParseUint(c.Param("device_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidPushDeviceID})
+		return
+	}
+
+	found, err := h.pushService.DeleteDevice(c.Request.Context(), uint(subscriberID), uint(deviceID))
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	if !found {
+		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrPushDeviceNotFound})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": constants.MsgPushDeviceDeletedSuccessfully})
+}
+
+// subscriberID parses the subscriber in the path and checks it exists, responding with an error if not
+func (h *PushHandler) subscriberID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidSubscriberID})
+		return 0, false
+	}
+	if _, err := h.subscriberService.GetSubscriberByID(c.Request.Context(), uint(id)); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrSubscriberNotFound})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func toPushDeviceResponse(device *push.Device) dtos.PushDeviceResponse {
+	return dtos.PushDeviceResponse{
+		ID:           device.ID,
+		SubscriberID: device.SubscriberID,
+		Platform:     device.Platform,
+		Token:        device.Token,
+		CreatedAt:    device.CreatedAt,
+		UpdatedAt:    device.UpdatedAt,
+	}
+}
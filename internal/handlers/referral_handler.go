@@ -0,0 +1,201 @@
+package handlers
+
+import (
+	"errors"
+	"html/template"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"newsletter-service/internal/constants"
+	"newsletter-service/internal/daos"
+	"newsletter-service/internal/dtos"
+	apperrors "newsletter-service/internal/errors"
+	"newsletter-service/internal/events"
+	"newsletter-service/internal/router/middleware"
+	"newsletter-service/internal/services/referral"
+	"newsletter-service/internal/tenant"
+)
+
+type ReferralHandler struct {
+	referralService referral.Service
+	eventBus        *events.Bus
+}
+
+func NewReferralHandler(referralService referral.Service, eventBus *events.Bus) *ReferralHandler {
+	return &ReferralHandler{
+		referralService: referralService,
+		eventBus:        eventBus,
+	}
+}
+
+// Redeem signs a reader up through a referral link. Nothing is subscribed until the reader follows the link
+// in the confirmation email, so the answer is the same whether or not one was already on its way.
+func (h *ReferralHandler) Redeem(c *gin.Context) {
+	var req dtos.RedeemReferralRequest
+	if !middleware.ValidateJSON(c, &req) {
+		return
+	}
+
+	_, err := h.referralService.Redeem(c.Request.Context(), referral.Redemption{
+		Code:  req.Code,
+		Email: req.Email,
+		Name:  req.Name,
+	})
+	if errors.Is(err, referral.ErrDisabled) {
+		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrReferralsDisabled})
+		return
+	}
+	if errors.Is(err, referral.ErrUnknownCode) {
+		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrUnknownReferralCode})
+		return
+	}
+	if errors.Is(err, referral.ErrAlreadySubscribed) {
+		apperrors.Abort(c, apperrors.NewConflictError(constants.ErrAlreadySubscribed, err))
+		return
+	}
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"message": constants.MsgReferralConfirmationSent})
+}
+
+var referralConfirmTemplate = template.Must(template.New("referral-confirm").Parse(`<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>{{.Title}} - Newsletter Service</title>
+    <style>
+        body {
+            font-family: Arial, sans-serif;
+            line-height: 1.6;
+            color: #333;
+            max-width: 600px;
+            margin: 50px auto;
+            padding: 20px;
+            background-color: #f4f4f4;
+        }
+        .container {
+            background-color: white;
+            padding: 40px;
+            border-radius: 10px;
+            box-shadow: 0 2px 5px rgba(0,0,0,0.1);
+            text-align: center;
+        }
+        h1 {
+            color: {{if .OK}}#28a745{{else}}#dc3545{{end}};
+            margin-bottom: 20px;
+        }
+        .topic-list {
+            text-align: left;
+            margin: 20px 0;
+        }
+        .topic-item {
+            padding: 5px 0;
+        }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <h1>{{.Title}}</h1>
+        <p>{{.Message}}</p>
+        {{if .Topics}}
+        <p>You'll hear from us about:</p>
+        <div class="topic-list">
+            {{range .Topics}}<div class="topic-item">• {{.}}</div>{{end}}
+        </div>
+        {{end}}
+    </div>
+</body>
+</html>`))
+
+// ConfirmGet handles the link in a referral confirmation email: it subscribes the reader and credits the
+// subscriber who referred them
+func (h *ReferralHandler) ConfirmGet(c *gin.Context) {
+	token := c.Query("token")
+	if token == "" {
+		renderReferralConfirm(c, http.StatusBadRequest, false, "Invalid Link", "This confirmation link is incomplete. Copy the whole link from the email and try again.", nil)
+		return
+	}
+
+	confirmation, err := h.referralService.Confirm(c.Request.Context(), token)
+	if errors.Is(err, referral.ErrInvalidToken) {
+		renderReferralConfirm(c, http.StatusNotFound, false, "Link Expired", "This confirmation link is invalid or has expired. Sign up again to get a new one.", nil)
+		return
+	}
+	if errors.Is(err, referral.ErrAlreadySubscribed) {
+		renderReferralConfirm(c, http.StatusConflict, false, "Already Subscribed", "This address is already subscribed.", nil)
+		return
+	}
+	if err != nil {
+		renderReferralConfirm(c, http.StatusInternalServerError, false, "Something Went Wrong", "We couldn't confirm your subscription. Please try again later.", nil)
+		return
+	}
+
+	// Only the click that created the subscriber announces it
+	if sub := confirmation.Subscriber; sub != nil {
+		created := dtos.SubscriberResponse{
+			ID:               sub.ID,
+			Email:            sub.Email,
+			Name:             sub.Name,
+			IsActive:         sub.IsActive,
+			EmailStatus:      sub.EmailStatus,
+			Timezone:         sub.Timezone,
+			Locale:           sub.Locale,
+			Phone:            sub.Phone,
+			Channels:         daos.DecodeChannels(sub.Channels),
+			ReferralCode:     sub.ReferralCode,
+			ReferralCount:    sub.ReferralCount,
+			ReferredByID:     sub.ReferredByID,
+			Signup:           signupAttribution(sub),
+			SubscribedTopics: confirmation.TopicNames,
+			CreatedAt:        sub.CreatedAt,
+			UpdatedAt:        sub.UpdatedAt,
+		}
+		ctx := tenant.WithOrganization(c.Request.Context(), sub.OrganizationID)
+		h.eventBus.Emit(ctx, events.SubscriberCreated, created)
+	}
+
+	renderReferralConfirm(c, http.StatusOK, true, "Subscription Confirmed", "Thanks for confirming. You're now subscribed.", confirmation.TopicNames)
+}
+
+func renderReferralConfirm(c *gin.Context, status int, ok bool, title, message string, topics []string) {
+	c.Header("Content-Type", "text/html")
+	c.Status(status)
+	referralConfirmTemplate.Execute(c.Writer, gin.H{
+		"OK":      ok,
+		"Title":   title,
+		"Message": message,
+		"Topics":  topics,
+	})
+}
+
+// GetLeaderboard ranks the organization's subscribers by how many readers they referred
+func (h *ReferralHandler) GetLeaderboard(c *gin.Context) {
+	var query dtos.LeaderboardQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidLeaderboardQuery, "details": err.Error()})
+		return
+	}
+
+	entries, err := h.referralService.GetLeaderboard(c.Request.Context(), query.Limit)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	response := dtos.LeaderboardResponse{Entries: make([]dtos.LeaderboardEntryResponse, 0, len(entries))}
+	for _, entry := range entries {
+		response.Entries = append(response.Entries, dtos.LeaderboardEntryResponse{
+			Rank:          entry.Rank,
+			SubscriberID:  entry.Subscriber.ID,
+			Name:          entry.Subscriber.Name,
+			Email:         entry.Subscriber.Email,
+			ReferralCount: entry.Subscriber.ReferralCount,
+		})
+	}
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"newsletter-service/internal/services/retention"
+)
+
+type RetentionHandler struct {
+	retentionService retention.Service
+}
+
+func NewRetentionHandler(retentionService retention.Service) *RetentionHandler {
+	return &RetentionHandler{
+		retentionService: retentionService,
+	}
+}
+
+// PreviewRetention reports how many rows the next retention run would anonymize or delete, without changing any
+func (h *RetentionHandler) PreviewRetention(c *gin.Context) {
+	report, err := h.retentionService.Enforce(c.Request.Context(), true)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"newsletter-service/internal/constants"
+	"newsletter-service/internal/dtos"
+)
+
+// bindShape reads ?fields= and ?include= for an endpoint returning response, a value of its DTO. includes maps
+// each embed the endpoint offers to the response field it fills. Names the response doesn't have get a 400.
+func bindShape(c *gin.Context, response interface{}, includes map[string]string) (dtos.Shape, bool) {
+	var query dtos.ShapeQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidFieldSelection, "details": err.Error()})
+		return dtos.Shape{}, false
+	}
+	shape, err := query.Parse(response, includes)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidFieldSelection, "details": err.Error()})
+		return dtos.Shape{}, false
+	}
+	return shape, true
+}
+
+// respondShaped writes a single resource with only the selected fields, and the resource's ETag
+func respondShaped(c *gin.Context, shape dtos.Shape, response interface{}, etag string) {
+	body, err := shape.Select(response)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	respondWithETag(c, etag, body)
+}
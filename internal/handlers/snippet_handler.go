@@ -0,0 +1,198 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"newsletter-service/internal/constants"
+	"newsletter-service/internal/daos"
+	"newsletter-service/internal/dtos"
+	"newsletter-service/internal/router/middleware"
+	"newsletter-service/internal/services/audit"
+	"newsletter-service/internal/services/snippet"
+)
+
+type SnippetHandler struct {
+	snippetService snippet.Service
+	auditService   audit.Service
+}
+
+func NewSnippetHandler(snippetService snippet.Service, auditService audit.Service) *SnippetHandler {
+	return &SnippetHandler{
+		snippetService: snippetService,
+		auditService:   auditService,
+	}
+}
+
+// GetSnippets retrieves snippets by name with pagination
+func (h *SnippetHandler) GetSnippets(c *gin.Context) {
+	var pagination dtos.PaginationRequest
+	if err := c.ShouldBindQuery(&pagination); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidPaginationParams})
+		return
+	}
+
+	page, pageSize := pagination.GetDefaults()
+	offset := pagination.CalculateOffset()
+
+	snippets, total, err := h.snippetService.GetSnippetsWithPagination(c.Request.Context(), offset, pageSize)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	response := make([]dtos.SnippetResponse, 0, len(snippets))
+	for _, s := range snippets {
+		response = append(response, toSnippetResponse(s))
+	}
+
+	paginationResponse := dtos.CreatePaginationResponse(page, pageSize, total)
+	c.JSON(http.StatusOK, dtos.PaginatedResponse[dtos.SnippetResponse]{
+		Data:       response,
+		Pagination: paginationResponse,
+	})
+}
+
+// CreateSnippet adds a snippet that content can include by name
+func (h *SnippetHandler) CreateSnippet(c *gin.Context) {
+	var req dtos.CreateSnippetRequest
+	if !middleware.ValidateJSON(c, &req) {
+		return
+	}
+
+	s := &snippet.Snippet{
+		Name:        req.Name,
+		Format:      req.Format,
+		Body:        req.Body,
+		Description: req.Description,
+	}
+	if s.Format == "" {
+		s.Format = daos.SnippetFormatHTML
+	}
+
+	if err := h.snippetService.CreateSnippet(c.Request.Context(), s); err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	recordAudit(c, h.auditService, audit.ActionCreate, audit.EntitySnippet, s.ID, nil, s)
+
+	c.JSON(http.StatusCreated, toSnippetResponse(s))
+}
+
+// GetSnippetByID retrieves a snippet by ID
+func (h *SnippetHandler) GetSnippetByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidSnippetID})
+		return
+	}
+
+	s, err := h.snippetService.GetSnippetByID(c.Request.Context(), uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrSnippetNotFound})
+		return
+	}
+
+	c.JSON(http.StatusOK, toSnippetResponse(s))
+}
+
+// UpdateSnippet changes a snippet's name, format, body or description. Content including it is sent with the
+// new body from then on, including content already published.
+func (h *SnippetHandler) UpdateSnippet(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidSnippetID})
+		return
+	}
+
+	var req dtos.UpdateSnippetRequest
+	if !middleware.ValidateJSON(c, &req) {
+		return
+	}
+
+	before, err := h.snippetService.GetSnippetByID(c.Request.Context(), uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrSnippetNotFound})
+		return
+	}
+
+	updates := make(map[string]interface{})
+	if req.Name != "" {
+		updates["name"] = req.Name
+	}
+	if req.Format != "" {
+		updates["format"] = req.Format
+	}
+	if req.Body != "" {
+		updates["body"] = req.Body
+	}
+	if req.Description != nil {
+		updates["description"] = *req.Description
+	}
+
+	if err := h.snippetService.UpdateSnippet(c.Request.Context(), uint(id), updates); err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	after, err := h.snippetService.GetSnippetByID(c.Request.Context(), uint(id))
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	recordAudit(c, h.auditService, audit.ActionUpdate, audit.EntitySnippet, uint(id), before, after)
+
+	c.JSON(http.StatusOK, toSnippetResponse(after))
+}
+
+// DeleteSnippet removes a snippet; content still including it is sent without it
+func (h *SnippetHandler) DeleteSnippet(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidSnippetID})
+		return
+	}
+
+	before, err := h.snippetService.GetSnippetByID(c.Request.Context(), uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrSnippetNotFound})
+		return
+	}
+
+	if err := h.snippetService.DeleteSnippet(c.Request.Context(), uint(id)); err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	recordAudit(c, h.auditService, audit.ActionDelete, audit.EntitySnippet, uint(id), before, nil)
+
+	c.JSON(http.StatusOK, gin.H{"message": constants.MsgSnippetDeletedSuccessfully})
+}
+
+// writeError responds to a failed create or update
+func (h *SnippetHandler) writeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, snippet.ErrInvalidName):
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidSnippetName})
+	case errors.Is(err, snippet.ErrDuplicateName):
+		c.JSON(http.StatusConflict, gin.H{"error": constants.ErrSnippetNameExists})
+	default:
+		abortWithError(c, err)
+	}
+}
+
+func toSnippetResponse(s *snippet.Snippet) dtos.SnippetResponse {
+	return dtos.SnippetResponse{
+		ID:          s.ID,
+		Name:        s.Name,
+		Format:      s.Format,
+		Body:        s.Body,
+		Description: s.Description,
+		CreatedAt:   s.CreatedAt,
+		UpdatedAt:   s.UpdatedAt,
+	}
+}
@@ -0,0 +1,382 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"newsletter-service/internal/constants"
+	"newsletter-service/internal/dtos"
+	apperrors "newsletter-service/internal/errors"
+	"newsletter-service/internal/router/middleware"
+	"newsletter-service/internal/services/audit"
+	"newsletter-service/internal/services/sponsor"
+)
+
+type SponsorHandler struct {
+	sponsorService sponsor.Service
+	auditService   audit.Service
+}
+
+func NewSponsorHandler(sponsorService sponsor.Service, auditService audit.Service) *SponsorHandler {
+	return &SponsorHandler{
+		sponsorService: sponsorService,
+		auditService:   auditService,
+	}
+}
+
+// GetSponsors lists sponsors with pagination, by name
+func (h *SponsorHandler) GetSponsors(c *gin.Context) {
+	var pagination dtos.PaginationRequest
+	if err := c.ShouldBindQuery(&pagination); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidPaginationParams})
+		return
+	}
+
+	page, pageSize := pagination.GetDefaults()
+	offset := pagination.CalculateOffset()
+
+	sponsors, total, err := h.sponsorService.GetSponsorsWithPagination(c.Request.Context(), offset, pageSize)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	response := make([]dtos.SponsorResponse, 0, len(sponsors))
+	for _, s := range sponsors {
+		response = append(response, toSponsorResponse(s))
+	}
+
+	c.JSON(http.StatusOK, dtos.PaginatedResponse[dtos.SponsorResponse]{
+		Data:       response,
+		Pagination: dtos.CreatePaginationResponse(page, pageSize, total),
+	})
+}
+
+// CreateSponsor adds an advertiser whose block booked slots place into content
+func (h *SponsorHandler) CreateSponsor(c *gin.Context) {
+	var req dtos.CreateSponsorRequest
+	if !middleware.ValidateJSON(c, &req) {
+		return
+	}
+
+	s := &sponsor.Sponsor{
+		Name:         req.Name,
+		URL:          req.URL,
+		LogoURL:      req.LogoURL,
+		Message:      req.Message,
+		ContactEmail: req.ContactEmail,
+	}
+
+	if err := h.sponsorService.CreateSponsor(c.Request.Context(), s); err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	recordAudit(c, h.auditService, audit.ActionCreate, audit.EntitySponsor, s.ID, nil, s)
+
+	c.JSON(http.StatusCreated, toSponsorResponse(s))
+}
+
+// GetSponsorByID retrieves a sponsor by ID
+func (h *SponsorHandler) GetSponsorByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidSponsorID})
+		return
+	}
+
+	s, err := h.sponsorService.GetSponsorByID(c.Request.Context(), uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrSponsorNotFound})
+		return
+	}
+
+	c.JSON(http.StatusOK, toSponsorResponse(s))
+}
+
+// UpdateSponsor changes a sponsor. Content sent from then on, digests included, shows the new block.
+func (h *SponsorHandler) UpdateSponsor(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidSponsorID})
+		return
+	}
+
+	var req dtos.UpdateSponsorRequest
+	if !middleware.ValidateJSON(c, &req) {
+		return
+	}
+
+	before, err := h.sponsorService.GetSponsorByID(c.Request.Context(), uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrSponsorNotFound})
+		return
+	}
+
+	updates := make(map[string]interface{})
+	if req.Name != "" {
+		updates["name"] = req.Name
+	}
+	if req.URL != "" {
+		updates["url"] = req.URL
+	}
+	if req.LogoURL != nil {
+		updates["logo_url"] = *req.LogoURL
+	}
+	if req.Message != nil {
+		updates["message"] = *req.Message
+	}
+	if req.ContactEmail != nil {
+		updates["contact_email"] = *req.ContactEmail
+	}
+
+	if err := h.sponsorService.UpdateSponsor(c.Request.Context(), uint(id), updates); err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	after, err := h.sponsorService.GetSponsorByID(c.Request.Context(), uint(id))
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	recordAudit(c, h.auditService, audit.ActionUpdate, audit.EntitySponsor, uint(id), before, after)
+
+	c.JSON(http.StatusOK, toSponsorResponse(after))
+}
+
+// DeleteSponsor deletes a sponsor and cancels its unserved slots. Served slots stay in its report.
+func (h *SponsorHandler) DeleteSponsor(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidSponsorID})
+		return
+	}
+
+	before, err := h.sponsorService.GetSponsorByID(c.Request.Context(), uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrSponsorNotFound})
+		return
+	}
+
+	if err := h.sponsorService.DeleteSponsor(c.Request.Context(), uint(id)); err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	recordAudit(c, h.auditService, audit.ActionDelete, audit.EntitySponsor, uint(id), before, nil)
+
+	c.JSON(http.StatusOK, gin.H{"message": constants.MsgSponsorDeletedSuccessfully})
+}
+
+// BookSlot books a placement for the sponsor, in one content or in the first content a topic sends on a date
+func (h *SponsorHandler) BookSlot(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidSponsorID})
+		return
+	}
+
+	var req dtos.BookSponsorSlotRequest
+	if !middleware.ValidateJSON(c, &req) {
+		return
+	}
+
+	slot := &sponsor.Slot{
+		SponsorID:  uint(id),
+		TopicID:    req.TopicID,
+		ContentID:  req.ContentID,
+		Position:   req.Position,
+		Message:    req.Message,
+		PriceCents: req.PriceCents,
+		Currency:   req.Currency,
+	}
+	if req.Date != "" {
+		// Validated as YYYY-MM-DD
+		date, _ := time.Parse(time.DateOnly, req.Date)
+		slot.Date = &date
+	}
+
+	if err := h.sponsorService.BookSlot(c.Request.Context(), slot); err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	recordAudit(c, h.auditService, audit.ActionCreate, audit.EntitySponsorSlot, slot.ID, nil, slot)
+
+	c.JSON(http.StatusCreated, toSponsorSlotResponse(slot))
+}
+
+// GetSlots lists booked slots with pagination, newest first
+func (h *SponsorHandler) GetSlots(c *gin.Context) {
+	var query dtos.SponsorSlotQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidSponsorFilter, "details": err.Error()})
+		return
+	}
+
+	page, pageSize := query.GetDefaults()
+	offset := query.CalculateOffset()
+
+	filter := sponsor.SlotFilter{
+		SponsorID: query.SponsorID,
+		TopicID:   query.TopicID,
+		Served:    query.Served,
+	}
+	slots, total, err := h.sponsorService.GetSlotsWithPagination(c.Request.Context(), filter, offset, pageSize)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	response := make([]dtos.SponsorSlotResponse, 0, len(slots))
+	for _, slot := range slots {
+		response = append(response, toSponsorSlotResponse(slot))
+	}
+
+	c.JSON(http.StatusOK, dtos.PaginatedResponse[dtos.SponsorSlotResponse]{
+		Data:       response,
+		Pagination: dtos.CreatePaginationResponse(page, pageSize, total),
+	})
+}
+
+// GetSlotByID retrieves a booked slot by ID
+func (h *SponsorHandler) GetSlotByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidSponsorSlotID})
+		return
+	}
+
+	slot, err := h.sponsorService.GetSlotByID(c.Request.Context(), uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrSponsorSlotNotFound})
+		return
+	}
+
+	c.JSON(http.StatusOK, toSponsorSlotResponse(slot))
+}
+
+// CancelSlot cancels a slot whose content hasn't been sent
+func (h *SponsorHandler) CancelSlot(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidSponsorSlotID})
+		return
+	}
+
+	before, err := h.sponsorService.GetSlotByID(c.Request.Context(), uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrSponsorSlotNotFound})
+		return
+	}
+
+	if err := h.sponsorService.CancelSlot(c.Request.Context(), uint(id)); err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	recordAudit(c, h.auditService, audit.ActionDelete, audit.EntitySponsorSlot, uint(id), before, nil)
+
+	c.JSON(http.StatusOK, gin.H{"message": constants.MsgSponsorSlotCanceled})
+}
+
+// GetReport returns the sponsor's slots with the sends and impressions of those served. Impressions are opens
+// of the content carrying the block, so they are only counted where open tracking is on.
+func (h *SponsorHandler) GetReport(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidSponsorID})
+		return
+	}
+
+	report, err := h.sponsorService.GetReport(c.Request.Context(), uint(id))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrSponsorNotFound})
+		return
+	}
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	response := dtos.SponsorReportResponse{
+		Sponsor:     toSponsorResponse(report.Sponsor),
+		Slots:       make([]dtos.SponsorSlotStatsResponse, 0, len(report.Slots)),
+		Sends:       report.Sends,
+		Impressions: report.Impressions,
+	}
+	for _, stats := range report.Slots {
+		response.Slots = append(response.Slots, dtos.SponsorSlotStatsResponse{
+			SponsorSlotResponse: toSponsorSlotResponse(stats.Slot),
+			Sends:               stats.Sends,
+			Impressions:         stats.Impressions,
+		})
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// writeError responds to a failed slot booking or cancellation
+func (h *SponsorHandler) writeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrSponsorSlotNotFound})
+	case errors.Is(err, sponsor.ErrSponsorNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrSponsorNotFound})
+	case errors.Is(err, sponsor.ErrTopicNotFound):
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrTopicNotFound})
+	case errors.Is(err, sponsor.ErrContentNotFound):
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrContentNotFound})
+	case errors.Is(err, sponsor.ErrInvalidSlot):
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidSponsorSlot})
+	case errors.Is(err, sponsor.ErrDatePassed):
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrSponsorSlotDatePassed})
+	case errors.Is(err, sponsor.ErrContentSent):
+		apperrors.Abort(c, apperrors.NewConflictError(constants.ErrContentAlreadySent, err))
+	case errors.Is(err, sponsor.ErrSlotTaken):
+		apperrors.Abort(c, apperrors.NewConflictError(constants.ErrSponsorSlotTaken, err))
+	case errors.Is(err, sponsor.ErrSlotServed):
+		apperrors.Abort(c, apperrors.NewConflictError(constants.ErrSponsorSlotServed, err))
+	default:
+		abortWithError(c, err)
+	}
+}
+
+func toSponsorResponse(s *sponsor.Sponsor) dtos.SponsorResponse {
+	return dtos.SponsorResponse{
+		ID:           s.ID,
+		Name:         s.Name,
+		URL:          s.URL,
+		LogoURL:      s.LogoURL,
+		Message:      s.Message,
+		ContactEmail: s.ContactEmail,
+		CreatedAt:    s.CreatedAt,
+		UpdatedAt:    s.UpdatedAt,
+	}
+}
+
+func toSponsorSlotResponse(slot *sponsor.Slot) dtos.SponsorSlotResponse {
+	response := dtos.SponsorSlotResponse{
+		ID:         slot.ID,
+		SponsorID:  slot.SponsorID,
+		TopicID:    slot.TopicID,
+		ContentID:  slot.ContentID,
+		Position:   slot.Position,
+		Message:    slot.Message,
+		PriceCents: slot.PriceCents,
+		Currency:   slot.Currency,
+		ServedAt:   slot.ServedAt,
+		CreatedAt:  slot.CreatedAt,
+		UpdatedAt:  slot.UpdatedAt,
+	}
+	if slot.Date != nil {
+		date := slot.Date.Format(time.DateOnly)
+		response.Date = &date
+	}
+	return response
+}
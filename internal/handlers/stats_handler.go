@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"newsletter-service/internal/constants"
+	"newsletter-service/internal/dtos"
+	"newsletter-service/internal/services/stats"
+)
+
+// defaultTimeseriesRange is used when the timeseries request has no from parameter
+const defaultTimeseriesRange = 30 * 24 * time.Hour
+
+type StatsHandler struct {
+	statsService stats.Service
+}
+
+func NewStatsHandler(statsService stats.Service) *StatsHandler {
+	return &StatsHandler{
+		statsService: statsService,
+	}
+}
+
+// GetOverview returns service-wide totals for the metrics dashboard
+func (h *StatsHandler) GetOverview(c *gin.Context) {
+	overview, err := h.statsService.GetOverview(c.Request.Context())
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, overview)
+}
+
+// GetTimeseries returns one metric bucketed by day, week or month
+func (h *StatsHandler) GetTimeseries(c *gin.Context) {
+	var query dtos.TimeseriesQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidStatsQuery, "details": err.Error()})
+		return
+	}
+
+	interval := query.Interval
+	if interval == "" {
+		interval = stats.IntervalDay
+	}
+
+	from, to, ok := statsRange(c, query.From, query.To)
+	if !ok {
+		return
+	}
+
+	series, err := h.statsService.GetTimeseries(c.Request.Context(), query.Metric, interval, from, to)
+	if errors.Is(err, stats.ErrInvalidRange) {
+		details := fmt.Sprintf("from must be before to and span at most %d buckets", stats.MaxBuckets)
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidStatsQuery, "details": details})
+		return
+	}
+	if errors.Is(err, stats.ErrUnknownMetric) || errors.Is(err, stats.ErrUnknownInterval) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidStatsQuery, "details": err.Error()})
+		return
+	}
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, series)
+}
+
+// GetDomainStats returns delivery outcomes per recipient domain, most failures first
+func (h *StatsHandler) GetDomainStats(c *gin.Context) {
+	var query dtos.DomainStatsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidStatsQuery, "details": err.Error()})
+		return
+	}
+
+	report, err := h.statsService.GetDomainReport(c.Request.Context(), query.Days, query.Limit)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// GetSignups breaks new subscribers down by one signup attribution field, bucketed by day, week or month
+func (h *StatsHandler) GetSignups(c *gin.Context) {
+	var query dtos.SignupStatsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidStatsQuery, "details": err.Error()})
+		return
+	}
+
+	interval := query.Interval
+	if interval == "" {
+		interval = stats.IntervalDay
+	}
+	from, to, ok := statsRange(c, query.From, query.To)
+	if !ok {
+		return
+	}
+
+	report, err := h.statsService.GetSignupReport(c.Request.Context(), query.By, interval, from, to, query.Limit)
+	if errors.Is(err, stats.ErrInvalidRange) {
+		details := fmt.Sprintf("from must be before to and span at most %d buckets", stats.MaxBuckets)
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidStatsQuery, "details": details})
+		return
+	}
+	if errors.Is(err, stats.ErrUnknownSignupBy) || errors.Is(err, stats.ErrUnknownInterval) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidStatsQuery, "details": err.Error()})
+		return
+	}
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// statsRange parses the RFC3339 from and to parameters of a bucketed stats query. to defaults to now and from
+// to defaultTimeseriesRange before it; invalid values are answered with 400.
+func statsRange(c *gin.Context, fromParam, toParam string) (time.Time, time.Time, bool) {
+	to := time.Now()
+	if toParam != "" {
+		parsed, err := time.Parse(time.RFC3339, toParam)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidStatsQuery, "details": "to must be RFC3339"})
+			return time.Time{}, time.Time{}, false
+		}
+		to = parsed
+	}
+	from := to.Add(-defaultTimeseriesRange)
+	if fromParam != "" {
+		parsed, err := time.Parse(time.RFC3339, fromParam)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidStatsQuery, "details": "from must be RFC3339"})
+			return time.Time{}, time.Time{}, false
+		}
+		from = parsed
+	}
+	return from, to, true
+}
@@ -1,63 +1,111 @@
 package handlers
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 
 	"newsletter-service/internal/constants"
+	"newsletter-service/internal/daos"
 	"newsletter-service/internal/dtos"
+	apperrors "newsletter-service/internal/errors"
+	"newsletter-service/internal/events"
+	"newsletter-service/internal/locale"
 	"newsletter-service/internal/router/middleware"
+	"newsletter-service/internal/services/audit"
+	"newsletter-service/internal/services/emailcheck"
 	"newsletter-service/internal/services/subscriber"
 )
 
 type SubscriberHandler struct {
 	subscriberService subscriber.Service
+	auditService      audit.Service
+	eventBus          *events.Bus
 }
 
-func NewSubscriberHandler(subscriberService subscriber.Service) *SubscriberHandler {
+func NewSubscriberHandler(subscriberService subscriber.Service, auditService audit.Service, eventBus *events.Bus) *SubscriberHandler {
 	return &SubscriberHandler{
 		subscriberService: subscriberService,
+		auditService:      auditService,
+		eventBus:          eventBus,
 	}
 }
 
 // GetSubscribers retrieves all subscribers
 func (h *SubscriberHandler) GetSubscribers(c *gin.Context) {
-	var pagination dtos.PaginationRequest
-	if err := c.ShouldBindQuery(&pagination); err != nil {
+	var query dtos.SubscriberListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidPaginationParams})
 		return
 	}
+	pagination := query.ListQuery
+	filter, ok := signupFilter(c, query.SignupFilterQuery)
+	if !ok {
+		return
+	}
+	shape, ok := bindShape(c, dtos.SubscriberResponse{}, subscriberIncludes)
+	if !ok {
+		return
+	}
 
-	// Check if pagination parameters were provided
-	if pagination.Page > 0 || pagination.PageSize > 0 {
+	ids, err := pagination.ParseIDs()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidIDs, "details": err.Error()})
+		return
+	}
+	if ids != nil {
+		subscribers, err := h.subscriberService.GetSubscribersByIDs(c.Request.Context(), ids)
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+		data, ok := h.subscriberList(c, shape, orderByIDs(subscribers, ids, func(sub *subscriber.Subscriber) uint { return sub.ID }))
+		if !ok {
+			return
+		}
+		if data == nil {
+			data = []interface{}{}
+		}
+		c.JSON(http.StatusOK, data)
+		return
+	}
+
+	// Check if pagination parameters were provided; soft-deleted rows and filtered lists are only listed paginated
+	if pagination.Page > 0 || pagination.PageSize > 0 || pagination.IncludeDeleted || !filter.IsZero() {
 		// Use paginated response
 		page, pageSize := pagination.GetDefaults()
 		offset := pagination.CalculateOffset()
 
-		subscribers, total, err := h.subscriberService.GetAllSubscribersWithPagination(c.Request.Context(), offset, pageSize)
+		listPage := h.subscriberService.GetAllSubscribersWithPagination
+		if pagination.IncludeDeleted {
+			listPage = h.subscriberService.GetAllSubscribersIncludingDeletedWithPagination
+		}
+		if !filter.IsZero() {
+			listPage = func(ctx context.Context, offset, limit int) ([]*subscriber.Subscriber, int64, error) {
+				return h.subscriberService.GetSubscribersBySignupWithPagination(ctx, filter, pagination.IncludeDeleted, offset, limit)
+			}
+		}
+
+		subscribers, total, err := listPage(c.Request.Context(), offset, pageSize)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			abortWithError(c, err)
 			return
 		}
 
-		var response []dtos.SubscriberResponse
-		for _, sub := range subscribers {
-			response = append(response, dtos.SubscriberResponse{
-				ID:        sub.ID,
-				Email:     sub.Email,
-				Name:      sub.Name,
-				IsActive:  sub.IsActive,
-				CreatedAt: sub.CreatedAt,
-				UpdatedAt: sub.UpdatedAt,
-			})
+		data, ok := h.subscriberList(c, shape, subscribers)
+		if !ok {
+			return
 		}
 
 		paginationResponse := dtos.CreatePaginationResponse(page, pageSize, total)
-		paginatedResponse := dtos.PaginatedResponse[dtos.SubscriberResponse]{
-			Data:       response,
+		paginatedResponse := dtos.PaginatedResponse[interface{}]{
+			Data:       data,
 			Pagination: paginationResponse,
 		}
 
@@ -66,24 +114,73 @@ func (h *SubscriberHandler) GetSubscribers(c *gin.Context) {
 		// Use non-paginated response for backward compatibility
 		subscribers, err := h.subscriberService.GetAllSubscribers(c.Request.Context())
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			abortWithError(c, err)
 			return
 		}
 
-		var response []dtos.SubscriberResponse
-		for _, sub := range subscribers {
-			response = append(response, dtos.SubscriberResponse{
-				ID:        sub.ID,
-				Email:     sub.Email,
-				Name:      sub.Name,
-				IsActive:  sub.IsActive,
-				CreatedAt: sub.CreatedAt,
-				UpdatedAt: sub.UpdatedAt,
-			})
+		data, ok := h.subscriberList(c, shape, subscribers)
+		if !ok {
+			return
 		}
 
-		c.JSON(http.StatusOK, response)
+		c.JSON(http.StatusOK, data)
+	}
+}
+
+// subscriberIncludes are the embeds subscriber responses offer. A single subscriber always comes with its topics.
+var subscriberIncludes = map[string]string{"topics": "subscribed_topics"}
+
+// subscriberList builds the items of a subscriber list. With include=topics the topic names of the whole page
+// are loaded in one query, sparing clients a request per subscriber.
+func (h *SubscriberHandler) subscriberList(c *gin.Context, shape dtos.Shape, subscribers []*subscriber.Subscriber) ([]interface{}, bool) {
+	var topicNames map[uint][]string
+	if shape.Includes("topics") {
+		ids := make([]uint, len(subscribers))
+		for i, sub := range subscribers {
+			ids[i] = sub.ID
+		}
+		var err error
+		if topicNames, err = h.subscriberService.GetSubscribedTopicNamesByIDs(c.Request.Context(), ids); err != nil {
+			abortWithError(c, err)
+			return nil, false
+		}
 	}
+
+	var response []dtos.SubscriberResponse
+	for _, sub := range subscribers {
+		item := dtos.SubscriberResponse{
+			ID:            sub.ID,
+			Email:         sub.Email,
+			Name:          sub.Name,
+			IsActive:      sub.IsActive,
+			EmailStatus:   sub.EmailStatus,
+			Timezone:      sub.Timezone,
+			Locale:        sub.Locale,
+			Phone:         sub.Phone,
+			Channels:      daos.DecodeChannels(sub.Channels),
+			ReferralCode:  sub.ReferralCode,
+			ReferralCount: sub.ReferralCount,
+			ReferredByID:  sub.ReferredByID,
+			Signup:        signupAttribution(sub),
+			CreatedAt:     sub.CreatedAt,
+			UpdatedAt:     sub.UpdatedAt,
+			DeletedAt:     deletedAt(sub.DeletedAt),
+		}
+		if topicNames != nil {
+			item.SubscribedTopics = topicNames[sub.ID]
+			if item.SubscribedTopics == nil {
+				item.SubscribedTopics = []string{}
+			}
+		}
+		response = append(response, item)
+	}
+
+	data, err := dtos.SelectAll(shape, response)
+	if err != nil {
+		abortWithError(c, err)
+		return nil, false
+	}
+	return data, true
 }
 
 // CreateSubscriber creates a new subscriber
@@ -97,53 +194,61 @@ func (h *SubscriberHandler) CreateSubscriber(c *gin.Context) {
 		Email:    req.Email,
 		Name:     req.Name,
 		IsActive: true,
+		Timezone: subscriberTimezone(c, req.Timezone),
+		Locale:   subscriberLocale(c, req.Locale),
+		Phone:    req.Phone,
+		Channels: daos.EncodeChannels(req.Channels),
 	}
+	recordSignup(c, subscriberModel, daos.SignupSourceAPI, req)
 
-	var err error
-	if len(req.SubscribedTopics) > 0 {
-		// Create subscriber with topics
-		err = h.subscriberService.CreateSubscriberWithTopics(c.Request.Context(), subscriberModel, req.SubscribedTopics)
-	} else {
-		// Create subscriber without topics
-		err = h.subscriberService.CreateSubscriber(c.Request.Context(), subscriberModel)
+	topicNames, err := h.subscriberService.CreateSubscriberWithTopics(c.Request.Context(), subscriberModel, req.SubscribedTopics)
+	if errors.Is(err, emailcheck.ErrUndeliverable) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrUndeliverableEmail, "details": err.Error()})
+		return
 	}
-
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if errors.Is(err, subscriber.ErrTopicsNotFound) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrSubscribedTopicNotFound, "details": err.Error()})
+		return
+	}
+	if errors.Is(err, subscriber.ErrTopicArchived) {
+		c.JSON(http.StatusConflict, gin.H{"error": constants.ErrTopicArchived, "details": err.Error()})
+		return
+	}
+	if errors.Is(err, subscriber.ErrDuplicateEmail) {
+		apperrors.Abort(c, apperrors.NewConflictError(constants.ErrSubscriberEmailExists, err))
 		return
 	}
-
-	// Get subscriber with subscribed topics for response
-	subscriberWithTopics, topicNames, err := h.subscriberService.GetSubscriberByIDWithTopics(c.Request.Context(), subscriberModel.ID)
 	if err != nil {
-		// Fallback to basic response if getting topics fails
-		response := dtos.SubscriberResponse{
-			ID:               subscriberModel.ID,
-			Email:            subscriberModel.Email,
-			Name:             subscriberModel.Name,
-			IsActive:         subscriberModel.IsActive,
-			SubscribedTopics: req.SubscribedTopics,
-			CreatedAt:        subscriberModel.CreatedAt,
-			UpdatedAt:        subscriberModel.UpdatedAt,
-		}
-		c.JSON(http.StatusCreated, response)
+		abortWithError(c, err)
 		return
 	}
 
 	response := dtos.SubscriberResponse{
-		ID:               subscriberWithTopics.ID,
-		Email:            subscriberWithTopics.Email,
-		Name:             subscriberWithTopics.Name,
-		IsActive:         subscriberWithTopics.IsActive,
+		ID:               subscriberModel.ID,
+		Email:            subscriberModel.Email,
+		Name:             subscriberModel.Name,
+		IsActive:         subscriberModel.IsActive,
+		EmailStatus:      subscriberModel.EmailStatus,
+		Timezone:         subscriberModel.Timezone,
+		Locale:           subscriberModel.Locale,
+		Phone:            subscriberModel.Phone,
+		Channels:         daos.DecodeChannels(subscriberModel.Channels),
+		ReferralCode:     subscriberModel.ReferralCode,
+		ReferralCount:    subscriberModel.ReferralCount,
+		ReferredByID:     subscriberModel.ReferredByID,
+		Signup:           signupAttribution(subscriberModel),
 		SubscribedTopics: topicNames,
-		CreatedAt:        subscriberWithTopics.CreatedAt,
-		UpdatedAt:        subscriberWithTopics.UpdatedAt,
+		CreatedAt:        subscriberModel.CreatedAt,
+		UpdatedAt:        subscriberModel.UpdatedAt,
 	}
 
+	recordAudit(c, h.auditService, audit.ActionCreate, audit.EntitySubscriber, response.ID, nil, response)
+	h.eventBus.Emit(c.Request.Context(), events.SubscriberCreated, response)
+
 	c.JSON(http.StatusCreated, response)
 }
 
-// GetSubscriberByID retrieves a subscriber by ID
+// GetSubscriberByID retrieves a subscriber by ID, answering 304 when the client's copy is current
 func (h *SubscriberHandler) GetSubscriberByID(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
@@ -151,23 +256,22 @@ func (h *SubscriberHandler) GetSubscriberByID(c *gin.Context) {
 		return
 	}
 
-	subscriberModel, topicNames, err := h.subscriberService.GetSubscriberByIDWithTopics(c.Request.Context(), uint(id))
-	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrSubscriberNotFound})
+	shape, ok := bindShape(c, dtos.SubscriberResponse{}, subscriberIncludes)
+	if !ok {
 		return
 	}
 
-	response := dtos.SubscriberResponse{
-		ID:               subscriberModel.ID,
-		Email:            subscriberModel.Email,
-		Name:             subscriberModel.Name,
-		IsActive:         subscriberModel.IsActive,
-		SubscribedTopics: topicNames,
-		CreatedAt:        subscriberModel.CreatedAt,
-		UpdatedAt:        subscriberModel.UpdatedAt,
+	response, err := h.subscriberSnapshot(c.Request.Context(), uint(id))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrSubscriberNotFound})
+			return
+		}
+		abortWithError(c, err)
+		return
 	}
 
-	c.JSON(http.StatusOK, response)
+	respondShaped(c, shape, response, subscriberETag(response))
 }
 
 // UpdateSubscriber updates a subscriber
@@ -193,12 +297,48 @@ func (h *SubscriberHandler) UpdateSubscriber(c *gin.Context) {
 	if req.IsActive != nil {
 		updates["is_active"] = *req.IsActive
 	}
+	if req.Timezone != "" {
+		updates["timezone"] = req.Timezone
+	}
+	if req.Locale != "" {
+		updates["locale"] = locale.Normalize(req.Locale)
+	}
+	if req.Phone != "" {
+		updates["phone"] = req.Phone
+	}
+	if len(req.Channels) > 0 {
+		updates["channels"] = daos.EncodeChannels(req.Channels)
+	}
+
+	before, _ := h.subscriberSnapshot(c.Request.Context(), uint(id))
+	if !checkIfMatch(c, subscriberETag(before)) {
+		return
+	}
 
 	if err := h.subscriberService.UpdateSubscriberWithTopics(c.Request.Context(), uint(id), updates, req.SubscribedTopics); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		if errors.Is(err, subscriber.ErrTopicsNotFound) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrSubscribedTopicNotFound})
+			return
+		}
+		if errors.Is(err, subscriber.ErrTopicArchived) {
+			c.JSON(http.StatusConflict, gin.H{"error": constants.ErrTopicArchived, "details": err.Error()})
+			return
+		}
+		if errors.Is(err, subscriber.ErrDuplicateEmail) {
+			apperrors.Abort(c, apperrors.NewConflictError(constants.ErrSubscriberEmailExists, err))
+			return
+		}
+		abortWithError(c, err)
 		return
 	}
 
+	after, _ := h.subscriberSnapshot(c.Request.Context(), uint(id))
+	recordAudit(c, h.auditService, audit.ActionUpdate, audit.EntitySubscriber, uint(id), before, after)
+	if after != nil {
+		h.eventBus.Emit(c.Request.Context(), events.SubscriberUpdated, after)
+	}
+
+	setETag(c, subscriberETag(after))
 	c.JSON(http.StatusOK, gin.H{"message": constants.MsgSubscriberUpdatedSuccessfully})
 }
 
@@ -210,14 +350,176 @@ func (h *SubscriberHandler) DeleteSubscriber(c *gin.Context) {
 		return
 	}
 
+	before, _ := h.subscriberSnapshot(c.Request.Context(), uint(id))
+
 	if err := h.subscriberService.DeleteSubscriber(c.Request.Context(), uint(id)); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		abortWithError(c, err)
 		return
 	}
 
+	recordAudit(c, h.auditService, audit.ActionDelete, audit.EntitySubscriber, uint(id), before, nil)
+	h.eventBus.Emit(c.Request.Context(), events.SubscriberDeleted, gin.H{"subscriber_id": uint(id)})
+
 	c.JSON(http.StatusOK, gin.H{"message": constants.MsgSubscriberDeletedSuccessfully})
 }
 
+// RestoreSubscriber undoes the soft delete of a subscriber
+func (h *SubscriberHandler) RestoreSubscriber(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidSubscriberID})
+		return
+	}
+
+	if err := h.subscriberService.RestoreSubscriber(c.Request.Context(), uint(id)); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrSubscriberNotFound})
+			return
+		}
+		if errors.Is(err, subscriber.ErrDuplicateEmail) {
+			apperrors.Abort(c, apperrors.NewConflictError(constants.ErrSubscriberEmailExists, err))
+			return
+		}
+		abortWithError(c, err)
+		return
+	}
+
+	after, _ := h.subscriberSnapshot(c.Request.Context(), uint(id))
+	recordAudit(c, h.auditService, audit.ActionRestore, audit.EntitySubscriber, uint(id), nil, after)
+	if after != nil {
+		h.eventBus.Emit(c.Request.Context(), events.SubscriberRestored, after)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": constants.MsgSubscriberRestoredSuccessfully})
+}
+
+// GetDuplicateSubscribers lists groups of subscribers whose addresses only differ by case or a +tag
+func (h *SubscriberHandler) GetDuplicateSubscribers(c *gin.Context) {
+	var pagination dtos.PaginationRequest
+	if err := c.ShouldBindQuery(&pagination); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidPaginationParams})
+		return
+	}
+
+	page, pageSize := pagination.GetDefaults()
+	offset := pagination.CalculateOffset()
+
+	groups, total, err := h.subscriberService.GetDuplicateGroupsWithPagination(c.Request.Context(), offset, pageSize)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	response := make([]dtos.DuplicateSubscribersResponse, 0, len(groups))
+	for _, group := range groups {
+		item := dtos.DuplicateSubscribersResponse{NormalizedEmail: group.NormalizedEmail}
+		for _, sub := range group.Subscribers {
+			item.Subscribers = append(item.Subscribers, dtos.SubscriberResponse{
+				ID:            sub.ID,
+				Email:         sub.Email,
+				Name:          sub.Name,
+				IsActive:      sub.IsActive,
+				EmailStatus:   sub.EmailStatus,
+				Timezone:      sub.Timezone,
+				Locale:        sub.Locale,
+				Phone:         sub.Phone,
+				Channels:      daos.DecodeChannels(sub.Channels),
+				ReferralCode:  sub.ReferralCode,
+				ReferralCount: sub.ReferralCount,
+				ReferredByID:  sub.ReferredByID,
+				Signup:        signupAttribution(sub),
+				CreatedAt:     sub.CreatedAt,
+				UpdatedAt:     sub.UpdatedAt,
+			})
+		}
+		response = append(response, item)
+	}
+
+	paginationResponse := dtos.CreatePaginationResponse(page, pageSize, total)
+	c.JSON(http.StatusOK, dtos.PaginatedResponse[dtos.DuplicateSubscribersResponse]{
+		Data:       response,
+		Pagination: paginationResponse,
+	})
+}
+
+// MergeSubscribers moves subscriptions and email logs from the source subscriber to the target and deletes the source
+func (h *SubscriberHandler) MergeSubscribers(c *gin.Context) {
+	var req dtos.MergeSubscribersRequest
+	if !middleware.ValidateJSON(c, &req) {
+		return
+	}
+
+	sourceBefore, _ := h.subscriberSnapshot(c.Request.Context(), req.SourceID)
+	targetBefore, _ := h.subscriberSnapshot(c.Request.Context(), req.TargetID)
+
+	if err := h.subscriberService.MergeSubscribers(c.Request.Context(), req.SourceID, req.TargetID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrSubscriberNotFound})
+			return
+		}
+		if errors.Is(err, subscriber.ErrSelfMerge) {
+			apperrors.Abort(c, apperrors.NewValidationError(constants.ErrSelfMerge, err))
+			return
+		}
+		abortWithError(c, err)
+		return
+	}
+
+	after, _ := h.subscriberSnapshot(c.Request.Context(), req.TargetID)
+	recordAudit(c, h.auditService, audit.ActionDelete, audit.EntitySubscriber, req.SourceID, sourceBefore, nil)
+	recordAudit(c, h.auditService, audit.ActionUpdate, audit.EntitySubscriber, req.TargetID, targetBefore, after)
+	h.eventBus.Emit(c.Request.Context(), events.SubscriberDeleted, gin.H{"subscriber_id": req.SourceID, "merged_into": req.TargetID})
+	if after != nil {
+		h.eventBus.Emit(c.Request.Context(), events.SubscriberUpdated, after)
+	}
+
+	c.JSON(http.StatusOK, after)
+}
+
+// MigrateSubscribers moves or copies the subscribers of the topic in the path to another topic in one
+// transaction
+func (h *SubscriberHandler) MigrateSubscribers(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidTopicID})
+		return
+	}
+
+	var req dtos.MigrateSubscribersRequest
+	if !middleware.ValidateJSON(c, &req) {
+		return
+	}
+
+	result, err := h.subscriberService.MigrateSubscribers(c.Request.Context(), uint(id), req.TargetTopicID, req.Mode == "move")
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrTopicNotFound})
+		case errors.Is(err, subscriber.ErrTopicsNotFound):
+			c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrSubscribedTopicNotFound, "details": err.Error()})
+		case errors.Is(err, subscriber.ErrSameTopic):
+			c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidMigrationTarget})
+		case errors.Is(err, subscriber.ErrTopicArchived):
+			c.JSON(http.StatusConflict, gin.H{"error": constants.ErrTopicArchived, "details": err.Error()})
+		default:
+			abortWithError(c, err)
+		}
+		return
+	}
+
+	response := dtos.MigrateSubscribersResponse{
+		SourceTopicID:     uint(id),
+		TargetTopicID:     req.TargetTopicID,
+		Mode:              req.Mode,
+		Migrated:          result.Migrated,
+		AlreadySubscribed: result.AlreadySubscribed,
+		Removed:           result.Removed,
+	}
+	recordAudit(c, h.auditService, audit.ActionMigrate, audit.EntityTopic, uint(id), nil, response)
+
+	c.JSON(http.StatusOK, response)
+}
+
 // CreateSubscription creates a new subscription
 func (h *SubscriberHandler) CreateSubscription(c *gin.Context) {
 	var req dtos.CreateSubscriptionRequest
@@ -226,10 +528,35 @@ func (h *SubscriberHandler) CreateSubscription(c *gin.Context) {
 		return
 	}
 
-	if err := h.subscriberService.Subscribe(c.Request.Context(), req.SubscriberID, req.TopicID); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	before, _ := h.subscriberSnapshot(c.Request.Context(), req.SubscriberID)
+
+	created, err := h.subscriberService.Subscribe(c.Request.Context(), req.SubscriberID, req.TopicID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrSubscriberNotFound})
+		return
+	}
+	if errors.Is(err, subscriber.ErrTopicsNotFound) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrSubscribedTopicNotFound, "details": err.Error()})
 		return
 	}
+	if errors.Is(err, subscriber.ErrTopicArchived) {
+		c.JSON(http.StatusConflict, gin.H{"error": constants.ErrTopicArchived, "details": err.Error()})
+		return
+	}
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	// Subscribing again is not an error; nothing changed, so there is nothing to audit
+	if !created {
+		c.JSON(http.StatusOK, gin.H{"message": constants.MsgAlreadySubscribed})
+		return
+	}
+
+	// A new subscription changes the subscriber's topic list, so audit it as a subscriber update
+	after, _ := h.subscriberSnapshot(c.Request.Context(), req.SubscriberID)
+	recordAudit(c, h.auditService, audit.ActionUpdate, audit.EntitySubscriber, req.SubscriberID, before, after)
 
 	c.JSON(http.StatusCreated, gin.H{"message": constants.MsgSubscriptionCreatedSuccessfully})
 }
@@ -250,7 +577,7 @@ func (h *SubscriberHandler) GetSubscriptions(c *gin.Context) {
 
 		subscriptions, total, err := h.subscriberService.GetAllSubscriptionsWithPagination(c.Request.Context(), offset, pageSize)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			abortWithError(c, err)
 			return
 		}
 
@@ -260,6 +587,7 @@ func (h *SubscriberHandler) GetSubscriptions(c *gin.Context) {
 				ID:           sub.ID,
 				SubscriberID: sub.SubscriberID,
 				TopicID:      sub.TopicID,
+				Push:         sub.Push,
 				CreatedAt:    sub.CreatedAt,
 			})
 		}
@@ -275,7 +603,7 @@ func (h *SubscriberHandler) GetSubscriptions(c *gin.Context) {
 		// Use non-paginated response for backward compatibility
 		subscriptions, err := h.subscriberService.GetAllSubscriptions(c.Request.Context())
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			abortWithError(c, err)
 			return
 		}
 
@@ -285,6 +613,7 @@ func (h *SubscriberHandler) GetSubscriptions(c *gin.Context) {
 				ID:           sub.ID,
 				SubscriberID: sub.SubscriberID,
 				TopicID:      sub.TopicID,
+				Push:         sub.Push,
 				CreatedAt:    sub.CreatedAt,
 			})
 		}
@@ -303,7 +632,7 @@ func (h *SubscriberHandler) GetSubscriptionsBySubscriber(c *gin.Context) {
 
 	subscriptions, err := h.subscriberService.GetSubscriptionsBySubscriberID(c.Request.Context(), uint(subscriberID))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		abortWithError(c, err)
 		return
 	}
 
@@ -313,6 +642,7 @@ func (h *SubscriberHandler) GetSubscriptionsBySubscriber(c *gin.Context) {
 			ID:           sub.ID,
 			SubscriberID: sub.SubscriberID,
 			TopicID:      sub.TopicID,
+			Push:         sub.Push,
 			CreatedAt:    sub.CreatedAt,
 		})
 	}
@@ -330,7 +660,7 @@ func (h *SubscriberHandler) GetSubscriptionsByTopic(c *gin.Context) {
 
 	subscriptions, err := h.subscriberService.GetSubscriptionsByTopicID(c.Request.Context(), uint(topicID))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		abortWithError(c, err)
 		return
 	}
 
@@ -340,6 +670,7 @@ func (h *SubscriberHandler) GetSubscriptionsByTopic(c *gin.Context) {
 			ID:           sub.ID,
 			SubscriberID: sub.SubscriberID,
 			TopicID:      sub.TopicID,
+			Push:         sub.Push,
 			CreatedAt:    sub.CreatedAt,
 		})
 	}
@@ -356,13 +687,45 @@ func (h *SubscriberHandler) DeleteSubscription(c *gin.Context) {
 	}
 
 	if err := h.subscriberService.Unsubscribe(c.Request.Context(), uint(id)); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		abortWithError(c, err)
 		return
 	}
 
+	recordAudit(c, h.auditService, audit.ActionDelete, audit.EntitySubscription, uint(id), nil, nil)
+	h.eventBus.Emit(c.Request.Context(), events.SubscriberUnsubscribed, gin.H{"subscription_id": uint(id)})
+
 	c.JSON(http.StatusOK, gin.H{"message": constants.MsgSubscriptionDeletedSuccessfully})
 }
 
+// UpdateSubscription sets a subscription's preferences, such as whether the topic's content is also pushed
+func (h *SubscriberHandler) UpdateSubscription(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidSubscriptionID})
+		return
+	}
+
+	var req dtos.UpdateSubscriptionRequest
+	if !middleware.ValidateJSON(c, &req) {
+		return
+	}
+
+	updates := map[string]interface{}{"push": *req.Push}
+	found, err := h.subscriberService.UpdateSubscription(c.Request.Context(), uint(id), updates)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	if !found {
+		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrSubscriptionNotFound})
+		return
+	}
+
+	recordAudit(c, h.auditService, audit.ActionUpdate, audit.EntitySubscription, uint(id), nil, updates)
+
+	c.JSON(http.StatusOK, gin.H{"message": constants.MsgSubscriptionUpdatedSuccessfully})
+}
+
 // BulkCreateSubscribers creates multiple subscribers at once
 func (h *SubscriberHandler) BulkCreateSubscribers(c *gin.Context) {
 	var req dtos.BulkCreateSubscribersRequest
@@ -373,7 +736,7 @@ func (h *SubscriberHandler) BulkCreateSubscribers(c *gin.Context) {
 	startTime := time.Now()
 	var subscribers []*subscriber.Subscriber
 	var topicNamesList [][]string
-	var errors []dtos.BulkError
+	var rowErrors []dtos.BulkError
 
 	// Prepare subscriber models
 	for _, createReq := range req.Subscribers {
@@ -381,57 +744,68 @@ func (h *SubscriberHandler) BulkCreateSubscribers(c *gin.Context) {
 			Email:    createReq.Email,
 			Name:     createReq.Name,
 			IsActive: true,
+			Timezone: subscriberTimezone(c, createReq.Timezone),
+			Locale:   subscriberLocale(c, createReq.Locale),
+			Phone:    createReq.Phone,
+			Channels: daos.EncodeChannels(createReq.Channels),
 		}
+		recordSignup(c, subscriberModel, daos.SignupSourceBulk, createReq)
 		subscribers = append(subscribers, subscriberModel)
 		topicNamesList = append(topicNamesList, createReq.SubscribedTopics)
 	}
 
-	// Perform bulk create
-	successIDs, bulkErrors := h.subscriberService.BulkCreateSubscribers(c.Request.Context(), subscribers, topicNamesList)
+	// Perform bulk create in a single transaction
+	results, err := h.subscriberService.BulkCreateSubscribers(c.Request.Context(), subscribers, topicNamesList)
+	if errors.Is(err, subscriber.ErrDuplicateEmail) {
+		// Another request created one of the addresses while the batch was being inserted
+		apperrors.Abort(c, apperrors.NewConflictError(constants.ErrSubscriberEmailExists, err))
+		return
+	}
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
 
-	// Convert errors to response format
-	for i, err := range bulkErrors {
-		if err != nil {
-			var email string
-			if i < len(req.Subscribers) {
-				email = req.Subscribers[i].Email
-			}
-			errors = append(errors, dtos.BulkError{
-				Index: i,
-				Email: email,
-				Error: err.Error(),
+	var successResponses []dtos.SubscriberResponse
+	for _, result := range results {
+		if result.Err != nil {
+			rowErrors = append(rowErrors, dtos.BulkError{
+				Index: result.Index,
+				Email: req.Subscribers[result.Index].Email,
+				Error: result.Err.Error(),
 			})
+			continue
 		}
-	}
 
-	// Build success response
-	var successResponses []dtos.SubscriberResponse
-	for i, id := range successIDs {
-		if i < len(subscribers) {
-			sub := subscribers[i]
-			if sub.ID == id {
-				var topics []string
-				if i < len(topicNamesList) {
-					topics = topicNamesList[i]
-				}
-				successResponses = append(successResponses, dtos.SubscriberResponse{
-					ID:               sub.ID,
-					Email:            sub.Email,
-					Name:             sub.Name,
-					IsActive:         sub.IsActive,
-					SubscribedTopics: topics,
-					CreatedAt:        sub.CreatedAt,
-					UpdatedAt:        sub.UpdatedAt,
-				})
-			}
+		sub := result.Subscriber
+		created := dtos.SubscriberResponse{
+			ID:               sub.ID,
+			Email:            sub.Email,
+			Name:             sub.Name,
+			IsActive:         sub.IsActive,
+			EmailStatus:      sub.EmailStatus,
+			Timezone:         sub.Timezone,
+			Locale:           sub.Locale,
+			Phone:            sub.Phone,
+			Channels:         daos.DecodeChannels(sub.Channels),
+			ReferralCode:     sub.ReferralCode,
+			ReferralCount:    sub.ReferralCount,
+			ReferredByID:     sub.ReferredByID,
+			Signup:           signupAttribution(sub),
+			SubscribedTopics: result.TopicNames,
+			CreatedAt:        sub.CreatedAt,
+			UpdatedAt:        sub.UpdatedAt,
 		}
+		recordAudit(c, h.auditService, audit.ActionCreate, audit.EntitySubscriber, sub.ID, nil, created)
+		h.eventBus.Emit(c.Request.Context(), events.SubscriberCreated, created)
+		successResponses = append(successResponses, created)
 	}
 
 	endTime := time.Now()
 	summary := dtos.BulkOperationSummary{
 		Total:       len(req.Subscribers),
-		Success:     len(successIDs),
-		Errors:      len(errors),
+		Success:     len(successResponses),
+		Errors:      len(rowErrors),
 		StartedAt:   startTime,
 		CompletedAt: endTime,
 		Duration:    endTime.Sub(startTime).String(),
@@ -439,14 +813,14 @@ func (h *SubscriberHandler) BulkCreateSubscribers(c *gin.Context) {
 
 	response := dtos.BulkCreateSubscribersResponse{
 		Success: successResponses,
-		Errors:  errors,
+		Errors:  rowErrors,
 		Summary: summary,
 	}
 
 	statusCode := http.StatusCreated
-	if len(errors) > 0 && len(successIDs) == 0 {
+	if len(rowErrors) > 0 && len(successResponses) == 0 {
 		statusCode = http.StatusBadRequest
-	} else if len(errors) > 0 {
+	} else if len(rowErrors) > 0 {
 		statusCode = http.StatusMultiStatus
 	}
 
@@ -485,9 +859,24 @@ func (h *SubscriberHandler) BulkUpdateSubscribers(c *gin.Context) {
 		bulkUpdates = append(bulkUpdates, bulkUpdate)
 	}
 
+	befores := make(map[uint]*dtos.SubscriberResponse, len(bulkUpdates))
+	for _, update := range bulkUpdates {
+		befores[update.ID], _ = h.subscriberSnapshot(c.Request.Context(), update.ID)
+	}
+
 	// Perform bulk update
 	bulkErrors := h.subscriberService.BulkUpdateSubscribers(c.Request.Context(), bulkUpdates)
 
+	for i, update := range bulkUpdates {
+		if before := befores[update.ID]; before != nil {
+			after, _ := h.subscriberSnapshot(c.Request.Context(), update.ID)
+			recordAudit(c, h.auditService, audit.ActionUpdate, audit.EntitySubscriber, update.ID, before, after)
+			if after != nil && (i >= len(bulkErrors) || bulkErrors[i] == nil) {
+				h.eventBus.Emit(c.Request.Context(), events.SubscriberUpdated, after)
+			}
+		}
+	}
+
 	// Convert errors to response format
 	for i, err := range bulkErrors {
 		if err != nil {
@@ -537,11 +926,27 @@ func (h *SubscriberHandler) BulkDeleteSubscribers(c *gin.Context) {
 	}
 
 	startTime := time.Now()
-	var errors []dtos.BulkError
+	var rowErrors []dtos.BulkError
+
+	befores := make(map[uint]*dtos.SubscriberResponse, len(req.IDs))
+	for _, id := range req.IDs {
+		befores[id], _ = h.subscriberSnapshot(c.Request.Context(), id)
+	}
 
 	// Perform bulk delete
 	bulkErrors := h.subscriberService.BulkDeleteSubscribers(c.Request.Context(), req.IDs)
 
+	for _, id := range req.IDs {
+		before := befores[id]
+		if before == nil {
+			continue
+		}
+		if _, err := h.subscriberSnapshot(c.Request.Context(), id); errors.Is(err, gorm.ErrRecordNotFound) {
+			recordAudit(c, h.auditService, audit.ActionDelete, audit.EntitySubscriber, id, before, nil)
+			h.eventBus.Emit(c.Request.Context(), events.SubscriberDeleted, gin.H{"subscriber_id": id})
+		}
+	}
+
 	// Convert errors to response format
 	for i, err := range bulkErrors {
 		if err != nil {
@@ -549,7 +954,7 @@ func (h *SubscriberHandler) BulkDeleteSubscribers(c *gin.Context) {
 			if i < len(req.IDs) {
 				id = req.IDs[i]
 			}
-			errors = append(errors, dtos.BulkError{
+			rowErrors = append(rowErrors, dtos.BulkError{
 				Index: i,
 				ID:    id,
 				Error: err.Error(),
@@ -560,8 +965,8 @@ func (h *SubscriberHandler) BulkDeleteSubscribers(c *gin.Context) {
 	endTime := time.Now()
 	summary := dtos.BulkOperationSummary{
 		Total:       len(req.IDs),
-		Success:     len(req.IDs) - len(errors),
-		Errors:      len(errors),
+		Success:     len(req.IDs) - len(rowErrors),
+		Errors:      len(rowErrors),
 		StartedAt:   startTime,
 		CompletedAt: endTime,
 		Duration:    endTime.Sub(startTime).String(),
@@ -569,16 +974,168 @@ func (h *SubscriberHandler) BulkDeleteSubscribers(c *gin.Context) {
 
 	response := dtos.BulkResponse{
 		Success: gin.H{"message": "Bulk delete completed"},
-		Errors:  errors,
+		Errors:  rowErrors,
 		Summary: summary,
 	}
 
 	statusCode := http.StatusOK
-	if len(errors) > 0 && summary.Success == 0 {
+	if len(rowErrors) > 0 && summary.Success == 0 {
 		statusCode = http.StatusBadRequest
-	} else if len(errors) > 0 {
+	} else if len(rowErrors) > 0 {
 		statusCode = http.StatusMultiStatus
 	}
 
 	c.JSON(statusCode, response)
 }
+
+// subscriberSnapshot loads a subscriber with topics, as GET /subscribers/:id returns it and audit entries record
+// it. A missing subscriber is gorm.ErrRecordNotFound.
+func (h *SubscriberHandler) subscriberSnapshot(ctx context.Context, id uint) (*dtos.SubscriberResponse, error) {
+	subscriberModel, topicNames, err := h.subscriberService.GetSubscriberByIDWithTopics(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dtos.SubscriberResponse{
+		ID:               subscriberModel.ID,
+		Email:            subscriberModel.Email,
+		Name:             subscriberModel.Name,
+		IsActive:         subscriberModel.IsActive,
+		EmailStatus:      subscriberModel.EmailStatus,
+		Timezone:         subscriberModel.Timezone,
+		Locale:           subscriberModel.Locale,
+		Phone:            subscriberModel.Phone,
+		Channels:         daos.DecodeChannels(subscriberModel.Channels),
+		ReferralCode:     subscriberModel.ReferralCode,
+		ReferralCount:    subscriberModel.ReferralCount,
+		ReferredByID:     subscriberModel.ReferredByID,
+		Signup:           signupAttribution(subscriberModel),
+		SubscribedTopics: topicNames,
+		CreatedAt:        subscriberModel.CreatedAt,
+		UpdatedAt:        subscriberModel.UpdatedAt,
+	}, nil
+}
+
+// subscriberETag returns the ETag of a subscriber snapshot, or "" for a nil one. Changing only the topics
+// leaves the subscriber's row untouched, so they are part of the version.
+func subscriberETag(snapshot *dtos.SubscriberResponse) string {
+	if snapshot == nil {
+		return ""
+	}
+	return resourceETag(snapshot.ID, snapshot.UpdatedAt, snapshot.SubscribedTopics)
+}
+
+// subscriberTimezone returns the time zone given in the request body, or else one detected from the client's
+// Time-Zone header (e.g. a signup form sending Intl.DateTimeFormat().resolvedOptions().timeZone)
+func subscriberTimezone(c *gin.Context, timezone string) string {
+	if timezone != "" {
+		return timezone
+	}
+
+	detected := strings.TrimSpace(c.GetHeader("Time-Zone"))
+	if detected == "" || strings.EqualFold(detected, "local") {
+		return ""
+	}
+	if _, err := time.LoadLocation(detected); err != nil {
+		return ""
+	}
+	return detected
+}
+
+// subscriberLocale returns the locale given in the request body, or else the client's preferred language from
+// its Accept-Language header, normalized either way
+func subscriberLocale(c *gin.Context, tag string) string {
+	if tag != "" {
+		return locale.Normalize(tag)
+	}
+	return locale.FromAcceptLanguage(c.GetHeader("Accept-Language"))
+}
+
+// countryHeaders are set by CDNs and edge platforms to the country of the client's IP address
+var countryHeaders = []string{"CF-IPCountry", "CloudFront-Viewer-Country", "X-Vercel-IP-Country", "X-AppEngine-Country"}
+
+// recordSignup fills in where a new subscriber signed up from: the attribution given in the request body, the
+// API key the request was made with, and the referring page and country detected from the request's headers
+func recordSignup(c *gin.Context, sub *subscriber.Subscriber, source string, req dtos.CreateSubscriberRequest) {
+	sub.SignupSource = source
+	sub.SignupForm = strings.TrimSpace(req.Form)
+	sub.SignupReferrer = req.Referrer
+	if sub.SignupReferrer == "" && len(c.GetHeader("Referer")) <= 500 {
+		sub.SignupReferrer = c.GetHeader("Referer")
+	}
+	sub.SignupCountry = strings.ToUpper(req.Country)
+	for _, header := range countryHeaders {
+		if sub.SignupCountry != "" {
+			break
+		}
+		sub.SignupCountry = detectedCountry(c.GetHeader(header))
+	}
+	if req.UTM != nil {
+		sub.UTM = daos.UTM{Source: req.UTM.Source, Medium: req.UTM.Medium, Campaign: req.UTM.Campaign}
+	}
+	if id, ok := c.Get(constants.ContextKeyAPIKeyID); ok {
+		if apiKeyID, ok := id.(uint); ok {
+			sub.SignupAPIKeyID = &apiKeyID
+		}
+	}
+}
+
+// detectedCountry returns a country header's ISO 3166-1 alpha-2 code, or "" for values that aren't one, such as
+// Cloudflare's XX (unknown) and T1 (Tor)
+func detectedCountry(value string) string {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	if len(value) != 2 || value == "XX" || value == "T1" {
+		return ""
+	}
+	for _, r := range value {
+		if r < 'A' || r > 'Z' {
+			return ""
+		}
+	}
+	return value
+}
+
+// signupAttribution describes where a subscriber signed up from in responses
+func signupAttribution(sub *subscriber.Subscriber) dtos.SignupAttribution {
+	return dtos.SignupAttribution{
+		Source:   sub.SignupSource,
+		Form:     sub.SignupForm,
+		APIKeyID: sub.SignupAPIKeyID,
+		Referrer: sub.SignupReferrer,
+		Country:  sub.SignupCountry,
+		UTM:      dtos.UTM{Source: sub.UTM.Source, Medium: sub.UTM.Medium, Campaign: sub.UTM.Campaign},
+	}
+}
+
+// signupFilter converts signup filter parameters, answering 400 for timestamps that aren't RFC3339
+func signupFilter(c *gin.Context, query dtos.SignupFilterQuery) (daos.SignupFilter, bool) {
+	filter := daos.SignupFilter{
+		Source:       query.SignupSource,
+		Form:         query.SignupForm,
+		UTMSource:    query.UTMSource,
+		UTMMedium:    query.UTMMedium,
+		UTMCampaign:  query.UTMCampaign,
+		Country:      strings.ToUpper(query.Country),
+		APIKeyID:     query.APIKeyID,
+		ReferredByID: query.ReferredByID,
+	}
+	for _, bound := range []struct {
+		name  string
+		value string
+		dest  **time.Time
+	}{
+		{"signed_up_after", query.SignedUpAfter, &filter.After},
+		{"signed_up_before", query.SignedUpBefore, &filter.Before},
+	} {
+		if bound.value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, bound.value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidSignupFilter, "details": bound.name + " must be RFC3339"})
+			return filter, false
+		}
+		*bound.dest = &parsed
+	}
+	return filter, true
+}
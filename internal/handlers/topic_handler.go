@@ -1,60 +1,95 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 
 	"newsletter-service/internal/constants"
+	"newsletter-service/internal/daos"
 	"newsletter-service/internal/dtos"
+	apperrors "newsletter-service/internal/errors"
+	"newsletter-service/internal/providers/templates"
+	"newsletter-service/internal/router/middleware"
+	"newsletter-service/internal/services/audit"
 	"newsletter-service/internal/services/topic"
 )
 
 type TopicHandler struct {
 	topicService topic.Service
+	auditService audit.Service
 }
 
-func NewTopicHandler(topicService topic.Service) *TopicHandler {
+func NewTopicHandler(topicService topic.Service, auditService audit.Service) *TopicHandler {
 	return &TopicHandler{
 		topicService: topicService,
+		auditService: auditService,
 	}
 }
 
 // GetTopics retrieves all topics
 func (h *TopicHandler) GetTopics(c *gin.Context) {
-	var pagination dtos.PaginationRequest
+	var pagination dtos.ListQuery
 	if err := c.ShouldBindQuery(&pagination); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidPaginationParams})
 		return
 	}
+	shape, ok := bindShape(c, dtos.TopicResponse{}, nil)
+	if !ok {
+		return
+	}
+
+	ids, err := pagination.ParseIDs()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidIDs, "details": err.Error()})
+		return
+	}
+	if ids != nil {
+		// ?ids= looks up those topics instead of a page; unknown or deleted IDs are skipped rather than a 404
+		topics, err := h.topicService.GetTopicsByIDs(c.Request.Context(), ids)
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+		data, ok := h.topicList(c, shape, orderByIDs(topics, ids, func(topicModel *topic.Topic) uint { return topicModel.ID }))
+		if !ok {
+			return
+		}
+		if data == nil {
+			data = []interface{}{}
+		}
+		c.JSON(http.StatusOK, data)
+		return
+	}
 
-	// Check if pagination parameters were provided
-	if pagination.Page > 0 || pagination.PageSize > 0 {
+	// Check if pagination parameters were provided; soft-deleted rows are only listed paginated
+	if pagination.Page > 0 || pagination.PageSize > 0 || pagination.IncludeDeleted {
 		// Use paginated response
 		page, pageSize := pagination.GetDefaults()
 		offset := pagination.CalculateOffset()
 
-		topics, total, err := h.topicService.GetAllTopicsWithPagination(c.Request.Context(), offset, pageSize)
+		listPage := h.topicService.GetAllTopicsWithPagination
+		if pagination.IncludeDeleted {
+			listPage = h.topicService.GetAllTopicsIncludingDeletedWithPagination
+		}
+
+		topics, total, err := listPage(c.Request.Context(), offset, pageSize)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			abortWithError(c, err)
 			return
 		}
 
-		var response []dtos.TopicResponse
-		for _, topic := range topics {
-			response = append(response, dtos.TopicResponse{
-				ID:          topic.ID,
-				Name:        topic.Name,
-				Description: topic.Description,
-				CreatedAt:   topic.CreatedAt,
-				UpdatedAt:   topic.UpdatedAt,
-			})
+		data, ok := h.topicList(c, shape, topics)
+		if !ok {
+			return
 		}
 
 		paginationResponse := dtos.CreatePaginationResponse(page, pageSize, total)
-		paginatedResponse := dtos.PaginatedResponse[dtos.TopicResponse]{
-			Data:       response,
+		paginatedResponse := dtos.PaginatedResponse[interface{}]{
+			Data:       data,
 			Pagination: paginationResponse,
 		}
 
@@ -63,47 +98,74 @@ func (h *TopicHandler) GetTopics(c *gin.Context) {
 		// Use non-paginated response for backward compatibility
 		topics, err := h.topicService.GetAllTopics(c.Request.Context())
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			abortWithError(c, err)
 			return
 		}
 
-		var response []dtos.TopicResponse
-		for _, topic := range topics {
-			response = append(response, dtos.TopicResponse{
-				ID:          topic.ID,
-				Name:        topic.Name,
-				Description: topic.Description,
-				CreatedAt:   topic.CreatedAt,
-				UpdatedAt:   topic.UpdatedAt,
-			})
+		data, ok := h.topicList(c, shape, topics)
+		if !ok {
+			return
 		}
+		c.JSON(http.StatusOK, data)
+	}
+}
 
-		c.JSON(http.StatusOK, response)
+// topicList builds the items of a topic list with the fields the request selected
+func (h *TopicHandler) topicList(c *gin.Context, shape dtos.Shape, topics []*topic.Topic) ([]interface{}, bool) {
+	var response []dtos.TopicResponse
+	for _, topicModel := range topics {
+		response = append(response, *toTopicResponse(topicModel))
+	}
+	data, err := dtos.SelectAll(shape, response)
+	if err != nil {
+		abortWithError(c, err)
+		return nil, false
 	}
+	return data, true
 }
 
 // CreateTopic creates a new topic
 func (h *TopicHandler) CreateTopic(c *gin.Context) {
 	var req dtos.CreateTopicRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidRequestBody})
+	if !middleware.ValidateJSON(c, &req) {
 		return
 	}
 
 	topicModel := &topic.Topic{
 		Name:        req.Name,
+		Slug:        req.Slug,
 		Description: req.Description,
+		Branding:    toBrandingModel(req.Branding),
+		UTM:         toUTMModel(req.UTM),
 	}
 
 	if err := h.topicService.CreateTopic(c.Request.Context(), topicModel); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		if errors.Is(err, topic.ErrDuplicateName) {
+			apperrors.Abort(c, apperrors.NewConflictError(constants.ErrTopicNameExists, err))
+			return
+		}
+		if errors.Is(err, topic.ErrDuplicateSlug) {
+			apperrors.Abort(c, apperrors.NewConflictError(constants.ErrTopicSlugExists, err))
+			return
+		}
+		if errors.Is(err, topic.ErrInvalidSlug) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidTopicSlug})
+			return
+		}
+		abortWithError(c, err)
 		return
 	}
 
+	recordAudit(c, h.auditService, audit.ActionCreate, audit.EntityTopic, topicModel.ID, nil, topicModel)
+
 	response := dtos.TopicResponse{
 		ID:          topicModel.ID,
 		Name:        topicModel.Name,
+		Slug:        topicModel.Slug,
 		Description: topicModel.Description,
+		Branding:    toBrandingResponse(topicModel.Branding),
+		UTM:         toUTMResponse(topicModel.UTM),
+		ArchivedAt:  topicModel.ArchivedAt,
 		CreatedAt:   topicModel.CreatedAt,
 		UpdatedAt:   topicModel.UpdatedAt,
 	}
@@ -111,7 +173,7 @@ func (h *TopicHandler) CreateTopic(c *gin.Context) {
 	c.JSON(http.StatusCreated, response)
 }
 
-// GetTopicByID retrieves a topic by ID
+// GetTopicByID retrieves a topic by ID, answering 304 when the client's copy is current
 func (h *TopicHandler) GetTopicByID(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
@@ -119,21 +181,22 @@ func (h *TopicHandler) GetTopicByID(c *gin.Context) {
 		return
 	}
 
-	topicModel, err := h.topicService.GetTopicByID(c.Request.Context(), uint(id))
-	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrTopicNotFound})
+	shape, ok := bindShape(c, dtos.TopicResponse{}, nil)
+	if !ok {
 		return
 	}
 
-	response := dtos.TopicResponse{
-		ID:          topicModel.ID,
-		Name:        topicModel.Name,
-		Description: topicModel.Description,
-		CreatedAt:   topicModel.CreatedAt,
-		UpdatedAt:   topicModel.UpdatedAt,
+	topicModel, err := h.topicService.GetTopicByID(c.Request.Context(), uint(id))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrTopicNotFound})
+			return
+		}
+		abortWithError(c, err)
+		return
 	}
 
-	c.JSON(http.StatusOK, response)
+	respondShaped(c, shape, toTopicResponse(topicModel), topicETag(topicModel))
 }
 
 // UpdateTopic updates a topic
@@ -145,8 +208,7 @@ func (h *TopicHandler) UpdateTopic(c *gin.Context) {
 	}
 
 	var req dtos.UpdateTopicRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidRequestBody})
+	if !middleware.ValidateJSON(c, &req) {
 		return
 	}
 
@@ -154,18 +216,169 @@ func (h *TopicHandler) UpdateTopic(c *gin.Context) {
 	if req.Name != "" {
 		updates["name"] = req.Name
 	}
+	if req.Slug != "" {
+		updates["slug"] = req.Slug
+	}
 	if req.Description != "" {
 		updates["description"] = req.Description
 	}
+	if req.Branding != nil {
+		brandingUpdates(updates, req.Branding)
+	}
+	if req.UTM != nil {
+		utmUpdates(updates, req.UTM)
+	}
+
+	before, _ := h.topicService.GetTopicByID(c.Request.Context(), uint(id))
+	if !checkIfMatch(c, topicETag(before)) {
+		return
+	}
 
 	if err := h.topicService.UpdateTopic(c.Request.Context(), uint(id), updates); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		if errors.Is(err, topic.ErrDuplicateName) {
+			apperrors.Abort(c, apperrors.NewConflictError(constants.ErrTopicNameExists, err))
+			return
+		}
+		if errors.Is(err, topic.ErrDuplicateSlug) {
+			apperrors.Abort(c, apperrors.NewConflictError(constants.ErrTopicSlugExists, err))
+			return
+		}
+		if errors.Is(err, topic.ErrInvalidSlug) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidTopicSlug})
+			return
+		}
+		abortWithError(c, err)
 		return
 	}
 
+	after, _ := h.topicService.GetTopicByID(c.Request.Context(), uint(id))
+	recordAudit(c, h.auditService, audit.ActionUpdate, audit.EntityTopic, uint(id), before, after)
+
+	setETag(c, topicETag(after))
 	c.JSON(http.StatusOK, gin.H{"message": constants.MsgTopicUpdatedSuccessfully})
 }
 
+// GetTopicTheme returns the theme set on a topic and the one its emails render with
+func (h *TopicHandler) GetTopicTheme(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidTopicID})
+		return
+	}
+
+	topicModel, branding, err := h.topicService.GetTopicBranding(c.Request.Context(), uint(id))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrTopicNotFound})
+			return
+		}
+		abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toThemeResponseFor(topicModel, branding))
+}
+
+// UpdateTopicTheme replaces the theme of a topic's emails; fields left out use the organization's theme. The
+// topic's sender identity is kept.
+func (h *TopicHandler) UpdateTopicTheme(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidTopicID})
+		return
+	}
+
+	var req dtos.Theme
+	if !middleware.ValidateJSON(c, &req) {
+		return
+	}
+
+	before, err := h.topicService.GetTopicByID(c.Request.Context(), uint(id))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrTopicNotFound})
+			return
+		}
+		abortWithError(c, err)
+		return
+	}
+
+	updates := make(map[string]interface{})
+	themeUpdates(updates, req)
+	if err := h.topicService.UpdateTopic(c.Request.Context(), uint(id), updates); err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	topicModel, branding, err := h.topicService.GetTopicBranding(c.Request.Context(), uint(id))
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	recordAudit(c, h.auditService, audit.ActionUpdate, audit.EntityTopic, uint(id), before, topicModel)
+
+	c.JSON(http.StatusOK, toThemeResponseFor(topicModel, branding))
+}
+
+// toThemeResponseFor pairs a topic's own theme with the effective one: the merged branding with the template's
+// defaults filled in, as an email renders it
+func toThemeResponseFor(topicModel *topic.Topic, branding daos.Branding) dtos.ThemeResponse {
+	resolved := (&templates.Branding{
+		LogoURL:             branding.LogoURL,
+		PrimaryColor:        branding.PrimaryColor,
+		DarkLogoURL:         branding.DarkLogoURL,
+		BackgroundColor:     branding.BackgroundColor,
+		TextColor:           branding.TextColor,
+		FontFamily:          branding.FontFamily,
+		DarkPrimaryColor:    branding.DarkPrimaryColor,
+		DarkBackgroundColor: branding.DarkBackgroundColor,
+		DarkTextColor:       branding.DarkTextColor,
+	}).Resolved()
+
+	return dtos.ThemeResponse{
+		TopicID: topicModel.ID,
+		Theme:   toThemeResponse(topicModel.Branding),
+		Effective: dtos.Theme{
+			LogoURL:             resolved.LogoURL,
+			DarkLogoURL:         resolved.DarkLogoURL,
+			PrimaryColor:        resolved.PrimaryColor,
+			BackgroundColor:     resolved.BackgroundColor,
+			TextColor:           resolved.TextColor,
+			FontFamily:          resolved.FontFamily,
+			DarkPrimaryColor:    resolved.DarkPrimaryColor,
+			DarkBackgroundColor: resolved.DarkBackgroundColor,
+			DarkTextColor:       resolved.DarkTextColor,
+		},
+	}
+}
+
+// topicETag returns the ETag of a topic, or "" for a nil one
+func topicETag(topicModel *topic.Topic) string {
+	if topicModel == nil {
+		return ""
+	}
+	return resourceETag(topicModel.ID, topicModel.UpdatedAt)
+}
+
+// toTopicResponse is a topic as GET /topics/:id returns it, nil for a nil topic
+func toTopicResponse(topicModel *topic.Topic) *dtos.TopicResponse {
+	if topicModel == nil {
+		return nil
+	}
+	return &dtos.TopicResponse{
+		ID:          topicModel.ID,
+		Name:        topicModel.Name,
+		Slug:        topicModel.Slug,
+		Description: topicModel.Description,
+		Branding:    toBrandingResponse(topicModel.Branding),
+		UTM:         toUTMResponse(topicModel.UTM),
+		ArchivedAt:  topicModel.ArchivedAt,
+		CreatedAt:   topicModel.CreatedAt,
+		UpdatedAt:   topicModel.UpdatedAt,
+		DeletedAt:   deletedAt(topicModel.DeletedAt),
+	}
+}
+
 // DeleteTopic deletes a topic
 func (h *TopicHandler) DeleteTopic(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
@@ -174,10 +387,121 @@ func (h *TopicHandler) DeleteTopic(c *gin.Context) {
 		return
 	}
 
-	if err := h.topicService.DeleteTopic(c.Request.Context(), uint(id)); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	var query dtos.DeleteTopicQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidRequestBody, "details": err.Error()})
+		return
+	}
+
+	before, _ := h.topicService.GetTopicByID(c.Request.Context(), uint(id))
+
+	summary, err := h.topicService.DeleteTopic(c.Request.Context(), uint(id), query.Force)
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrTopicNotFound})
+		case errors.Is(err, topic.ErrTopicInUse):
+			c.JSON(http.StatusConflict, gin.H{"error": constants.ErrTopicInUse, "affected": summary})
+		default:
+			abortWithError(c, err)
+		}
+		return
+	}
+
+	recordAudit(c, h.auditService, audit.ActionDelete, audit.EntityTopic, uint(id), before, summary)
+
+	c.JSON(http.StatusOK, gin.H{"message": constants.MsgTopicDeletedSuccessfully, "affected": summary})
+}
+
+// RestoreTopic undoes the soft delete of a topic
+func (h *TopicHandler) RestoreTopic(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidTopicID})
+		return
+	}
+
+	if err := h.topicService.RestoreTopic(c.Request.Context(), uint(id)); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrTopicNotFound})
+			return
+		}
+		abortWithError(c, err)
+		return
+	}
+
+	after, _ := h.topicService.GetTopicByID(c.Request.Context(), uint(id))
+	recordAudit(c, h.auditService, audit.ActionRestore, audit.EntityTopic, uint(id), nil, after)
+
+	c.JSON(http.StatusOK, gin.H{"message": constants.MsgTopicRestoredSuccessfully})
+}
+
+// ArchiveTopic stops a topic taking new content and subscriptions while keeping its history
+func (h *TopicHandler) ArchiveTopic(c *gin.Context) {
+	h.setArchived(c, true)
+}
+
+// UnarchiveTopic lets an archived topic take new content and subscriptions again
+func (h *TopicHandler) UnarchiveTopic(c *gin.Context) {
+	h.setArchived(c, false)
+}
+
+func (h *TopicHandler) setArchived(c *gin.Context, archived bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidTopicID})
+		return
+	}
+
+	before, _ := h.topicService.GetTopicByID(c.Request.Context(), uint(id))
+
+	action, message := audit.ActionArchive, constants.MsgTopicArchivedSuccessfully
+	setArchived := h.topicService.ArchiveTopic
+	if !archived {
+		action, message = audit.ActionUpdate, constants.MsgTopicUnarchivedSuccessfully
+		setArchived = h.topicService.UnarchiveTopic
+	}
+	if err := setArchived(c.Request.Context(), uint(id)); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrTopicNotFound})
+			return
+		}
+		abortWithError(c, err)
+		return
+	}
+
+	after, _ := h.topicService.GetTopicByID(c.Request.Context(), uint(id))
+	recordAudit(c, h.auditService, action, audit.EntityTopic, uint(id), before, after)
+
+	c.JSON(http.StatusOK, gin.H{"message": message})
+}
+
+// GetTopicStats returns subscriber growth, churn and last-send performance for a topic
+func (h *TopicHandler) GetTopicStats(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidTopicID})
+		return
+	}
+
+	days := 30
+	if raw := c.Query("days"); raw != "" {
+		days, err = strconv.Atoi(raw)
+		if err != nil || days < 1 || days > 365 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidStatsDays})
+			return
+		}
+	}
+
+	stats, err := h.topicService.GetTopicStats(c.Request.Context(), uint(id), days)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrTopicNotFound})
+			return
+		}
+		abortWithError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": constants.MsgTopicDeletedSuccessfully})
+	c.JSON(http.StatusOK, stats)
 }
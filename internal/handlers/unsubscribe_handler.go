@@ -7,16 +7,19 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"newsletter-service/internal/constants"
+	"newsletter-service/internal/events"
 	"newsletter-service/internal/services/subscriber"
 )
 
 type UnsubscribeHandler struct {
 	subscriberService subscriber.Service
+	eventBus          *events.Bus
 }
 
-func NewUnsubscribeHandler(subscriberService subscriber.Service) *UnsubscribeHandler {
+func NewUnsubscribeHandler(subscriberService subscriber.Service, eventBus *events.Bus) *UnsubscribeHandler {
 	return &UnsubscribeHandler{
 		subscriberService: subscriberService,
+		eventBus:          eventBus,
 	}
 }
 
@@ -126,6 +129,8 @@ func (h *UnsubscribeHandler) UnsubscribeGet(c *gin.Context) {
 
 	html += `</div>
         
+        <p>Getting too many emails? <a href="/preferences?subscriber=` + subscriberIDStr + `">Choose how often you hear from each topic</a> instead.</p>
+
         <p>Are you sure you want to unsubscribe from all newsletters?</p>
         
         <form method="POST" action="/unsubscribe" style="display: inline;">
@@ -171,10 +176,17 @@ func (h *UnsubscribeHandler) UnsubscribePost(c *gin.Context) {
 	}
 
 	if err := h.subscriberService.UpdateSubscriber(c.Request.Context(), uint(subscriberID), updates); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to unsubscribe"})
+		abortWithError(c, err)
 		return
 	}
 
+	if sub, err := h.subscriberService.GetSubscriberByID(c.Request.Context(), uint(subscriberID)); err == nil {
+		h.eventBus.Emit(c.Request.Context(), events.SubscriberUnsubscribed, gin.H{
+			"subscriber_id": sub.ID,
+			"email":         sub.Email,
+		})
+	}
+
 	// Render success page
 	html := `
 <!DOCTYPE html>
@@ -244,7 +256,7 @@ func (h *UnsubscribeHandler) Resubscribe(c *gin.Context) {
 	}
 
 	if err := h.subscriberService.UpdateSubscriber(c.Request.Context(), uint(subscriberID), updates); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		abortWithError(c, err)
 		return
 	}
 
@@ -0,0 +1,218 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"newsletter-service/internal/constants"
+	"newsletter-service/internal/dtos"
+	"newsletter-service/internal/router/middleware"
+	"newsletter-service/internal/services/audit"
+	"newsletter-service/internal/services/webhook"
+)
+
+type WebhookHandler struct {
+	webhookService webhook.Service
+	auditService   audit.Service
+}
+
+func NewWebhookHandler(webhookService webhook.Service, auditService audit.Service) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+		auditService:   auditService,
+	}
+}
+
+// GetWebhooks retrieves webhooks with pagination
+func (h *WebhookHandler) GetWebhooks(c *gin.Context) {
+	var pagination dtos.PaginationRequest
+	if err := c.ShouldBindQuery(&pagination); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidPaginationParams})
+		return
+	}
+
+	page, pageSize := pagination.GetDefaults()
+	offset := pagination.CalculateOffset()
+
+	webhooks, total, err := h.webhookService.GetWebhooksWithPagination(c.Request.Context(), offset, pageSize)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	response := make([]dtos.WebhookResponse, 0, len(webhooks))
+	for _, wh := range webhooks {
+		response = append(response, toWebhookResponse(wh))
+	}
+
+	paginationResponse := dtos.CreatePaginationResponse(page, pageSize, total)
+	c.JSON(http.StatusOK, dtos.PaginatedResponse[dtos.WebhookResponse]{
+		Data:       response,
+		Pagination: paginationResponse,
+	})
+}
+
+// CreateWebhook registers an endpoint, returning its signing secret once
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	var req dtos.CreateWebhookRequest
+	if !middleware.ValidateJSON(c, &req) {
+		return
+	}
+
+	wh := &webhook.Webhook{
+		URL:         req.URL,
+		Events:      webhook.EncodeEvents(req.Events),
+		Description: req.Description,
+	}
+
+	secret, err := h.webhookService.CreateWebhook(c.Request.Context(), wh)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	recordAudit(c, h.auditService, audit.ActionCreate, audit.EntityWebhook, wh.ID, nil, wh)
+
+	c.JSON(http.StatusCreated, dtos.CreateWebhookResponse{
+		WebhookResponse: toWebhookResponse(wh),
+		Secret:          secret,
+	})
+}
+
+// GetWebhookByID retrieves a webhook by ID
+func (h *WebhookHandler) GetWebhookByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidWebhookID})
+		return
+	}
+
+	wh, err := h.webhookService.GetWebhookByID(c.Request.Context(), uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrWebhookNotFound})
+		return
+	}
+
+	c.JSON(http.StatusOK, toWebhookResponse(wh))
+}
+
+// UpdateWebhook changes a webhook's URL, event filter, description or active flag
+func (h *WebhookHandler) UpdateWebhook(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidWebhookID})
+		return
+	}
+
+	var req dtos.UpdateWebhookRequest
+	if !middleware.ValidateJSON(c, &req) {
+		return
+	}
+
+	before, err := h.webhookService.GetWebhookByID(c.Request.Context(), uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrWebhookNotFound})
+		return
+	}
+
+	updates := make(map[string]interface{})
+	if req.URL != "" {
+		updates["url"] = req.URL
+	}
+	if len(req.Events) > 0 {
+		updates["events"] = webhook.EncodeEvents(req.Events)
+	}
+	if req.Description != nil {
+		updates["description"] = *req.Description
+	}
+	if req.IsActive != nil {
+		updates["is_active"] = *req.IsActive
+	}
+
+	if err := h.webhookService.UpdateWebhook(c.Request.Context(), uint(id), updates); err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	after, err := h.webhookService.GetWebhookByID(c.Request.Context(), uint(id))
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	recordAudit(c, h.auditService, audit.ActionUpdate, audit.EntityWebhook, uint(id), before, after)
+
+	c.JSON(http.StatusOK, toWebhookResponse(after))
+}
+
+// DeleteWebhook removes a webhook; its pending deliveries are abandoned
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidWebhookID})
+		return
+	}
+
+	before, err := h.webhookService.GetWebhookByID(c.Request.Context(), uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrWebhookNotFound})
+		return
+	}
+
+	if err := h.webhookService.DeleteWebhook(c.Request.Context(), uint(id)); err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	recordAudit(c, h.auditService, audit.ActionDelete, audit.EntityWebhook, uint(id), before, nil)
+
+	c.JSON(http.StatusOK, gin.H{"message": constants.MsgWebhookDeletedSuccessfully})
+}
+
+// GetWebhookDeliveries retrieves the delivery log of a webhook with pagination
+func (h *WebhookHandler) GetWebhookDeliveries(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidWebhookID})
+		return
+	}
+
+	var pagination dtos.PaginationRequest
+	if err := c.ShouldBindQuery(&pagination); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidPaginationParams})
+		return
+	}
+
+	if _, err := h.webhookService.GetWebhookByID(c.Request.Context(), uint(id)); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrWebhookNotFound})
+		return
+	}
+
+	page, pageSize := pagination.GetDefaults()
+	offset := pagination.CalculateOffset()
+
+	deliveries, total, err := h.webhookService.GetDeliveriesWithPagination(c.Request.Context(), uint(id), offset, pageSize)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	paginationResponse := dtos.CreatePaginationResponse(page, pageSize, total)
+	c.JSON(http.StatusOK, dtos.PaginatedResponse[*webhook.Delivery]{
+		Data:       deliveries,
+		Pagination: paginationResponse,
+	})
+}
+
+func toWebhookResponse(wh *webhook.Webhook) dtos.WebhookResponse {
+	return dtos.WebhookResponse{
+		ID:          wh.ID,
+		URL:         wh.URL,
+		Events:      webhook.DecodeEvents(wh.Events),
+		Description: wh.Description,
+		IsActive:    wh.IsActive,
+		CreatedAt:   wh.CreatedAt,
+		UpdatedAt:   wh.UpdatedAt,
+	}
+}
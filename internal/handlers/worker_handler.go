@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"newsletter-service/internal/constants"
+	"newsletter-service/internal/providers/templates"
+	"newsletter-service/internal/schedulers"
+	"newsletter-service/internal/services/emailtemplate"
+	"newsletter-service/internal/services/notification"
+)
+
+// WorkerHandler serves the worker's admin API for controlling its scheduled jobs
+type WorkerHandler struct {
+	cron                 *schedulers.Cron
+	notificationService  notification.Service
+	emailTemplateService emailtemplate.Service
+	drain                func() // Stops scheduling; the worker exits once running jobs finish
+}
+
+func NewWorkerHandler(cron *schedulers.Cron, notificationService notification.Service, emailTemplateService emailtemplate.Service, drain func()) *WorkerHandler {
+	return &WorkerHandler{
+		cron:                 cron,
+		notificationService:  notificationService,
+		emailTemplateService: emailTemplateService,
+		drain:                drain,
+	}
+}
+
+// GetJobs reports every job's schedule, next run and last run result
+func (h *WorkerHandler) GetJobs(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"paused": h.cron.Paused(),
+		"jobs":   h.cron.Status(),
+	})
+}
+
+// Pause skips scheduled runs until resumed; running jobs finish and triggered runs still happen
+func (h *WorkerHandler) Pause(c *gin.Context) {
+	h.cron.Pause()
+	c.JSON(http.StatusOK, gin.H{"message": constants.MsgWorkerPaused})
+}
+
+// Resume lets scheduled runs happen again
+func (h *WorkerHandler) Resume(c *gin.Context) {
+	h.cron.Resume()
+	c.JSON(http.StatusOK, gin.H{"message": constants.MsgWorkerResumed})
+}
+
+// TriggerJob runs a job immediately, or as soon as its current run finishes
+func (h *WorkerHandler) TriggerJob(c *gin.Context) {
+	if err := h.cron.Trigger(c.Param("name")); err != nil {
+		if errors.Is(err, schedulers.ErrJobNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrWorkerJobNotFound})
+			return
+		}
+		abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"message": constants.MsgWorkerJobTriggered})
+}
+
+// GetConcurrency reports how many emails the worker sends at once and, with adaptive concurrency, how the
+// limit has moved
+func (h *WorkerHandler) GetConcurrency(c *gin.Context) {
+	c.JSON(http.StatusOK, h.notificationService.GetConcurrencyStats())
+}
+
+// GetTimeouts reports how many provider calls ran past the send timeout and how many job runs were cancelled
+// for running past theirs
+func (h *WorkerHandler) GetTimeouts(c *gin.Context) {
+	jobs := make(map[string]int64)
+	for _, job := range h.cron.Status() {
+		jobs[job.Name] = job.Timeouts
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"sends": h.notificationService.GetSendTimeoutStats(),
+		"jobs":  jobs,
+	})
+}
+
+// Drain stops scheduling new runs and shuts the worker down once running jobs finish
+func (h *WorkerHandler) Drain(c *gin.Context) {
+	h.drain()
+	c.JSON(http.StatusAccepted, gin.H{"message": constants.MsgWorkerDraining})
+}
+
+// ReloadTemplates re-reads the email templates the worker renders sends with, without waiting for the next
+// refresh
+func (h *WorkerHandler) ReloadTemplates(c *gin.Context) {
+	status, err := h.emailTemplateService.Reload(c.Request.Context())
+	if err != nil {
+		if errors.Is(err, templates.ErrInvalidTemplate) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidEmailTemplate, "details": err.Error()})
+			return
+		}
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, status)
+}
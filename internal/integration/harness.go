@@ -0,0 +1,165 @@
+// Package integration runs the send pipeline end to end against throwaway Postgres and Redis containers:
+// content is published, the worker's scheduler picks it up and sends through a recording provider, and the
+// recipients and email logs are checked. Its tests need a Docker daemon and are built only with the integration
+// tag: `go test -tags integration ./internal/integration`.
+package integration
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/ory/dockertest/v3"
+	"github.com/ory/dockertest/v3/docker"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+
+	"newsletter-service/internal/config"
+	"newsletter-service/internal/connections"
+	"newsletter-service/migration"
+)
+
+// Images match the ones scripts/local.sh runs
+const (
+	postgresImage = "postgres"
+	postgresTag   = "15-alpine"
+	redisImage    = "redis"
+	redisTag      = "7-alpine"
+)
+
+// containerExpiry is when Docker removes the containers even if Close never runs, e.g. after a crash
+const containerExpiry = 10 * time.Minute
+
+// Harness holds the containers of one run and the connections to them
+type Harness struct {
+	DB    *gorm.DB
+	Redis redis.UniversalClient
+
+	pool      *dockertest.Pool
+	resources []*dockertest.Resource
+}
+
+// Start starts Postgres and Redis, waits until they accept connections and migrates the database. Call Close
+// when done, including after an error.
+func Start() (*Harness, error) {
+	pool, err := dockertest.NewPool("")
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to Docker: %w", err)
+	}
+	if err := pool.Client.Ping(); err != nil {
+		return nil, fmt.Errorf("failed to reach Docker: %w", err)
+	}
+	pool.MaxWait = 2 * time.Minute
+	h := &Harness{pool: pool}
+
+	postgres, err := h.run(postgresImage, postgresTag, []string{
+		"POSTGRES_USER=postgres",
+		"POSTGRES_PASSWORD=postgres",
+		"POSTGRES_DB=newsletter_test",
+	})
+	if err != nil {
+		return h, err
+	}
+	dbHost, dbPort, err := hostPort(postgres, "5432/tcp")
+	if err != nil {
+		return h, err
+	}
+	dbConfig := config.DatabaseConfig{
+		Host:     dbHost,
+		Port:     dbPort,
+		User:     "postgres",
+		Password: "postgres",
+		Name:     "newsletter_test",
+		SSLMode:  "disable",
+	}
+	if err := pool.Retry(func() error {
+		h.DB, err = connections.NewPostgresDB(dbConfig)
+		return err
+	}); err != nil {
+		return h, fmt.Errorf("postgres did not become ready: %w", err)
+	}
+	// Every fixture insert would otherwise be logged
+	h.DB.Logger = h.DB.Logger.LogMode(gormlogger.Silent)
+
+	sqlDB, err := h.DB.DB()
+	if err != nil {
+		return h, fmt.Errorf("failed to get underlying sql.DB: %w", err)
+	}
+	if err := migration.Run(sqlDB, migration.CommandUp); err != nil {
+		return h, fmt.Errorf("failed to migrate: %w", err)
+	}
+
+	redisResource, err := h.run(redisImage, redisTag, nil)
+	if err != nil {
+		return h, err
+	}
+	redisHost, redisPort, err := hostPort(redisResource, "6379/tcp")
+	if err != nil {
+		return h, err
+	}
+	if err := pool.Retry(func() error {
+		h.Redis, err = connections.NewRedisClient(config.RedisConfig{Host: redisHost, Port: redisPort})
+		return err
+	}); err != nil {
+		return h, fmt.Errorf("redis did not become ready: %w", err)
+	}
+
+	return h, nil
+}
+
+// run starts a container that Docker removes once it stops
+func (h *Harness) run(repository, tag string, env []string) (*dockertest.Resource, error) {
+	resource, err := h.pool.RunWithOptions(&dockertest.RunOptions{
+		Repository: repository,
+		Tag:        tag,
+		Env:        env,
+	}, func(hc *docker.HostConfig) {
+		hc.AutoRemove = true
+		hc.RestartPolicy = docker.RestartPolicy{Name: "no"}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start %s:%s: %w", repository, tag, err)
+	}
+	h.resources = append(h.resources, resource)
+	if err := resource.Expire(uint(containerExpiry.Seconds())); err != nil {
+		log.Printf("Warning: failed to set expiry of %s:%s: %v", repository, tag, err)
+	}
+	return resource, nil
+}
+
+// Close closes the connections and removes the containers. It does nothing on a nil Harness, which Start
+// returns when Docker can't be reached.
+func (h *Harness) Close() {
+	if h == nil {
+		return
+	}
+	if h.Redis != nil {
+		h.Redis.Close()
+	}
+	if h.DB != nil {
+		if sqlDB, err := h.DB.DB(); err == nil {
+			sqlDB.Close()
+		}
+	}
+	for _, resource := range h.resources {
+		if err := h.pool.Purge(resource); err != nil {
+			log.Printf("Warning: failed to remove container %s: %v", resource.Container.Name, err)
+		}
+	}
+}
+
+// hostPort returns where a container port is published on the host
+func hostPort(resource *dockertest.Resource, port string) (string, int, error) {
+	host, portText, err := net.SplitHostPort(resource.GetHostPort(port))
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to find published port %s: %w", port, err)
+	}
+	portNumber, err := strconv.Atoi(portText)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid published port %q: %w", portText, err)
+	}
+	return host, portNumber, nil
+}
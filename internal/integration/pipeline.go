@@ -0,0 +1,207 @@
+package integration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"newsletter-service/internal/cache"
+	"newsletter-service/internal/config"
+	"newsletter-service/internal/constants"
+	"newsletter-service/internal/providers/providertest"
+	"newsletter-service/internal/schedulers"
+	"newsletter-service/internal/services/content"
+	"newsletter-service/internal/services/notification"
+	"newsletter-service/internal/services/subscriber"
+	"newsletter-service/internal/services/topic"
+	"newsletter-service/internal/tenant"
+)
+
+// PipelineOptions sets the audience of a pipeline run
+type PipelineOptions struct {
+	OrganizationID uint
+	Subscribers    int // Active subscribers of the published topic
+	Inactive       int // Subscribers of the topic who were deactivated and must not be sent to
+	Failing        int // Active subscribers whose sends the provider rejects
+	Bystanders     int // Subscribers of another topic only
+}
+
+// DefaultPipelineOptions is a run that finishes in a few seconds once the containers are up
+func DefaultPipelineOptions() PipelineOptions {
+	return PipelineOptions{OrganizationID: 1, Subscribers: 50, Inactive: 5, Failing: 3, Bystanders: 10}
+}
+
+// PipelineResult is what a run sent and logged
+type PipelineResult struct {
+	ContentID  uint
+	Delivered  int // Recipients the provider accepted emails for
+	SentLogs   int64
+	FailedLogs int64
+	Duration   time.Duration
+}
+
+// RunPipeline creates a topic and its audience, publishes a content, runs the worker's pending notifications
+// job and checks that exactly the active subscribers were sent to and logged. The job is then run again to
+// check that nothing is sent twice. Services are wired as the worker wires them, with the recording provider
+// in place of the configured ones.
+func RunPipeline(ctx context.Context, h *Harness, opts PipelineOptions) (*PipelineResult, error) {
+	if opts.Failing > opts.Subscribers {
+		return nil, fmt.Errorf("failing subscribers (%d) can't outnumber active ones (%d)", opts.Failing, opts.Subscribers)
+	}
+	orgCtx := tenant.WithOrganization(ctx, opts.OrganizationID)
+
+	readCache := cache.NewRedis(h.Redis)
+	topicService := topic.NewServiceWithCache(topic.NewRepository(h.DB), nil, readCache)
+	contentService := content.NewService(content.NewRepository(h.DB))
+	subscriberService := subscriber.NewServiceWithCache(subscriber.NewRepository(h.DB), topicService, nil, config.SubscribersConfig{}, readCache)
+	notificationService := notification.NewService(h.DB, contentService, subscriberService)
+
+	provider := providertest.NewRecordingProvider("", nil)
+	scheduler := schedulers.NewNotificationSchedulerWithProvider(contentService, notificationService, provider, nil)
+	locker := schedulers.NewRedisLocker(h.Redis)
+	job := schedulers.LockedJob(locker, schedulers.JobPendingNotifications, schedulers.DefaultLockTTL, scheduler.ProcessPendingNotifications)
+
+	// Audience
+	runTag := time.Now().Format("150405.000000")
+	published := &topic.Topic{Name: "Integration " + runTag}
+	other := &topic.Topic{Name: "Integration bystanders " + runTag}
+	for _, t := range []*topic.Topic{published, other} {
+		if err := topicService.CreateTopic(orgCtx, t); err != nil {
+			return nil, fmt.Errorf("failed to create topic: %w", err)
+		}
+	}
+
+	addSubscriber := func(kind string, i int, topicName string) (*subscriber.Subscriber, error) {
+		s := &subscriber.Subscriber{
+			Name:  fmt.Sprintf("%s %d", kind, i),
+			Email: fmt.Sprintf("%s%d.%s@example.com", kind, i, runTag),
+		}
+		if _, err := subscriberService.CreateSubscriberWithTopics(orgCtx, s, []string{topicName}); err != nil {
+			return nil, fmt.Errorf("failed to create %s subscriber: %w", kind, err)
+		}
+		return s, nil
+	}
+
+	expected := make(map[string]bool)
+	for i := 0; i < opts.Subscribers; i++ {
+		s, err := addSubscriber("active", i, published.Name)
+		if err != nil {
+			return nil, err
+		}
+		if i < opts.Failing {
+			provider.FailFor(s.Email, errors.New("550 mailbox unavailable"))
+			continue
+		}
+		expected[s.Email] = true
+	}
+	for i := 0; i < opts.Inactive; i++ {
+		s, err := addSubscriber("inactive", i, published.Name)
+		if err != nil {
+			return nil, err
+		}
+		// Subscribers are created active, so deactivate them as the API would
+		if err := subscriberService.UpdateSubscriber(orgCtx, s.ID, map[string]interface{}{"is_active": false}); err != nil {
+			return nil, fmt.Errorf("failed to deactivate subscriber: %w", err)
+		}
+	}
+	for i := 0; i < opts.Bystanders; i++ {
+		if _, err := addSubscriber("bystander", i, other.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	// Publish
+	issue := &content.Content{TopicID: published.ID, Title: "Integration issue " + runTag, Body: "Sent by the integration harness."}
+	if err := contentService.CreateContent(orgCtx, issue); err != nil {
+		return nil, fmt.Errorf("failed to create content: %w", err)
+	}
+	if err := contentService.PublishContent(orgCtx, issue.ID); err != nil {
+		return nil, fmt.Errorf("failed to publish content: %w", err)
+	}
+
+	// The worker lists pending content across organizations, so the job runs without one
+	started := time.Now()
+	if err := job(ctx); err != nil {
+		return nil, fmt.Errorf("pending notifications job failed: %w", err)
+	}
+	result := &PipelineResult{ContentID: issue.ID, Duration: time.Since(started)}
+
+	var problems []string
+	delivered := make(map[string]int)
+	for _, sent := range provider.Sent() {
+		delivered[sent.Notification.To]++
+	}
+	result.Delivered = len(delivered)
+	for email, count := range delivered {
+		switch {
+		case !expected[email]:
+			problems = append(problems, fmt.Sprintf("sent to %s, who should not receive the content", email))
+		case count > 1:
+			problems = append(problems, fmt.Sprintf("sent to %s %d times", email, count))
+		}
+	}
+	if result.Delivered != len(expected) {
+		problems = append(problems, fmt.Sprintf("sent to %d recipients, want %d", result.Delivered, len(expected)))
+	}
+
+	sentLogs, failedLogs, err := countEmailLogs(orgCtx, h, issue.ID)
+	if err != nil {
+		return result, err
+	}
+	result.SentLogs, result.FailedLogs = sentLogs, failedLogs
+	if result.SentLogs != int64(len(expected)) {
+		problems = append(problems, fmt.Sprintf("%d sent email logs, want %d", result.SentLogs, len(expected)))
+	}
+	if result.FailedLogs != int64(opts.Failing) {
+		problems = append(problems, fmt.Sprintf("%d failed email logs, want %d", result.FailedLogs, opts.Failing))
+	}
+
+	sentContent, err := contentService.GetContentByID(orgCtx, issue.ID)
+	if err != nil {
+		return result, fmt.Errorf("failed to reload content: %w", err)
+	}
+	if !sentContent.NotificationsSent {
+		problems = append(problems, "content is not marked as sent")
+	}
+
+	// A second run must find nothing pending
+	sentBefore := len(provider.Sent())
+	if err := job(ctx); err != nil {
+		return result, fmt.Errorf("second pending notifications job failed: %w", err)
+	}
+	if resent := len(provider.Sent()) - sentBefore; resent > 0 {
+		problems = append(problems, fmt.Sprintf("second run sent %d more emails", resent))
+	}
+
+	if len(problems) > 0 {
+		return result, fmt.Errorf("pipeline check failed:\n  %s", strings.Join(problems, "\n  "))
+	}
+	return result, nil
+}
+
+// countEmailLogs counts the content's sent and failed email logs
+func countEmailLogs(ctx context.Context, h *Harness, contentID uint) (sent, failed int64, err error) {
+	var rows []struct {
+		Status string
+		Count  int64
+	}
+	err = h.DB.WithContext(ctx).Model(&notification.EmailLog{}).
+		Select("status, COUNT(*) AS count").
+		Where("content_id = ?", contentID).
+		Group("status").
+		Scan(&rows).Error
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to count email logs: %w", err)
+	}
+	for _, row := range rows {
+		switch row.Status {
+		case constants.StatusSent:
+			sent = row.Count
+		case constants.StatusFailed:
+			failed = row.Count
+		}
+	}
+	return sent, failed, nil
+}
@@ -0,0 +1,50 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"log"
+	"os"
+	"testing"
+)
+
+// harness is shared by every test in the package; its containers start once per run
+var harness *Harness
+
+func TestMain(m *testing.M) {
+	var err error
+	harness, err = Start()
+	if err != nil {
+		harness.Close()
+		log.Printf("Failed to start integration harness: %v", err)
+		os.Exit(1)
+	}
+	code := m.Run()
+	harness.Close()
+	os.Exit(code)
+}
+
+// TestPipeline publishes content to a fresh audience per case and checks the worker sends it exactly to the
+// active subscribers, logs every send and failure, and sends nothing on a second run
+func TestPipeline(t *testing.T) {
+	tests := []struct {
+		name string
+		opts PipelineOptions
+	}{
+		{name: "default audience", opts: DefaultPipelineOptions()},
+		{name: "no failures", opts: PipelineOptions{OrganizationID: 1, Subscribers: 20}},
+		{name: "large audience", opts: PipelineOptions{OrganizationID: 1, Subscribers: 500, Inactive: 20, Failing: 20, Bystanders: 50}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := RunPipeline(context.Background(), harness, tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Logf("content %d sent to %d recipients in %s (%d sent and %d failed email logs)",
+				result.ContentID, result.Delivered, result.Duration, result.SentLogs, result.FailedLogs)
+		})
+	}
+}
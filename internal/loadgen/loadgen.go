@@ -0,0 +1,283 @@
+// Package loadgen measures the send pipeline: it sends contents to a generated audience through the in-memory
+// recording provider and reports throughput, allocations and database queries per email. Numbers from it guide
+// the worker's concurrency and batching settings; cmd/loadgen runs it against the configured database.
+package loadgen
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"strconv"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+
+	"newsletter-service/internal/config"
+	"newsletter-service/internal/daos"
+	"newsletter-service/internal/providers/providertest"
+	"newsletter-service/internal/services/content"
+	"newsletter-service/internal/services/notification"
+	"newsletter-service/internal/services/subscriber"
+	"newsletter-service/internal/tenant"
+)
+
+// Options sets the size and shape of a load test
+type Options struct {
+	OrganizationID uint
+	Subscribers    int           // Audience of every content sent
+	Runs           int           // Contents sent one after the other to the same audience
+	Concurrency    int           // Worker max_async_process; 0 uses the worker default
+	Latency        time.Duration // Time the mock provider takes per email
+	BatchSize      int           // Rows per INSERT while creating the audience
+}
+
+// DefaultOptions sends three contents to 1000 subscribers with the worker's default concurrency
+func DefaultOptions() Options {
+	return Options{
+		OrganizationID: 1,
+		Subscribers:    1000,
+		Runs:           3,
+		Latency:        5 * time.Millisecond,
+		BatchSize:      500,
+	}
+}
+
+// Report is the outcome of sending one content
+type Report struct {
+	Run             int
+	Emails          int // Emails the provider accepted
+	Duration        time.Duration
+	EmailsPerSecond float64
+	AllocsPerEmail  float64
+	BytesPerEmail   float64
+	QueriesPerEmail float64
+}
+
+func (r Report) String() string {
+	return fmt.Sprintf("run %d: %d emails in %s, %.0f emails/s, %.0f allocs/email, %.0f B/email, %.2f queries/email",
+		r.Run, r.Emails, r.Duration.Round(time.Millisecond), r.EmailsPerSecond, r.AllocsPerEmail, r.BytesPerEmail, r.QueriesPerEmail)
+}
+
+// Run creates the audience, sends opts.Runs contents to it and removes everything it created
+func Run(ctx context.Context, db *gorm.DB, opts Options) ([]Report, error) {
+	audience, err := NewAudience(ctx, db, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer audience.Cleanup()
+
+	reports := make([]Report, 0, opts.Runs)
+	for run := 1; run <= opts.Runs; run++ {
+		report, err := audience.Send(run)
+		if err != nil {
+			return reports, err
+		}
+		reports = append(reports, *report)
+	}
+	return reports, nil
+}
+
+// Benchmark sends a content to the audience per iteration, for use in a benchmark function. ns/op and
+// allocs/op include creating each content; the per-email metrics it adds cover the send alone.
+func Benchmark(db *gorm.DB, opts Options) func(b *testing.B) {
+	return func(b *testing.B) {
+		if err := benchmark(b, db, opts); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// RunBenchmark runs Benchmark outside of `go test`, where a failing benchmark can't call b.Fatal
+func RunBenchmark(db *gorm.DB, opts Options) (testing.BenchmarkResult, error) {
+	var failed error
+	result := testing.Benchmark(func(b *testing.B) {
+		if failed == nil {
+			failed = benchmark(b, db, opts)
+		}
+	})
+	return result, failed
+}
+
+func benchmark(b *testing.B, db *gorm.DB, opts Options) error {
+	audience, err := NewAudience(context.Background(), db, opts)
+	if err != nil {
+		return err
+	}
+	defer audience.Cleanup()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	var total Report
+	for i := 0; i < b.N; i++ {
+		report, err := audience.Send(i + 1)
+		if err != nil {
+			return err
+		}
+		total.Emails += report.Emails
+		total.Duration += report.Duration
+		total.AllocsPerEmail += report.AllocsPerEmail * float64(report.Emails)
+		total.QueriesPerEmail += report.QueriesPerEmail * float64(report.Emails)
+	}
+	b.StopTimer()
+
+	if total.Emails > 0 {
+		b.ReportMetric(float64(total.Emails)/total.Duration.Seconds(), "emails/s")
+		b.ReportMetric(total.AllocsPerEmail/float64(total.Emails), "allocs/email")
+		b.ReportMetric(total.QueriesPerEmail/float64(total.Emails), "queries/email")
+	}
+	return nil
+}
+
+// Audience is a topic with generated subscribers, and the services that send to it
+type Audience struct {
+	ctx      context.Context
+	db       *gorm.DB
+	opts     Options
+	runTag   string
+	topic    *daos.Topic
+	size     int
+	provider *providertest.RecordingProvider
+	sender   notification.Service
+}
+
+// NewAudience creates a topic with opts.Subscribers active subscribers
+func NewAudience(ctx context.Context, db *gorm.DB, opts Options) (*Audience, error) {
+	if opts.OrganizationID == 0 {
+		return nil, errors.New("an organization is required")
+	}
+	if opts.Subscribers <= 0 {
+		return nil, errors.New("at least one subscriber is required")
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultOptions().BatchSize
+	}
+	if err := db.Use(queryCounter); err != nil && !errors.Is(err, gorm.ErrRegistered) {
+		return nil, fmt.Errorf("failed to register query counter: %w", err)
+	}
+
+	a := &Audience{
+		ctx:    tenant.WithOrganization(ctx, opts.OrganizationID),
+		db:     db,
+		opts:   opts,
+		runTag: strconv.FormatInt(time.Now().UnixNano(), 36),
+	}
+	a.topic = &daos.Topic{Name: "Load test " + a.runTag}
+	if err := db.WithContext(a.ctx).Create(a.topic).Error; err != nil {
+		return nil, fmt.Errorf("failed to create topic: %w", err)
+	}
+
+	subscribers := make([]*daos.Subscriber, 0, opts.Subscribers)
+	for i := 0; i < opts.Subscribers; i++ {
+		email := fmt.Sprintf("load%d.%s@example.com", i, a.runTag)
+		subscribers = append(subscribers, &daos.Subscriber{
+			Name:            fmt.Sprintf("Load %d", i),
+			Email:           email,
+			NormalizedEmail: subscriber.NormalizeEmail(email),
+			IsActive:        true,
+			Channels:        "email",
+			EmailStatus:     "valid",
+		})
+	}
+	if err := db.WithContext(a.ctx).CreateInBatches(subscribers, opts.BatchSize).Error; err != nil {
+		a.Cleanup()
+		return nil, fmt.Errorf("failed to create subscribers: %w", err)
+	}
+	subscriptions := make([]*daos.Subscription, 0, len(subscribers))
+	for _, s := range subscribers {
+		subscriptions = append(subscriptions, &daos.Subscription{SubscriberID: s.ID, TopicID: a.topic.ID})
+	}
+	if err := db.WithContext(a.ctx).CreateInBatches(subscriptions, opts.BatchSize).Error; err != nil {
+		a.Cleanup()
+		return nil, fmt.Errorf("failed to create subscriptions: %w", err)
+	}
+	a.size = len(subscribers)
+
+	// Reads go straight to Postgres, as they do in a worker without Redis
+	contentService := content.NewService(content.NewRepository(db))
+	subscriberService := subscriber.NewService(subscriber.NewRepository(db))
+	a.sender = notification.NewServiceWithWorkerConfig(db, contentService, subscriberService, config.WorkerConfig{MaxAsyncProcess: opts.Concurrency})
+	a.provider = providertest.NewRecordingProvider("loadgen", nil)
+	a.provider.SetLatency(opts.Latency)
+	return a, nil
+}
+
+// Send publishes a content to the audience and sends it, measuring only the send
+func (a *Audience) Send(run int) (*Report, error) {
+	now := time.Now().UTC()
+	c := &daos.Content{
+		TopicID:       a.topic.ID,
+		Title:         fmt.Sprintf("Load test %s run %d", a.runTag, run),
+		Body:          "A load test issue with a little **Markdown** in it.",
+		IsPublished:   true,
+		PublishedAt:   &now,
+		Channels:      "email",
+		ApprovalState: daos.ApprovalStatePublished,
+	}
+	if err := a.db.WithContext(a.ctx).Create(c).Error; err != nil {
+		return nil, fmt.Errorf("failed to create content: %w", err)
+	}
+	a.provider.Reset()
+
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	queriesBefore := queryCounter.count.Load()
+	started := time.Now()
+
+	err := a.sender.SendNotificationsByContentIDWithProvider(a.ctx, c.ID, a.provider)
+
+	duration := time.Since(started)
+	queries := queryCounter.count.Load() - queriesBefore
+	runtime.ReadMemStats(&after)
+	if err != nil {
+		return nil, fmt.Errorf("send failed: %w", err)
+	}
+
+	emails := len(a.provider.Sent())
+	if emails != a.size {
+		return nil, fmt.Errorf("sent %d emails to an audience of %d", emails, a.size)
+	}
+	perEmail := func(total float64) float64 { return total / float64(emails) }
+	return &Report{
+		Run:             run,
+		Emails:          emails,
+		Duration:        duration,
+		EmailsPerSecond: float64(emails) / duration.Seconds(),
+		AllocsPerEmail:  perEmail(float64(after.Mallocs - before.Mallocs)),
+		BytesPerEmail:   perEmail(float64(after.TotalAlloc - before.TotalAlloc)),
+		QueriesPerEmail: perEmail(float64(queries)),
+	}, nil
+}
+
+// Cleanup deletes the audience, its contents and their email logs
+func (a *Audience) Cleanup() {
+	db := a.db.WithContext(a.ctx).Unscoped()
+	var subscriberIDs []uint
+	if err := db.Model(&daos.Subscription{}).Where("topic_id = ?", a.topic.ID).Pluck("subscriber_id", &subscriberIDs).Error; err != nil {
+		fmt.Printf("Warning: failed to list load test subscribers: %v\n", err)
+	}
+	contentIDs := db.Model(&daos.Content{}).Select("id").Where("topic_id = ?", a.topic.ID)
+
+	deletes := []struct {
+		name string
+		run  func() error
+	}{
+		{"email logs", func() error { return db.Where("content_id IN (?)", contentIDs).Delete(&daos.EmailLog{}).Error }},
+		{"contents", func() error { return db.Where("topic_id = ?", a.topic.ID).Delete(&daos.Content{}).Error }},
+		{"subscriptions", func() error { return db.Where("topic_id = ?", a.topic.ID).Delete(&daos.Subscription{}).Error }},
+		{"subscribers", func() error {
+			if len(subscriberIDs) == 0 {
+				return nil
+			}
+			return db.Where("id IN ?", subscriberIDs).Delete(&daos.Subscriber{}).Error
+		}},
+		{"topic", func() error { return db.Delete(a.topic).Error }},
+	}
+	for _, d := range deletes {
+		if err := d.run(); err != nil {
+			fmt.Printf("Warning: failed to delete load test %s: %v\n", d.name, err)
+		}
+	}
+}
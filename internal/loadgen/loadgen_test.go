@@ -0,0 +1,21 @@
+package loadgen_test
+
+import (
+	"fmt"
+	"testing"
+
+	"newsletter-service/internal/loadgen"
+	"newsletter-service/internal/services/servicetest"
+)
+
+// BenchmarkSend sends a content per iteration to an audience in the test database, at several worker
+// concurrencies. It is skipped when TEST_DATABASE_URL is unset.
+func BenchmarkSend(b *testing.B) {
+	db := servicetest.OpenDB(b)
+
+	for _, concurrency := range []int{1, 10, 50} {
+		opts := loadgen.DefaultOptions()
+		opts.Concurrency = concurrency
+		b.Run(fmt.Sprintf("concurrency=%d", concurrency), loadgen.Benchmark(db, opts))
+	}
+}
@@ -0,0 +1,36 @@
+package loadgen
+
+import (
+	"sync/atomic"
+
+	"gorm.io/gorm"
+)
+
+// queryCounter counts every statement gorm runs, whatever the session
+var queryCounter = &countingPlugin{}
+
+type countingPlugin struct {
+	count atomic.Int64
+}
+
+func (p *countingPlugin) Name() string {
+	return "loadgen:query_counter"
+}
+
+func (p *countingPlugin) Initialize(db *gorm.DB) error {
+	count := func(*gorm.DB) { p.count.Add(1) }
+	callbacks := []error{
+		db.Callback().Create().After("gorm:create").Register("loadgen:count_create", count),
+		db.Callback().Query().After("gorm:query").Register("loadgen:count_query", count),
+		db.Callback().Update().After("gorm:update").Register("loadgen:count_update", count),
+		db.Callback().Delete().After("gorm:delete").Register("loadgen:count_delete", count),
+		db.Callback().Row().After("gorm:row").Register("loadgen:count_row", count),
+		db.Callback().Raw().After("gorm:raw").Register("loadgen:count_raw", count),
+	}
+	for _, err := range callbacks {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+// Package locale normalizes BCP 47 language tags and picks which of a content's languages a reader gets
+package locale
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// translationTag is the shape of the locales content is translated into: a language with an optional script
+// and region, e.g. "de", "pt-BR", "zh-Hant-TW"
+var translationTag = regexp.MustCompile(`^[A-Za-z]{2,3}([-_][A-Za-z]{4})?([-_]([A-Za-z]{2}|[0-9]{3}))?$`)
+
+// Valid reports whether tag is a language, language-region or language-script-region tag
+func Valid(tag string) bool {
+	return translationTag.MatchString(tag)
+}
+
+// Normalize returns tag with "-" separators in the usual case: a lowercase language, titlecase script and
+// uppercase region ("pt_br" becomes "pt-BR", "zh-hant-tw" becomes "zh-Hant-TW"). Other subtags are lowercased.
+func Normalize(tag string) string {
+	subtags := strings.FieldsFunc(strings.TrimSpace(tag), func(r rune) bool { return r == '-' || r == '_' })
+	for i, subtag := range subtags {
+		switch {
+		case i == 0:
+			subtags[i] = strings.ToLower(subtag)
+		case len(subtag) == 4 && isAlpha(subtag) && i == 1:
+			subtags[i] = strings.ToUpper(subtag[:1]) + strings.ToLower(subtag[1:])
+		case len(subtag) == 2 && isAlpha(subtag), len(subtag) == 3 && isDigits(subtag):
+			subtags[i] = strings.ToUpper(subtag)
+		default:
+			subtags[i] = strings.ToLower(subtag)
+		}
+	}
+	return strings.Join(subtags, "-")
+}
+
+// Language returns the language subtag of a tag: "pt" for "pt-BR"
+func Language(tag string) string {
+	language, _, _ := strings.Cut(Normalize(tag), "-")
+	return language
+}
+
+// Match returns which of the available locales suits a reader who prefers preferred: the same tag, else the
+// bare language ("pt" for "pt-BR"), else another variant of the language, the first in sorted order so the
+// choice is stable. ok is false when none is in the reader's language.
+func Match(preferred string, available []string) (string, bool) {
+	preferred = Normalize(preferred)
+	if preferred == "" {
+		return "", false
+	}
+	language := Language(preferred)
+
+	var variants []string
+	for _, tag := range available {
+		switch normalized := Normalize(tag); {
+		case strings.EqualFold(normalized, preferred):
+			return tag, true
+		case Language(normalized) == language:
+			variants = append(variants, tag)
+		}
+	}
+	if len(variants) == 0 {
+		return "", false
+	}
+
+	sort.Slice(variants, func(i, j int) bool {
+		// The bare language first, then the rest by name
+		iBare, jBare := Normalize(variants[i]) == language, Normalize(variants[j]) == language
+		if iBare != jBare {
+			return iBare
+		}
+		return variants[i] < variants[j]
+	})
+	return variants[0], true
+}
+
+// FromAcceptLanguage returns the first language tag of an Accept-Language header that Valid accepts, or "" when
+// there is none. Tags are taken in the order listed; clients list them by preference.
+func FromAcceptLanguage(header string) string {
+	for _, part := range strings.Split(header, ",") {
+		tag, _, _ := strings.Cut(part, ";")
+		tag = strings.TrimSpace(tag)
+		if Valid(tag) {
+			return Normalize(tag)
+		}
+	}
+	return ""
+}
+
+func isAlpha(s string) bool {
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
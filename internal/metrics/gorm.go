@@ -0,0 +1,88 @@
+package metrics
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const gormQueryStartedKey = "metrics:query_started"
+
+// GormPlugin times every GORM operation into the query histograms and logs the ones slower than a threshold.
+// A send that loads subscribers one at a time shows up as a burst of single-row queries on one table, which is
+// how N+1 patterns are found in production.
+type GormPlugin struct {
+	slowThreshold time.Duration
+}
+
+// NewGormPlugin creates the metrics plugin for use with db.Use. Queries slower than slowThreshold are logged
+// with their SQL; a threshold of zero or less logs none.
+func NewGormPlugin(slowThreshold time.Duration) gorm.Plugin {
+	return &GormPlugin{slowThreshold: slowThreshold}
+}
+
+// Name implements gorm.Plugin
+func (p *GormPlugin) Name() string {
+	return "metrics"
+}
+
+// Initialize implements gorm.Plugin by registering before/after callbacks for each operation
+func (p *GormPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	hooks := []struct {
+		name   string
+		before func(name string, fn func(*gorm.DB)) error
+		after  func(name string, fn func(*gorm.DB)) error
+	}{
+		{"create", cb.Create().Before("gorm:create").Register, cb.Create().After("gorm:create").Register},
+		{"query", cb.Query().Before("gorm:query").Register, cb.Query().After("gorm:query").Register},
+		{"update", cb.Update().Before("gorm:update").Register, cb.Update().After("gorm:update").Register},
+		{"delete", cb.Delete().Before("gorm:delete").Register, cb.Delete().After("gorm:delete").Register},
+		{"row", cb.Row().Before("gorm:row").Register, cb.Row().After("gorm:row").Register},
+		{"raw", cb.Raw().Before("gorm:raw").Register, cb.Raw().After("gorm:raw").Register},
+	}
+
+	for _, hook := range hooks {
+		if err := hook.before("metrics:before_"+hook.name, startQuery); err != nil {
+			return err
+		}
+		if err := hook.after("metrics:after_"+hook.name, p.observeQuery(hook.name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func startQuery(db *gorm.DB) {
+	db.InstanceSet(gormQueryStartedKey, time.Now())
+}
+
+func (p *GormPlugin) observeQuery(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		value, ok := db.InstanceGet(gormQueryStartedKey)
+		if !ok {
+			return
+		}
+		took := time.Since(value.(time.Time))
+
+		table := db.Statement.Table
+		if table == "" {
+			table = "unknown" // Raw SQL and Row scans without a model
+		}
+		queryDuration.WithLabelValues(table, operation).Observe(took.Seconds())
+		if db.Error == nil {
+			queryRows.WithLabelValues(table, operation).Observe(float64(db.RowsAffected))
+		} else if !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+			queryErrors.WithLabelValues(table, operation).Inc()
+		}
+
+		if p.slowThreshold > 0 && took > p.slowThreshold {
+			slowQueries.WithLabelValues(table, operation).Inc()
+			// The SQL keeps its placeholders, so subscriber addresses and other values stay out of the log
+			log.Printf("Slow query (%s on %s) took %s, %d rows: %s",
+				operation, table, took.Round(time.Millisecond), db.RowsAffected, db.Statement.SQL.String())
+		}
+	}
+}
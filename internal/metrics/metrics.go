@@ -0,0 +1,52 @@
+// Package metrics holds the service's Prometheus collectors and the handler that exposes them. Collectors are
+// registered with the default registry when the package loads, so every binary that imports it serves the same
+// metric names at /metrics.
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "newsletter"
+
+// Query metrics, labelled by table and GORM operation (create, query, update, delete, row, raw)
+var (
+	queryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "db",
+		Name:      "query_duration_seconds",
+		Help:      "Time taken by database queries.",
+		Buckets:   prometheus.ExponentialBuckets(0.0005, 2, 15), // 0.5ms to about 8s
+	}, []string{"table", "operation"})
+
+	queryRows = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "db",
+		Name:      "query_rows",
+		Help:      "Rows returned or affected by database queries.",
+		Buckets:   prometheus.ExponentialBuckets(1, 4, 9), // 1 to 65536
+	}, []string{"table", "operation"})
+
+	queryErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "db",
+		Name:      "query_errors_total",
+		Help:      "Database queries that failed, not counting lookups that found no record.",
+	}, []string{"table", "operation"})
+
+	slowQueries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "db",
+		Name:      "slow_queries_total",
+		Help:      "Database queries slower than [database] slow_query_threshold.",
+	}, []string{"table", "operation"})
+)
+
+// Handler serves every registered metric in the Prometheus text format
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
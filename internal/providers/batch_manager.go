@@ -2,11 +2,18 @@ package providers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
+
+	"newsletter-service/internal/providers/templates"
 )
 
+// ErrEmailQueued is returned by BatchedEmailProvider.SendEmail when an email with an OnResult callback was
+// added to a batch. The email has not been sent yet; OnResult reports whether it was once the batch is processed.
+var ErrEmailQueued = errors.New("email queued for batch sending")
+
 // AsyncBatchManager handles batching for providers that don't support true bulk
 type AsyncBatchManager struct {
 	provider       EmailProviderInterface
@@ -58,7 +65,66 @@ func (bm *AsyncBatchManager) AddToBatch(email *EmailNotification) error {
 	return nil
 }
 
-// ProcessBatch processes the current batch
+// BatchItemError is an email of a batch that failed to send
+type BatchItemError struct {
+	Email *EmailNotification
+	Err   error
+}
+
+// BatchError reports the emails of a processed batch that failed; the rest were sent
+type BatchError struct {
+	Failed []BatchItemError
+	Total  int
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("batch processing failed: %d out of %d emails failed", len(e.Failed), e.Total)
+}
+
+// batchGroupKey identifies emails with the same content, which can share one bulk send
+type batchGroupKey struct {
+	subject     string
+	body        string
+	previewText string
+	locale      string
+	from        string
+	fromName    string
+	replyTo     string
+	branding    templates.Branding
+}
+
+// groupBatch splits a batch into runs of emails with the same subject, body, sender and branding, in the order
+// each content was first queued, so overlapping campaigns never borrow each other's content
+func groupBatch(batch []*EmailNotification) [][]*EmailNotification {
+	index := make(map[batchGroupKey]int)
+	var groups [][]*EmailNotification
+	for _, email := range batch {
+		key := batchGroupKey{
+			subject:     email.Subject,
+			body:        email.Body,
+			previewText: email.PreviewText,
+			locale:      email.Locale,
+			from:        email.From,
+			fromName:    email.FromName,
+			replyTo:     email.ReplyTo,
+		}
+		if email.Branding != nil {
+			key.branding = *email.Branding
+		}
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], email)
+	}
+	return groups
+}
+
+// ProcessBatch sends the current batch, one bulk send per distinct content when the provider supports bulk,
+// otherwise one send per email. Failed emails are reported in a *BatchError, and every email's outcome is
+// passed to its OnResult callback.
 func (bm *AsyncBatchManager) ProcessBatch(ctx context.Context) error {
 	bm.mutex.Lock()
 	currentBatch := make([]*EmailNotification, len(bm.batch))
@@ -70,56 +136,104 @@ func (bm *AsyncBatchManager) ProcessBatch(ctx context.Context) error {
 		return nil
 	}
 
-	// Check if provider supports true bulk
+	var failed []BatchItemError
 	if bm.provider.SupportsBulk() {
-		// Convert to bulk notification
-		recipients := make([]string, len(currentBatch))
-		for i, email := range currentBatch {
-			recipients[i] = email.To
+		for _, group := range groupBatch(currentBatch) {
+			failed = append(failed, bm.sendBulk(ctx, group)...)
 		}
+	} else {
+		failed = bm.sendEach(ctx, currentBatch)
+	}
+
+	if len(failed) > 0 {
+		return &BatchError{Failed: failed, Total: len(currentBatch)}
+	}
+	return nil
+}
+
+// sendBulk sends a group of emails with the same content in one bulk send; on failure every email fails
+func (bm *AsyncBatchManager) sendBulk(ctx context.Context, group []*EmailNotification) []BatchItemError {
+	recipients := make([]string, len(group))
+	for i, email := range group {
+		recipients[i] = email.To
+	}
+
+	bulkNotification := &BulkEmailNotification{
+		To:          recipients,
+		Subject:     group[0].Subject,
+		Body:        group[0].Body,
+		From:        group[0].From,
+		FromName:    group[0].FromName,
+		ReplyTo:     group[0].ReplyTo,
+		Branding:    group[0].Branding,
+		PreviewText: group[0].PreviewText,
+		Locale:      group[0].Locale,
+	}
 
-		bulkNotification := &BulkEmailNotification{
-			To:      recipients,
-			Subject: currentBatch[0].Subject, // Assume same subject for batch
-			Body:    currentBatch[0].Body,    // Assume same body for batch
-			From:    currentBatch[0].From,
+	messageIDs, err := bm.provider.SendBulkEmail(ctx, bulkNotification)
+	if err != nil {
+		failed := make([]BatchItemError, len(group))
+		for i, email := range group {
+			failed[i] = BatchItemError{Email: email, Err: err}
+			reportResult(email, "", err)
 		}
+		return failed
+	}
 
-		return bm.provider.SendBulkEmail(ctx, bulkNotification)
+	for _, email := range group {
+		messageID := messageIDs[email.To]
+		if messageID == "" {
+			messageID = email.MessageID
+		}
+		reportResult(email, messageID, nil)
 	}
+	return nil
+}
 
-	// Send individually with concurrency control
+// sendEach sends the emails individually with concurrency control
+func (bm *AsyncBatchManager) sendEach(ctx context.Context, batch []*EmailNotification) []BatchItemError {
 	var wg sync.WaitGroup
 	semaphore := make(chan struct{}, 5) // Limit concurrent sends
-	errorChan := make(chan error, len(currentBatch))
+	errorChan := make(chan BatchItemError, len(batch))
 
-	for _, email := range currentBatch {
+	for _, email := range batch {
 		wg.Add(1)
 		go func(e *EmailNotification) {
 			defer wg.Done()
-			semaphore <- struct{}{}
+
+			// Emails still waiting for a slot when the batch times out fail without being sent
+			select {
+			case semaphore <- struct{}{}:
+			case <-ctx.Done():
+				errorChan <- BatchItemError{Email: e, Err: ctx.Err()}
+				reportResult(e, "", ctx.Err())
+				return
+			}
 			defer func() { <-semaphore }()
 
-			if err := bm.provider.SendEmail(ctx, e); err != nil {
-				errorChan <- err
+			messageID, err := bm.provider.SendEmail(ctx, e)
+			if err != nil {
+				errorChan <- BatchItemError{Email: e, Err: err}
 			}
+			reportResult(e, messageID, err)
 		}(email)
 	}
 
 	wg.Wait()
 	close(errorChan)
 
-	// Check for errors
-	var errors []error
-	for err := range errorChan {
-		errors = append(errors, err)
+	var failed []BatchItemError
+	for item := range errorChan {
+		failed = append(failed, item)
 	}
+	return failed
+}
 
-	if len(errors) > 0 {
-		return fmt.Errorf("batch processing failed: %d out of %d emails failed", len(errors), len(currentBatch))
+// reportResult passes a batched email's outcome to its callback, if it has one
+func reportResult(email *EmailNotification, messageID string, err error) {
+	if email.OnResult != nil {
+		email.OnResult(messageID, err)
 	}
-
-	return nil
 }
 
 // GetBatchSize returns current batch size
@@ -153,9 +267,7 @@ func (bm *AsyncBatchManager) timeoutProcessor() {
 		case <-bm.processingChan:
 			// Process the batch
 			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-			if err := bm.ProcessBatch(ctx); err != nil {
-				fmt.Printf("Batch processing error: %v\n", err)
-			}
+			logBatchError(bm.provider.GetProviderName(), bm.ProcessBatch(ctx))
 			cancel()
 
 		case <-bm.stopChan:
@@ -166,6 +278,21 @@ func (bm *AsyncBatchManager) timeoutProcessor() {
 	}
 }
 
+// logBatchError prints a batch failure with the recipient and error of each failed email
+func logBatchError(providerName string, err error) {
+	if err == nil {
+		return
+	}
+	fmt.Printf("Batch processing error (%s): %v\n", providerName, err)
+
+	var batchErr *BatchError
+	if errors.As(err, &batchErr) {
+		for _, item := range batchErr.Failed {
+			fmt.Printf("  %s (%q): %v\n", item.Email.To, item.Email.Subject, item.Err)
+		}
+	}
+}
+
 // Stop stops the batch manager and processes remaining emails
 func (bm *AsyncBatchManager) Stop() error {
 	close(bm.stopChan)
@@ -204,37 +331,59 @@ func NewBatchedEmailProvider(provider EmailProviderInterface, batchSize int, bul
 	}
 }
 
-// SendEmail sends an individual email or adds to batch
-func (bp *BatchedEmailProvider) SendEmail(ctx context.Context, notification *EmailNotification) error {
-	if bp.bulkEnabled || bp.batchManager == nil {
-		// Send directly for bulk-enabled providers or when no batch manager
+// messageIDAssigner is implemented by providers whose message IDs are chosen by the sender, so an ID can be
+// returned for an email that is only batched now and sent later
+type messageIDAssigner interface {
+	AssignMessageID(notification *EmailNotification) string
+}
+
+// SendEmail sends an individual email or adds to batch. A batched email with an OnResult callback returns
+// ErrEmailQueued, as its outcome is only known later; without one it is acknowledged once queued. Urgent
+// emails skip the batch rather than wait up to the batch timeout.
+func (bp *BatchedEmailProvider) SendEmail(ctx context.Context, notification *EmailNotification) (string, error) {
+	if bp.bulkEnabled || bp.batchManager == nil || notification.Urgent {
+		// Send directly for bulk-enabled providers, urgent emails or when no batch manager
 		return bp.provider.SendEmail(ctx, notification)
 	}
 
 	// Add to batch for non-bulk providers
-	return bp.batchManager.AddToBatch(notification)
+	messageID := bp.assignMessageID(notification)
+	if err := bp.batchManager.AddToBatch(notification); err != nil {
+		return "", err
+	}
+	if notification.OnResult != nil {
+		return messageID, ErrEmailQueued
+	}
+	return messageID, nil
 }
 
 // SendBulkEmail sends bulk emails
-func (bp *BatchedEmailProvider) SendBulkEmail(ctx context.Context, notification *BulkEmailNotification) error {
+func (bp *BatchedEmailProvider) SendBulkEmail(ctx context.Context, notification *BulkEmailNotification) (map[string]string, error) {
 	if bp.bulkEnabled {
 		return bp.provider.SendBulkEmail(ctx, notification)
 	}
 
 	// For non-bulk providers, add all emails to batch
+	messageIDs := make(map[string]string, len(notification.To))
 	for _, recipient := range notification.To {
-		email := &EmailNotification{
-			To:      recipient,
-			Subject: notification.Subject,
-			Body:    notification.Body,
-			From:    notification.From,
+		email := notification.single(recipient)
+		if messageID := bp.assignMessageID(email); messageID != "" {
+			messageIDs[recipient] = messageID
 		}
 		if err := bp.batchManager.AddToBatch(email); err != nil {
-			return err
+			return messageIDs, err
 		}
 	}
 
-	return nil
+	return messageIDs, nil
+}
+
+// assignMessageID gives a batched email its message ID up front when the provider allows it
+func (bp *BatchedEmailProvider) assignMessageID(notification *EmailNotification) string {
+	if assigner, ok := bp.provider.(messageIDAssigner); ok {
+		return assigner.AssignMessageID(notification)
+	}
+	return ""
 }
 
 // Delegate other methods to the underlying provider
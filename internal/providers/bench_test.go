@@ -0,0 +1,160 @@
+package providers_test
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"newsletter-service/internal/config"
+	"newsletter-service/internal/providers"
+	"newsletter-service/internal/providers/providertest"
+)
+
+// benchBatchSize is the number of emails queued per iteration, the batch size SMTP providers are wrapped with
+const benchBatchSize = 50
+
+// BenchmarkBatchManager queues a batch of emails and flushes it per iteration, through a provider with and
+// without bulk support. The batch manager's size is one more than the emails queued, so the flush measured is
+// the benchmark's own rather than one triggered in the background.
+func BenchmarkBatchManager(b *testing.B) {
+	for _, bulk := range []bool{false, true} {
+		name := "individual"
+		if bulk {
+			name = "bulk"
+		}
+		b.Run(name, func(b *testing.B) {
+			provider := providertest.NewRecordingProvider("", nil)
+			provider.SetBulk(bulk)
+			manager := providers.NewAsyncBatchManager(provider, benchBatchSize+1, time.Hour)
+			defer manager.Stop()
+
+			emails := benchEmails(benchBatchSize)
+			ctx := context.Background()
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for _, email := range emails {
+					if err := manager.AddToBatch(email); err != nil {
+						b.Fatal(err)
+					}
+				}
+				if err := manager.ProcessBatch(ctx); err != nil {
+					b.Fatal(err)
+				}
+				provider.Reset()
+			}
+			b.ReportMetric(float64(b.N*benchBatchSize)/b.Elapsed().Seconds(), "emails/s")
+		})
+	}
+}
+
+// BenchmarkProviderFactoryDistribute splits a batch of emails across three providers per iteration, for each
+// load balancing strategy
+func BenchmarkProviderFactoryDistribute(b *testing.B) {
+	strategies := []string{
+		config.LoadBalancingRoundRobin,
+		config.LoadBalancingWeighted,
+		config.LoadBalancingLeastLoad,
+		config.LoadBalancingWeightedRandom,
+	}
+	for _, strategy := range strategies {
+		b.Run(strategy, func(b *testing.B) {
+			factory := benchFactory(b, "https://api.example.com/send", strategy)
+			emails := benchEmailValues(benchBatchSize)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if len(factory.DistributeEmails(emails)) == 0 {
+					b.Fatal("no provider was given any emails")
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkProviderFactorySend distributes a batch of emails and sends each through the provider it was given,
+// with the send counter, domain throttle and batching wrappers the factory adds, to a local HTTP API
+func BenchmarkProviderFactorySend(b *testing.B) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"success":true}`))
+	}))
+	defer server.Close()
+
+	factory := benchFactory(b, server.URL, config.LoadBalancingRoundRobin)
+	emails := benchEmailValues(benchBatchSize)
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for provider, batch := range factory.DistributeEmails(emails) {
+			for j := range batch {
+				if _, err := provider.SendEmail(ctx, &batch[j]); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	}
+	b.ReportMetric(float64(b.N*benchBatchSize)/b.Elapsed().Seconds(), "emails/s")
+}
+
+// benchFactory creates a factory of three bulk API providers sending to endpoint, with limits high enough
+// that no benchmark reaches them
+func benchFactory(b *testing.B, endpoint, strategy string) *providers.ProviderFactory {
+	b.Helper()
+
+	cfg := &config.ProvidersConfig{
+		LoadBalancing: strategy,
+		Weights:       make(map[string]int),
+		API:           make(map[string]config.APIProviderConfig),
+	}
+	for i := 1; i <= 3; i++ {
+		name := fmt.Sprintf("bench_%d", i)
+		cfg.Enabled = append(cfg.Enabled, name)
+		cfg.Weights[name] = i
+		cfg.API[name] = config.APIProviderConfig{
+			Endpoint:         endpoint,
+			Token:            "token",
+			From:             "sender@example.com",
+			Priority:         i,
+			MaxEmailsPerHour: math.MaxInt32,
+			BulkEnabled:      true,
+			MaxBatchSize:     100,
+		}
+	}
+
+	factory, err := providers.NewProviderFactory(cfg, nil)
+	if err != nil {
+		b.Fatal(err)
+	}
+	return factory
+}
+
+// benchEmails returns n emails of one content to distinct recipients
+func benchEmails(n int) []*providers.EmailNotification {
+	emails := make([]*providers.EmailNotification, n)
+	for i := range emails {
+		emails[i] = &providers.EmailNotification{
+			To:      fmt.Sprintf("subscriber%d@example.com", i),
+			Subject: "Weekly",
+			Body:    "<p>Hello</p>",
+		}
+	}
+	return emails
+}
+
+// benchEmailValues is benchEmails for APIs taking emails by value
+func benchEmailValues(n int) []providers.EmailNotification {
+	emails := make([]providers.EmailNotification, n)
+	for i, email := range benchEmails(n) {
+		emails[i] = *email
+	}
+	return emails
+}
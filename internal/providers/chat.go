@@ -0,0 +1,139 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"newsletter-service/internal/config"
+)
+
+// defaultChatTimeout bounds each post when [chat] leaves timeout unset
+const defaultChatTimeout = 10 * time.Second
+
+// ChatMessage is the summary of a content posted to chat destinations
+type ChatMessage struct {
+	Topic   string // Topic name, empty when unknown
+	Title   string
+	Summary string // Plain text, already shortened
+}
+
+// ChatProvider posts content summaries to one chat destination: a Slack or Discord channel webhook, or a
+// Telegram chat
+type ChatProvider interface {
+	PostMessage(ctx context.Context, message *ChatMessage) error
+	GetProviderType() EmailProvider
+	GetProviderName() string
+	ValidateConfig() error
+}
+
+// chatDestination is a provider with the topics it follows; nil topics follow every topic
+type chatDestination struct {
+	provider ChatProvider
+	topics   map[uint]bool
+}
+
+// ChatBroadcaster posts content summaries to every chat destination following the content's topic
+type ChatBroadcaster struct {
+	destinations []chatDestination
+}
+
+// NewChatBroadcaster creates the destinations enabled in [chat]. It returns nil when none is enabled.
+func NewChatBroadcaster(cfg *config.ChatConfig) (*ChatBroadcaster, error) {
+	if len(cfg.Enabled) == 0 {
+		return nil, nil
+	}
+
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultChatTimeout
+	}
+	client := &http.Client{Timeout: timeout}
+
+	broadcaster := &ChatBroadcaster{}
+	for _, name := range cfg.Enabled {
+		var provider ChatProvider
+		var topics []uint
+		if slackConfig, exists := cfg.Slack[name]; exists {
+			provider = NewSlackChatProvider(name, &slackConfig, client)
+			topics = slackConfig.Topics
+		} else if discordConfig, exists := cfg.Discord[name]; exists {
+			provider = NewDiscordChatProvider(name, &discordConfig, client)
+			topics = discordConfig.Topics
+		} else if telegramConfig, exists := cfg.Telegram[name]; exists {
+			provider = NewTelegramChatProvider(name, &telegramConfig, client)
+			topics = telegramConfig.Topics
+		} else {
+			fmt.Printf("Warning: Enabled chat destination '%s' not found in configuration\n", name)
+			continue
+		}
+
+		if err := provider.ValidateConfig(); err != nil {
+			return nil, fmt.Errorf("invalid chat destination %s: %w", name, err)
+		}
+		broadcaster.destinations = append(broadcaster.destinations, chatDestination{
+			provider: provider,
+			topics:   topicSet(topics),
+		})
+	}
+
+	if len(broadcaster.destinations) == 0 {
+		return nil, fmt.Errorf("no enabled chat destinations configured")
+	}
+
+	fmt.Printf("Initialized %d chat destinations: %v\n", len(broadcaster.destinations), cfg.Enabled)
+	return broadcaster, nil
+}
+
+func topicSet(topics []uint) map[uint]bool {
+	if len(topics) == 0 {
+		return nil
+	}
+	set := make(map[uint]bool, len(topics))
+	for _, topicID := range topics {
+		set[topicID] = true
+	}
+	return set
+}
+
+// Follows reports whether any destination follows the topic
+func (b *ChatBroadcaster) Follows(topicID uint) bool {
+	for _, destination := range b.destinations {
+		if destination.follows(topicID) {
+			return true
+		}
+	}
+	return false
+}
+
+// Broadcast posts the message to every destination following the topic. A failing destination doesn't stop
+// the others; their errors are returned together.
+func (b *ChatBroadcaster) Broadcast(ctx context.Context, topicID uint, message *ChatMessage) error {
+	var errs []error
+	for _, destination := range b.destinations {
+		if !destination.follows(topicID) {
+			continue
+		}
+		if err := destination.provider.PostMessage(ctx, message); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", destination.provider.GetProviderName(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (d chatDestination) follows(topicID uint) bool {
+	return d.topics == nil || d.topics[topicID]
+}
+
+// unwrapURLError drops the request URL from a client error. Chat webhook URLs and the Telegram API path carry
+// the destination's credentials, so they stay out of logs.
+func unwrapURLError(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return urlErr.Err
+	}
+	return err
+}
@@ -0,0 +1,104 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"newsletter-service/internal/config"
+	"newsletter-service/internal/tracing"
+)
+
+// DiscordChatProvider posts content summaries to a Discord channel through a channel webhook
+type DiscordChatProvider struct {
+	name   string
+	config *config.DiscordChatConfig
+	client *http.Client
+}
+
+// discordWebhookMessage is the body of a webhook execution, with the summary as a single embed
+type discordWebhookMessage struct {
+	Username string         `json:"username,omitempty"`
+	Embeds   []discordEmbed `json:"embeds"`
+}
+
+type discordEmbed struct {
+	Title       string              `json:"title"`
+	Description string              `json:"description"`
+	Footer      *discordEmbedFooter `json:"footer,omitempty"`
+}
+
+type discordEmbedFooter struct {
+	Text string `json:"text"`
+}
+
+// NewDiscordChatProvider creates a Discord chat destination
+func NewDiscordChatProvider(name string, cfg *config.DiscordChatConfig, client *http.Client) ChatProvider {
+	return &DiscordChatProvider{
+		name:   name,
+		config: cfg,
+		client: client,
+	}
+}
+
+// PostMessage posts the summary as an embed, with the topic in its footer
+func (p *DiscordChatProvider) PostMessage(ctx context.Context, message *ChatMessage) (err error) {
+	ctx, span := tracing.StartSpan(ctx, "provider.discord.post", attribute.String("provider.name", p.name))
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+	}()
+
+	embed := discordEmbed{Title: message.Title, Description: message.Summary}
+	if message.Topic != "" {
+		embed.Footer = &discordEmbedFooter{Text: message.Topic}
+	}
+	payload, err := json.Marshal(discordWebhookMessage{Username: p.config.Username, Embeds: []discordEmbed{embed}})
+	if err != nil {
+		return fmt.Errorf("failed to serialize Discord message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.config.WebhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create Discord request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send Discord request: %w", unwrapURLError(err))
+	}
+	defer resp.Body.Close()
+
+	// Webhooks answer 204 No Content, or 200 with the message when called with ?wait=true
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("Discord webhook returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// GetProviderType returns the provider type
+func (p *DiscordChatProvider) GetProviderType() EmailProvider {
+	return DiscordProviderType
+}
+
+// GetProviderName returns the destination name
+func (p *DiscordChatProvider) GetProviderName() string {
+	return p.name
+}
+
+// ValidateConfig checks the webhook URL
+func (p *DiscordChatProvider) ValidateConfig() error {
+	if !strings.HasPrefix(p.config.WebhookURL, "https://") {
+		return fmt.Errorf("Discord webhook_url must be an https URL")
+	}
+	return nil
+}
@@ -1,60 +1,359 @@
 package providers
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
-	"sync/atomic"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"text/template"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+
 	"newsletter-service/internal/config"
+	"newsletter-service/internal/tracing"
+)
+
+// API provider auth schemes
+const (
+	APIAuthBearer = "bearer" // Authorization: Bearer <token> (default)
+	APIAuthBasic  = "basic"  // Authorization: Basic base64(<token>), with the token written as user:password
+	APIAuthHeader = "header" // <auth_header>: <token>, e.g. X-Api-Key
+	APIAuthNone   = "none"
 )
 
-// NewDynamicAPIProvider creates an API provider from dynamic configuration
+// API provider bulk strategies. They decide how one bulk send reaches many recipients without any of them
+// seeing the others' addresses.
+const (
+	BulkIndividual       = "individual"       // One request per recipient (default)
+	BulkPersonalizations = "personalizations" // One request; payload_template gives each of .Recipients its own envelope
+	BulkBCC              = "bcc"              // One request addressed to the sender, with the recipients in .Bcc
+)
+
+// defaultAPIPayloadTemplate is the Mailtrap send API format, used when payload_template is unset
+const defaultAPIPayloadTemplate = `{"from":{"email":{{json .From}},"name":{{json .FromName}}},` +
+	`"to":[{{range $i, $r := .Recipients}}{{if $i}},{{end}}{"email":{{json $r}}}{{end}}],` +
+	`{{if .Bcc}}"bcc":[{{range $i, $r := .Bcc}}{{if $i}},{{end}}{"email":{{json $r}}}{{end}}],{{end}}` +
+	`{{if .ReplyTo}}"headers":{"Reply-To":{{json .ReplyTo}}},{{end}}` +
+	`"subject":{{json .Subject}},"text":{{json .Text}},"html":{{json .HTML}},"category":"Newsletter"}`
+
+// defaultAPIFromName is the sender name of emails from organizations and topics without one
+const defaultAPIFromName = "Newsletter Service"
+
+// defaultAPIMessageIDPath is where the Mailtrap send API returns message IDs, used when payload_template is unset
+const defaultAPIMessageIDPath = "message_ids"
+
+// defaultAPITimeout bounds each API request when timeout is unset
+const defaultAPITimeout = 30 * time.Second
+
+// APIPayload is the data available to an API provider's payload_template
+type APIPayload struct {
+	From       string
+	FromName   string   // Organization's or topic's sender name, else Newsletter Service
+	ReplyTo    string   // Empty when replies go to From
+	To         string   // First of Recipients
+	Recipients []string // Visible recipients; several only with the personalizations bulk strategy
+	Bcc        []string // Hidden recipients, with the bcc bulk strategy
+	Subject    string
+	Text       string // Plain-text alternative, generated from the body when it is HTML
+	HTML       string // Body rendered into the email template
+}
+
+// apiTemplateFuncs are the functions available to payload templates; json encodes any value as JSON
+var apiTemplateFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		encoded, err := json.Marshal(v)
+		return string(encoded), err
+	},
+}
+
+// NewDynamicAPIProvider creates an API provider from dynamic configuration.
+// Template errors are reported by ValidateConfig.
 func NewDynamicAPIProvider(name string, cfg *config.APIProviderConfig) EmailProviderInterface {
-	// Return a generic API provider for now
-	// In the future, we could add specific implementations based on provider type
+	payloadTemplate := cfg.PayloadTemplate
+	if payloadTemplate == "" {
+		payloadTemplate = defaultAPIPayloadTemplate
+	}
+	tmpl, templateErr := template.New(name).Funcs(apiTemplateFuncs).Option("missingkey=error").Parse(payloadTemplate)
+
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultAPITimeout
+	}
+
+	messageIDPath := cfg.MessageIDPath
+	if messageIDPath == "" && cfg.PayloadTemplate == "" && cfg.MessageIDHeader == "" {
+		messageIDPath = defaultAPIMessageIDPath
+	}
+
 	return &GenericAPIProvider{
-		apiKey:         cfg.Token,
-		endpoint:       cfg.Endpoint,
-		name:           name,
-		priority:       cfg.Priority,
-		maxEmailsHour:  cfg.MaxEmailsPerHour,
-		bulkEnabled:    cfg.BulkEnabled,
-		maxBatchSize:   cfg.MaxBatchSize,
-		isHealthy:      true,
-		emailsSentHour: 0,
+		apiKey:          cfg.Token,
+		endpoint:        cfg.Endpoint,
+		from:            cfg.From,
+		name:            name,
+		priority:        cfg.Priority,
+		maxEmailsHour:   cfg.MaxEmailsPerHour,
+		bulkEnabled:     cfg.BulkEnabled,
+		maxBatchSize:    cfg.MaxBatchSize,
+		method:          cfg.Method,
+		authScheme:      cfg.AuthScheme,
+		authHeader:      cfg.AuthHeader,
+		headers:         cfg.Headers,
+		successStatus:   cfg.SuccessStatus,
+		messageIDPath:   messageIDPath,
+		messageIDHeader: cfg.MessageIDHeader,
+		bulkStrategy:    cfg.BulkStrategy,
+		payload:         tmpl,
+		templateErr:     templateErr,
+		client:          &http.Client{Timeout: timeout},
+		isHealthy:       true,
 	}
 }
 
-// GenericAPIProvider is a flexible API-based email provider
+// GenericAPIProvider sends through any HTTP email API, shaping requests from its configured
+// payload template, auth scheme and success status codes
 type GenericAPIProvider struct {
-	apiKey         string
-	endpoint       string
-	name           string
-	priority       int
-	maxEmailsHour  int
-	bulkEnabled    bool
-	maxBatchSize   int
-	isHealthy      bool
-	emailsSentHour int64
-	lastError      error
-	lastHourReset  time.Time
+	apiKey          string
+	endpoint        string
+	from            string
+	name            string
+	priority        int
+	maxEmailsHour   int
+	bulkEnabled     bool
+	maxBatchSize    int
+	method          string
+	authScheme      string
+	authHeader      string
+	headers         map[string]string
+	successStatus   []int
+	messageIDPath   string
+	messageIDHeader string
+	bulkStrategy    string
+	payload         *template.Template
+	templateErr     error
+	client          *http.Client
+	isHealthy       bool
+	lastError       error
 }
 
 // Implement EmailProviderInterface methods for GenericAPIProvider
-func (p *GenericAPIProvider) SendEmail(ctx context.Context, notification *EmailNotification) error {
-	// Generic API implementation would go here
-	// For now, just return a placeholder
-	atomic.AddInt64(&p.emailsSentHour, 1)
-	return nil
+func (p *GenericAPIProvider) SendEmail(ctx context.Context, notification *EmailNotification) (string, error) {
+	message := &BulkEmailNotification{
+		To:          []string{notification.To},
+		Subject:     notification.Subject,
+		Body:        notification.Body,
+		From:        notification.From,
+		FromName:    notification.FromName,
+		ReplyTo:     notification.ReplyTo,
+		Branding:    notification.Branding,
+		PreviewText: notification.PreviewText,
+		Locale:      notification.Locale,
+	}
+	messageIDs, err := p.send(ctx, message.To, nil, message)
+	if err != nil {
+		return "", err
+	}
+	return messageIDs[notification.To], nil
+}
+
+// SendBulkEmail sends to every recipient using the provider's bulk strategy, so no recipient sees another's address
+func (p *GenericAPIProvider) SendBulkEmail(ctx context.Context, notification *BulkEmailNotification) (map[string]string, error) {
+	switch p.bulkStrategy {
+	case BulkPersonalizations:
+		return p.send(ctx, notification.To, nil, notification)
+	case BulkBCC:
+		return p.send(ctx, nil, notification.To, notification)
+	}
+
+	messageIDs := make(map[string]string, len(notification.To))
+	for _, recipient := range notification.To {
+		ids, err := p.send(ctx, []string{recipient}, nil, notification)
+		if err != nil {
+			return messageIDs, err
+		}
+		if messageID, ok := ids[recipient]; ok {
+			messageIDs[recipient] = messageID
+		}
+	}
+	return messageIDs, nil
 }
 
-func (p *GenericAPIProvider) SendBulkEmail(ctx context.Context, notification *BulkEmailNotification) error {
-	// Generic bulk API implementation
-	atomic.AddInt64(&p.emailsSentHour, int64(len(notification.To)))
+// send renders message for the recipients, posts it and updates the provider statistics; message.To is
+// ignored. A message with only bcc recipients is addressed to the sender. It returns the message IDs keyed by
+// recipient.
+func (p *GenericAPIProvider) send(ctx context.Context, recipients, bcc []string, message *BulkEmailNotification) (map[string]string, error) {
+	if len(recipients) == 0 && len(bcc) == 0 {
+		return nil, nil
+	}
+
+	rendered, err := renderEmail(message.email())
+	if err != nil {
+		return nil, err
+	}
+	from := message.From
+	if from == "" {
+		from = p.from
+	}
+	fromName := message.FromName
+	if fromName == "" {
+		fromName = defaultAPIFromName
+	}
+	if len(recipients) == 0 {
+		recipients = []string{from}
+	}
+
+	payload, err := p.renderPayload(APIPayload{
+		From:       from,
+		FromName:   fromName,
+		ReplyTo:    message.ReplyTo,
+		To:         recipients[0],
+		Recipients: recipients,
+		Bcc:        bcc,
+		Subject:    message.Subject,
+		Text:       rendered.Text,
+		HTML:       rendered.HTML,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids, err := p.post(ctx, payload)
+
+	// Update statistics
+	if err != nil {
+		p.isHealthy = false
+		p.lastError = err
+	} else {
+		p.isHealthy = true
+		p.lastError = nil
+	}
+
+	if len(bcc) > 0 {
+		// A BCC send is a single message, so its first ID belongs to every recipient
+		return recipientMessageIDs(bcc, ids[:min(len(ids), 1)]), err
+	}
+	return recipientMessageIDs(recipients, ids), err
+}
+
+// renderPayload executes the payload template and checks that it produced JSON
+func (p *GenericAPIProvider) renderPayload(data APIPayload) ([]byte, error) {
+	if p.templateErr != nil {
+		return nil, fmt.Errorf("invalid payload_template for %s: %w", p.name, p.templateErr)
+	}
+
+	var buf bytes.Buffer
+	if err := p.payload.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to render %s payload: %w", p.name, err)
+	}
+	if !json.Valid(buf.Bytes()) {
+		return nil, fmt.Errorf("%s payload_template did not produce valid JSON", p.name)
+	}
+	return buf.Bytes(), nil
+}
+
+// post sends the rendered payload to the endpoint and returns the message IDs from the response
+func (p *GenericAPIProvider) post(ctx context.Context, payload []byte) (messageIDs []string, err error) {
+	ctx, span := tracing.StartSpan(ctx, "provider.api.send", attribute.String("provider.name", p.GetProviderName()))
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+	}()
+
+	method := p.method
+	if method == "" {
+		method = http.MethodPost
+	}
+	req, err := http.NewRequestWithContext(ctx, method, p.endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s request: %w", p.name, err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	for header, value := range p.headers {
+		req.Header.Set(header, value)
+	}
+	switch p.authScheme {
+	case APIAuthBasic:
+		req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(p.apiKey)))
+	case APIAuthHeader:
+		req.Header.Set(p.authHeader, p.apiKey)
+	case APIAuthNone:
+	default:
+		req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send %s request: %w", p.name, err)
+	}
+	defer resp.Body.Close()
+
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+	if !p.isSuccess(resp.StatusCode) {
+		// Keep a little of the response; APIs explain rejections in the body
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("%s API returned status %d: %s", p.name, resp.StatusCode, strings.TrimSpace(string(detail)))
+	}
+
+	return p.responseMessageIDs(resp), nil
+}
+
+// responseMessageIDs reads the message IDs from the configured response header or JSON path. A string
+// value is one ID for the whole request and an array has one ID per recipient. The email was accepted
+// either way, so a response without IDs is not an error.
+func (p *GenericAPIProvider) responseMessageIDs(resp *http.Response) []string {
+	if p.messageIDHeader != "" {
+		if messageID := resp.Header.Get(p.messageIDHeader); messageID != "" {
+			return []string{messageID}
+		}
+	}
+	if p.messageIDPath == "" {
+		return nil
+	}
+
+	var value interface{}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&value); err != nil {
+		return nil
+	}
+	for _, key := range strings.Split(p.messageIDPath, ".") {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = object[key]
+	}
+
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		ids := make([]string, len(v))
+		for i, id := range v {
+			ids[i], _ = id.(string)
+		}
+		return ids
+	}
 	return nil
 }
 
+// isSuccess reports whether a response status means the email was accepted; any 2xx unless configured
+func (p *GenericAPIProvider) isSuccess(status int) bool {
+	if len(p.successStatus) == 0 {
+		return status >= 200 && status < 300
+	}
+	for _, code := range p.successStatus {
+		if status == code {
+			return true
+		}
+	}
+	return false
+}
+
 func (p *GenericAPIProvider) SupportsBulk() bool {
 	return p.bulkEnabled
 }
@@ -68,23 +367,9 @@ func (p *GenericAPIProvider) GetLimits() ProviderLimits {
 }
 
 func (p *GenericAPIProvider) GetStats() ProviderStats {
-	// Reset counter if more than an hour has passed
-	if time.Since(p.lastHourReset) > time.Hour {
-		atomic.StoreInt64(&p.emailsSentHour, 0)
-		p.lastHourReset = time.Now()
-	}
-
-	emailsSent := int(atomic.LoadInt64(&p.emailsSentHour))
-	currentLoad := 0
-	if p.maxEmailsHour > 0 {
-		currentLoad = (emailsSent * 100) / p.maxEmailsHour
-	}
-
 	return ProviderStats{
-		EmailsSentLastHour: emailsSent,
-		CurrentLoad:        currentLoad,
-		IsHealthy:          p.isHealthy,
-		LastError:          p.lastError,
+		IsHealthy: p.isHealthy,
+		LastError: p.lastError,
 	}
 }
 
@@ -104,9 +389,124 @@ func (p *GenericAPIProvider) IsEnabled() bool {
 	return true
 }
 
+// ValidateConfig checks the endpoint, auth settings, success codes and bulk strategy, and that the payload
+// template renders valid JSON for a sample email. Bulk providers must also keep the sample's recipients
+// out of each other's to and cc fields.
 func (p *GenericAPIProvider) ValidateConfig() error {
-	if p.apiKey == "" {
+	endpoint, err := url.Parse(p.endpoint)
+	if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+		return fmt.Errorf("API endpoint must be an http(s) URL")
+	}
+
+	switch p.authScheme {
+	case "", APIAuthBearer, APIAuthBasic, APIAuthNone:
+	case APIAuthHeader:
+		if p.authHeader == "" {
+			return fmt.Errorf("auth_header is required for the header auth scheme")
+		}
+	default:
+		return fmt.Errorf("auth_scheme must be bearer, basic, header or none")
+	}
+	if p.authScheme != APIAuthNone && p.apiKey == "" {
 		return fmt.Errorf("API key is required")
 	}
+
+	for _, code := range p.successStatus {
+		if code < 100 || code > 599 {
+			return fmt.Errorf("success_status %d is not an HTTP status code", code)
+		}
+	}
+
+	switch p.bulkStrategy {
+	case "", BulkIndividual, BulkPersonalizations, BulkBCC:
+	default:
+		return fmt.Errorf("bulk_strategy must be individual, personalizations or bcc")
+	}
+
+	// Render a sample bulk send the way the strategy would, two recipients sharing one request
+	recipients := []string{"recipient@example.com", "other@example.com"}
+	sample := APIPayload{
+		From:       "sender@example.com",
+		FromName:   defaultAPIFromName,
+		ReplyTo:    "replies@example.com",
+		To:         recipients[0],
+		Recipients: recipients[:1],
+		Subject:    `Validation "subject"`,
+		Text:       "Body",
+		HTML:       "<p>Body</p>",
+	}
+	switch p.bulkStrategy {
+	case BulkPersonalizations:
+		sample.Recipients = recipients
+	case BulkBCC:
+		sample.To = sample.From
+		sample.Recipients = []string{sample.From}
+		sample.Bcc = recipients
+	}
+
+	payload, err := p.renderPayload(sample)
+	if err != nil {
+		return err
+	}
+	if p.bulkEnabled {
+		return checkRecipientPrivacy(payload, recipients)
+	}
+	return nil
+}
+
+// checkRecipientPrivacy fails when a rendered payload lists more than one of the recipients in the same to
+// or cc field, which every recipient of that message sees
+func checkRecipientPrivacy(payload []byte, recipients []string) error {
+	var value interface{}
+	if err := json.Unmarshal(payload, &value); err != nil {
+		return err
+	}
+	return walkRecipientFields(value, recipients)
+}
+
+func walkRecipientFields(value interface{}, recipients []string) error {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if visible := strings.ToLower(key); (visible == "to" || visible == "cc") && len(mentionedRecipients(field, recipients)) > 1 {
+				return fmt.Errorf("payload_template lists several bulk recipients in one %q field, exposing their addresses to each other; set bulk_strategy or give each recipient its own envelope", key)
+			}
+			if err := walkRecipientFields(field, recipients); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, item := range v {
+			if err := walkRecipientFields(item, recipients); err != nil {
+				return err
+			}
+		}
+	}
 	return nil
 }
+
+// mentionedRecipients returns the recipients whose addresses appear in any string within value
+func mentionedRecipients(value interface{}, recipients []string) map[string]bool {
+	found := make(map[string]bool)
+	var collect func(value interface{})
+	collect = func(value interface{}) {
+		switch v := value.(type) {
+		case string:
+			for _, recipient := range recipients {
+				if strings.Contains(v, recipient) {
+					found[recipient] = true
+				}
+			}
+		case map[string]interface{}:
+			for _, field := range v {
+				collect(field)
+			}
+		case []interface{}:
+			for _, item := range v {
+				collect(item)
+			}
+		}
+	}
+	collect(value)
+	return found
+}
@@ -0,0 +1,160 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"newsletter-service/internal/config"
+)
+
+// personalizationsTemplate gives each recipient its own envelope, the way SendGrid's personalizations do
+const personalizationsTemplate = `{"from":{"email":{{json .From}}},` +
+	`"personalizations":[{{range $i, $r := .Recipients}}{{if $i}},{{end}}{"to":[{"email":{{json $r}}}]}{{end}}],` +
+	`"subject":{{json .Subject}},"content":[{"type":"text/html","value":{{json .HTML}}}]}`
+
+// TestBulkSendKeepsRecipientsApart sends one bulk email through each bulk strategy and checks no recipient
+// can see another: each to or cc field of every request names at most one of them, and a recipient named in a
+// visible field is never named in another's.
+func TestBulkSendKeepsRecipientsApart(t *testing.T) {
+	recipients := []string{"ada@example.com", "grace@example.com", "linus@example.com"}
+
+	tests := []struct {
+		name            string
+		strategy        string
+		payloadTemplate string
+		wantRequests    int
+		wantVisible     int // Recipients named in a to or cc field across all requests
+	}{
+		{name: "individual", strategy: BulkIndividual, wantRequests: len(recipients), wantVisible: len(recipients)},
+		{name: "default strategy", strategy: "", wantRequests: len(recipients), wantVisible: len(recipients)},
+		{name: "personalizations", strategy: BulkPersonalizations, payloadTemplate: personalizationsTemplate, wantRequests: 1, wantVisible: len(recipients)},
+		{name: "bcc", strategy: BulkBCC, wantRequests: 1, wantVisible: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var bodies [][]byte
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				mu.Lock()
+				bodies = append(bodies, body)
+				mu.Unlock()
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(`{"success":true}`))
+			}))
+			defer server.Close()
+
+			provider := NewDynamicAPIProvider("test_api", &config.APIProviderConfig{
+				Endpoint:         server.URL,
+				Token:            "token",
+				From:             "sender@example.com",
+				MaxEmailsPerHour: 1000,
+				BulkEnabled:      true,
+				MaxBatchSize:     100,
+				PayloadTemplate:  tt.payloadTemplate,
+				BulkStrategy:     tt.strategy,
+			})
+			if err := provider.ValidateConfig(); err != nil {
+				t.Fatalf("ValidateConfig() = %v", err)
+			}
+
+			_, err := provider.SendBulkEmail(context.Background(), &BulkEmailNotification{
+				To:      recipients,
+				Subject: "Weekly",
+				Body:    "<p>Hello</p>",
+			})
+			if err != nil {
+				t.Fatalf("SendBulkEmail() = %v", err)
+			}
+			if len(bodies) != tt.wantRequests {
+				t.Fatalf("got %d requests, want %d", len(bodies), tt.wantRequests)
+			}
+
+			visible := 0
+			for _, body := range bodies {
+				visible += countVisibleRecipients(t, body, recipients)
+				// A request addressed to one recipient must not carry the others anywhere
+				if tt.wantRequests == len(recipients) {
+					if named := mentionedRecipients(string(body), recipients); len(named) != 1 {
+						t.Errorf("request names %d recipients, want 1: %s", len(named), body)
+					}
+				}
+			}
+			if visible != tt.wantVisible {
+				t.Errorf("recipients named in to or cc fields = %d, want %d", visible, tt.wantVisible)
+			}
+		})
+	}
+}
+
+// TestValidateConfigRejectsSharedRecipientFields checks that a bulk provider whose template lists every
+// recipient in one to or cc field is refused before it sends anything
+func TestValidateConfigRejectsSharedRecipientFields(t *testing.T) {
+	templates := map[string]string{
+		"to": `{"to":[{{range $i, $r := .Recipients}}{{if $i}},{{end}}{{json $r}}{{end}}],"subject":{{json .Subject}}}`,
+		"cc": `{"to":{{json .From}},"cc":[{{range $i, $r := .Recipients}}{{if $i}},{{end}}{{json $r}}{{end}}],"subject":{{json .Subject}}}`,
+	}
+	for field, payloadTemplate := range templates {
+		t.Run(field, func(t *testing.T) {
+			provider := NewDynamicAPIProvider("test_api", &config.APIProviderConfig{
+				Endpoint:         "https://api.example.com/send",
+				Token:            "token",
+				From:             "sender@example.com",
+				MaxEmailsPerHour: 1000,
+				BulkEnabled:      true,
+				MaxBatchSize:     100,
+				PayloadTemplate:  payloadTemplate,
+				BulkStrategy:     BulkPersonalizations,
+			})
+			err := provider.ValidateConfig()
+			if err == nil || !strings.Contains(err.Error(), "exposing their addresses") {
+				t.Fatalf("ValidateConfig() = %v, want the recipient privacy error", err)
+			}
+		})
+	}
+}
+
+// countVisibleRecipients returns how many recipients a JSON request body names in to or cc fields, failing
+// the test when one field names more than one of them
+func countVisibleRecipients(t *testing.T, body []byte, recipients []string) int {
+	t.Helper()
+	var payload interface{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.Fatalf("request body is not JSON: %v\n%s", err, body)
+	}
+	visible := 0
+	for _, field := range visibleRecipientFields(payload) {
+		named := mentionedRecipients(field, recipients)
+		if len(named) > 1 {
+			t.Errorf("one visible field names %d recipients: %s", len(named), body)
+		}
+		visible += len(named)
+	}
+	return visible
+}
+
+// visibleRecipientFields returns every to and cc field within a payload, at any depth
+func visibleRecipientFields(value interface{}) []interface{} {
+	var fields []interface{}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if key := strings.ToLower(key); key == "to" || key == "cc" {
+				fields = append(fields, field)
+			}
+			fields = append(fields, visibleRecipientFields(field)...)
+		}
+	case []interface{}:
+		for _, item := range v {
+			fields = append(fields, visibleRecipientFields(item)...)
+		}
+	}
+	return fields
+}
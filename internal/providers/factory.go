@@ -2,6 +2,7 @@ package providers
 
 import (
 	"fmt"
+	"strings"
 	"sync"
 
 	"newsletter-service/internal/config"
@@ -11,6 +12,8 @@ import (
 type ProviderFactory struct {
 	providers    []EmailProviderInterface
 	loadBalancer LoadBalancer
+	warmup       map[string]*WarmupPolicy // Keyed by provider name
+	pauses       *ProviderPauses          // Providers taken out of rotation; nil pauses none
 	mutex        sync.RWMutex
 }
 
@@ -20,31 +23,68 @@ type LoadBalancer interface {
 	DistributeLoad(providers []EmailProviderInterface, emails []EmailNotification) map[EmailProviderInterface][]EmailNotification
 }
 
-// NewProviderFactory creates a new provider factory from dynamic configuration
-func NewProviderFactory(cfg *config.ProvidersConfig) (*ProviderFactory, error) {
+// NewProviderFactory creates a new provider factory from dynamic configuration. Each provider's hourly limit
+// is enforced through the send counter; nil counts in memory for this process only.
+func NewProviderFactory(cfg *config.ProvidersConfig, counter SendCounter) (*ProviderFactory, error) {
+	if counter == nil {
+		counter = NewMemorySendCounter()
+	}
+
 	factory := &ProviderFactory{
 		providers: make([]EmailProviderInterface, 0),
+		warmup:    make(map[string]*WarmupPolicy),
+	}
+
+	warmupPolicies := make(map[string]*WarmupPolicy, len(cfg.Warmup))
+	for key, warmupConfig := range cfg.Warmup {
+		policy, err := NewWarmupPolicy(key, &warmupConfig)
+		if err != nil {
+			return nil, err
+		}
+		warmupPolicies[strings.ToLower(key)] = policy
 	}
 
+	// Sends to each recipient domain are throttled across all providers
+	throttle := NewDomainThrottle(&cfg.DomainThrottle)
+
 	// Initialize only enabled providers
 	for _, providerName := range cfg.Enabled {
+		// The local development provider writes emails to disk, so it is neither throttled nor warmed up
+		if providerName == LocalProviderName {
+			provider := NewLocalProvider(&cfg.Local)
+			if err := provider.ValidateConfig(); err != nil {
+				return nil, fmt.Errorf("invalid local provider: %w", err)
+			}
+			factory.providers = append(factory.providers, NewLimitedEmailProvider(provider, counter))
+			continue
+		}
+
 		// Check SMTP providers
 		if smtpConfig, exists := cfg.SMTP[providerName]; exists {
 			provider := NewDynamicSMTPProvider(providerName, &smtpConfig)
+			from := smtpConfig.From
+			if from == "" {
+				from = smtpConfig.Username
+			}
+			factory.assignWarmup(providerName, from, warmupPolicies)
 
 			// Wrap with batch manager if needed (SMTP doesn't support bulk)
 			batchedProvider := NewBatchedEmailProvider(provider, 50, false) // 50 batch size, no bulk
-			factory.providers = append(factory.providers, batchedProvider)
+			factory.providers = append(factory.providers, NewLimitedEmailProvider(factory.throttled(batchedProvider, throttle), counter))
 			continue
 		}
 
 		// Check API providers
 		if apiConfig, exists := cfg.API[providerName]; exists {
 			provider := NewDynamicAPIProvider(providerName, &apiConfig)
+			if err := provider.ValidateConfig(); err != nil {
+				return nil, fmt.Errorf("invalid API provider %s: %w", providerName, err)
+			}
+			factory.assignWarmup(providerName, apiConfig.From, warmupPolicies)
 
 			// Wrap with batch manager based on bulk_enabled setting
 			batchedProvider := NewBatchedEmailProvider(provider, apiConfig.MaxBatchSize, apiConfig.BulkEnabled)
-			factory.providers = append(factory.providers, batchedProvider)
+			factory.providers = append(factory.providers, NewLimitedEmailProvider(factory.throttled(batchedProvider, throttle), counter))
 			continue
 		}
 
@@ -52,14 +92,11 @@ func NewProviderFactory(cfg *config.ProvidersConfig) (*ProviderFactory, error) {
 	}
 
 	// Initialize load balancer
-	switch cfg.LoadBalancing {
-	case "weighted":
-		factory.loadBalancer = NewWeightedLoadBalancer()
-	case "least_load":
-		factory.loadBalancer = NewLeastLoadBalancer()
-	default:
-		factory.loadBalancer = NewRoundRobinLoadBalancer()
+	loadBalancer, err := factory.newLoadBalancer(cfg.LoadBalancing, cfg.Weights)
+	if err != nil {
+		return nil, err
 	}
+	factory.loadBalancer = loadBalancer
 
 	if len(factory.providers) == 0 {
 		return nil, fmt.Errorf("no enabled email providers configured")
@@ -69,15 +106,122 @@ func NewProviderFactory(cfg *config.ProvidersConfig) (*ProviderFactory, error) {
 	return factory, nil
 }
 
-// GetProvider returns a provider based on load balancing strategy
+// newLoadBalancer creates the load balancer for a load_balancing strategy, round robin by default
+func (f *ProviderFactory) newLoadBalancer(strategy string, weights map[string]int) (LoadBalancer, error) {
+	switch strategy {
+	case config.LoadBalancingWeighted:
+		return NewWeightedLoadBalancer(), nil
+	case config.LoadBalancingLeastLoad:
+		return NewLeastLoadBalancer(), nil
+	case config.LoadBalancingWeightedRandom:
+		if err := f.validateWeights(weights); err != nil {
+			return nil, err
+		}
+		return NewWeightedRandomLoadBalancer(weights), nil
+	default:
+		return NewRoundRobinLoadBalancer(), nil
+	}
+}
+
+// validateWeights checks [providers.weights] for the weighted_random strategy: no negative weights and at
+// least one enabled provider with traffic. Weights of providers that aren't enabled are ignored.
+func (f *ProviderFactory) validateWeights(weights map[string]int) error {
+	enabled := make(map[string]bool, len(f.providers))
+	for _, provider := range f.providers {
+		enabled[provider.GetProviderName()] = true
+	}
+
+	total := 0
+	for name, weight := range weights {
+		if weight < 0 {
+			return fmt.Errorf("provider weight for %s must not be negative", name)
+		}
+		if !enabled[name] {
+			fmt.Printf("Warning: Weighted provider '%s' is not enabled\n", name)
+			continue
+		}
+		total += weight
+	}
+	if total == 0 {
+		return fmt.Errorf("weighted_random load balancing needs a positive weight for an enabled provider")
+	}
+	return nil
+}
+
+// throttled wraps a provider with recipient-domain throttling when it is enabled
+func (f *ProviderFactory) throttled(provider EmailProviderInterface, throttle *DomainThrottle) EmailProviderInterface {
+	if throttle == nil {
+		return provider
+	}
+	return NewThrottledEmailProvider(provider, throttle)
+}
+
+// assignWarmup applies the warm-up policy for a provider's name, or else for the domain it sends from
+func (f *ProviderFactory) assignWarmup(providerName, from string, policies map[string]*WarmupPolicy) {
+	if policy, ok := policies[strings.ToLower(providerName)]; ok {
+		f.warmup[providerName] = policy
+		return
+	}
+	if policy, ok := policies[emailDomain(from)]; ok {
+		f.warmup[providerName] = policy
+	}
+}
+
+// GetWarmupPolicy returns the warm-up policy of a provider, or nil when it isn't warming up
+func (f *ProviderFactory) GetWarmupPolicy(providerName string) *WarmupPolicy {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+
+	return f.warmup[providerName]
+}
+
+// SetPauses makes the factory route around the providers pauses reports as paused
+func (f *ProviderFactory) SetPauses(pauses *ProviderPauses) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.pauses = pauses
+}
+
+// IsPaused reports whether the provider is paused
+func (f *ProviderFactory) IsPaused(providerName string) bool {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+
+	return f.pauses.IsPaused(providerName)
+}
+
+// AllPaused reports whether every provider is paused, leaving none to route new emails to
+func (f *ProviderFactory) AllPaused() bool {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+
+	return len(f.providers) > 0 && len(f.unpaused()) == 0
+}
+
+// unpaused returns the providers new emails may be routed to; f.mutex is held
+func (f *ProviderFactory) unpaused() []EmailProviderInterface {
+	if f.pauses == nil {
+		return f.providers
+	}
+	available := make([]EmailProviderInterface, 0, len(f.providers))
+	for _, provider := range f.providers {
+		if !f.pauses.IsPaused(provider.GetProviderName()) {
+			available = append(available, provider)
+		}
+	}
+	return available
+}
+
+// GetProvider returns a provider based on load balancing strategy, or nil when every provider is paused
 func (f *ProviderFactory) GetProvider(emailCount int) EmailProviderInterface {
 	f.mutex.RLock()
 	defer f.mutex.RUnlock()
 
-	return f.loadBalancer.SelectProvider(f.providers, emailCount)
+	return f.loadBalancer.SelectProvider(f.unpaused(), emailCount)
 }
 
-// GetProviders returns all enabled providers
+// GetProviders returns all enabled providers, paused ones included
 func (f *ProviderFactory) GetProviders() []EmailProviderInterface {
 	f.mutex.RLock()
 	defer f.mutex.RUnlock()
@@ -92,21 +236,21 @@ func (f *ProviderFactory) GetProviders() []EmailProviderInterface {
 	return enabled
 }
 
-// DistributeEmails distributes emails across providers
+// DistributeEmails distributes emails across the providers that aren't paused
 func (f *ProviderFactory) DistributeEmails(emails []EmailNotification) map[EmailProviderInterface][]EmailNotification {
 	f.mutex.RLock()
 	defer f.mutex.RUnlock()
 
-	return f.loadBalancer.DistributeLoad(f.providers, emails)
+	return f.loadBalancer.DistributeLoad(f.unpaused(), emails)
 }
 
-// GetHealthyProviders returns only healthy providers
+// GetHealthyProviders returns only healthy providers that aren't paused
 func (f *ProviderFactory) GetHealthyProviders() []EmailProviderInterface {
 	f.mutex.RLock()
 	defer f.mutex.RUnlock()
 
 	healthy := make([]EmailProviderInterface, 0)
-	for _, provider := range f.providers {
+	for _, provider := range f.unpaused() {
 		if provider.IsEnabled() && provider.GetStats().IsHealthy {
 			healthy = append(healthy, provider)
 		}
@@ -114,13 +258,13 @@ func (f *ProviderFactory) GetHealthyProviders() []EmailProviderInterface {
 	return healthy
 }
 
-// GetBulkCapableProviders returns providers that support bulk operations
+// GetBulkCapableProviders returns providers that support bulk operations and aren't paused
 func (f *ProviderFactory) GetBulkCapableProviders() []EmailProviderInterface {
 	f.mutex.RLock()
 	defer f.mutex.RUnlock()
 
 	bulk := make([]EmailProviderInterface, 0)
-	for _, provider := range f.providers {
+	for _, provider := range f.unpaused() {
 		if provider.IsEnabled() && provider.SupportsBulk() {
 			bulk = append(bulk, provider)
 		}
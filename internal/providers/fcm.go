@@ -0,0 +1,301 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"go.opentelemetry.io/otel/attribute"
+
+	"newsletter-service/internal/config"
+	"newsletter-service/internal/tracing"
+)
+
+// FCM endpoints and the OAuth scope a service account needs to send messages
+const (
+	fcmSendURL   = "https://fcm.googleapis.com/v1/projects/%s/messages:send"
+	fcmScope     = "https://www.googleapis.com/auth/firebase.messaging"
+	googleOAuth2 = "https://oauth2.googleapis.com/token"
+)
+
+// FCMPushProvider sends push notifications through the Firebase Cloud Messaging HTTP v1 API, authenticated
+// as a service account
+type FCMPushProvider struct {
+	name      string
+	config    *config.FCMPushConfig
+	account   fcmServiceAccount
+	client    *http.Client
+	isHealthy bool
+	lastError error
+
+	// Access tokens last an hour and are shared by every send until shortly before they expire
+	tokenMu     sync.Mutex
+	accessToken string
+	tokenExpiry time.Time
+}
+
+// fcmServiceAccount is the part of a Google service account key file used to sign token requests
+type fcmServiceAccount struct {
+	ProjectID    string `json:"project_id"`
+	ClientEmail  string `json:"client_email"`
+	PrivateKey   string `json:"private_key"`
+	PrivateKeyID string `json:"private_key_id"`
+	TokenURI     string `json:"token_uri"`
+}
+
+// fcmResponse holds the message name of a successful send, or the error of a rejected one
+type fcmResponse struct {
+	Name  string `json:"name"`
+	Error struct {
+		Status  string `json:"status"`
+		Message string `json:"message"`
+		Details []struct {
+			ErrorCode string `json:"errorCode"`
+		} `json:"details"`
+	} `json:"error"`
+}
+
+// NewFCMPushProvider creates an FCM push provider, reading the service account key file
+func NewFCMPushProvider(name string, cfg *config.FCMPushConfig) (EmailProviderInterface, error) {
+	provider := &FCMPushProvider{
+		name:      name,
+		config:    cfg,
+		client:    &http.Client{Timeout: 30 * time.Second},
+		isHealthy: true,
+	}
+
+	if cfg.CredentialsFile == "" {
+		return provider, nil // Reported by ValidateConfig
+	}
+	data, err := os.ReadFile(cfg.CredentialsFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read FCM credentials file: %w", err)
+	}
+	if err := json.Unmarshal(data, &provider.account); err != nil {
+		return nil, fmt.Errorf("failed to parse FCM credentials file: %w", err)
+	}
+	if provider.account.TokenURI == "" {
+		provider.account.TokenURI = googleOAuth2
+	}
+	return provider, nil
+}
+
+// SendEmail sends the notification to the FCM registration token in To and returns the FCM message name.
+// Tokens FCM no longer knows return ErrPushDeviceGone without marking the provider unhealthy.
+func (p *FCMPushProvider) SendEmail(ctx context.Context, notification *EmailNotification) (string, error) {
+	messageID, err := p.send(ctx, notification)
+
+	// Update statistics
+	if err != nil {
+		if !errors.Is(err, ErrPushDeviceGone) {
+			p.isHealthy = false
+			p.lastError = err
+		}
+		return "", err
+	}
+	p.isHealthy = true
+	p.lastError = nil
+	return messageID, nil
+}
+
+// SendBulkEmail sends one message per device token
+func (p *FCMPushProvider) SendBulkEmail(ctx context.Context, notification *BulkEmailNotification) (map[string]string, error) {
+	return sendEachPush(ctx, p, notification)
+}
+
+func (p *FCMPushProvider) send(ctx context.Context, notification *EmailNotification) (messageID string, err error) {
+	ctx, span := tracing.StartSpan(ctx, "provider.fcm.send", attribute.String("provider.name", p.name))
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+	}()
+
+	token, err := p.token(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	body, err := json.Marshal(map[string]any{
+		"message": map[string]any{
+			"token": notification.To,
+			"notification": map[string]string{
+				"title": notification.Subject,
+				"body":  notification.Body,
+			},
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal FCM message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf(fcmSendURL, url.PathEscape(p.projectID())), bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to create FCM request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to send FCM request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+	var result fcmResponse
+	json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&result)
+	if resp.StatusCode == http.StatusOK {
+		return result.Name, nil
+	}
+
+	for _, detail := range result.Error.Details {
+		if detail.ErrorCode == "UNREGISTERED" {
+			return "", ErrPushDeviceGone
+		}
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		p.clearToken()
+	}
+	return "", fmt.Errorf("FCM API returned status %d: %s %s", resp.StatusCode, result.Error.Status, result.Error.Message)
+}
+
+// token returns a cached OAuth access token, exchanging a signed service account assertion for a new one
+// when it is about to expire
+func (p *FCMPushProvider) token(ctx context.Context) (string, error) {
+	p.tokenMu.Lock()
+	defer p.tokenMu.Unlock()
+
+	if p.accessToken != "" && time.Until(p.tokenExpiry) > time.Minute {
+		return p.accessToken, nil
+	}
+
+	key, err := jwt.ParseRSAPrivateKeyFromPEM([]byte(p.account.PrivateKey))
+	if err != nil {
+		return "", fmt.Errorf("invalid FCM service account private key: %w", err)
+	}
+	now := time.Now()
+	assertion := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":   p.account.ClientEmail,
+		"scope": fcmScope,
+		"aud":   p.account.TokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	})
+	assertion.Header["kid"] = p.account.PrivateKeyID
+	signed, err := assertion.SignedString(key)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign FCM token request: %w", err)
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "urn:ietf:params:oauth:grant-type:jwt-bearer")
+	form.Set("assertion", signed)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.account.TokenURI, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("failed to create FCM token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to request FCM access token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+		Error       string `json:"error_description"`
+	}
+	json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&result)
+	if resp.StatusCode != http.StatusOK || result.AccessToken == "" {
+		return "", fmt.Errorf("FCM token request returned status %d: %s", resp.StatusCode, result.Error)
+	}
+
+	p.accessToken = result.AccessToken
+	p.tokenExpiry = now.Add(time.Duration(result.ExpiresIn) * time.Second)
+	return p.accessToken, nil
+}
+
+// clearToken drops a token FCM rejected so the next send fetches a new one
+func (p *FCMPushProvider) clearToken() {
+	p.tokenMu.Lock()
+	defer p.tokenMu.Unlock()
+	p.accessToken = ""
+}
+
+func (p *FCMPushProvider) projectID() string {
+	if p.config.ProjectID != "" {
+		return p.config.ProjectID
+	}
+	return p.account.ProjectID
+}
+
+// SupportsBulk returns false; the HTTP v1 API sends one message per request
+func (p *FCMPushProvider) SupportsBulk() bool {
+	return false
+}
+
+// GetLimits returns FCM provider limitations
+func (p *FCMPushProvider) GetLimits() ProviderLimits {
+	return ProviderLimits{
+		MaxEmailsPerHour: p.config.MaxPerHour,
+		MaxBatchSize:     1,
+		SupportsBulk:     false,
+	}
+}
+
+// GetStats returns current FCM provider health
+func (p *FCMPushProvider) GetStats() ProviderStats {
+	return ProviderStats{
+		IsHealthy: p.isHealthy,
+		LastError: p.lastError,
+	}
+}
+
+// GetProviderType returns the provider type
+func (p *FCMPushProvider) GetProviderType() EmailProvider {
+	return FCMProviderType
+}
+
+// GetProviderName returns the provider name
+func (p *FCMPushProvider) GetProviderName() string {
+	return p.name
+}
+
+// GetPriority returns provider priority (lower = higher priority)
+func (p *FCMPushProvider) GetPriority() int {
+	return p.config.Priority
+}
+
+// IsEnabled returns true if provider is enabled
+func (p *FCMPushProvider) IsEnabled() bool {
+	return true
+}
+
+// ValidateConfig checks that the service account can sign token requests for a project
+func (p *FCMPushProvider) ValidateConfig() error {
+	if p.config.CredentialsFile == "" {
+		return fmt.Errorf("FCM credentials_file is required")
+	}
+	if p.account.ClientEmail == "" {
+		return fmt.Errorf("FCM credentials file has no client_email")
+	}
+	if _, err := jwt.ParseRSAPrivateKeyFromPEM([]byte(p.account.PrivateKey)); err != nil {
+		return fmt.Errorf("FCM credentials file has an invalid private_key: %w", err)
+	}
+	if p.projectID() == "" {
+		return fmt.Errorf("FCM project_id is required")
+	}
+	return nil
+}
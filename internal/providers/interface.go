@@ -2,14 +2,24 @@ package providers
 
 import (
 	"context"
+	"fmt"
+
+	"newsletter-service/internal/providers/templates"
 )
 
 // EmailProvider represents different email service provider types
 type EmailProvider string
 
 const (
-	SMTPProviderType EmailProvider = "smtp"
-	APIProviderType  EmailProvider = "api"
+	SMTPProviderType     EmailProvider = "smtp"
+	APIProviderType      EmailProvider = "api"
+	LocalProviderType    EmailProvider = "local"
+	SMSProviderType      EmailProvider = "sms"
+	FCMProviderType      EmailProvider = "fcm"
+	WebPushProviderType  EmailProvider = "webpush"
+	SlackProviderType    EmailProvider = "slack"
+	DiscordProviderType  EmailProvider = "discord"
+	TelegramProviderType EmailProvider = "telegram"
 
 	// Legacy constants for backward compatibility
 	SMTPProvider EmailProvider = "smtp"
@@ -17,18 +27,86 @@ const (
 
 // EmailNotification represents an email to be sent
 type EmailNotification struct {
-	To      string
-	Subject string
-	Body    string
-	From    string // Optional, will use default if empty
+	To        string
+	Subject   string
+	Body      string
+	From      string // Optional, will use default if empty
+	MessageID string // Optional Message-ID header for providers that let the sender set it (SMTP)
+
+	// Sender identity and look of the organization or topic sending the email, all optional
+	FromName string
+	ReplyTo  string
+	Branding *templates.Branding
+
+	PreviewText string // Optional inbox preview (preheader) for the HTML template
+	Locale      string // Language of the template's text around the body; English when empty
+	ReferralURL string // The recipient's referral link, shown in the template's footer; optional
+
+	// Urgent emails, from high priority content, are sent at once instead of waiting in a provider batch
+	Urgent bool
+
+	// PushKeys are the encryption keys of a Web Push subscription, whose endpoint is in To. Other providers
+	// ignore them.
+	PushKeys *PushKeys
+
+	// OnResult is called with the outcome of an email that was queued for a later batch instead of sent,
+	// which SendEmail signals with ErrEmailQueued. It runs on the batch's goroutine.
+	OnResult func(messageID string, err error)
+}
+
+// PushKeys are the keys a browser returns with a Web Push subscription, base64url encoded
+type PushKeys struct {
+	P256dh string // Subscription's P-256 public key
+	Auth   string // 16-byte authentication secret
 }
 
 // BulkEmailNotification represents a bulk email to be sent
 type BulkEmailNotification struct {
-	To      []string
-	Subject string
-	Body    string
-	From    string // Optional, will use default if empty
+	To       []string
+	Subject  string
+	Body     string
+	From     string // Optional, will use default if empty
+	FromName string
+	ReplyTo  string
+	Branding *templates.Branding
+
+	PreviewText string
+	Locale      string
+}
+
+// single returns the email one recipient of the bulk email receives, for providers that send them one by one
+func (n *BulkEmailNotification) single(to string) *EmailNotification {
+	return &EmailNotification{
+		To:          to,
+		Subject:     n.Subject,
+		Body:        n.Body,
+		From:        n.From,
+		FromName:    n.FromName,
+		ReplyTo:     n.ReplyTo,
+		Branding:    n.Branding,
+		PreviewText: n.PreviewText,
+		Locale:      n.Locale,
+	}
+}
+
+// email returns what the HTML template renders for the bulk email
+func (n *BulkEmailNotification) email() templates.Email {
+	return templates.Email{Subject: n.Subject, Body: n.Body, PreviewText: n.PreviewText, Branding: n.Branding, Locale: n.Locale}
+}
+
+// email returns what the HTML template renders for the email
+func (n *EmailNotification) email() templates.Email {
+	return templates.Email{Subject: n.Subject, Body: n.Body, PreviewText: n.PreviewText, Branding: n.Branding, Locale: n.Locale, ReferralURL: n.ReferralURL}
+}
+
+// renderEmail generates the HTML email and its plain-text alternative from the templates, the two parts every
+// provider sends
+func renderEmail(email templates.Email) (templates.Rendered, error) {
+	rendered, err := templates.RenderEmail(email)
+	if err != nil {
+		return templates.Rendered{}, fmt.Errorf("failed to generate email template: %w", err)
+	}
+	return rendered, nil
 }
 
 // ProviderLimits represents provider limitations and capabilities
@@ -38,7 +116,8 @@ type ProviderLimits struct {
 	SupportsBulk     bool
 }
 
-// ProviderStats represents real-time provider statistics
+// ProviderStats represents real-time provider statistics. Providers report their health; the sends and
+// load over the last hour are filled in by LimitedEmailProvider from the shared SendCounter.
 type ProviderStats struct {
 	EmailsSentLastHour int
 	CurrentLoad        int // Percentage 0-100
@@ -48,8 +127,10 @@ type ProviderStats struct {
 
 // EmailProviderInterface defines the contract for all email providers
 type EmailProviderInterface interface {
-	SendEmail(ctx context.Context, notification *EmailNotification) error
-	SendBulkEmail(ctx context.Context, notification *BulkEmailNotification) error
+	// SendEmail returns the provider's message ID for the email, or "" when it gives none
+	SendEmail(ctx context.Context, notification *EmailNotification) (string, error)
+	// SendBulkEmail returns message IDs keyed by recipient; recipients without one are left out
+	SendBulkEmail(ctx context.Context, notification *BulkEmailNotification) (map[string]string, error)
 	SupportsBulk() bool
 	GetLimits() ProviderLimits
 	GetStats() ProviderStats
@@ -73,3 +154,23 @@ type ProviderConfig interface {
 	GetProviderType() EmailProvider
 	Validate() error
 }
+
+// recipientMessageIDs pairs the message IDs from an API response with the recipients they were sent to: one
+// ID per recipient in order, or a single ID shared by every recipient of the request
+func recipientMessageIDs(recipients []string, ids []string) map[string]string {
+	messageIDs := make(map[string]string, len(recipients))
+	switch len(ids) {
+	case 0:
+	case 1:
+		for _, recipient := range recipients {
+			messageIDs[recipient] = ids[0]
+		}
+	default:
+		for i, recipient := range recipients {
+			if i < len(ids) && ids[i] != "" {
+				messageIDs[recipient] = ids[i]
+			}
+		}
+	}
+	return messageIDs
+}
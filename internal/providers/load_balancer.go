@@ -1,6 +1,7 @@
 package providers
 
 import (
+	"math/rand/v2"
 	"sort"
 	"sync/atomic"
 )
@@ -153,6 +154,91 @@ func (lb *WeightedLoadBalancer) DistributeLoad(providers []EmailProviderInterfac
 	return distribution
 }
 
+// WeightedRandomLoadBalancer splits traffic across providers in proportion to configured weights, e.g.
+// 70/30 while migrating between ESPs. Providers without a weight get no traffic while a weighted one is healthy.
+type WeightedRandomLoadBalancer struct {
+	weights map[string]int // Keyed by provider name
+}
+
+// NewWeightedRandomLoadBalancer creates a weighted-random load balancer from [providers.weights]
+func NewWeightedRandomLoadBalancer(weights map[string]int) LoadBalancer {
+	return &WeightedRandomLoadBalancer{weights: weights}
+}
+
+// SelectProvider picks a healthy provider at random, weighted by its share of traffic
+func (lb *WeightedRandomLoadBalancer) SelectProvider(providers []EmailProviderInterface, emailCount int) EmailProviderInterface {
+	if len(providers) == 0 {
+		return nil
+	}
+
+	candidates, total := lb.candidates(providers)
+	if len(candidates) == 0 {
+		return providers[0] // Fallback to first provider
+	}
+	return lb.pick(candidates, total)
+}
+
+// DistributeLoad assigns each email to a provider picked at random by weight
+func (lb *WeightedRandomLoadBalancer) DistributeLoad(providers []EmailProviderInterface, emails []EmailNotification) map[EmailProviderInterface][]EmailNotification {
+	distribution := make(map[EmailProviderInterface][]EmailNotification)
+
+	if len(providers) == 0 || len(emails) == 0 {
+		return distribution
+	}
+
+	candidates, total := lb.candidates(providers)
+	if len(candidates) == 0 {
+		return distribution
+	}
+
+	for _, email := range emails {
+		provider := lb.pick(candidates, total)
+		distribution[provider] = append(distribution[provider], email)
+	}
+
+	return distribution
+}
+
+// candidates returns the healthy weighted providers and their total weight. When none of them is healthy,
+// the remaining healthy providers share the traffic equally so sends fail over instead of stopping.
+func (lb *WeightedRandomLoadBalancer) candidates(providers []EmailProviderInterface) ([]weightedProvider, int) {
+	var weighted, fallback []weightedProvider
+	total := 0
+	for _, provider := range providers {
+		if !provider.GetStats().IsHealthy {
+			continue
+		}
+		if weight := lb.weights[provider.GetProviderName()]; weight > 0 {
+			weighted = append(weighted, weightedProvider{provider: provider, weight: weight})
+			total += weight
+		} else {
+			fallback = append(fallback, weightedProvider{provider: provider, weight: 1})
+		}
+	}
+
+	if len(weighted) > 0 {
+		return weighted, total
+	}
+	return fallback, len(fallback)
+}
+
+// pick chooses one candidate with probability weight/total
+func (lb *WeightedRandomLoadBalancer) pick(candidates []weightedProvider, total int) EmailProviderInterface {
+	n := rand.IntN(total)
+	for _, candidate := range candidates {
+		if n < candidate.weight {
+			return candidate.provider
+		}
+		n -= candidate.weight
+	}
+	return candidates[len(candidates)-1].provider
+}
+
+type weightedProvider struct {
+	provider EmailProviderInterface
+	weight   int
+}
+
 // LeastLoadLoadBalancer selects provider with least current load
 type LeastLoadLoadBalancer struct{}
 
@@ -0,0 +1,257 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"newsletter-service/internal/config"
+)
+
+// LocalProviderName is the name that selects the local provider in providers.enabled
+const LocalProviderName = "local"
+
+// Local provider output modes
+const (
+	LocalModeFile    = "file"    // One .eml file per email in dir (default)
+	LocalModeMaildir = "maildir" // Delivered into the maildir at dir, readable by mail clients
+	LocalModeSMTP    = "smtp"    // Relayed to a local catcher such as MailHog
+)
+
+// Local provider defaults used when [providers.local] leaves them unset
+const (
+	defaultLocalDir      = "tmp/mail"
+	defaultLocalFrom     = "newsletter@localhost"
+	defaultLocalSMTPHost = "localhost"
+	defaultLocalSMTPPort = 1025 // MailHog and Mailpit
+
+	// localMaxEmailsPerHour is high enough never to limit, as nothing leaves the machine
+	localMaxEmailsPerHour = 1000000
+)
+
+// unsafeFileChars are replaced in recipients when naming email files
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9@._+-]`)
+
+// LocalEmailProvider keeps emails on the developer's machine so the exact rendered output can be inspected
+// without provider credentials. Nothing is delivered to recipients.
+type LocalEmailProvider struct {
+	mode      string
+	dir       string
+	from      string
+	priority  int
+	pool      *smtpPool // SMTP mode only
+	isHealthy bool
+	lastError error
+}
+
+// NewLocalProvider creates the local development provider from [providers.local]
+func NewLocalProvider(cfg *config.LocalProviderConfig) EmailProviderInterface {
+	mode := cfg.Mode
+	if mode == "" {
+		mode = LocalModeFile
+	}
+	dir := cfg.Dir
+	if dir == "" {
+		dir = defaultLocalDir
+	}
+	from := cfg.From
+	if from == "" {
+		from = defaultLocalFrom
+	}
+
+	provider := &LocalEmailProvider{
+		mode:      mode,
+		dir:       dir,
+		from:      from,
+		priority:  cfg.Priority,
+		isHealthy: true,
+	}
+
+	if mode == LocalModeSMTP {
+		host := cfg.SMTPHost
+		if host == "" {
+			host = defaultLocalSMTPHost
+		}
+		port := cfg.SMTPPort
+		if port <= 0 {
+			port = defaultLocalSMTPPort
+		}
+		provider.pool = newSMTPPool(&SMTPConfig{Host: host, Port: port, From: from, TLSMode: SMTPTLSNone, PoolSize: 1})
+	}
+
+	return provider
+}
+
+// SendEmail renders the email and writes or relays it according to the mode
+func (p *LocalEmailProvider) SendEmail(ctx context.Context, notification *EmailNotification) (string, error) {
+	messageID := p.AssignMessageID(notification)
+	err := p.deliver(ctx, notification)
+
+	// Update statistics
+	if err != nil {
+		p.isHealthy = false
+		p.lastError = err
+		return "", err
+	}
+	p.isHealthy = true
+	p.lastError = nil
+	return messageID, nil
+}
+
+// SendBulkEmail writes one email per recipient, as recipients would receive them
+func (p *LocalEmailProvider) SendBulkEmail(ctx context.Context, notification *BulkEmailNotification) (map[string]string, error) {
+	messageIDs := make(map[string]string, len(notification.To))
+	for _, recipient := range notification.To {
+		if err := ctx.Err(); err != nil {
+			return messageIDs, err
+		}
+		messageID, err := p.SendEmail(ctx, notification.single(recipient))
+		if err != nil {
+			return messageIDs, err
+		}
+		messageIDs[recipient] = messageID
+	}
+	return messageIDs, nil
+}
+
+// AssignMessageID sets the email's Message-ID header if it has none and returns it
+func (p *LocalEmailProvider) AssignMessageID(notification *EmailNotification) string {
+	if notification.MessageID == "" {
+		notification.MessageID = newMessageID(p.fromAddress(notification))
+	}
+	return notification.MessageID
+}
+
+func (p *LocalEmailProvider) fromAddress(notification *EmailNotification) string {
+	if notification.From != "" {
+		return notification.From
+	}
+	return p.from
+}
+
+// deliver renders the full message and hands it to the configured output
+func (p *LocalEmailProvider) deliver(ctx context.Context, notification *EmailNotification) error {
+	rendered, err := renderEmail(notification.email())
+	if err != nil {
+		return err
+	}
+
+	from := p.fromAddress(notification)
+	now := time.Now()
+	msg, err := alternativeMessage(fmt.Sprintf(
+		"%sTo: %s\r\nSubject: %s\r\nDate: %s\r\nMessage-ID: %s\r\n",
+		senderHeaders(notification.FromName, from, notification.ReplyTo),
+		notification.To,
+		notification.Subject,
+		now.Format(time.RFC1123Z),
+		notification.MessageID,
+	), rendered)
+	if err != nil {
+		return fmt.Errorf("failed to build email: %w", err)
+	}
+
+	switch p.mode {
+	case LocalModeSMTP:
+		return p.relay(ctx, from, notification.To, msg)
+	case LocalModeMaildir:
+		return p.writeMaildir(now, msg)
+	default:
+		name := fmt.Sprintf("%s-%s.eml", now.Format("20060102-150405.000000000"), unsafeFileChars.ReplaceAllString(notification.To, "_"))
+		return p.writeFile(p.dir, name, msg)
+	}
+}
+
+// relay sends the message to the local SMTP catcher
+func (p *LocalEmailProvider) relay(ctx context.Context, from, to string, msg []byte) error {
+	conn, err := p.pool.get(ctx)
+	if err != nil {
+		return err
+	}
+	err = p.pool.send(ctx, conn, from, []string{to}, msg)
+	p.pool.put(conn)
+	return err
+}
+
+// writeMaildir delivers the message the maildir way: written under tmp, then moved into new
+func (p *LocalEmailProvider) writeMaildir(now time.Time, msg []byte) error {
+	for _, sub := range []string{"tmp", "new", "cur"} {
+		if err := os.MkdirAll(filepath.Join(p.dir, sub), 0o755); err != nil {
+			return fmt.Errorf("failed to create maildir %s: %w", p.dir, err)
+		}
+	}
+
+	hostname, _ := os.Hostname()
+	name := fmt.Sprintf("%d.%d_%d.%s", now.Unix(), now.Nanosecond(), os.Getpid(), hostname)
+	if err := p.writeFile(filepath.Join(p.dir, "tmp"), name, msg); err != nil {
+		return err
+	}
+	if err := os.Rename(filepath.Join(p.dir, "tmp", name), filepath.Join(p.dir, "new", name)); err != nil {
+		return fmt.Errorf("failed to deliver to maildir %s: %w", p.dir, err)
+	}
+	return nil
+}
+
+func (p *LocalEmailProvider) writeFile(dir, name string, msg []byte) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create mail directory %s: %w", dir, err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name), msg, 0o644); err != nil {
+		return fmt.Errorf("failed to write email %s: %w", name, err)
+	}
+	return nil
+}
+
+// SupportsBulk returns true; bulk sends write one email per recipient
+func (p *LocalEmailProvider) SupportsBulk() bool {
+	return true
+}
+
+// GetLimits returns local provider limitations
+func (p *LocalEmailProvider) GetLimits() ProviderLimits {
+	return ProviderLimits{
+		MaxEmailsPerHour: localMaxEmailsPerHour,
+		MaxBatchSize:     1000,
+		SupportsBulk:     true,
+	}
+}
+
+// GetStats returns current local provider health
+func (p *LocalEmailProvider) GetStats() ProviderStats {
+	return ProviderStats{
+		IsHealthy: p.isHealthy,
+		LastError: p.lastError,
+	}
+}
+
+// GetProviderType returns the provider type
+func (p *LocalEmailProvider) GetProviderType() EmailProvider {
+	return LocalProviderType
+}
+
+// GetProviderName returns the provider name
+func (p *LocalEmailProvider) GetProviderName() string {
+	return LocalProviderName
+}
+
+// GetPriority returns provider priority (lower = higher priority)
+func (p *LocalEmailProvider) GetPriority() int {
+	return p.priority
+}
+
+// IsEnabled returns true if provider is enabled
+func (p *LocalEmailProvider) IsEnabled() bool {
+	return true
+}
+
+// ValidateConfig checks the mode
+func (p *LocalEmailProvider) ValidateConfig() error {
+	switch p.mode {
+	case LocalModeFile, LocalModeMaildir, LocalModeSMTP:
+		return nil
+	default:
+		return fmt.Errorf("local provider mode must be file, maildir or smtp")
+	}
+}
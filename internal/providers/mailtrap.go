@@ -6,30 +6,31 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"sync/atomic"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+
 	"newsletter-service/internal/config"
-	"newsletter-service/internal/providers/templates"
+	"newsletter-service/internal/tracing"
 )
 
 // MailtrapProvider implements Mailtrap bulk API email provider
 type MailtrapProvider struct {
-	config         *config.MailtrapConfig
-	emailsSentHour int64
-	lastHourReset  time.Time
-	isHealthy      bool
-	lastError      error
+	config    *config.MailtrapConfig
+	isHealthy bool
+	lastError error
 }
 
 // MailtrapEmail represents the Mailtrap API payload structure
 type MailtrapEmail struct {
 	From     MailtrapContact   `json:"from"`
 	To       []MailtrapContact `json:"to"`
+	Bcc      []MailtrapContact `json:"bcc,omitempty"`
 	Subject  string            `json:"subject"`
 	Text     string            `json:"text,omitempty"`
 	HTML     string            `json:"html,omitempty"`
 	Category string            `json:"category,omitempty"`
+	Headers  map[string]string `json:"headers,omitempty"`
 }
 
 type MailtrapContact struct {
@@ -37,22 +38,41 @@ type MailtrapContact struct {
 	Name  string `json:"name,omitempty"`
 }
 
+// MailtrapResponse is the body of an accepted Mailtrap send
+type MailtrapResponse struct {
+	Success    bool     `json:"success"`
+	MessageIDs []string `json:"message_ids"`
+}
+
+// mailtrapFromName returns the sender name shown to recipients
+func mailtrapFromName(fromName string) string {
+	if fromName == "" {
+		return "Newsletter Service"
+	}
+	return fromName
+}
+
+// mailtrapHeaders returns the custom headers of an email, or nil when it has none
+func mailtrapHeaders(replyTo string) map[string]string {
+	if replyTo == "" {
+		return nil
+	}
+	return map[string]string{"Reply-To": replyTo}
+}
+
 // NewMailtrapProvider creates a new Mailtrap provider
 func NewMailtrapProvider(config *config.MailtrapConfig) EmailProviderInterface {
 	return &MailtrapProvider{
-		config:         config,
-		emailsSentHour: 0,
-		lastHourReset:  time.Now(),
-		isHealthy:      true,
+		config:    config,
+		isHealthy: true,
 	}
 }
 
 // SendEmail sends a single email via Mailtrap API
-func (p *MailtrapProvider) SendEmail(ctx context.Context, notification *EmailNotification) error {
-	// Generate HTML email using template
-	htmlBody, err := templates.GenerateEmailHTML(notification.Subject, notification.Body)
+func (p *MailtrapProvider) SendEmail(ctx context.Context, notification *EmailNotification) (string, error) {
+	rendered, err := renderEmail(notification.email())
 	if err != nil {
-		return fmt.Errorf("failed to generate email template: %w", err)
+		return "", err
 	}
 
 	// Determine from address
@@ -65,18 +85,19 @@ func (p *MailtrapProvider) SendEmail(ctx context.Context, notification *EmailNot
 	email := MailtrapEmail{
 		From: MailtrapContact{
 			Email: from,
-			Name:  "Newsletter Service",
+			Name:  mailtrapFromName(notification.FromName),
 		},
 		To: []MailtrapContact{
 			{Email: notification.To},
 		},
 		Subject:  notification.Subject,
-		Text:     notification.Body,
-		HTML:     htmlBody,
+		Text:     rendered.Text,
+		HTML:     rendered.HTML,
 		Category: "Newsletter",
+		Headers:  mailtrapHeaders(notification.ReplyTo),
 	}
 
-	err = p.sendToMailtrap(ctx, email)
+	messageIDs, err := p.sendToMailtrap(ctx, email)
 
 	// Update statistics
 	if err != nil {
@@ -85,18 +106,19 @@ func (p *MailtrapProvider) SendEmail(ctx context.Context, notification *EmailNot
 	} else {
 		p.isHealthy = true
 		p.lastError = nil
-		atomic.AddInt64(&p.emailsSentHour, 1)
 	}
 
-	return err
+	if err != nil {
+		return "", err
+	}
+	return messageIDs[notification.To], nil
 }
 
 // SendBulkEmail sends bulk emails via Mailtrap API
-func (p *MailtrapProvider) SendBulkEmail(ctx context.Context, notification *BulkEmailNotification) error {
-	// Generate HTML email using template
-	htmlBody, err := templates.GenerateEmailHTML(notification.Subject, notification.Body)
+func (p *MailtrapProvider) SendBulkEmail(ctx context.Context, notification *BulkEmailNotification) (map[string]string, error) {
+	rendered, err := renderEmail(notification.email())
 	if err != nil {
-		return fmt.Errorf("failed to generate email template: %w", err)
+		return nil, err
 	}
 
 	// Determine from address
@@ -111,20 +133,22 @@ func (p *MailtrapProvider) SendBulkEmail(ctx context.Context, notification *Bulk
 		recipients[i] = MailtrapContact{Email: email}
 	}
 
-	// Prepare Mailtrap bulk payload
+	// Prepare Mailtrap bulk payload, addressed to the sender with recipients hidden in Bcc
 	email := MailtrapEmail{
 		From: MailtrapContact{
 			Email: from,
-			Name:  "Newsletter Service",
+			Name:  mailtrapFromName(notification.FromName),
 		},
-		To:       recipients,
+		To:       []MailtrapContact{{Email: from}},
+		Bcc:      recipients,
 		Subject:  notification.Subject,
-		Text:     notification.Body,
-		HTML:     htmlBody,
+		Text:     rendered.Text,
+		HTML:     rendered.HTML,
 		Category: "Newsletter",
+		Headers:  mailtrapHeaders(notification.ReplyTo),
 	}
 
-	err = p.sendToMailtrap(ctx, email)
+	messageIDs, err := p.sendToMailtrap(ctx, email)
 
 	// Update statistics
 	if err != nil {
@@ -133,22 +157,27 @@ func (p *MailtrapProvider) SendBulkEmail(ctx context.Context, notification *Bulk
 	} else {
 		p.isHealthy = true
 		p.lastError = nil
-		atomic.AddInt64(&p.emailsSentHour, int64(len(notification.To)))
 	}
 
-	return err
+	return messageIDs, err
 }
 
-// sendToMailtrap handles the HTTP request to Mailtrap API
-func (p *MailtrapProvider) sendToMailtrap(ctx context.Context, email MailtrapEmail) error {
+// sendToMailtrap handles the HTTP request to Mailtrap API and returns the message IDs keyed by recipient
+func (p *MailtrapProvider) sendToMailtrap(ctx context.Context, email MailtrapEmail) (messageIDs map[string]string, err error) {
+	ctx, span := tracing.StartSpan(ctx, "provider.mailtrap.send", attribute.String("provider.name", p.GetProviderName()))
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+	}()
+
 	jsonPayload, err := json.Marshal(email)
 	if err != nil {
-		return fmt.Errorf("failed to marshal Mailtrap payload: %w", err)
+		return nil, fmt.Errorf("failed to marshal Mailtrap payload: %w", err)
 	}
 
 	req, err := http.NewRequestWithContext(ctx, "POST", p.config.BulkEndpoint, bytes.NewReader(jsonPayload))
 	if err != nil {
-		return fmt.Errorf("failed to create Mailtrap request: %w", err)
+		return nil, fmt.Errorf("failed to create Mailtrap request: %w", err)
 	}
 
 	req.Header.Set("Authorization", "Bearer "+p.config.APIToken)
@@ -157,15 +186,34 @@ func (p *MailtrapProvider) sendToMailtrap(ctx context.Context, email MailtrapEma
 	client := &http.Client{Timeout: 30 * time.Second}
 	resp, err := client.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to send Mailtrap request: %w", err)
+		return nil, fmt.Errorf("failed to send Mailtrap request: %w", err)
 	}
 	defer resp.Body.Close()
 
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("Mailtrap API returned status %d", resp.StatusCode)
+		return nil, fmt.Errorf("Mailtrap API returned status %d", resp.StatusCode)
 	}
 
-	return nil
+	// Mailtrap returns one message ID per recipient, in request order. The email was accepted either way,
+	// so an unreadable body only loses the IDs.
+	var result MailtrapResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, nil
+	}
+	if len(email.Bcc) > 0 {
+		// A bulk send is one message to every Bcc recipient, identified by its first ID
+		recipients := make([]string, len(email.Bcc))
+		for i, contact := range email.Bcc {
+			recipients[i] = contact.Email
+		}
+		return recipientMessageIDs(recipients, result.MessageIDs[:min(len(result.MessageIDs), 1)]), nil
+	}
+	recipients := make([]string, len(email.To))
+	for i, contact := range email.To {
+		recipients[i] = contact.Email
+	}
+	return recipientMessageIDs(recipients, result.MessageIDs), nil
 }
 
 // SupportsBulk returns true as Mailtrap supports bulk operations
@@ -182,22 +230,11 @@ func (p *MailtrapProvider) GetLimits() ProviderLimits {
 	}
 }
 
-// GetStats returns current Mailtrap provider statistics
+// GetStats returns current Mailtrap provider health
 func (p *MailtrapProvider) GetStats() ProviderStats {
-	// Reset counter if more than an hour has passed
-	if time.Since(p.lastHourReset) > time.Hour {
-		atomic.StoreInt64(&p.emailsSentHour, 0)
-		p.lastHourReset = time.Now()
-	}
-
-	emailsSent := int(atomic.LoadInt64(&p.emailsSentHour))
-	currentLoad := (emailsSent * 100) / p.GetLimits().MaxEmailsPerHour
-
 	return ProviderStats{
-		EmailsSentLastHour: emailsSent,
-		CurrentLoad:        currentLoad,
-		IsHealthy:          p.isHealthy,
-		LastError:          p.lastError,
+		IsHealthy: p.isHealthy,
+		LastError: p.lastError,
 	}
 }
 
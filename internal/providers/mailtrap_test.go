@@ -0,0 +1,52 @@
+package providers
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"newsletter-service/internal/config"
+)
+
+// TestMailtrapBulkSendKeepsRecipientsApart checks a Mailtrap bulk send addresses the sender and hides every
+// recipient in bcc, so no to or cc field names any of them
+func TestMailtrapBulkSendKeepsRecipientsApart(t *testing.T) {
+	recipients := []string{"ada@example.com", "grace@example.com", "linus@example.com"}
+
+	var bodies [][]byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, body)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"success":true,"message_ids":["m1"]}`))
+	}))
+	defer server.Close()
+
+	provider := NewMailtrapProvider(&config.MailtrapConfig{
+		APIToken:         "token",
+		BulkEndpoint:     server.URL,
+		From:             "sender@example.com",
+		MaxEmailsPerHour: 1000,
+		MaxBatchSize:     100,
+		BulkEnabled:      true,
+	})
+	_, err := provider.SendBulkEmail(context.Background(), &BulkEmailNotification{
+		To:      recipients,
+		Subject: "Weekly",
+		Body:    "<p>Hello</p>",
+	})
+	if err != nil {
+		t.Fatalf("SendBulkEmail() = %v", err)
+	}
+	if len(bodies) != 1 {
+		t.Fatalf("got %d requests, want 1", len(bodies))
+	}
+	if visible := countVisibleRecipients(t, bodies[0], recipients); visible != 0 {
+		t.Errorf("recipients named in to or cc fields = %d, want 0: %s", visible, bodies[0])
+	}
+	if named := mentionedRecipients(string(bodies[0]), recipients); len(named) != len(recipients) {
+		t.Errorf("request names %d recipients, want all %d in bcc: %s", len(named), len(recipients), bodies[0])
+	}
+}
@@ -0,0 +1,51 @@
+package providers
+
+import (
+	"bytes"
+	"fmt"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/textproto"
+
+	"newsletter-service/internal/providers/templates"
+)
+
+// alternativeMessage builds a MIME message with the plain-text and HTML parts of an email as
+// multipart/alternative alternatives, after the given header lines (each ending in CRLF). The text part comes
+// first: clients show the last part they can display. Both parts are quoted-printable, which keeps lines within
+// SMTP's 998 character limit however long the HTML's lines are.
+func alternativeMessage(headers string, rendered templates.Rendered) ([]byte, error) {
+	var body bytes.Buffer
+	parts := multipart.NewWriter(&body)
+	for _, part := range []struct {
+		contentType string
+		content     string
+	}{
+		{"text/plain; charset=UTF-8", rendered.Text},
+		{"text/html; charset=UTF-8", rendered.HTML},
+	} {
+		w, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		qp := quotedprintable.NewWriter(w)
+		if _, err := qp.Write([]byte(part.content)); err != nil {
+			return nil, err
+		}
+		if err := qp.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := parts.Close(); err != nil {
+		return nil, err
+	}
+
+	var msg bytes.Buffer
+	msg.WriteString(headers)
+	fmt.Fprintf(&msg, "MIME-Version: 1.0\r\nContent-Type: multipart/alternative; boundary=%q\r\n\r\n", parts.Boundary())
+	msg.Write(body.Bytes())
+	return msg.Bytes(), nil
+}
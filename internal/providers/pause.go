@@ -0,0 +1,58 @@
+package providers
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// ProviderPauses knows which providers are paused. Pauses are set through the API of another process, so the
+// set is loaded again once it is older than its refresh interval; while loading fails the last known set
+// stays in force.
+type ProviderPauses struct {
+	load     func(ctx context.Context) ([]string, error)
+	refresh  time.Duration
+	mu       sync.Mutex
+	paused   map[string]bool
+	loadedAt time.Time
+}
+
+// NewProviderPauses creates a pause set read through load, which returns the names of paused providers
+func NewProviderPauses(load func(ctx context.Context) ([]string, error), refresh time.Duration) *ProviderPauses {
+	return &ProviderPauses{load: load, refresh: refresh}
+}
+
+// IsPaused reports whether the provider is paused. A nil set pauses nothing.
+func (p *ProviderPauses) IsPaused(providerName string) bool {
+	if p == nil {
+		return false
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if time.Since(p.loadedAt) >= p.refresh {
+		names, err := p.load(context.Background())
+		if err != nil {
+			log.Printf("Warning: failed to load paused providers, keeping the last known ones: %v", err)
+		} else {
+			p.paused = make(map[string]bool, len(names))
+			for _, name := range names {
+				p.paused[name] = true
+			}
+		}
+		// A failed load is retried after the interval too, rather than on every send
+		p.loadedAt = time.Now()
+	}
+	return p.paused[providerName]
+}
+
+// Invalidate makes the next IsPaused load the set again, after this process paused or resumed a provider
+func (p *ProviderPauses) Invalidate() {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.loadedAt = time.Time{}
+}
@@ -0,0 +1,247 @@
+// Package providertest has an in-memory email provider that records what it is asked to send, and a clock tests
+// can stop and move, for testing send flows without an SMTP server or provider credentials. A RecordingProvider
+// can be handed to anything taking a providers.EmailProviderInterface, such as
+// notification.Service.SendNotificationsByContentIDWithProvider.
+package providertest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"newsletter-service/internal/providers"
+)
+
+// DefaultName is the name of a RecordingProvider created without one
+const DefaultName = "recording"
+
+// Sent is an email the provider accepted
+type Sent struct {
+	Notification providers.EmailNotification
+	MessageID    string
+	At           time.Time // From the provider's clock
+}
+
+// RecordingProvider keeps every email it is sent in memory instead of delivering it. Sends can be made to fail
+// per recipient or all at once. It is safe for concurrent use, as send flows send from several goroutines.
+type RecordingProvider struct {
+	name     string
+	clock    Clock
+	priority int
+	bulk     bool
+	latency  time.Duration
+
+	mu       sync.Mutex
+	sent     []Sent
+	attempts int
+	failAll  error
+	failures map[string]error // By recipient
+	nextID   int
+}
+
+// NewRecordingProvider creates a provider named name (DefaultName when empty) that timestamps emails with
+// clock (SystemClock when nil)
+func NewRecordingProvider(name string, clock Clock) *RecordingProvider {
+	if name == "" {
+		name = DefaultName
+	}
+	if clock == nil {
+		clock = SystemClock
+	}
+	return &RecordingProvider{name: name, clock: clock, failures: make(map[string]error)}
+}
+
+// SetBulk sets whether the provider claims to support bulk sends; it doesn't by default, so send flows
+// take their one-by-one path
+func (p *RecordingProvider) SetBulk(bulk bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.bulk = bulk
+}
+
+// SetLatency makes each send take d, as a round trip to a real provider would; sends give up early when their
+// context ends
+func (p *RecordingProvider) SetLatency(d time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.latency = d
+}
+
+// SetPriority sets the priority load balancers see
+func (p *RecordingProvider) SetPriority(priority int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.priority = priority
+}
+
+// FailFor makes sends to recipient return err; a nil err makes them succeed again
+func (p *RecordingProvider) FailFor(recipient string, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err == nil {
+		delete(p.failures, recipient)
+		return
+	}
+	p.failures[recipient] = err
+}
+
+// FailAll makes every send return err, as an outage would; a nil err ends it
+func (p *RecordingProvider) FailAll(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.failAll = err
+}
+
+// Sent returns the emails accepted so far, in the order they were sent
+func (p *RecordingProvider) Sent() []Sent {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]Sent(nil), p.sent...)
+}
+
+// SentTo returns the emails accepted for recipient
+func (p *RecordingProvider) SentTo(recipient string) []Sent {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var sent []Sent
+	for _, s := range p.sent {
+		if s.Notification.To == recipient {
+			sent = append(sent, s)
+		}
+	}
+	return sent
+}
+
+// Attempts returns how many single sends were attempted, failed ones included
+func (p *RecordingProvider) Attempts() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.attempts
+}
+
+// Reset forgets the recorded emails and attempts; failures stay set
+func (p *RecordingProvider) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.sent = nil
+	p.attempts = 0
+}
+
+// SendEmail records the email, or returns the failure set for its recipient
+func (p *RecordingProvider) SendEmail(ctx context.Context, notification *providers.EmailNotification) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	if latency := p.getLatency(); latency > 0 {
+		timer := time.NewTimer(latency)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return "", ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.attempts++
+	if p.failAll != nil {
+		return "", p.failAll
+	}
+	if err := p.failures[notification.To]; err != nil {
+		return "", err
+	}
+
+	p.nextID++
+	messageID := notification.MessageID
+	if messageID == "" {
+		messageID = fmt.Sprintf("<%d.%s@providertest>", p.nextID, p.name)
+	}
+	recorded := *notification
+	recorded.OnResult = nil
+	p.sent = append(p.sent, Sent{Notification: recorded, MessageID: messageID, At: p.clock.Now()})
+	return messageID, nil
+}
+
+func (p *RecordingProvider) getLatency() time.Duration {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.latency
+}
+
+// SendBulkEmail records one email per recipient and stops at the first failure, returning the message IDs of
+// the recipients before it
+func (p *RecordingProvider) SendBulkEmail(ctx context.Context, notification *providers.BulkEmailNotification) (map[string]string, error) {
+	messageIDs := make(map[string]string, len(notification.To))
+	for _, recipient := range notification.To {
+		messageID, err := p.SendEmail(ctx, &providers.EmailNotification{
+			To:          recipient,
+			Subject:     notification.Subject,
+			Body:        notification.Body,
+			From:        notification.From,
+			FromName:    notification.FromName,
+			ReplyTo:     notification.ReplyTo,
+			Branding:    notification.Branding,
+			PreviewText: notification.PreviewText,
+			Locale:      notification.Locale,
+		})
+		if err != nil {
+			return messageIDs, err
+		}
+		messageIDs[recipient] = messageID
+	}
+	return messageIDs, nil
+}
+
+// SupportsBulk reports what SetBulk last set
+func (p *RecordingProvider) SupportsBulk() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.bulk
+}
+
+// GetLimits returns limits high enough never to hold a test back
+func (p *RecordingProvider) GetLimits() providers.ProviderLimits {
+	return providers.ProviderLimits{
+		MaxEmailsPerHour: 1000000,
+		MaxBatchSize:     1000,
+		SupportsBulk:     p.SupportsBulk(),
+	}
+}
+
+// GetStats reports the provider unhealthy while FailAll is in effect
+func (p *RecordingProvider) GetStats() providers.ProviderStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return providers.ProviderStats{
+		EmailsSentLastHour: len(p.sent),
+		IsHealthy:          p.failAll == nil,
+		LastError:          p.failAll,
+	}
+}
+
+// GetProviderType returns the local provider type, as nothing leaves the process
+func (p *RecordingProvider) GetProviderType() providers.EmailProvider {
+	return providers.LocalProviderType
+}
+
+func (p *RecordingProvider) GetProviderName() string {
+	return p.name
+}
+
+func (p *RecordingProvider) GetPriority() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.priority
+}
+
+func (p *RecordingProvider) IsEnabled() bool {
+	return true
+}
+
+func (p *RecordingProvider) ValidateConfig() error {
+	return nil
+}
+
+var _ providers.EmailProviderInterface = (*RecordingProvider)(nil)
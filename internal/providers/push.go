@@ -0,0 +1,128 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"newsletter-service/internal/config"
+)
+
+// Push providers implement EmailProviderInterface like the SMS providers. For them an EmailNotification's
+// To is the device token (FCM) or subscription endpoint (Web Push), Subject the notification title and
+// Body its text.
+
+// ErrPushDeviceGone is returned when the push service reports that a device token or subscription no longer
+// exists. The device should be removed rather than retried.
+var ErrPushDeviceGone = errors.New("push device is no longer registered")
+
+// pushPayload is the JSON message delivered to web push service workers
+type pushPayload struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+// NewPushProviderFactory creates the push channel's providers from [push], wrapped with hourly limits counted
+// by counter (nil counts in memory). It returns nil when no push provider is enabled.
+func NewPushProviderFactory(cfg *config.PushConfig, counter SendCounter) (*ProviderFactory, error) {
+	if len(cfg.Enabled) == 0 {
+		return nil, nil
+	}
+	if counter == nil {
+		counter = NewMemorySendCounter()
+	}
+
+	factory := &ProviderFactory{
+		providers: make([]EmailProviderInterface, 0),
+		warmup:    make(map[string]*WarmupPolicy),
+	}
+
+	for _, providerName := range cfg.Enabled {
+		var provider EmailProviderInterface
+		var err error
+		if fcmConfig, exists := cfg.FCM[providerName]; exists {
+			provider, err = NewFCMPushProvider(providerName, &fcmConfig)
+		} else if webPushConfig, exists := cfg.WebPush[providerName]; exists {
+			provider, err = NewWebPushProvider(providerName, &webPushConfig)
+		} else {
+			fmt.Printf("Warning: Enabled push provider '%s' not found in configuration\n", providerName)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid push provider %s: %w", providerName, err)
+		}
+
+		if err := provider.ValidateConfig(); err != nil {
+			return nil, fmt.Errorf("invalid push provider %s: %w", providerName, err)
+		}
+		factory.providers = append(factory.providers, NewLimitedEmailProvider(provider, counter))
+	}
+
+	loadBalancer, err := factory.newLoadBalancer(cfg.LoadBalancing, cfg.Weights)
+	if err != nil {
+		return nil, err
+	}
+	factory.loadBalancer = loadBalancer
+
+	if len(factory.providers) == 0 {
+		return nil, fmt.Errorf("no enabled push providers configured")
+	}
+
+	fmt.Printf("Initialized %d push providers: %v\n", len(factory.providers), cfg.Enabled)
+	return factory, nil
+}
+
+// DistributePush spreads push notifications across the providers that can reach each device: Web Push
+// subscriptions, which carry keys, only through Web Push providers and FCM tokens only through FCM. Devices
+// with no provider of their kind enabled are left out.
+func (f *ProviderFactory) DistributePush(notifications []EmailNotification) map[EmailProviderInterface][]EmailNotification {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+
+	var webPush, fcm []EmailNotification
+	for _, notification := range notifications {
+		if notification.PushKeys != nil {
+			webPush = append(webPush, notification)
+		} else {
+			fcm = append(fcm, notification)
+		}
+	}
+
+	var webPushProviders, fcmProviders []EmailProviderInterface
+	for _, provider := range f.providers {
+		switch provider.GetProviderType() {
+		case WebPushProviderType:
+			webPushProviders = append(webPushProviders, provider)
+		case FCMProviderType:
+			fcmProviders = append(fcmProviders, provider)
+		}
+	}
+
+	distribution := f.loadBalancer.DistributeLoad(webPushProviders, webPush)
+	for provider, assigned := range f.loadBalancer.DistributeLoad(fcmProviders, fcm) {
+		distribution[provider] = assigned
+	}
+	return distribution
+}
+
+// sendEachPush sends a bulk notification as one push message per device
+func sendEachPush(ctx context.Context, provider EmailProviderInterface, notification *BulkEmailNotification) (map[string]string, error) {
+	messageIDs := make(map[string]string, len(notification.To))
+	for _, recipient := range notification.To {
+		if err := ctx.Err(); err != nil {
+			return messageIDs, err
+		}
+		messageID, err := provider.SendEmail(ctx, &EmailNotification{To: recipient, Subject: notification.Subject, Body: notification.Body})
+		if err != nil {
+			return messageIDs, err
+		}
+		messageIDs[recipient] = messageID
+	}
+	return messageIDs, nil
+}
+
+// marshalPushPayload encodes a notification's title and text for a service worker
+func marshalPushPayload(notification *EmailNotification) ([]byte, error) {
+	return json.Marshal(pushPayload{Title: notification.Subject, Body: notification.Body})
+}
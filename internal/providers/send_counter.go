@@ -0,0 +1,288 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// sendWindowMinutes is the length of the sliding send window, counted in one-minute buckets
+const sendWindowMinutes = 60
+
+// SendCounter counts each provider's sends over a sliding hour and reserves sends under its hourly limit.
+// Implementations are safe for concurrent use.
+type SendCounter interface {
+	// Reserve claims n sends for the provider if they all fit under limit within the last hour. A limit of
+	// 0 or less is unlimited. It returns whether the sends were claimed.
+	Reserve(ctx context.Context, provider string, n, limit int) (bool, error)
+	// Count returns the provider's sends within the last hour
+	Count(ctx context.Context, provider string) (int, error)
+}
+
+// reserveSendsScript sums a provider's minute buckets within the window, dropping older ones, and claims
+// ARGV[2] sends in the current minute if the total stays within the limit. Returns {claimed, total}.
+var reserveSendsScript = redis.NewScript(`
+local minute = tonumber(ARGV[1])
+local n = tonumber(ARGV[2])
+local limit = tonumber(ARGV[3])
+local window = tonumber(ARGV[4])
+local buckets = redis.call('HGETALL', KEYS[1])
+local total = 0
+for i = 1, #buckets, 2 do
+	if tonumber(buckets[i]) <= minute - window then
+		redis.call('HDEL', KEYS[1], buckets[i])
+	else
+		total = total + tonumber(buckets[i + 1])
+	end
+end
+if n == 0 or (limit > 0 and total + n > limit) then
+	return {0, total}
+end
+redis.call('HINCRBY', KEYS[1], ARGV[1], n)
+redis.call('EXPIRE', KEYS[1], window * 120)
+return {1, total + n}
+`)
+
+// RedisSendCounter shares send counts between every web and worker replica, so hourly limits hold across
+// processes
+type RedisSendCounter struct {
+	client redis.UniversalClient
+}
+
+// NewRedisSendCounter creates a Redis-backed send counter
+func NewRedisSendCounter(client redis.UniversalClient) *RedisSendCounter {
+	return &RedisSendCounter{client: client}
+}
+
+func (c *RedisSendCounter) Reserve(ctx context.Context, provider string, n, limit int) (bool, error) {
+	claimed, _, err := c.run(ctx, provider, n, limit)
+	return claimed, err
+}
+
+func (c *RedisSendCounter) Count(ctx context.Context, provider string) (int, error) {
+	_, total, err := c.run(ctx, provider, 0, 0)
+	return total, err
+}
+
+func (c *RedisSendCounter) run(ctx context.Context, provider string, n, limit int) (bool, int, error) {
+	key := fmt.Sprintf("provider_sends:%s", provider)
+	minute := time.Now().Unix() / 60
+	res, err := reserveSendsScript.Run(ctx, c.client, []string{key}, minute, n, limit, sendWindowMinutes).Slice()
+	if err != nil {
+		return false, 0, fmt.Errorf("failed to count sends for provider %s: %w", provider, err)
+	}
+
+	claimed, _ := res[0].(int64)
+	total, _ := res[1].(int64)
+	return claimed == 1, int(total), nil
+}
+
+// MemorySendCounter counts sends per process, for running without Redis
+type MemorySendCounter struct {
+	windows map[string]*sendWindow
+	mu      sync.Mutex
+}
+
+// sendWindow holds one provider's sends per minute, indexed by minute modulo the window
+type sendWindow struct {
+	minutes [sendWindowMinutes]int64
+	counts  [sendWindowMinutes]int
+}
+
+// NewMemorySendCounter creates an in-memory send counter
+func NewMemorySendCounter() *MemorySendCounter {
+	return &MemorySendCounter{windows: make(map[string]*sendWindow)}
+}
+
+func (c *MemorySendCounter) Reserve(ctx context.Context, provider string, n, limit int) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	minute := time.Now().Unix() / 60
+	window := c.window(provider)
+	total := window.total(minute)
+	if n == 0 || (limit > 0 && total+n > limit) {
+		return false, nil
+	}
+
+	slot := minute % sendWindowMinutes
+	if window.minutes[slot] != minute {
+		window.minutes[slot] = minute
+		window.counts[slot] = 0
+	}
+	window.counts[slot] += n
+	return true, nil
+}
+
+func (c *MemorySendCounter) Count(ctx context.Context, provider string) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.window(provider).total(time.Now().Unix() / 60), nil
+}
+
+func (c *MemorySendCounter) window(provider string) *sendWindow {
+	window, ok := c.windows[provider]
+	if !ok {
+		window = &sendWindow{}
+		c.windows[provider] = window
+	}
+	return window
+}
+
+// total sums the buckets still inside the window ending at minute
+func (w *sendWindow) total(minute int64) int {
+	total := 0
+	for slot, bucketMinute := range w.minutes {
+		if bucketMinute > minute-sendWindowMinutes {
+			total += w.counts[slot]
+		}
+	}
+	return total
+}
+
+// FallbackSendCounter counts in primary, normally Redis, and in fallback while primary fails, so sends carry on
+// through a Redis outage under a per-process limit rather than stopping. Counts made during the outage stay in
+// fallback; once primary answers again the shared count is used.
+type FallbackSendCounter struct {
+	primary  SendCounter
+	fallback SendCounter
+
+	mu       sync.Mutex
+	degraded bool
+}
+
+// NewFallbackSendCounter creates a counter that falls back from primary to fallback while primary returns errors
+func NewFallbackSendCounter(primary, fallback SendCounter) *FallbackSendCounter {
+	return &FallbackSendCounter{primary: primary, fallback: fallback}
+}
+
+func (c *FallbackSendCounter) Reserve(ctx context.Context, provider string, n, limit int) (bool, error) {
+	claimed, err := c.primary.Reserve(ctx, provider, n, limit)
+	if c.failedOver(ctx, err) {
+		return c.fallback.Reserve(ctx, provider, n, limit)
+	}
+	return claimed, err
+}
+
+func (c *FallbackSendCounter) Count(ctx context.Context, provider string) (int, error) {
+	total, err := c.primary.Count(ctx, provider)
+	if c.failedOver(ctx, err) {
+		return c.fallback.Count(ctx, provider)
+	}
+	return total, err
+}
+
+// failedOver reports whether err from primary means the fallback should answer, logging the change when
+// counting moves between the two
+func (c *FallbackSendCounter) failedOver(ctx context.Context, err error) bool {
+	degraded := err != nil && ctx.Err() == nil
+	if err != nil && !degraded {
+		return false // Cancelled by the caller, not a Redis failure
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.degraded != degraded {
+		c.degraded = degraded
+		if degraded {
+			log.Printf("Warning: shared send counts unavailable (%v); hourly limits apply per process until they return", err)
+		} else {
+			log.Printf("Shared send counts available again")
+		}
+	}
+	return degraded
+}
+
+// LimitedEmailProvider enforces a provider's MaxEmailsPerHour over a sliding hour and reports its sends
+// from the shared counter
+type LimitedEmailProvider struct {
+	provider EmailProviderInterface
+	counter  SendCounter
+}
+
+// NewLimitedEmailProvider wraps a provider with hourly limit enforcement
+func NewLimitedEmailProvider(provider EmailProviderInterface, counter SendCounter) EmailProviderInterface {
+	return &LimitedEmailProvider{
+		provider: provider,
+		counter:  counter,
+	}
+}
+
+// SendEmail sends if the provider is under its hourly limit
+func (lp *LimitedEmailProvider) SendEmail(ctx context.Context, notification *EmailNotification) (string, error) {
+	if err := lp.reserve(ctx, 1); err != nil {
+		return "", err
+	}
+	return lp.provider.SendEmail(ctx, notification)
+}
+
+// SendBulkEmail sends if every recipient fits under the provider's hourly limit
+func (lp *LimitedEmailProvider) SendBulkEmail(ctx context.Context, notification *BulkEmailNotification) (map[string]string, error) {
+	if err := lp.reserve(ctx, len(notification.To)); err != nil {
+		return nil, err
+	}
+	return lp.provider.SendBulkEmail(ctx, notification)
+}
+
+// reserve claims n sends. Sends go ahead when the counter is unavailable, rather than stopping delivery.
+func (lp *LimitedEmailProvider) reserve(ctx context.Context, n int) error {
+	limit := lp.provider.GetLimits().MaxEmailsPerHour
+	claimed, err := lp.counter.Reserve(ctx, lp.provider.GetProviderName(), n, limit)
+	if err != nil {
+		fmt.Printf("Warning: sending without hourly limit check: %v\n", err)
+		return nil
+	}
+	if !claimed {
+		return fmt.Errorf("provider %s reached its hourly rate limit of %d emails", lp.provider.GetProviderName(), limit)
+	}
+	return nil
+}
+
+// GetStats returns the provider's health with its sends and load over the last hour
+func (lp *LimitedEmailProvider) GetStats() ProviderStats {
+	stats := lp.provider.GetStats()
+
+	sent, err := lp.counter.Count(context.Background(), lp.provider.GetProviderName())
+	if err != nil {
+		return stats
+	}
+	stats.EmailsSentLastHour = sent
+	if limit := lp.provider.GetLimits().MaxEmailsPerHour; limit > 0 {
+		stats.CurrentLoad = (sent * 100) / limit
+	}
+	return stats
+}
+
+// Delegate other methods to the underlying provider
+func (lp *LimitedEmailProvider) SupportsBulk() bool {
+	return lp.provider.SupportsBulk()
+}
+
+func (lp *LimitedEmailProvider) GetLimits() ProviderLimits {
+	return lp.provider.GetLimits()
+}
+
+func (lp *LimitedEmailProvider) GetProviderType() EmailProvider {
+	return lp.provider.GetProviderType()
+}
+
+func (lp *LimitedEmailProvider) GetProviderName() string {
+	return lp.provider.GetProviderName()
+}
+
+func (lp *LimitedEmailProvider) GetPriority() int {
+	return lp.provider.GetPriority()
+}
+
+func (lp *LimitedEmailProvider) IsEnabled() bool {
+	return lp.provider.IsEnabled()
+}
+
+func (lp *LimitedEmailProvider) ValidateConfig() error {
+	return lp.provider.ValidateConfig()
+}
@@ -6,26 +6,30 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"sync/atomic"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+
 	"newsletter-service/internal/config"
-	"newsletter-service/internal/providers/templates"
+	"newsletter-service/internal/tracing"
 )
 
+// sendGridEndpoint is SendGrid's v3 mail send API
+const sendGridEndpoint = "https://api.sendgrid.com/v3/mail/send"
+
 // SendGridProvider implements SendGrid API email provider
 type SendGridProvider struct {
-	config         *config.SendGridConfig
-	emailsSentHour int64
-	lastHourReset  time.Time
-	isHealthy      bool
-	lastError      error
+	config    *config.SendGridConfig
+	endpoint  string // sendGridEndpoint, or a local server in tests
+	isHealthy bool
+	lastError error
 }
 
 // SendGridEmail represents the SendGrid API payload structure
 type SendGridEmail struct {
 	Personalizations []SendGridPersonalization `json:"personalizations"`
 	From             SendGridContact           `json:"from"`
+	ReplyTo          *SendGridContact          `json:"reply_to,omitempty"`
 	Subject          string                    `json:"subject"`
 	Content          []SendGridContent         `json:"content"`
 }
@@ -44,22 +48,28 @@ type SendGridContent struct {
 	Value string `json:"value"`
 }
 
+// sendGridReplyTo returns the reply_to contact, or nil to leave it out
+func sendGridReplyTo(replyTo string) *SendGridContact {
+	if replyTo == "" {
+		return nil
+	}
+	return &SendGridContact{Email: replyTo}
+}
+
 // NewSendGridProvider creates a new SendGrid provider
 func NewSendGridProvider(config *config.SendGridConfig) EmailProviderInterface {
 	return &SendGridProvider{
-		config:         config,
-		emailsSentHour: 0,
-		lastHourReset:  time.Now(),
-		isHealthy:      true,
+		config:    config,
+		endpoint:  sendGridEndpoint,
+		isHealthy: true,
 	}
 }
 
 // SendEmail sends a single email via SendGrid API
-func (p *SendGridProvider) SendEmail(ctx context.Context, notification *EmailNotification) error {
-	// Generate HTML email using template
-	htmlBody, err := templates.GenerateEmailHTML(notification.Subject, notification.Body)
+func (p *SendGridProvider) SendEmail(ctx context.Context, notification *EmailNotification) (string, error) {
+	rendered, err := renderEmail(notification.email())
 	if err != nil {
-		return fmt.Errorf("failed to generate email template: %w", err)
+		return "", err
 	}
 
 	// Determine from address
@@ -77,15 +87,16 @@ func (p *SendGridProvider) SendEmail(ctx context.Context, notification *EmailNot
 				},
 			},
 		},
-		From:    SendGridContact{Email: from},
+		From:    SendGridContact{Email: from, Name: notification.FromName},
+		ReplyTo: sendGridReplyTo(notification.ReplyTo),
 		Subject: notification.Subject,
 		Content: []SendGridContent{
-			{Type: "text/plain", Value: notification.Body},
-			{Type: "text/html", Value: htmlBody},
+			{Type: "text/plain", Value: rendered.Text},
+			{Type: "text/html", Value: rendered.HTML},
 		},
 	}
 
-	err = p.sendToSendGrid(ctx, email)
+	messageIDs, err := p.sendToSendGrid(ctx, email)
 
 	// Update statistics
 	if err != nil {
@@ -94,18 +105,19 @@ func (p *SendGridProvider) SendEmail(ctx context.Context, notification *EmailNot
 	} else {
 		p.isHealthy = true
 		p.lastError = nil
-		atomic.AddInt64(&p.emailsSentHour, 1)
 	}
 
-	return err
+	if err != nil {
+		return "", err
+	}
+	return messageIDs[notification.To], nil
 }
 
 // SendBulkEmail sends bulk emails via SendGrid API
-func (p *SendGridProvider) SendBulkEmail(ctx context.Context, notification *BulkEmailNotification) error {
-	// Generate HTML email using template
-	htmlBody, err := templates.GenerateEmailHTML(notification.Subject, notification.Body)
+func (p *SendGridProvider) SendBulkEmail(ctx context.Context, notification *BulkEmailNotification) (map[string]string, error) {
+	rendered, err := renderEmail(notification.email())
 	if err != nil {
-		return fmt.Errorf("failed to generate email template: %w", err)
+		return nil, err
 	}
 
 	// Determine from address
@@ -114,26 +126,25 @@ func (p *SendGridProvider) SendBulkEmail(ctx context.Context, notification *Bulk
 		from = p.config.From
 	}
 
-	// Convert recipients to SendGrid format
-	recipients := make([]SendGridContact, len(notification.To))
+	// One personalization per recipient; recipients sharing one would see each other's addresses
+	personalizations := make([]SendGridPersonalization, len(notification.To))
 	for i, email := range notification.To {
-		recipients[i] = SendGridContact{Email: email}
+		personalizations[i] = SendGridPersonalization{To: []SendGridContact{{Email: email}}}
 	}
 
 	// Prepare SendGrid bulk payload
 	email := SendGridEmail{
-		Personalizations: []SendGridPersonalization{
-			{To: recipients},
-		},
-		From:    SendGridContact{Email: from},
-		Subject: notification.Subject,
+		Personalizations: personalizations,
+		From:             SendGridContact{Email: from, Name: notification.FromName},
+		ReplyTo:          sendGridReplyTo(notification.ReplyTo),
+		Subject:          notification.Subject,
 		Content: []SendGridContent{
-			{Type: "text/plain", Value: notification.Body},
-			{Type: "text/html", Value: htmlBody},
+			{Type: "text/plain", Value: rendered.Text},
+			{Type: "text/html", Value: rendered.HTML},
 		},
 	}
 
-	err = p.sendToSendGrid(ctx, email)
+	messageIDs, err := p.sendToSendGrid(ctx, email)
 
 	// Update statistics
 	if err != nil {
@@ -142,22 +153,27 @@ func (p *SendGridProvider) SendBulkEmail(ctx context.Context, notification *Bulk
 	} else {
 		p.isHealthy = true
 		p.lastError = nil
-		atomic.AddInt64(&p.emailsSentHour, int64(len(notification.To)))
 	}
 
-	return err
+	return messageIDs, err
 }
 
-// sendToSendGrid handles the HTTP request to SendGrid API
-func (p *SendGridProvider) sendToSendGrid(ctx context.Context, email SendGridEmail) error {
+// sendToSendGrid handles the HTTP request to SendGrid API and returns the message IDs keyed by recipient
+func (p *SendGridProvider) sendToSendGrid(ctx context.Context, email SendGridEmail) (messageIDs map[string]string, err error) {
+	ctx, span := tracing.StartSpan(ctx, "provider.sendgrid.send", attribute.String("provider.name", p.GetProviderName()))
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+	}()
+
 	jsonPayload, err := json.Marshal(email)
 	if err != nil {
-		return fmt.Errorf("failed to marshal SendGrid payload: %w", err)
+		return nil, fmt.Errorf("failed to marshal SendGrid payload: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", "https://api.sendgrid.com/v3/mail/send", bytes.NewReader(jsonPayload))
+	req, err := http.NewRequestWithContext(ctx, "POST", p.endpoint, bytes.NewReader(jsonPayload))
 	if err != nil {
-		return fmt.Errorf("failed to create SendGrid request: %w", err)
+		return nil, fmt.Errorf("failed to create SendGrid request: %w", err)
 	}
 
 	req.Header.Set("Authorization", "Bearer "+p.config.APIKey)
@@ -166,15 +182,27 @@ func (p *SendGridProvider) sendToSendGrid(ctx context.Context, email SendGridEma
 	client := &http.Client{Timeout: 30 * time.Second}
 	resp, err := client.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to send SendGrid request: %w", err)
+		return nil, fmt.Errorf("failed to send SendGrid request: %w", err)
 	}
 	defer resp.Body.Close()
 
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
 	if resp.StatusCode != http.StatusAccepted {
-		return fmt.Errorf("SendGrid API returned status %d", resp.StatusCode)
+		return nil, fmt.Errorf("SendGrid API returned status %d", resp.StatusCode)
 	}
 
-	return nil
+	// SendGrid returns one message ID for the whole request in a header
+	var recipients []string
+	for _, personalization := range email.Personalizations {
+		for _, contact := range personalization.To {
+			recipients = append(recipients, contact.Email)
+		}
+	}
+	messageID := resp.Header.Get("X-Message-Id")
+	if messageID == "" {
+		return nil, nil
+	}
+	return recipientMessageIDs(recipients, []string{messageID}), nil
 }
 
 // SupportsBulk returns true as SendGrid supports bulk operations
@@ -191,22 +219,11 @@ func (p *SendGridProvider) GetLimits() ProviderLimits {
 	}
 }
 
-// GetStats returns current SendGrid provider statistics
+// GetStats returns current SendGrid provider health
 func (p *SendGridProvider) GetStats() ProviderStats {
-	// Reset counter if more than an hour has passed
-	if time.Since(p.lastHourReset) > time.Hour {
-		atomic.StoreInt64(&p.emailsSentHour, 0)
-		p.lastHourReset = time.Now()
-	}
-
-	emailsSent := int(atomic.LoadInt64(&p.emailsSentHour))
-	currentLoad := (emailsSent * 100) / p.GetLimits().MaxEmailsPerHour
-
 	return ProviderStats{
-		EmailsSentLastHour: emailsSent,
-		CurrentLoad:        currentLoad,
-		IsHealthy:          p.isHealthy,
-		LastError:          p.lastError,
+		IsHealthy: p.isHealthy,
+		LastError: p.lastError,
 	}
 }
 
@@ -0,0 +1,50 @@
+package providers
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"newsletter-service/internal/config"
+)
+
+// TestSendGridBulkSendKeepsRecipientsApart checks a SendGrid bulk send gives each recipient a personalization
+// of their own, so each to field names exactly one of them
+func TestSendGridBulkSendKeepsRecipientsApart(t *testing.T) {
+	recipients := []string{"ada@example.com", "grace@example.com", "linus@example.com"}
+
+	var bodies [][]byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, body)
+		w.Header().Set("X-Message-Id", "m1")
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	provider := NewSendGridProvider(&config.SendGridConfig{
+		APIKey:           "key",
+		From:             "sender@example.com",
+		MaxEmailsPerHour: 1000,
+		MaxBatchSize:     100,
+		BulkEnabled:      true,
+	}).(*SendGridProvider)
+	provider.endpoint = server.URL
+
+	_, err := provider.SendBulkEmail(context.Background(), &BulkEmailNotification{
+		To:      recipients,
+		Subject: "Weekly",
+		Body:    "<p>Hello</p>",
+	})
+	if err != nil {
+		t.Fatalf("SendBulkEmail() = %v", err)
+	}
+	if len(bodies) != 1 {
+		t.Fatalf("got %d requests, want 1", len(bodies))
+	}
+	if visible := countVisibleRecipients(t, bodies[0], recipients); visible != len(recipients) {
+		t.Errorf("recipients named in to or cc fields = %d, want %d: %s", visible, len(recipients), bodies[0])
+	}
+}
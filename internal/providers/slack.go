@@ -0,0 +1,90 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"newsletter-service/internal/config"
+	"newsletter-service/internal/tracing"
+)
+
+// slackEscaper escapes the characters Slack treats as markup in message text
+var slackEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// SlackChatProvider posts content summaries to a Slack channel through an incoming webhook
+type SlackChatProvider struct {
+	name   string
+	config *config.SlackChatConfig
+	client *http.Client
+}
+
+// NewSlackChatProvider creates a Slack chat destination
+func NewSlackChatProvider(name string, cfg *config.SlackChatConfig, client *http.Client) ChatProvider {
+	return &SlackChatProvider{
+		name:   name,
+		config: cfg,
+		client: client,
+	}
+}
+
+// PostMessage posts the summary with the title in bold and the topic as context
+func (p *SlackChatProvider) PostMessage(ctx context.Context, message *ChatMessage) (err error) {
+	ctx, span := tracing.StartSpan(ctx, "provider.slack.post", attribute.String("provider.name", p.name))
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+	}()
+
+	text := fmt.Sprintf("*%s*\n%s", slackEscaper.Replace(message.Title), slackEscaper.Replace(message.Summary))
+	if message.Topic != "" {
+		text = fmt.Sprintf("_%s_\n%s", slackEscaper.Replace(message.Topic), text)
+	}
+	payload, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return fmt.Errorf("failed to serialize Slack message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.config.WebhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create Slack request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send Slack request: %w", unwrapURLError(err))
+	}
+	defer resp.Body.Close()
+
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("Slack webhook returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// GetProviderType returns the provider type
+func (p *SlackChatProvider) GetProviderType() EmailProvider {
+	return SlackProviderType
+}
+
+// GetProviderName returns the destination name
+func (p *SlackChatProvider) GetProviderName() string {
+	return p.name
+}
+
+// ValidateConfig checks the webhook URL
+func (p *SlackChatProvider) ValidateConfig() error {
+	if !strings.HasPrefix(p.config.WebhookURL, "https://") {
+		return fmt.Errorf("Slack webhook_url must be an https URL")
+	}
+	return nil
+}
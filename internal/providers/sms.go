@@ -0,0 +1,74 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+
+	"newsletter-service/internal/config"
+)
+
+// SMS providers implement EmailProviderInterface so the load balancers, hourly limits and send counter
+// serve the SMS channel unchanged. For them an EmailNotification's To is an E.164 phone number and Body
+// the message text; Subject and From are ignored.
+
+// NewSMSProviderFactory creates the SMS channel's providers from [sms], wrapped with hourly limits counted
+// by counter (nil counts in memory). It returns nil when no SMS provider is enabled.
+func NewSMSProviderFactory(cfg *config.SMSConfig, counter SendCounter) (*ProviderFactory, error) {
+	if len(cfg.Enabled) == 0 {
+		return nil, nil
+	}
+	if counter == nil {
+		counter = NewMemorySendCounter()
+	}
+
+	factory := &ProviderFactory{
+		providers: make([]EmailProviderInterface, 0),
+		warmup:    make(map[string]*WarmupPolicy),
+	}
+
+	for _, providerName := range cfg.Enabled {
+		var provider EmailProviderInterface
+		if twilioConfig, exists := cfg.Twilio[providerName]; exists {
+			provider = NewTwilioSMSProvider(providerName, &twilioConfig)
+		} else if snsConfig, exists := cfg.SNS[providerName]; exists {
+			provider = NewSNSSMSProvider(providerName, &snsConfig)
+		} else {
+			fmt.Printf("Warning: Enabled SMS provider '%s' not found in configuration\n", providerName)
+			continue
+		}
+
+		if err := provider.ValidateConfig(); err != nil {
+			return nil, fmt.Errorf("invalid SMS provider %s: %w", providerName, err)
+		}
+		factory.providers = append(factory.providers, NewLimitedEmailProvider(provider, counter))
+	}
+
+	loadBalancer, err := factory.newLoadBalancer(cfg.LoadBalancing, cfg.Weights)
+	if err != nil {
+		return nil, err
+	}
+	factory.loadBalancer = loadBalancer
+
+	if len(factory.providers) == 0 {
+		return nil, fmt.Errorf("no enabled SMS providers configured")
+	}
+
+	fmt.Printf("Initialized %d SMS providers: %v\n", len(factory.providers), cfg.Enabled)
+	return factory, nil
+}
+
+// sendEachSMS sends a bulk notification as one SMS per recipient; SMS APIs have no shared messages
+func sendEachSMS(ctx context.Context, provider EmailProviderInterface, notification *BulkEmailNotification) (map[string]string, error) {
+	messageIDs := make(map[string]string, len(notification.To))
+	for _, recipient := range notification.To {
+		if err := ctx.Err(); err != nil {
+			return messageIDs, err
+		}
+		messageID, err := provider.SendEmail(ctx, &EmailNotification{To: recipient, Body: notification.Body})
+		if err != nil {
+			return messageIDs, err
+		}
+		messageIDs[recipient] = messageID
+	}
+	return messageIDs, nil
+}
@@ -2,22 +2,31 @@ package providers
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
-	"net/smtp"
-	"sync/atomic"
+	"net/mail"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+
 	"newsletter-service/internal/config"
-	"newsletter-service/internal/providers/templates"
+	"newsletter-service/internal/tracing"
 )
 
 // SMTPConfig holds SMTP-specific configuration
 type SMTPConfig struct {
-	Host     string
-	Port     int
-	Username string
-	Password string
-	From     string
+	Host               string
+	Port               int
+	Username           string
+	Password           string
+	From               string
+	TLSMode            string // One of the SMTPTLS* modes
+	InsecureSkipVerify bool
+	PoolSize           int // Connections kept open for reuse
+	DialTimeout        time.Duration
+	SendTimeout        time.Duration
+	IdleTimeout        time.Duration // Idle connections older than this are redialed
 }
 
 // GetProviderType returns the provider type
@@ -39,126 +48,213 @@ func (c *SMTPConfig) Validate() error {
 	if c.Password == "" {
 		return fmt.Errorf("SMTP password is required")
 	}
+	switch c.TLSMode {
+	case SMTPTLSOpportunistic, SMTPTLSStartTLS, SMTPTLSImplicit, SMTPTLSNone:
+	default:
+		return fmt.Errorf("SMTP tls_mode must be starttls, implicit or none")
+	}
 	return nil
 }
 
 // SMTPEmailProvider implements the enhanced SMTP email provider
 type SMTPEmailProvider struct {
-	name           string
-	config         *SMTPConfig
-	priority       int
-	maxEmailsHour  int
-	emailsSentHour int64
-	lastHourReset  time.Time
-	isHealthy      bool
-	lastError      error
+	name          string
+	config        *SMTPConfig
+	pool          *smtpPool
+	priority      int
+	maxEmailsHour int
+	isHealthy     bool
+	lastError     error
 }
 
 // NewSMTPProvider creates a new SMTP provider (legacy)
 func NewSMTPProvider(config *SMTPConfig) EmailProviderInterface {
 	return &SMTPEmailProvider{
-		config:         config,
-		emailsSentHour: 0,
-		lastHourReset:  time.Now(),
-		isHealthy:      true,
+		config:    config,
+		pool:      newSMTPPool(config),
+		isHealthy: true,
 	}
 }
 
 // NewDynamicSMTPProvider creates a new SMTP provider from dynamic config
 func NewDynamicSMTPProvider(name string, config *config.SMTPProviderConfig) EmailProviderInterface {
+	smtpConfig := convertToSMTPConfig(config)
 	return &SMTPEmailProvider{
-		name:           name,
-		config:         convertToSMTPConfig(config),
-		priority:       config.Priority,
-		maxEmailsHour:  config.MaxEmailsPerHour,
-		emailsSentHour: 0,
-		lastHourReset:  time.Now(),
-		isHealthy:      true,
+		name:          name,
+		config:        smtpConfig,
+		pool:          newSMTPPool(smtpConfig),
+		priority:      config.Priority,
+		maxEmailsHour: config.MaxEmailsPerHour,
+		isHealthy:     true,
 	}
 }
 
 // convertToSMTPConfig converts dynamic config to internal config
 func convertToSMTPConfig(config *config.SMTPProviderConfig) *SMTPConfig {
 	return &SMTPConfig{
-		Host:     config.Host,
-		Port:     config.Port,
-		Username: config.Username,
-		Password: config.Password,
-		From:     config.From,
+		Host:               config.Host,
+		Port:               config.Port,
+		Username:           config.Username,
+		Password:           config.Password,
+		From:               config.From,
+		TLSMode:            config.TLSMode,
+		InsecureSkipVerify: config.InsecureSkipVerify,
+		PoolSize:           config.PoolSize,
+		DialTimeout:        config.DialTimeout,
+		SendTimeout:        config.SendTimeout,
+		IdleTimeout:        config.IdleTimeout,
 	}
 }
 
-// SendEmail sends an email using SMTP
-func (p *SMTPEmailProvider) SendEmail(ctx context.Context, notification *EmailNotification) error {
-	// Generate HTML email using template
-	htmlBody, err := templates.GenerateEmailHTML(notification.Subject, notification.Body)
-	if err != nil {
-		return fmt.Errorf("failed to generate email template: %w", err)
-	}
-
-	auth := smtp.PlainAuth("", p.config.Username, p.config.Password, p.config.Host)
-
-	to := []string{notification.To}
+// SendEmail sends an email using SMTP over a pooled connection
+func (p *SMTPEmailProvider) SendEmail(ctx context.Context, notification *EmailNotification) (string, error) {
+	ctx, span := tracing.StartSpan(ctx, "provider.smtp.SendEmail", attribute.String("provider.name", p.GetProviderName()))
+	defer span.End()
 
-	// Determine from address
-	from := notification.From
-	if from == "" {
-		from = p.config.From
-	}
-	if from == "" {
-		from = p.config.Username
+	conn, err := p.pool.get(ctx)
+	if err != nil {
+		p.recordResult(err)
+		tracing.RecordError(span, err)
+		return "", err
 	}
 
-	msg := []byte(fmt.Sprintf(
-		"From: %s\r\nTo: %s\r\nSubject: %s\r\nContent-Type: text/html; charset=UTF-8\r\n\r\n%s\r\n",
-		from,
-		notification.To,
-		notification.Subject,
-		htmlBody,
-	))
-
-	addr := fmt.Sprintf("%s:%d", p.config.Host, p.config.Port)
-	err = smtp.SendMail(addr, auth, from, to, msg)
-
-	// Update statistics
+	err = p.sendOn(ctx, conn, notification)
+	p.pool.put(conn)
 	if err != nil {
-		p.isHealthy = false
-		p.lastError = err
-	} else {
-		p.isHealthy = true
-		p.lastError = nil
-		atomic.AddInt64(&p.emailsSentHour, 1)
+		tracing.RecordError(span, err)
+		return "", err
 	}
-
-	return err
+	return notification.MessageID, nil
 }
 
-// SendBulkEmail sends bulk emails (SMTP doesn't support true bulk, so send individually)
-func (p *SMTPEmailProvider) SendBulkEmail(ctx context.Context, notification *BulkEmailNotification) error {
+// SendBulkEmail sends bulk emails (SMTP doesn't support true bulk, so send individually over one connection)
+func (p *SMTPEmailProvider) SendBulkEmail(ctx context.Context, notification *BulkEmailNotification) (map[string]string, error) {
 	var lastError error
 	successCount := 0
+	messageIDs := make(map[string]string, len(notification.To))
 
+	var conn *smtpConn
 	for _, recipient := range notification.To {
-		singleNotification := &EmailNotification{
-			To:      recipient,
-			Subject: notification.Subject,
-			Body:    notification.Body,
-			From:    notification.From,
+		// The rest of the batch counts as failed once the caller gives up on it
+		if err := ctx.Err(); err != nil {
+			lastError = err
+			break
+		}
+		singleNotification := notification.single(recipient)
+
+		// Reuse the connection for the whole batch, redialing only after a network error
+		if conn == nil {
+			var err error
+			if conn, err = p.pool.get(ctx); err != nil {
+				p.recordResult(err)
+				lastError = err
+				break
+			}
 		}
 
-		if err := p.SendEmail(ctx, singleNotification); err != nil {
+		if err := p.sendOn(ctx, conn, singleNotification); err != nil {
 			lastError = err
 		} else {
 			successCount++
+			messageIDs[recipient] = singleNotification.MessageID
+		}
+
+		if conn.broken {
+			p.pool.put(conn)
+			conn = nil
 		}
 	}
+	if conn != nil {
+		p.pool.put(conn)
+	}
 
 	// Consider successful if at least 50% succeeded
 	if successCount < len(notification.To)/2 {
-		return fmt.Errorf("bulk email failed: %d/%d succeeded, last error: %v", successCount, len(notification.To), lastError)
+		return messageIDs, fmt.Errorf("bulk email failed: %d/%d succeeded, last error: %v", successCount, len(notification.To), lastError)
 	}
 
-	return nil
+	return messageIDs, nil
+}
+
+// sendOn renders and sends one email over a pooled connection and updates the provider statistics
+func (p *SMTPEmailProvider) sendOn(ctx context.Context, conn *smtpConn, notification *EmailNotification) error {
+	rendered, err := renderEmail(notification.email())
+	if err != nil {
+		return err
+	}
+
+	from := p.fromAddress(notification)
+	messageID := p.AssignMessageID(notification)
+
+	msg, err := alternativeMessage(fmt.Sprintf(
+		"%sTo: %s\r\nSubject: %s\r\nMessage-ID: %s\r\n",
+		senderHeaders(notification.FromName, from, notification.ReplyTo),
+		notification.To,
+		notification.Subject,
+		messageID,
+	), rendered)
+	if err != nil {
+		return fmt.Errorf("failed to build email: %w", err)
+	}
+
+	err = p.pool.send(ctx, conn, from, []string{notification.To}, msg)
+	p.recordResult(err)
+	return err
+}
+
+// fromAddress determines the from address: the notification's, else the configured one, else the username
+func (p *SMTPEmailProvider) fromAddress(notification *EmailNotification) string {
+	if notification.From != "" {
+		return notification.From
+	}
+	if p.config.From != "" {
+		return p.config.From
+	}
+	return p.config.Username
+}
+
+// AssignMessageID sets the email's Message-ID header if it has none and returns it. SMTP message IDs are
+// chosen by the sender, so the ID is known before the email is sent.
+func (p *SMTPEmailProvider) AssignMessageID(notification *EmailNotification) string {
+	if notification.MessageID == "" {
+		notification.MessageID = newMessageID(p.fromAddress(notification))
+	}
+	return notification.MessageID
+}
+
+// senderHeaders returns the From header, with the display name when there is one, and the Reply-To header
+// when replies go elsewhere. The envelope sender stays the bare from address.
+func senderHeaders(fromName, from, replyTo string) string {
+	headers := "From: " + from + "\r\n"
+	if fromName != "" {
+		headers = "From: " + (&mail.Address{Name: fromName, Address: from}).String() + "\r\n"
+	}
+	if replyTo != "" {
+		headers += "Reply-To: " + (&mail.Address{Address: replyTo}).String() + "\r\n"
+	}
+	return headers
+}
+
+// newMessageID generates a unique Message-ID in the sender's domain, e.g. <3f2a...@example.com>
+func newMessageID(from string) string {
+	domain := emailDomain(from)
+	if domain == "" {
+		domain = "localhost"
+	}
+	token := make([]byte, 16)
+	_, _ = rand.Read(token)
+	return fmt.Sprintf("<%s@%s>", hex.EncodeToString(token), domain)
+}
+
+// recordResult updates health and hourly counters after a send attempt
+func (p *SMTPEmailProvider) recordResult(err error) {
+	if err != nil {
+		p.isHealthy = false
+		p.lastError = err
+	} else {
+		p.isHealthy = true
+		p.lastError = nil
+	}
 }
 
 // SupportsBulk returns false as SMTP doesn't support true bulk operations
@@ -180,22 +276,11 @@ func (p *SMTPEmailProvider) GetLimits() ProviderLimits {
 	}
 }
 
-// GetStats returns current provider statistics
+// GetStats returns current provider health
 func (p *SMTPEmailProvider) GetStats() ProviderStats {
-	// Reset counter if more than an hour has passed
-	if time.Since(p.lastHourReset) > time.Hour {
-		atomic.StoreInt64(&p.emailsSentHour, 0)
-		p.lastHourReset = time.Now()
-	}
-
-	emailsSent := int(atomic.LoadInt64(&p.emailsSentHour))
-	currentLoad := (emailsSent * 100) / p.GetLimits().MaxEmailsPerHour
-
 	return ProviderStats{
-		EmailsSentLastHour: emailsSent,
-		CurrentLoad:        currentLoad,
-		IsHealthy:          p.isHealthy,
-		LastError:          p.lastError,
+		IsHealthy: p.isHealthy,
+		LastError: p.lastError,
 	}
 }
 
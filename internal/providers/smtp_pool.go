@@ -0,0 +1,238 @@
+package providers
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"time"
+)
+
+// SMTP TLS modes
+const (
+	SMTPTLSOpportunistic = ""         // STARTTLS when the server offers it
+	SMTPTLSStartTLS      = "starttls" // STARTTLS is required
+	SMTPTLSImplicit      = "implicit" // TLS from the first byte, usually port 465
+	SMTPTLSNone          = "none"     // Plain text, for local test servers only
+)
+
+// Pool defaults used when the SMTP provider config leaves them unset
+const (
+	defaultSMTPPoolSize    = 4
+	defaultSMTPDialTimeout = 10 * time.Second
+	defaultSMTPSendTimeout = 30 * time.Second
+	defaultSMTPIdleTimeout = time.Minute
+)
+
+// smtpPool keeps authenticated SMTP connections open between messages, so large sends skip the
+// dial, TLS handshake and AUTH round trips for every email
+type smtpPool struct {
+	config *SMTPConfig
+	idle   chan *smtpConn
+	open   chan struct{} // One token per open connection, capped at the pool size
+}
+
+// smtpConn is one pooled connection
+type smtpConn struct {
+	client   *smtp.Client
+	conn     net.Conn
+	lastUsed time.Time
+	broken   bool // Set after a network error; broken connections are closed instead of reused
+}
+
+func newSMTPPool(config *SMTPConfig) *smtpPool {
+	size := config.PoolSize
+	if size <= 0 {
+		size = defaultSMTPPoolSize
+	}
+	return &smtpPool{
+		config: config,
+		idle:   make(chan *smtpConn, size),
+		open:   make(chan struct{}, size),
+	}
+}
+
+// get returns an idle connection, or dials a new one while the pool has room, or waits for one to be returned
+func (p *smtpPool) get(ctx context.Context) (*smtpConn, error) {
+	for {
+		// Prefer idle connections over dialing
+		select {
+		case c := <-p.idle:
+			if p.alive(c) {
+				return c, nil
+			}
+			continue
+		default:
+		}
+
+		select {
+		case c := <-p.idle:
+			if p.alive(c) {
+				return c, nil
+			}
+		case p.open <- struct{}{}:
+			c, err := p.dial(ctx)
+			if err != nil {
+				<-p.open
+				return nil, err
+			}
+			return c, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// put returns a connection after use, closing it if it broke
+func (p *smtpPool) put(c *smtpConn) {
+	if c.broken {
+		p.discard(c)
+		return
+	}
+	c.lastUsed = time.Now()
+	p.idle <- c
+}
+
+// alive checks an idle connection before reuse, closing it if it idled too long or the server hung up
+func (p *smtpPool) alive(c *smtpConn) bool {
+	idleTimeout := p.config.IdleTimeout
+	if idleTimeout <= 0 {
+		idleTimeout = defaultSMTPIdleTimeout
+	}
+	if time.Since(c.lastUsed) > idleTimeout {
+		p.discard(c)
+		return false
+	}
+
+	c.conn.SetDeadline(time.Now().Add(p.sendTimeout()))
+	if err := c.client.Noop(); err != nil {
+		p.discard(c)
+		return false
+	}
+	return true
+}
+
+func (p *smtpPool) discard(c *smtpConn) {
+	c.conn.SetDeadline(time.Now().Add(time.Second))
+	if err := c.client.Quit(); err != nil {
+		c.client.Close()
+	}
+	<-p.open
+}
+
+// dial connects, negotiates TLS for the configured mode and authenticates
+func (p *smtpPool) dial(ctx context.Context) (*smtpConn, error) {
+	dialTimeout := p.config.DialTimeout
+	if dialTimeout <= 0 {
+		dialTimeout = defaultSMTPDialTimeout
+	}
+	dialer := &net.Dialer{Timeout: dialTimeout, KeepAlive: 30 * time.Second}
+	addr := fmt.Sprintf("%s:%d", p.config.Host, p.config.Port)
+	tlsConfig := &tls.Config{ServerName: p.config.Host, InsecureSkipVerify: p.config.InsecureSkipVerify}
+
+	var conn net.Conn
+	var err error
+	if p.config.TLSMode == SMTPTLSImplicit {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: tlsConfig}).DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to SMTP server %s: %w", addr, err)
+	}
+	conn.SetDeadline(time.Now().Add(dialTimeout))
+
+	client, err := smtp.NewClient(conn, p.config.Host)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to start SMTP session with %s: %w", addr, err)
+	}
+
+	if err := p.startTLS(client, tlsConfig); err != nil {
+		client.Close()
+		return nil, err
+	}
+
+	if p.config.Username != "" {
+		if ok, _ := client.Extension("AUTH"); ok {
+			auth := smtp.PlainAuth("", p.config.Username, p.config.Password, p.config.Host)
+			if err := client.Auth(auth); err != nil {
+				client.Close()
+				return nil, fmt.Errorf("SMTP authentication failed: %w", err)
+			}
+		}
+	}
+
+	return &smtpConn{client: client, conn: conn, lastUsed: time.Now()}, nil
+}
+
+func (p *smtpPool) startTLS(client *smtp.Client, tlsConfig *tls.Config) error {
+	switch p.config.TLSMode {
+	case SMTPTLSImplicit, SMTPTLSNone:
+		return nil
+	}
+
+	if ok, _ := client.Extension("STARTTLS"); !ok {
+		if p.config.TLSMode == SMTPTLSStartTLS {
+			return fmt.Errorf("SMTP server %s does not support STARTTLS", p.config.Host)
+		}
+		return nil
+	}
+	if err := client.StartTLS(tlsConfig); err != nil {
+		return fmt.Errorf("SMTP STARTTLS failed: %w", err)
+	}
+	return nil
+}
+
+// send delivers one message over a pooled connection within the send timeout. After a rejection by the
+// server (an SMTP reply) the transaction is reset so the connection can be reused; any other error marks
+// the connection broken.
+func (p *smtpPool) send(ctx context.Context, c *smtpConn, from string, to []string, msg []byte) error {
+	deadline := time.Now().Add(p.sendTimeout())
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	c.conn.SetDeadline(deadline)
+
+	err := p.transact(c, from, to, msg)
+	if err == nil {
+		return nil
+	}
+
+	var reply *textproto.Error
+	if !errors.As(err, &reply) || c.client.Reset() != nil {
+		c.broken = true
+	}
+	return err
+}
+
+// transact runs one MAIL / RCPT / DATA exchange
+func (p *smtpPool) transact(c *smtpConn, from string, to []string, msg []byte) error {
+	if err := c.client.Mail(from); err != nil {
+		return err
+	}
+	for _, recipient := range to {
+		if err := c.client.Rcpt(recipient); err != nil {
+			return err
+		}
+	}
+
+	w, err := c.client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	return w.Close()
+}
+
+func (p *smtpPool) sendTimeout() time.Duration {
+	if p.config.SendTimeout > 0 {
+		return p.config.SendTimeout
+	}
+	return defaultSMTPSendTimeout
+}
@@ -0,0 +1,204 @@
+package providers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"go.opentelemetry.io/otel/attribute"
+
+	"newsletter-service/internal/config"
+	"newsletter-service/internal/tracing"
+)
+
+// SNS SMS types; transactional messages are delivered with higher reliability at a higher price
+const (
+	SNSSMSTransactional = "Transactional"
+	SNSSMSPromotional   = "Promotional"
+)
+
+// SNSSMSProvider publishes SMS directly to phone numbers through Amazon SNS
+type SNSSMSProvider struct {
+	name      string
+	config    *config.SNSSMSConfig
+	client    *http.Client
+	signer    *v4.Signer
+	isHealthy bool
+	lastError error
+
+	// AWS credentials are loaded on first send, as they may come from the instance role
+	awsOnce sync.Once
+	awsCfg  aws.Config
+	awsErr  error
+}
+
+// snsPublishResponse is the XML body of a successful Publish call
+type snsPublishResponse struct {
+	MessageID string `xml:"PublishResult>MessageId"`
+}
+
+// NewSNSSMSProvider creates an Amazon SNS SMS provider
+func NewSNSSMSProvider(name string, cfg *config.SNSSMSConfig) EmailProviderInterface {
+	return &SNSSMSProvider{
+		name:      name,
+		config:    cfg,
+		client:    &http.Client{Timeout: 30 * time.Second},
+		signer:    v4.NewSigner(),
+		isHealthy: true,
+	}
+}
+
+// SendEmail publishes the notification's Body as an SMS to the phone number in To and returns the SNS message ID
+func (p *SNSSMSProvider) SendEmail(ctx context.Context, notification *EmailNotification) (string, error) {
+	messageID, err := p.publish(ctx, notification.To, notification.Body)
+
+	// Update statistics
+	if err != nil {
+		p.isHealthy = false
+		p.lastError = err
+		return "", err
+	}
+	p.isHealthy = true
+	p.lastError = nil
+	return messageID, nil
+}
+
+// SendBulkEmail publishes one SMS per recipient
+func (p *SNSSMSProvider) SendBulkEmail(ctx context.Context, notification *BulkEmailNotification) (map[string]string, error) {
+	return sendEachSMS(ctx, p, notification)
+}
+
+// publish calls the SNS Publish action with a SigV4-signed query API request
+func (p *SNSSMSProvider) publish(ctx context.Context, phone, message string) (messageID string, err error) {
+	ctx, span := tracing.StartSpan(ctx, "provider.sns.publish", attribute.String("provider.name", p.name))
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+	}()
+
+	p.awsOnce.Do(func() {
+		p.awsCfg, p.awsErr = awsconfig.LoadDefaultConfig(context.WithoutCancel(ctx), awsconfig.WithRegion(p.config.Region))
+	})
+	if p.awsErr != nil {
+		return "", fmt.Errorf("failed to load AWS config: %w", p.awsErr)
+	}
+	credentials, err := p.awsCfg.Credentials.Retrieve(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get AWS credentials: %w", err)
+	}
+
+	smsType := p.config.SMSType
+	if smsType == "" {
+		smsType = SNSSMSPromotional
+	}
+	form := url.Values{}
+	form.Set("Action", "Publish")
+	form.Set("Version", "2010-03-31")
+	form.Set("PhoneNumber", phone)
+	form.Set("Message", message)
+	form.Set("MessageAttributes.entry.1.Name", "AWS.SNS.SMS.SMSType")
+	form.Set("MessageAttributes.entry.1.Value.DataType", "String")
+	form.Set("MessageAttributes.entry.1.Value.StringValue", smsType)
+	if p.config.SenderID != "" {
+		form.Set("MessageAttributes.entry.2.Name", "AWS.SNS.SMS.SenderID")
+		form.Set("MessageAttributes.entry.2.Value.DataType", "String")
+		form.Set("MessageAttributes.entry.2.Value.StringValue", p.config.SenderID)
+	}
+	body := form.Encode()
+
+	endpoint := fmt.Sprintf("https://sns.%s.amazonaws.com/", p.config.Region)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to create SNS request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	payloadHash := sha256.Sum256([]byte(body))
+	if err := p.signer.SignHTTP(ctx, credentials, req, hex.EncodeToString(payloadHash[:]), "sns", p.config.Region, time.Now()); err != nil {
+		return "", fmt.Errorf("failed to sign SNS request: %w", err)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to send SNS request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+	if resp.StatusCode != http.StatusOK {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return "", fmt.Errorf("SNS returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(detail)))
+	}
+
+	var result snsPublishResponse
+	if err := xml.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&result); err != nil {
+		return "", nil
+	}
+	return result.MessageID, nil
+}
+
+// SupportsBulk returns false; SNS publishes to one phone number at a time
+func (p *SNSSMSProvider) SupportsBulk() bool {
+	return false
+}
+
+// GetLimits returns SNS provider limitations
+func (p *SNSSMSProvider) GetLimits() ProviderLimits {
+	return ProviderLimits{
+		MaxEmailsPerHour: p.config.MaxPerHour,
+		MaxBatchSize:     1,
+		SupportsBulk:     false,
+	}
+}
+
+// GetStats returns current SNS provider health
+func (p *SNSSMSProvider) GetStats() ProviderStats {
+	return ProviderStats{
+		IsHealthy: p.isHealthy,
+		LastError: p.lastError,
+	}
+}
+
+// GetProviderType returns the provider type
+func (p *SNSSMSProvider) GetProviderType() EmailProvider {
+	return SMSProviderType
+}
+
+// GetProviderName returns the provider name
+func (p *SNSSMSProvider) GetProviderName() string {
+	return p.name
+}
+
+// GetPriority returns provider priority (lower = higher priority)
+func (p *SNSSMSProvider) GetPriority() int {
+	return p.config.Priority
+}
+
+// IsEnabled returns true if provider is enabled
+func (p *SNSSMSProvider) IsEnabled() bool {
+	return true
+}
+
+// ValidateConfig checks the region and SMS type
+func (p *SNSSMSProvider) ValidateConfig() error {
+	if p.config.Region == "" {
+		return fmt.Errorf("SNS region is required")
+	}
+	switch p.config.SMSType {
+	case "", SNSSMSTransactional, SNSSMSPromotional:
+		return nil
+	default:
+		return fmt.Errorf("SNS sms_type must be Transactional or Promotional")
+	}
+}
@@ -0,0 +1,100 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"newsletter-service/internal/config"
+	"newsletter-service/internal/tracing"
+)
+
+// telegramAPIBase is the Bot API root; methods are called at <base><token>/<method>
+const telegramAPIBase = "https://api.telegram.org/bot"
+
+// TelegramChatProvider posts content summaries to a Telegram chat or channel as a bot
+type TelegramChatProvider struct {
+	name   string
+	config *config.TelegramChatConfig
+	client *http.Client
+}
+
+// telegramResponse is the Bot API's reply envelope; description explains a failed call
+type telegramResponse struct {
+	OK          bool   `json:"ok"`
+	Description string `json:"description"`
+}
+
+// NewTelegramChatProvider creates a Telegram chat destination
+func NewTelegramChatProvider(name string, cfg *config.TelegramChatConfig, client *http.Client) ChatProvider {
+	return &TelegramChatProvider{
+		name:   name,
+		config: cfg,
+		client: client,
+	}
+}
+
+// PostMessage sends the summary as plain text, so nothing in the content is read as Telegram markup
+func (p *TelegramChatProvider) PostMessage(ctx context.Context, message *ChatMessage) (err error) {
+	ctx, span := tracing.StartSpan(ctx, "provider.telegram.post", attribute.String("provider.name", p.name))
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+	}()
+
+	text := message.Title + "\n\n" + message.Summary
+	if message.Topic != "" {
+		text = message.Topic + ": " + text
+	}
+	payload, err := json.Marshal(map[string]interface{}{
+		"chat_id":                  p.config.ChatID,
+		"text":                     text,
+		"disable_web_page_preview": true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to serialize Telegram message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, telegramAPIBase+p.config.BotToken+"/sendMessage", bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create Telegram request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send Telegram request: %w", unwrapURLError(err))
+	}
+	defer resp.Body.Close()
+
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+	var result telegramResponse
+	json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&result)
+	if resp.StatusCode != http.StatusOK || !result.OK {
+		return fmt.Errorf("Telegram API returned status %d: %s", resp.StatusCode, result.Description)
+	}
+	return nil
+}
+
+// GetProviderType returns the provider type
+func (p *TelegramChatProvider) GetProviderType() EmailProvider {
+	return TelegramProviderType
+}
+
+// GetProviderName returns the destination name
+func (p *TelegramChatProvider) GetProviderName() string {
+	return p.name
+}
+
+// ValidateConfig checks the bot token and chat
+func (p *TelegramChatProvider) ValidateConfig() error {
+	if p.config.BotToken == "" || p.config.ChatID == "" {
+		return fmt.Errorf("Telegram bot_token and chat_id are required")
+	}
+	return nil
+}
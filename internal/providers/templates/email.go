@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"html/template"
+	"regexp"
 	"strings"
 )
 
@@ -11,20 +12,26 @@ const (
 	// Base email template with proper styling and unsubscribe mechanism
 	BaseEmailTemplate = `
 <!DOCTYPE html>
-<html>
+<html lang="{{.Text.Lang}}">
 <head>
     <meta charset="UTF-8">
     <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <meta name="color-scheme" content="light dark">
+    <meta name="supported-color-schemes" content="light dark">
     <title>{{.Subject}}</title>
     <style>
+        :root {
+            color-scheme: light dark;
+            supported-color-schemes: light dark;
+        }
         body {
-            font-family: 'Arial', sans-serif;
+            font-family: {{fontFamily .Branding.FontFamily}};
             line-height: 1.6;
-            color: #333;
+            color: {{.Branding.TextColor}};
             max-width: 600px;
             margin: 0 auto;
             padding: 20px;
-            background-color: #f4f4f4;
+            background-color: {{.Branding.BackgroundColor}};
         }
         .email-container {
             background-color: white;
@@ -34,19 +41,19 @@ const (
         }
         .header {
             text-align: center;
-            border-bottom: 2px solid #007bff;
+            border-bottom: 2px solid {{.Branding.PrimaryColor}};
             padding-bottom: 20px;
             margin-bottom: 30px;
         }
         .header h1 {
-            color: #007bff;
+            color: {{.Branding.PrimaryColor}};
             margin: 0;
         }
         .content {
             margin-bottom: 30px;
         }
         .content h2 {
-            color: #333;
+            color: {{.Branding.TextColor}};
             margin-top: 0;
         }
         .content p {
@@ -66,8 +73,15 @@ const (
         .unsubscribe-link:hover {
             text-decoration: underline;
         }
+        .referral-link {
+            color: {{.Branding.PrimaryColor}};
+        }
+        .header img {
+            max-width: 200px;
+            max-height: 80px;
+        }
         .topic-tag {
-            background-color: #007bff;
+            background-color: {{.Branding.PrimaryColor}};
             color: white;
             padding: 2px 8px;
             border-radius: 12px;
@@ -84,12 +98,63 @@ const (
                 padding: 20px;
             }
         }
+        @media (prefers-color-scheme: dark) {
+            body {
+                background-color: {{.Branding.DarkBackgroundColor}} !important;
+                color: {{.Branding.DarkTextColor}} !important;
+            }
+            .email-container {
+                background-color: #1e1e1e !important;
+                box-shadow: none;
+            }
+            .header {
+                border-bottom-color: {{.Branding.DarkPrimaryColor}} !important;
+            }
+            .header h1 {
+                color: {{.Branding.DarkPrimaryColor}} !important;
+            }
+            .content h2 {
+                color: {{.Branding.DarkTextColor}} !important;
+            }
+            .content a, .referral-link {
+                color: {{.Branding.DarkPrimaryColor}} !important;
+            }
+            .topic-tag {
+                background-color: {{.Branding.DarkPrimaryColor}} !important;
+            }
+            .footer, .unsubscribe-link {
+                color: #aaaaaa !important;
+            }
+            .footer {
+                border-top-color: #444444 !important;
+            }
+            .logo-light {
+                display: none !important;
+            }
+            .logo-dark {
+                display: inline-block !important;
+            }
+        }
     </style>
 </head>
 <body>
+    {{if .PreviewText}}
+    <div style="display:none;max-height:0;max-width:0;overflow:hidden;opacity:0;font-size:1px;line-height:1px;color:#f4f4f4;">
+        {{.PreviewText}}{{previewPadding}}
+    </div>
+    {{end}}
     <div class="email-container">
         <div class="header">
-            <h1>Newsletter</h1>
+            {{if .Branding.LogoURL}}
+            {{if ne .Branding.DarkLogoURL .Branding.LogoURL}}
+            <img src="{{.Branding.LogoURL}}" alt="{{.Branding.Name}}" class="logo-light">
+            <img src="{{.Branding.DarkLogoURL}}" alt="{{.Branding.Name}}" class="logo-dark" style="display:none;">
+            {{else}}
+            <img src="{{.Branding.LogoURL}}" alt="{{.Branding.Name}}">
+            {{end}}
+            {{else}}
+            <h1>{{.Branding.Name}}</h1>
+            {{end}}
             {{if .TopicName}}
             <span class="topic-tag">{{.TopicName}}</span>
             {{end}}
@@ -103,17 +168,25 @@ const (
         </div>
         
         <div class="footer">
-            <p>You received this email because you subscribed to our newsletter.</p>
+            <p>{{.Text.SubscribedNotice}}</p>
             {{if .UnsubscribeURL}}
             <p>
                 <a href="{{.UnsubscribeURL}}" class="unsubscribe-link">
-                    Unsubscribe from this newsletter
+                    {{.Text.Unsubscribe}}
                 </a>
             </p>
             {{end}}
-            <p>© 2025 Newsletter Service. All rights reserved.</p>
+            {{if .ReferralURL}}
+            <p>
+                {{.Text.Refer}} <a href="{{.ReferralURL}}" class="referral-link">{{.Text.ReferLink}}</a>
+            </p>
+            {{end}}
+            <p>{{.Branding.FooterText}}</p>
         </div>
     </div>
+    {{if .OpenTrackingURL}}
+    <img src="{{.OpenTrackingURL}}" width="1" height="1" alt="" style="display:none;">
+    {{end}}
 </body>
 </html>`
 
@@ -127,22 +200,95 @@ const (
 {{.Body}}
 
 ---
-You received this email because you subscribed to our newsletter.
+{{.Text.SubscribedNotice}}
 {{if .UnsubscribeURL}}
-To unsubscribe, visit: {{.UnsubscribeURL}}
+{{.Text.UnsubscribeAt}} {{.UnsubscribeURL}}
+{{end}}
+{{if .ReferralURL}}
+{{.Text.Refer}} {{.ReferralURL}}
 {{end}}
 
-© 2025 Newsletter Service. All rights reserved.
+{{.Branding.FooterText}}
 `
 )
 
+// Defaults for emails sent without branding
+const (
+	DefaultBrandName           = "Newsletter"
+	DefaultPrimaryColor        = "#007bff"
+	DefaultFooterText          = "© 2025 Newsletter Service. All rights reserved."
+	DefaultBackgroundColor     = "#f4f4f4"
+	DefaultTextColor           = "#333333"
+	DefaultFontFamily          = "Arial, sans-serif"
+	DefaultDarkBackgroundColor = "#121212"
+	DefaultDarkTextColor       = "#e4e4e4"
+)
+
+// Branding is the look of an organization's or topic's emails. Empty fields use the defaults.
+type Branding struct {
+	Name         string // Shown in the header, and as the logo's alt text
+	LogoURL      string
+	PrimaryColor string // Hex, e.g. #007bff
+	FooterText   string
+
+	// Theme; colors are hex. The dark mode ones apply when the reader's client prefers a dark color scheme.
+	DarkLogoURL         string // Defaults to LogoURL
+	BackgroundColor     string
+	TextColor           string
+	FontFamily          string // CSS font stack; one with characters outside fontFamilyPattern uses the default
+	DarkPrimaryColor    string // Defaults to PrimaryColor
+	DarkBackgroundColor string
+	DarkTextColor       string
+}
+
+// Resolved returns b with its empty fields set to the defaults the template renders with; b may be nil
+func (b *Branding) Resolved() Branding {
+	return b.withDefaults(StringsFor(""))
+}
+
+// withDefaults returns b with its empty fields set to the defaults, the footer in the email's language; b may
+// be nil
+func (b *Branding) withDefaults(text Strings) Branding {
+	var given Branding
+	if b != nil {
+		given = *b
+	}
+	pick := func(value, fallback string) string {
+		if value != "" {
+			return value
+		}
+		return fallback
+	}
+
+	branding := Branding{
+		Name:                pick(given.Name, DefaultBrandName),
+		LogoURL:             given.LogoURL,
+		PrimaryColor:        pick(given.PrimaryColor, DefaultPrimaryColor),
+		FooterText:          pick(given.FooterText, text.FooterText),
+		BackgroundColor:     pick(given.BackgroundColor, DefaultBackgroundColor),
+		TextColor:           pick(given.TextColor, DefaultTextColor),
+		FontFamily:          pick(given.FontFamily, DefaultFontFamily),
+		DarkBackgroundColor: pick(given.DarkBackgroundColor, DefaultDarkBackgroundColor),
+		DarkTextColor:       pick(given.DarkTextColor, DefaultDarkTextColor),
+	}
+	branding.DarkLogoURL = pick(given.DarkLogoURL, branding.LogoURL)
+	branding.DarkPrimaryColor = pick(given.DarkPrimaryColor, branding.PrimaryColor)
+	return branding
+}
+
 type EmailTemplateData struct {
-	Subject        string
-	Body           template.HTML
-	TopicName      string
-	UnsubscribeURL string
-	SubscriberID   uint
-	ContentID      uint
+	Subject         string
+	PreviewText     string // Preheader shown after the subject in inbox lists, hidden in the email
+	Body            template.HTML
+	TopicName       string
+	UnsubscribeURL  string
+	OpenTrackingURL string
+	ReferralURL     string // The recipient's referral link; the footer invites them to share it
+	SubscriberID    uint
+	ContentID       uint
+	Branding        *Branding // Nil uses the default branding
+	Locale          string    // Language of the template's own text; English when empty or unsupported
+	Text            Strings   // Set from Locale when rendering
 }
 
 // Legacy EmailData for backward compatibility
@@ -151,20 +297,41 @@ type EmailData struct {
 	Body    template.HTML
 }
 
-// GenerateEmailHTML generates a styled HTML email from template data
-func GenerateEmailHTMLWithData(data EmailTemplateData) (string, error) {
-	tmpl, err := template.New("email").Parse(BaseEmailTemplate)
-	if err != nil {
-		return "", fmt.Errorf("failed to parse email template: %w", err)
+// previewPaddingRepeat is how many invisible spacers follow the preview text, so inboxes don't fill the rest of
+// the preview with the header and body
+const previewPaddingRepeat = 90
+
+// fontFamilyPattern is what a font stack may contain: names, quotes, commas and spaces, nothing that could end
+// the CSS declaration it is written into
+var fontFamilyPattern = regexp.MustCompile(`^[A-Za-z0-9 ,'"_-]{1,200}$`)
+
+// templateFuncs are the functions available to the HTML template
+var templateFuncs = template.FuncMap{
+	"previewPadding": func() template.HTML {
+		return template.HTML(strings.Repeat("&#847;&zwnj;&nbsp;", previewPaddingRepeat))
+	},
+	"fontFamily": FontFamilyCSS,
+}
+
+// FontFamilyCSS writes a font stack into CSS, which html/template would otherwise reject for its quotes. Stacks
+// outside fontFamilyPattern give the default.
+func FontFamilyCSS(stack string) template.CSS {
+	if !fontFamilyPattern.MatchString(stack) {
+		stack = DefaultFontFamily
 	}
+	return template.CSS(stack)
+}
 
+// GenerateEmailHTML generates a styled HTML email from template data, with the HTML template in use (see Use)
+func GenerateEmailHTMLWithData(data EmailTemplateData) (string, error) {
 	// Convert plain text body to HTML if needed
 	if !strings.Contains(string(data.Body), "<") {
 		data.Body = template.HTML(convertToHTMLParagraphs(string(data.Body)))
 	}
+	data = prepareData(data)
 
 	var buf bytes.Buffer
-	if err := tmpl.Execute(&buf, data); err != nil {
+	if err := currentTemplates().html.Execute(&buf, data); err != nil {
 		return "", fmt.Errorf("failed to execute email template: %w", err)
 	}
 
@@ -173,36 +340,91 @@ func GenerateEmailHTMLWithData(data EmailTemplateData) (string, error) {
 
 // GenerateEmailHTML generates a styled HTML email (backward compatibility)
 func GenerateEmailHTML(subject, body string) (string, error) {
+	return RenderEmailHTML(Email{Subject: subject, Body: body})
+}
+
+// Email is what providers render into the HTML template
+type Email struct {
+	Subject     string
+	Body        string // Plain text; line breaks become paragraphs
+	PreviewText string
+	Branding    *Branding // Nil uses the default branding
+	Locale      string    // Language of the template's own text, such as the unsubscribe link
+	ReferralURL string    // The recipient's referral link, if any
+}
+
+// RenderEmailHTML generates a styled HTML email in the email's branding
+func RenderEmailHTML(email Email) (string, error) {
 	data := EmailTemplateData{
-		Subject: subject,
-		Body:    template.HTML(convertToHTMLParagraphs(body)),
+		Subject:     email.Subject,
+		PreviewText: email.PreviewText,
+		Body:        template.HTML(convertToHTMLParagraphs(email.Body)),
+		Branding:    email.Branding,
+		Locale:      email.Locale,
+		ReferralURL: email.ReferralURL,
 	}
 	return GenerateEmailHTMLWithData(data)
 }
 
-// GenerateEmailHTMLWithUnsubscribe generates HTML email with unsubscribe link
+// GenerateEmailHTMLWithUnsubscribe generates HTML email with unsubscribe link and open tracking pixel
 func GenerateEmailHTMLWithUnsubscribe(data EmailTemplateData, baseURL string) (string, error) {
 	if baseURL != "" && data.SubscriberID > 0 && data.ContentID > 0 {
 		data.UnsubscribeURL = fmt.Sprintf("%s/unsubscribe?subscriber=%d&content=%d",
 			strings.TrimRight(baseURL, "/"), data.SubscriberID, data.ContentID)
+		data.OpenTrackingURL = fmt.Sprintf("%s/track/open?subscriber=%d&content=%d",
+			strings.TrimRight(baseURL, "/"), data.SubscriberID, data.ContentID)
 	}
 
 	return GenerateEmailHTMLWithData(data)
 }
 
-// GenerateEmailText generates plain text email
+// GenerateEmailText generates plain text email with the text template in use. An HTML body is converted to
+// text first (see HTMLToText).
 func GenerateEmailText(data EmailTemplateData) (string, error) {
-	tmpl, err := template.New("email-text").Parse(PlainTextTemplate)
-	if err != nil {
-		return "", fmt.Errorf("failed to parse text template: %w", err)
-	}
+	data.Body = template.HTML(HTMLToText(string(data.Body)))
+	data = prepareData(data)
 
 	var buf bytes.Buffer
-	if err := tmpl.Execute(&buf, data); err != nil {
+	if err := currentTemplates().text.Execute(&buf, data); err != nil {
 		return "", fmt.Errorf("failed to execute text template: %w", err)
 	}
 
-	return buf.String(), nil
+	return tidyText(buf.String()), nil
+}
+
+// Rendered is an email's HTML and its plain-text alternative, sent together as multipart/alternative
+type Rendered struct {
+	HTML string
+	Text string
+}
+
+// RenderEmail renders both parts of an email in its branding. The text part is generated from the body, so
+// content written only in HTML still reads well in clients that show plain text.
+func RenderEmail(email Email) (Rendered, error) {
+	htmlBody, err := RenderEmailHTML(email)
+	if err != nil {
+		return Rendered{}, err
+	}
+	text, err := GenerateEmailText(EmailTemplateData{
+		Subject:     email.Subject,
+		PreviewText: email.PreviewText,
+		Body:        template.HTML(email.Body),
+		Branding:    email.Branding,
+		Locale:      email.Locale,
+		ReferralURL: email.ReferralURL,
+	})
+	if err != nil {
+		return Rendered{}, err
+	}
+	return Rendered{HTML: htmlBody, Text: text}, nil
+}
+
+// prepareData fills in the template's own strings and the branding defaults
+func prepareData(data EmailTemplateData) EmailTemplateData {
+	data.Text = StringsFor(data.Locale)
+	branding := data.Branding.withDefaults(data.Text)
+	data.Branding = &branding
+	return data
 }
 
 // convertToHTMLParagraphs converts plain text with line breaks to HTML paragraphs
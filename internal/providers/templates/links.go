@@ -0,0 +1,80 @@
+package templates
+
+import (
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// LinkTags are the UTM parameters added to the links of an email
+type LinkTags struct {
+	Source   string
+	Medium   string
+	Campaign string
+}
+
+// IsZero reports whether there is nothing to tag links with
+func (t LinkTags) IsZero() bool {
+	return t.Source == "" && t.Medium == "" && t.Campaign == ""
+}
+
+var (
+	anchorTagPattern = regexp.MustCompile(`(?i)<a\s[^>]*>`)
+	hrefPattern      = regexp.MustCompile(`(?i)(\shref\s*=\s*)(?:"([^"]*)"|'([^']*)')`)
+)
+
+// TagLinks adds the UTM parameters to every http and https link in an HTML body. Parameters a link already
+// has are left alone, so authors can tag single links themselves. Plain text bodies have no links to tag.
+func TagLinks(body string, tags LinkTags) string {
+	if tags.IsZero() {
+		return body
+	}
+	return anchorTagPattern.ReplaceAllStringFunc(body, func(tag string) string {
+		return hrefPattern.ReplaceAllStringFunc(tag, func(attr string) string {
+			m := hrefPattern.FindStringSubmatchIndex(attr)
+			prefix, quote := attr[m[2]:m[3]], `"`
+			var href string
+			if m[4] >= 0 {
+				href = attr[m[4]:m[5]]
+			} else {
+				href, quote = attr[m[6]:m[7]], "'"
+			}
+
+			tagged, ok := tagURL(html.UnescapeString(href), tags)
+			if !ok {
+				return attr
+			}
+			return prefix + quote + html.EscapeString(tagged) + quote
+		})
+	})
+}
+
+// tagURL appends the missing UTM parameters to an http or https URL, keeping its existing query as written
+func tagURL(link string, tags LinkTags) (string, bool) {
+	u, err := url.Parse(strings.TrimSpace(link))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", false
+	}
+
+	existing := u.Query()
+	added := url.Values{}
+	for _, param := range []struct{ key, value string }{
+		{"utm_source", tags.Source},
+		{"utm_medium", tags.Medium},
+		{"utm_campaign", tags.Campaign},
+	} {
+		if param.value != "" && !existing.Has(param.key) {
+			added.Set(param.key, param.value)
+		}
+	}
+	if len(added) == 0 {
+		return "", false
+	}
+
+	if u.RawQuery != "" {
+		u.RawQuery += "&"
+	}
+	u.RawQuery += added.Encode()
+	return u.String(), true
+}
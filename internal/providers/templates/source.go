@@ -0,0 +1,270 @@
+package templates
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"log"
+	"sync"
+	"sync/atomic"
+	texttemplate "text/template"
+	"time"
+)
+
+// Names of the templates a Source provides
+const (
+	HTMLTemplateName    = "email.html"
+	TextTemplateName    = "email.txt"
+	SponsorTemplateName = "sponsor.html" // Sponsor block written into sponsored content
+)
+
+// TemplateNames lists every template a Source may override
+var TemplateNames = []string{HTMLTemplateName, TextTemplateName, SponsorTemplateName}
+
+var (
+	// ErrTemplateNotFound is returned by a Source without the named template; the built-in one is used instead
+	ErrTemplateNotFound = errors.New("template not found")
+	// ErrInvalidTemplate is wrapped into the error of a template that doesn't parse or render
+	ErrInvalidTemplate = errors.New("invalid template")
+)
+
+// Source loads the text of a template by name, letting designers change templates without a deploy
+type Source interface {
+	Load(ctx context.Context, name string) (string, error)
+}
+
+// Origins of a loaded template
+const (
+	OriginBuiltin = "builtin"
+	OriginSource  = "source"
+)
+
+// Status describes the templates in use
+type Status struct {
+	LoadedAt time.Time         // Zero while only the built-in templates have been used
+	Origins  map[string]string // OriginBuiltin or OriginSource, keyed by template name
+}
+
+// executor is a parsed template: html/template for the HTML part, text/template for the plain-text part so its
+// output isn't HTML-escaped
+type executor interface {
+	Execute(w io.Writer, data any) error
+}
+
+// templateSet is one consistent set of parsed templates; rendering reads whichever set is current
+type templateSet struct {
+	html     executor
+	text     executor
+	sponsor  executor
+	loadedAt time.Time
+	origins  map[string]string
+}
+
+var (
+	builtinOnce sync.Once
+	builtinSet  *templateSet
+
+	activeSet atomic.Pointer[templateSet]
+
+	sourceMu sync.Mutex
+	source   Source
+)
+
+// builtinTemplates parses the compiled-in templates once
+func builtinTemplates() *templateSet {
+	builtinOnce.Do(func() {
+		html, err := parseTemplate(HTMLTemplateName, BaseEmailTemplate)
+		if err != nil {
+			panic(err)
+		}
+		text, err := parseTemplate(TextTemplateName, PlainTextTemplate)
+		if err != nil {
+			panic(err)
+		}
+		sponsor, err := parseTemplate(SponsorTemplateName, SponsorTemplate)
+		if err != nil {
+			panic(err)
+		}
+		builtinSet = &templateSet{
+			html:    html,
+			text:    text,
+			sponsor: sponsor,
+			origins: map[string]string{
+				HTMLTemplateName:    OriginBuiltin,
+				TextTemplateName:    OriginBuiltin,
+				SponsorTemplateName: OriginBuiltin,
+			},
+		}
+	})
+	return builtinSet
+}
+
+// currentTemplates returns the templates emails are rendered with
+func currentTemplates() *templateSet {
+	if set := activeSet.Load(); set != nil {
+		return set
+	}
+	return builtinTemplates()
+}
+
+// Use renders emails with the templates of src from now on, loading them immediately and again every refresh
+// until ctx ends; a refresh of 0 only reloads through Reload. Templates src doesn't have stay built-in. If a
+// load fails the templates in use are kept, so a bad edit never stops sends. A nil src restores the built-in
+// templates.
+func Use(ctx context.Context, src Source, refresh time.Duration) {
+	sourceMu.Lock()
+	source = src
+	sourceMu.Unlock()
+
+	if src == nil {
+		activeSet.Store(nil)
+		return
+	}
+	if _, err := Reload(ctx); err != nil {
+		log.Printf("Warning: using built-in email templates: %v", err)
+	}
+	if refresh <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(refresh)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := Reload(ctx); err != nil {
+					log.Printf("Warning: keeping current email templates: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+// Reload re-reads every template from the source set by Use and swaps them in once all of them parse and
+// render. It returns the status of the templates in use afterwards.
+func Reload(ctx context.Context) (Status, error) {
+	sourceMu.Lock()
+	src := source
+	sourceMu.Unlock()
+	if src == nil {
+		return CurrentStatus(), nil
+	}
+
+	builtin := builtinTemplates()
+	set := &templateSet{
+		html:     builtin.html,
+		text:     builtin.text,
+		sponsor:  builtin.sponsor,
+		loadedAt: time.Now(),
+		origins:  make(map[string]string),
+	}
+	for _, name := range TemplateNames {
+		set.origins[name] = OriginBuiltin
+		body, err := src.Load(ctx, name)
+		if errors.Is(err, ErrTemplateNotFound) {
+			continue
+		}
+		if err != nil {
+			return CurrentStatus(), fmt.Errorf("failed to load %s: %w", name, err)
+		}
+		tmpl, err := validate(name, body)
+		if err != nil {
+			return CurrentStatus(), err
+		}
+		switch name {
+		case HTMLTemplateName:
+			set.html = tmpl
+		case TextTemplateName:
+			set.text = tmpl
+		case SponsorTemplateName:
+			set.sponsor = tmpl
+		}
+		set.origins[name] = OriginSource
+	}
+
+	activeSet.Store(set)
+	return CurrentStatus(), nil
+}
+
+// CurrentStatus reports where the templates in use came from
+func CurrentStatus() Status {
+	set := currentTemplates()
+	origins := make(map[string]string, len(set.origins))
+	for name, origin := range set.origins {
+		origins[name] = origin
+	}
+	return Status{LoadedAt: set.loadedAt, Origins: origins}
+}
+
+// Builtin returns the compiled-in text of a template
+func Builtin(name string) (string, error) {
+	switch name {
+	case HTMLTemplateName:
+		return BaseEmailTemplate, nil
+	case TextTemplateName:
+		return PlainTextTemplate, nil
+	case SponsorTemplateName:
+		return SponsorTemplate, nil
+	}
+	return "", fmt.Errorf("%w: %s", ErrTemplateNotFound, name)
+}
+
+// Validate checks that body parses as the named template and renders a sample email, or a sample block for the
+// sponsor template, so a template can be rejected before it is stored
+func Validate(name, body string) error {
+	_, err := validate(name, body)
+	return err
+}
+
+func validate(name, body string) (executor, error) {
+	if _, err := Builtin(name); err != nil {
+		return nil, err
+	}
+	tmpl, err := parseTemplate(name, body)
+	if err != nil {
+		return nil, err
+	}
+
+	var sample any = prepareData(EmailTemplateData{
+		Subject:         "Sample subject",
+		PreviewText:     "Sample preview",
+		Body:            "<p>Sample body</p>",
+		TopicName:       "Sample topic",
+		UnsubscribeURL:  "https://example.com/unsubscribe",
+		OpenTrackingURL: "https://example.com/track/open",
+		ReferralURL:     "https://example.com/join?ref=sample",
+	})
+	if name == SponsorTemplateName {
+		sample = SponsorData{
+			Name:    "Sample sponsor",
+			URL:     "https://example.com",
+			LogoURL: "https://example.com/logo.png",
+			Message: "Sample message",
+			Text:    StringsFor(""),
+		}
+	}
+	if err := tmpl.Execute(io.Discard, sample); err != nil {
+		return nil, fmt.Errorf("%w: %s does not render: %w", ErrInvalidTemplate, name, err)
+	}
+	return tmpl, nil
+}
+
+// parseTemplate parses a template with the functions every email template may call
+func parseTemplate(name, body string) (executor, error) {
+	var tmpl executor
+	var err error
+	if name == TextTemplateName {
+		tmpl, err = texttemplate.New(name).Funcs(texttemplate.FuncMap(templateFuncs)).Parse(body)
+	} else {
+		tmpl, err = template.New(name).Funcs(templateFuncs).Parse(body)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to parse %s: %w", ErrInvalidTemplate, name, err)
+	}
+	return tmpl, nil
+}
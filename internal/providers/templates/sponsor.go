@@ -0,0 +1,52 @@
+package templates
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// SponsorTemplate is the built-in sponsor block. It is written into the content body, so it is styled in place
+// rather than through the email template's stylesheet.
+const SponsorTemplate = `<div class="sponsor" style="margin: 20px 0; padding: 15px; border: 1px solid #e0e0e0; border-radius: 8px;">
+    <small style="display: block; margin-bottom: 10px; text-transform: uppercase; letter-spacing: 1px; color: #888888;">{{.Text.SponsoredBy}}</small>
+    {{if .LogoURL}}<a href="{{.URL}}"><img src="{{.LogoURL}}" alt="{{.Name}}" style="max-width: 160px; max-height: 60px; border: 0;"></a><br>{{end}}
+    <a href="{{.URL}}"><strong>{{.Name}}</strong></a>{{if .Message}}: {{.Message}}{{end}}
+</div>`
+
+// SponsorData is what the sponsor template renders
+type SponsorData struct {
+	Name    string
+	URL     string
+	LogoURL string
+	Message string
+	Locale  string  // Language of the block's label; English when empty or unsupported
+	Text    Strings // Set from Locale when rendering
+}
+
+// RenderSponsor renders a sponsor block with the sponsor template in use (see Use). The block becomes one
+// paragraph of the body, so the template's line breaks are dropped rather than turned into <br>s.
+func RenderSponsor(data SponsorData) (string, error) {
+	data.Text = StringsFor(data.Locale)
+
+	var buf bytes.Buffer
+	if err := currentTemplates().sponsor.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to execute sponsor template: %w", err)
+	}
+	return strings.Join(strings.Fields(buf.String()), " "), nil
+}
+
+// BodyHTML returns a plain text body as HTML paragraphs, so that markup added to it doesn't run its paragraphs
+// together in the plain-text part. A body that already has markup is returned as it is.
+func BodyHTML(body string) string {
+	if strings.Contains(body, "<") {
+		return body
+	}
+	var paragraphs []string
+	for _, p := range strings.Split(body, "\n\n") {
+		if p = strings.TrimSpace(p); p != "" {
+			paragraphs = append(paragraphs, "<p>"+strings.ReplaceAll(p, "\n", "<br>")+"</p>")
+		}
+	}
+	return strings.Join(paragraphs, "\n\n")
+}
@@ -0,0 +1,104 @@
+package templates
+
+import "newsletter-service/internal/locale"
+
+// Strings are the template's own words in one language; the subject, body and branding come from the email
+type Strings struct {
+	Lang                string // The html lang attribute
+	SubscribedNotice    string
+	Unsubscribe         string // Unsubscribe link text
+	UnsubscribeAt       string // Plain text lead-in to the unsubscribe URL
+	Refer               string // Invitation before the subscriber's referral link
+	ReferLink           string // Referral link text
+	FooterText          string // Used when the branding has no footer text
+	DailyDigestSubject  string // Format taking the latest content's title
+	WeeklyDigestSubject string
+	SponsoredBy         string // Label of a sponsor block
+}
+
+// defaultLanguage is used for locales without strings of their own
+const defaultLanguage = "en"
+
+// catalog holds the strings of every supported language
+var catalog = map[string]Strings{
+	"en": {
+		Lang:                "en",
+		SubscribedNotice:    "You received this email because you subscribed to our newsletter.",
+		Unsubscribe:         "Unsubscribe from this newsletter",
+		UnsubscribeAt:       "To unsubscribe, visit:",
+		Refer:               "Know someone who would enjoy this newsletter?",
+		ReferLink:           "Share your referral link",
+		FooterText:          DefaultFooterText,
+		DailyDigestSubject:  "Your daily digest: %s",
+		WeeklyDigestSubject: "Your weekly digest: %s",
+		SponsoredBy:         "Sponsored by",
+	},
+	"de": {
+		Lang:                "de",
+		SubscribedNotice:    "Sie erhalten diese E-Mail, weil Sie unseren Newsletter abonniert haben.",
+		Unsubscribe:         "Von diesem Newsletter abmelden",
+		UnsubscribeAt:       "Zum Abmelden besuchen Sie:",
+		Refer:               "Kennen Sie jemanden, dem dieser Newsletter gefallen würde?",
+		ReferLink:           "Teilen Sie Ihren Empfehlungslink",
+		FooterText:          "© 2025 Newsletter Service. Alle Rechte vorbehalten.",
+		DailyDigestSubject:  "Ihre tägliche Zusammenfassung: %s",
+		WeeklyDigestSubject: "Ihre wöchentliche Zusammenfassung: %s",
+		SponsoredBy:         "Gesponsert von",
+	},
+	"es": {
+		Lang:                "es",
+		SubscribedNotice:    "Recibiste este correo porque te suscribiste a nuestro boletín.",
+		Unsubscribe:         "Darse de baja de este boletín",
+		UnsubscribeAt:       "Para darte de baja, visita:",
+		Refer:               "¿Conoces a alguien a quien le gustaría este boletín?",
+		ReferLink:           "Comparte tu enlace de recomendación",
+		FooterText:          "© 2025 Newsletter Service. Todos los derechos reservados.",
+		DailyDigestSubject:  "Tu resumen diario: %s",
+		WeeklyDigestSubject: "Tu resumen semanal: %s",
+		SponsoredBy:         "Patrocinado por",
+	},
+	"fr": {
+		Lang:                "fr",
+		SubscribedNotice:    "Vous recevez cet e-mail car vous êtes abonné à notre newsletter.",
+		Unsubscribe:         "Se désabonner de cette newsletter",
+		UnsubscribeAt:       "Pour vous désabonner, rendez-vous sur :",
+		Refer:               "Vous connaissez quelqu'un qui aimerait cette newsletter ?",
+		ReferLink:           "Partagez votre lien de parrainage",
+		FooterText:          "© 2025 Newsletter Service. Tous droits réservés.",
+		DailyDigestSubject:  "Votre résumé quotidien : %s",
+		WeeklyDigestSubject: "Votre résumé hebdomadaire : %s",
+		SponsoredBy:         "Sponsorisé par",
+	},
+	"it": {
+		Lang:                "it",
+		SubscribedNotice:    "Hai ricevuto questa email perché ti sei iscritto alla nostra newsletter.",
+		Unsubscribe:         "Annulla l'iscrizione a questa newsletter",
+		UnsubscribeAt:       "Per annullare l'iscrizione, visita:",
+		Refer:               "Conosci qualcuno a cui piacerebbe questa newsletter?",
+		ReferLink:           "Condividi il tuo link di invito",
+		FooterText:          "© 2025 Newsletter Service. Tutti i diritti riservati.",
+		DailyDigestSubject:  "Il tuo riepilogo giornaliero: %s",
+		WeeklyDigestSubject: "Il tuo riepilogo settimanale: %s",
+		SponsoredBy:         "Sponsorizzato da",
+	},
+	"pt": {
+		Lang:                "pt",
+		SubscribedNotice:    "Você recebeu este e-mail porque assinou nossa newsletter.",
+		Unsubscribe:         "Cancelar a inscrição nesta newsletter",
+		UnsubscribeAt:       "Para cancelar a inscrição, acesse:",
+		Refer:               "Conhece alguém que gostaria desta newsletter?",
+		ReferLink:           "Compartilhe seu link de indicação",
+		FooterText:          "© 2025 Newsletter Service. Todos os direitos reservados.",
+		DailyDigestSubject:  "Seu resumo diário: %s",
+		WeeklyDigestSubject: "Seu resumo semanal: %s",
+		SponsoredBy:         "Patrocinado por",
+	},
+}
+
+// StringsFor returns the template strings in the language of a locale, or English when it isn't supported
+func StringsFor(tag string) Strings {
+	if strings, ok := catalog[locale.Language(tag)]; ok {
+		return strings
+	}
+	return catalog[defaultLanguage]
+}
@@ -0,0 +1,277 @@
+package templates
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// skippedElements hold nothing a reader of the plain-text part should see
+var skippedElements = map[atom.Atom]bool{
+	atom.Head: true, atom.Title: true, atom.Style: true, atom.Script: true, atom.Noscript: true,
+	atom.Template: true, atom.Svg: true, atom.Iframe: true, atom.Object: true,
+}
+
+// blockElements start on a line of their own, separated from their neighbours by a blank line
+var blockElements = map[atom.Atom]bool{
+	atom.P: true, atom.Div: true, atom.Section: true, atom.Article: true, atom.Header: true, atom.Footer: true,
+	atom.Main: true, atom.Aside: true, atom.Nav: true, atom.Table: true, atom.Tr: true, atom.Ul: true,
+	atom.Ol: true, atom.Dl: true, atom.Pre: true, atom.Blockquote: true, atom.Figure: true, atom.Address: true,
+	atom.H1: true, atom.H2: true, atom.H3: true, atom.H4: true, atom.H5: true, atom.H6: true,
+}
+
+// headingLevels turn headings into their markdown form, e.g. "## Title"
+var headingLevels = map[atom.Atom]int{
+	atom.H1: 1, atom.H2: 2, atom.H3: 3, atom.H4: 4, atom.H5: 5, atom.H6: 6,
+}
+
+var (
+	hiddenStylePattern = regexp.MustCompile(`(?i)display\s*:\s*none`)
+	blankLinesPattern  = regexp.MustCompile(`\n{3,}`)
+)
+
+// HTMLToText turns an HTML body or document into a readable plain-text alternative: styles, scripts and hidden
+// elements such as the preheader are dropped, links become [text](url), headings, lists and quotes take their
+// markdown form and images show their alt text. A body without markup is returned as it is.
+func HTMLToText(body string) string {
+	if !strings.Contains(body, "<") {
+		return body
+	}
+
+	var nodes []*html.Node
+	if strings.Contains(strings.ToLower(body), "<html") {
+		root, err := html.Parse(strings.NewReader(body))
+		if err != nil {
+			return body
+		}
+		nodes = []*html.Node{root}
+	} else {
+		fragment, err := html.ParseFragment(strings.NewReader(body), &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body})
+		if err != nil {
+			return body
+		}
+		nodes = fragment
+	}
+
+	w := &textWriter{}
+	for _, n := range nodes {
+		w.node(n)
+	}
+	return tidyText(w.String())
+}
+
+// tidyText trims trailing spaces from every line and leaves at most one blank line between paragraphs
+func tidyText(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t\r")
+	}
+	text = blankLinesPattern.ReplaceAllString(strings.Join(lines, "\n"), "\n\n")
+	return strings.Trim(text, "\n") + "\n"
+}
+
+// textWriter renders nodes as text, collapsing the whitespace of HTML source the way a browser does
+type textWriter struct {
+	strings.Builder
+	pre     int  // Depth of <pre> elements, inside which whitespace is kept
+	pending bool // A space is owed before the next word
+	marker  bool // The line holds only a list or heading marker, which its content follows directly
+}
+
+// text writes words, collapsing whitespace outside <pre>
+func (w *textWriter) text(s string) {
+	if w.pre > 0 {
+		w.WriteString(s)
+		return
+	}
+	for i, word := range strings.Fields(s) {
+		if i > 0 || (w.pending && !w.atLineStart() && !w.marker) {
+			w.WriteString(" ")
+		}
+		w.WriteString(word)
+		w.pending = false
+		w.marker = false
+	}
+	if len(s) > 0 && strings.TrimRight(s, " \t\r\n\f") != s {
+		w.pending = true
+	}
+}
+
+func (w *textWriter) atLineStart() bool {
+	s := w.String()
+	return s == "" || strings.HasSuffix(s, "\n")
+}
+
+// newline ends the current line, if it has anything on it
+func (w *textWriter) newline() {
+	if w.marker {
+		return
+	}
+	if !w.atLineStart() {
+		w.WriteString("\n")
+	}
+	w.pending = false
+}
+
+// paragraph leaves a blank line before what follows
+func (w *textWriter) paragraph() {
+	if w.marker {
+		return
+	}
+	w.newline()
+	if s := w.String(); s != "" && !strings.HasSuffix(s, "\n\n") {
+		w.WriteString("\n")
+	}
+}
+
+func (w *textWriter) node(n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		w.text(n.Data)
+		return
+	case html.DocumentNode:
+		w.children(n)
+		return
+	case html.ElementNode:
+	default:
+		return
+	}
+
+	if skippedElements[n.DataAtom] || hiddenStylePattern.MatchString(attr(n, "style")) || hasAttr(n, "hidden") {
+		return
+	}
+
+	switch n.DataAtom {
+	case atom.Br:
+		w.WriteString("\n")
+		w.pending = false
+	case atom.Hr:
+		w.paragraph()
+		w.WriteString("---")
+		w.paragraph()
+	case atom.Img:
+		if alt := strings.TrimSpace(attr(n, "alt")); alt != "" {
+			w.text("[" + alt + "]")
+		}
+	case atom.A:
+		w.link(n)
+	case atom.Li:
+		w.listItem(n)
+	case atom.Td, atom.Th:
+		w.children(n)
+		w.pending = true
+	case atom.Blockquote:
+		w.quote(n)
+	case atom.Pre:
+		w.paragraph()
+		w.pre++
+		w.children(n)
+		w.pre--
+		w.paragraph()
+	default:
+		if level, ok := headingLevels[n.DataAtom]; ok {
+			w.paragraph()
+			w.WriteString(strings.Repeat("#", level) + " ")
+			w.marker = true
+			w.children(n)
+			w.marker = false
+			w.paragraph()
+			return
+		}
+		if blockElements[n.DataAtom] {
+			w.paragraph()
+			w.children(n)
+			w.paragraph()
+			return
+		}
+		w.children(n)
+	}
+}
+
+func (w *textWriter) children(n *html.Node) {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		w.node(c)
+	}
+}
+
+// link writes a link as [text](url), or just the URL when the text is the URL or the link has no text
+func (w *textWriter) link(n *html.Node) {
+	href := strings.TrimSpace(attr(n, "href"))
+	if href == "" || strings.HasPrefix(href, "#") || strings.HasPrefix(strings.ToLower(href), "javascript:") {
+		w.children(n)
+		return
+	}
+
+	inner := &textWriter{}
+	inner.children(n)
+	label := strings.Join(strings.Fields(inner.String()), " ")
+	switch {
+	case label == "" || label == href:
+		w.text(href)
+		return
+	case "mailto:"+label == href:
+		w.text(label)
+		return
+	}
+	w.text("[" + label + "](" + href + ")")
+}
+
+// quote writes every line of a blockquote behind "> ", blank lines included so its paragraphs stay one quote
+func (w *textWriter) quote(n *html.Node) {
+	inner := &textWriter{pre: w.pre}
+	inner.children(n)
+	quoted := strings.Trim(inner.String(), "\n")
+	if quoted == "" {
+		return
+	}
+
+	w.paragraph()
+	for _, line := range strings.Split(quoted, "\n") {
+		w.WriteString(strings.TrimRight("> "+line, " ") + "\n")
+	}
+	w.paragraph()
+}
+
+// listItem writes "- item", or "1. item" in an ordered list
+func (w *textWriter) listItem(n *html.Node) {
+	w.newline()
+	marker := "- "
+	if n.Parent != nil && n.Parent.DataAtom == atom.Ol {
+		number := 1
+		if start, err := strconv.Atoi(attr(n.Parent, "start")); err == nil {
+			number = start
+		}
+		for s := n.PrevSibling; s != nil; s = s.PrevSibling {
+			if s.Type == html.ElementNode && s.DataAtom == atom.Li {
+				number++
+			}
+		}
+		marker = strconv.Itoa(number) + ". "
+	}
+	w.WriteString(marker)
+	w.marker = true
+	w.children(n)
+	w.marker = false
+	w.newline()
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+func hasAttr(n *html.Node, key string) bool {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,147 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"newsletter-service/internal/config"
+)
+
+// DomainThrottle spaces out sends to each recipient domain so mailbox providers such as Gmail, Yahoo and
+// Outlook don't throttle or block us. It is shared by every provider and applies per process.
+type DomainThrottle struct {
+	defaultPerMinute int
+	perMinute        map[string]int
+	next             map[string]time.Time // Earliest time the next send to a domain may start
+	mutex            sync.Mutex
+}
+
+// NewDomainThrottle creates a throttle from [providers.domain_throttle], or returns nil when it is disabled
+func NewDomainThrottle(cfg *config.DomainThrottleConfig) *DomainThrottle {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	perMinute := make(map[string]int, len(cfg.PerMinute))
+	for domain, limit := range cfg.PerMinute {
+		perMinute[strings.ToLower(domain)] = limit
+	}
+
+	return &DomainThrottle{
+		defaultPerMinute: cfg.DefaultPerMinute,
+		perMinute:        perMinute,
+		next:             make(map[string]time.Time),
+	}
+}
+
+// Limit returns the sends per minute allowed to a domain, 0 when it is unthrottled
+func (t *DomainThrottle) Limit(domain string) int {
+	if t == nil {
+		return 0
+	}
+	if limit, ok := t.perMinute[domain]; ok {
+		return limit
+	}
+	return t.defaultPerMinute
+}
+
+// Wait blocks until an email to the recipient's domain may be sent, or ctx is done
+func (t *DomainThrottle) Wait(ctx context.Context, recipient string) error {
+	domain := emailDomain(recipient)
+	limit := t.Limit(domain)
+	if limit <= 0 {
+		return nil
+	}
+
+	// Claim the domain's next free slot; sends are spread evenly over the minute
+	t.mutex.Lock()
+	now := time.Now()
+	slot := t.next[domain]
+	if slot.Before(now) {
+		slot = now
+	}
+	t.next[domain] = slot.Add(time.Minute / time.Duration(limit))
+	t.mutex.Unlock()
+
+	delay := time.Until(slot)
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("throttled sending to %s: %w", domain, ctx.Err())
+	case <-timer.C:
+		return nil
+	}
+}
+
+// ThrottledEmailProvider waits on a DomainThrottle before each recipient is sent to
+type ThrottledEmailProvider struct {
+	provider EmailProviderInterface
+	throttle *DomainThrottle
+}
+
+// NewThrottledEmailProvider wraps a provider with recipient-domain throttling
+func NewThrottledEmailProvider(provider EmailProviderInterface, throttle *DomainThrottle) EmailProviderInterface {
+	return &ThrottledEmailProvider{
+		provider: provider,
+		throttle: throttle,
+	}
+}
+
+// SendEmail sends once the recipient's domain has a free slot
+func (tp *ThrottledEmailProvider) SendEmail(ctx context.Context, notification *EmailNotification) (string, error) {
+	if err := tp.throttle.Wait(ctx, notification.To); err != nil {
+		return "", err
+	}
+	return tp.provider.SendEmail(ctx, notification)
+}
+
+// SendBulkEmail sends once every recipient's domain has had a free slot
+func (tp *ThrottledEmailProvider) SendBulkEmail(ctx context.Context, notification *BulkEmailNotification) (map[string]string, error) {
+	for _, recipient := range notification.To {
+		if err := tp.throttle.Wait(ctx, recipient); err != nil {
+			return nil, err
+		}
+	}
+	return tp.provider.SendBulkEmail(ctx, notification)
+}
+
+// Delegate other methods to the underlying provider
+func (tp *ThrottledEmailProvider) SupportsBulk() bool {
+	return tp.provider.SupportsBulk()
+}
+
+func (tp *ThrottledEmailProvider) GetLimits() ProviderLimits {
+	return tp.provider.GetLimits()
+}
+
+func (tp *ThrottledEmailProvider) GetStats() ProviderStats {
+	return tp.provider.GetStats()
+}
+
+func (tp *ThrottledEmailProvider) GetProviderType() EmailProvider {
+	return tp.provider.GetProviderType()
+}
+
+func (tp *ThrottledEmailProvider) GetProviderName() string {
+	return tp.provider.GetProviderName()
+}
+
+func (tp *ThrottledEmailProvider) GetPriority() int {
+	return tp.provider.GetPriority()
+}
+
+func (tp *ThrottledEmailProvider) IsEnabled() bool {
+	return tp.provider.IsEnabled()
+}
+
+func (tp *ThrottledEmailProvider) ValidateConfig() error {
+	return tp.provider.ValidateConfig()
+}
@@ -0,0 +1,158 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"newsletter-service/internal/config"
+	"newsletter-service/internal/tracing"
+)
+
+// twilioAPIBase is the Twilio REST API root; messages are created under the account
+const twilioAPIBase = "https://api.twilio.com/2010-04-01"
+
+// TwilioSMSProvider sends SMS through the Twilio Messages API
+type TwilioSMSProvider struct {
+	name      string
+	config    *config.TwilioSMSConfig
+	client    *http.Client
+	isHealthy bool
+	lastError error
+}
+
+// twilioMessage is the part of Twilio's message resource we read: its SID, or the error of a rejected request
+type twilioMessage struct {
+	SID     string `json:"sid"`
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// NewTwilioSMSProvider creates a Twilio SMS provider
+func NewTwilioSMSProvider(name string, cfg *config.TwilioSMSConfig) EmailProviderInterface {
+	return &TwilioSMSProvider{
+		name:      name,
+		config:    cfg,
+		client:    &http.Client{Timeout: 30 * time.Second},
+		isHealthy: true,
+	}
+}
+
+// SendEmail sends the notification's Body as an SMS to the phone number in To and returns the message SID
+func (p *TwilioSMSProvider) SendEmail(ctx context.Context, notification *EmailNotification) (string, error) {
+	messageID, err := p.send(ctx, notification.To, notification.Body)
+
+	// Update statistics
+	if err != nil {
+		p.isHealthy = false
+		p.lastError = err
+		return "", err
+	}
+	p.isHealthy = true
+	p.lastError = nil
+	return messageID, nil
+}
+
+// SendBulkEmail sends one SMS per recipient
+func (p *TwilioSMSProvider) SendBulkEmail(ctx context.Context, notification *BulkEmailNotification) (map[string]string, error) {
+	return sendEachSMS(ctx, p, notification)
+}
+
+func (p *TwilioSMSProvider) send(ctx context.Context, to, body string) (messageID string, err error) {
+	ctx, span := tracing.StartSpan(ctx, "provider.twilio.send", attribute.String("provider.name", p.name))
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+	}()
+
+	form := url.Values{}
+	form.Set("To", to)
+	form.Set("Body", body)
+	if p.config.MessagingServiceSID != "" {
+		form.Set("MessagingServiceSid", p.config.MessagingServiceSID)
+	} else {
+		form.Set("From", p.config.From)
+	}
+
+	endpoint := fmt.Sprintf("%s/Accounts/%s/Messages.json", twilioAPIBase, url.PathEscape(p.config.AccountSID))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("failed to create Twilio request: %w", err)
+	}
+	req.SetBasicAuth(p.config.AccountSID, p.config.AuthToken)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to send Twilio request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+	var message twilioMessage
+	json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&message)
+	if resp.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("Twilio API returned status %d: %d %s", resp.StatusCode, message.Code, message.Message)
+	}
+	return message.SID, nil
+}
+
+// SupportsBulk returns false; every SMS is its own message
+func (p *TwilioSMSProvider) SupportsBulk() bool {
+	return false
+}
+
+// GetLimits returns Twilio provider limitations
+func (p *TwilioSMSProvider) GetLimits() ProviderLimits {
+	return ProviderLimits{
+		MaxEmailsPerHour: p.config.MaxPerHour,
+		MaxBatchSize:     1,
+		SupportsBulk:     false,
+	}
+}
+
+// GetStats returns current Twilio provider health
+func (p *TwilioSMSProvider) GetStats() ProviderStats {
+	return ProviderStats{
+		IsHealthy: p.isHealthy,
+		LastError: p.lastError,
+	}
+}
+
+// GetProviderType returns the provider type
+func (p *TwilioSMSProvider) GetProviderType() EmailProvider {
+	return SMSProviderType
+}
+
+// GetProviderName returns the provider name
+func (p *TwilioSMSProvider) GetProviderName() string {
+	return p.name
+}
+
+// GetPriority returns provider priority (lower = higher priority)
+func (p *TwilioSMSProvider) GetPriority() int {
+	return p.config.Priority
+}
+
+// IsEnabled returns true if provider is enabled
+func (p *TwilioSMSProvider) IsEnabled() bool {
+	return true
+}
+
+// ValidateConfig checks the account credentials and sender
+func (p *TwilioSMSProvider) ValidateConfig() error {
+	if p.config.AccountSID == "" || p.config.AuthToken == "" {
+		return fmt.Errorf("Twilio account_sid and auth_token are required")
+	}
+	if p.config.From == "" && p.config.MessagingServiceSID == "" {
+		return fmt.Errorf("Twilio from or messaging_service_sid is required")
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package providers
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"newsletter-service/internal/config"
+)
+
+// WarmupPolicy caps the daily sends of a new provider or sending domain while it builds reputation.
+// Providers sending from the same warmed-up domain share one policy and one cap.
+type WarmupPolicy struct {
+	Key       string    // Provider name or sending domain from [providers.warmup]
+	StartDate time.Time // Day 1 of the ramp, in UTC
+	DailyCaps []int
+}
+
+// NewWarmupPolicy parses a [providers.warmup] entry
+func NewWarmupPolicy(key string, cfg *config.WarmupConfig) (*WarmupPolicy, error) {
+	if len(cfg.DailyCaps) == 0 {
+		return nil, fmt.Errorf("warm-up %s needs daily_caps", key)
+	}
+	for day, limit := range cfg.DailyCaps {
+		if limit < 0 {
+			return nil, fmt.Errorf("warm-up %s has a negative cap on day %d", key, day+1)
+		}
+	}
+
+	startDate, err := time.Parse(time.DateOnly, cfg.StartDate)
+	if err != nil {
+		return nil, fmt.Errorf("warm-up %s has an invalid start_date: %w", key, err)
+	}
+
+	return &WarmupPolicy{Key: key, StartDate: startDate, DailyCaps: cfg.DailyCaps}, nil
+}
+
+// Day returns the 1-based ramp day at t, or 0 before the start date
+func (p *WarmupPolicy) Day(t time.Time) int {
+	today := WarmupDate(t)
+	if today.Before(p.StartDate) {
+		return 0
+	}
+	return int(today.Sub(p.StartDate)/(24*time.Hour)) + 1
+}
+
+// DailyCap returns the send cap at t and whether warm-up still applies. Nothing is sent before the start date.
+func (p *WarmupPolicy) DailyCap(t time.Time) (int, bool) {
+	day := p.Day(t)
+	if day == 0 {
+		return 0, true
+	}
+	if day > len(p.DailyCaps) {
+		return 0, false
+	}
+	return p.DailyCaps[day-1], true
+}
+
+// WarmupDate truncates t to its UTC day, which is when daily caps reset
+func WarmupDate(t time.Time) time.Time {
+	year, month, day := t.UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// emailDomain returns the lower-cased domain of an address, e.g. "example.com" for "News <no-reply@Example.com>"
+func emailDomain(address string) string {
+	at := strings.LastIndex(address, "@")
+	if at < 0 {
+		return ""
+	}
+	return strings.ToLower(strings.TrimRight(address[at+1:], "> "))
+}
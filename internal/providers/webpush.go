@@ -0,0 +1,322 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"go.opentelemetry.io/otel/attribute"
+	"golang.org/x/crypto/hkdf"
+
+	"newsletter-service/internal/config"
+	"newsletter-service/internal/tracing"
+)
+
+// Web Push defaults
+const (
+	defaultWebPushTTL = 24 * time.Hour
+	webPushRecordSize = 4096 // aes128gcm record size; payloads are sent as a single record
+	vapidTokenTTL     = 12 * time.Hour
+)
+
+// WebPushProvider sends standard Web Push messages straight to each browser's push service, encrypted for
+// the subscription (RFC 8291) and signed with the application's VAPID key (RFC 8292)
+type WebPushProvider struct {
+	name      string
+	config    *config.WebPushConfig
+	vapidKey  *ecdsa.PrivateKey
+	vapidPub  []byte // Uncompressed public point derived from vapidKey
+	client    *http.Client
+	isHealthy bool
+	lastError error
+}
+
+// NewWebPushProvider creates a Web Push provider from its VAPID key pair
+func NewWebPushProvider(name string, cfg *config.WebPushConfig) (EmailProviderInterface, error) {
+	provider := &WebPushProvider{
+		name:      name,
+		config:    cfg,
+		client:    &http.Client{Timeout: 30 * time.Second},
+		isHealthy: true,
+	}
+
+	if cfg.VAPIDPrivateKey == "" {
+		return provider, nil // Reported by ValidateConfig
+	}
+	key, public, err := parseVAPIDKey(cfg.VAPIDPrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	provider.vapidKey = key
+	provider.vapidPub = public
+	return provider, nil
+}
+
+// parseVAPIDKey decodes a base64url P-256 private scalar, the format VAPID key generators print, and returns
+// it with its uncompressed public point
+func parseVAPIDKey(encoded string) (*ecdsa.PrivateKey, []byte, error) {
+	scalar, err := decodeBase64URL(encoded)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid VAPID private key: %w", err)
+	}
+	key, err := ecdh.P256().NewPrivateKey(scalar)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid VAPID private key: %w", err)
+	}
+
+	point := key.PublicKey().Bytes() // 0x04 || X || Y
+	return &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(point[1:33]),
+			Y:     new(big.Int).SetBytes(point[33:]),
+		},
+		D: new(big.Int).SetBytes(scalar),
+	}, point, nil
+}
+
+// SendEmail encrypts the notification's title and text for the subscription whose endpoint is in To and
+// returns the push service's message URL. Expired subscriptions return ErrPushDeviceGone without marking
+// the provider unhealthy.
+func (p *WebPushProvider) SendEmail(ctx context.Context, notification *EmailNotification) (string, error) {
+	messageID, err := p.send(ctx, notification)
+
+	// Update statistics
+	if err != nil {
+		if !errors.Is(err, ErrPushDeviceGone) {
+			p.isHealthy = false
+			p.lastError = err
+		}
+		return "", err
+	}
+	p.isHealthy = true
+	p.lastError = nil
+	return messageID, nil
+}
+
+// SendBulkEmail is not used for Web Push, whose messages are encrypted per subscription
+func (p *WebPushProvider) SendBulkEmail(ctx context.Context, notification *BulkEmailNotification) (map[string]string, error) {
+	return nil, fmt.Errorf("Web Push provider %s cannot send without subscription keys", p.name)
+}
+
+func (p *WebPushProvider) send(ctx context.Context, notification *EmailNotification) (messageID string, err error) {
+	ctx, span := tracing.StartSpan(ctx, "provider.webpush.send", attribute.String("provider.name", p.name))
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+	}()
+
+	if notification.PushKeys == nil {
+		return "", fmt.Errorf("Web Push subscription keys are required")
+	}
+	endpoint, err := url.Parse(notification.To)
+	if err != nil || endpoint.Scheme != "https" {
+		return "", fmt.Errorf("invalid Web Push endpoint %q", notification.To)
+	}
+
+	payload, err := marshalPushPayload(notification)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal push payload: %w", err)
+	}
+	body, err := encryptWebPush(payload, notification.PushKeys)
+	if err != nil {
+		return "", err
+	}
+	authorization, err := p.vapidAuthorization(endpoint)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.String(), bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to create Web Push request: %w", err)
+	}
+	req.Header.Set("Authorization", authorization)
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("TTL", strconv.Itoa(int(p.ttl().Seconds())))
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to send Web Push request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		return "", ErrPushDeviceGone
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return "", fmt.Errorf("push service returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(detail)))
+	}
+	return resp.Header.Get("Location"), nil
+}
+
+// vapidAuthorization signs a VAPID token for the push service's origin
+func (p *WebPushProvider) vapidAuthorization(endpoint *url.URL) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"aud": endpoint.Scheme + "://" + endpoint.Host,
+		"exp": time.Now().Add(vapidTokenTTL).Unix(),
+		"sub": p.config.Subject,
+	})
+	signed, err := token.SignedString(p.vapidKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign VAPID token: %w", err)
+	}
+	return fmt.Sprintf("vapid t=%s, k=%s", signed, p.config.VAPIDPublicKey), nil
+}
+
+// encryptWebPush encrypts a payload for a subscription with the aes128gcm content coding: an ephemeral ECDH
+// key agreed with the subscription's key, mixed with its auth secret, derives the content key and nonce
+func encryptWebPush(payload []byte, keys *PushKeys) ([]byte, error) {
+	uaPublic, err := decodeBase64URL(keys.P256dh)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Web Push p256dh key: %w", err)
+	}
+	authSecret, err := decodeBase64URL(keys.Auth)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Web Push auth secret: %w", err)
+	}
+	uaKey, err := ecdh.P256().NewPublicKey(uaPublic)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Web Push p256dh key: %w", err)
+	}
+
+	asKey, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate Web Push key: %w", err)
+	}
+	sharedSecret, err := asKey.ECDH(uaKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to agree Web Push key: %w", err)
+	}
+	asPublic := asKey.PublicKey().Bytes()
+
+	keyInfo := append(append([]byte("WebPush: info\x00"), uaPublic...), asPublic...)
+	ikm := make([]byte, 32)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, sharedSecret, authSecret, keyInfo), ikm); err != nil {
+		return nil, err
+	}
+
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate Web Push salt: %w", err)
+	}
+	cek := make([]byte, 16)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, ikm, salt, []byte("Content-Encoding: aes128gcm\x00")), cek); err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, 12)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, ikm, salt, []byte("Content-Encoding: nonce\x00")), nonce); err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	if len(payload)+1+gcm.Overhead() > webPushRecordSize {
+		return nil, fmt.Errorf("push payload of %d bytes is too large", len(payload))
+	}
+
+	// Header: salt, record size, key ID length and the ephemeral public key; then the single, final record
+	// (padding delimiter 0x02)
+	header := make([]byte, 0, 16+4+1+len(asPublic))
+	header = append(header, salt...)
+	header = binary.BigEndian.AppendUint32(header, webPushRecordSize)
+	header = append(header, byte(len(asPublic)))
+	header = append(header, asPublic...)
+	return gcm.Seal(header, nonce, append(payload, 0x02), nil), nil
+}
+
+// decodeBase64URL accepts base64url with or without padding, as browsers and key generators differ
+func decodeBase64URL(encoded string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(encoded, "="))
+}
+
+func (p *WebPushProvider) ttl() time.Duration {
+	if p.config.TTL > 0 {
+		return p.config.TTL
+	}
+	return defaultWebPushTTL
+}
+
+// SupportsBulk returns false; every message is encrypted for one subscription
+func (p *WebPushProvider) SupportsBulk() bool {
+	return false
+}
+
+// GetLimits returns Web Push provider limitations
+func (p *WebPushProvider) GetLimits() ProviderLimits {
+	return ProviderLimits{
+		MaxEmailsPerHour: p.config.MaxPerHour,
+		MaxBatchSize:     1,
+		SupportsBulk:     false,
+	}
+}
+
+// GetStats returns current Web Push provider health
+func (p *WebPushProvider) GetStats() ProviderStats {
+	return ProviderStats{
+		IsHealthy: p.isHealthy,
+		LastError: p.lastError,
+	}
+}
+
+// GetProviderType returns the provider type
+func (p *WebPushProvider) GetProviderType() EmailProvider {
+	return WebPushProviderType
+}
+
+// GetProviderName returns the provider name
+func (p *WebPushProvider) GetProviderName() string {
+	return p.name
+}
+
+// GetPriority returns provider priority (lower = higher priority)
+func (p *WebPushProvider) GetPriority() int {
+	return p.config.Priority
+}
+
+// IsEnabled returns true if provider is enabled
+func (p *WebPushProvider) IsEnabled() bool {
+	return true
+}
+
+// ValidateConfig checks the VAPID key pair and contact subject
+func (p *WebPushProvider) ValidateConfig() error {
+	if p.vapidKey == nil || p.config.VAPIDPublicKey == "" {
+		return fmt.Errorf("Web Push vapid_public_key and vapid_private_key are required")
+	}
+	public, err := decodeBase64URL(p.config.VAPIDPublicKey)
+	if err != nil || !bytes.Equal(public, p.vapidPub) {
+		return fmt.Errorf("Web Push vapid_public_key does not match vapid_private_key")
+	}
+	if !strings.HasPrefix(p.config.Subject, "mailto:") && !strings.HasPrefix(p.config.Subject, "https://") {
+		return fmt.Errorf("Web Push subject must be a mailto: or https: URL")
+	}
+	return nil
+}
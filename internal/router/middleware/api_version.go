@@ -0,0 +1,222 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"newsletter-service/internal/constants"
+	apperrors "newsletter-service/internal/errors"
+)
+
+// ResponseSerializer shapes the JSON bodies of one API version. Handlers are shared by every version and write
+// v1 bodies; the serializer of the request's version turns them into what that version promises.
+type ResponseSerializer interface {
+	Serialize(c *gin.Context, status int, body []byte) []byte
+}
+
+// PassthroughSerializer sends bodies as the handlers wrote them. It is v1's, whose responses are bare arrays,
+// objects and {"error": ...} maps.
+type PassthroughSerializer struct{}
+
+func (PassthroughSerializer) Serialize(_ *gin.Context, _ int, body []byte) []byte {
+	return body
+}
+
+// EnvelopeSerializer wraps every body as {"data", "error", "meta"}. It is v2's.
+type EnvelopeSerializer struct{}
+
+// envelope is a v2 response. Data is null on errors and Error is null on success.
+type envelope struct {
+	Data  json.RawMessage `json:"data"`
+	Error *envelopeError  `json:"error"`
+	Meta  envelopeMeta    `json:"meta"`
+}
+
+type envelopeError struct {
+	Code    string          `json:"code"`
+	Message string          `json:"message"`
+	Details json.RawMessage `json:"details,omitempty"`
+}
+
+type envelopeMeta struct {
+	APIVersion string          `json:"api_version"`
+	RequestID  string          `json:"request_id,omitempty"`
+	Pagination json.RawMessage `json:"pagination,omitempty"`
+}
+
+func (EnvelopeSerializer) Serialize(c *gin.Context, status int, body []byte) []byte {
+	response := envelope{Meta: envelopeMeta{APIVersion: constants.APIVersion2}}
+	if requestID, ok := c.Request.Context().Value("request_id").(string); ok {
+		response.Meta.RequestID = requestID
+	}
+
+	var fields map[string]json.RawMessage
+	isObject := json.Unmarshal(body, &fields) == nil && fields != nil
+
+	switch {
+	case status >= http.StatusBadRequest:
+		response.Error = envelopeErrorFrom(status, fields)
+	case isObject && len(fields) == 2 && fields["data"] != nil && fields["pagination"] != nil:
+		// Paginated lists move their pagination into meta
+		response.Data = fields["data"]
+		response.Meta.Pagination = fields["pagination"]
+	default:
+		response.Data = body
+	}
+
+	enveloped, err := json.Marshal(response)
+	if err != nil {
+		return body
+	}
+	return enveloped
+}
+
+// envelopeErrorFrom reads the error out of a v1 error body, either an AppError's {"error": {"code", "message",
+// "details"}} or a handler's {"error": "message", "details": ...}. Any other fields are kept in details.
+func envelopeErrorFrom(status int, fields map[string]json.RawMessage) *envelopeError {
+	result := &envelopeError{Code: apperrors.CodeForStatus(status), Message: http.StatusText(status)}
+
+	if raw, ok := fields["error"]; ok {
+		var structured envelopeError
+		var message string
+		switch {
+		case json.Unmarshal(raw, &message) == nil:
+			result.Message = message
+		case json.Unmarshal(raw, &structured) == nil:
+			if structured.Code != "" {
+				result.Code = structured.Code
+			}
+			if structured.Message != "" {
+				result.Message = structured.Message
+			}
+			result.Details = structured.Details
+		}
+	}
+
+	extra := make(map[string]json.RawMessage)
+	for name, value := range fields {
+		if name != "error" {
+			extra[name] = value
+		}
+	}
+	switch {
+	case len(extra) == 1 && extra["details"] != nil:
+		result.Details = extra["details"]
+	case len(extra) > 0:
+		if result.Details != nil {
+			extra["details"] = result.Details
+		}
+		if details, err := json.Marshal(extra); err == nil {
+			result.Details = details
+		}
+	}
+	return result
+}
+
+// serializers are the response serializers of each API version
+var serializers = map[string]ResponseSerializer{
+	constants.APIVersion1: PassthroughSerializer{},
+	constants.APIVersion2: EnvelopeSerializer{},
+}
+
+// APIVersionMiddleware picks the API version of each /api/ request and serializes its JSON responses for that
+// version. The version is the one in the path, except that a v1 request whose Accept header names
+// application/vnd.newsletter.v2+json gets v2 responses. The version is sent back in the API-Version header.
+func APIVersionMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		version, ok := requestAPIVersion(c)
+		if !ok {
+			c.Next()
+			return
+		}
+		c.Set(constants.ContextKeyAPIVersion, version)
+		c.Header(constants.HeaderAPIVersion, version)
+
+		writer := &serializingResponseWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		c.Next()
+		c.Writer = writer.ResponseWriter
+
+		// Errors left to the error middleware are answered here, so they are serialized like the rest;
+		// the error middleware still logs them
+		if !writer.written && len(c.Errors) > 0 {
+			appErr := apperrors.Classify(c.Errors.Last().Err)
+			body, err := json.Marshal(gin.H{"error": appErr})
+			if err == nil {
+				writer.ResponseWriter.WriteHeader(appErr.StatusCode)
+				writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+				writer.body.Write(body)
+				writer.written = true
+			}
+		}
+		writer.flush(c, serializers[version])
+	}
+}
+
+// requestAPIVersion returns the version of a request under /api/, and false for any other path
+func requestAPIVersion(c *gin.Context) (string, bool) {
+	path := c.Request.URL.Path
+	switch {
+	case strings.HasPrefix(path, "/api/"+constants.APIVersion2+"/"):
+		return constants.APIVersion2, true
+	case strings.HasPrefix(path, "/api/"+constants.APIVersion1+"/"):
+		if strings.Contains(c.GetHeader("Accept"), constants.MediaTypeV2) {
+			return constants.APIVersion2, true
+		}
+		return constants.APIVersion1, true
+	}
+	return "", false
+}
+
+// serializingResponseWriter holds back the response so it can be serialized once the handler is done
+type serializingResponseWriter struct {
+	gin.ResponseWriter
+	body    bytes.Buffer
+	written bool
+}
+
+func (w *serializingResponseWriter) Write(data []byte) (int, error) {
+	w.written = true
+	return w.body.Write(data)
+}
+
+func (w *serializingResponseWriter) WriteString(s string) (int, error) {
+	w.written = true
+	return w.body.WriteString(s)
+}
+
+// WriteHeaderNow is called for bodiless responses such as 204 and 304; the header is sent on flush
+func (w *serializingResponseWriter) WriteHeaderNow() {
+	w.written = true
+}
+
+func (w *serializingResponseWriter) Written() bool {
+	return w.written
+}
+
+func (w *serializingResponseWriter) Size() int {
+	if !w.written {
+		return -1
+	}
+	return w.body.Len()
+}
+
+// flush sends the held back response, serializing JSON bodies
+func (w *serializingResponseWriter) flush(c *gin.Context, serializer ResponseSerializer) {
+	if !w.written {
+		return
+	}
+	body := w.body.Bytes()
+	if len(body) > 0 && strings.HasPrefix(w.Header().Get("Content-Type"), "application/json") {
+		body = serializer.Serialize(c, w.Status(), body)
+		w.Header().Del("Content-Length")
+	}
+	w.ResponseWriter.WriteHeaderNow()
+	if len(body) > 0 {
+		_, _ = w.ResponseWriter.Write(body)
+	}
+}
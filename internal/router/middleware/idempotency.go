@@ -0,0 +1,293 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+
+	"newsletter-service/internal/config"
+	"newsletter-service/internal/constants"
+)
+
+const (
+	defaultIdempotencyTTL     = 24 * time.Hour
+	defaultIdempotencyLockTTL = time.Minute
+	maxIdempotencyKeyLength   = 255
+)
+
+// IdempotentResponse is the response cached for an Idempotency-Key and replayed on retries
+type IdempotentResponse struct {
+	Fingerprint string `json:"fingerprint"` // Hash of the request body the key was first used with
+	Status      int    `json:"status"`
+	ContentType string `json:"content_type"`
+	Body        []byte `json:"body"`
+}
+
+// IdempotencyStore persists responses and in-flight locks for idempotency keys
+type IdempotencyStore interface {
+	Get(ctx context.Context, key string) (*IdempotentResponse, error)
+	Save(ctx context.Context, key string, response *IdempotentResponse, ttl time.Duration) error
+	Lock(ctx context.Context, key string, ttl time.Duration) (bool, error)
+	Unlock(ctx context.Context, key string) error
+}
+
+// RedisIdempotencyStore implements IdempotencyStore using Redis so retries may hit any instance
+type RedisIdempotencyStore struct {
+	client redis.UniversalClient
+}
+
+type memoryIdempotencyEntry struct {
+	response  *IdempotentResponse
+	expiresAt time.Time
+}
+
+// MemoryIdempotencyStore implements IdempotencyStore in memory (single instance only)
+type MemoryIdempotencyStore struct {
+	responses map[string]memoryIdempotencyEntry
+	locks     map[string]time.Time
+	mu        sync.Mutex
+}
+
+// NewRedisIdempotencyStore creates a Redis-backed idempotency store
+func NewRedisIdempotencyStore(client redis.UniversalClient) *RedisIdempotencyStore {
+	return &RedisIdempotencyStore{client: client}
+}
+
+// NewMemoryIdempotencyStore creates an in-memory idempotency store
+func NewMemoryIdempotencyStore() *MemoryIdempotencyStore {
+	return &MemoryIdempotencyStore{
+		responses: make(map[string]memoryIdempotencyEntry),
+		locks:     make(map[string]time.Time),
+	}
+}
+
+func (r *RedisIdempotencyStore) Get(ctx context.Context, key string) (*IdempotentResponse, error) {
+	data, err := r.client.Get(ctx, "idempotency:response:"+key).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var response IdempotentResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+func (r *RedisIdempotencyStore) Save(ctx context.Context, key string, response *IdempotentResponse, ttl time.Duration) error {
+	data, err := json.Marshal(response)
+	if err != nil {
+		return err
+	}
+	return r.client.Set(ctx, "idempotency:response:"+key, data, ttl).Err()
+}
+
+func (r *RedisIdempotencyStore) Lock(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	return r.client.SetNX(ctx, "idempotency:lock:"+key, 1, ttl).Result()
+}
+
+func (r *RedisIdempotencyStore) Unlock(ctx context.Context, key string) error {
+	return r.client.Del(ctx, "idempotency:lock:"+key).Err()
+}
+
+func (m *MemoryIdempotencyStore) Get(ctx context.Context, key string) (*IdempotentResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, exists := m.responses[key]
+	if !exists || time.Now().After(entry.expiresAt) {
+		return nil, nil
+	}
+	return entry.response, nil
+}
+
+func (m *MemoryIdempotencyStore) Save(ctx context.Context, key string, response *IdempotentResponse, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// Drop expired responses so the map doesn't grow forever
+	now := time.Now()
+	for k, entry := range m.responses {
+		if now.After(entry.expiresAt) {
+			delete(m.responses, k)
+		}
+	}
+
+	m.responses[key] = memoryIdempotencyEntry{response: response, expiresAt: now.Add(ttl)}
+	return nil
+}
+
+func (m *MemoryIdempotencyStore) Lock(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	if expiresAt, exists := m.locks[key]; exists && now.Before(expiresAt) {
+		return false, nil
+	}
+	m.locks[key] = now.Add(ttl)
+	return true, nil
+}
+
+func (m *MemoryIdempotencyStore) Unlock(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.locks, key)
+	return nil
+}
+
+// bufferedResponseWriter copies the response body so it can be cached after the handler runs
+type bufferedResponseWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *bufferedResponseWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *bufferedResponseWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// IdempotencyMiddleware replays the cached response when a request is retried with the same
+// Idempotency-Key, so retries don't create duplicate resources or send a campaign twice.
+// Requests without the header are passed through unchanged.
+func IdempotencyMiddleware(cfg config.IdempotencyConfig, store IdempotencyStore) gin.HandlerFunc {
+	ttl := cfg.TTL
+	if ttl <= 0 {
+		ttl = defaultIdempotencyTTL
+	}
+	lockTTL := cfg.LockTTL
+	if lockTTL <= 0 {
+		lockTTL = defaultIdempotencyLockTTL
+	}
+
+	return func(c *gin.Context) {
+		idempotencyKey := c.GetHeader(constants.HeaderIdempotencyKey)
+		if !cfg.Enabled || idempotencyKey == "" {
+			c.Next()
+			return
+		}
+
+		if len(idempotencyKey) > maxIdempotencyKeyLength {
+			c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidIdempotencyKey})
+			c.Abort()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidRequestBody})
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		ctx := c.Request.Context()
+		key := idempotencyStoreKey(c, idempotencyKey)
+		fingerprint := requestFingerprint(body)
+
+		cached, err := store.Get(ctx, key)
+		if err != nil {
+			// Fail open: an unavailable store must not block writes
+			log.Printf("Idempotency store error: %v", err)
+			c.Next()
+			return
+		}
+		if cached != nil {
+			replayIdempotentResponse(c, cached, fingerprint)
+			return
+		}
+
+		locked, err := store.Lock(ctx, key, lockTTL)
+		if err != nil {
+			log.Printf("Idempotency store error: %v", err)
+			c.Next()
+			return
+		}
+		if !locked {
+			c.JSON(http.StatusConflict, gin.H{"error": constants.ErrIdempotencyInProgress})
+			c.Abort()
+			return
+		}
+		defer func() {
+			if err := store.Unlock(context.Background(), key); err != nil {
+				log.Printf("Idempotency store error: %v", err)
+			}
+		}()
+
+		// The first request may have saved its response and unlocked between the lookup above and the lock
+		cached, err = store.Get(ctx, key)
+		if err != nil {
+			log.Printf("Idempotency store error: %v", err)
+		}
+		if cached != nil {
+			replayIdempotentResponse(c, cached, fingerprint)
+			return
+		}
+
+		writer := &bufferedResponseWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		c.Next()
+
+		// Server errors are not cached so the client can retry them
+		status := writer.Status()
+		if status >= http.StatusInternalServerError {
+			return
+		}
+
+		response := &IdempotentResponse{
+			Fingerprint: fingerprint,
+			Status:      status,
+			ContentType: writer.Header().Get("Content-Type"),
+			Body:        writer.body.Bytes(),
+		}
+		if err := store.Save(context.Background(), key, response, ttl); err != nil {
+			log.Printf("Idempotency store error: %v", err)
+		}
+	}
+}
+
+func replayIdempotentResponse(c *gin.Context, cached *IdempotentResponse, fingerprint string) {
+	if cached.Fingerprint != fingerprint {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": constants.ErrIdempotencyKeyReused})
+		c.Abort()
+		return
+	}
+
+	c.Header(constants.HeaderIdempotentReplayed, "true")
+	c.Data(cached.Status, cached.ContentType, cached.Body)
+	c.Abort()
+}
+
+// idempotencyStoreKey scopes the client's key to the caller, organization and request target so keys can't
+// collide across users, the organizations an operator works in, or the resources one route addresses
+func idempotencyStoreKey(c *gin.Context, idempotencyKey string) string {
+	caller := c.GetString(gin.AuthUserKey)
+	if caller == "" {
+		caller = c.ClientIP()
+	}
+	return fmt.Sprintf("%s:%d:%s:%s:%s", caller, c.GetUint(constants.ContextKeyOrganizationID), c.Request.Method, c.Request.URL.RequestURI(), idempotencyKey)
+}
+
+func requestFingerprint(body []byte) string {
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,112 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"newsletter-service/internal/config"
+	"newsletter-service/internal/constants"
+)
+
+// interleavingStore is a memory store that runs beforeLock, once, as a request takes the lock on a key, to
+// interleave another request's steps between that request's lookup and its lock
+type interleavingStore struct {
+	*MemoryIdempotencyStore
+	beforeLock func(key string)
+}
+
+func (s *interleavingStore) Lock(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	if s.beforeLock != nil {
+		beforeLock := s.beforeLock
+		s.beforeLock = nil
+		beforeLock(key)
+	}
+	return s.MemoryIdempotencyStore.Lock(ctx, key, ttl)
+}
+
+// TestIdempotencyRetryAfterFirstRequestFinishes has a retry miss the cached response, then the first request
+// save it and unlock before the retry locks the key. The retry must replay the response, not run the handler.
+func TestIdempotencyRetryAfterFirstRequestFinishes(t *testing.T) {
+	const body = `{"email":"ada@example.com"}`
+	store := &interleavingStore{MemoryIdempotencyStore: NewMemoryIdempotencyStore()}
+	first := &IdempotentResponse{
+		Fingerprint: requestFingerprint([]byte(body)),
+		Status:      http.StatusCreated,
+		ContentType: "application/json; charset=utf-8",
+		Body:        []byte(`{"id":1}`),
+	}
+	store.beforeLock = func(key string) {
+		ctx := context.Background()
+		// The first request holds the lock, saves its response and releases the lock
+		if locked, _ := store.MemoryIdempotencyStore.Lock(ctx, key, time.Minute); !locked {
+			t.Fatal("first request could not lock the key")
+		}
+		if err := store.Save(ctx, key, first, time.Hour); err != nil {
+			t.Fatal(err)
+		}
+		if err := store.Unlock(ctx, key); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	calls := 0
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(IdempotencyMiddleware(config.IdempotencyConfig{Enabled: true}, store))
+	router.POST("/subscribers", func(c *gin.Context) {
+		calls++
+		c.JSON(http.StatusCreated, gin.H{"id": 2})
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/subscribers", strings.NewReader(body))
+	req.Header.Set(constants.HeaderIdempotencyKey, "retry-1")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if calls != 0 {
+		t.Fatalf("handler ran %d times for a key whose response was saved, want 0", calls)
+	}
+	if w.Code != first.Status || w.Body.String() != string(first.Body) {
+		t.Errorf("response = %d %s, want the first request's %d %s", w.Code, w.Body, first.Status, first.Body)
+	}
+	if w.Header().Get(constants.HeaderIdempotentReplayed) != "true" {
+		t.Errorf("%s header missing from the replayed response", constants.HeaderIdempotentReplayed)
+	}
+}
+
+// TestIdempotencyRetryWhileFirstRequestRuns has a retry arrive while the first request holds the lock
+func TestIdempotencyRetryWhileFirstRequestRuns(t *testing.T) {
+	store := &interleavingStore{MemoryIdempotencyStore: NewMemoryIdempotencyStore()}
+	store.beforeLock = func(key string) {
+		if locked, _ := store.MemoryIdempotencyStore.Lock(context.Background(), key, time.Minute); !locked {
+			t.Fatal("first request could not lock the key")
+		}
+	}
+
+	calls := 0
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(IdempotencyMiddleware(config.IdempotencyConfig{Enabled: true}, store))
+	router.POST("/subscribers", func(c *gin.Context) {
+		calls++
+		c.JSON(http.StatusCreated, gin.H{"id": 2})
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/subscribers", strings.NewReader(`{}`))
+	req.Header.Set(constants.HeaderIdempotencyKey, "retry-2")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if calls != 0 {
+		t.Fatalf("handler ran %d times while the key was locked, want 0", calls)
+	}
+	if w.Code != http.StatusConflict {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusConflict)
+	}
+}
@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// defaultCleanupInterval is used when rate_limit.cleanup_interval is not configured
+const defaultCleanupInterval = 5 * time.Minute
+
+// StartRateLimitJanitor periodically removes expired entries from limiter until ctx is cancelled
+func StartRateLimitJanitor(ctx context.Context, limiter RateLimiter, interval time.Duration) {
+	if interval <= 0 {
+		interval = defaultCleanupInterval
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := limiter.CleanupExpired(); err != nil {
+					log.Printf("Warning: rate limiter cleanup failed: %v", err)
+				}
+			}
+		}
+	}()
+}
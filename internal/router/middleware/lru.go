@@ -0,0 +1,47 @@
+package middleware
+
+import "container/list"
+
+// keyLRU tracks key recency so memory limiters can evict the least recently used
+// entry once they hold maxEntries keys. It is not safe for concurrent use; callers
+// hold their own lock.
+type keyLRU struct {
+	order      *list.List
+	elements   map[string]*list.Element
+	maxEntries int // 0 means unbounded
+}
+
+func newKeyLRU(maxEntries int) *keyLRU {
+	return &keyLRU{
+		order:      list.New(),
+		elements:   make(map[string]*list.Element),
+		maxEntries: maxEntries,
+	}
+}
+
+// touch marks key as most recently used and returns the key evicted to stay within maxEntries, if any
+func (l *keyLRU) touch(key string) (string, bool) {
+	if element, exists := l.elements[key]; exists {
+		l.order.MoveToFront(element)
+		return "", false
+	}
+
+	l.elements[key] = l.order.PushFront(key)
+	if l.maxEntries <= 0 || l.order.Len() <= l.maxEntries {
+		return "", false
+	}
+
+	oldest := l.order.Back()
+	evicted := oldest.Value.(string)
+	l.order.Remove(oldest)
+	delete(l.elements, evicted)
+	return evicted, true
+}
+
+// remove forgets key
+func (l *keyLRU) remove(key string) {
+	if element, exists := l.elements[key]; exists {
+		l.order.Remove(element)
+		delete(l.elements, key)
+	}
+}
@@ -1,19 +1,111 @@
 package middleware
 
 import (
+	"context"
+	"errors"
+	"log"
 	"net/http"
 	"strings"
 
 	"newsletter-service/internal/config"
+	"newsletter-service/internal/constants"
+	"newsletter-service/internal/services/apikey"
+	"newsletter-service/internal/services/auth"
+	"newsletter-service/internal/tenant"
 
 	"github.com/gin-gonic/gin"
 )
 
-// AuthMiddleware provides basic authentication
-func AuthMiddleware(cfg *config.Config) gin.HandlerFunc {
-	return gin.BasicAuth(gin.Accounts{
+// AuthMiddleware accepts an API key, a JWT bearer token or basic authentication. API keys belong to an
+// organization and scope the request to it; the other two are operator credentials.
+func AuthMiddleware(cfg *config.Config, authService auth.Service, apiKeyService apikey.Service) gin.HandlerFunc {
+	basicAuth := gin.BasicAuth(gin.Accounts{
 		cfg.Auth.Username: cfg.Auth.Password,
 	})
+	jwtAuth := JWTAuthMiddleware(authService)
+	apiKeyAuth := APIKeyAuthMiddleware(apiKeyService)
+
+	return gin.HandlerFunc(func(c *gin.Context) {
+		if apiKeyService != nil && c.GetHeader(constants.HeaderAPIKey) != "" {
+			apiKeyAuth(c)
+			return
+		}
+		if authService != nil && strings.HasPrefix(c.GetHeader("Authorization"), constants.BearerTokenType+" ") {
+			jwtAuth(c)
+			return
+		}
+
+		basicAuth(c)
+		if !c.IsAborted() {
+			c.Set(constants.ContextKeyAuthMethod, constants.AuthMethodBasic)
+		}
+	})
+}
+
+// JWTAuthMiddleware verifies bearer access tokens issued by the auth service
+func JWTAuthMiddleware(authService auth.Service) gin.HandlerFunc {
+	return gin.HandlerFunc(func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		if !strings.HasPrefix(header, constants.BearerTokenType+" ") {
+			c.Header("WWW-Authenticate", constants.BearerTokenType+" realm=\""+constants.BasicAuthRealm+"\"")
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error":   constants.ErrUnauthorized,
+				"message": "Bearer token required",
+			})
+			c.Abort()
+			return
+		}
+
+		token := strings.TrimPrefix(header, constants.BearerTokenType+" ")
+		claims, err := authService.ValidateAccessToken(c.Request.Context(), token)
+		if err != nil {
+			c.Header("WWW-Authenticate", constants.BearerTokenType+" error=\"invalid_token\"")
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error":   constants.ErrUnauthorized,
+				"message": constants.ErrInvalidToken,
+			})
+			c.Abort()
+			return
+		}
+
+		// Expose the authenticated user the same way gin.BasicAuth does
+		c.Set(gin.AuthUserKey, claims.Subject)
+		c.Set(constants.ContextKeyAuthMethod, constants.AuthMethodJWT)
+		ctx := context.WithValue(c.Request.Context(), "user_id", claims.Subject)
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+	})
+}
+
+// APIKeyAuthMiddleware authenticates the X-API-Key header and scopes the request to the key's organization
+func APIKeyAuthMiddleware(apiKeyService apikey.Service) gin.HandlerFunc {
+	return gin.HandlerFunc(func(c *gin.Context) {
+		key, err := apiKeyService.Authenticate(c.Request.Context(), c.GetHeader(constants.HeaderAPIKey))
+		if err != nil {
+			if !errors.Is(err, apikey.ErrInvalidKey) {
+				log.Printf("Failed to authenticate API key: %v", err)
+				c.JSON(http.StatusInternalServerError, gin.H{"error": constants.ErrInternalServerError})
+				c.Abort()
+				return
+			}
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error":   constants.ErrUnauthorized,
+				"message": constants.ErrInvalidAPIKey,
+			})
+			c.Abort()
+			return
+		}
+
+		// The key's prefix identifies it in audit logs without exposing the secret
+		c.Set(gin.AuthUserKey, key.KeyPrefix)
+		c.Set(constants.ContextKeyAuthMethod, constants.AuthMethodAPIKey)
+		c.Set(constants.ContextKeyOrganizationID, key.OrganizationID)
+		c.Set(constants.ContextKeyAPIKeyID, key.ID)
+		c.Request = c.Request.WithContext(tenant.WithOrganization(c.Request.Context(), key.OrganizationID))
+
+		c.Next()
+	})
 }
 
 // SchedulerAuthMiddleware provides separate authentication for scheduler APIs
@@ -56,8 +148,9 @@ func CORSMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
 		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With, X-API-Key")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With, X-API-Key, X-Organization-ID, Idempotency-Key, If-Match, If-None-Match")
 		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE")
+		c.Writer.Header().Set("Access-Control-Expose-Headers", "X-RateLimit-Limit, X-RateLimit-Remaining, X-RateLimit-Reset, Retry-After, Idempotent-Replayed, ETag, API-Version")
 
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(204)
@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"newsletter-service/internal/constants"
+	"newsletter-service/internal/services/organization"
+	"newsletter-service/internal/tenant"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// OrganizationMiddleware scopes requests made with operator credentials to the organization named in the
+// X-Organization-ID header, or the default organization without one. Requests authenticated with an API key
+// are already scoped to the key's organization and may only repeat it in the header.
+// It must run after AuthMiddleware.
+func OrganizationMiddleware(organizationService organization.Service) gin.HandlerFunc {
+	return gin.HandlerFunc(func(c *gin.Context) {
+		header := c.GetHeader(constants.HeaderOrganizationID)
+
+		var organizationID uint
+		if header == "" {
+			organizationID = tenant.DefaultOrganizationID
+		} else {
+			id, err := strconv.ParseUint(header, 10, 32)
+			if err != nil || id == 0 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrInvalidOrganizationID})
+				c.Abort()
+				return
+			}
+			organizationID = uint(id)
+		}
+
+		if keyOrganizationID, ok := tenant.OrganizationID(c.Request.Context()); ok {
+			if header != "" && organizationID != keyOrganizationID {
+				c.JSON(http.StatusForbidden, gin.H{"error": constants.ErrOrganizationMismatch})
+				c.Abort()
+				return
+			}
+			c.Next()
+			return
+		}
+
+		if organizationID != tenant.DefaultOrganizationID {
+			if _, err := organizationService.GetOrganizationByID(c.Request.Context(), organizationID); err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrOrganizationNotFound})
+				} else {
+					log.Printf("Failed to look up organization %d: %v", organizationID, err)
+					c.JSON(http.StatusInternalServerError, gin.H{"error": constants.ErrInternalServerError})
+				}
+				c.Abort()
+				return
+			}
+		}
+
+		c.Set(constants.ContextKeyOrganizationID, organizationID)
+		c.Request = c.Request.WithContext(tenant.WithOrganization(c.Request.Context(), organizationID))
+		c.Next()
+	})
+}
+
+// OperatorOnlyMiddleware rejects requests made with an API key, for routes that manage the whole deployment
+// rather than one organization
+func OperatorOnlyMiddleware() gin.HandlerFunc {
+	return gin.HandlerFunc(func(c *gin.Context) {
+		if c.GetString(constants.ContextKeyAuthMethod) == constants.AuthMethodAPIKey {
+			c.JSON(http.StatusForbidden, gin.H{"error": constants.ErrOperatorCredentialsOnly})
+			c.Abort()
+			return
+		}
+		c.Next()
+	})
+}
@@ -3,7 +3,10 @@ package middleware
 import (
 	"encoding/json"
 	"fmt"
+	"log"
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -12,6 +15,7 @@ import (
 	"github.com/go-redis/redis/v8"
 
 	"newsletter-service/internal/config"
+	"newsletter-service/internal/services/apikey"
 )
 
 // TokenBucket represents a leaky bucket for rate limiting
@@ -23,25 +27,47 @@ type TokenBucket struct {
 	LastRefill time.Time     `json:"last_refill"` // Last refill time
 }
 
+// RateLimitResult describes the outcome of a rate limit check and the state of the caller's bucket
+type RateLimitResult struct {
+	Allowed   bool
+	Limit     int       // Bucket capacity
+	Remaining int       // Tokens left after this request
+	ResetAt   time.Time // When the next refill adds tokens
+}
+
+// RetryAfter returns how long a rejected caller should wait before retrying
+func (r *RateLimitResult) RetryAfter(now time.Time) time.Duration {
+	if wait := r.ResetAt.Sub(now); wait > 0 {
+		return wait
+	}
+	return 0
+}
+
 // RateLimiter interface for different storage backends
 type RateLimiter interface {
-	Allow(key string, rule config.RateLimitRule) (bool, error)
+	Allow(key string, rule config.RateLimitRule) (*RateLimitResult, error)
 	CleanupExpired() error
 }
 
+// requestReleaser is implemented by limiters that hold capacity until the request completes
+type requestReleaser interface {
+	Release(key string, rule config.RateLimitRule) error
+}
+
 // RedisRateLimiter implements RateLimiter using Redis
 type RedisRateLimiter struct {
-	client *redis.Client
+	client redis.UniversalClient
 }
 
 // MemoryRateLimiter implements RateLimiter using in-memory storage
 type MemoryRateLimiter struct {
 	buckets map[string]*TokenBucket
+	lru     *keyLRU
 	mu      sync.RWMutex
 }
 
 // NewRedisRateLimiter creates a new Redis-based rate limiter
-func NewRedisRateLimiter(client *redis.Client) *RedisRateLimiter {
+func NewRedisRateLimiter(client redis.UniversalClient) *RedisRateLimiter {
 	return &RedisRateLimiter{
 		client: client,
 	}
@@ -49,13 +75,20 @@ func NewRedisRateLimiter(client *redis.Client) *RedisRateLimiter {
 
 // NewMemoryRateLimiter creates a new memory-based rate limiter
 func NewMemoryRateLimiter() *MemoryRateLimiter {
+	return NewMemoryRateLimiterWithMaxEntries(0)
+}
+
+// NewMemoryRateLimiterWithMaxEntries creates a memory-based rate limiter that evicts the
+// least recently used bucket once it holds maxEntries buckets (0 means unbounded)
+func NewMemoryRateLimiterWithMaxEntries(maxEntries int) *MemoryRateLimiter {
 	return &MemoryRateLimiter{
 		buckets: make(map[string]*TokenBucket),
+		lru:     newKeyLRU(maxEntries),
 	}
 }
 
 // Allow checks if a request should be allowed based on rate limiting rules
-func (r *RedisRateLimiter) Allow(key string, rule config.RateLimitRule) (bool, error) {
+func (r *RedisRateLimiter) Allow(key string, rule config.RateLimitRule) (*RateLimitResult, error) {
 	now := time.Now()
 	bucketKey := fmt.Sprintf("rate_limit:%s", key)
 
@@ -73,12 +106,12 @@ func (r *RedisRateLimiter) Allow(key string, rule config.RateLimitRule) (bool, e
 			LastRefill: now,
 		}
 	} else if err != nil {
-		return false, err
+		return nil, err
 	} else {
 		// Parse existing bucket
 		bucket = &TokenBucket{}
 		if err := json.Unmarshal([]byte(data), bucket); err != nil {
-			return false, err
+			return nil, err
 		}
 
 		// Refill tokens if enough time has passed
@@ -88,7 +121,7 @@ func (r *RedisRateLimiter) Allow(key string, rule config.RateLimitRule) (bool, e
 		if bucket.Tokens <= 0 {
 			// Save updated bucket back to Redis
 			r.saveBucket(bucketKey, bucket)
-			return false, nil
+			return newRateLimitResult(bucket, false), nil
 		}
 
 		// Consume a token
@@ -97,10 +130,10 @@ func (r *RedisRateLimiter) Allow(key string, rule config.RateLimitRule) (bool, e
 
 	// Save updated bucket back to Redis with expiration
 	if err := r.saveBucket(bucketKey, bucket); err != nil {
-		return false, err
+		return nil, err
 	}
 
-	return true, nil
+	return newRateLimitResult(bucket, true), nil
 }
 
 // CleanupExpired removes expired buckets (handled automatically by Redis TTL)
@@ -110,13 +143,17 @@ func (r *RedisRateLimiter) CleanupExpired() error {
 }
 
 // Allow checks if a request should be allowed based on rate limiting rules
-func (m *MemoryRateLimiter) Allow(key string, rule config.RateLimitRule) (bool, error) {
+func (m *MemoryRateLimiter) Allow(key string, rule config.RateLimitRule) (*RateLimitResult, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	now := time.Now()
 	bucket, exists := m.buckets[key]
 
+	if evicted, ok := m.lru.touch(key); ok {
+		delete(m.buckets, evicted)
+	}
+
 	if !exists {
 		// Create new bucket
 		bucket = &TokenBucket{
@@ -127,7 +164,7 @@ func (m *MemoryRateLimiter) Allow(key string, rule config.RateLimitRule) (bool,
 			LastRefill: now,
 		}
 		m.buckets[key] = bucket
-		return true, nil
+		return newRateLimitResult(bucket, true), nil
 	}
 
 	// Refill tokens if enough time has passed
@@ -135,25 +172,26 @@ func (m *MemoryRateLimiter) Allow(key string, rule config.RateLimitRule) (bool,
 
 	// Check if we have tokens available
 	if bucket.Tokens <= 0 {
-		return false, nil
+		return newRateLimitResult(bucket, false), nil
 	}
 
 	// Consume a token
 	bucket.Tokens--
-	return true, nil
+	return newRateLimitResult(bucket, true), nil
 }
 
-// CleanupExpired removes expired buckets from memory
+// CleanupExpired removes buckets that have refilled completely, since a fresh bucket is equivalent
 func (m *MemoryRateLimiter) CleanupExpired() error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	now := time.Now()
-	expiry := 24 * time.Hour // Remove buckets older than 24 hours
+	expiry := 24 * time.Hour // Remove buckets older than 24 hours regardless
 
 	for key, bucket := range m.buckets {
-		if now.Sub(bucket.LastRefill) > expiry {
+		if now.Sub(bucket.LastRefill) > expiry || bucketRefilled(bucket, now) {
 			delete(m.buckets, key)
+			m.lru.remove(key)
 		}
 	}
 
@@ -162,6 +200,52 @@ func (m *MemoryRateLimiter) CleanupExpired() error {
 
 // Helper methods
 
+// bucketRefilled reports whether enough time has passed for the bucket to be full again
+func bucketRefilled(bucket *TokenBucket, now time.Time) bool {
+	if bucket.RefillSize <= 0 || bucket.RefillRate <= 0 {
+		return false
+	}
+	missing := bucket.Capacity - bucket.Tokens
+	refills := (missing + bucket.RefillSize - 1) / bucket.RefillSize
+	return now.Sub(bucket.LastRefill) >= time.Duration(refills)*bucket.RefillRate
+}
+
+// lookupAPIKeyOverride returns the custom rate limit for the request's X-API-Key, if any
+func lookupAPIKeyOverride(c *gin.Context, apiKeyService apikey.Service) *apikey.RateLimitOverride {
+	rawKey := c.GetHeader("X-API-Key")
+	if apiKeyService == nil || rawKey == "" {
+		return nil
+	}
+
+	override, err := apiKeyService.GetRateLimitOverride(c.Request.Context(), rawKey)
+	if err != nil {
+		// Fall back to the configured rule rather than failing the request
+		log.Printf("Warning: API key rate limit lookup failed: %v", err)
+		return nil
+	}
+	return override
+}
+
+func newRateLimitResult(bucket *TokenBucket, allowed bool) *RateLimitResult {
+	remaining := bucket.Tokens
+	if remaining < 0 {
+		remaining = 0
+	}
+	return &RateLimitResult{
+		Allowed:   allowed,
+		Limit:     bucket.Capacity,
+		Remaining: remaining,
+		ResetAt:   bucket.LastRefill.Add(bucket.RefillRate),
+	}
+}
+
+// setRateLimitHeaders exposes the caller's bucket state so clients can pace themselves
+func setRateLimitHeaders(c *gin.Context, result *RateLimitResult) {
+	c.Header("X-RateLimit-Limit", strconv.Itoa(result.Limit))
+	c.Header("X-RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	c.Header("X-RateLimit-Reset", strconv.FormatInt(result.ResetAt.Unix(), 10))
+}
+
 func (r *RedisRateLimiter) refillTokens(bucket *TokenBucket, now time.Time) {
 	// Calculate how many refill periods have passed
 	elapsed := now.Sub(bucket.LastRefill)
@@ -204,9 +288,13 @@ func (r *RedisRateLimiter) saveBucket(key string, bucket *TokenBucket) error {
 	return r.client.Set(r.client.Context(), key, data, time.Hour).Err()
 }
 
-// RateLimitMiddleware creates a rate limiting middleware
-func RateLimitMiddleware(cfg *config.Config, limiter RateLimiter) gin.HandlerFunc {
+// RateLimitMiddleware creates a rate limiting middleware.
+// Rules are read from cfgProvider on every request so reloaded limits apply immediately.
+// Requests carrying an API key with a custom limit use that key's bucket instead of the route rule.
+func RateLimitMiddleware(cfgProvider config.Provider, limiter RateLimiter, apiKeyService apikey.Service) gin.HandlerFunc {
 	return gin.HandlerFunc(func(c *gin.Context) {
+		cfg := cfgProvider.Current()
+
 		// Skip if rate limiting is disabled
 		if !cfg.RateLimit.Enabled {
 			c.Next()
@@ -225,6 +313,19 @@ func RateLimitMiddleware(cfg *config.Config, limiter RateLimiter) gin.HandlerFun
 			rule = routeRule
 		}
 
+		// Per-API-key overrides take precedence over route rules
+		var identifier string
+		if override := lookupAPIKeyOverride(c, apiKeyService); override != nil {
+			rule = config.RateLimitRule{
+				BucketSize:     override.BucketSize,
+				RefillSize:     override.RefillSize,
+				RefillDuration: override.RefillDuration,
+				IdentifyBy:     "api_key",
+				Enabled:        true,
+			}
+			identifier = fmt.Sprintf("api_key_id:%d", override.APIKeyID)
+		}
+
 		// Skip if rule is disabled
 		if !rule.Enabled {
 			c.Next()
@@ -232,9 +333,10 @@ func RateLimitMiddleware(cfg *config.Config, limiter RateLimiter) gin.HandlerFun
 		}
 
 		// Generate identifier based on rule configuration
-		var identifier string
-		switch rule.IdentifyBy {
-		case "api_key":
+		switch {
+		case identifier != "":
+			// Already keyed by the API key record
+		case rule.IdentifyBy == "api_key":
 			apiKey := c.GetHeader("X-API-Key")
 			if apiKey == "" {
 				apiKey = c.GetHeader("Authorization")
@@ -246,8 +348,6 @@ func RateLimitMiddleware(cfg *config.Config, limiter RateLimiter) gin.HandlerFun
 				apiKey = "anonymous"
 			}
 			identifier = fmt.Sprintf("api_key:%s", apiKey)
-		case "ip":
-			fallthrough
 		default:
 			// Default to IP-based rate limiting
 			clientIP := c.ClientIP()
@@ -255,7 +355,7 @@ func RateLimitMiddleware(cfg *config.Config, limiter RateLimiter) gin.HandlerFun
 		}
 
 		// Check if request is allowed
-		allowed, err := limiter.Allow(identifier, rule)
+		result, err := limiter.Allow(identifier, rule)
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{
 				"error":   "Internal server error",
@@ -265,16 +365,31 @@ func RateLimitMiddleware(cfg *config.Config, limiter RateLimiter) gin.HandlerFun
 			return
 		}
 
-		if !allowed {
+		setRateLimitHeaders(c, result)
+
+		if !result.Allowed {
+			retryAfter := int(math.Ceil(result.RetryAfter(time.Now()).Seconds()))
+			if retryAfter < 1 {
+				retryAfter = 1
+			}
+			c.Header("Retry-After", strconv.Itoa(retryAfter))
 			c.JSON(http.StatusTooManyRequests, gin.H{
 				"error":       "Rate limit exceeded",
 				"message":     "Too many requests. Please try again later.",
-				"retry_after": rule.RefillDuration.Seconds(),
+				"retry_after": retryAfter,
 			})
 			c.Abort()
 			return
 		}
 
+		if releaser, ok := limiter.(requestReleaser); ok {
+			defer func() {
+				if err := releaser.Release(identifier, rule); err != nil {
+					log.Printf("Warning: failed to release rate limit slot: %v", err)
+				}
+			}()
+		}
+
 		c.Next()
 	})
 }
@@ -0,0 +1,175 @@
+package middleware
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"newsletter-service/internal/config"
+)
+
+// slidingWindowScript counts the request against the current fixed window if the
+// weighted estimate across the previous and current windows is under the limit.
+// Returns {allowed, estimated count after this request}.
+var slidingWindowScript = redis.NewScript(`
+local curr = tonumber(redis.call('GET', KEYS[1]) or '0')
+local prev = tonumber(redis.call('GET', KEYS[2]) or '0')
+local weight = tonumber(ARGV[1])
+local limit = tonumber(ARGV[2])
+local estimated = prev * weight + curr
+if estimated >= limit then
+	return {0, math.ceil(estimated)}
+end
+curr = redis.call('INCR', KEYS[1])
+redis.call('PEXPIRE', KEYS[1], ARGV[3])
+return {1, math.ceil(prev * weight + curr)}
+`)
+
+// windowCounters holds the request counts of two adjacent fixed windows
+type windowCounters struct {
+	window   int64         // Index of the current window
+	length   time.Duration // Window length the counters were recorded with
+	current  int
+	previous int
+	lastSeen time.Time
+}
+
+// RedisSlidingWindowLimiter implements a sliding window counter in Redis
+type RedisSlidingWindowLimiter struct {
+	client redis.UniversalClient
+}
+
+// MemorySlidingWindowLimiter implements a sliding window counter in memory
+type MemorySlidingWindowLimiter struct {
+	counters map[string]*windowCounters
+	lru      *keyLRU
+	mu       sync.Mutex
+}
+
+// NewRedisSlidingWindowLimiter creates a Redis-based sliding window limiter
+func NewRedisSlidingWindowLimiter(client redis.UniversalClient) *RedisSlidingWindowLimiter {
+	return &RedisSlidingWindowLimiter{client: client}
+}
+
+// NewMemorySlidingWindowLimiter creates a memory-based sliding window limiter that evicts the
+// least recently used key once it holds maxEntries keys (0 means unbounded)
+func NewMemorySlidingWindowLimiter(maxEntries int) *MemorySlidingWindowLimiter {
+	return &MemorySlidingWindowLimiter{
+		counters: make(map[string]*windowCounters),
+		lru:      newKeyLRU(maxEntries),
+	}
+}
+
+// Allow checks the request against the weighted count of the current and previous windows
+func (r *RedisSlidingWindowLimiter) Allow(key string, rule config.RateLimitRule) (*RateLimitResult, error) {
+	now := time.Now()
+	window := slidingWindowLength(rule)
+	index, weight := slidingWindowPosition(now, window)
+
+	// The hash tag keeps both windows in one cluster slot, as the script needs
+	keys := []string{
+		fmt.Sprintf("rate_limit:sw:{%s}:%d", key, index),
+		fmt.Sprintf("rate_limit:sw:{%s}:%d", key, index-1),
+	}
+	res, err := slidingWindowScript.Run(r.client.Context(), r.client, keys, weight, rule.Limit, (2 * window).Milliseconds()).Slice()
+	if err != nil {
+		return nil, err
+	}
+
+	allowed, _ := res[0].(int64)
+	count, _ := res[1].(int64)
+	return newSlidingWindowResult(rule, allowed == 1, int(count), index, window), nil
+}
+
+// CleanupExpired removes expired windows (handled automatically by Redis TTL)
+func (r *RedisSlidingWindowLimiter) CleanupExpired() error {
+	return nil
+}
+
+// Allow checks the request against the weighted count of the current and previous windows
+func (m *MemorySlidingWindowLimiter) Allow(key string, rule config.RateLimitRule) (*RateLimitResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	window := slidingWindowLength(rule)
+	index, weight := slidingWindowPosition(now, window)
+
+	if evicted, ok := m.lru.touch(key); ok {
+		delete(m.counters, evicted)
+	}
+
+	counters, exists := m.counters[key]
+	if !exists {
+		counters = &windowCounters{window: index}
+		m.counters[key] = counters
+	}
+	counters.length = window
+	counters.lastSeen = now
+
+	// Roll the windows forward
+	switch {
+	case counters.window == index-1:
+		counters.previous = counters.current
+		counters.current = 0
+		counters.window = index
+	case counters.window < index-1:
+		counters.previous = 0
+		counters.current = 0
+		counters.window = index
+	}
+
+	estimated := float64(counters.previous)*weight + float64(counters.current)
+	if estimated >= float64(rule.Limit) {
+		return newSlidingWindowResult(rule, false, int(estimated+0.5), index, window), nil
+	}
+
+	counters.current++
+	estimated++
+	return newSlidingWindowResult(rule, true, int(estimated+0.5), index, window), nil
+}
+
+// CleanupExpired removes counters whose windows have both elapsed
+func (m *MemorySlidingWindowLimiter) CleanupExpired() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	for key, counters := range m.counters {
+		if now.Sub(counters.lastSeen) > 2*counters.length {
+			delete(m.counters, key)
+			m.lru.remove(key)
+		}
+	}
+	return nil
+}
+
+// slidingWindowLength returns the configured window, defaulting to one minute
+func slidingWindowLength(rule config.RateLimitRule) time.Duration {
+	if rule.Window > 0 {
+		return rule.Window
+	}
+	return time.Minute
+}
+
+// slidingWindowPosition returns the current window index and the weight of the previous window
+func slidingWindowPosition(now time.Time, window time.Duration) (int64, float64) {
+	index := now.UnixNano() / int64(window)
+	elapsed := now.UnixNano() - index*int64(window)
+	return index, 1 - float64(elapsed)/float64(window)
+}
+
+func newSlidingWindowResult(rule config.RateLimitRule, allowed bool, count int, index int64, window time.Duration) *RateLimitResult {
+	remaining := rule.Limit - count
+	if remaining < 0 {
+		remaining = 0
+	}
+	return &RateLimitResult{
+		Allowed:   allowed,
+		Limit:     rule.Limit,
+		Remaining: remaining,
+		ResetAt:   time.Unix(0, (index+1)*int64(window)),
+	}
+}
@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"github.com/go-redis/redis/v8"
+
+	"newsletter-service/internal/config"
+)
+
+// StrategyRateLimiter dispatches each check to the limiter for the rule's strategy
+type StrategyRateLimiter struct {
+	tokenBucket   RateLimiter
+	slidingWindow RateLimiter
+	concurrency   ConcurrencyLimiter
+}
+
+// NewStrategyRateLimiter combines one limiter per strategy
+func NewStrategyRateLimiter(tokenBucket, slidingWindow RateLimiter, concurrency ConcurrencyLimiter) *StrategyRateLimiter {
+	return &StrategyRateLimiter{
+		tokenBucket:   tokenBucket,
+		slidingWindow: slidingWindow,
+		concurrency:   concurrency,
+	}
+}
+
+// NewRedisStrategyRateLimiter creates Redis-backed limiters for every strategy
+func NewRedisStrategyRateLimiter(client redis.UniversalClient) *StrategyRateLimiter {
+	return NewStrategyRateLimiter(NewRedisRateLimiter(client), NewRedisSlidingWindowLimiter(client), NewRedisConcurrencyLimiter(client))
+}
+
+// NewMemoryStrategyRateLimiter creates in-memory limiters for every strategy, each keeping at most
+// maxEntries keys (0 means unbounded)
+func NewMemoryStrategyRateLimiter(maxEntries int) *StrategyRateLimiter {
+	return NewStrategyRateLimiter(
+		NewMemoryRateLimiterWithMaxEntries(maxEntries),
+		NewMemorySlidingWindowLimiter(maxEntries),
+		NewMemoryConcurrencyLimiter(),
+	)
+}
+
+// Allow checks the request with the limiter matching rule.Strategy
+func (s *StrategyRateLimiter) Allow(key string, rule config.RateLimitRule) (*RateLimitResult, error) {
+	return s.limiterFor(rule).Allow(key, rule)
+}
+
+// Release frees a concurrency slot; other strategies hold nothing to release
+func (s *StrategyRateLimiter) Release(key string, rule config.RateLimitRule) error {
+	if rule.Strategy != config.RateLimitStrategyConcurrency {
+		return nil
+	}
+	return s.concurrency.Release(key)
+}
+
+// CleanupExpired cleans up every underlying limiter
+func (s *StrategyRateLimiter) CleanupExpired() error {
+	for _, limiter := range []RateLimiter{s.tokenBucket, s.slidingWindow, s.concurrency} {
+		if err := limiter.CleanupExpired(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *StrategyRateLimiter) limiterFor(rule config.RateLimitRule) RateLimiter {
+	switch rule.Strategy {
+	case config.RateLimitStrategySlidingWindow:
+		return s.slidingWindow
+	case config.RateLimitStrategyConcurrency:
+		return s.concurrency
+	default:
+		return s.tokenBucket
+	}
+}
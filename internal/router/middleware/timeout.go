@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"newsletter-service/internal/config"
+	apperrors "newsletter-service/internal/errors"
+)
+
+// TimeoutMiddleware puts a deadline on the request context, from [timeouts] request or the route's override
+// under [timeouts.routes]. Services and repositories pass the context on, so a query still running at the
+// deadline is cancelled rather than holding a connection for a client that has likely given up. A handler that
+// returns without answering after the deadline is answered with 504. Settings are read per request and follow
+// config reloads.
+func TimeoutMiddleware(cfgProvider config.Provider) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		timeout := requestTimeout(cfgProvider.Current().Timeouts, c)
+		if timeout <= 0 {
+			c.Next()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) && !c.Writer.Written() {
+			apperrors.Abort(c, apperrors.NewTimeoutError("The request took too long and was cancelled", ctx.Err()))
+		}
+	}
+}
+
+// requestTimeout returns the route's own timeout if it has one, or the default
+func requestTimeout(cfg config.TimeoutsConfig, c *gin.Context) time.Duration {
+	if route := c.FullPath(); route != "" {
+		if timeout, ok := cfg.Routes[c.Request.Method+":"+route]; ok {
+			return timeout
+		}
+	}
+	return cfg.Request
+}
@@ -1,75 +1,92 @@
 package router
 
 import (
+	"context"
+
 	"github.com/gin-gonic/gin"
 	"github.com/go-redis/redis/v8"
 
 	"newsletter-service/internal/config"
+	"newsletter-service/internal/constants"
 	"newsletter-service/internal/errors"
 	"newsletter-service/internal/handlers"
 	"newsletter-service/internal/logger"
+	"newsletter-service/internal/metrics"
 	"newsletter-service/internal/router/middleware"
+	"newsletter-service/internal/services/apikey"
+	"newsletter-service/internal/services/auth"
+	"newsletter-service/internal/services/organization"
+	"newsletter-service/internal/tracing"
 )
 
-func SetupRoutes(h *handlers.Handler, cfg *config.Config, redisClient *redis.Client) *gin.Engine {
+func SetupRoutes(h *handlers.Handler, cfgProvider config.Provider, redisClient redis.UniversalClient, authService auth.Service, apiKeyService apikey.Service, organizationService organization.Service) *gin.Engine {
 	r := gin.Default()
+	cfg := cfgProvider.Current()
 
 	// Apply global middleware
+	r.Use(tracing.GinMiddleware(constants.ServiceNameMain))
 	r.Use(middleware.CORSMiddleware())
 	r.Use(middleware.ValidationMiddleware())
 	r.Use(logger.LoggerMiddleware())
 	r.Use(errors.ErrorHandler())
+	r.Use(middleware.APIVersionMiddleware())
+	r.Use(middleware.TimeoutMiddleware(cfgProvider))
+
+	// Health check endpoints (no auth required, registered before rate limiting so probes are never throttled)
+	r.GET("/health", h.Health.Health)
+	r.GET("/health/live", h.Health.Live)
+	r.GET("/health/ready", h.Health.Ready)
+
+	// Prometheus metrics (no auth required, like the health checks; keep it off the public ingress)
+	r.GET("/metrics", gin.WrapH(metrics.Handler()))
+
+	// API documentation (no auth required)
+	r.GET("/openapi.json", h.Docs.OpenAPIJSON)
+	r.GET("/openapi.yaml", h.Docs.OpenAPIYAML)
+	r.GET("/docs", h.Docs.SwaggerUI)
 
 	// Initialize rate limiter based on configuration
 	var rateLimiter middleware.RateLimiter
 	if cfg.RateLimit.Storage == "redis" && redisClient != nil {
-		rateLimiter = middleware.NewRedisRateLimiter(redisClient)
+		rateLimiter = middleware.NewRedisStrategyRateLimiter(redisClient)
 	} else {
-		rateLimiter = middleware.NewMemoryRateLimiter()
+		rateLimiter = middleware.NewMemoryStrategyRateLimiter(cfg.RateLimit.MaxEntries)
+		middleware.StartRateLimitJanitor(context.Background(), rateLimiter, cfg.RateLimit.CleanupInterval)
 	}
 
 	// Apply rate limiting middleware globally
-	r.Use(middleware.RateLimitMiddleware(cfg, rateLimiter))
+	r.Use(middleware.RateLimitMiddleware(cfgProvider, rateLimiter, apiKeyService))
+
+	// Idempotency-Key support for unsafe endpoints (responses shared through Redis when available)
+	var idempotencyStore middleware.IdempotencyStore
+	if redisClient != nil {
+		idempotencyStore = middleware.NewRedisIdempotencyStore(redisClient)
+	} else {
+		idempotencyStore = middleware.NewMemoryIdempotencyStore()
+	}
+	idempotent := middleware.IdempotencyMiddleware(cfg.Idempotency, idempotencyStore)
+
+	// Auth routes (issue, refresh and revoke JWTs)
+	authRoutes := r.Group("/auth")
+	{
+		authRoutes.POST("/login", h.Auth.Login)
+		authRoutes.POST("/refresh", h.Auth.Refresh)
+		authRoutes.POST("/logout", h.Auth.Logout)
+	}
 
-	// Public API routes (with basic auth)
-	v1 := r.Group("/api/v1")
-	v1.Use(middleware.AuthMiddleware(cfg))
+	// Public API routes (with an API key, basic auth or JWT bearer token), scoped to one organization. v1 and
+	// v2 share their handlers and differ only in how APIVersionMiddleware serializes the responses.
+	for _, version := range []string{constants.APIVersion1, constants.APIVersion2} {
+		api := r.Group("/api/" + version)
+		api.Use(middleware.AuthMiddleware(cfg, authService, apiKeyService), middleware.OrganizationMiddleware(organizationService))
+		registerAPIRoutes(api, h, idempotent)
+	}
+
+	// GraphQL query endpoint for admin dashboards (same auth as the REST API)
+	graphqlRoutes := r.Group("/graphql")
+	graphqlRoutes.Use(middleware.AuthMiddleware(cfg, authService, apiKeyService), middleware.OrganizationMiddleware(organizationService))
 	{
-		// Topic routes
-		v1.GET("/topics", h.Topic.GetTopics)
-		v1.POST("/topics", h.Topic.CreateTopic)
-		v1.GET("/topics/:id", h.Topic.GetTopicByID)
-		v1.PUT("/topics/:id", h.Topic.UpdateTopic)
-		v1.DELETE("/topics/:id", h.Topic.DeleteTopic)
-
-		// Subscriber routes
-		v1.GET("/subscribers", h.Subscriber.GetSubscribers)
-		v1.POST("/subscribers", h.Subscriber.CreateSubscriber)
-		v1.POST("/subscribers/bulk", h.Subscriber.BulkCreateSubscribers)
-		v1.PUT("/subscribers/bulk", h.Subscriber.BulkUpdateSubscribers)
-		v1.DELETE("/subscribers/bulk", h.Subscriber.BulkDeleteSubscribers)
-		v1.GET("/subscribers/:id", h.Subscriber.GetSubscriberByID)
-		v1.PUT("/subscribers/:id", h.Subscriber.UpdateSubscriber)
-		v1.DELETE("/subscribers/:id", h.Subscriber.DeleteSubscriber)
-
-		// Subscription routes
-		v1.POST("/subscriptions", h.Subscriber.CreateSubscription)
-		v1.GET("/subscriptions", h.Subscriber.GetSubscriptions)
-		v1.GET("/subscriptions/subscriber/:subscriber_id", h.Subscriber.GetSubscriptionsBySubscriber)
-		v1.GET("/subscriptions/topic/:topic_id", h.Subscriber.GetSubscriptionsByTopic)
-		v1.DELETE("/subscriptions/:id", h.Subscriber.DeleteSubscription)
-
-		// Content routes
-		v1.GET("/contents", h.Content.GetContents)
-		v1.POST("/contents", h.Content.CreateContent)
-		v1.GET("/contents/:id", h.Content.GetContentByID)
-		v1.PUT("/contents/:id", h.Content.UpdateContent)
-		v1.DELETE("/contents/:id", h.Content.DeleteContent)
-		v1.POST("/contents/:id/publish", h.Content.PublishContent)
-
-		// Email log routes
-		v1.GET("/email-logs", h.Notification.GetEmailLogs)
-		v1.GET("/email-logs/:id", h.Notification.GetEmailLogByID)
+		graphqlRoutes.POST("", h.GraphQL.Query)
 	}
 
 	// Scheduler API routes (with separate authentication)
@@ -77,21 +94,205 @@ func SetupRoutes(h *handlers.Handler, cfg *config.Config, redisClient *redis.Cli
 	scheduler.Use(middleware.SchedulerAuthMiddleware(cfg))
 	{
 		// Notification endpoints for scheduled tasks
-		scheduler.POST("/notifications/send", h.Notification.SendNotifications)
+		scheduler.POST("/notifications/send", idempotent, h.Notification.SendNotifications)
 		scheduler.GET("/notifications/pending", h.Content.GetPendingNotifications)
-		scheduler.POST("/notifications/retry-failed", h.Notification.RetryFailedNotifications)
+		scheduler.POST("/notifications/retry-failed", idempotent, h.Notification.RetryFailedNotifications)
 
 		// Health check for scheduler
 		scheduler.GET("/health", h.Health.SchedulerHealth)
 	}
 
-	// Health check endpoint (no auth required)
-	r.GET("/health", h.Health.Health)
-
 	// Unsubscribe endpoints (no auth required for user convenience)
 	r.GET("/unsubscribe", h.Unsubscribe.UnsubscribeGet)
 	r.POST("/unsubscribe", h.Unsubscribe.UnsubscribePost)
 	r.POST("/subscribers/:id/resubscribe", h.Unsubscribe.Resubscribe)
 
+	// Preference center where subscribers choose how often they receive each topic (no auth required)
+	r.GET("/preferences", h.Preference.PreferenceCenterGet)
+	r.POST("/preferences", h.Preference.PreferenceCenterPost)
+
+	// Referral signups and the confirmation link emailed to referred readers (no auth required)
+	r.POST("/referrals/redeem", h.Referral.Redeem)
+	r.GET("/referrals/confirm", h.Referral.ConfirmGet)
+
+	// Topic landing pages (public signup pages)
+	r.GET("/topics/:slug", h.Landing.GetLandingPage)
+	r.POST("/topics/:slug", h.Landing.SubscribeFromLandingPage)
+
+	// Stripe webhook events, authenticated by their signature
+	r.POST("/billing/stripe/webhook", h.Billing.StripeWebhook)
+
+	// Poll answer links in sent emails, authenticated by their signed token
+	r.GET("/polls/respond", h.Poll.Respond)
+
+	// Open tracking pixel embedded in sent emails (no auth required)
+	r.GET("/track/open", h.Notification.TrackOpen)
+
+	// Uploaded images linked from content (no auth required, as email clients load them)
+	r.GET("/assets/:key", h.Asset.ServeAsset)
+
 	return r
 }
+
+// registerAPIRoutes registers the public API on one version's route group
+func registerAPIRoutes(api *gin.RouterGroup, h *handlers.Handler, idempotent gin.HandlerFunc) {
+	operatorOnly := middleware.OperatorOnlyMiddleware()
+
+	// Topic routes
+	api.GET("/topics", h.Topic.GetTopics)
+	api.POST("/topics", idempotent, h.Topic.CreateTopic)
+	api.GET("/topics/:id", h.Topic.GetTopicByID)
+	api.GET("/topics/:id/stats", h.Topic.GetTopicStats)
+	api.GET("/topics/:id/theme", h.Topic.GetTopicTheme)
+	api.PUT("/topics/:id/theme", h.Topic.UpdateTopicTheme)
+	api.PUT("/topics/:id", h.Topic.UpdateTopic)
+	api.DELETE("/topics/:id", h.Topic.DeleteTopic)
+	api.POST("/topics/:id/restore", h.Topic.RestoreTopic)
+	api.POST("/topics/:id/archive", h.Topic.ArchiveTopic)
+	api.POST("/topics/:id/unarchive", h.Topic.UnarchiveTopic)
+	api.POST("/topics/:id/migrate-subscribers", idempotent, h.Subscriber.MigrateSubscribers)
+
+	// Subscriber routes
+	api.GET("/subscribers", h.Subscriber.GetSubscribers)
+	api.POST("/subscribers", idempotent, h.Subscriber.CreateSubscriber)
+	api.POST("/subscribers/bulk", idempotent, h.Subscriber.BulkCreateSubscribers)
+	api.PUT("/subscribers/bulk", h.Subscriber.BulkUpdateSubscribers)
+	api.DELETE("/subscribers/bulk", h.Subscriber.BulkDeleteSubscribers)
+	api.GET("/subscribers/engagement", h.Engagement.GetEngagementSegment)
+	api.GET("/subscribers/duplicates", h.Subscriber.GetDuplicateSubscribers)
+	api.POST("/subscribers/merge", idempotent, h.Subscriber.MergeSubscribers)
+	api.GET("/subscribers/:id", h.Subscriber.GetSubscriberByID)
+	api.PUT("/subscribers/:id", h.Subscriber.UpdateSubscriber)
+	api.DELETE("/subscribers/:id", h.Subscriber.DeleteSubscriber)
+	api.POST("/subscribers/:id/restore", h.Subscriber.RestoreSubscriber)
+	api.GET("/subscribers/:id/push-devices", h.Push.GetDevices)
+	api.POST("/subscribers/:id/push-devices", h.Push.RegisterDevice)
+	api.DELETE("/subscribers/:id/push-devices/:device_id", h.Push.DeleteDevice)
+	api.GET("/subscribers/:id/preferences", h.Preference.GetPreferences)
+	api.PUT("/subscribers/:id/preferences", h.Preference.UpdatePreferences)
+
+	// Referral routes
+	api.GET("/referrals/leaderboard", h.Referral.GetLeaderboard)
+
+	// Subscription routes
+	api.POST("/subscriptions", idempotent, h.Subscriber.CreateSubscription)
+	api.GET("/subscriptions", h.Subscriber.GetSubscriptions)
+	api.GET("/subscriptions/subscriber/:subscriber_id", h.Subscriber.GetSubscriptionsBySubscriber)
+	api.GET("/subscriptions/topic/:topic_id", h.Subscriber.GetSubscriptionsByTopic)
+	api.PUT("/subscriptions/:id", h.Subscriber.UpdateSubscription)
+	api.DELETE("/subscriptions/:id", h.Subscriber.DeleteSubscription)
+
+	// Content routes
+	api.GET("/contents", h.Content.GetContents)
+	api.POST("/contents", idempotent, h.Content.CreateContent)
+	api.GET("/contents/:id", h.Content.GetContentByID)
+	api.PUT("/contents/:id", h.Content.UpdateContent)
+	api.DELETE("/contents/:id", h.Content.DeleteContent)
+	api.POST("/contents/:id/restore", h.Content.RestoreContent)
+	api.POST("/contents/:id/publish", idempotent, h.Content.PublishContent)
+	api.POST("/contents/:id/lint", h.Content.LintContent)
+	api.GET("/contents/:id/translations", h.Content.GetContentTranslations)
+	api.PUT("/contents/:id/translations/:locale", h.Content.SetContentTranslation)
+	api.DELETE("/contents/:id/translations/:locale", h.Content.DeleteContentTranslation)
+	api.POST("/contents/:id/submit", h.Approval.SubmitContent)
+	api.POST("/contents/:id/assign", h.Approval.AssignReviewer)
+	api.POST("/contents/:id/approve", h.Approval.ApproveContent)
+	api.POST("/contents/:id/reject", h.Approval.RejectContent)
+	api.POST("/contents/:id/comments", h.Approval.CommentOnContent)
+	api.GET("/contents/:id/approvals", h.Approval.GetApprovalLog)
+	api.GET("/contents/:id/email-logs/summary", h.Notification.GetContentEmailLogSummary)
+	api.POST("/contents/:id/resend-failed", h.Notification.ResendFailedNotifications)
+	api.GET("/contents/:id/delivery", h.Notification.GetContentDelivery)
+	api.POST("/contents/:id/delivery/complete", h.Notification.CompleteContentDelivery)
+	api.GET("/contents/:id/delivery/report", h.Notification.GetContentSendReport)
+
+	// Email log routes
+	api.GET("/email-logs", h.Notification.GetEmailLogs)
+	api.GET("/email-logs/:id", h.Notification.GetEmailLogByID)
+
+	// Email provider routes
+	api.GET("/providers/status", operatorOnly, h.Notification.GetProviderStatus)
+	api.GET("/providers/:name/stats", operatorOnly, h.Notification.GetProviderStats)
+	api.GET("/providers/pauses", operatorOnly, h.Notification.GetProviderPauses)
+	api.POST("/providers/:name/pause", operatorOnly, h.Notification.PauseProvider)
+	api.POST("/providers/:name/resume", operatorOnly, h.Notification.ResumeProvider)
+
+	// Audit log routes
+	api.GET("/audit-logs", operatorOnly, h.Audit.GetAuditLogs)
+	api.GET("/audit-logs/:id", operatorOnly, h.Audit.GetAuditLogByID)
+
+	// API key routes
+	api.GET("/api-keys", h.APIKey.GetAPIKeys)
+	api.POST("/api-keys", idempotent, h.APIKey.CreateAPIKey)
+	api.GET("/api-keys/:id", h.APIKey.GetAPIKeyByID)
+	api.PUT("/api-keys/:id", h.APIKey.UpdateAPIKey)
+	api.DELETE("/api-keys/:id", h.APIKey.RevokeAPIKey)
+
+	// Webhook routes
+	api.GET("/webhooks", operatorOnly, h.Webhook.GetWebhooks)
+	api.POST("/webhooks", operatorOnly, idempotent, h.Webhook.CreateWebhook)
+	api.GET("/webhooks/:id", operatorOnly, h.Webhook.GetWebhookByID)
+	api.PUT("/webhooks/:id", operatorOnly, h.Webhook.UpdateWebhook)
+	api.DELETE("/webhooks/:id", operatorOnly, h.Webhook.DeleteWebhook)
+	api.GET("/webhooks/:id/deliveries", operatorOnly, h.Webhook.GetWebhookDeliveries)
+
+	// Dashboard stats routes
+	api.GET("/stats/overview", h.Stats.GetOverview)
+	api.GET("/stats/timeseries", h.Stats.GetTimeseries)
+	api.GET("/stats/domains", h.Stats.GetDomainStats)
+	api.GET("/stats/signups", h.Stats.GetSignups)
+
+	// Data retention routes
+	api.GET("/retention/preview", operatorOnly, h.Retention.PreviewRetention)
+
+	// Organization routes
+	api.GET("/organizations", operatorOnly, h.Organization.GetOrganizations)
+	api.POST("/organizations", operatorOnly, idempotent, h.Organization.CreateOrganization)
+	api.GET("/organizations/:id", operatorOnly, h.Organization.GetOrganizationByID)
+	api.PUT("/organizations/:id", operatorOnly, h.Organization.UpdateOrganization)
+
+	// Asset routes
+	api.POST("/assets", idempotent, h.Asset.UploadAsset)
+
+	// Snippet routes
+	api.GET("/snippets", h.Snippet.GetSnippets)
+	api.POST("/snippets", idempotent, h.Snippet.CreateSnippet)
+	api.GET("/snippets/:id", h.Snippet.GetSnippetByID)
+	api.PUT("/snippets/:id", h.Snippet.UpdateSnippet)
+	api.DELETE("/snippets/:id", h.Snippet.DeleteSnippet)
+
+	// Billing routes
+	api.GET("/plans", h.Billing.GetPlans)
+	api.POST("/plans", idempotent, h.Billing.CreatePlan)
+	api.GET("/plans/:id", h.Billing.GetPlanByID)
+	api.PUT("/plans/:id", h.Billing.UpdatePlan)
+	api.DELETE("/plans/:id", h.Billing.DeletePlan)
+	api.POST("/plans/:id/checkout", idempotent, h.Billing.CreateCheckout)
+	api.GET("/billing/subscriptions", h.Billing.GetSubscriptions)
+
+	// Sponsor routes
+	api.GET("/sponsors", h.Sponsor.GetSponsors)
+	api.POST("/sponsors", idempotent, h.Sponsor.CreateSponsor)
+	api.GET("/sponsors/:id", h.Sponsor.GetSponsorByID)
+	api.PUT("/sponsors/:id", h.Sponsor.UpdateSponsor)
+	api.DELETE("/sponsors/:id", h.Sponsor.DeleteSponsor)
+	api.POST("/sponsors/:id/slots", idempotent, h.Sponsor.BookSlot)
+	api.GET("/sponsors/:id/report", h.Sponsor.GetReport)
+	api.GET("/sponsor-slots", h.Sponsor.GetSlots)
+	api.GET("/sponsor-slots/:id", h.Sponsor.GetSlotByID)
+	api.DELETE("/sponsor-slots/:id", h.Sponsor.CancelSlot)
+
+	// Poll routes
+	api.POST("/contents/:id/polls", idempotent, h.Poll.CreatePoll)
+	api.GET("/contents/:id/polls", h.Poll.GetContentPolls)
+	api.GET("/polls/:id", h.Poll.GetPollByID)
+	api.PUT("/polls/:id", h.Poll.UpdatePoll)
+	api.DELETE("/polls/:id", h.Poll.DeletePoll)
+	api.GET("/polls/:id/results", h.Poll.GetResults)
+
+	// Email template routes; templates are shared by every organization
+	api.GET("/email-templates", operatorOnly, h.EmailTemplate.GetEmailTemplates)
+	api.POST("/email-templates/reload", operatorOnly, h.EmailTemplate.ReloadEmailTemplates)
+	api.GET("/email-templates/:name", operatorOnly, h.EmailTemplate.GetEmailTemplate)
+	api.PUT("/email-templates/:name", operatorOnly, h.EmailTemplate.UpdateEmailTemplate)
+}
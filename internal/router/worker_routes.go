@@ -0,0 +1,39 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"newsletter-service/internal/config"
+	"newsletter-service/internal/constants"
+	"newsletter-service/internal/handlers"
+	"newsletter-service/internal/logger"
+	"newsletter-service/internal/metrics"
+	"newsletter-service/internal/router/middleware"
+	"newsletter-service/internal/tracing"
+)
+
+// SetupWorkerAdminRoutes builds the worker's admin API, protected by the scheduler credentials
+func SetupWorkerAdminRoutes(h *handlers.WorkerHandler, cfg *config.Config) *gin.Engine {
+	r := gin.New()
+	r.Use(gin.Recovery())
+	r.Use(tracing.GinMiddleware(constants.ServiceNameWorker))
+	r.Use(logger.LoggerMiddleware())
+
+	// The worker's query metrics, for scraping without the scheduler credentials
+	r.GET("/metrics", gin.WrapH(metrics.Handler()))
+
+	admin := r.Group("/worker/v1")
+	admin.Use(middleware.SchedulerAuthMiddleware(cfg))
+	{
+		admin.GET("/jobs", h.GetJobs)
+		admin.POST("/jobs/:name/run", h.TriggerJob)
+		admin.POST("/pause", h.Pause)
+		admin.POST("/resume", h.Resume)
+		admin.POST("/drain", h.Drain)
+		admin.GET("/concurrency", h.GetConcurrency)
+		admin.GET("/timeouts", h.GetTimeouts)
+		admin.POST("/templates/reload", h.ReloadTemplates)
+	}
+
+	return r
+}
@@ -0,0 +1,449 @@
+package schedulers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Worker job names, used as keys under [worker.jobs]
+const (
+	JobPendingNotifications  = "pending_notifications"  // Publish due content and send queued emails
+	JobPriorityNotifications = "priority_notifications" // Send due high priority content between runs of pending_notifications
+	JobWebhookDeliveries     = "webhook_deliveries"     // Retry failed or interrupted webhook deliveries
+	JobEngagement            = "engagement"             // Recalculate engagement scores and apply the sunset policy
+	JobEmailCheck            = "email_check"            // Verify MX records of pending addresses
+	JobRetention             = "retention"              // Anonymize and delete rows past their retention period
+	JobEmailRetries          = "email_retries"          // Retry failed emails under the retry limit
+	JobDigests               = "digests"                // Queue daily and weekly digest emails that are due
+	JobReferrals             = "referrals"              // Email confirmation links to readers who signed up through a referral
+	JobDunning               = "dunning"                // Email subscribers whose subscription payment failed
+	JobEmailLogArchive       = "email_log_archive"      // Move email logs past [email_logs] archive_after to email_logs_archive
+	JobDeliveryProgress      = "delivery_progress"      // Count the recipients of sending content and complete finished deliveries
+)
+
+// Schedule computes when a job runs next
+type Schedule interface {
+	Next(after time.Time) time.Time
+}
+
+// ParseSchedule parses a standard five-field cron expression ("minute hour day-of-month month day-of-week",
+// e.g. "*/5 * * * *" or "0 3 * * MON-FRI"), a descriptor (@yearly, @monthly, @weekly, @daily, @midnight,
+// @hourly) or "@every <duration>". Cron expressions are evaluated in local time.
+func ParseSchedule(spec string) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+
+	if rest, ok := strings.CutPrefix(spec, "@every "); ok {
+		interval, err := time.ParseDuration(strings.TrimSpace(rest))
+		if err != nil {
+			return nil, fmt.Errorf("invalid @every interval %q: %w", rest, err)
+		}
+		if interval <= 0 {
+			return nil, fmt.Errorf("@every interval must be positive, got %s", interval)
+		}
+		return everySchedule{interval: interval}, nil
+	}
+
+	switch spec {
+	case "@yearly", "@annually":
+		spec = "0 0 1 1 *"
+	case "@monthly":
+		spec = "0 0 1 * *"
+	case "@weekly":
+		spec = "0 0 * * 0"
+	case "@daily", "@midnight":
+		spec = "0 0 * * *"
+	case "@hourly":
+		spec = "0 * * * *"
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid cron expression %q: expected 5 fields, got %d", spec, len(fields))
+	}
+
+	var schedule cronSchedule
+	var err error
+	if schedule.minute, err = parseCronField(fields[0], cronMinute); err != nil {
+		return nil, err
+	}
+	if schedule.hour, err = parseCronField(fields[1], cronHour); err != nil {
+		return nil, err
+	}
+	if schedule.dom, err = parseCronField(fields[2], cronDayOfMonth); err != nil {
+		return nil, err
+	}
+	if schedule.month, err = parseCronField(fields[3], cronMonth); err != nil {
+		return nil, err
+	}
+	if schedule.dow, err = parseCronField(fields[4], cronDayOfWeek); err != nil {
+		return nil, err
+	}
+	// Sunday may be written as 7
+	if schedule.dow&(1<<7) != 0 {
+		schedule.dow |= 1
+	}
+	schedule.domAny = fields[2] == "*" || fields[2] == "?"
+	schedule.dowAny = fields[4] == "*" || fields[4] == "?"
+	return schedule, nil
+}
+
+// everySchedule runs at a fixed interval from the previous run
+type everySchedule struct {
+	interval time.Duration
+}
+
+func (s everySchedule) Next(after time.Time) time.Time {
+	return after.Add(s.interval)
+}
+
+// cronSchedule holds one bit per allowed value of each field
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	domAny, dowAny                bool
+}
+
+// Next returns the first matching minute after the given time, or the zero time if none exists within five years
+func (s cronSchedule) Next(after time.Time) time.Time {
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// dayMatches applies the cron rule that a restricted day-of-month and day-of-week match if either does
+func (s cronSchedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domAny || s.dowAny {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+type cronField struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	cronMinute     = cronField{name: "minute", min: 0, max: 59}
+	cronHour       = cronField{name: "hour", min: 0, max: 23}
+	cronDayOfMonth = cronField{name: "day of month", min: 1, max: 31}
+	cronMonth      = cronField{name: "month", min: 1, max: 12, names: map[string]int{
+		"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+		"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+	}}
+	cronDayOfWeek = cronField{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+	}}
+)
+
+// parseCronField parses a comma-separated list of values, ranges and steps ("*", "*/15", "1-5", "MON,WED")
+func parseCronField(expr string, field cronField) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(expr, ",") {
+		rangeExpr, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			if step, err = strconv.Atoi(part[i+1:]); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step in %s field %q", field.name, part)
+			}
+			rangeExpr = part[:i]
+		}
+
+		low, high := field.min, field.max
+		switch {
+		case rangeExpr == "*" || rangeExpr == "?":
+		case strings.Contains(rangeExpr, "-"):
+			bounds := strings.SplitN(rangeExpr, "-", 2)
+			var err error
+			if low, err = field.value(bounds[0]); err != nil {
+				return 0, err
+			}
+			if high, err = field.value(bounds[1]); err != nil {
+				return 0, err
+			}
+		default:
+			value, err := field.value(rangeExpr)
+			if err != nil {
+				return 0, err
+			}
+			low = value
+			if step == 1 {
+				high = value
+			}
+		}
+		if low > high {
+			return 0, fmt.Errorf("invalid range in %s field %q", field.name, part)
+		}
+
+		for v := low; v <= high; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func (f cronField) value(s string) (int, error) {
+	if v, ok := f.names[strings.ToUpper(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < f.min || v > f.max {
+		return 0, fmt.Errorf("invalid %s value %q (allowed %d-%d)", f.name, s, f.min, f.max)
+	}
+	return v, nil
+}
+
+// ErrJobNotFound is returned when triggering a job that isn't scheduled
+var ErrJobNotFound = errors.New("worker job not found")
+
+// DefaultJobTimeout bounds a job run when neither [worker] job_timeout nor the job's own timeout is set
+const DefaultJobTimeout = 30 * time.Minute
+
+// ErrJobTimedOut is returned by a job wrapped with WithTimeout that ran past its timeout
+var ErrJobTimedOut = errors.New("worker job timed out")
+
+// JobFunc runs one pass of a worker job
+type JobFunc func(ctx context.Context) error
+
+// WithTimeout cancels a run of the job once it has taken longer than timeout, so a hung connection can't hold
+// the job, and its lock, forever. The job must stop when its context ends. A timeout of zero or less runs the
+// job without one.
+func WithTimeout(timeout time.Duration, run JobFunc) JobFunc {
+	if timeout <= 0 {
+		return run
+	}
+	return func(ctx context.Context) error {
+		runCtx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		err := run(runCtx)
+		if errors.Is(runCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil {
+			if err == nil {
+				return fmt.Errorf("%w after %s", ErrJobTimedOut, timeout)
+			}
+			return fmt.Errorf("%w after %s: %w", ErrJobTimedOut, timeout, err)
+		}
+		return err
+	}
+}
+
+// JobRun describes one finished run of a job
+type JobRun struct {
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+	Duration   string    `json:"duration"`
+	Triggered  bool      `json:"triggered"` // Started through Trigger rather than the schedule
+	Skipped    bool      `json:"skipped"`   // Another worker held the job lock
+	TimedOut   bool      `json:"timed_out"` // Cancelled for running past its timeout
+	Error      string    `json:"error,omitempty"`
+}
+
+// JobStatus is a snapshot of one job
+type JobStatus struct {
+	Name     string     `json:"name"`
+	Schedule string     `json:"schedule"`
+	Running  bool       `json:"running"`
+	NextRun  *time.Time `json:"next_run,omitempty"`
+	LastRun  *JobRun    `json:"last_run,omitempty"`
+	Timeouts int64      `json:"timeouts"` // Runs cancelled for running past their timeout since the worker started
+}
+
+// Cron runs named jobs on their schedules. Each job runs in its own goroutine and never overlaps itself;
+// a run that takes longer than the gap to the next one skips the runs it missed. While paused, scheduled
+// runs are skipped but triggered runs still happen.
+type Cron struct {
+	jobs   []*cronJob
+	wg     sync.WaitGroup
+	mu     sync.Mutex // guards paused and the run state of every job
+	paused bool
+}
+
+type cronJob struct {
+	name     string
+	spec     string
+	schedule Schedule
+	run      JobFunc
+	trigger  chan struct{}
+	running  bool
+	next     time.Time
+	lastRun  *JobRun
+	timeouts int64
+}
+
+func NewCron() *Cron {
+	return &Cron{}
+}
+
+// AddJob registers run under name with a schedule accepted by ParseSchedule. Jobs must be added before Start.
+func (c *Cron) AddJob(name, spec string, run JobFunc) error {
+	schedule, err := ParseSchedule(spec)
+	if err != nil {
+		return fmt.Errorf("job %s: %w", name, err)
+	}
+	c.jobs = append(c.jobs, &cronJob{name: name, spec: spec, schedule: schedule, run: run, trigger: make(chan struct{}, 1)})
+	return nil
+}
+
+// Start runs every job on its schedule until ctx is cancelled
+func (c *Cron) Start(ctx context.Context) {
+	for _, job := range c.jobs {
+		c.wg.Add(1)
+		go func(job *cronJob) {
+			defer c.wg.Done()
+			c.loop(ctx, job)
+		}(job)
+	}
+}
+
+// Wait blocks until every job has stopped after ctx was cancelled, letting in-flight runs finish
+func (c *Cron) Wait() {
+	c.wg.Wait()
+}
+
+// Pause skips scheduled runs until Resume; runs already in progress finish
+func (c *Cron) Pause() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.paused = true
+}
+
+// Resume lets scheduled runs happen again
+func (c *Cron) Resume() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.paused = false
+}
+
+// Paused reports whether scheduled runs are being skipped
+func (c *Cron) Paused() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.paused
+}
+
+// Trigger runs a job as soon as it is idle. A trigger while one is already waiting is merged into it.
+func (c *Cron) Trigger(name string) error {
+	for _, job := range c.jobs {
+		if job.name == name {
+			select {
+			case job.trigger <- struct{}{}:
+			default:
+			}
+			return nil
+		}
+	}
+	return ErrJobNotFound
+}
+
+// Status returns a snapshot of every job in the order they were added
+func (c *Cron) Status() []JobStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	statuses := make([]JobStatus, len(c.jobs))
+	for i, job := range c.jobs {
+		statuses[i] = JobStatus{Name: job.name, Schedule: job.spec, Running: job.running, Timeouts: job.timeouts}
+		if !job.next.IsZero() {
+			next := job.next
+			statuses[i].NextRun = &next
+		}
+		if job.lastRun != nil {
+			lastRun := *job.lastRun
+			statuses[i].LastRun = &lastRun
+		}
+	}
+	return statuses
+}
+
+func (c *Cron) loop(ctx context.Context, job *cronJob) {
+	for {
+		next := job.schedule.Next(time.Now())
+		c.setNext(job, next)
+
+		// A job whose schedule never fires again still answers triggers
+		var timer *time.Timer
+		var timerC <-chan time.Time
+		if !next.IsZero() {
+			timer = time.NewTimer(time.Until(next))
+			timerC = timer.C
+		}
+
+		stopped, triggered := false, false
+		select {
+		case <-ctx.Done():
+			stopped = true
+		case <-timerC:
+		case <-job.trigger:
+			triggered = true
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		if stopped {
+			return
+		}
+		if !triggered && c.Paused() {
+			continue
+		}
+
+		// A run in progress finishes even if the worker is stopping
+		c.runJob(context.WithoutCancel(ctx), job, triggered)
+	}
+}
+
+func (c *Cron) setNext(job *cronJob, next time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	job.next = next
+}
+
+func (c *Cron) runJob(ctx context.Context, job *cronJob, triggered bool) {
+	c.mu.Lock()
+	job.running = true
+	c.mu.Unlock()
+
+	run := &JobRun{StartedAt: time.Now(), Triggered: triggered}
+	err := job.run(ctx)
+	run.FinishedAt = time.Now()
+	run.Duration = run.FinishedAt.Sub(run.StartedAt).String()
+	if errors.Is(err, ErrJobLocked) {
+		run.Skipped = true
+	} else if err != nil {
+		run.Error = err.Error()
+		run.TimedOut = errors.Is(err, ErrJobTimedOut)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	job.running = false
+	job.lastRun = run
+	if run.TimedOut {
+		job.timeouts++
+	}
+}
@@ -0,0 +1,256 @@
+package schedulers
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// DefaultLockTTL is how long a job or content claim is held when [worker] lock_ttl is unset.
+// Held locks are extended while their work runs, so the TTL only matters when a worker dies.
+const DefaultLockTTL = 5 * time.Minute
+
+// Locker grants locks shared by every worker replica, so a job or content is only processed by one at a time
+type Locker interface {
+	// TryLock takes the lock if it is free and reports whether it did
+	TryLock(ctx context.Context, key string, ttl time.Duration) (bool, error)
+	// Extend renews a lock this worker holds; it reports false if the lock was lost
+	Extend(ctx context.Context, key string, ttl time.Duration) (bool, error)
+	// Unlock releases a lock this worker holds
+	Unlock(ctx context.Context, key string) error
+}
+
+// RedisLocker implements Locker with Redis keys owned by a random per-process token
+type RedisLocker struct {
+	client redis.UniversalClient
+	owner  string
+}
+
+// Only touch the lock when it still holds our token, so a lock that expired and was taken over is left alone
+var (
+	extendLockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0`)
+	unlockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+)
+
+// NewRedisLocker creates a Redis-backed locker
+func NewRedisLocker(client redis.UniversalClient) *RedisLocker {
+	return &RedisLocker{client: client, owner: lockOwner()}
+}
+
+func (l *RedisLocker) TryLock(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	return l.client.SetNX(ctx, "worker:lock:"+key, l.owner, ttl).Result()
+}
+
+func (l *RedisLocker) Extend(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	extended, err := extendLockScript.Run(ctx, l.client, []string{"worker:lock:" + key}, l.owner, ttl.Milliseconds()).Int()
+	return extended == 1, err
+}
+
+func (l *RedisLocker) Unlock(ctx context.Context, key string) error {
+	return unlockScript.Run(ctx, l.client, []string{"worker:lock:" + key}, l.owner).Err()
+}
+
+// MemoryLocker implements Locker within this process. It only keeps replicas apart when there is a single one,
+// so it stands in for Redis when the worker runs without it.
+type MemoryLocker struct {
+	mu      sync.Mutex
+	expires map[string]time.Time
+}
+
+// NewMemoryLocker creates a process-local locker
+func NewMemoryLocker() *MemoryLocker {
+	return &MemoryLocker{expires: make(map[string]time.Time)}
+}
+
+func (l *MemoryLocker) TryLock(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	if expires, ok := l.expires[key]; ok && now.Before(expires) {
+		return false, nil
+	}
+	l.expires[key] = now.Add(ttl)
+	return true, nil
+}
+
+func (l *MemoryLocker) Extend(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	if expires, ok := l.expires[key]; !ok || !now.Before(expires) {
+		return false, nil
+	}
+	l.expires[key] = now.Add(ttl)
+	return true, nil
+}
+
+func (l *MemoryLocker) Unlock(ctx context.Context, key string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.expires, key)
+	return nil
+}
+
+// FallbackLocker takes locks from primary, normally Redis, and from fallback while primary fails, so jobs keep
+// running through a Redis outage instead of failing every tick. Locks taken during the outage are only local to
+// this process; once primary answers again new locks go back to it. Each held lock is extended and released
+// through the locker that granted it.
+type FallbackLocker struct {
+	primary  Locker
+	fallback Locker
+
+	mu        sync.Mutex
+	grantedBy map[string]Locker
+	degraded  bool
+}
+
+// NewFallbackLocker creates a locker that falls back from primary to fallback while primary returns errors
+func NewFallbackLocker(primary, fallback Locker) *FallbackLocker {
+	return &FallbackLocker{primary: primary, fallback: fallback, grantedBy: make(map[string]Locker)}
+}
+
+func (l *FallbackLocker) TryLock(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	locker := l.primary
+	acquired, err := locker.TryLock(ctx, key, ttl)
+	if err != nil && ctx.Err() == nil {
+		l.setDegraded(true, err)
+		locker = l.fallback
+		acquired, err = locker.TryLock(ctx, key, ttl)
+	} else if err == nil {
+		l.setDegraded(false, nil)
+	}
+	if err != nil || !acquired {
+		return false, err
+	}
+
+	l.mu.Lock()
+	l.grantedBy[key] = locker
+	l.mu.Unlock()
+	return true, nil
+}
+
+func (l *FallbackLocker) Extend(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	return l.holder(key, false).Extend(ctx, key, ttl)
+}
+
+func (l *FallbackLocker) Unlock(ctx context.Context, key string) error {
+	return l.holder(key, true).Unlock(ctx, key)
+}
+
+// Degraded reports whether the last lock was taken from the fallback
+func (l *FallbackLocker) Degraded() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.degraded
+}
+
+// holder returns the locker that granted key, forgetting it when release is set
+func (l *FallbackLocker) holder(key string, release bool) Locker {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	locker, ok := l.grantedBy[key]
+	if !ok {
+		return l.primary
+	}
+	if release {
+		delete(l.grantedBy, key)
+	}
+	return locker
+}
+
+// setDegraded logs when locks move to the fallback and back, rather than on every lock
+func (l *FallbackLocker) setDegraded(degraded bool, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.degraded == degraded {
+		return
+	}
+	l.degraded = degraded
+	if degraded {
+		log.Printf("Warning: shared locks unavailable (%v); using process-local locks, so replicas may overlap", err)
+	} else {
+		log.Printf("Shared locks available again")
+	}
+}
+
+// lockOwner identifies this process in lock values, which also helps when inspecting Redis
+func lockOwner() string {
+	hostname, _ := os.Hostname()
+	token := make([]byte, 8)
+	_, _ = rand.Read(token)
+	return fmt.Sprintf("%s:%d:%s", hostname, os.Getpid(), hex.EncodeToString(token))
+}
+
+// ErrJobLocked is returned by a LockedJob run skipped because another worker holds the job
+var ErrJobLocked = errors.New("worker job is running on another worker")
+
+// withLock runs fn while holding key, extending the lock until fn returns. It reports whether fn ran.
+func withLock(ctx context.Context, locker Locker, key string, ttl time.Duration, fn func(ctx context.Context) error) (bool, error) {
+	if ttl <= 0 {
+		ttl = DefaultLockTTL
+	}
+
+	acquired, err := locker.TryLock(ctx, key, ttl)
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire lock %s: %w", key, err)
+	}
+	if !acquired {
+		return false, nil
+	}
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(ttl / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if held, err := locker.Extend(ctx, key, ttl); err != nil || !held {
+					log.Printf("Lost lock %s while running (err: %v)", key, err)
+					return
+				}
+			}
+		}
+	}()
+
+	defer func() {
+		close(done)
+		if err := locker.Unlock(context.WithoutCancel(ctx), key); err != nil {
+			log.Printf("Failed to release lock %s: %v", key, err)
+		}
+	}()
+
+	return true, fn(ctx)
+}
+
+// LockedJob wraps a worker job so only one replica runs it at a time; replicas that find it running skip the run
+func LockedJob(locker Locker, name string, ttl time.Duration, run JobFunc) JobFunc {
+	return func(ctx context.Context) error {
+		acquired, err := withLock(ctx, locker, "job:"+name, ttl, run)
+		if err != nil {
+			return err
+		}
+		if !acquired {
+			return ErrJobLocked
+		}
+		return nil
+	}
+}
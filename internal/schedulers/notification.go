@@ -2,68 +2,248 @@ package schedulers
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
+	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+
+	"newsletter-service/internal/daos"
+	"newsletter-service/internal/events"
 	"newsletter-service/internal/providers"
 	"newsletter-service/internal/services/content"
 	"newsletter-service/internal/services/notification"
+	"newsletter-service/internal/tracing"
 )
 
+// ErrContentLocked is returned by QueueContent while a worker is sending the content
+var ErrContentLocked = errors.New("content is already being sent")
+
+// ErrContentSent is returned by QueueContent for content that has been sent
+var ErrContentSent = errors.New("content has already been sent")
+
+// ErrContentNotPublished is returned by QueueContent for content the worker wouldn't pick up
+var ErrContentNotPublished = errors.New("content is not published")
+
 type NotificationScheduler struct {
 	contentService      content.Service
 	notificationService notification.Service
 	emailProvider       providers.EmailProviderInterface
+	eventBus            *events.Bus
+	locker              Locker // Claims each content so concurrent workers never send it twice
+	lockTTL             time.Duration
 }
 
-func NewNotificationScheduler(contentService content.Service, notificationService notification.Service) *NotificationScheduler {
+func NewNotificationScheduler(contentService content.Service, notificationService notification.Service, eventBus *events.Bus) *NotificationScheduler {
 	return &NotificationScheduler{
 		contentService:      contentService,
 		notificationService: notificationService,
+		eventBus:            eventBus,
 	}
 }
 
-func NewNotificationSchedulerWithProvider(contentService content.Service, notificationService notification.Service, emailProvider providers.EmailProviderInterface) *NotificationScheduler {
+func NewNotificationSchedulerWithProvider(contentService content.Service, notificationService notification.Service, emailProvider providers.EmailProviderInterface, eventBus *events.Bus) *NotificationScheduler {
 	return &NotificationScheduler{
 		contentService:      contentService,
 		notificationService: notificationService,
 		emailProvider:       emailProvider,
+		eventBus:            eventBus,
+	}
+}
+
+// NewNotificationSchedulerWithLocker creates a scheduler that claims each content through locker before sending it
+func NewNotificationSchedulerWithLocker(contentService content.Service, notificationService notification.Service, eventBus *events.Bus, locker Locker, lockTTL time.Duration) *NotificationScheduler {
+	return &NotificationScheduler{
+		contentService:      contentService,
+		notificationService: notificationService,
+		eventBus:            eventBus,
+		locker:              locker,
+		lockTTL:             lockTTL,
 	}
 }
 
 func (s *NotificationScheduler) ProcessPendingNotifications(ctx context.Context) error {
+	ctx, span := tracing.StartSpan(ctx, "scheduler.ProcessPendingNotifications")
+	defer span.End()
+
 	// Get pending content IDs
 	pendingContentIDs, err := s.contentService.GetPendingNotifications(ctx)
 	if err != nil {
+		tracing.RecordError(span, err)
 		return err
 	}
 
 	log.Printf("Found %d pending notifications", len(pendingContentIDs))
+	span.SetAttributes(attribute.Int("notifications.pending", len(pendingContentIDs)))
+
+	return s.processPending(ctx, pendingContentIDs)
+}
+
+// ProcessPriorityNotifications sends only pending high priority content. It runs apart from
+// ProcessPendingNotifications and more often, so an urgent announcement isn't left waiting behind a long digest
+// send; the content lock keeps the two from sending the same content.
+func (s *NotificationScheduler) ProcessPriorityNotifications(ctx context.Context) error {
+	ctx, span := tracing.StartSpan(ctx, "scheduler.ProcessPriorityNotifications")
+	defer span.End()
+
+	pendingContentIDs, err := s.contentService.GetPendingNotificationsByPriority(ctx, daos.ContentPriorityHigh)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return err
+	}
+	span.SetAttributes(attribute.Int("notifications.pending", len(pendingContentIDs)))
+	if len(pendingContentIDs) > 0 {
+		log.Printf("Found %d pending high priority notifications", len(pendingContentIDs))
+	}
+
+	return s.processPending(ctx, pendingContentIDs)
+}
 
-	// Process each content ID
+// processPending sends the pending content in order, claiming each first when the scheduler has a locker
+func (s *NotificationScheduler) processPending(ctx context.Context, pendingContentIDs []uint) error {
 	for _, contentID := range pendingContentIDs {
-		log.Printf("Processing notification for content ID: %d", contentID)
-
-		// Use provider-aware method if provider is available, otherwise use standard method
-		var err error
-		if s.emailProvider != nil {
-			err = s.notificationService.SendNotificationsByContentIDWithProvider(ctx, contentID, s.emailProvider)
-		} else {
-			err = s.notificationService.SendNotificationsByContentID(ctx, contentID)
+		// Content left when the run times out stays pending for the next run
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if s.locker == nil {
+			s.processContent(ctx, contentID)
+			continue
 		}
 
+		claimed, err := withLock(ctx, s.locker, contentLockKey(contentID), s.lockTTL, func(ctx context.Context) error {
+			// Another worker may have sent it between listing pending content and taking the claim
+			contentModel, err := s.contentService.GetContentByID(ctx, contentID)
+			if err != nil || contentModel.NotificationsSent {
+				return nil
+			}
+			s.processContent(ctx, contentID)
+			return nil
+		})
 		if err != nil {
-			log.Printf("Failed to send notification for content %d: %v", contentID, err)
-			continue
+			log.Printf("Failed to claim content %d: %v", contentID, err)
+		} else if !claimed {
+			log.Printf("Content %d is claimed by another worker, skipping", contentID)
 		}
+	}
+
+	return nil
+}
+
+// processContent sends the notifications of one content
+func (s *NotificationScheduler) processContent(ctx context.Context, contentID uint) {
+	log.Printf("Processing notification for content ID: %d", contentID)
+
+	if err := s.sendContent(ctx, contentID); err != nil {
+		log.Printf("Failed to send notification for content %d: %v", contentID, err)
+		return
+	}
+
+	log.Printf("Successfully sent notification for content ID: %d", contentID)
+}
+
+// QueueContent hands one content to the workers on request, such as from the scheduler API: content scheduled
+// for later becomes due now, and the next pending run sends it under the usual claim. Only workers send, so the
+// request can't duplicate a send. It returns ErrContentLocked while another holder of the content's lock, a
+// concurrent request or, with a locker shared with the workers, a worker sending it, has it claimed; it returns
+// ErrContentSent once the content was sent and ErrContentNotPublished for content no worker would pick up.
+func (s *NotificationScheduler) QueueContent(ctx context.Context, contentID uint) error {
+	if s.locker == nil {
+		return s.queueContent(ctx, contentID)
+	}
+
+	claimed, err := withLock(ctx, s.locker, contentLockKey(contentID), s.lockTTL, func(ctx context.Context) error {
+		return s.queueContent(ctx, contentID)
+	})
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		return ErrContentLocked
+	}
+	return nil
+}
 
-		log.Printf("Successfully sent notification for content ID: %d", contentID)
+// queueContent makes one content due now
+func (s *NotificationScheduler) queueContent(ctx context.Context, contentID uint) error {
+	contentModel, err := s.contentService.GetContentByID(ctx, contentID)
+	if err != nil {
+		return err
+	}
+	if contentModel.NotificationsSent {
+		return ErrContentSent
+	}
+	if !contentModel.IsPublished {
+		return ErrContentNotPublished
+	}
+
+	// Local-time content is already pending; its recipients are released as their local time arrives
+	now := time.Now()
+	if contentModel.SendAt == nil || !contentModel.SendAt.After(now) || contentModel.LocalSendTime != "" {
+		return nil
+	}
+	return s.contentService.UpdateContent(ctx, contentID, map[string]interface{}{"send_at": now})
+}
+
+// sendContent sends the notifications of one content and announces it
+func (s *NotificationScheduler) sendContent(ctx context.Context, contentID uint) error {
+	// Use provider-aware method if provider is available, otherwise use standard method
+	var err error
+	if s.emailProvider != nil {
+		err = s.notificationService.SendNotificationsByContentIDWithProvider(ctx, contentID, s.emailProvider)
+	} else {
+		err = s.notificationService.SendNotificationsByContentID(ctx, contentID)
+	}
+	if err != nil {
+		return err
+	}
+
+	s.publishSendCompleted(ctx, contentID)
+	return nil
+}
+
+// contentLockKey names the lock held while a content is being sent
+func contentLockKey(contentID uint) string {
+	return fmt.Sprintf("content:%d", contentID)
+}
+
+// ProcessQueuedEmails sends email logs that were queued individually, such as failed sends requeued for a content
+func (s *NotificationScheduler) ProcessQueuedEmails(ctx context.Context) error {
+	ctx, span := tracing.StartSpan(ctx, "scheduler.ProcessQueuedEmails")
+	defer span.End()
+
+	sentCount, err := s.notificationService.SendQueuedEmails(ctx)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return err
 	}
 
+	if sentCount > 0 {
+		log.Printf("Sent %d queued emails", sentCount)
+	}
 	return nil
 }
 
+// publishSendCompleted announces that a content's notifications have gone out
+func (s *NotificationScheduler) publishSendCompleted(ctx context.Context, contentID uint) {
+	data := map[string]interface{}{
+		"content_id":   contentID,
+		"completed_at": time.Now().UTC(),
+	}
+	if contentModel, err := s.contentService.GetContentByID(ctx, contentID); err == nil {
+		data["topic_id"] = contentModel.TopicID
+		data["title"] = contentModel.Title
+	}
+
+	s.eventBus.Emit(ctx, events.SendCompleted, data)
+}
+
 // RetryFailedNotifications retries sending failed email notifications
 func (s *NotificationScheduler) RetryFailedNotifications(ctx context.Context) error {
+	ctx, span := tracing.StartSpan(ctx, "scheduler.RetryFailedNotifications")
+	defer span.End()
+
 	log.Printf("Starting failed notifications retry process")
 
 	// Use provider-aware method if provider is available, otherwise use standard method
@@ -76,9 +256,28 @@ func (s *NotificationScheduler) RetryFailedNotifications(ctx context.Context) er
 
 	if err != nil {
 		log.Printf("Failed to retry failed notifications: %v", err)
+		tracing.RecordError(span, err)
 		return err
 	}
 
 	log.Printf("Successfully initiated retry for failed notifications")
 	return nil
 }
+
+// RetryFailedNotificationsInBatches retries failed emails through the notification service's providers,
+// loading batchSize at a time (the worker's periodic retry job)
+func (s *NotificationScheduler) RetryFailedNotificationsInBatches(ctx context.Context, batchSize int) error {
+	ctx, span := tracing.StartSpan(ctx, "scheduler.RetryFailedNotificationsInBatches")
+	defer span.End()
+
+	result, err := s.notificationService.RetryFailedEmailsInBatches(ctx, batchSize)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return err
+	}
+
+	if result.Attempted > 0 || result.Skipped > 0 {
+		log.Printf("Retried %d failed emails: %d sent, %d skipped", result.Attempted, result.Sent, result.Skipped)
+	}
+	return nil
+}
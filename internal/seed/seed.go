@@ -0,0 +1,303 @@
+// Package seed fills a database with fake but plausible topics, subscribers, contents and email logs, so
+// development, demos and load tests have representative data without handcrafted fixtures
+package seed
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"newsletter-service/internal/constants"
+	"newsletter-service/internal/daos"
+	"newsletter-service/internal/providers"
+	"newsletter-service/internal/services/subscriber"
+	"newsletter-service/internal/tenant"
+)
+
+// Options sets how much data a run creates
+type Options struct {
+	OrganizationID   uint
+	Topics           int
+	Subscribers      int
+	ContentsPerTopic int
+	EmailLogs        int   // Spread over the sent contents and their topics' subscribers
+	Seed             int64 // Runs with the same seed create the same data, apart from names kept unique per run
+	BatchSize        int   // Rows per INSERT
+}
+
+// DefaultOptions is a small dataset that seeds in a few seconds
+func DefaultOptions() Options {
+	return Options{
+		OrganizationID:   1,
+		Topics:           5,
+		Subscribers:      500,
+		ContentsPerTopic: 10,
+		EmailLogs:        5000,
+		Seed:             time.Now().UnixNano(),
+		BatchSize:        500,
+	}
+}
+
+// Summary counts the rows a run created
+type Summary struct {
+	Topics        int
+	Subscribers   int
+	Subscriptions int
+	Contents      int
+	EmailLogs     int
+}
+
+// Run creates the data in one transaction, so a failed run leaves nothing behind. Email addresses carry a tag
+// unique to the run and topic names get it when taken, so seeding an already seeded database adds to it.
+func Run(ctx context.Context, db *gorm.DB, opts Options) (*Summary, error) {
+	if opts.OrganizationID == 0 {
+		return nil, fmt.Errorf("an organization is required")
+	}
+	if opts.Topics < 0 || opts.Subscribers < 0 || opts.ContentsPerTopic < 0 || opts.EmailLogs < 0 {
+		return nil, fmt.Errorf("volumes can't be negative")
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultOptions().BatchSize
+	}
+
+	g := &generator{
+		rand:   rand.New(rand.NewSource(opts.Seed)),
+		now:    time.Now().UTC(),
+		runTag: strconv.FormatInt(time.Now().Unix(), 36),
+		opts:   opts,
+	}
+	summary := &Summary{}
+	ctx = tenant.WithOrganization(ctx, opts.OrganizationID)
+
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		topics, err := g.topics(tx)
+		if err != nil {
+			return err
+		}
+		if err := tx.CreateInBatches(topics, opts.BatchSize).Error; err != nil {
+			return fmt.Errorf("failed to create topics: %w", err)
+		}
+		summary.Topics = len(topics)
+
+		subscribers := g.subscribers()
+		if err := tx.CreateInBatches(subscribers, opts.BatchSize).Error; err != nil {
+			return fmt.Errorf("failed to create subscribers: %w", err)
+		}
+		summary.Subscribers = len(subscribers)
+
+		subscriptions, audiences := g.subscriptions(topics, subscribers)
+		if err := tx.CreateInBatches(subscriptions, opts.BatchSize).Error; err != nil {
+			return fmt.Errorf("failed to create subscriptions: %w", err)
+		}
+		summary.Subscriptions = len(subscriptions)
+
+		contents := g.contents(topics)
+		if err := tx.CreateInBatches(contents, opts.BatchSize).Error; err != nil {
+			return fmt.Errorf("failed to create contents: %w", err)
+		}
+		summary.Contents = len(contents)
+
+		logs := g.emailLogs(contents, audiences)
+		if err := tx.CreateInBatches(logs, opts.BatchSize).Error; err != nil {
+			return fmt.Errorf("failed to create email logs: %w", err)
+		}
+		summary.EmailLogs = len(logs)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+// generator builds the rows of one run
+type generator struct {
+	rand   *rand.Rand
+	now    time.Time
+	runTag string
+	opts   Options
+}
+
+// topics names topics from the list, numbering repeats, and tags names the organization already uses
+func (g *generator) topics(tx *gorm.DB) ([]*daos.Topic, error) {
+	var existing []string
+	if err := tx.Model(&daos.Topic{}).Unscoped().Pluck("name", &existing).Error; err != nil {
+		return nil, fmt.Errorf("failed to load topic names: %w", err)
+	}
+	taken := make(map[string]bool, len(existing))
+	for _, name := range existing {
+		taken[name] = true
+	}
+
+	topics := make([]*daos.Topic, 0, g.opts.Topics)
+	for i := 0; i < g.opts.Topics; i++ {
+		name := topicNames[i%len(topicNames)]
+		if round := i / len(topicNames); round > 0 {
+			name = fmt.Sprintf("%s %d", name, round+1)
+		}
+		if taken[name] {
+			name = fmt.Sprintf("%s %s", name, g.runTag)
+		}
+		topics = append(topics, &daos.Topic{
+			Name:        name,
+			Description: fmt.Sprintf("News and notes on %s, sent when there's something worth reading.", strings.ToLower(name)),
+			CreatedAt:   g.pastTime(365 * 24 * time.Hour),
+		})
+	}
+	return topics, nil
+}
+
+func (g *generator) subscribers() []*daos.Subscriber {
+	subscribers := make([]*daos.Subscriber, 0, g.opts.Subscribers)
+	for i := 0; i < g.opts.Subscribers; i++ {
+		first, last := pick(g.rand, firstNames), pick(g.rand, lastNames)
+		email := fmt.Sprintf("%s.%s.%d.%s@%s", strings.ToLower(first), strings.ToLower(last), i+1, g.runTag, pick(g.rand, emailDomains))
+		subscribers = append(subscribers, &daos.Subscriber{
+			Name:            first + " " + last,
+			Email:           email,
+			NormalizedEmail: subscriber.NormalizeEmail(email),
+			IsActive:        true,
+			Timezone:        pick(g.rand, timezones),
+			Locale:          pick(g.rand, locales),
+			Channels:        "email",
+			EmailStatus:     "valid",
+			EngagementScore: float64(g.rand.Intn(101)),
+			CreatedAt:       g.pastTime(365 * 24 * time.Hour),
+		})
+	}
+	return subscribers
+}
+
+// subscriptions subscribes each subscriber to one to three topics and returns them with each topic's audience
+func (g *generator) subscriptions(topics []*daos.Topic, subscribers []*daos.Subscriber) ([]*daos.Subscription, map[uint][]*daos.Subscriber) {
+	audiences := make(map[uint][]*daos.Subscriber, len(topics))
+	var subscriptions []*daos.Subscription
+	if len(topics) == 0 {
+		return subscriptions, audiences
+	}
+
+	for _, s := range subscribers {
+		count := 1 + g.rand.Intn(min(3, len(topics)))
+		for _, i := range g.rand.Perm(len(topics))[:count] {
+			topic := topics[i]
+			subscriptions = append(subscriptions, &daos.Subscription{
+				SubscriberID: s.ID,
+				TopicID:      topic.ID,
+				CreatedAt:    s.CreatedAt.Add(time.Duration(g.rand.Intn(72)) * time.Hour),
+			})
+			audiences[topic.ID] = append(audiences[topic.ID], s)
+		}
+	}
+	return subscriptions, audiences
+}
+
+// contents writes issues for each topic; about seven in ten were published and sent during the last 90 days,
+// the rest are drafts
+func (g *generator) contents(topics []*daos.Topic) []*daos.Content {
+	contents := make([]*daos.Content, 0, len(topics)*g.opts.ContentsPerTopic)
+	for _, topic := range topics {
+		for i := 0; i < g.opts.ContentsPerTopic; i++ {
+			title := fmt.Sprintf("%s %s", pick(g.rand, headlineOpeners), pick(g.rand, headlineSubjects))
+			content := &daos.Content{
+				TopicID:       topic.ID,
+				Title:         title,
+				Body:          g.body(title),
+				PreviewText:   pick(g.rand, sentences),
+				Channels:      "email",
+				ApprovalState: daos.ApprovalStateDraft,
+				CreatedAt:     g.pastTime(120 * 24 * time.Hour),
+			}
+			if g.rand.Float64() < 0.7 {
+				publishedAt := g.pastTime(90 * 24 * time.Hour)
+				content.IsPublished = true
+				content.PublishedAt = &publishedAt
+				content.NotificationsSent = true
+				content.NotificationsSentAt = &publishedAt
+				content.DeliveryState = daos.DeliveryStateSending // Counted by the worker from the seeded logs
+				content.ApprovalState = daos.ApprovalStatePublished
+			}
+			contents = append(contents, content)
+		}
+	}
+	return contents
+}
+
+// emailLogs records sends of the sent contents to their topics' subscribers: mostly delivered, some opened,
+// a few failed
+func (g *generator) emailLogs(contents []*daos.Content, audiences map[uint][]*daos.Subscriber) []*daos.EmailLog {
+	var sent []*daos.Content
+	for _, c := range contents {
+		if c.NotificationsSent && len(audiences[c.TopicID]) > 0 {
+			sent = append(sent, c)
+		}
+	}
+	logs := make([]*daos.EmailLog, 0, g.opts.EmailLogs)
+	if len(sent) == 0 {
+		return logs
+	}
+
+	for i := 0; i < g.opts.EmailLogs; i++ {
+		c := pick(g.rand, sent)
+		s := pick(g.rand, audiences[c.TopicID])
+		at := c.PublishedAt.Add(time.Duration(g.rand.Intn(3600)) * time.Second)
+		log := &daos.EmailLog{
+			SubscriberID:      s.ID,
+			ContentID:         c.ID,
+			EmailAddress:      s.Email,
+			Subject:           c.Title,
+			Body:              c.Body,
+			PreviewText:       c.PreviewText,
+			Status:            constants.StatusSent,
+			SentAt:            &at,
+			Provider:          providers.LocalProviderName,
+			ProviderMessageID: fmt.Sprintf("seed-%s-%d", g.runTag, i+1),
+			Channel:           "email",
+			CreatedAt:         at,
+		}
+		switch roll := g.rand.Float64(); {
+		case roll < 0.05:
+			message := "550 mailbox unavailable"
+			log.Status = constants.StatusFailed
+			log.SentAt = nil
+			log.ErrorMessage = &message
+			log.RetryCount = 1 + g.rand.Intn(constants.MaxEmailRetryCount)
+		case roll < 0.5:
+			openedAt := at.Add(time.Duration(1+g.rand.Intn(48*60)) * time.Minute)
+			if openedAt.Before(g.now) {
+				log.OpenedAt = &openedAt
+			}
+		}
+		logs = append(logs, log)
+	}
+	return logs
+}
+
+// body writes a short Markdown issue
+func (g *generator) body(title string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", title)
+	for p := 0; p < 2+g.rand.Intn(3); p++ {
+		for s := 0; s < 2+g.rand.Intn(3); s++ {
+			if s > 0 {
+				b.WriteString(" ")
+			}
+			b.WriteString(pick(g.rand, sentences))
+		}
+		b.WriteString("\n\n")
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// pastTime returns a time up to maxAge ago
+func (g *generator) pastTime(maxAge time.Duration) time.Time {
+	return g.now.Add(-time.Duration(g.rand.Int63n(int64(maxAge))))
+}
+
+func pick[T any](r *rand.Rand, items []T) T {
+	return items[r.Intn(len(items))]
+}
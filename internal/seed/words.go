@@ -0,0 +1,51 @@
+package seed
+
+// Word lists the fake data is drawn from
+
+var firstNames = []string{
+	"Ada", "Alan", "Amara", "Ben", "Carla", "Chen", "Dario", "Elif", "Emma", "Farah", "Felix", "Grace", "Hana",
+	"Ines", "Ivan", "Jonas", "Kenji", "Lena", "Liam", "Maya", "Mateo", "Nadia", "Noah", "Olga", "Omar", "Priya",
+	"Rafael", "Sara", "Sofia", "Tariq", "Uma", "Victor", "Wen", "Yara", "Zoe",
+}
+
+var lastNames = []string{
+	"Adeyemi", "Berg", "Costa", "Dubois", "Eriksen", "Fischer", "Garcia", "Haddad", "Ivanova", "Jensen", "Kim",
+	"Lopez", "Moreau", "Nakamura", "Okafor", "Patel", "Quinn", "Rossi", "Schmidt", "Tanaka", "Usman", "Virtanen",
+	"Weber", "Xu", "Yilmaz", "Zhang",
+}
+
+var emailDomains = []string{"example.com", "example.org", "example.net", "mail.example", "inbox.test"}
+
+// topicNames are the names given to seeded topics, in order; larger volumes repeat them with a number
+var topicNames = []string{
+	"Technology", "Science", "Design", "Startups", "Climate", "Health", "Finance", "Travel", "Food", "Books",
+	"Music", "Film", "Sports", "Gaming", "Security", "Open Source", "Careers", "Education", "Space", "History",
+}
+
+var headlineOpeners = []string{
+	"What's new in", "A week in", "The state of", "Five things about", "Inside", "Rethinking", "A field guide to",
+	"Notes on", "The future of", "Behind the scenes of",
+}
+
+var headlineSubjects = []string{
+	"remote teams", "small datasets", "city gardens", "quiet tools", "long reads", "open standards",
+	"local news", "battery research", "indie studios", "public transport", "home labs", "ocean data",
+}
+
+var sentences = []string{
+	"Here is what caught our attention this week.",
+	"We spoke to people doing the work and asked what surprised them.",
+	"The numbers tell part of the story; the rest is in the details below.",
+	"A few links worth your time, with a short note on each.",
+	"If you only read one thing today, make it this.",
+	"Reply to this email and tell us what you'd like covered next.",
+	"Some of this is early, so treat the conclusions as a starting point.",
+	"Thanks for reading, and see you in the next issue.",
+}
+
+var timezones = []string{
+	"", "UTC", "Europe/Berlin", "Europe/London", "America/New_York", "America/Los_Angeles", "America/Sao_Paulo",
+	"Asia/Tokyo", "Asia/Kolkata", "Australia/Sydney",
+}
+
+var locales = []string{"", "en", "en", "de", "es", "fr", "it", "pt-BR"}
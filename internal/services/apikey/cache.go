@@ -0,0 +1,101 @@
+package apikey
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+type redisCache struct {
+	client redis.UniversalClient
+}
+
+type memoryCacheEntry struct {
+	override  *RateLimitOverride
+	expiresAt time.Time
+}
+
+type memoryCache struct {
+	entries map[string]memoryCacheEntry
+	mu      sync.RWMutex
+}
+
+// NewRedisCache creates a Redis-backed override cache shared by all instances
+func NewRedisCache(client redis.UniversalClient) Cache {
+	return &redisCache{client: client}
+}
+
+// NewMemoryCache creates an in-memory override cache (single instance only)
+func NewMemoryCache() Cache {
+	return &memoryCache{entries: make(map[string]memoryCacheEntry)}
+}
+
+func (r *redisCache) Get(ctx context.Context, hash string) (*RateLimitOverride, bool, error) {
+	data, err := r.client.Get(ctx, overrideKey(hash)).Result()
+	if err == redis.Nil {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	var override *RateLimitOverride
+	if err := json.Unmarshal([]byte(data), &override); err != nil {
+		return nil, false, err
+	}
+	return override, true, nil
+}
+
+func (r *redisCache) Set(ctx context.Context, hash string, override *RateLimitOverride, ttl time.Duration) error {
+	data, err := json.Marshal(override)
+	if err != nil {
+		return err
+	}
+	return r.client.Set(ctx, overrideKey(hash), data, ttl).Err()
+}
+
+func (r *redisCache) Delete(ctx context.Context, hash string) error {
+	return r.client.Del(ctx, overrideKey(hash)).Err()
+}
+
+func (m *memoryCache) Get(ctx context.Context, hash string) (*RateLimitOverride, bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	entry, exists := m.entries[hash]
+	if !exists || time.Now().After(entry.expiresAt) {
+		return nil, false, nil
+	}
+	return entry.override, true, nil
+}
+
+func (m *memoryCache) Set(ctx context.Context, hash string, override *RateLimitOverride, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// Drop expired entries so lookups of unknown keys don't grow the map forever
+	now := time.Now()
+	for key, entry := range m.entries {
+		if now.After(entry.expiresAt) {
+			delete(m.entries, key)
+		}
+	}
+
+	m.entries[hash] = memoryCacheEntry{override: override, expiresAt: now.Add(ttl)}
+	return nil
+}
+
+func (m *memoryCache) Delete(ctx context.Context, hash string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.entries, hash)
+	return nil
+}
+
+func overrideKey(hash string) string {
+	return fmt.Sprintf("apikey:rate_limit:%s", hash)
+}
@@ -0,0 +1,12 @@
+package apikey
+
+// Core contains shared business logic for API key domain
+type Core struct {
+	service Service
+}
+
+func NewCore(service Service) *Core {
+	return &Core{
+		service: service,
+	}
+}
@@ -0,0 +1,31 @@
+package apikey
+
+import (
+	"context"
+	"time"
+)
+
+type Repository interface {
+	Create(ctx context.Context, key *APIKey) error
+	GetByID(ctx context.Context, id uint) (*APIKey, error)
+	GetByHash(ctx context.Context, hash string) (*APIKey, error)
+	GetAllWithPagination(ctx context.Context, offset, limit int) ([]*APIKey, int64, error)
+	Update(ctx context.Context, id uint, updates map[string]interface{}) error
+}
+
+// Cache stores rate limit overrides by key hash. A cached nil override means the key has none.
+type Cache interface {
+	Get(ctx context.Context, hash string) (*RateLimitOverride, bool, error)
+	Set(ctx context.Context, hash string, override *RateLimitOverride, ttl time.Duration) error
+	Delete(ctx context.Context, hash string) error
+}
+
+type Service interface {
+	CreateAPIKey(ctx context.Context, key *APIKey) (string, error)
+	GetAPIKeyByID(ctx context.Context, id uint) (*APIKey, error)
+	GetAPIKeysWithPagination(ctx context.Context, offset, limit int) ([]*APIKey, int64, error)
+	UpdateAPIKey(ctx context.Context, id uint, updates map[string]interface{}) error
+	RevokeAPIKey(ctx context.Context, id uint) error
+	GetRateLimitOverride(ctx context.Context, rawKey string) (*RateLimitOverride, error)
+	Authenticate(ctx context.Context, rawKey string) (*APIKey, error)
+}
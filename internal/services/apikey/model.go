@@ -0,0 +1,46 @@
+package apikey
+
+import (
+	"errors"
+	"time"
+
+	"newsletter-service/internal/daos"
+)
+
+// Type alias for backward compatibility
+type APIKey = daos.APIKey
+
+// KeyPrefix marks generated keys so they are recognisable in logs and secret scanners
+const KeyPrefix = "nsk_"
+
+// ErrInvalidKey is returned when authenticating with a key that is unknown or revoked
+var ErrInvalidKey = errors.New("invalid or revoked API key")
+
+// RateLimitOverride is a per-key token bucket that replaces the route rule
+type RateLimitOverride struct {
+	APIKeyID       uint          `json:"api_key_id"`
+	BucketSize     int           `json:"bucket_size"`
+	RefillSize     int           `json:"refill_size"`
+	RefillDuration time.Duration `json:"refill_duration"`
+}
+
+// overrideFromKey returns the key's rate limit override, or nil if it has none
+func overrideFromKey(key *APIKey) *RateLimitOverride {
+	if key.RateLimitBucketSize == nil {
+		return nil
+	}
+
+	override := &RateLimitOverride{
+		APIKeyID:       key.ID,
+		BucketSize:     *key.RateLimitBucketSize,
+		RefillSize:     *key.RateLimitBucketSize,
+		RefillDuration: time.Minute,
+	}
+	if key.RateLimitRefillSize != nil {
+		override.RefillSize = *key.RateLimitRefillSize
+	}
+	if key.RateLimitRefillSeconds != nil {
+		override.RefillDuration = time.Duration(*key.RateLimitRefillSeconds) * time.Second
+	}
+	return override
+}
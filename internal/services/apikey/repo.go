@@ -0,0 +1,53 @@
+package apikey
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) Create(ctx context.Context, key *APIKey) error {
+	return r.db.WithContext(ctx).Create(key).Error
+}
+
+func (r *repository) GetByID(ctx context.Context, id uint) (*APIKey, error) {
+	var key APIKey
+	err := r.db.WithContext(ctx).First(&key, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *repository) GetByHash(ctx context.Context, hash string) (*APIKey, error) {
+	var key APIKey
+	err := r.db.WithContext(ctx).Where("key_hash = ?", hash).First(&key).Error
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *repository) GetAllWithPagination(ctx context.Context, offset, limit int) ([]*APIKey, int64, error) {
+	var keys []*APIKey
+	var total int64
+
+	if err := r.db.WithContext(ctx).Model(&APIKey{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := r.db.WithContext(ctx).Order("id DESC").Offset(offset).Limit(limit).Find(&keys).Error
+	return keys, total, err
+}
+
+func (r *repository) Update(ctx context.Context, id uint, updates map[string]interface{}) error {
+	return r.db.WithContext(ctx).Model(&APIKey{}).Where("id = ?", id).Updates(updates).Error
+}
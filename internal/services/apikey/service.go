@@ -0,0 +1,131 @@
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// overrideCacheTTL bounds how long a stale override can be served by other instances
+const overrideCacheTTL = 5 * time.Minute
+
+type service struct {
+	repo  Repository
+	cache Cache
+}
+
+func NewService(repo Repository, cache Cache) Service {
+	return &service{repo: repo, cache: cache}
+}
+
+// CreateAPIKey generates a new key, stores its hash and returns the plaintext key.
+// The plaintext is not recoverable afterwards.
+func (s *service) CreateAPIKey(ctx context.Context, key *APIKey) (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("failed to generate API key: %w", err)
+	}
+	rawKey := KeyPrefix + hex.EncodeToString(secret)
+
+	key.KeyHash = HashKey(rawKey)
+	key.KeyPrefix = rawKey[:len(KeyPrefix)+8]
+	if err := s.repo.Create(ctx, key); err != nil {
+		return "", err
+	}
+	return rawKey, nil
+}
+
+func (s *service) GetAPIKeyByID(ctx context.Context, id uint) (*APIKey, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *service) GetAPIKeysWithPagination(ctx context.Context, offset, limit int) ([]*APIKey, int64, error) {
+	return s.repo.GetAllWithPagination(ctx, offset, limit)
+}
+
+func (s *service) UpdateAPIKey(ctx context.Context, id uint, updates map[string]interface{}) error {
+	key, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.Update(ctx, id, updates); err != nil {
+		return err
+	}
+	s.invalidate(ctx, key.KeyHash)
+	return nil
+}
+
+func (s *service) RevokeAPIKey(ctx context.Context, id uint) error {
+	key, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.Update(ctx, id, map[string]interface{}{"revoked_at": time.Now()}); err != nil {
+		return err
+	}
+	s.invalidate(ctx, key.KeyHash)
+	return nil
+}
+
+// GetRateLimitOverride returns the override for rawKey, or nil when the key is unknown,
+// revoked or has no custom limits. Results (including misses) are cached.
+func (s *service) GetRateLimitOverride(ctx context.Context, rawKey string) (*RateLimitOverride, error) {
+	hash := HashKey(rawKey)
+
+	if override, found, err := s.cache.Get(ctx, hash); err != nil {
+		log.Printf("Warning: API key override cache lookup failed: %v", err)
+	} else if found {
+		return override, nil
+	}
+
+	var override *RateLimitOverride
+	key, err := s.repo.GetByHash(ctx, hash)
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		// Unknown key, cache the miss below
+	case err != nil:
+		return nil, err
+	case key.RevokedAt == nil:
+		override = overrideFromKey(key)
+	}
+
+	if err := s.cache.Set(ctx, hash, override, overrideCacheTTL); err != nil {
+		log.Printf("Warning: failed to cache API key override: %v", err)
+	}
+	return override, nil
+}
+
+// Authenticate returns the live key matching rawKey, or ErrInvalidKey. The lookup is not scoped to an
+// organization, since the key is what decides the request's organization.
+func (s *service) Authenticate(ctx context.Context, rawKey string) (*APIKey, error) {
+	key, err := s.repo.GetByHash(ctx, HashKey(rawKey))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrInvalidKey
+	}
+	if err != nil {
+		return nil, err
+	}
+	if key.RevokedAt != nil {
+		return nil, ErrInvalidKey
+	}
+	return key, nil
+}
+
+func (s *service) invalidate(ctx context.Context, hash string) {
+	if err := s.cache.Delete(ctx, hash); err != nil {
+		log.Printf("Warning: failed to invalidate API key override cache: %v", err)
+	}
+}
+
+// HashKey returns the hex SHA-256 of a plaintext API key
+func HashKey(rawKey string) string {
+	sum := sha256.Sum256([]byte(rawKey))
+	return hex.EncodeToString(sum[:])
+}
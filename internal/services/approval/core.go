@@ -0,0 +1,12 @@
+package approval
+
+// Core contains shared business logic for approval domain
+type Core struct {
+	service Service
+}
+
+func NewCore(service Service) *Core {
+	return &Core{
+		service: service,
+	}
+}
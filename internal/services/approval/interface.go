@@ -0,0 +1,23 @@
+package approval
+
+import "context"
+
+type Repository interface {
+	GetContent(ctx context.Context, contentID uint) (*Content, error)
+	Transition(ctx context.Context, contentID uint, from []string, updates map[string]interface{}, event *Event) (bool, error)
+	CreateEvent(ctx context.Context, event *Event) error
+	GetEvents(ctx context.Context, contentID uint) ([]*Event, error)
+}
+
+type Service interface {
+	Enabled() bool
+	Submit(ctx context.Context, contentID uint, actor, reviewer, comment string) (*Content, error)
+	Assign(ctx context.Context, contentID uint, actor, reviewer string) (*Content, error)
+	Approve(ctx context.Context, contentID uint, actor, comment string) (*Content, error)
+	Reject(ctx context.Context, contentID uint, actor, comment string) (*Content, error)
+	Comment(ctx context.Context, contentID uint, actor, comment string) (*Event, error)
+	GetLog(ctx context.Context, contentID uint) ([]*Event, error)
+	CheckPublish(ctx context.Context, contentID uint, actor string) error
+	MarkPublished(ctx context.Context, contentID uint, actor string) error
+	Reopen(ctx context.Context, contentID uint, actor string) error
+}
@@ -0,0 +1,28 @@
+package approval
+
+import (
+	"errors"
+
+	"newsletter-service/internal/daos"
+)
+
+// Type alias for backward compatibility
+type Content = daos.Content
+type Event = daos.ApprovalEvent
+
+var (
+	// ErrInvalidTransition is returned when the content's current state doesn't allow the change
+	ErrInvalidTransition = errors.New("content is not in a state that allows this")
+	// ErrNotReviewer is returned when someone who isn't a reviewer approves or rejects content
+	ErrNotReviewer = errors.New("only reviewers can approve or reject content")
+	// ErrNotAssignedReviewer is returned when content assigned to one reviewer is decided by another
+	ErrNotAssignedReviewer = errors.New("content is assigned to another reviewer")
+	// ErrUnknownReviewer is returned when content is assigned to someone who isn't a reviewer
+	ErrUnknownReviewer = errors.New("the assigned reviewer is not a reviewer")
+	// ErrNotPublisher is returned when someone who isn't a publisher publishes content
+	ErrNotPublisher = errors.New("only publishers can publish content")
+	// ErrNotApproved is returned when unapproved content is published while the workflow is enabled
+	ErrNotApproved = errors.New("content must be approved before it is published")
+	// ErrCommentRequired is returned for a rejection or comment without text
+	ErrCommentRequired = errors.New("a comment is required")
+)
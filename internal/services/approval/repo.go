@@ -0,0 +1,53 @@
+package approval
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) GetContent(ctx context.Context, contentID uint) (*Content, error) {
+	var content Content
+	err := r.db.WithContext(ctx).First(&content, contentID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &content, nil
+}
+
+// Transition applies updates to the content and logs event, provided the content is still in one of the from
+// states. It reports false without changing anything when it isn't, so concurrent decisions can't both win.
+func (r *repository) Transition(ctx context.Context, contentID uint, from []string, updates map[string]interface{}, event *Event) (bool, error) {
+	applied := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&Content{}).Where("id = ? AND approval_state IN ?", contentID, from).Updates(updates)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		applied = true
+		return tx.Create(event).Error
+	})
+	return applied, err
+}
+
+func (r *repository) CreateEvent(ctx context.Context, event *Event) error {
+	return r.db.WithContext(ctx).Create(event).Error
+}
+
+// GetEvents returns the content's approval log, oldest first
+func (r *repository) GetEvents(ctx context.Context, contentID uint) ([]*Event, error) {
+	var events []*Event
+	err := r.db.WithContext(ctx).Where("content_id = ?", contentID).Order("created_at, id").Find(&events).Error
+	return events, err
+}
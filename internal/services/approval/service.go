@@ -0,0 +1,233 @@
+package approval
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"newsletter-service/internal/config"
+	"newsletter-service/internal/daos"
+)
+
+type service struct {
+	repo Repository
+	cfg  config.ApprovalConfig
+}
+
+// NewService creates the approval workflow. Reviewers and publishers are matched against the actor names the
+// handlers pass in; an empty list lets anyone take that role.
+func NewService(repo Repository, cfg config.ApprovalConfig) Service {
+	return &service{repo: repo, cfg: cfg}
+}
+
+// Enabled reports whether content must be approved before it is published
+func (s *service) Enabled() bool {
+	return s.cfg.Enabled
+}
+
+// Submit asks for a review of draft content, assigning it to reviewer when one is named
+func (s *service) Submit(ctx context.Context, contentID uint, actor, reviewer, comment string) (*Content, error) {
+	content, err := s.repo.GetContent(ctx, contentID)
+	if err != nil {
+		return nil, err
+	}
+	reviewer = strings.TrimSpace(reviewer)
+	if reviewer != "" && !hasRole(s.cfg.Reviewers, reviewer) {
+		return nil, ErrUnknownReviewer
+	}
+
+	updates := map[string]interface{}{"approval_state": daos.ApprovalStateInReview}
+	if reviewer != "" {
+		updates["reviewer"] = reviewer
+	}
+	return s.transition(ctx, content, []string{daos.ApprovalStateDraft}, updates, &Event{
+		Action:   daos.ApprovalActionSubmit,
+		Actor:    actor,
+		Reviewer: reviewer,
+		Comment:  strings.TrimSpace(comment),
+	})
+}
+
+// Assign hands draft or submitted content to another reviewer without changing its state
+func (s *service) Assign(ctx context.Context, contentID uint, actor, reviewer string) (*Content, error) {
+	content, err := s.repo.GetContent(ctx, contentID)
+	if err != nil {
+		return nil, err
+	}
+	reviewer = strings.TrimSpace(reviewer)
+	if reviewer == "" || !hasRole(s.cfg.Reviewers, reviewer) {
+		return nil, ErrUnknownReviewer
+	}
+
+	from := []string{daos.ApprovalStateDraft, daos.ApprovalStateInReview}
+	return s.transition(ctx, content, from, map[string]interface{}{"reviewer": reviewer}, &Event{
+		Action:   daos.ApprovalActionAssign,
+		Actor:    actor,
+		Reviewer: reviewer,
+	})
+}
+
+// Approve accepts submitted content so it can be published
+func (s *service) Approve(ctx context.Context, contentID uint, actor, comment string) (*Content, error) {
+	content, err := s.decidable(ctx, contentID, actor)
+	if err != nil {
+		return nil, err
+	}
+	updates := map[string]interface{}{"approval_state": daos.ApprovalStateApproved}
+	return s.transition(ctx, content, []string{daos.ApprovalStateInReview}, updates, &Event{
+		Action:  daos.ApprovalActionApprove,
+		Actor:   actor,
+		Comment: strings.TrimSpace(comment),
+	})
+}
+
+// Reject sends submitted content back to draft. The comment tells the author what to change.
+func (s *service) Reject(ctx context.Context, contentID uint, actor, comment string) (*Content, error) {
+	comment = strings.TrimSpace(comment)
+	if comment == "" {
+		return nil, ErrCommentRequired
+	}
+	content, err := s.decidable(ctx, contentID, actor)
+	if err != nil {
+		return nil, err
+	}
+	updates := map[string]interface{}{"approval_state": daos.ApprovalStateDraft}
+	return s.transition(ctx, content, []string{daos.ApprovalStateInReview}, updates, &Event{
+		Action:  daos.ApprovalActionReject,
+		Actor:   actor,
+		Comment: comment,
+	})
+}
+
+// Comment adds a note to the content's approval log in any state
+func (s *service) Comment(ctx context.Context, contentID uint, actor, comment string) (*Event, error) {
+	comment = strings.TrimSpace(comment)
+	if comment == "" {
+		return nil, ErrCommentRequired
+	}
+	if _, err := s.repo.GetContent(ctx, contentID); err != nil {
+		return nil, err
+	}
+
+	event := &Event{
+		ContentID: contentID,
+		Action:    daos.ApprovalActionComment,
+		Actor:     actor,
+		Comment:   comment,
+	}
+	if err := s.repo.CreateEvent(ctx, event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// GetLog returns the content's submissions, decisions and comments, oldest first
+func (s *service) GetLog(ctx context.Context, contentID uint) ([]*Event, error) {
+	if _, err := s.repo.GetContent(ctx, contentID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetEvents(ctx, contentID)
+}
+
+// CheckPublish returns an error when actor may not publish the content. Publishing is unrestricted while the
+// workflow is disabled; content that is already published may be published again.
+func (s *service) CheckPublish(ctx context.Context, contentID uint, actor string) error {
+	if !s.cfg.Enabled {
+		return nil
+	}
+	content, err := s.repo.GetContent(ctx, contentID)
+	if err != nil {
+		return err
+	}
+	if content.ApprovalState != daos.ApprovalStateApproved && content.ApprovalState != daos.ApprovalStatePublished {
+		return ErrNotApproved
+	}
+	if !hasRole(s.cfg.Publishers, actor) {
+		return ErrNotPublisher
+	}
+	return nil
+}
+
+// MarkPublished records that the content was published. It runs whether or not the workflow is enabled so
+// states stay accurate if it is turned on later.
+func (s *service) MarkPublished(ctx context.Context, contentID uint, actor string) error {
+	content, err := s.repo.GetContent(ctx, contentID)
+	if err != nil {
+		return err
+	}
+	from := []string{daos.ApprovalStateDraft, daos.ApprovalStateInReview, daos.ApprovalStateApproved}
+	updates := map[string]interface{}{"approval_state": daos.ApprovalStatePublished}
+	_, err = s.transition(ctx, content, from, updates, &Event{Action: daos.ApprovalActionPublish, Actor: actor})
+	if errors.Is(err, ErrInvalidTransition) {
+		// Published again
+		return nil
+	}
+	return err
+}
+
+// Reopen returns edited content to draft when it was submitted or approved, so the changes are reviewed
+// too. Drafts and published content are left as they are.
+func (s *service) Reopen(ctx context.Context, contentID uint, actor string) error {
+	content, err := s.repo.GetContent(ctx, contentID)
+	if err != nil {
+		return err
+	}
+	if content.ApprovalState != daos.ApprovalStateInReview && content.ApprovalState != daos.ApprovalStateApproved {
+		return nil
+	}
+	from := []string{daos.ApprovalStateInReview, daos.ApprovalStateApproved}
+	updates := map[string]interface{}{"approval_state": daos.ApprovalStateDraft}
+	_, err = s.transition(ctx, content, from, updates, &Event{Action: daos.ApprovalActionReopen, Actor: actor})
+	if errors.Is(err, ErrInvalidTransition) {
+		// Changed state in the meantime
+		return nil
+	}
+	return err
+}
+
+// decidable loads submitted content that actor may approve or reject: a reviewer, and the assigned one if the
+// content has been assigned
+func (s *service) decidable(ctx context.Context, contentID uint, actor string) (*Content, error) {
+	content, err := s.repo.GetContent(ctx, contentID)
+	if err != nil {
+		return nil, err
+	}
+	if !hasRole(s.cfg.Reviewers, actor) {
+		return nil, ErrNotReviewer
+	}
+	if content.Reviewer != "" && content.Reviewer != actor {
+		return nil, ErrNotAssignedReviewer
+	}
+	return content, nil
+}
+
+// transition moves content from one of the from states as updates describe and logs event. The content is
+// returned as updated, or ErrInvalidTransition when it wasn't in any of the from states.
+func (s *service) transition(ctx context.Context, content *Content, from []string, updates map[string]interface{}, event *Event) (*Content, error) {
+	event.ContentID = content.ID
+	event.FromState = content.ApprovalState
+	event.ToState = content.ApprovalState
+	if state, ok := updates["approval_state"].(string); ok {
+		event.ToState = state
+	}
+
+	applied, err := s.repo.Transition(ctx, content.ID, from, updates, event)
+	if err != nil {
+		return nil, err
+	}
+	if !applied {
+		return nil, ErrInvalidTransition
+	}
+
+	content.ApprovalState = event.ToState
+	if reviewer, ok := updates["reviewer"].(string); ok {
+		content.Reviewer = reviewer
+	}
+	return content, nil
+}
+
+// hasRole reports whether actor is in members; an empty list admits everyone
+func hasRole(members []string, actor string) bool {
+	return len(members) == 0 || slices.Contains(members, actor)
+}
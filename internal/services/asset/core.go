@@ -0,0 +1,12 @@
+package asset
+
+// Core contains shared business logic for asset domain
+type Core struct {
+	service Service
+}
+
+func NewCore(service Service) *Core {
+	return &Core{
+		service: service,
+	}
+}
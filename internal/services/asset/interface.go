@@ -0,0 +1,25 @@
+package asset
+
+import (
+	"context"
+	"io"
+)
+
+type Repository interface {
+	Create(ctx context.Context, asset *Asset) error
+	GetByKey(ctx context.Context, key string) (*Asset, error)
+}
+
+// Store holds the bytes of uploaded assets
+type Store interface {
+	Put(ctx context.Context, key, contentType string, data []byte) error
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+type Service interface {
+	Upload(ctx context.Context, filename string, r io.Reader) (*Asset, error)
+	Open(ctx context.Context, key string) (*Asset, io.ReadCloser, error)
+	URL(key string) string
+	MaxSize() int64
+}
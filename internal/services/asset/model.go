@@ -0,0 +1,31 @@
+package asset
+
+import (
+	"errors"
+
+	"newsletter-service/internal/daos"
+)
+
+// Type alias for backward compatibility
+type Asset = daos.Asset
+
+// Storage backends
+const (
+	StorageLocal = "local"
+	StorageS3    = "s3"
+)
+
+// allowedContentTypes maps the image types accepted for upload to the extension their keys get. The type is
+// sniffed from the file itself, never taken from the upload's name or headers. SVG is left out since it can
+// carry script.
+var allowedContentTypes = map[string]string{
+	"image/png":  ".png",
+	"image/jpeg": ".jpg",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+var (
+	ErrTooLarge        = errors.New("asset exceeds the maximum size")
+	ErrUnsupportedType = errors.New("unsupported asset type")
+)
@@ -0,0 +1,30 @@
+package asset
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) Create(ctx context.Context, asset *Asset) error {
+	return r.db.WithContext(ctx).Create(asset).Error
+}
+
+// GetByKey finds an asset by the key it is served under. Assets are served without credentials, so outside a
+// scoped request this looks across every organization.
+func (r *repository) GetByKey(ctx context.Context, key string) (*Asset, error) {
+	var asset Asset
+	err := r.db.WithContext(ctx).Where("key = ?", key).First(&asset).Error
+	if err != nil {
+		return nil, err
+	}
+	return &asset, nil
+}
@@ -0,0 +1,135 @@
+package asset
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+
+	"newsletter-service/internal/config"
+)
+
+// s3Store keeps assets as objects in an S3 bucket, using SigV4-signed REST requests
+type s3Store struct {
+	cfg    config.S3AssetsConfig
+	client *http.Client
+	signer *v4.Signer
+
+	// AWS credentials are loaded on first use, as they may come from the instance role
+	awsOnce sync.Once
+	awsCfg  aws.Config
+	awsErr  error
+}
+
+// NewS3Store creates a store writing to the configured bucket
+func NewS3Store(cfg config.S3AssetsConfig) (Store, error) {
+	if cfg.Bucket == "" || cfg.Region == "" {
+		return nil, fmt.Errorf("S3 asset storage needs a bucket and region")
+	}
+	return &s3Store{
+		cfg:    cfg,
+		client: &http.Client{Timeout: 60 * time.Second},
+		signer: v4.NewSigner(),
+	}, nil
+}
+
+func (s *s3Store) Put(ctx context.Context, key, contentType string, data []byte) error {
+	resp, err := s.do(ctx, http.MethodPut, key, data, contentType)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return s3Error(resp)
+}
+
+func (s *s3Store) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	resp, err := s.do(ctx, http.MethodGet, key, nil, "")
+	if err != nil {
+		return nil, err
+	}
+	if err := s3Error(resp); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+func (s *s3Store) Delete(ctx context.Context, key string) error {
+	resp, err := s.do(ctx, http.MethodDelete, key, nil, "")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return s3Error(resp)
+}
+
+// objectURL addresses the object virtual-hosted style on AWS, and path-style on a custom endpoint
+func (s *s3Store) objectURL(key string) string {
+	if s.cfg.Endpoint != "" {
+		return fmt.Sprintf("%s/%s/%s%s", strings.TrimRight(s.cfg.Endpoint, "/"), s.cfg.Bucket, s.cfg.Prefix, key)
+	}
+	return fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s%s", s.cfg.Bucket, s.cfg.Region, s.cfg.Prefix, key)
+}
+
+func (s *s3Store) do(ctx context.Context, method, key string, data []byte, contentType string) (*http.Response, error) {
+	s.awsOnce.Do(func() {
+		s.awsCfg, s.awsErr = awsconfig.LoadDefaultConfig(context.WithoutCancel(ctx), awsconfig.WithRegion(s.cfg.Region))
+	})
+	if s.awsErr != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", s.awsErr)
+	}
+	credentials, err := s.awsCfg.Credentials.Retrieve(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get AWS credentials: %w", err)
+	}
+
+	var body io.Reader
+	if data != nil {
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, s.objectURL(key), body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create S3 request: %w", err)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	payloadHash := sha256.Sum256(data)
+	hash := hex.EncodeToString(payloadHash[:])
+	req.Header.Set("X-Amz-Content-Sha256", hash)
+	if err := s.signer.SignHTTP(ctx, credentials, req, hash, "s3", s.cfg.Region, time.Now()); err != nil {
+		return nil, fmt.Errorf("failed to sign S3 request: %w", err)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send S3 request: %w", err)
+	}
+	return resp, nil
+}
+
+// s3Error returns nil for a successful response, or the start of S3's error document. A missing object's
+// error wraps fs.ErrNotExist, as the local store's does.
+func s3Error(resp *http.Response) error {
+	if resp.StatusCode < 300 {
+		return nil
+	}
+	detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	err := fmt.Errorf("S3 returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(detail)))
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: %w", fs.ErrNotExist, err)
+	}
+	return err
+}
@@ -0,0 +1,98 @@
+package asset
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"newsletter-service/internal/config"
+)
+
+// defaultMaxSize is the upload limit when none is configured
+const defaultMaxSize = 5 << 20
+
+// maxFilenameLength matches the filename column
+const maxFilenameLength = 255
+
+type service struct {
+	repo  Repository
+	store Store
+	cfg   config.AssetsConfig
+}
+
+// NewService creates an asset service, filling in defaults for unset config values
+func NewService(repo Repository, store Store, cfg config.AssetsConfig) Service {
+	if cfg.MaxSize <= 0 {
+		cfg.MaxSize = defaultMaxSize
+	}
+	return &service{repo: repo, store: store, cfg: cfg}
+}
+
+// Upload checks that r holds an accepted image no larger than the maximum size, then stores it under a new
+// random key. filename is kept for reference only.
+func (s *service) Upload(ctx context.Context, filename string, r io.Reader) (*Asset, error) {
+	data, err := io.ReadAll(io.LimitReader(r, s.cfg.MaxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read upload: %w", err)
+	}
+	if int64(len(data)) > s.cfg.MaxSize {
+		return nil, ErrTooLarge
+	}
+
+	contentType := http.DetectContentType(data)
+	ext, ok := allowedContentTypes[contentType]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedType, contentType)
+	}
+
+	filename = filepath.Base(strings.ReplaceAll(filename, `\`, "/"))
+	if len(filename) > maxFilenameLength {
+		filename = filename[:maxFilenameLength]
+	}
+	asset := &Asset{
+		Key:         uuid.NewString() + ext,
+		Filename:    strings.ToValidUTF8(filename, ""),
+		ContentType: contentType,
+		Size:        int64(len(data)),
+	}
+
+	if err := s.store.Put(ctx, asset.Key, contentType, data); err != nil {
+		return nil, fmt.Errorf("failed to store asset: %w", err)
+	}
+	if err := s.repo.Create(ctx, asset); err != nil {
+		if deleteErr := s.store.Delete(ctx, asset.Key); deleteErr != nil {
+			log.Printf("Warning: failed to delete stored asset %s after failing to save it: %v", asset.Key, deleteErr)
+		}
+		return nil, err
+	}
+	return asset, nil
+}
+
+// Open returns the asset stored under key and its contents, which the caller must close
+func (s *service) Open(ctx context.Context, key string) (*Asset, io.ReadCloser, error) {
+	asset, err := s.repo.GetByKey(ctx, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	body, err := s.store.Open(ctx, key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open asset %s: %w", key, err)
+	}
+	return asset, body, nil
+}
+
+// URL returns the public URL of an asset, or its path when no public URL is configured
+func (s *service) URL(key string) string {
+	return strings.TrimRight(s.cfg.PublicURL, "/") + "/assets/" + key
+}
+
+// MaxSize returns the largest accepted upload in bytes
+func (s *service) MaxSize() int64 {
+	return s.cfg.MaxSize
+}
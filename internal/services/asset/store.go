@@ -0,0 +1,76 @@
+package asset
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"newsletter-service/internal/config"
+)
+
+// defaultDir is where local storage keeps uploads when no directory is configured
+const defaultDir = "data/assets"
+
+// NewStore creates the store configured by cfg.Storage
+func NewStore(cfg config.AssetsConfig) (Store, error) {
+	switch cfg.Storage {
+	case "", StorageLocal:
+		return NewLocalStore(cfg.Dir)
+	case StorageS3:
+		return NewS3Store(cfg.S3)
+	default:
+		return nil, fmt.Errorf("unknown asset storage %q", cfg.Storage)
+	}
+}
+
+// localStore keeps assets as files in one directory, named by their key
+type localStore struct {
+	dir string
+}
+
+// NewLocalStore creates a store writing to dir, creating it if needed
+func NewLocalStore(dir string) (Store, error) {
+	if dir == "" {
+		dir = defaultDir
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create asset directory: %w", err)
+	}
+	return &localStore{dir: dir}, nil
+}
+
+// Put writes to a temporary file first, so a failed write never leaves a partial asset under its key
+func (s *localStore) Put(ctx context.Context, key, contentType string, data []byte) error {
+	tmp, err := os.CreateTemp(s.dir, ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path(key))
+}
+
+func (s *localStore) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	return os.Open(s.path(key))
+}
+
+func (s *localStore) Delete(ctx context.Context, key string) error {
+	if err := os.Remove(s.path(key)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// path keeps keys inside the directory; keys are generated, but this is the one place a bad one could escape
+func (s *localStore) path(key string) string {
+	return filepath.Join(s.dir, filepath.Base(key))
+}
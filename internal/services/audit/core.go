@@ -0,0 +1,12 @@
+package audit
+
+// Core contains shared business logic for audit domain
+type Core struct {
+	service Service
+}
+
+func NewCore(service Service) *Core {
+	return &Core{
+		service: service,
+	}
+}
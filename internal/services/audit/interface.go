@@ -0,0 +1,15 @@
+package audit
+
+import "context"
+
+type Repository interface {
+	Create(ctx context.Context, log *AuditLog) error
+	GetByID(ctx context.Context, id uint) (*AuditLog, error)
+	GetWithPagination(ctx context.Context, filter Filter, offset, limit int) ([]*AuditLog, int64, error)
+}
+
+type Service interface {
+	Record(ctx context.Context, entry Entry) error
+	GetAuditLogByID(ctx context.Context, id uint) (*AuditLog, error)
+	GetAuditLogsWithPagination(ctx context.Context, filter Filter, offset, limit int) ([]*AuditLog, int64, error)
+}
@@ -0,0 +1,76 @@
+package audit
+
+import (
+	"time"
+
+	"newsletter-service/internal/daos"
+)
+
+// Type alias for backward compatibility
+type AuditLog = daos.AuditLog
+
+// Audited actions
+const (
+	ActionCreate   = "create"
+	ActionUpdate   = "update"
+	ActionDelete   = "delete"
+	ActionPublish  = "publish"
+	ActionRevoke   = "revoke"
+	ActionRestore  = "restore"
+	ActionSubmit   = "submit"
+	ActionApprove  = "approve"
+	ActionReject   = "reject"
+	ActionArchive  = "archive"
+	ActionMigrate  = "migrate"
+	ActionPause    = "pause"
+	ActionResume   = "resume"
+	ActionComplete = "complete"
+)
+
+// Audited entity types
+const (
+	EntityTopic         = "topic"
+	EntitySubscriber    = "subscriber"
+	EntitySubscription  = "subscription"
+	EntityContent       = "content"
+	EntityAPIKey        = "api_key"
+	EntityWebhook       = "webhook"
+	EntityOrganization  = "organization"
+	EntityAsset         = "asset"
+	EntitySnippet       = "snippet"
+	EntityEmailTemplate = "email_template"
+	EntityPlan          = "plan"
+	EntitySponsor       = "sponsor"
+	EntitySponsorSlot   = "sponsor_slot"
+	EntityPoll          = "poll"
+	EntityProvider      = "provider" // Identified by the ID of its pause
+)
+
+// Entry describes a single mutating operation to be recorded
+type Entry struct {
+	Actor      string
+	ActorType  string
+	Action     string
+	EntityType string
+	EntityID   uint
+	Before     interface{}
+	After      interface{}
+	IPAddress  string
+	RequestID  string
+}
+
+// Filter narrows down audit log queries
+type Filter struct {
+	Actor      string
+	Action     string
+	EntityType string
+	EntityID   uint
+	From       *time.Time
+	To         *time.Time
+}
+
+// FieldChange represents a single changed field in an audit diff
+type FieldChange struct {
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
@@ -0,0 +1,66 @@
+package audit
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) Create(ctx context.Context, log *AuditLog) error {
+	return r.db.WithContext(ctx).Create(log).Error
+}
+
+func (r *repository) GetByID(ctx context.Context, id uint) (*AuditLog, error) {
+	var log AuditLog
+	err := r.db.WithContext(ctx).First(&log, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &log, nil
+}
+
+func (r *repository) GetWithPagination(ctx context.Context, filter Filter, offset, limit int) ([]*AuditLog, int64, error) {
+	var logs []*AuditLog
+	var total int64
+
+	query := applyFilter(r.db.WithContext(ctx).Model(&AuditLog{}), filter)
+
+	// Get total count
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Get paginated results
+	err := query.Order("created_at desc").Offset(offset).Limit(limit).Find(&logs).Error
+	return logs, total, err
+}
+
+func applyFilter(query *gorm.DB, filter Filter) *gorm.DB {
+	if filter.Actor != "" {
+		query = query.Where("actor = ?", filter.Actor)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.EntityType != "" {
+		query = query.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityID > 0 {
+		query = query.Where("entity_id = ?", filter.EntityID)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at <= ?", *filter.To)
+	}
+	return query
+}
@@ -0,0 +1,105 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+type service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) Service {
+	return &service{repo: repo}
+}
+
+// Record stores an audit entry with serialized before/after state and a field-level diff
+func (s *service) Record(ctx context.Context, entry Entry) error {
+	beforeMap, beforeJSON, err := toStateJSON(entry.Before)
+	if err != nil {
+		return fmt.Errorf("failed to serialize before state: %w", err)
+	}
+
+	afterMap, afterJSON, err := toStateJSON(entry.After)
+	if err != nil {
+		return fmt.Errorf("failed to serialize after state: %w", err)
+	}
+
+	var changesJSON *string
+	if changes := diffStates(beforeMap, afterMap); len(changes) > 0 {
+		data, err := json.Marshal(changes)
+		if err != nil {
+			return fmt.Errorf("failed to serialize changes: %w", err)
+		}
+		encoded := string(data)
+		changesJSON = &encoded
+	}
+
+	log := &AuditLog{
+		Actor:       entry.Actor,
+		ActorType:   entry.ActorType,
+		Action:      entry.Action,
+		EntityType:  entry.EntityType,
+		EntityID:    entry.EntityID,
+		BeforeState: beforeJSON,
+		AfterState:  afterJSON,
+		Changes:     changesJSON,
+		IPAddress:   entry.IPAddress,
+		RequestID:   entry.RequestID,
+	}
+
+	return s.repo.Create(ctx, log)
+}
+
+func (s *service) GetAuditLogByID(ctx context.Context, id uint) (*AuditLog, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *service) GetAuditLogsWithPagination(ctx context.Context, filter Filter, offset, limit int) ([]*AuditLog, int64, error) {
+	return s.repo.GetWithPagination(ctx, filter, offset, limit)
+}
+
+// toStateJSON normalizes an entity snapshot into a generic map and its JSON encoding
+func toStateJSON(state interface{}) (map[string]interface{}, *string, error) {
+	if state == nil || (reflect.ValueOf(state).Kind() == reflect.Ptr && reflect.ValueOf(state).IsNil()) {
+		return nil, nil, nil
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var stateMap map[string]interface{}
+	if err := json.Unmarshal(data, &stateMap); err != nil {
+		return nil, nil, err
+	}
+
+	encoded := string(data)
+	return stateMap, &encoded, nil
+}
+
+// diffStates returns the fields whose values differ between two snapshots
+func diffStates(before, after map[string]interface{}) map[string]FieldChange {
+	changes := make(map[string]FieldChange)
+
+	for key, afterVal := range after {
+		beforeVal, exists := before[key]
+		if !exists || !reflect.DeepEqual(beforeVal, afterVal) {
+			changes[key] = FieldChange{From: beforeVal, To: afterVal}
+		}
+	}
+
+	for key, beforeVal := range before {
+		if _, exists := after[key]; !exists {
+			changes[key] = FieldChange{From: beforeVal, To: nil}
+		}
+	}
+
+	// Timestamps always move on update and add noise to the diff
+	delete(changes, "updated_at")
+
+	return changes
+}
@@ -0,0 +1,12 @@
+package auth
+
+// Core contains shared business logic for auth domain
+type Core struct {
+	service Service
+}
+
+func NewCore(service Service) *Core {
+	return &Core{
+		service: service,
+	}
+}
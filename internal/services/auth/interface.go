@@ -0,0 +1,19 @@
+package auth
+
+import (
+	"context"
+	"time"
+)
+
+// Repository stores revoked token IDs until they would have expired anyway
+type Repository interface {
+	Revoke(ctx context.Context, tokenID string, expiresAt time.Time) error
+	IsRevoked(ctx context.Context, tokenID string) (bool, error)
+}
+
+type Service interface {
+	Login(ctx context.Context, username, password string) (*TokenPair, error)
+	Refresh(ctx context.Context, refreshToken string) (*TokenPair, error)
+	Logout(ctx context.Context, accessToken, refreshToken string) error
+	ValidateAccessToken(ctx context.Context, accessToken string) (*Claims, error)
+}
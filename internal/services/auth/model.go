@@ -0,0 +1,27 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Token types carried in the "typ" claim
+const (
+	TokenTypeAccess  = "access"
+	TokenTypeRefresh = "refresh"
+)
+
+// Claims represents the JWT claims issued by the auth service
+type Claims struct {
+	TokenType string `json:"typ"`
+	jwt.RegisteredClaims
+}
+
+// TokenPair represents an issued access/refresh token pair
+type TokenPair struct {
+	AccessToken      string
+	RefreshToken     string
+	AccessExpiresAt  time.Time
+	RefreshExpiresAt time.Time
+}
@@ -0,0 +1,74 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+type redisRepository struct {
+	client redis.UniversalClient
+}
+
+type memoryRepository struct {
+	revoked map[string]time.Time
+	mu      sync.RWMutex
+}
+
+// NewRepository creates a Redis-backed revocation store
+func NewRepository(client redis.UniversalClient) Repository {
+	return &redisRepository{client: client}
+}
+
+// NewMemoryRepository creates an in-memory revocation store (single instance only)
+func NewMemoryRepository() Repository {
+	return &memoryRepository{revoked: make(map[string]time.Time)}
+}
+
+func (r *redisRepository) Revoke(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		// Token already expired, nothing to revoke
+		return nil
+	}
+	return r.client.Set(ctx, revokedKey(tokenID), 1, ttl).Err()
+}
+
+func (r *redisRepository) IsRevoked(ctx context.Context, tokenID string) (bool, error) {
+	count, err := r.client.Exists(ctx, revokedKey(tokenID)).Result()
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (m *memoryRepository) Revoke(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// Drop entries whose tokens have expired on their own
+	now := time.Now()
+	for id, exp := range m.revoked {
+		if now.After(exp) {
+			delete(m.revoked, id)
+		}
+	}
+
+	m.revoked[tokenID] = expiresAt
+	return nil
+}
+
+func (m *memoryRepository) IsRevoked(ctx context.Context, tokenID string) (bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	exp, exists := m.revoked[tokenID]
+	return exists && time.Now().Before(exp), nil
+}
+
+func revokedKey(tokenID string) string {
+	return fmt.Sprintf("auth:revoked:%s", tokenID)
+}
@@ -0,0 +1,196 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"newsletter-service/internal/config"
+)
+
+var (
+	ErrInvalidCredentials = errors.New("invalid username or password")
+	ErrInvalidToken       = errors.New("invalid or expired token")
+	ErrTokenRevoked       = errors.New("token has been revoked")
+)
+
+const (
+	defaultAccessTokenTTL  = 15 * time.Minute
+	defaultRefreshTokenTTL = 7 * 24 * time.Hour
+)
+
+type service struct {
+	repo   Repository
+	config *config.AuthConfig
+}
+
+func NewService(repo Repository, cfg *config.AuthConfig) Service {
+	return &service{
+		repo:   repo,
+		config: cfg,
+	}
+}
+
+// Login verifies the configured admin credentials and issues a token pair
+func (s *service) Login(ctx context.Context, username, password string) (*TokenPair, error) {
+	usernameMatch := subtle.ConstantTimeCompare([]byte(username), []byte(s.config.Username)) == 1
+	passwordMatch := subtle.ConstantTimeCompare([]byte(password), []byte(s.config.Password)) == 1
+	if !usernameMatch || !passwordMatch {
+		return nil, ErrInvalidCredentials
+	}
+
+	return s.issueTokenPair(username)
+}
+
+// Refresh exchanges a valid refresh token for a new pair, revoking the old refresh token
+func (s *service) Refresh(ctx context.Context, refreshToken string) (*TokenPair, error) {
+	claims, err := s.parseToken(ctx, refreshToken, TokenTypeRefresh)
+	if err != nil {
+		return nil, err
+	}
+
+	// Rotate refresh tokens so a leaked one can only be used once
+	if err := s.repo.Revoke(ctx, claims.ID, claims.ExpiresAt.Time); err != nil {
+		return nil, fmt.Errorf("failed to revoke refresh token: %w", err)
+	}
+
+	return s.issueTokenPair(claims.Subject)
+}
+
+// Logout revokes the given access token and, if provided, the refresh token
+func (s *service) Logout(ctx context.Context, accessToken, refreshToken string) error {
+	claims, err := s.parseToken(ctx, accessToken, TokenTypeAccess)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.Revoke(ctx, claims.ID, claims.ExpiresAt.Time); err != nil {
+		return fmt.Errorf("failed to revoke access token: %w", err)
+	}
+
+	if refreshToken == "" {
+		return nil
+	}
+
+	refreshClaims, err := s.parseToken(ctx, refreshToken, TokenTypeRefresh)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.Revoke(ctx, refreshClaims.ID, refreshClaims.ExpiresAt.Time); err != nil {
+		return fmt.Errorf("failed to revoke refresh token: %w", err)
+	}
+
+	return nil
+}
+
+// ValidateAccessToken parses and verifies an access token, including revocation
+func (s *service) ValidateAccessToken(ctx context.Context, accessToken string) (*Claims, error) {
+	return s.parseToken(ctx, accessToken, TokenTypeAccess)
+}
+
+func (s *service) issueTokenPair(subject string) (*TokenPair, error) {
+	now := time.Now()
+	accessExpiresAt := now.Add(s.accessTokenTTL())
+	refreshExpiresAt := now.Add(s.refreshTokenTTL())
+
+	accessToken, err := s.signToken(subject, TokenTypeAccess, now, accessExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := s.signToken(subject, TokenTypeRefresh, now, refreshExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TokenPair{
+		AccessToken:      accessToken,
+		RefreshToken:     refreshToken,
+		AccessExpiresAt:  accessExpiresAt,
+		RefreshExpiresAt: refreshExpiresAt,
+	}, nil
+}
+
+func (s *service) signToken(subject, tokenType string, issuedAt, expiresAt time.Time) (string, error) {
+	tokenID, err := generateTokenID()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate token ID: %w", err)
+	}
+
+	claims := Claims{
+		TokenType: tokenType,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        tokenID,
+			Subject:   subject,
+			Issuer:    s.config.JWTIssuer,
+			IssuedAt:  jwt.NewNumericDate(issuedAt),
+			NotBefore: jwt.NewNumericDate(issuedAt),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	signed, err := token.SignedString([]byte(s.config.JWTSecret))
+	if err != nil {
+		return "", fmt.Errorf("failed to sign token: %w", err)
+	}
+
+	return signed, nil
+}
+
+func (s *service) parseToken(ctx context.Context, tokenString, expectedType string) (*Claims, error) {
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithExpirationRequired(),
+	}
+	if s.config.JWTIssuer != "" {
+		options = append(options, jwt.WithIssuer(s.config.JWTIssuer))
+	}
+
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		return []byte(s.config.JWTSecret), nil
+	}, options...)
+	if err != nil || claims.TokenType != expectedType || claims.ID == "" {
+		return nil, ErrInvalidToken
+	}
+
+	revoked, err := s.repo.IsRevoked(ctx, claims.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check token revocation: %w", err)
+	}
+	if revoked {
+		return nil, ErrTokenRevoked
+	}
+
+	return claims, nil
+}
+
+func (s *service) accessTokenTTL() time.Duration {
+	if s.config.AccessTokenTTL > 0 {
+		return s.config.AccessTokenTTL
+	}
+	return defaultAccessTokenTTL
+}
+
+func (s *service) refreshTokenTTL() time.Duration {
+	if s.config.RefreshTokenTTL > 0 {
+		return s.config.RefreshTokenTTL
+	}
+	return defaultRefreshTokenTTL
+}
+
+func generateTokenID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
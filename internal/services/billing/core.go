@@ -0,0 +1,12 @@
+package billing
+
+// Core contains shared business logic for billing domain
+type Core struct {
+	service Service
+}
+
+func NewCore(service Service) *Core {
+	return &Core{
+		service: service,
+	}
+}
@@ -0,0 +1,47 @@
+package billing
+
+import (
+	"context"
+	"time"
+
+	"newsletter-service/internal/providers"
+)
+
+type Repository interface {
+	CreatePlan(ctx context.Context, plan *Plan) error
+	GetPlanByID(ctx context.Context, id uint) (*Plan, error)
+	GetPlanByIDUnscoped(ctx context.Context, id uint) (*Plan, error)
+	GetPlansWithPagination(ctx context.Context, topicID uint, offset, limit int) ([]*Plan, int64, error)
+	PriceTaken(ctx context.Context, stripePriceID string, excludeID uint) (bool, error)
+	UpdatePlan(ctx context.Context, id uint, updates map[string]interface{}) error
+	DeletePlan(ctx context.Context, id uint) error
+	GetSubscriptionByStripeID(ctx context.Context, stripeSubscriptionID string) (*Subscription, error)
+	GetSubscriptionsWithPagination(ctx context.Context, filter SubscriptionFilter, offset, limit int) ([]*Subscription, int64, error)
+	HasEntitlement(ctx context.Context, subscriberID, topicID uint) (bool, error)
+	GetCustomerID(ctx context.Context, subscriberID uint) (string, error)
+	CreateSubscription(ctx context.Context, subscription *Subscription) (bool, error)
+	UpdateSubscription(ctx context.Context, stripeSubscriptionID string, updates map[string]interface{}) (bool, error)
+	MarkPaymentFailed(ctx context.Context, stripeSubscriptionID string, failedAt time.Time) error
+	ClearPaymentFailure(ctx context.Context, stripeSubscriptionID string) error
+	GetDueForDunning(ctx context.Context, step int, failedBefore time.Time, limit int) ([]*Subscription, error)
+	ClaimDunningStep(ctx context.Context, id uint, step int, sentAt time.Time) (bool, error)
+	ReleaseDunningStep(ctx context.Context, id uint, step int) error
+}
+
+// Mailer sends one email straight away. The worker's notification service sends dunning emails through its
+// providers.
+type Mailer interface {
+	SendTransactionalEmail(ctx context.Context, email providers.EmailNotification) (string, error)
+}
+
+type Service interface {
+	CreatePlan(ctx context.Context, plan *Plan) error
+	GetPlanByID(ctx context.Context, id uint) (*Plan, error)
+	GetPlansWithPagination(ctx context.Context, topicID uint, offset, limit int) ([]*Plan, int64, error)
+	UpdatePlan(ctx context.Context, id uint, updates map[string]interface{}) error
+	DeletePlan(ctx context.Context, id uint) error
+	CreateCheckout(ctx context.Context, planID, subscriberID uint) (*Checkout, error)
+	HandleWebhook(ctx context.Context, payload []byte, signature string) error
+	GetSubscriptionsWithPagination(ctx context.Context, filter SubscriptionFilter, offset, limit int) ([]*Subscription, int64, error)
+	SendDunningEmails(ctx context.Context) (int, error)
+}
@@ -0,0 +1,54 @@
+package billing
+
+import (
+	"errors"
+
+	"newsletter-service/internal/daos"
+)
+
+// Type aliases for backward compatibility
+type Plan = daos.Plan
+type Subscription = daos.BillingSubscription
+type Subscriber = daos.Subscriber
+
+var (
+	// ErrDisabled is returned for checkouts and webhook events while [billing] is not enabled
+	ErrDisabled = errors.New("billing is not enabled")
+	// ErrPlanInactive is returned for checkouts of a plan that is no longer sold
+	ErrPlanInactive = errors.New("plan is not active")
+	// ErrAlreadyEntitled is returned for checkouts by a subscriber already paying for a plan of the topic
+	ErrAlreadyEntitled = errors.New("subscriber already pays for this topic")
+	// ErrDuplicatePrice is returned when another plan, of any organization, already sells the Stripe price
+	ErrDuplicatePrice = errors.New("another plan already uses this Stripe price")
+	// ErrInvalidSignature is returned for webhook requests Stripe didn't sign or signed too long ago
+	ErrInvalidSignature = errors.New("invalid Stripe signature")
+	// ErrInvalidEvent is returned for signed webhook requests whose event can't be decoded
+	ErrInvalidEvent = errors.New("invalid Stripe event")
+	// ErrTopicNotFound is returned for plans of a topic the organization doesn't have
+	ErrTopicNotFound = errors.New("topic not found")
+	// ErrSubscriberNotFound is returned for checkouts by a subscriber the organization doesn't have
+	ErrSubscriberNotFound = errors.New("subscriber not found")
+)
+
+// Checkout is a Stripe Checkout session a subscriber pays for a plan through
+type Checkout struct {
+	SessionID string
+	URL       string // Stripe's hosted payment page
+}
+
+// SubscriptionFilter narrows a subscription listing; zero fields match everything
+type SubscriptionFilter struct {
+	SubscriberID uint
+	TopicID      uint
+	Status       string
+}
+
+// Entitled reports whether a subscription in the status receives its topic's premium content
+func Entitled(status string) bool {
+	for _, entitled := range daos.EntitledBillingStatuses {
+		if status == entitled {
+			return true
+		}
+	}
+	return false
+}
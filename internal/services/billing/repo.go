@@ -0,0 +1,205 @@
+package billing
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"newsletter-service/internal/daos"
+)
+
+// dunningStatuses are the statuses of subscriptions still open after a failed payment, whose subscribers can
+// keep them by paying
+var dunningStatuses = []string{daos.BillingStatusActive, daos.BillingStatusPastDue, daos.BillingStatusUnpaid}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) CreatePlan(ctx context.Context, plan *Plan) error {
+	return r.db.WithContext(ctx).Create(plan).Error
+}
+
+func (r *repository) GetPlanByID(ctx context.Context, id uint) (*Plan, error) {
+	var plan Plan
+	err := r.db.WithContext(ctx).First(&plan, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &plan, nil
+}
+
+// GetPlanByIDUnscoped finds a plan even after it was deleted, for subscriptions that were bought before
+func (r *repository) GetPlanByIDUnscoped(ctx context.Context, id uint) (*Plan, error) {
+	var plan Plan
+	err := r.db.WithContext(ctx).Unscoped().First(&plan, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &plan, nil
+}
+
+// GetPlansWithPagination lists plans by topic and name, only those of topicID unless it is 0
+func (r *repository) GetPlansWithPagination(ctx context.Context, topicID uint, offset, limit int) ([]*Plan, int64, error) {
+	var plans []*Plan
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&Plan{})
+	if topicID != 0 {
+		query = query.Where("topic_id = ?", topicID)
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("topic_id, name").Offset(offset).Limit(limit).Find(&plans).Error
+	return plans, total, err
+}
+
+// PriceTaken reports whether a plan other than excludeID sells the Stripe price. Prices are unique across
+// organizations and deleted plans, so the lookup is raw SQL that isn't narrowed to ctx's organization.
+func (r *repository) PriceTaken(ctx context.Context, stripePriceID string, excludeID uint) (bool, error) {
+	var taken bool
+	err := r.db.WithContext(ctx).
+		Raw("SELECT EXISTS (SELECT 1 FROM plans WHERE stripe_price_id = ? AND id <> ?)", stripePriceID, excludeID).
+		Scan(&taken).Error
+	return taken, err
+}
+
+func (r *repository) UpdatePlan(ctx context.Context, id uint, updates map[string]interface{}) error {
+	return r.db.WithContext(ctx).Model(&Plan{}).Where("id = ?", id).Updates(updates).Error
+}
+
+func (r *repository) DeletePlan(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&Plan{}, id).Error
+}
+
+func (r *repository) GetSubscriptionByStripeID(ctx context.Context, stripeSubscriptionID string) (*Subscription, error) {
+	var subscription Subscription
+	err := r.db.WithContext(ctx).Where("stripe_subscription_id = ?", stripeSubscriptionID).First(&subscription).Error
+	if err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+// GetSubscriptionsWithPagination lists subscriptions matching the filter, newest first
+func (r *repository) GetSubscriptionsWithPagination(ctx context.Context, filter SubscriptionFilter, offset, limit int) ([]*Subscription, int64, error) {
+	var subscriptions []*Subscription
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&Subscription{})
+	if filter.SubscriberID != 0 {
+		query = query.Where("subscriber_id = ?", filter.SubscriberID)
+	}
+	if filter.TopicID != 0 {
+		query = query.Where("topic_id = ?", filter.TopicID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("id desc").Offset(offset).Limit(limit).Find(&subscriptions).Error
+	return subscriptions, total, err
+}
+
+// HasEntitlement reports whether the subscriber pays for any plan of the topic
+func (r *repository) HasEntitlement(ctx context.Context, subscriberID, topicID uint) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&Subscription{}).
+		Where("subscriber_id = ? AND topic_id = ? AND status IN ?", subscriberID, topicID, daos.EntitledBillingStatuses).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// GetCustomerID returns the Stripe customer of the subscriber's latest subscription, or "" if they have none
+func (r *repository) GetCustomerID(ctx context.Context, subscriberID uint) (string, error) {
+	var customerIDs []string
+	err := r.db.WithContext(ctx).Model(&Subscription{}).
+		Where("subscriber_id = ? AND stripe_customer_id <> ''", subscriberID).
+		Order("id desc").
+		Limit(1).
+		Pluck("stripe_customer_id", &customerIDs).Error
+	if err != nil || len(customerIDs) == 0 {
+		return "", err
+	}
+	return customerIDs[0], nil
+}
+
+// CreateSubscription records a Stripe subscription unless it already is, reporting whether it was created.
+// Stripe sends the checkout and subscription events in no particular order, and either may record it first.
+func (r *repository) CreateSubscription(ctx context.Context, subscription *Subscription) (bool, error) {
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "stripe_subscription_id"}},
+		DoNothing: true,
+	}).Create(subscription)
+	return result.RowsAffected > 0, result.Error
+}
+
+// UpdateSubscription applies updates to a Stripe subscription, reporting whether it was found
+func (r *repository) UpdateSubscription(ctx context.Context, stripeSubscriptionID string, updates map[string]interface{}) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&Subscription{}).
+		Where("stripe_subscription_id = ?", stripeSubscriptionID).
+		Updates(updates)
+	return result.RowsAffected > 0, result.Error
+}
+
+// MarkPaymentFailed starts dunning a subscription. Stripe's retries failing again keep the time of the first
+// failure, so the schedule isn't restarted.
+func (r *repository) MarkPaymentFailed(ctx context.Context, stripeSubscriptionID string, failedAt time.Time) error {
+	return r.db.WithContext(ctx).Model(&Subscription{}).
+		Where("stripe_subscription_id = ? AND payment_failed_at IS NULL", stripeSubscriptionID).
+		Update("payment_failed_at", failedAt).Error
+}
+
+// ClearPaymentFailure ends dunning once a payment goes through
+func (r *repository) ClearPaymentFailure(ctx context.Context, stripeSubscriptionID string) error {
+	return r.db.WithContext(ctx).Model(&Subscription{}).
+		Where("stripe_subscription_id = ? AND payment_failed_at IS NOT NULL", stripeSubscriptionID).
+		Updates(map[string]interface{}{
+			"payment_failed_at":     nil,
+			"dunning_emails_sent":   0,
+			"last_dunning_email_at": nil,
+		}).Error
+}
+
+// GetDueForDunning returns open subscriptions that have had step dunning emails and whose payment failed
+// before failedBefore, longest failing first
+func (r *repository) GetDueForDunning(ctx context.Context, step int, failedBefore time.Time, limit int) ([]*Subscription, error) {
+	var subscriptions []*Subscription
+	err := r.db.WithContext(ctx).
+		Where("payment_failed_at IS NOT NULL AND payment_failed_at <= ?", failedBefore).
+		Where("dunning_emails_sent = ? AND status IN ?", step, dunningStatuses).
+		Order("payment_failed_at").
+		Limit(limit).
+		Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+// ClaimDunningStep counts the email of the step as sent before it goes out, so two workers can't both send
+// it. It reports false when another worker claimed it first or the payment went through meanwhile.
+func (r *repository) ClaimDunningStep(ctx context.Context, id uint, step int, sentAt time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&Subscription{}).
+		Where("id = ? AND dunning_emails_sent = ? AND payment_failed_at IS NOT NULL", id, step).
+		Updates(map[string]interface{}{
+			"dunning_emails_sent":   step + 1,
+			"last_dunning_email_at": sentAt,
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+// ReleaseDunningStep undoes the claim of an email that failed to send, so the next run tries it again
+func (r *repository) ReleaseDunningStep(ctx context.Context, id uint, step int) error {
+	return r.db.WithContext(ctx).Model(&Subscription{}).
+		Where("id = ? AND dunning_emails_sent = ?", id, step+1).
+		Update("dunning_emails_sent", step).Error
+}
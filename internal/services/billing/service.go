@@ -0,0 +1,420 @@
+package billing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"log"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"newsletter-service/internal/config"
+	"newsletter-service/internal/daos"
+	"newsletter-service/internal/providers"
+	"newsletter-service/internal/services/subscriber"
+	"newsletter-service/internal/services/topic"
+	"newsletter-service/internal/tenant"
+)
+
+// Defaults for settings left at zero
+const defaultWebhookTolerance = 5 * time.Minute
+
+// defaultDunningSchedule emails straight after a failed payment, then three and seven days on
+var defaultDunningSchedule = []time.Duration{0, 72 * time.Hour, 168 * time.Hour}
+
+// dunningBatchSize caps how many emails of each dunning step one run sends
+const dunningBatchSize = 200
+
+type service struct {
+	repo              Repository
+	topicService      topic.Service
+	subscriberService subscriber.Service
+	stripe            *stripeClient
+	mailer            Mailer // Nil in the web API, which leaves dunning emails to the worker
+	cfg               config.BillingConfig
+}
+
+func NewService(repo Repository, topicService topic.Service, subscriberService subscriber.Service, cfg config.BillingConfig) Service {
+	return &service{
+		repo:              repo,
+		topicService:      topicService,
+		subscriberService: subscriberService,
+		stripe:            newStripeClient(cfg.SecretKey),
+		cfg:               cfg,
+	}
+}
+
+// NewServiceWithMailer creates a billing service that also sends dunning emails through mailer
+func NewServiceWithMailer(repo Repository, topicService topic.Service, subscriberService subscriber.Service, cfg config.BillingConfig, mailer Mailer) Service {
+	return &service{
+		repo:              repo,
+		topicService:      topicService,
+		subscriberService: subscriberService,
+		stripe:            newStripeClient(cfg.SecretKey),
+		mailer:            mailer,
+		cfg:               cfg,
+	}
+}
+
+// CreatePlan adds a paid tier to a topic of the organization ctx is scoped to
+func (s *service) CreatePlan(ctx context.Context, plan *Plan) error {
+	if _, err := s.topicService.GetTopicByID(ctx, plan.TopicID); errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrTopicNotFound
+	} else if err != nil {
+		return err
+	}
+	if err := s.checkPrice(ctx, plan.StripePriceID, 0); err != nil {
+		return err
+	}
+	plan.Currency = strings.ToLower(plan.Currency)
+	return s.repo.CreatePlan(ctx, plan)
+}
+
+func (s *service) GetPlanByID(ctx context.Context, id uint) (*Plan, error) {
+	return s.repo.GetPlanByID(ctx, id)
+}
+
+func (s *service) GetPlansWithPagination(ctx context.Context, topicID uint, offset, limit int) ([]*Plan, int64, error) {
+	return s.repo.GetPlansWithPagination(ctx, topicID, offset, limit)
+}
+
+// UpdatePlan changes a plan. A new Stripe price only applies to checkouts from then on; subscribers already
+// paying keep the price they bought until they change it in Stripe.
+func (s *service) UpdatePlan(ctx context.Context, id uint, updates map[string]interface{}) error {
+	if _, err := s.repo.GetPlanByID(ctx, id); err != nil {
+		return err
+	}
+	if priceID, ok := updates["stripe_price_id"].(string); ok {
+		if err := s.checkPrice(ctx, priceID, id); err != nil {
+			return err
+		}
+	}
+	if currency, ok := updates["currency"].(string); ok {
+		updates["currency"] = strings.ToLower(currency)
+	}
+	return s.repo.UpdatePlan(ctx, id, updates)
+}
+
+// DeletePlan stops selling a plan. Subscriptions to it stay entitled until they are canceled in Stripe.
+func (s *service) DeletePlan(ctx context.Context, id uint) error {
+	if _, err := s.repo.GetPlanByID(ctx, id); err != nil {
+		return err
+	}
+	return s.repo.DeletePlan(ctx, id)
+}
+
+func (s *service) checkPrice(ctx context.Context, stripePriceID string, excludeID uint) error {
+	taken, err := s.repo.PriceTaken(ctx, stripePriceID, excludeID)
+	if err != nil {
+		return err
+	}
+	if taken {
+		return ErrDuplicatePrice
+	}
+	return nil
+}
+
+// CreateCheckout starts a Stripe Checkout session for a subscriber to buy a plan. The subscription is
+// recorded when Stripe reports the completed checkout to the webhook, not here.
+func (s *service) CreateCheckout(ctx context.Context, planID, subscriberID uint) (*Checkout, error) {
+	if !s.cfg.Enabled {
+		return nil, ErrDisabled
+	}
+
+	plan, err := s.repo.GetPlanByID(ctx, planID)
+	if err != nil {
+		return nil, err
+	}
+	if !plan.Active {
+		return nil, ErrPlanInactive
+	}
+	sub, err := s.subscriberService.GetSubscriberByID(ctx, subscriberID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrSubscriberNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	entitled, err := s.repo.HasEntitlement(ctx, subscriberID, plan.TopicID)
+	if err != nil {
+		return nil, err
+	}
+	if entitled {
+		return nil, ErrAlreadyEntitled
+	}
+
+	// Returning customers pay with the card Stripe already has
+	customerID, err := s.repo.GetCustomerID(ctx, subscriberID)
+	if err != nil {
+		return nil, err
+	}
+	return s.stripe.createCheckoutSession(ctx, checkoutRequest{
+		Plan:         plan,
+		SubscriberID: sub.ID,
+		Email:        sub.Email,
+		CustomerID:   customerID,
+		SuccessURL:   s.cfg.SuccessURL,
+		CancelURL:    s.cfg.CancelURL,
+	})
+}
+
+// HandleWebhook verifies and applies a Stripe webhook event. Events arrive without an organization; each
+// subscription is scoped to the one of the plan it was bought through.
+func (s *service) HandleWebhook(ctx context.Context, payload []byte, signature string) error {
+	if !s.cfg.Enabled {
+		return ErrDisabled
+	}
+	if err := verifySignature(payload, signature, s.cfg.WebhookSecret, s.webhookTolerance(), time.Now()); err != nil {
+		return err
+	}
+
+	var event stripeEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidEvent, err)
+	}
+	switch event.Type {
+	case eventCheckoutCompleted:
+		var session stripeCheckoutSession
+		if err := json.Unmarshal(event.Data.Object, &session); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidEvent, err)
+		}
+		return s.checkoutCompleted(ctx, &session)
+	case eventSubscriptionCreated, eventSubscriptionUpdated, eventSubscriptionDeleted:
+		var subscription stripeSubscription
+		if err := json.Unmarshal(event.Data.Object, &subscription); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidEvent, err)
+		}
+		return s.subscriptionChanged(ctx, &subscription)
+	case eventInvoicePaid, eventInvoicePaymentFailed:
+		var invoice stripeInvoice
+		if err := json.Unmarshal(event.Data.Object, &invoice); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidEvent, err)
+		}
+		subscriptionID := invoice.subscriptionID()
+		if subscriptionID == "" {
+			return nil
+		}
+		if event.Type == eventInvoicePaid {
+			return s.repo.ClearPaymentFailure(ctx, subscriptionID)
+		}
+		return s.repo.MarkPaymentFailed(ctx, subscriptionID, time.Now())
+	}
+	return nil
+}
+
+// checkoutCompleted records the subscription a Checkout session bought. The session only says whether it was
+// paid; the subscription events that follow bring its exact status.
+func (s *service) checkoutCompleted(ctx context.Context, session *stripeCheckoutSession) error {
+	if session.Mode != "subscription" || session.Subscription == "" {
+		return nil
+	}
+	status := daos.BillingStatusIncomplete
+	switch session.PaymentStatus {
+	case "paid":
+		status = daos.BillingStatusActive
+	case "no_payment_required":
+		status = daos.BillingStatusTrialing
+	}
+	return s.recordSubscription(ctx, session.Metadata, &Subscription{
+		StripeCustomerID:     session.Customer,
+		StripeSubscriptionID: session.Subscription,
+		Status:               status,
+	})
+}
+
+// subscriptionChanged brings a recorded subscription up to date, recording it first if its checkout event
+// hasn't arrived yet
+func (s *service) subscriptionChanged(ctx context.Context, subscription *stripeSubscription) error {
+	updates := map[string]interface{}{
+		"status":               subscription.Status,
+		"current_period_end":   subscription.periodEnd(),
+		"cancel_at_period_end": subscription.CancelAtPeriodEnd,
+	}
+	if subscription.Customer != "" {
+		updates["stripe_customer_id"] = subscription.Customer
+	}
+	found, err := s.repo.UpdateSubscription(ctx, subscription.ID, updates)
+	if err != nil || found {
+		return err
+	}
+	return s.recordSubscription(ctx, subscription.Metadata, &Subscription{
+		StripeCustomerID:     subscription.Customer,
+		StripeSubscriptionID: subscription.ID,
+		Status:               subscription.Status,
+		CurrentPeriodEnd:     subscription.periodEnd(),
+		CancelAtPeriodEnd:    subscription.CancelAtPeriodEnd,
+	})
+}
+
+// recordSubscription creates a subscription for the subscriber and plan in the Checkout metadata, and
+// subscribes the subscriber to the plan's topic. Subscriptions not bought through CreateCheckout lack the
+// metadata and are ignored.
+func (s *service) recordSubscription(ctx context.Context, metadata map[string]string, subscription *Subscription) error {
+	subscriberID, planID := metadataID(metadata, metadataSubscriberID), metadataID(metadata, metadataPlanID)
+	if subscriberID == 0 || planID == 0 {
+		return nil
+	}
+	plan, err := s.repo.GetPlanByIDUnscoped(ctx, planID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Printf("Ignoring Stripe subscription %s of unknown plan %d", subscription.StripeSubscriptionID, planID)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	ctx = tenant.WithOrganization(ctx, plan.OrganizationID)
+
+	// Only a subscriber of the plan's organization can hold it
+	if _, err := s.subscriberService.GetSubscriberByID(ctx, subscriberID); errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Printf("Ignoring Stripe subscription %s of unknown subscriber %d", subscription.StripeSubscriptionID, subscriberID)
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	subscription.SubscriberID = subscriberID
+	subscription.PlanID = plan.ID
+	subscription.TopicID = plan.TopicID
+	subscription.OrganizationID = plan.OrganizationID
+	created, err := s.repo.CreateSubscription(ctx, subscription)
+	if err != nil || !created {
+		return err
+	}
+
+	// Paying for a topic puts the subscriber on it. Later events leave their subscriptions alone, so one
+	// who unsubscribes while still paying stays unsubscribed.
+	if _, err := s.subscriberService.Subscribe(ctx, subscriberID, plan.TopicID); err != nil {
+		log.Printf("Failed to subscribe subscriber %d to topic %d of plan %d: %v", subscriberID, plan.TopicID, plan.ID, err)
+	}
+	return nil
+}
+
+func (s *service) GetSubscriptionsWithPagination(ctx context.Context, filter SubscriptionFilter, offset, limit int) ([]*Subscription, int64, error) {
+	return s.repo.GetSubscriptionsWithPagination(ctx, filter, offset, limit)
+}
+
+// SendDunningEmails emails subscribers whose payment failed, one email at each delay of [billing]
+// dunning_schedule after the failure, and returns how many were sent. A subscription gets at most one email
+// per run, and none once a payment goes through or it is canceled.
+func (s *service) SendDunningEmails(ctx context.Context) (int, error) {
+	if !s.cfg.Enabled {
+		return 0, nil
+	}
+	if s.mailer == nil {
+		return 0, fmt.Errorf("a mailer is required to send dunning emails - use NewServiceWithMailer")
+	}
+
+	schedule := s.dunningSchedule()
+	now := time.Now()
+	emailed := make(map[uint]bool)
+	plans := make(map[uint]*Plan)
+	sent := 0
+	var errs []error
+	for step, delay := range schedule {
+		subscriptions, err := s.repo.GetDueForDunning(ctx, step, now.Add(-delay), dunningBatchSize)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to get subscriptions due for dunning: %w", err))
+			continue
+		}
+		for _, subscription := range subscriptions {
+			if ctx.Err() != nil {
+				return sent, errors.Join(errs...)
+			}
+			if emailed[subscription.ID] {
+				continue
+			}
+			ok, err := s.sendDunningEmail(ctx, subscription, step, step == len(schedule)-1, plans)
+			if err != nil {
+				log.Printf("Failed to send dunning email for billing subscription %d: %v", subscription.ID, err)
+				errs = append(errs, err)
+				continue
+			}
+			if ok {
+				emailed[subscription.ID] = true
+				sent++
+			}
+		}
+	}
+	return sent, errors.Join(errs...)
+}
+
+// sendDunningEmail claims and sends the email of one dunning step, reporting false when there was nothing to
+// send. A failed send is released so the next run retries it.
+func (s *service) sendDunningEmail(ctx context.Context, subscription *Subscription, step int, last bool, plans map[uint]*Plan) (bool, error) {
+	ctx = tenant.WithOrganization(ctx, subscription.OrganizationID)
+	claimed, err := s.repo.ClaimDunningStep(ctx, subscription.ID, step, time.Now())
+	if err != nil || !claimed {
+		return false, err
+	}
+
+	sub, err := s.subscriberService.GetSubscriberByID(ctx, subscription.SubscriberID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// Deleted subscribers aren't chased; the step stays claimed so they aren't looked up again
+		return false, nil
+	}
+	if err == nil {
+		plan := plans[subscription.PlanID]
+		if plan == nil {
+			plan, err = s.repo.GetPlanByIDUnscoped(ctx, subscription.PlanID)
+			plans[subscription.PlanID] = plan
+		}
+		if err == nil {
+			_, err = s.mailer.SendTransactionalEmail(ctx, s.dunningEmail(sub, plan, last))
+		}
+	}
+	if err != nil {
+		if releaseErr := s.repo.ReleaseDunningStep(ctx, subscription.ID, step); releaseErr != nil {
+			err = errors.Join(err, releaseErr)
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// dunningEmail asks a subscriber whose payment failed to update their payment details
+func (s *service) dunningEmail(sub *Subscriber, plan *Plan, last bool) providers.EmailNotification {
+	subject := "Your payment for " + plan.Name + " didn't go through"
+	if last {
+		subject = "Last reminder: your payment for " + plan.Name + " didn't go through"
+	}
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "<p>Hi %s,</p>\n", html.EscapeString(sub.Name))
+	fmt.Fprintf(&body, "<p>We couldn't take the payment for your %s subscription.</p>\n", html.EscapeString(plan.Name))
+	if s.cfg.PortalURL != "" {
+		fmt.Fprintf(&body, "<p><a href=\"%s\">Update your payment details</a> to keep receiving premium issues.</p>\n", html.EscapeString(s.cfg.PortalURL))
+	} else {
+		body.WriteString("<p>Please update your payment details to keep receiving premium issues.</p>\n")
+	}
+	if last {
+		body.WriteString("<p>This is our last reminder. If the payment keeps failing, your subscription will end.</p>")
+	} else {
+		body.WriteString("<p>We'll try the payment again over the next few days.</p>")
+	}
+
+	return providers.EmailNotification{
+		To:          sub.Email,
+		Subject:     subject,
+		Body:        body.String(),
+		PreviewText: "Update your payment details to keep your subscription",
+	}
+}
+
+func (s *service) webhookTolerance() time.Duration {
+	if s.cfg.WebhookTolerance > 0 {
+		return s.cfg.WebhookTolerance
+	}
+	return defaultWebhookTolerance
+}
+
+func (s *service) dunningSchedule() []time.Duration {
+	if len(s.cfg.DunningSchedule) > 0 {
+		return s.cfg.DunningSchedule
+	}
+	return defaultDunningSchedule
+}
@@ -0,0 +1,240 @@
+package billing
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"newsletter-service/internal/tracing"
+)
+
+// stripeAPIBase is the Stripe REST API root
+const stripeAPIBase = "https://api.stripe.com/v1"
+
+// Stripe webhook events the service acts on; others are acknowledged and ignored
+const (
+	eventCheckoutCompleted    = "checkout.session.completed"
+	eventSubscriptionCreated  = "customer.subscription.created"
+	eventSubscriptionUpdated  = "customer.subscription.updated"
+	eventSubscriptionDeleted  = "customer.subscription.deleted"
+	eventInvoicePaid          = "invoice.paid"
+	eventInvoicePaymentFailed = "invoice.payment_failed"
+)
+
+// Metadata keys set on Checkout sessions and their subscriptions, tying them back to the subscriber and plan
+const (
+	metadataSubscriberID = "subscriber_id"
+	metadataPlanID       = "plan_id"
+)
+
+// stripeClient creates Checkout sessions through the Stripe API
+type stripeClient struct {
+	secretKey string
+	client    *http.Client
+}
+
+func newStripeClient(secretKey string) *stripeClient {
+	return &stripeClient{
+		secretKey: secretKey,
+		client:    &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// checkoutRequest is a subscription Checkout session for one subscriber and plan
+type checkoutRequest struct {
+	Plan         *Plan
+	SubscriberID uint
+	Email        string
+	CustomerID   string // Stripe customer of an earlier subscription; Stripe creates one from Email when empty
+	SuccessURL   string
+	CancelURL    string
+}
+
+// stripeError is the error Stripe answers a rejected request with
+type stripeError struct {
+	Error struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func (c *stripeClient) createCheckoutSession(ctx context.Context, req checkoutRequest) (checkout *Checkout, err error) {
+	ctx, span := tracing.StartSpan(ctx, "billing.stripe.create_checkout_session", attribute.Int("plan.id", int(req.Plan.ID)))
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+	}()
+
+	subscriberID := strconv.FormatUint(uint64(req.SubscriberID), 10)
+	planID := strconv.FormatUint(uint64(req.Plan.ID), 10)
+	form := url.Values{}
+	form.Set("mode", "subscription")
+	form.Set("line_items[0][price]", req.Plan.StripePriceID)
+	form.Set("line_items[0][quantity]", "1")
+	form.Set("success_url", req.SuccessURL)
+	form.Set("cancel_url", req.CancelURL)
+	form.Set("client_reference_id", subscriberID)
+	form.Set("metadata["+metadataSubscriberID+"]", subscriberID)
+	form.Set("metadata["+metadataPlanID+"]", planID)
+	form.Set("subscription_data[metadata]["+metadataSubscriberID+"]", subscriberID)
+	form.Set("subscription_data[metadata]["+metadataPlanID+"]", planID)
+	if req.CustomerID != "" {
+		form.Set("customer", req.CustomerID)
+	} else {
+		form.Set("customer_email", req.Email)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, stripeAPIBase+"/checkout/sessions", strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Stripe request: %w", err)
+	}
+	httpReq.Header.Set("Authorization", "Bearer "+c.secretKey)
+	httpReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send Stripe request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if resp.StatusCode != http.StatusOK {
+		var apiErr stripeError
+		json.Unmarshal(body, &apiErr)
+		return nil, fmt.Errorf("Stripe API returned status %d: %s %s", resp.StatusCode, apiErr.Error.Type, apiErr.Error.Message)
+	}
+
+	var session struct {
+		ID  string `json:"id"`
+		URL string `json:"url"`
+	}
+	if err := json.Unmarshal(body, &session); err != nil {
+		return nil, fmt.Errorf("failed to decode Stripe checkout session: %w", err)
+	}
+	return &Checkout{SessionID: session.ID, URL: session.URL}, nil
+}
+
+// verifySignature checks a Stripe-Signature header ("t=<unix time>,v1=<hex HMAC>,...") against the payload.
+// Any v1 signature made with secret over "<t>.<payload>" is accepted if t is within tolerance of now.
+func verifySignature(payload []byte, header, secret string, tolerance time.Duration, now time.Time) error {
+	var timestamp int64
+	var signatures []string
+	for _, part := range strings.Split(header, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "t":
+			timestamp, _ = strconv.ParseInt(value, 10, 64)
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+	if timestamp == 0 || len(signatures) == 0 {
+		return ErrInvalidSignature
+	}
+	signedAt := time.Unix(timestamp, 0)
+	if now.Sub(signedAt) > tolerance || signedAt.Sub(now) > tolerance {
+		return ErrInvalidSignature
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10) + "."))
+	mac.Write(payload)
+	expected := mac.Sum(nil)
+	for _, signature := range signatures {
+		if got, err := hex.DecodeString(signature); err == nil && hmac.Equal(got, expected) {
+			return nil
+		}
+	}
+	return ErrInvalidSignature
+}
+
+// stripeEvent is a webhook event; Data.Object is the resource it is about
+type stripeEvent struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+	Data struct {
+		Object json.RawMessage `json:"object"`
+	} `json:"data"`
+}
+
+// stripeCheckoutSession is the part of a Checkout session the service reads
+type stripeCheckoutSession struct {
+	ID            string            `json:"id"`
+	Mode          string            `json:"mode"`
+	Customer      string            `json:"customer"`
+	Subscription  string            `json:"subscription"`
+	PaymentStatus string            `json:"payment_status"` // paid, or no_payment_required while trialing
+	Metadata      map[string]string `json:"metadata"`
+}
+
+// stripeSubscription is the part of a Stripe subscription the service reads. Newer API versions moved
+// current_period_end from the subscription to its items.
+type stripeSubscription struct {
+	ID                string            `json:"id"`
+	Customer          string            `json:"customer"`
+	Status            string            `json:"status"`
+	CurrentPeriodEnd  int64             `json:"current_period_end"`
+	CancelAtPeriodEnd bool              `json:"cancel_at_period_end"`
+	Metadata          map[string]string `json:"metadata"`
+	Items             struct {
+		Data []struct {
+			CurrentPeriodEnd int64 `json:"current_period_end"`
+		} `json:"data"`
+	} `json:"items"`
+}
+
+// periodEnd returns when the subscription's current period ends, or nil if Stripe didn't say
+func (s *stripeSubscription) periodEnd() *time.Time {
+	end := s.CurrentPeriodEnd
+	if end == 0 && len(s.Items.Data) > 0 {
+		end = s.Items.Data[0].CurrentPeriodEnd
+	}
+	if end == 0 {
+		return nil
+	}
+	t := time.Unix(end, 0)
+	return &t
+}
+
+// stripeInvoice is the part of an invoice the service reads. Newer API versions name the subscription under
+// parent.subscription_details instead.
+type stripeInvoice struct {
+	ID           string `json:"id"`
+	Subscription string `json:"subscription"`
+	Parent       struct {
+		SubscriptionDetails struct {
+			Subscription string `json:"subscription"`
+		} `json:"subscription_details"`
+	} `json:"parent"`
+}
+
+func (i *stripeInvoice) subscriptionID() string {
+	if i.Subscription != "" {
+		return i.Subscription
+	}
+	return i.Parent.SubscriptionDetails.Subscription
+}
+
+// metadataID reads one of the IDs set on Checkout sessions, returning 0 if it is missing or malformed
+func metadataID(metadata map[string]string, key string) uint {
+	id, err := strconv.ParseUint(metadata[key], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return uint(id)
+}
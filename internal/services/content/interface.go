@@ -5,23 +5,40 @@ import "context"
 type Repository interface {
 	Create(ctx context.Context, content *Content) error
 	GetByID(ctx context.Context, id uint) (*Content, error)
+	GetByIDs(ctx context.Context, ids []uint) ([]*Content, error)
 	GetAll(ctx context.Context) ([]*Content, error)
 	GetAllWithPagination(ctx context.Context, offset, limit int) ([]*Content, int64, error)
+	GetAllIncludingDeletedWithPagination(ctx context.Context, offset, limit int) ([]*Content, int64, error)
 	Update(ctx context.Context, id uint, updates map[string]interface{}) error
 	Delete(ctx context.Context, id uint) error
+	Restore(ctx context.Context, id uint) error
 	Publish(ctx context.Context, id uint) error
 	GetPendingNotifications(ctx context.Context) ([]uint, error)
+	GetPendingNotificationsByPriority(ctx context.Context, priority string) ([]uint, error)
 	MarkNotificationsSent(ctx context.Context, id uint) error
+	IsTopicArchived(ctx context.Context, topicID uint) (bool, error)
+	LoadTopics(ctx context.Context, contents []*Content) error
+	GetTranslations(ctx context.Context, contentID uint) ([]*Translation, error)
+	UpsertTranslation(ctx context.Context, translation *Translation) error
+	DeleteTranslation(ctx context.Context, contentID uint, locale string) (*Translation, error)
 }
 
 type Service interface {
 	CreateContent(ctx context.Context, content *Content) error
 	GetContentByID(ctx context.Context, id uint) (*Content, error)
+	GetContentsByIDs(ctx context.Context, ids []uint) ([]*Content, error)
 	GetAllContent(ctx context.Context) ([]*Content, error)
 	GetAllContentWithPagination(ctx context.Context, offset, limit int) ([]*Content, int64, error)
+	GetAllContentIncludingDeletedWithPagination(ctx context.Context, offset, limit int) ([]*Content, int64, error)
+	LoadTopics(ctx context.Context, contents []*Content) error
 	UpdateContent(ctx context.Context, id uint, updates map[string]interface{}) error
 	DeleteContent(ctx context.Context, id uint) error
+	RestoreContent(ctx context.Context, id uint) error
 	PublishContent(ctx context.Context, id uint) error
 	GetPendingNotifications(ctx context.Context) ([]uint, error)
+	GetPendingNotificationsByPriority(ctx context.Context, priority string) ([]uint, error)
 	MarkNotificationsSent(ctx context.Context, id uint) error
+	GetTranslations(ctx context.Context, contentID uint) ([]*Translation, error)
+	SetTranslation(ctx context.Context, translation *Translation) error
+	DeleteTranslation(ctx context.Context, contentID uint, locale string) (*Translation, error)
 }
@@ -1,8 +1,53 @@
 package content
 
 import (
+	"errors"
+	"time"
+
 	"newsletter-service/internal/daos"
 )
 
 // Type alias for backward compatibility
 type Content = daos.Content
+
+// ErrInvalidLocalSendTime is returned for a local send time that isn't a valid "HH:MM"
+var ErrInvalidLocalSendTime = errors.New("local_send_time must be a 24-hour time formatted HH:MM")
+
+// ErrTopicArchived is returned when content is added to an archived topic
+var ErrTopicArchived = errors.New("topic is archived")
+
+// maxUTCOffset is the furthest-ahead time zone offset (UTC+14); no local-time send is due before its time there
+const maxUTCOffset = 14 * time.Hour
+
+// ValidateLocalSendTime checks a "HH:MM" local send time; empty means the content isn't sent at a local time
+func ValidateLocalSendTime(localSendTime string) error {
+	if localSendTime == "" {
+		return nil
+	}
+	if _, err := time.Parse("15:04", localSendTime); err != nil || len(localSendTime) != 5 {
+		return ErrInvalidLocalSendTime
+	}
+	return nil
+}
+
+// LocalReleaseTime returns when a recipient in loc should receive content sent at a local time: its local send
+// time on the send_at date, or on the publish date when send_at is unset (dates taken in UTC)
+func LocalReleaseTime(c *Content, loc *time.Location) (time.Time, error) {
+	clock, err := time.Parse("15:04", c.LocalSendTime)
+	if err != nil {
+		return time.Time{}, ErrInvalidLocalSendTime
+	}
+
+	date := time.Now().UTC()
+	if c.SendAt != nil {
+		date = c.SendAt.UTC()
+	} else if c.PublishedAt != nil {
+		date = c.PublishedAt.UTC()
+	}
+	return time.Date(date.Year(), date.Month(), date.Day(), clock.Hour(), clock.Minute(), 0, 0, loc), nil
+}
+
+// FirstLocalReleaseTime returns when the earliest time zone's recipients of local-time content become due
+func FirstLocalReleaseTime(c *Content) (time.Time, error) {
+	return LocalReleaseTime(c, time.FixedZone("UTC+14", int(maxUTCOffset.Seconds())))
+}
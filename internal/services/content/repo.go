@@ -5,6 +5,9 @@ import (
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"newsletter-service/internal/daos"
 )
 
 type repository struct {
@@ -19,6 +22,40 @@ func (r *repository) Create(ctx context.Context, content *Content) error {
 	return r.db.WithContext(ctx).Create(content).Error
 }
 
+// IsTopicArchived reports whether the topic is archived. Topics that don't exist aren't; creating content for
+// them fails on the foreign key as before.
+func (r *repository) IsTopicArchived(ctx context.Context, topicID uint) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&daos.Topic{}).Where("id = ? AND archived_at IS NOT NULL", topicID).Count(&count).Error
+	return count > 0, err
+}
+
+// LoadTopics sets the Topic of each content with a single query. Deleted topics are loaded as well, since
+// deleted contents listed with include_deleted may still point at them.
+func (r *repository) LoadTopics(ctx context.Context, contents []*Content) error {
+	if len(contents) == 0 {
+		return nil
+	}
+
+	topicIDs := make([]uint, 0, len(contents))
+	for _, c := range contents {
+		topicIDs = append(topicIDs, c.TopicID)
+	}
+	var topics []*daos.Topic
+	if err := r.db.WithContext(ctx).Unscoped().Where("id IN ?", topicIDs).Find(&topics).Error; err != nil {
+		return err
+	}
+
+	byID := make(map[uint]*daos.Topic, len(topics))
+	for _, t := range topics {
+		byID[t.ID] = t
+	}
+	for _, c := range contents {
+		c.Topic = byID[c.TopicID]
+	}
+	return nil
+}
+
 func (r *repository) GetByID(ctx context.Context, id uint) (*Content, error) {
 	var content Content
 	err := r.db.WithContext(ctx).First(&content, id).Error
@@ -28,6 +65,12 @@ func (r *repository) GetByID(ctx context.Context, id uint) (*Content, error) {
 	return &content, nil
 }
 
+func (r *repository) GetByIDs(ctx context.Context, ids []uint) ([]*Content, error) {
+	var contents []*Content
+	err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&contents).Error
+	return contents, err
+}
+
 func (r *repository) GetAll(ctx context.Context) ([]*Content, error) {
 	var contents []*Content
 	err := r.db.WithContext(ctx).Order("created_at desc").Find(&contents).Error
@@ -48,6 +91,21 @@ func (r *repository) GetAllWithPagination(ctx context.Context, offset, limit int
 	return contents, total, err
 }
 
+// GetAllIncludingDeletedWithPagination lists soft-deleted contents alongside live ones
+func (r *repository) GetAllIncludingDeletedWithPagination(ctx context.Context, offset, limit int) ([]*Content, int64, error) {
+	var contents []*Content
+	var total int64
+
+	// Get total count
+	if err := r.db.WithContext(ctx).Unscoped().Model(&Content{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Get paginated results
+	err := r.db.WithContext(ctx).Unscoped().Order("created_at desc").Offset(offset).Limit(limit).Find(&contents).Error
+	return contents, total, err
+}
+
 func (r *repository) Update(ctx context.Context, id uint, updates map[string]interface{}) error {
 	return r.db.WithContext(ctx).Model(&Content{}).Where("id = ?", id).Updates(updates).Error
 }
@@ -56,6 +114,20 @@ func (r *repository) Delete(ctx context.Context, id uint) error {
 	return r.db.WithContext(ctx).Delete(&Content{}, id).Error
 }
 
+// Restore undoes a soft delete. It returns gorm.ErrRecordNotFound when no deleted content has the ID.
+func (r *repository) Restore(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).Unscoped().Model(&Content{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
 func (r *repository) Publish(ctx context.Context, id uint) error {
 	now := time.Now()
 	updates := map[string]interface{}{
@@ -65,21 +137,77 @@ func (r *repository) Publish(ctx context.Context, id uint) error {
 	return r.db.WithContext(ctx).Model(&Content{}).Where("id = ?", id).Updates(updates).Error
 }
 
+// priorityOrder sorts content from high to low priority
+const priorityOrder = "CASE priority WHEN 'high' THEN 0 WHEN 'low' THEN 2 ELSE 1 END"
+
+// GetPendingNotifications returns pending content highest priority first, oldest first within a priority
 func (r *repository) GetPendingNotifications(ctx context.Context) ([]uint, error) {
 	var contentIDs []uint
-	err := r.db.WithContext(ctx).
+	err := r.pending(ctx).
+		Order(priorityOrder).
+		Order("id").
+		Pluck("id", &contentIDs).Error
+	return contentIDs, err
+}
+
+func (r *repository) GetPendingNotificationsByPriority(ctx context.Context, priority string) ([]uint, error) {
+	var contentIDs []uint
+	err := r.pending(ctx).
+		Where("priority = ?", priority).
+		Order("id").
+		Pluck("id", &contentIDs).Error
+	return contentIDs, err
+}
+
+// pending selects content whose notifications are due. Content scheduled for later isn't pending yet, except
+// local-time content: the dispatcher releases each time zone's recipients when their local time arrives.
+func (r *repository) pending(ctx context.Context) *gorm.DB {
+	return r.db.WithContext(ctx).
 		Model(&Content{}).
 		Select("id").
 		Where("is_published = ? AND notifications_sent = ?", true, false).
-		Pluck("id", &contentIDs).Error
-	return contentIDs, err
+		Where("send_at IS NULL OR send_at <= ? OR COALESCE(local_send_time, '') <> ''", time.Now())
 }
 
+// MarkNotificationsSent records that the content went out to its recipients. Its delivery is complete once the
+// worker finds none of them outstanding, and is reported then.
 func (r *repository) MarkNotificationsSent(ctx context.Context, id uint) error {
 	now := time.Now()
 	updates := map[string]interface{}{
 		"notifications_sent":    true,
 		"notifications_sent_at": now,
+		"delivery_state":        daos.DeliveryStateSending,
+		"delivery_report_due":   true,
 	}
 	return r.db.WithContext(ctx).Model(&Content{}).Where("id = ?", id).Updates(updates).Error
 }
+
+func (r *repository) GetTranslations(ctx context.Context, contentID uint) ([]*Translation, error) {
+	var translations []*Translation
+	err := r.db.WithContext(ctx).Where("content_id = ?", contentID).Order("locale").Find(&translations).Error
+	return translations, err
+}
+
+// UpsertTranslation creates the content's translation into the locale, or replaces the existing one
+func (r *repository) UpsertTranslation(ctx context.Context, translation *Translation) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "content_id"}, {Name: "locale"}},
+		DoUpdates: clause.AssignmentColumns([]string{"title", "body", "preview_text", "updated_at"}),
+	}, clause.Returning{}).Create(translation).Error
+}
+
+// DeleteTranslation returns the deleted translation, or ErrTranslationNotFound when the content has none
+// into the locale
+func (r *repository) DeleteTranslation(ctx context.Context, contentID uint, locale string) (*Translation, error) {
+	var translation Translation
+	result := r.db.WithContext(ctx).Clauses(clause.Returning{}).
+		Where("content_id = ? AND locale = ?", contentID, locale).
+		Delete(&translation)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrTranslationNotFound
+	}
+	return &translation, nil
+}
@@ -10,7 +10,11 @@ func NewService(repo Repository) Service {
 	return &service{repo: repo}
 }
 
+// CreateContent saves new content, refusing topics that are archived with ErrTopicArchived
 func (s *service) CreateContent(ctx context.Context, content *Content) error {
+	if err := s.checkTopic(ctx, content.TopicID); err != nil {
+		return err
+	}
 	return s.repo.Create(ctx, content)
 }
 
@@ -18,6 +22,10 @@ func (s *service) GetContentByID(ctx context.Context, id uint) (*Content, error)
 	return s.repo.GetByID(ctx, id)
 }
 
+func (s *service) GetContentsByIDs(ctx context.Context, ids []uint) ([]*Content, error) {
+	return s.repo.GetByIDs(ctx, ids)
+}
+
 func (s *service) GetAllContent(ctx context.Context) ([]*Content, error) {
 	return s.repo.GetAll(ctx)
 }
@@ -26,14 +34,44 @@ func (s *service) GetAllContentWithPagination(ctx context.Context, offset, limit
 	return s.repo.GetAllWithPagination(ctx, offset, limit)
 }
 
+func (s *service) GetAllContentIncludingDeletedWithPagination(ctx context.Context, offset, limit int) ([]*Content, int64, error) {
+	return s.repo.GetAllIncludingDeletedWithPagination(ctx, offset, limit)
+}
+
+// LoadTopics embeds each content's topic, for responses that include it
+func (s *service) LoadTopics(ctx context.Context, contents []*Content) error {
+	return s.repo.LoadTopics(ctx, contents)
+}
+
 func (s *service) UpdateContent(ctx context.Context, id uint, updates map[string]interface{}) error {
+	if topicID, ok := updates["topic_id"].(uint); ok {
+		if err := s.checkTopic(ctx, topicID); err != nil {
+			return err
+		}
+	}
 	return s.repo.Update(ctx, id, updates)
 }
 
+// checkTopic returns ErrTopicArchived when content can't be added to the topic
+func (s *service) checkTopic(ctx context.Context, topicID uint) error {
+	archived, err := s.repo.IsTopicArchived(ctx, topicID)
+	if err != nil {
+		return err
+	}
+	if archived {
+		return ErrTopicArchived
+	}
+	return nil
+}
+
 func (s *service) DeleteContent(ctx context.Context, id uint) error {
 	return s.repo.Delete(ctx, id)
 }
 
+func (s *service) RestoreContent(ctx context.Context, id uint) error {
+	return s.repo.Restore(ctx, id)
+}
+
 func (s *service) PublishContent(ctx context.Context, id uint) error {
 	return s.repo.Publish(ctx, id)
 }
@@ -42,6 +80,35 @@ func (s *service) GetPendingNotifications(ctx context.Context) ([]uint, error) {
 	return s.repo.GetPendingNotifications(ctx)
 }
 
+// GetPendingNotificationsByPriority returns the pending content of one priority, oldest first
+func (s *service) GetPendingNotificationsByPriority(ctx context.Context, priority string) ([]uint, error) {
+	return s.repo.GetPendingNotificationsByPriority(ctx, priority)
+}
+
 func (s *service) MarkNotificationsSent(ctx context.Context, id uint) error {
 	return s.repo.MarkNotificationsSent(ctx, id)
 }
+
+// GetTranslations returns the content's translations by locale. Translations aren't organization scoped
+// themselves, so the content is looked up first.
+func (s *service) GetTranslations(ctx context.Context, contentID uint) ([]*Translation, error) {
+	if _, err := s.repo.GetByID(ctx, contentID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetTranslations(ctx, contentID)
+}
+
+// SetTranslation creates or replaces the content's translation into translation.Locale
+func (s *service) SetTranslation(ctx context.Context, translation *Translation) error {
+	if _, err := s.repo.GetByID(ctx, translation.ContentID); err != nil {
+		return err
+	}
+	return s.repo.UpsertTranslation(ctx, translation)
+}
+
+func (s *service) DeleteTranslation(ctx context.Context, contentID uint, locale string) (*Translation, error) {
+	if _, err := s.repo.GetByID(ctx, contentID); err != nil {
+		return nil, err
+	}
+	return s.repo.DeleteTranslation(ctx, contentID, locale)
+}
@@ -0,0 +1,48 @@
+package content
+
+import (
+	"errors"
+
+	"newsletter-service/internal/daos"
+	"newsletter-service/internal/locale"
+)
+
+// Translation is a content's title, body and preview text in another locale
+type Translation = daos.ContentTranslation
+
+// ErrTranslationNotFound is returned for a content that exists but has no translation into the locale
+var ErrTranslationNotFound = errors.New("translation not found")
+
+// Localize returns c as a reader preferring preferred should receive it: with the title, body and preview
+// text of the best matching translation, or c itself when the content's own locale matches as well or
+// nothing matches. Translations into the content's own locale never win over it.
+func Localize(c *Content, translations []*Translation, preferred string) *Content {
+	if preferred == "" || len(translations) == 0 {
+		return c
+	}
+
+	byLocale := make(map[string]*Translation, len(translations))
+	available := make([]string, 0, len(translations)+1)
+	if c.Locale != "" {
+		available = append(available, c.Locale)
+	}
+	for _, t := range translations {
+		if _, ok := byLocale[t.Locale]; !ok {
+			byLocale[t.Locale] = t
+			available = append(available, t.Locale)
+		}
+	}
+
+	match, ok := locale.Match(preferred, available)
+	if !ok || match == c.Locale {
+		return c
+	}
+	t := byLocale[match]
+
+	localized := *c
+	localized.Title = t.Title
+	localized.Body = t.Body
+	localized.PreviewText = t.PreviewText
+	localized.Locale = t.Locale
+	return &localized
+}
@@ -0,0 +1,12 @@
+package emailcheck
+
+// Core contains shared business logic for email check domain
+type Core struct {
+	service Service
+}
+
+func NewCore(service Service) *Core {
+	return &Core{
+		service: service,
+	}
+}
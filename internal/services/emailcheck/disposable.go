@@ -0,0 +1,67 @@
+package emailcheck
+
+import "strings"
+
+// disposableDomains are common throwaway mailbox providers. Subdomains match too.
+var disposableDomains = []string{
+	"10minutemail.com",
+	"burnermail.io",
+	"dispostable.com",
+	"emailondeck.com",
+	"fakeinbox.com",
+	"getnada.com",
+	"grr.la",
+	"guerrillamail.com",
+	"guerrillamail.net",
+	"guerrillamail.org",
+	"mailcatch.com",
+	"maildrop.cc",
+	"mailinator.com",
+	"mailnesia.com",
+	"mintemail.com",
+	"moakt.com",
+	"mohmal.com",
+	"mytemp.email",
+	"sharklasers.com",
+	"spamgourmet.com",
+	"tempail.com",
+	"tempinbox.com",
+	"temp-mail.org",
+	"tempmail.com",
+	"tempmailo.com",
+	"throwawaymail.com",
+	"trashmail.com",
+	"yopmail.com",
+}
+
+// blocklist is a set of disposable domains
+type blocklist map[string]struct{}
+
+// newBlocklist combines the built-in list with comma-separated extra domains
+func newBlocklist(extra string) blocklist {
+	list := make(blocklist, len(disposableDomains))
+	for _, domain := range disposableDomains {
+		list[domain] = struct{}{}
+	}
+	for _, domain := range strings.Split(extra, ",") {
+		domain = strings.ToLower(strings.TrimSpace(domain))
+		if domain != "" {
+			list[domain] = struct{}{}
+		}
+	}
+	return list
+}
+
+// contains reports whether domain or one of its parent domains is listed
+func (b blocklist) contains(domain string) bool {
+	for {
+		if _, ok := b[domain]; ok {
+			return true
+		}
+		dot := strings.IndexByte(domain, '.')
+		if dot < 0 {
+			return false
+		}
+		domain = domain[dot+1:]
+	}
+}
@@ -0,0 +1,16 @@
+package emailcheck
+
+import (
+	"context"
+	"time"
+)
+
+type Repository interface {
+	GetPending(ctx context.Context, limit int) ([]*Subscriber, error)
+	UpdateStatus(ctx context.Context, id uint, status string, checkedAt time.Time, deactivate bool) error
+}
+
+type Service interface {
+	Check(ctx context.Context, email string) (*Result, error)
+	VerifyPending(ctx context.Context) (int, error)
+}
@@ -0,0 +1,37 @@
+package emailcheck
+
+import (
+	"errors"
+	"time"
+
+	"newsletter-service/internal/daos"
+)
+
+// Type alias for backward compatibility
+type Subscriber = daos.Subscriber
+
+// Email statuses stored on subscribers
+const (
+	StatusUnverified = "unverified" // Not checked (created before checks existed, or the lookup was inconclusive)
+	StatusPending    = "pending"    // Waiting for the worker to verify MX records
+	StatusValid      = "valid"
+	StatusRisky      = "risky"   // Accepted but flagged, e.g. a disposable domain when disposable_action is "flag"
+	StatusInvalid    = "invalid" // Undeliverable; rejected on create, deactivated when found by the worker
+)
+
+// Actions for addresses on disposable domains
+const (
+	DisposableActionReject = "reject"
+	DisposableActionFlag   = "flag"
+)
+
+// ErrUndeliverable is returned (wrapped with the reason) for addresses that must be rejected
+var ErrUndeliverable = errors.New("undeliverable email address")
+
+// Result is the outcome of checking one address
+type Result struct {
+	Email     string     `json:"email"` // Normalized address
+	Status    string     `json:"status"`
+	Reason    string     `json:"reason,omitempty"`
+	CheckedAt *time.Time `json:"checked_at,omitempty"` // Nil when the domain was not resolved
+}
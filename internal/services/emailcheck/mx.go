@@ -0,0 +1,84 @@
+package emailcheck
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"time"
+)
+
+// mxCache resolves whether a domain accepts mail and caches conclusive answers
+type mxCache struct {
+	resolver *net.Resolver
+	timeout  time.Duration
+	ttl      time.Duration
+
+	mu      sync.Mutex
+	entries map[string]mxEntry
+}
+
+type mxEntry struct {
+	deliverable bool
+	expiresAt   time.Time
+}
+
+func newMXCache(timeout, ttl time.Duration) *mxCache {
+	return &mxCache{
+		resolver: net.DefaultResolver,
+		timeout:  timeout,
+		ttl:      ttl,
+		entries:  make(map[string]mxEntry),
+	}
+}
+
+// lookup reports whether domain has a usable MX record, or an A/AAAA record as the implicit MX.
+// ok is false when DNS could not give a definite answer (timeouts, server failures).
+func (c *mxCache) lookup(ctx context.Context, domain string) (deliverable, ok bool) {
+	now := time.Now()
+	c.mu.Lock()
+	entry, found := c.entries[domain]
+	c.mu.Unlock()
+	if found && now.Before(entry.expiresAt) {
+		return entry.deliverable, true
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	deliverable, ok = c.resolve(ctx, domain)
+	if ok {
+		c.mu.Lock()
+		c.entries[domain] = mxEntry{deliverable: deliverable, expiresAt: now.Add(c.ttl)}
+		c.mu.Unlock()
+	}
+	return deliverable, ok
+}
+
+func (c *mxCache) resolve(ctx context.Context, domain string) (deliverable, ok bool) {
+	records, err := c.resolver.LookupMX(ctx, domain)
+	if err == nil && len(records) > 0 {
+		// A single "." record is a null MX: the domain explicitly accepts no mail (RFC 7505)
+		if len(records) == 1 && records[0].Host == "." {
+			return false, true
+		}
+		return true, true
+	}
+	if err != nil && !isNotFound(err) {
+		return false, false
+	}
+
+	addrs, err := c.resolver.LookupIPAddr(ctx, domain)
+	if err != nil {
+		if isNotFound(err) {
+			return false, true
+		}
+		return false, false
+	}
+	return len(addrs) > 0, true
+}
+
+func isNotFound(err error) bool {
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr) && dnsErr.IsNotFound
+}
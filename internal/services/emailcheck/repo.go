@@ -0,0 +1,33 @@
+package emailcheck
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) GetPending(ctx context.Context, limit int) ([]*Subscriber, error) {
+	var subscribers []*Subscriber
+	err := r.db.WithContext(ctx).Where("email_status = ?", StatusPending).Order("id").Limit(limit).Find(&subscribers).Error
+	return subscribers, err
+}
+
+func (r *repository) UpdateStatus(ctx context.Context, id uint, status string, checkedAt time.Time, deactivate bool) error {
+	updates := map[string]interface{}{
+		"email_status":     status,
+		"email_checked_at": checkedAt,
+	}
+	if deactivate {
+		updates["is_active"] = false
+	}
+	return r.db.WithContext(ctx).Model(&Subscriber{}).Where("id = ?", id).Updates(updates).Error
+}
@@ -0,0 +1,138 @@
+package emailcheck
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/mail"
+	"strings"
+	"time"
+
+	"newsletter-service/internal/config"
+)
+
+// verifyBatchSize is how many pending addresses the worker verifies per run
+const verifyBatchSize = 100
+
+type service struct {
+	repo      Repository
+	cfg       config.EmailCheckConfig
+	blocklist blocklist
+	mx        *mxCache
+}
+
+// NewService creates an email check service, filling in defaults for unset config values
+func NewService(repo Repository, cfg config.EmailCheckConfig) Service {
+	if cfg.MXTimeout <= 0 {
+		cfg.MXTimeout = 3 * time.Second
+	}
+	if cfg.MXCacheTTL <= 0 {
+		cfg.MXCacheTTL = time.Hour
+	}
+	if cfg.DisposableAction == "" {
+		cfg.DisposableAction = DisposableActionReject
+	}
+
+	return &service{
+		repo:      repo,
+		cfg:       cfg,
+		blocklist: newBlocklist(cfg.BlockedDomains),
+		mx:        newMXCache(cfg.MXTimeout, cfg.MXCacheTTL),
+	}
+}
+
+// Check normalizes an address and classifies it. Syntax errors, null or missing MX records and
+// (unless configured to flag) disposable domains return ErrUndeliverable. With async checks
+// enabled the MX lookup is skipped and the status is pending.
+func (s *service) Check(ctx context.Context, email string) (*Result, error) {
+	normalized, domain, err := Normalize(email)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUndeliverable, err)
+	}
+	result := &Result{Email: normalized, Status: StatusUnverified}
+
+	if s.blocklist.contains(domain) {
+		if s.cfg.DisposableAction != DisposableActionFlag {
+			return nil, fmt.Errorf("%w: disposable domain %s", ErrUndeliverable, domain)
+		}
+		result.Status = StatusRisky
+		result.Reason = "disposable domain"
+	}
+
+	if !s.cfg.CheckMX || result.Status == StatusRisky {
+		return result, nil
+	}
+	if s.cfg.Async {
+		result.Status = StatusPending
+		return result, nil
+	}
+
+	deliverable, ok := s.mx.lookup(ctx, domain)
+	if !ok {
+		return result, nil
+	}
+	if !deliverable {
+		return nil, fmt.Errorf("%w: domain %s does not accept mail", ErrUndeliverable, domain)
+	}
+	now := time.Now()
+	result.Status = StatusValid
+	result.CheckedAt = &now
+	return result, nil
+}
+
+// VerifyPending resolves MX records for subscribers created while async checks were enabled.
+// Undeliverable subscribers are marked invalid and deactivated rather than deleted.
+func (s *service) VerifyPending(ctx context.Context) (int, error) {
+	subscribers, err := s.repo.GetPending(ctx, verifyBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	verified := 0
+	for _, subscriber := range subscribers {
+		status := StatusUnverified
+		_, domain, err := Normalize(subscriber.Email)
+		if err != nil {
+			status = StatusInvalid
+		} else if deliverable, ok := s.mx.lookup(ctx, domain); ok {
+			status = StatusValid
+			if !deliverable {
+				status = StatusInvalid
+			}
+		}
+
+		if err := s.repo.UpdateStatus(ctx, subscriber.ID, status, time.Now(), status == StatusInvalid); err != nil {
+			log.Printf("Failed to update email status for subscriber %d: %v", subscriber.ID, err)
+			continue
+		}
+		verified++
+	}
+	return verified, nil
+}
+
+// Normalize trims and lowercases an address and checks its syntax. Display names
+// ("Jane <jane@example.com>") are rejected. It returns the address and its domain.
+func Normalize(email string) (string, string, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Name != "" || addr.Address != email {
+		return "", "", fmt.Errorf("invalid address syntax")
+	}
+
+	at := strings.LastIndexByte(email, '@')
+	local, domain := email[:at], email[at+1:]
+	if len(local) > 64 {
+		return "", "", fmt.Errorf("local part longer than 64 characters")
+	}
+	if !strings.Contains(domain, ".") || strings.HasPrefix(domain, "[") {
+		return "", "", fmt.Errorf("domain must be a fully qualified host name")
+	}
+	for _, label := range strings.Split(domain, ".") {
+		if label == "" || len(label) > 63 || strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+			return "", "", fmt.Errorf("invalid domain %s", domain)
+		}
+	}
+
+	return local + "@" + domain, domain, nil
+}
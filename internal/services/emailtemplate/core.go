@@ -0,0 +1,100 @@
+package emailtemplate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"newsletter-service/internal/config"
+	"newsletter-service/internal/providers/templates"
+	"newsletter-service/internal/services/asset"
+)
+
+// maxTemplateSize bounds a template read from a file or bucket
+const maxTemplateSize = 1 << 20
+
+// NewStore creates the store for [templates] source, or nil for the built-in templates
+func NewStore(cfg config.TemplatesConfig, db *gorm.DB) (Store, error) {
+	switch cfg.Source {
+	case "", config.TemplateSourceBuiltin:
+		return nil, nil
+	case config.TemplateSourceFile:
+		dir := cfg.Dir
+		if dir == "" {
+			dir = "templates"
+		}
+		files, err := asset.NewLocalStore(dir)
+		if err != nil {
+			return nil, err
+		}
+		return &objectStore{objects: files}, nil
+	case config.TemplateSourceS3:
+		bucket, err := asset.NewS3Store(cfg.S3)
+		if err != nil {
+			return nil, err
+		}
+		return &objectStore{objects: bucket}, nil
+	case config.TemplateSourceDatabase:
+		return &databaseStore{repo: NewRepository(db)}, nil
+	default:
+		return nil, fmt.Errorf("unknown template source %q", cfg.Source)
+	}
+}
+
+// objectStore keeps each template as a file or object named after it, through the asset storage backends
+type objectStore struct {
+	objects asset.Store
+}
+
+func (s *objectStore) Load(ctx context.Context, name string) (string, error) {
+	r, err := s.objects.Open(ctx, name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", templates.ErrTemplateNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	defer r.Close()
+
+	body, err := io.ReadAll(io.LimitReader(r, maxTemplateSize+1))
+	if err != nil {
+		return "", err
+	}
+	if len(body) > maxTemplateSize {
+		return "", fmt.Errorf("template %s is larger than %d bytes", name, maxTemplateSize)
+	}
+	return string(body), nil
+}
+
+func (s *objectStore) Save(ctx context.Context, name, body string) error {
+	contentType := "text/plain; charset=utf-8"
+	if strings.HasSuffix(name, ".html") {
+		contentType = "text/html; charset=utf-8"
+	}
+	return s.objects.Put(ctx, name, contentType, []byte(body))
+}
+
+// databaseStore keeps templates in the email_templates table
+type databaseStore struct {
+	repo Repository
+}
+
+func (s *databaseStore) Load(ctx context.Context, name string) (string, error) {
+	template, err := s.repo.GetByName(ctx, name)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", templates.ErrTemplateNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	return template.Body, nil
+}
+
+func (s *databaseStore) Save(ctx context.Context, name, body string) error {
+	return s.repo.Save(ctx, &EmailTemplate{Name: name, Body: body})
+}
@@ -0,0 +1,29 @@
+package emailtemplate
+
+import (
+	"context"
+
+	"newsletter-service/internal/providers/templates"
+)
+
+type Repository interface {
+	GetByName(ctx context.Context, name string) (*EmailTemplate, error)
+	Save(ctx context.Context, template *EmailTemplate) error
+}
+
+// Store reads and writes template text by name. Load returns templates.ErrTemplateNotFound for a template
+// the store doesn't have.
+type Store interface {
+	templates.Source
+	Save(ctx context.Context, name, body string) error
+}
+
+type Service interface {
+	// Start loads the templates and keeps them refreshed until ctx ends
+	Start(ctx context.Context)
+	GetStatus() Status
+	GetTemplate(ctx context.Context, name string) (*Template, error)
+	// SaveTemplate checks the template renders, stores it and reloads the templates of this process
+	SaveTemplate(ctx context.Context, name, body string) (Status, error)
+	Reload(ctx context.Context) (Status, error)
+}
@@ -0,0 +1,32 @@
+package emailtemplate
+
+import (
+	"errors"
+	"time"
+
+	"newsletter-service/internal/daos"
+)
+
+// Type alias for backward compatibility
+type EmailTemplate = daos.EmailTemplate
+
+var (
+	// ErrUnknownTemplate is returned for a name other than email.html or email.txt
+	ErrUnknownTemplate = errors.New("unknown email template")
+	// ErrReadOnlySource is returned when saving while templates come from the built-in source
+	ErrReadOnlySource = errors.New("built-in templates can't be edited; set [templates] source to file, database or s3")
+)
+
+// Status describes where the templates in use were loaded from
+type Status struct {
+	Source    string            `json:"source"`
+	LoadedAt  *time.Time        `json:"loaded_at"` // Nil while the built-in templates are in use
+	Templates map[string]string `json:"templates"` // "builtin" or "source", keyed by template name
+}
+
+// Template is a template's text as stored in the source, or the built-in text where the source has none
+type Template struct {
+	Name    string `json:"name"`
+	Body    string `json:"body"`
+	Builtin bool   `json:"builtin"`
+}
@@ -0,0 +1,86 @@
+package emailtemplate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"newsletter-service/internal/config"
+	"newsletter-service/internal/providers/templates"
+)
+
+type service struct {
+	store  Store
+	source string
+	cfg    config.TemplatesConfig
+}
+
+// NewService creates the template service; a nil store keeps the built-in templates
+func NewService(store Store, cfg config.TemplatesConfig) Service {
+	source := cfg.Source
+	if source == "" {
+		source = config.TemplateSourceBuiltin
+	}
+	return &service{store: store, source: source, cfg: cfg}
+}
+
+func (s *service) Start(ctx context.Context) {
+	if s.store == nil {
+		return
+	}
+	templates.Use(ctx, s.store, s.cfg.RefreshInterval)
+}
+
+func (s *service) GetStatus() Status {
+	current := templates.CurrentStatus()
+	status := Status{Source: s.source, Templates: current.Origins}
+	if !current.LoadedAt.IsZero() {
+		status.LoadedAt = &current.LoadedAt
+	}
+	return status
+}
+
+func (s *service) GetTemplate(ctx context.Context, name string) (*Template, error) {
+	if !slices.Contains(templates.TemplateNames, name) {
+		return nil, ErrUnknownTemplate
+	}
+	if s.store != nil {
+		body, err := s.store.Load(ctx, name)
+		if err == nil {
+			return &Template{Name: name, Body: body}, nil
+		}
+		if !errors.Is(err, templates.ErrTemplateNotFound) {
+			return nil, fmt.Errorf("failed to load template %s: %w", name, err)
+		}
+	}
+	body, err := templates.Builtin(name)
+	if err != nil {
+		return nil, err
+	}
+	return &Template{Name: name, Body: body, Builtin: true}, nil
+}
+
+func (s *service) SaveTemplate(ctx context.Context, name, body string) (Status, error) {
+	if !slices.Contains(templates.TemplateNames, name) {
+		return Status{}, ErrUnknownTemplate
+	}
+	if s.store == nil {
+		return Status{}, ErrReadOnlySource
+	}
+	if err := templates.Validate(name, body); err != nil {
+		return Status{}, err
+	}
+	if err := s.store.Save(ctx, name, body); err != nil {
+		return Status{}, fmt.Errorf("failed to save template %s: %w", name, err)
+	}
+	return s.Reload(ctx)
+}
+
+// Reload re-reads the templates of this process; other replicas pick changes up on their next refresh
+func (s *service) Reload(ctx context.Context) (Status, error) {
+	if _, err := templates.Reload(ctx); err != nil {
+		return s.GetStatus(), err
+	}
+	return s.GetStatus(), nil
+}
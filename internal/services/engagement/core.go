@@ -0,0 +1,12 @@
+package engagement
+
+// Core contains shared business logic for engagement domain
+type Core struct {
+	service Service
+}
+
+func NewCore(service Service) *Core {
+	return &Core{
+		service: service,
+	}
+}
@@ -0,0 +1,16 @@
+package engagement
+
+import (
+	"context"
+)
+
+type Repository interface {
+	GetActivity(ctx context.Context, afterID uint, limit, window int) ([]Activity, error)
+	Update(ctx context.Context, id uint, updates map[string]interface{}) error
+	GetSegmentWithPagination(ctx context.Context, segment Segment, offset, limit int) ([]*Subscriber, int64, error)
+}
+
+type Service interface {
+	Run(ctx context.Context) (*RunResult, error)
+	GetSegmentWithPagination(ctx context.Context, segment Segment, offset, limit int) ([]*Subscriber, int64, error)
+}
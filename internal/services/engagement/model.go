@@ -0,0 +1,38 @@
+package engagement
+
+import (
+	"time"
+
+	"newsletter-service/internal/daos"
+)
+
+// Type alias for backward compatibility
+type Subscriber = daos.Subscriber
+
+// Activity is a subscriber's recent delivery history, aggregated from email logs
+type Activity struct {
+	SubscriberID    uint
+	IsActive        bool
+	EngagementScore float64
+	LastEngagedAt   *time.Time
+	SunsetAt        *time.Time
+	Sends           int64      // Sends within the score window
+	Opens           int64      // Opened sends within the score window
+	LastOpenedAt    *time.Time // Most recent open across all sends
+	UnengagedSends  int64      // Sends since the last open (all sends if never opened)
+}
+
+// Segment filters subscribers by engagement
+type Segment struct {
+	MinScore *float64
+	MaxScore *float64
+	Sunset   *bool // true: only subscribers flagged by the sunset policy, false: only those not flagged
+	Signup   daos.SignupFilter
+}
+
+// RunResult summarises one scoring pass
+type RunResult struct {
+	Scored   int `json:"scored"`
+	Sunset   int `json:"sunset"`   // Newly flagged or paused
+	Restored int `json:"restored"` // Flag cleared after the subscriber engaged again
+}
@@ -0,0 +1,75 @@
+package engagement
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"newsletter-service/internal/constants"
+)
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+// GetActivity aggregates the delivery history of up to limit subscribers with an ID above afterID
+func (r *repository) GetActivity(ctx context.Context, afterID uint, limit, window int) ([]Activity, error) {
+	var activity []Activity
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT s.id AS subscriber_id, s.is_active, s.engagement_score, s.last_engaged_at, s.sunset_at,
+		       COUNT(l.subscriber_id) FILTER (WHERE l.rn <= ?) AS sends,
+		       COUNT(l.subscriber_id) FILTER (WHERE l.rn <= ? AND l.opened_at IS NOT NULL) AS opens,
+		       MAX(l.opened_at) AS last_opened_at,
+		       COALESCE(MIN(l.rn) FILTER (WHERE l.opened_at IS NOT NULL) - 1, COUNT(l.subscriber_id)) AS unengaged_sends
+		FROM subscribers s
+		LEFT JOIN (
+			SELECT subscriber_id, opened_at,
+			       ROW_NUMBER() OVER (PARTITION BY subscriber_id ORDER BY sent_at DESC, id DESC) AS rn
+			FROM email_logs
+			WHERE status = ? AND deleted_at IS NULL AND subscriber_id IN (
+				SELECT id FROM subscribers WHERE id > ? AND deleted_at IS NULL ORDER BY id LIMIT ?
+			)
+		) l ON l.subscriber_id = s.id
+		WHERE s.id > ? AND s.deleted_at IS NULL
+		GROUP BY s.id
+		ORDER BY s.id
+		LIMIT ?`,
+		window, window, constants.StatusSent, afterID, limit, afterID, limit).Scan(&activity).Error
+	return activity, err
+}
+
+// Update writes engagement columns without touching updated_at
+func (r *repository) Update(ctx context.Context, id uint, updates map[string]interface{}) error {
+	return r.db.WithContext(ctx).Model(&Subscriber{}).Where("id = ?", id).UpdateColumns(updates).Error
+}
+
+func (r *repository) GetSegmentWithPagination(ctx context.Context, segment Segment, offset, limit int) ([]*Subscriber, int64, error) {
+	query := r.db.WithContext(ctx).Model(&Subscriber{})
+	if segment.MinScore != nil {
+		query = query.Where("engagement_score >= ?", *segment.MinScore)
+	}
+	if segment.MaxScore != nil {
+		query = query.Where("engagement_score <= ?", *segment.MaxScore)
+	}
+	if segment.Sunset != nil {
+		if *segment.Sunset {
+			query = query.Where("sunset_at IS NOT NULL")
+		} else {
+			query = query.Where("sunset_at IS NULL")
+		}
+	}
+	query = segment.Signup.Apply(query)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var subscribers []*Subscriber
+	err := query.Order("engagement_score DESC, id").Offset(offset).Limit(limit).Find(&subscribers).Error
+	return subscribers, total, err
+}
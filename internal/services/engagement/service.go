@@ -0,0 +1,120 @@
+package engagement
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"newsletter-service/internal/config"
+)
+
+// batchSize is how many subscribers are scored per query
+const batchSize = 500
+
+// Weights of the two score components; they add up to 1
+const (
+	openRateWeight = 0.7
+	recencyWeight  = 0.3
+)
+
+type service struct {
+	repo Repository
+	cfg  config.EngagementConfig
+}
+
+// NewService creates an engagement service, filling in defaults for unset config values
+func NewService(repo Repository, cfg config.EngagementConfig) Service {
+	if cfg.ScoreWindow <= 0 {
+		cfg.ScoreWindow = 10
+	}
+	if cfg.RecencyHalfLife <= 0 {
+		cfg.RecencyHalfLife = 30 * 24 * time.Hour
+	}
+	if cfg.SunsetAction == "" {
+		cfg.SunsetAction = config.SunsetActionFlag
+	}
+	return &service{repo: repo, cfg: cfg}
+}
+
+// Run recalculates every subscriber's engagement score and applies the sunset policy.
+// Subscribers who have not been sent anything score 0 and are never sunset.
+func (s *service) Run(ctx context.Context) (*RunResult, error) {
+	result := &RunResult{}
+	now := time.Now()
+
+	var afterID uint
+	for {
+		batch, err := s.repo.GetActivity(ctx, afterID, batchSize, s.cfg.ScoreWindow)
+		if err != nil {
+			return result, err
+		}
+		if len(batch) == 0 {
+			return result, nil
+		}
+
+		for _, activity := range batch {
+			updates := s.evaluate(activity, now, result)
+			if len(updates) > 0 {
+				if err := s.repo.Update(ctx, activity.SubscriberID, updates); err != nil {
+					return result, err
+				}
+			}
+			result.Scored++
+		}
+		afterID = batch[len(batch)-1].SubscriberID
+	}
+}
+
+// evaluate returns the columns that changed for one subscriber
+func (s *service) evaluate(activity Activity, now time.Time, result *RunResult) map[string]interface{} {
+	updates := make(map[string]interface{})
+
+	score := Score(activity.Sends, activity.Opens, activity.LastOpenedAt, now, s.cfg.RecencyHalfLife)
+	if score != activity.EngagementScore {
+		updates["engagement_score"] = score
+	}
+	if activity.LastOpenedAt != nil && (activity.LastEngagedAt == nil || !activity.LastOpenedAt.Equal(*activity.LastEngagedAt)) {
+		updates["last_engaged_at"] = *activity.LastOpenedAt
+	}
+
+	threshold := int64(s.cfg.SunsetAfterSends)
+	disengaged := threshold > 0 && activity.UnengagedSends >= threshold
+	switch {
+	case disengaged && activity.SunsetAt == nil && activity.IsActive:
+		updates["sunset_at"] = now
+		if s.cfg.SunsetAction == config.SunsetActionPause {
+			updates["is_active"] = false
+		}
+		result.Sunset++
+	case !disengaged && activity.SunsetAt != nil && activity.IsActive:
+		updates["sunset_at"] = nil
+		result.Restored++
+	}
+
+	return updates
+}
+
+// Score rates engagement from 0 to 100: the open rate over recent sends, plus a recency
+// component that halves every halfLife since the last open. The result is rounded to 2 decimals.
+func Score(sends, opens int64, lastOpenedAt *time.Time, now time.Time, halfLife time.Duration) float64 {
+	if sends == 0 {
+		return 0
+	}
+
+	openRate := float64(opens) / float64(sends)
+	recency := 0.0
+	if lastOpenedAt != nil {
+		elapsed := now.Sub(*lastOpenedAt)
+		if elapsed < 0 {
+			elapsed = 0
+		}
+		recency = math.Pow(0.5, float64(elapsed)/float64(halfLife))
+	}
+
+	score := 100 * (openRateWeight*openRate + recencyWeight*recency)
+	return math.Round(score*100) / 100
+}
+
+func (s *service) GetSegmentWithPagination(ctx context.Context, segment Segment, offset, limit int) ([]*Subscriber, int64, error) {
+	return s.repo.GetSegmentWithPagination(ctx, segment, offset, limit)
+}
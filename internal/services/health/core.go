@@ -0,0 +1,12 @@
+package health
+
+// Core contains shared business logic for health domain
+type Core struct {
+	service Service
+}
+
+func NewCore(service Service) *Core {
+	return &Core{
+		service: service,
+	}
+}
@@ -0,0 +1,13 @@
+package health
+
+import "context"
+
+type Repository interface {
+	Ping(ctx context.Context) error
+	AppliedMigrationVersion(ctx context.Context) (int64, error)
+}
+
+type Service interface {
+	Liveness(ctx context.Context) *Report
+	Readiness(ctx context.Context) *Report
+}
@@ -0,0 +1,34 @@
+package health
+
+// Dependency names reported by the readiness check
+const (
+	CheckPostgres   = "postgres"
+	CheckRedis      = "redis"
+	CheckMigrations = "migrations"
+	CheckProviders  = "providers"
+)
+
+// Check statuses
+const (
+	StatusHealthy   = "healthy"
+	StatusUnhealthy = "unhealthy"
+	StatusDisabled  = "disabled"
+)
+
+// CheckResult is the outcome of a single dependency check
+type CheckResult struct {
+	Status    string `json:"status"`
+	Message   string `json:"message,omitempty"`
+	LatencyMs int64  `json:"latency_ms"`
+}
+
+// Report aggregates dependency checks; Status is unhealthy if any check failed
+type Report struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks,omitempty"`
+}
+
+// Healthy reports whether every check passed or was disabled
+func (r *Report) Healthy() bool {
+	return r.Status == StatusHealthy
+}
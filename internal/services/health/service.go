@@ -0,0 +1,169 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"newsletter-service/internal/config"
+	"newsletter-service/internal/constants"
+	"newsletter-service/internal/providers"
+)
+
+// checkTimeout bounds each dependency check so probes answer promptly
+const checkTimeout = 2 * time.Second
+
+type service struct {
+	repo         Repository
+	redisClient  redis.UniversalClient
+	cfg          *config.Config
+	migrationDir string
+}
+
+// NewService creates a health service. redisClient may be nil when Redis is not in use.
+func NewService(repo Repository, redisClient redis.UniversalClient, cfg *config.Config) Service {
+	return &service{
+		repo:         repo,
+		redisClient:  redisClient,
+		cfg:          cfg,
+		migrationDir: constants.MigrationDir,
+	}
+}
+
+// Liveness only reports that the process is serving requests; dependency
+// failures must not make Kubernetes restart an otherwise healthy pod
+func (s *service) Liveness(ctx context.Context) *Report {
+	return &Report{Status: StatusHealthy}
+}
+
+// Readiness checks every dependency the service needs to handle traffic
+func (s *service) Readiness(ctx context.Context) *Report {
+	checks := map[string]func(context.Context) CheckResult{
+		CheckPostgres:   s.checkPostgres,
+		CheckRedis:      s.checkRedis,
+		CheckMigrations: s.checkMigrations,
+		CheckProviders:  s.checkProviders,
+	}
+
+	report := &Report{Status: StatusHealthy, Checks: make(map[string]CheckResult, len(checks))}
+	for name, check := range checks {
+		checkCtx, cancel := context.WithTimeout(ctx, checkTimeout)
+		start := time.Now()
+		result := check(checkCtx)
+		result.LatencyMs = time.Since(start).Milliseconds()
+		cancel()
+
+		report.Checks[name] = result
+		if result.Status == StatusUnhealthy {
+			report.Status = StatusUnhealthy
+		}
+	}
+	return report
+}
+
+func (s *service) checkPostgres(ctx context.Context) CheckResult {
+	if err := s.repo.Ping(ctx); err != nil {
+		return unhealthy(err.Error())
+	}
+	return CheckResult{Status: StatusHealthy}
+}
+
+func (s *service) checkRedis(ctx context.Context) CheckResult {
+	if s.redisClient == nil {
+		return CheckResult{Status: StatusDisabled, Message: "using in-memory storage"}
+	}
+	if err := s.redisClient.Ping(ctx).Err(); err != nil {
+		return unhealthy(err.Error())
+	}
+	return CheckResult{Status: StatusHealthy}
+}
+
+func (s *service) checkMigrations(ctx context.Context) CheckResult {
+	applied, err := s.repo.AppliedMigrationVersion(ctx)
+	if err != nil {
+		if s.cfg.Database.AutoMigrate {
+			return CheckResult{Status: StatusHealthy, Message: "schema managed by auto-migrate"}
+		}
+		return unhealthy(fmt.Sprintf("failed to read migration version: %v", err))
+	}
+
+	latest, err := latestMigrationVersion(s.migrationDir)
+	if err != nil {
+		// Migration files are not shipped with every image; the applied version is still reported
+		return CheckResult{Status: StatusHealthy, Message: fmt.Sprintf("applied version %d", applied)}
+	}
+
+	if applied < latest {
+		return unhealthy(fmt.Sprintf("pending migrations: applied version %d, latest %d", applied, latest))
+	}
+	return CheckResult{Status: StatusHealthy, Message: fmt.Sprintf("applied version %d", applied)}
+}
+
+func (s *service) checkProviders(ctx context.Context) CheckResult {
+	cfg := s.cfg.Providers
+	if len(cfg.Enabled) == 0 {
+		return CheckResult{Status: StatusDisabled, Message: "no email providers enabled"}
+	}
+
+	var problems []string
+	for _, name := range cfg.Enabled {
+		var provider providers.EmailProviderInterface
+		if name == providers.LocalProviderName {
+			provider = providers.NewLocalProvider(&cfg.Local)
+		} else if smtpConfig, exists := cfg.SMTP[name]; exists {
+			provider = providers.NewDynamicSMTPProvider(name, &smtpConfig)
+		} else if apiConfig, exists := cfg.API[name]; exists {
+			provider = providers.NewDynamicAPIProvider(name, &apiConfig)
+		} else {
+			problems = append(problems, fmt.Sprintf("%s: not configured", name))
+			continue
+		}
+
+		if err := provider.ValidateConfig(); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", name, err))
+		}
+	}
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return unhealthy(strings.Join(problems, "; "))
+	}
+	return CheckResult{Status: StatusHealthy, Message: fmt.Sprintf("%d providers configured", len(cfg.Enabled))}
+}
+
+// latestMigrationVersion returns the highest goose version found in dir
+func latestMigrationVersion(dir string) (int64, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, err
+	}
+
+	var latest int64
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sql") {
+			continue
+		}
+		prefix, _, found := strings.Cut(entry.Name(), "_")
+		if !found {
+			continue
+		}
+		version, err := strconv.ParseInt(prefix, 10, 64)
+		if err != nil {
+			continue
+		}
+		if version > latest {
+			latest = version
+		}
+	}
+	return latest, nil
+}
+
+func unhealthy(message string) CheckResult {
+	return CheckResult{Status: StatusUnhealthy, Message: message}
+}
@@ -0,0 +1,12 @@
+package landing
+
+// Core contains shared business logic for landing domain
+type Core struct {
+	service Service
+}
+
+func NewCore(service Service) *Core {
+	return &Core{
+		service: service,
+	}
+}
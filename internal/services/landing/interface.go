@@ -0,0 +1,16 @@
+package landing
+
+import (
+	"context"
+	"io"
+)
+
+type Repository interface {
+	GetRecentIssues(ctx context.Context, topicID uint, limit int) ([]*Content, error)
+}
+
+type Service interface {
+	GetPage(ctx context.Context, slug string) (*Page, error)
+	Subscribe(ctx context.Context, slug string, subscriber *Subscriber) (*Signup, error)
+	Render(w io.Writer, page *Page, form Form) error
+}
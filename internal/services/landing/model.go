@@ -0,0 +1,61 @@
+package landing
+
+import (
+	"errors"
+	"time"
+
+	"newsletter-service/internal/daos"
+	"newsletter-service/internal/providers/templates"
+)
+
+// Type aliases for backward compatibility
+type Topic = daos.Topic
+type Content = daos.Content
+type Subscriber = daos.Subscriber
+
+// ErrNotFound is returned for a slug no live topic has, for archived topics and while [landing_pages] is disabled
+var ErrNotFound = errors.New("landing page not found")
+
+// Page is what a topic's landing page shows
+type Page struct {
+	Topic  *Topic
+	Issues []Issue
+	Theme  templates.Branding // The topic's branding over its organization's, with the template defaults filled in
+}
+
+// Issue is a sent piece of the topic's content
+type Issue struct {
+	Title  string
+	SentAt time.Time
+}
+
+// Form is the subscribe form as a page is rendered with it: the values to show again and how the last post went
+type Form struct {
+	Email      string
+	Name       string
+	Referrer   string   // Page the reader came from, carried through the post
+	UTM        daos.UTM // Tags of the link the reader came through, carried through the post
+	Subscribed bool
+	Error      string
+}
+
+// PageData is what a landing page template executes with. The form posts to Action with the fields email and
+// name, the hidden fields referrer, utm_source, utm_medium and utm_campaign, and a website field that must stay
+// empty: it is hidden from readers, and posts that fill it in are dropped as spam.
+type PageData struct {
+	Name        string
+	Slug        string
+	Description string
+	Issues      []Issue
+	Theme       templates.Branding
+	Action      string
+	Form        Form
+}
+
+// Signup is the outcome of a landing page post. Subscriber and TopicNames are only set when the address was
+// new to the organization; an address already on its list is left as it is.
+type Signup struct {
+	Page       *Page
+	Subscriber *Subscriber
+	TopicNames []string
+}
@@ -0,0 +1,27 @@
+package landing
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+// GetRecentIssues returns the topic's most recently sent content, newest first
+func (r *repository) GetRecentIssues(ctx context.Context, topicID uint, limit int) ([]*Content, error) {
+	var contents []*Content
+	err := r.db.WithContext(ctx).
+		Select("id", "title", "published_at", "notifications_sent_at").
+		Where("topic_id = ? AND is_published = ? AND notifications_sent = ?", topicID, true, true).
+		Order("notifications_sent_at DESC NULLS LAST, id DESC").
+		Limit(limit).
+		Find(&contents).Error
+	return contents, err
+}
@@ -0,0 +1,137 @@
+package landing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+
+	"gorm.io/gorm"
+
+	"newsletter-service/internal/config"
+	"newsletter-service/internal/providers/templates"
+	"newsletter-service/internal/services/subscriber"
+	"newsletter-service/internal/services/topic"
+	"newsletter-service/internal/tenant"
+)
+
+// defaultRecentIssues is how many sent issues a page lists when recent_issues is 0
+const defaultRecentIssues = 5
+
+type service struct {
+	repo              Repository
+	topicService      topic.Service
+	subscriberService subscriber.Service
+	tmpl              *template.Template
+	cfg               config.LandingConfig
+}
+
+// NewService creates the landing page service with the template cfg names, failing when it can't be read or
+// doesn't render
+func NewService(repo Repository, topicService topic.Service, subscriberService subscriber.Service, cfg config.LandingConfig) (Service, error) {
+	tmpl, err := loadTemplate(cfg.TemplateFile)
+	if err != nil {
+		return nil, err
+	}
+	return &service{
+		repo:              repo,
+		topicService:      topicService,
+		subscriberService: subscriberService,
+		tmpl:              tmpl,
+		cfg:               cfg,
+	}, nil
+}
+
+// GetPage gathers the landing page of the topic with the slug. Pages are requested without an organization;
+// the topic's own scopes everything looked up after it.
+func (s *service) GetPage(ctx context.Context, slug string) (*Page, error) {
+	if !s.cfg.Enabled {
+		return nil, ErrNotFound
+	}
+	found, err := s.topicService.GetTopicBySlug(ctx, slug)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && found.ArchivedAt != nil) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	ctx = tenant.WithOrganization(ctx, found.OrganizationID)
+
+	_, branding, err := s.topicService.GetTopicBranding(ctx, found.ID)
+	if err != nil {
+		return nil, err
+	}
+	contents, err := s.repo.GetRecentIssues(ctx, found.ID, s.recentIssues())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recent issues: %w", err)
+	}
+
+	page := &Page{
+		Topic:  found,
+		Issues: make([]Issue, 0, len(contents)),
+		Theme: (&templates.Branding{
+			Name:                branding.FromName,
+			LogoURL:             branding.LogoURL,
+			PrimaryColor:        branding.PrimaryColor,
+			DarkLogoURL:         branding.DarkLogoURL,
+			BackgroundColor:     branding.BackgroundColor,
+			TextColor:           branding.TextColor,
+			FontFamily:          branding.FontFamily,
+			DarkPrimaryColor:    branding.DarkPrimaryColor,
+			DarkBackgroundColor: branding.DarkBackgroundColor,
+			DarkTextColor:       branding.DarkTextColor,
+		}).Resolved(),
+	}
+	for _, content := range contents {
+		issue := Issue{Title: content.Title}
+		if content.NotificationsSentAt != nil {
+			issue.SentAt = *content.NotificationsSentAt
+		} else if content.PublishedAt != nil {
+			issue.SentAt = *content.PublishedAt
+		}
+		page.Issues = append(page.Issues, issue)
+	}
+	return page, nil
+}
+
+// Subscribe adds a reader to the topic with the slug as a new subscriber of its organization. An address
+// already on the organization's list is left alone, so the public form can't change anyone's subscriptions;
+// the reader is thanked either way and can't tell which happened.
+func (s *service) Subscribe(ctx context.Context, slug string, sub *Subscriber) (*Signup, error) {
+	page, err := s.GetPage(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	ctx = tenant.WithOrganization(ctx, page.Topic.OrganizationID)
+
+	sub.OrganizationID = page.Topic.OrganizationID
+	topicNames, err := s.subscriberService.CreateSubscriberWithTopics(ctx, sub, []string{page.Topic.Name})
+	if errors.Is(err, subscriber.ErrDuplicateEmail) {
+		return &Signup{Page: page}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Signup{Page: page, Subscriber: sub, TopicNames: topicNames}, nil
+}
+
+// Render writes a page with the configured template
+func (s *service) Render(w io.Writer, page *Page, form Form) error {
+	return s.tmpl.Execute(w, PageData{
+		Name:        page.Topic.Name,
+		Slug:        page.Topic.Slug,
+		Description: page.Topic.Description,
+		Issues:      page.Issues,
+		Theme:       page.Theme,
+		Action:      "/topics/" + page.Topic.Slug,
+		Form:        form,
+	})
+}
+
+func (s *service) recentIssues() int {
+	if s.cfg.RecentIssues > 0 {
+		return s.cfg.RecentIssues
+	}
+	return defaultRecentIssues
+}
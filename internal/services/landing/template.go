@@ -0,0 +1,207 @@
+package landing
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"time"
+
+	"newsletter-service/internal/providers/templates"
+)
+
+// templateFuncs are the functions a landing page template may call
+var templateFuncs = template.FuncMap{
+	"fontFamily": templates.FontFamilyCSS,
+}
+
+// DefaultTemplate is the landing page used unless [landing_pages] names a template file
+const DefaultTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <meta name="color-scheme" content="light dark">
+    <title>{{.Name}}</title>
+    <style>
+        body {
+            font-family: {{fontFamily .Theme.FontFamily}};
+            line-height: 1.6;
+            color: {{.Theme.TextColor}};
+            max-width: 600px;
+            margin: 50px auto;
+            padding: 20px;
+            background-color: {{.Theme.BackgroundColor}};
+        }
+        .container {
+            background-color: white;
+            padding: 40px;
+            border-radius: 10px;
+            box-shadow: 0 2px 5px rgba(0,0,0,0.1);
+        }
+        .header {
+            text-align: center;
+            border-bottom: 2px solid {{.Theme.PrimaryColor}};
+            padding-bottom: 20px;
+            margin-bottom: 30px;
+        }
+        .header img {
+            max-width: 200px;
+            max-height: 80px;
+        }
+        h1 {
+            color: {{.Theme.PrimaryColor}};
+            margin: 10px 0 0;
+        }
+        .description {
+            white-space: pre-line;
+        }
+        form {
+            margin: 30px 0;
+        }
+        input[type="email"], input[type="text"] {
+            width: 100%;
+            box-sizing: border-box;
+            padding: 10px;
+            margin-bottom: 10px;
+            border: 1px solid #ccc;
+            border-radius: 5px;
+            font: inherit;
+        }
+        .btn {
+            width: 100%;
+            padding: 12px;
+            border: none;
+            border-radius: 5px;
+            background-color: {{.Theme.PrimaryColor}};
+            color: white;
+            font: inherit;
+            font-weight: bold;
+            cursor: pointer;
+        }
+        .btn:hover {
+            opacity: 0.8;
+        }
+        .trap {
+            position: absolute;
+            left: -10000px;
+        }
+        .notice {
+            padding: 15px;
+            border-radius: 5px;
+            margin: 20px 0;
+            background-color: #d4edda;
+            color: #155724;
+        }
+        .notice.error {
+            background-color: #f8d7da;
+            color: #721c24;
+        }
+        .issues {
+            list-style: none;
+            padding: 0;
+        }
+        .issues li {
+            padding: 8px 0;
+            border-bottom: 1px solid #eee;
+        }
+        .issues time {
+            color: #666;
+            font-size: 13px;
+            margin-left: 8px;
+        }
+        @media (prefers-color-scheme: dark) {
+            body {
+                background-color: {{.Theme.DarkBackgroundColor}};
+                color: {{.Theme.DarkTextColor}};
+            }
+            .container {
+                background-color: #1e1e1e;
+                box-shadow: none;
+            }
+            .header {
+                border-bottom-color: {{.Theme.DarkPrimaryColor}};
+            }
+            h1 {
+                color: {{.Theme.DarkPrimaryColor}};
+            }
+            .btn {
+                background-color: {{.Theme.DarkPrimaryColor}};
+            }
+            .issues li {
+                border-bottom-color: #444444;
+            }
+            .issues time {
+                color: #aaaaaa;
+            }
+        }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="header">
+            {{if .Theme.LogoURL}}<img src="{{.Theme.LogoURL}}" alt="{{.Theme.Name}}">{{end}}
+            <h1>{{.Name}}</h1>
+        </div>
+        {{if .Description}}<p class="description">{{.Description}}</p>{{end}}
+
+        {{if .Form.Subscribed}}
+        <div class="notice">Thanks for subscribing! Look out for the next issue in your inbox.</div>
+        {{else}}
+        {{if .Form.Error}}<div class="notice error">{{.Form.Error}}</div>{{end}}
+        <form method="POST" action="{{.Action}}">
+            <input type="email" name="email" placeholder="you@example.com" value="{{.Form.Email}}" required>
+            <input type="text" name="name" placeholder="Your name (optional)" value="{{.Form.Name}}">
+            <input type="hidden" name="referrer" value="{{.Form.Referrer}}">
+            <input type="hidden" name="utm_source" value="{{.Form.UTM.Source}}">
+            <input type="hidden" name="utm_medium" value="{{.Form.UTM.Medium}}">
+            <input type="hidden" name="utm_campaign" value="{{.Form.UTM.Campaign}}">
+            <div class="trap" aria-hidden="true">
+                <input type="text" name="website" tabindex="-1" autocomplete="off">
+            </div>
+            <button type="submit" class="btn">Subscribe</button>
+        </form>
+        {{end}}
+
+        {{if .Issues}}
+        <h2>Recent issues</h2>
+        <ul class="issues">
+            {{range .Issues}}<li>{{.Title}}{{if not .SentAt.IsZero}}<time datetime="{{.SentAt.Format "2006-01-02"}}">{{.SentAt.Format "Jan 2, 2006"}}</time>{{end}}</li>
+            {{end}}
+        </ul>
+        {{end}}
+    </div>
+</body>
+</html>`
+
+// loadTemplate parses the landing page template at path, or the built-in one when path is empty, and renders
+// a sample page with it so a broken template is reported at startup rather than to readers
+func loadTemplate(path string) (*template.Template, error) {
+	name, body := "built-in", DefaultTemplate
+	if path != "" {
+		name = path
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read landing page template: %w", err)
+		}
+		body = string(data)
+	}
+
+	tmpl, err := template.New("landing").Funcs(templateFuncs).Parse(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse landing page template %s: %w", name, err)
+	}
+	sample := PageData{
+		Name:        "Sample topic",
+		Slug:        "sample-topic",
+		Description: "Sample description",
+		Issues:      []Issue{{Title: "Sample issue", SentAt: time.Now()}},
+		Theme:       (&templates.Branding{}).Resolved(),
+		Action:      "/topics/sample-topic",
+		Form:        Form{Error: "Sample error"},
+	}
+	if err := tmpl.Execute(io.Discard, sample); err != nil {
+		return nil, fmt.Errorf("landing page template %s does not render: %w", name, err)
+	}
+	return tmpl, nil
+}
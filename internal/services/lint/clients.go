@@ -0,0 +1,174 @@
+package lint
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// clientElements are elements most email clients don't render, with what to do instead. Unsafe elements such
+// as <iframe> and <form> are reported by the sanitizer instead.
+var clientElements = map[string]string{
+	"video":  "Video doesn't play in Gmail or Outlook; link to it from a thumbnail image instead",
+	"audio":  "Audio doesn't play in most email clients; link to it instead",
+	"canvas": "<canvas> needs script, which email clients never run",
+	"svg":    "Inline SVG is dropped by Gmail and Outlook; use a PNG or JPEG image",
+	"link":   "External stylesheets are dropped by most email clients; inline the styles",
+}
+
+// cssRule flags a CSS declaration that breaks or is ignored in common email clients
+type cssRule struct {
+	property string                  // Property the rule is about, matched exactly
+	matches  func(value string) bool // Nil matches every value
+	message  string
+}
+
+var (
+	negativeLengthPattern = regexp.MustCompile(`(^|\s)-\d`)
+	layoutDisplayPattern  = regexp.MustCompile(`(?i)\b(inline-)?(flex|grid)\b`)
+)
+
+var cssRules = []cssRule{
+	{"position", func(v string) bool { return !strings.EqualFold(v, "static") }, "Gmail and Outlook ignore position; lay the email out with tables"},
+	{"display", layoutDisplayPattern.MatchString, "Outlook for Windows and several Gmail apps don't support flexbox or grid; lay the email out with tables"},
+	{"float", func(v string) bool { return !strings.EqualFold(v, "none") }, "Outlook for Windows ignores float; use table cells or the align attribute"},
+	{"background-image", nil, "Outlook for Windows ignores CSS background images; set a background color as a fallback"},
+	{"background", func(v string) bool { return strings.Contains(strings.ToLower(v), "url(") }, "Outlook for Windows ignores CSS background images; set a background color as a fallback"},
+	{"margin", negativeLengthPattern.MatchString, "Gmail and Outlook.com drop negative margins"},
+	{"margin-top", negativeLengthPattern.MatchString, "Gmail and Outlook.com drop negative margins"},
+	{"margin-left", negativeLengthPattern.MatchString, "Gmail and Outlook.com drop negative margins"},
+	{"box-shadow", nil, "Gmail and Outlook for Windows ignore box-shadow"},
+	{"transform", nil, "Most email clients ignore transform"},
+	{"animation", nil, "Most email clients ignore CSS animation"},
+	{"transition", nil, "Email clients ignore CSS transitions"},
+}
+
+// cssValueRules flag functions that break a declaration whichever property they are used in
+var cssValueRules = []struct {
+	function string
+	message  string
+}{
+	{"var(", "Gmail and Outlook don't support CSS custom properties; write the value out"},
+	{"calc(", "Outlook for Windows ignores calc(); use a fixed value"},
+}
+
+var (
+	styleDeclarationPattern = regexp.MustCompile(`([A-Za-z-]+)\s*:\s*([^;{}]+)`)
+	pixelWidthPattern       = regexp.MustCompile(`(?i)^\s*\d+\s*px\s*$`)
+)
+
+// checkClients reports constructs that break or look different in common email clients: CSS they ignore,
+// elements they don't render, styles outside the head and images without a width attribute, which Outlook
+// for Windows shows at their full size. Each problem is reported once however often it appears.
+func (d *document) checkClients() []Issue {
+	c := &clientChecker{seen: make(map[string]bool)}
+	for _, n := range d.nodes {
+		c.walk(n)
+	}
+	sort.SliceStable(c.issues, func(i, j int) bool { return c.issues[i].Rule < c.issues[j].Rule })
+	return c.issues
+}
+
+type clientChecker struct {
+	issues []Issue
+	seen   map[string]bool
+}
+
+func (c *clientChecker) add(rule, target, message string) {
+	key := rule + "\x00" + target + "\x00" + message
+	if c.seen[key] {
+		return
+	}
+	c.seen[key] = true
+	c.issues = append(c.issues, Issue{Severity: SeverityWarning, Rule: rule, Message: message, Target: target})
+}
+
+func (c *clientChecker) walk(n *html.Node) {
+	if n.Type == html.ElementNode {
+		name := strings.ToLower(n.Data)
+		if message, ok := clientElements[name]; ok {
+			c.add(RuleClientHTML, name, message)
+			return
+		}
+
+		switch name {
+		case "style":
+			if !inHead(n) {
+				c.add(RuleClientCSS, "style", "Some Gmail apps and webmail clients drop <style> elements in the body; use inline styles")
+			}
+			c.checkStylesheet(textContent(n))
+		case "img":
+			c.checkImageWidth(n)
+		}
+		if style, ok := attribute(n, "style"); ok {
+			c.checkDeclarations(style)
+		}
+	}
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		c.walk(child)
+	}
+}
+
+// checkStylesheet checks the at-rules and declarations of a <style> element
+func (c *clientChecker) checkStylesheet(css string) {
+	lower := strings.ToLower(css)
+	if strings.Contains(lower, "@import") {
+		c.add(RuleClientCSS, "@import", "Most email clients ignore @import; inline the styles")
+	}
+	if strings.Contains(lower, "@font-face") {
+		c.add(RuleClientCSS, "@font-face", "Gmail and Outlook ignore web fonts; make sure the font stack ends in a safe fallback")
+	}
+	c.checkDeclarations(css)
+}
+
+// checkDeclarations checks each property: value pair of an inline style or a stylesheet
+func (c *clientChecker) checkDeclarations(css string) {
+	for _, m := range styleDeclarationPattern.FindAllStringSubmatch(css, -1) {
+		property := strings.ToLower(m[1])
+		value := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(m[2]), "!important"))
+		for _, rule := range cssRules {
+			if rule.property == property && (rule.matches == nil || rule.matches(value)) {
+				c.add(RuleClientCSS, fmt.Sprintf("%s: %s", property, value), rule.message)
+			}
+		}
+		for _, rule := range cssValueRules {
+			if strings.Contains(strings.ToLower(value), rule.function) {
+				c.add(RuleClientCSS, fmt.Sprintf("%s: %s", property, value), rule.message)
+			}
+		}
+	}
+}
+
+// checkImageWidth reports images Outlook for Windows would show at their natural size
+func (c *clientChecker) checkImageWidth(n *html.Node) {
+	src, _ := attribute(n, "src")
+	width, ok := attribute(n, "width")
+	switch {
+	case !ok || strings.TrimSpace(width) == "":
+		c.add(RuleMissingWidth, src, "The image has no width attribute, so Outlook for Windows shows it at full size; set width in pixels, e.g. width=\"600\"")
+	case pixelWidthPattern.MatchString(width):
+		c.add(RuleMissingWidth, src, "Width attributes are a plain number of pixels; Outlook ignores a width with px, e.g. write width=\"600\"")
+	}
+}
+
+func inHead(n *html.Node) bool {
+	for p := n.Parent; p != nil; p = p.Parent {
+		if p.Type == html.ElementNode && strings.EqualFold(p.Data, "head") {
+			return true
+		}
+	}
+	return false
+}
+
+func textContent(n *html.Node) string {
+	var b strings.Builder
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.TextNode {
+			b.WriteString(c.Data)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,12 @@
+package lint
+
+// Core contains shared business logic for content lint domain
+type Core struct {
+	service Service
+}
+
+func NewCore(service Service) *Core {
+	return &Core{
+		service: service,
+	}
+}
@@ -0,0 +1,14 @@
+package lint
+
+import "context"
+
+type Service interface {
+	LintContent(ctx context.Context, id uint) (*Report, error)
+	PrepareForPublish(ctx context.Context, id uint) (*Report, error)
+}
+
+// ClientPreviewer renders an email in real email clients, the way Litmus or Email on Acid do, and reports
+// what breaks in each
+type ClientPreviewer interface {
+	Preview(ctx context.Context, email PreviewEmail) ([]ClientIssue, error)
+}
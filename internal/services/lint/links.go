@@ -0,0 +1,187 @@
+package lint
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"golang.org/x/net/html"
+
+	"newsletter-service/internal/constants"
+)
+
+// linkCheckConcurrency bounds how many links of one content are requested at once
+const linkCheckConcurrency = 8
+
+// textlessElements hold text that is never shown as part of the email
+var textlessElements = map[string]bool{"head": true, "title": true, "style": true, "script": true, "noscript": true}
+
+// outline is what the checks need from a body: its links, its images and how much text there is
+type outline struct {
+	links  []string
+	images []image
+	words  int
+}
+
+type image struct {
+	src    string
+	hasAlt bool
+}
+
+func (d *document) outline() outline {
+	var o outline
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		switch n.Type {
+		case html.TextNode:
+			o.words += len(strings.Fields(n.Data))
+		case html.ElementNode:
+			name := strings.ToLower(n.Data)
+			if textlessElements[name] {
+				return
+			}
+			switch name {
+			case "a":
+				if href, ok := attribute(n, "href"); ok {
+					o.links = append(o.links, href)
+				}
+			case "img":
+				src, _ := attribute(n, "src")
+				_, hasAlt := attribute(n, "alt")
+				o.images = append(o.images, image{src: src, hasAlt: hasAlt})
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	for _, n := range d.nodes {
+		walk(n)
+	}
+	return o
+}
+
+func attribute(n *html.Node, key string) (string, bool) {
+	for _, attr := range n.Attr {
+		if attr.Namespace == "" && strings.EqualFold(attr.Key, key) {
+			return attr.Val, true
+		}
+	}
+	return "", false
+}
+
+// linkTarget classifies a link or image URL before anything is requested. It returns the issue for URLs that
+// can't work in an email and whether the URL should be requested.
+func linkTarget(raw string, rule string) (*Issue, bool) {
+	link := strings.TrimSpace(raw)
+	if link == "" {
+		return &Issue{Severity: SeverityError, Rule: rule, Message: "The URL is empty", Target: raw}, false
+	}
+	// In-page anchors, mail and phone links, inline images and script URLs (reported as unsafe HTML)
+	if strings.HasPrefix(link, "#") || isScriptURL(link, true) || strings.HasPrefix(strings.ToLower(link), "data:") {
+		return nil, false
+	}
+
+	u, err := url.Parse(link)
+	if err != nil {
+		return &Issue{Severity: SeverityError, Rule: rule, Message: "The URL is malformed", Target: raw}, false
+	}
+	switch {
+	case u.Scheme == "":
+		return &Issue{
+			Severity: SeverityError,
+			Rule:     rule,
+			Message:  "Relative URLs don't resolve in email clients; use an absolute http or https URL",
+			Target:   raw,
+		}, false
+	case u.Scheme != "http" && u.Scheme != "https":
+		return nil, false
+	case u.Host == "":
+		return &Issue{Severity: SeverityError, Rule: rule, Message: "The URL has no host", Target: raw}, false
+	}
+	return nil, true
+}
+
+// linkChecker requests links to find the broken ones
+type linkChecker struct {
+	client *http.Client
+}
+
+// check requests every URL once, at most linkCheckConcurrency at a time. Pages that are gone (404, 410) are
+// errors; other failures may be temporary and are warnings.
+func (c *linkChecker) check(ctx context.Context, urls map[string]string) []Issue {
+	var (
+		mu     sync.Mutex
+		issues []Issue
+		wg     sync.WaitGroup
+	)
+	sem := make(chan struct{}, linkCheckConcurrency)
+	for link, rule := range urls {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(link, rule string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			issue := c.checkOne(ctx, link, rule)
+			if issue != nil {
+				mu.Lock()
+				issues = append(issues, *issue)
+				mu.Unlock()
+			}
+		}(link, rule)
+	}
+	wg.Wait()
+	return issues
+}
+
+func (c *linkChecker) checkOne(ctx context.Context, link, rule string) *Issue {
+	status, err := c.request(ctx, http.MethodHead, link)
+	// Plenty of servers don't answer HEAD properly
+	if err == nil && (status == http.StatusMethodNotAllowed || status == http.StatusNotImplemented || status == http.StatusForbidden) {
+		status, err = c.request(ctx, http.MethodGet, link)
+	}
+
+	switch {
+	case err != nil:
+		return &Issue{
+			Severity: SeverityWarning,
+			Rule:     RuleUnreachableLink,
+			Message:  fmt.Sprintf("The URL could not be reached: %v", err),
+			Target:   link,
+		}
+	case status == http.StatusNotFound || status == http.StatusGone:
+		return &Issue{
+			Severity: SeverityError,
+			Rule:     rule,
+			Message:  fmt.Sprintf("The URL returned %d %s", status, http.StatusText(status)),
+			Target:   link,
+		}
+	case status >= 400:
+		return &Issue{
+			Severity: SeverityWarning,
+			Rule:     RuleUnreachableLink,
+			Message:  fmt.Sprintf("The URL returned %d %s", status, http.StatusText(status)),
+			Target:   link,
+		}
+	}
+	return nil
+}
+
+func (c *linkChecker) request(ctx context.Context, method, link string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, method, link, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("User-Agent", constants.LintUserAgent)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,81 @@
+package lint
+
+import (
+	"errors"
+
+	"newsletter-service/internal/daos"
+)
+
+// Type alias for backward compatibility
+type Content = daos.Content
+
+// Issue severities
+const (
+	SeverityError   = "error" // Blocks publishing when block_publish is set
+	SeverityWarning = "warning"
+)
+
+// Rules issues are reported under
+const (
+	RuleUnsafeHTML      = "unsafe_html"      // Scripts, frames, forms, event handlers and script URLs; removed on publish
+	RuleBrokenLink      = "broken_link"      // Empty, malformed or relative links, and links answering 404 or 410
+	RuleUnreachableLink = "unreachable_link" // Links that failed for any other reason, which may be temporary
+	RuleBrokenImage     = "broken_image"
+	RuleMissingAlt      = "missing_alt"
+	RuleImageOnly       = "image_only"
+	RuleImageTextRatio  = "image_text_ratio"
+	RuleSpamScore       = "spam_score"
+	RuleClientCSS       = "client_css"     // CSS that common email clients ignore or break on
+	RuleClientHTML      = "client_html"    // Elements most email clients don't render
+	RuleMissingWidth    = "missing_width"  // Images without a pixel width attribute
+	RuleClientPreview   = "client_preview" // Reported by the configured email client preview service
+)
+
+// ErrPublishBlocked is returned with the report when content about to be published has lint errors
+var ErrPublishBlocked = errors.New("content has lint errors")
+
+// Issue is one problem found in content
+type Issue struct {
+	Severity string `json:"severity"`
+	Rule     string `json:"rule"`
+	Message  string `json:"message"`
+	Target   string `json:"target,omitempty"` // The link, image or element the issue is about
+}
+
+// Report is the outcome of linting one content
+type Report struct {
+	ContentID uint     `json:"content_id"`
+	Passed    bool     `json:"passed"` // No errors; warnings never block publishing
+	Errors    int      `json:"errors"`
+	Warnings  int      `json:"warnings"`
+	SpamScore *float64 `json:"spam_score,omitempty"` // Set when spamd scored the content
+	Issues    []Issue  `json:"issues"`
+}
+
+// PreviewEmail is what a ClientPreviewer renders: the email as subscribers get it
+type PreviewEmail struct {
+	ContentID uint   `json:"content_id"`
+	Subject   string `json:"subject"`
+	HTML      string `json:"html"` // Body rendered into the email template
+	Text      string `json:"text"` // Plain-text alternative
+}
+
+// ClientIssue is a problem a ClientPreviewer found in one email client
+type ClientIssue struct {
+	Client   string `json:"client"`   // e.g. "Outlook 2019 (Windows)"
+	Severity string `json:"severity"` // SeverityError or SeverityWarning; anything else is a warning
+	Message  string `json:"message"`
+	Target   string `json:"target,omitempty"`
+}
+
+func (r *Report) add(issues ...Issue) {
+	for _, issue := range issues {
+		if issue.Severity == SeverityError {
+			r.Errors++
+		} else {
+			r.Warnings++
+		}
+		r.Issues = append(r.Issues, issue)
+	}
+	r.Passed = r.Errors == 0
+}
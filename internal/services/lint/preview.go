@@ -0,0 +1,62 @@
+package lint
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// maxPreviewResponse bounds how much of a preview service's answer is read
+const maxPreviewResponse = 1 << 20
+
+// httpPreviewer posts the email as JSON to a preview service, which answers with the issues it found:
+//
+//	{"issues": [{"client": "Outlook 2019 (Windows)", "severity": "warning", "message": "...", "target": "..."}]}
+//
+// An adapter in front of Litmus, Email on Acid or an in-house renderer fits behind it.
+type httpPreviewer struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+func (p *httpPreviewer) Preview(ctx context.Context, email PreviewEmail) ([]ClientIssue, error) {
+	payload, err := json.Marshal(email)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create preview request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if p.token != "" {
+		req.Header.Set("Authorization", "Bearer "+p.token)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("preview service request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxPreviewResponse))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read preview service response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("preview service returned %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var result struct {
+		Issues []ClientIssue `json:"issues"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("preview service returned malformed JSON: %w", err)
+	}
+	return result.Issues, nil
+}
@@ -0,0 +1,166 @@
+package lint
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// unsafeElements are removed with everything inside them. Mail clients drop most of them anyway; removing them
+// up front keeps the preview, the web archive and the clients that don't consistent.
+var unsafeElements = map[string]bool{
+	"script": true, "noscript": true, "iframe": true, "frame": true, "frameset": true, "object": true,
+	"embed": true, "applet": true, "base": true, "form": true, "input": true, "button": true,
+	"textarea": true, "select": true,
+}
+
+// urlAttributes hold URLs that are followed or loaded
+var urlAttributes = map[string]bool{
+	"href": true, "src": true, "action": true, "formaction": true, "background": true, "poster": true,
+	"xlink:href": true,
+}
+
+// document is a parsed body: a whole HTML document, or the nodes of a fragment in order
+type document struct {
+	nodes []*html.Node
+}
+
+// isHTML matches how the email template decides whether a body is HTML or plain text
+func isHTML(body string) bool {
+	return strings.Contains(body, "<")
+}
+
+// parseBody parses a full HTML document, or a fragment as it would sit inside the template's body
+func parseBody(body string) (*document, error) {
+	if strings.Contains(strings.ToLower(body), "<html") {
+		root, err := html.Parse(strings.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		return &document{nodes: []*html.Node{root}}, nil
+	}
+
+	nodes, err := html.ParseFragment(strings.NewReader(body), &html.Node{
+		Type:     html.ElementNode,
+		Data:     "body",
+		DataAtom: atom.Body,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &document{nodes: nodes}, nil
+}
+
+func (d *document) render() (string, error) {
+	var buf bytes.Buffer
+	for _, n := range d.nodes {
+		if err := html.Render(&buf, n); err != nil {
+			return "", err
+		}
+	}
+	return buf.String(), nil
+}
+
+// sanitize removes unsafe elements and attributes from the document, returning what was removed
+func (d *document) sanitize() []Issue {
+	var issues []Issue
+	// Nodes at the top of a fragment have no parent to be removed from
+	kept := d.nodes[:0]
+	for _, n := range d.nodes {
+		if isUnsafeElement(n) {
+			issues = append(issues, unsafeElementIssue(n))
+			continue
+		}
+		issues = append(issues, sanitizeNode(n)...)
+		kept = append(kept, n)
+	}
+	d.nodes = kept
+	return issues
+}
+
+func sanitizeNode(n *html.Node) []Issue {
+	var issues []Issue
+	if n.Type == html.ElementNode {
+		issues = append(issues, sanitizeAttributes(n)...)
+	}
+	for c := n.FirstChild; c != nil; {
+		next := c.NextSibling
+		if isUnsafeElement(c) {
+			n.RemoveChild(c)
+			issues = append(issues, unsafeElementIssue(c))
+		} else {
+			issues = append(issues, sanitizeNode(c)...)
+		}
+		c = next
+	}
+	return issues
+}
+
+func isUnsafeElement(n *html.Node) bool {
+	return n.Type == html.ElementNode && unsafeElements[strings.ToLower(n.Data)]
+}
+
+func unsafeElementIssue(n *html.Node) Issue {
+	return Issue{
+		Severity: SeverityWarning,
+		Rule:     RuleUnsafeHTML,
+		Message:  fmt.Sprintf("<%s> elements are removed when the content is published", strings.ToLower(n.Data)),
+		Target:   strings.ToLower(n.Data),
+	}
+}
+
+// sanitizeAttributes drops event handlers and URLs that run script
+func sanitizeAttributes(n *html.Node) []Issue {
+	var issues []Issue
+	kept := n.Attr[:0]
+	for _, attr := range n.Attr {
+		name := strings.ToLower(attr.Key)
+		if attr.Namespace != "" {
+			name = strings.ToLower(attr.Namespace) + ":" + name
+		}
+
+		switch {
+		case strings.HasPrefix(name, "on"):
+			issues = append(issues, Issue{
+				Severity: SeverityWarning,
+				Rule:     RuleUnsafeHTML,
+				Message:  fmt.Sprintf("The %s event handler on <%s> is removed when the content is published", name, n.Data),
+				Target:   name,
+			})
+		case urlAttributes[name] && isScriptURL(attr.Val, n.Data == "img"):
+			issues = append(issues, Issue{
+				Severity: SeverityWarning,
+				Rule:     RuleUnsafeHTML,
+				Message:  fmt.Sprintf("The %s URL on <%s> is removed when the content is published", name, n.Data),
+				Target:   attr.Val,
+			})
+		default:
+			kept = append(kept, attr)
+		}
+	}
+	n.Attr = kept
+	return issues
+}
+
+// isScriptURL reports whether a URL runs script when followed or loaded. Inline images are allowed as data
+// URLs, except SVG which can carry script.
+func isScriptURL(value string, image bool) bool {
+	// Browsers ignore whitespace and control characters inside the scheme
+	v := strings.ToLower(strings.Map(func(r rune) rune {
+		if r <= ' ' {
+			return -1
+		}
+		return r
+	}, value))
+
+	switch {
+	case strings.HasPrefix(v, "javascript:"), strings.HasPrefix(v, "vbscript:"):
+		return true
+	case strings.HasPrefix(v, "data:"):
+		return !image || !strings.HasPrefix(v, "data:image/") || strings.HasPrefix(v, "data:image/svg")
+	}
+	return false
+}
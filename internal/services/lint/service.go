@@ -0,0 +1,261 @@
+package lint
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"newsletter-service/internal/config"
+	"newsletter-service/internal/providers/templates"
+	"newsletter-service/internal/services/content"
+)
+
+type service struct {
+	contentService content.Service
+	cfg            config.LintConfig
+	links          *linkChecker
+	spamd          *spamdClient    // Nil when spam scoring is off
+	previewer      ClientPreviewer // Nil without an email client preview service
+}
+
+// NewService creates a content lint service, filling in defaults for unset config values
+func NewService(contentService content.Service, cfg config.LintConfig) Service {
+	if cfg.LinkTimeout <= 0 {
+		cfg.LinkTimeout = 5 * time.Second
+	}
+	if cfg.MinWordsPerImage <= 0 {
+		cfg.MinWordsPerImage = 50
+	}
+	if cfg.SpamdTimeout <= 0 {
+		cfg.SpamdTimeout = 10 * time.Second
+	}
+	if cfg.ClientPreviewTimeout <= 0 {
+		cfg.ClientPreviewTimeout = 30 * time.Second
+	}
+
+	s := &service{
+		contentService: contentService,
+		cfg:            cfg,
+		links:          &linkChecker{client: &http.Client{Timeout: cfg.LinkTimeout}},
+	}
+	if cfg.SpamdAddr != "" {
+		s.spamd = &spamdClient{addr: cfg.SpamdAddr, timeout: cfg.SpamdTimeout}
+	}
+	if cfg.ClientPreviewURL != "" {
+		s.previewer = &httpPreviewer{
+			url:    cfg.ClientPreviewURL,
+			token:  cfg.ClientPreviewToken,
+			client: &http.Client{Timeout: cfg.ClientPreviewTimeout},
+		}
+	}
+	return s
+}
+
+// NewServiceWithPreviewer creates a content lint service that checks rendered emails with previewer instead of
+// the preview service in the config
+func NewServiceWithPreviewer(contentService content.Service, cfg config.LintConfig, previewer ClientPreviewer) Service {
+	s := NewService(contentService, cfg).(*service)
+	s.previewer = previewer
+	return s
+}
+
+// LintContent checks stored content without changing it. Unsafe HTML is reported as the warnings publishing
+// would fix.
+func (s *service) LintContent(ctx context.Context, id uint) (*Report, error) {
+	c, err := s.contentService.GetContentByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	report, _, err := s.lint(ctx, c)
+	return report, err
+}
+
+// PrepareForPublish removes unsafe HTML from content about to be published, saving the cleaned body, and
+// returns its lint report. With block_publish set, a report with errors comes back with ErrPublishBlocked.
+func (s *service) PrepareForPublish(ctx context.Context, id uint) (*Report, error) {
+	c, err := s.contentService.GetContentByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	report, body, err := s.lint(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	if body != c.Body {
+		if err := s.contentService.UpdateContent(ctx, id, map[string]interface{}{"body": body}); err != nil {
+			return nil, fmt.Errorf("failed to save sanitized body: %w", err)
+		}
+	}
+
+	if s.cfg.BlockPublish && !report.Passed {
+		return report, ErrPublishBlocked
+	}
+	return report, nil
+}
+
+// lint checks content and returns its report along with the body with unsafe HTML removed. Links, images and
+// spam are checked on the cleaned body, since that is what gets sent.
+func (s *service) lint(ctx context.Context, c *Content) (*Report, string, error) {
+	report := &Report{ContentID: c.ID, Passed: true, Issues: []Issue{}}
+	body := c.Body
+
+	var o outline
+	if isHTML(body) {
+		doc, err := parseBody(body)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to parse content body: %w", err)
+		}
+		if removed := doc.sanitize(); len(removed) > 0 {
+			report.add(removed...)
+			if body, err = doc.render(); err != nil {
+				return nil, "", fmt.Errorf("failed to render sanitized body: %w", err)
+			}
+		}
+		o = doc.outline()
+		if s.cfg.CheckClients {
+			report.add(doc.checkClients()...)
+		}
+	} else {
+		o.words = len(strings.Fields(body))
+	}
+
+	report.add(s.checkImages(o)...)
+	report.add(s.checkLinks(ctx, o)...)
+	s.scoreSpam(ctx, c, body, report)
+	s.previewClients(ctx, c, body, report)
+	return report, body, nil
+}
+
+func (s *service) checkImages(o outline) []Issue {
+	var issues []Issue
+	for _, img := range o.images {
+		if !img.hasAlt {
+			issues = append(issues, Issue{
+				Severity: SeverityWarning,
+				Rule:     RuleMissingAlt,
+				Message:  "The image has no alt text for screen readers and clients that block images",
+				Target:   img.src,
+			})
+		}
+	}
+
+	switch {
+	case len(o.images) == 0:
+	case o.words == 0:
+		issues = append(issues, Issue{
+			Severity: SeverityError,
+			Rule:     RuleImageOnly,
+			Message:  "The content is only images, which spam filters treat harshly; add text",
+		})
+	case o.words < len(o.images)*s.cfg.MinWordsPerImage:
+		issues = append(issues, Issue{
+			Severity: SeverityWarning,
+			Rule:     RuleImageTextRatio,
+			Message: fmt.Sprintf("The content has %d words of text for %d images; aim for at least %d words per image",
+				o.words, len(o.images), s.cfg.MinWordsPerImage),
+		})
+	}
+	return issues
+}
+
+// checkLinks reports links and images that can't work in an email, then requests the rest when link checks
+// are enabled. Each URL is checked once however often it appears.
+func (s *service) checkLinks(ctx context.Context, o outline) []Issue {
+	var issues []Issue
+	seen := make(map[string]bool)
+	requested := make(map[string]string)
+	check := func(raw, rule string) {
+		if seen[raw] {
+			return
+		}
+		seen[raw] = true
+
+		issue, request := linkTarget(raw, rule)
+		if issue != nil {
+			issues = append(issues, *issue)
+		}
+		if request {
+			requested[strings.TrimSpace(raw)] = rule
+		}
+	}
+	for _, link := range o.links {
+		check(link, RuleBrokenLink)
+	}
+	for _, img := range o.images {
+		check(img.src, RuleBrokenImage)
+	}
+
+	if s.cfg.CheckLinks && len(requested) > 0 {
+		checked := s.links.check(ctx, requested)
+		sort.Slice(checked, func(i, j int) bool { return checked[i].Target < checked[j].Target })
+		issues = append(issues, checked...)
+	}
+	return issues
+}
+
+// scoreSpam adds the spamd score to the report. spamd being unavailable is a warning, so publishing doesn't
+// depend on it.
+func (s *service) scoreSpam(ctx context.Context, c *Content, body string, report *Report) {
+	if s.spamd == nil {
+		return
+	}
+
+	rendered, err := templates.RenderEmailHTML(templates.Email{Subject: c.Title, Body: body, PreviewText: c.PreviewText})
+	if err != nil {
+		report.add(Issue{Severity: SeverityWarning, Rule: RuleSpamScore, Message: fmt.Sprintf("The content could not be rendered for spam scoring: %v", err)})
+		return
+	}
+
+	score, threshold, err := s.spamd.score(ctx, spamdMessage(c.ID, c.Title, rendered))
+	if err != nil {
+		report.add(Issue{Severity: SeverityWarning, Rule: RuleSpamScore, Message: fmt.Sprintf("The spam score is unknown: %v", err)})
+		return
+	}
+	report.SpamScore = &score
+
+	if s.cfg.SpamThreshold > 0 {
+		threshold = s.cfg.SpamThreshold
+	}
+	if score >= threshold {
+		report.add(Issue{
+			Severity: SeverityError,
+			Rule:     RuleSpamScore,
+			Message:  fmt.Sprintf("The spam score of %.1f is at or above the threshold of %.1f", score, threshold),
+		})
+	}
+}
+
+// previewClients adds what the email client preview service found in the rendered email. Like spam scoring, a
+// service that can't be reached is a warning rather than a reason to hold content back.
+func (s *service) previewClients(ctx context.Context, c *Content, body string, report *Report) {
+	if s.previewer == nil {
+		return
+	}
+
+	rendered, err := templates.RenderEmail(templates.Email{Subject: c.Title, Body: body, PreviewText: c.PreviewText})
+	if err != nil {
+		report.add(Issue{Severity: SeverityWarning, Rule: RuleClientPreview, Message: fmt.Sprintf("The content could not be rendered for client previews: %v", err)})
+		return
+	}
+
+	issues, err := s.previewer.Preview(ctx, PreviewEmail{ContentID: c.ID, Subject: c.Title, HTML: rendered.HTML, Text: rendered.Text})
+	if err != nil {
+		report.add(Issue{Severity: SeverityWarning, Rule: RuleClientPreview, Message: fmt.Sprintf("Email client previews are unavailable: %v", err)})
+		return
+	}
+	for _, issue := range issues {
+		severity := SeverityWarning
+		if issue.Severity == SeverityError {
+			severity = SeverityError
+		}
+		message := issue.Message
+		if issue.Client != "" {
+			message = issue.Client + ": " + message
+		}
+		report.add(Issue{Severity: severity, Rule: RuleClientPreview, Message: message, Target: issue.Target})
+	}
+}
@@ -0,0 +1,89 @@
+package lint
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// spamdClient scores messages with SpamAssassin's spamd using the CHECK command of the spamc protocol
+type spamdClient struct {
+	addr    string
+	timeout time.Duration
+}
+
+// score returns the message's spam score and the score spamd considers spam
+func (c *spamdClient) score(ctx context.Context, message []byte) (score, threshold float64, err error) {
+	dialer := net.Dialer{Timeout: c.timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", c.addr)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to connect to spamd: %w", err)
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(time.Now().Add(c.timeout)); err != nil {
+		return 0, 0, err
+	}
+
+	request := fmt.Sprintf("CHECK SPAMC/1.5\r\nContent-length: %d\r\n\r\n", len(message))
+	if _, err := io.WriteString(conn, request); err != nil {
+		return 0, 0, fmt.Errorf("failed to send message to spamd: %w", err)
+	}
+	if _, err := conn.Write(message); err != nil {
+		return 0, 0, fmt.Errorf("failed to send message to spamd: %w", err)
+	}
+
+	return parseSpamdResponse(bufio.NewReader(conn))
+}
+
+// parseSpamdResponse reads a CHECK response:
+//
+//	SPAMD/1.1 0 EX_OK
+//	Spam: False ; 2.1 / 5.0
+func parseSpamdResponse(r *bufio.Reader) (score, threshold float64, err error) {
+	status, err := r.ReadString('\n')
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to read spamd response: %w", err)
+	}
+	fields := strings.Fields(status)
+	if len(fields) < 3 || !strings.HasPrefix(fields[0], "SPAMD/") || fields[1] != "0" {
+		return 0, 0, fmt.Errorf("spamd returned %q", strings.TrimSpace(status))
+	}
+
+	for {
+		line, err := r.ReadString('\n')
+		line = strings.TrimSpace(line)
+		if name, value, ok := strings.Cut(line, ":"); ok && strings.EqualFold(name, "Spam") {
+			_, scores, _ := strings.Cut(value, ";")
+			got, required, _ := strings.Cut(scores, "/")
+			score, scoreErr := strconv.ParseFloat(strings.TrimSpace(got), 64)
+			threshold, thresholdErr := strconv.ParseFloat(strings.TrimSpace(required), 64)
+			if scoreErr != nil || thresholdErr != nil {
+				return 0, 0, fmt.Errorf("spamd returned a malformed score %q", line)
+			}
+			return score, threshold, nil
+		}
+		if line == "" || err != nil {
+			return 0, 0, fmt.Errorf("spamd response has no score")
+		}
+	}
+}
+
+// spamdMessage wraps rendered HTML in the headers SpamAssassin scores alongside the body. There is no From
+// header: the sender is decided at send time, and its rules belong to the provider's domain setup anyway.
+func spamdMessage(contentID uint, subject, renderedHTML string) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&b, "Message-ID: <lint-%d-%d@newsletter-service>\r\n", contentID, time.Now().UnixNano())
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/html; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(renderedHTML, "\n", "\r\n"))
+	return []byte(b.String())
+}
@@ -0,0 +1,108 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"newsletter-service/internal/constants"
+)
+
+// defaultArchiveBatchSize is used when [email_logs] archive_batch_size is unset
+const defaultArchiveBatchSize = 5000
+
+// ArchiveEmailLogs moves email logs older than [email_logs] archive_after from email_logs to email_logs_archive
+// in batches, and returns how many were moved. Pending logs stay until they are sent. Nothing happens when
+// archive_after is 0.
+func (s *notificationService) ArchiveEmailLogs(ctx context.Context) (int64, error) {
+	s.mu.RLock()
+	cfg := s.emailLogs
+	s.mu.RUnlock()
+	if cfg.ArchiveAfter <= 0 {
+		return 0, nil
+	}
+	batchSize := cfg.ArchiveBatchSize
+	if batchSize <= 0 {
+		batchSize = defaultArchiveBatchSize
+	}
+
+	columns, err := s.archiveColumns(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	// Deleting and inserting in one statement never leaves a log in both tables, or in neither
+	move := fmt.Sprintf(`WITH moved AS (
+			DELETE FROM email_logs WHERE id IN (
+				SELECT id FROM email_logs WHERE created_at < ? AND status <> ? ORDER BY id LIMIT ?)
+			RETURNING %[1]s)
+		INSERT INTO %[2]s (%[1]s) SELECT %[1]s FROM moved`, columns, constants.TableNameEmailLogsArchive)
+
+	before := time.Now().Add(-cfg.ArchiveAfter)
+	var total int64
+	for {
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+		result := s.db.WithContext(ctx).Exec(move, before, constants.StatusPending, batchSize)
+		total += result.RowsAffected
+		if result.Error != nil {
+			return total, fmt.Errorf("failed to archive email logs: %w", result.Error)
+		}
+		if result.RowsAffected < int64(batchSize) {
+			return total, nil
+		}
+	}
+}
+
+// archiveColumns lists the columns of email_logs, checking the archive has every one of them so no data is
+// dropped on the way
+func (s *notificationService) archiveColumns(ctx context.Context) (string, error) {
+	var columns []struct {
+		Table  string
+		Column string
+	}
+	err := s.db.WithContext(ctx).Raw(`SELECT table_name AS "table", column_name AS "column"
+		FROM information_schema.columns
+		WHERE table_schema = current_schema() AND table_name IN (?, ?)
+		ORDER BY ordinal_position`, constants.TableNameEmailLogs, constants.TableNameEmailLogsArchive).
+		Scan(&columns).Error
+	if err != nil {
+		return "", fmt.Errorf("failed to read email log columns: %w", err)
+	}
+
+	archived := make(map[string]bool)
+	for _, c := range columns {
+		if c.Table == constants.TableNameEmailLogsArchive {
+			archived[c.Column] = true
+		}
+	}
+	if len(archived) == 0 {
+		return "", errors.New("email_logs_archive does not exist, run the migrations")
+	}
+
+	var hot, missing []string
+	for _, c := range columns {
+		if c.Table != constants.TableNameEmailLogs {
+			continue
+		}
+		hot = append(hot, c.Column)
+		if !archived[c.Column] {
+			missing = append(missing, c.Column)
+		}
+	}
+	if len(missing) > 0 {
+		return "", fmt.Errorf("email_logs_archive lacks columns %s of email_logs", strings.Join(missing, ", "))
+	}
+	return strings.Join(hot, ", "), nil
+}
+
+// emailLogTable picks the table a listing reads from
+func emailLogTable(filter EmailLogFilter) string {
+	if filter.Archived {
+		return constants.TableNameEmailLogsArchive
+	}
+	return constants.TableNameEmailLogs
+}
@@ -0,0 +1,57 @@
+package notification
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"newsletter-service/internal/config"
+)
+
+// sharedBodies reports whether email log bodies are stored once in email_log_bodies
+func (s *notificationService) sharedBodies() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.emailLogs.BodyStorage == config.EmailLogBodyShared
+}
+
+// storeBody moves a new log's body into email_log_bodies when bodies are shared, leaving the log its hash.
+// Recipients of the same content share one row, so a large send stores its body once.
+func (s *notificationService) storeBody(tx *gorm.DB, log *EmailLog) error {
+	if log.Body == "" || !s.sharedBodies() {
+		return nil
+	}
+
+	sum := sha256.Sum256([]byte(log.Body))
+	hash := hex.EncodeToString(sum[:])
+	body := EmailLogBody{Hash: hash, Body: log.Body}
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&body).Error; err != nil {
+		return fmt.Errorf("failed to store email body: %w", err)
+	}
+	log.Body, log.BodyHash = "", hash
+	return nil
+}
+
+// loadBody fills in the body of a log that references a shared one. Logs are listed without their bodies, so
+// this is for a single log, when it is fetched or sent again.
+func (s *notificationService) loadBody(ctx context.Context, log *EmailLog) error {
+	if log.BodyHash == "" || log.Body != "" {
+		return nil
+	}
+
+	var body EmailLogBody
+	err := s.db.WithContext(ctx).Where("hash = ?", log.BodyHash).First(&body).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil // Removed with the last log that referenced it
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load body of email log %d: %w", log.ID, err)
+	}
+	log.Body = body.Body
+	return nil
+}
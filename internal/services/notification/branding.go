@@ -0,0 +1,73 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+
+	"newsletter-service/internal/daos"
+	"newsletter-service/internal/providers"
+	"newsletter-service/internal/providers/templates"
+)
+
+// sender is who a topic's emails come from and how they look, resolved from the topic's branding with the
+// organization's filling its gaps. Empty fields leave the provider's configured from address and the default
+// template in place.
+type sender struct {
+	from     string
+	fromName string
+	replyTo  string
+	branding *templates.Branding
+}
+
+func newSender(b daos.Branding) sender {
+	s := sender{from: b.FromAddress, fromName: b.FromName, replyTo: b.ReplyTo}
+	branding := templates.Branding{
+		Name:                b.FromName,
+		LogoURL:             b.LogoURL,
+		PrimaryColor:        b.PrimaryColor,
+		FooterText:          b.FooterText,
+		DarkLogoURL:         b.DarkLogoURL,
+		BackgroundColor:     b.BackgroundColor,
+		TextColor:           b.TextColor,
+		FontFamily:          b.FontFamily,
+		DarkPrimaryColor:    b.DarkPrimaryColor,
+		DarkBackgroundColor: b.DarkBackgroundColor,
+		DarkTextColor:       b.DarkTextColor,
+	}
+	// Theme values are merged into the template's defaults when each email renders
+	if branding != (templates.Branding{}) {
+		s.branding = &branding
+	}
+	return s
+}
+
+// apply sets the sender on an email
+func (s sender) apply(email *providers.EmailNotification) {
+	email.From = s.from
+	email.FromName = s.fromName
+	email.ReplyTo = s.replyTo
+	email.Branding = s.branding
+}
+
+// applyBulk sets the sender on a bulk email
+func (s sender) applyBulk(email *providers.BulkEmailNotification) {
+	email.From = s.from
+	email.FromName = s.fromName
+	email.ReplyTo = s.replyTo
+	email.Branding = s.branding
+}
+
+// senderFor returns the sender of a topic's emails
+func (s *notificationService) senderFor(ctx context.Context, topicID uint) sender {
+	return s.topicSettingsFor(ctx, topicID).sender
+}
+
+// senderForContent returns the sender of a content's emails, for email logs sent after the content's own send
+func (s *notificationService) senderForContent(ctx context.Context, contentID uint) sender {
+	c, err := s.contentService.GetContentByID(ctx, contentID)
+	if err != nil {
+		fmt.Printf("Failed to get content %d for its branding, using provider defaults: %v\n", contentID, err)
+		return sender{}
+	}
+	return s.senderFor(ctx, c.TopicID)
+}
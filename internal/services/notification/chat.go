@@ -0,0 +1,54 @@
+package notification
+
+import (
+	"context"
+
+	"newsletter-service/internal/events"
+	"newsletter-service/internal/logger"
+	"newsletter-service/internal/providers"
+	"newsletter-service/internal/services/content"
+	"newsletter-service/internal/services/topic"
+)
+
+// defaultChatSummaryLength is about a tweet's worth of the body, enough to tell readers what the issue is about
+const defaultChatSummaryLength = 280
+
+// SubscribeChat posts a summary of each content to the chat destinations following its topic, once its
+// notifications have gone out. Local-time content completes a run per time zone, so summaries wait until the
+// content is marked sent and are posted once.
+func SubscribeChat(bus *events.Bus, broadcaster *providers.ChatBroadcaster, contentService content.Service, topicService topic.Service, summaryLength int) {
+	if summaryLength <= 0 {
+		summaryLength = defaultChatSummaryLength
+	}
+
+	bus.Subscribe(func(ctx context.Context, event events.Event) {
+		data, _ := event.Data.(map[string]interface{})
+		contentID, ok := data["content_id"].(uint)
+		if !ok {
+			return
+		}
+		if topicID, ok := data["topic_id"].(uint); ok && !broadcaster.Follows(topicID) {
+			return
+		}
+
+		// Post without holding up the sending worker
+		go func(ctx context.Context) {
+			c, err := contentService.GetContentByID(ctx, contentID)
+			if err != nil || !c.NotificationsSent {
+				return
+			}
+
+			message := &providers.ChatMessage{
+				Title:   c.Title,
+				Summary: truncateText(plainText(c.Body), summaryLength),
+			}
+			if t, err := topicService.GetTopicByID(ctx, c.TopicID); err == nil {
+				message.Topic = t.Name
+			}
+
+			if err := broadcaster.Broadcast(ctx, c.TopicID, message); err != nil {
+				logger.Warn(ctx, "Failed to post content %d to chat: %v", contentID, err)
+			}
+		}(context.WithoutCancel(ctx))
+	}, events.SendCompleted)
+}
@@ -0,0 +1,291 @@
+package notification
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"math"
+	"sync"
+	"time"
+
+	"newsletter-service/internal/config"
+)
+
+// Adaptive concurrency defaults, used for settings left unset under [worker.adaptive_concurrency]
+const (
+	defaultConcurrency          = 10
+	defaultConcurrencyWindow    = 20
+	defaultConcurrencyLatency   = 2 * time.Second
+	defaultConcurrencyErrorRate = 0.2
+	defaultConcurrencyBackoff   = 0.5
+)
+
+// concurrencyLimiter bounds how many emails a service sends at once. With adaptive concurrency off it is a
+// fixed semaphore of max_async_process. With it on, the limit follows AIMD: every window of completed sends
+// either raises it by one, when the window was healthy and the limit was in use, or multiplies it by the
+// backoff, when sends were slow, failed too often or waited for database connections. A struggling provider
+// is thus sent to less and less instead of piling up timeouts, and the limit climbs back once it recovers.
+//
+// Sends are served by the priority of their content (see withSendPriority): a sender never takes a slot while
+// a higher priority one is waiting, and the high_priority_reserve share of the limit is only open to high
+// priority sends, so an urgent announcement gets slots ahead of a large digest already under way.
+//
+// The limiter belongs to the service, not to one send, so what it learned carries over to the next content.
+type concurrencyLimiter struct {
+	mu       sync.Mutex
+	released chan struct{} // Closed and replaced whenever a slot may have freed up
+	inFlight int
+	limit    float64
+	reserve  float64            // Share of the limit kept for high priority sends
+	waiting  [priorityRanks]int // Senders waiting for a slot, by rank
+
+	adaptive   bool
+	min, max   int
+	window     int
+	latency    time.Duration
+	errorRate  float64
+	backoff    float64
+	poolStats  func() sql.DBStats // Nil when the pool can't be observed
+	poolWaits  int64              // WaitCount when the current window started
+	sends      int                // Completed in the current window
+	failures   int
+	totalTime  time.Duration
+	peak       int // Most sends in flight during the window
+	increases  int64
+	decreases  int64
+	lastReason string
+}
+
+// newConcurrencyLimiter creates a limiter for cfg, which may be nil; poolStats reports database pool waits
+func newConcurrencyLimiter(cfg *config.WorkerConfig, poolStats func() sql.DBStats) *concurrencyLimiter {
+	l := &concurrencyLimiter{released: make(chan struct{}), poolStats: poolStats}
+	l.configure(cfg)
+	return l
+}
+
+// configure applies worker settings, keeping an adaptive limit within the new bounds rather than resetting it
+func (l *concurrencyLimiter) configure(cfg *config.WorkerConfig) {
+	fixed := defaultConcurrency
+	var adaptive config.AdaptiveConcurrencyConfig
+	var reserve float64
+	if cfg != nil {
+		if cfg.MaxAsyncProcess > 0 {
+			fixed = cfg.MaxAsyncProcess
+		}
+		adaptive = cfg.AdaptiveConcurrency
+		reserve = cfg.HighPriorityReserve
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.reserve = reserve
+	wasAdaptive := l.adaptive
+	l.adaptive = adaptive.Enabled
+	l.min, l.max = 1, fixed
+	if adaptive.Min > 0 {
+		l.min = adaptive.Min
+	}
+	if adaptive.Max > 0 {
+		l.max = adaptive.Max
+	}
+	if l.min > l.max {
+		l.min = l.max
+	}
+	l.window = valueOr(adaptive.Window, defaultConcurrencyWindow)
+	l.latency = valueOr(adaptive.LatencyTarget, defaultConcurrencyLatency)
+	l.errorRate = valueOr(adaptive.ErrorRate, defaultConcurrencyErrorRate)
+	l.backoff = valueOr(adaptive.Backoff, defaultConcurrencyBackoff)
+	if l.backoff >= 1 {
+		l.backoff = defaultConcurrencyBackoff
+	}
+
+	switch {
+	case !l.adaptive:
+		l.limit = float64(fixed)
+	case !wasAdaptive:
+		// Start where the fixed limit was, so turning it on doesn't change throughput at once
+		l.limit = math.Max(float64(l.min), math.Min(float64(fixed), float64(l.max)))
+	default:
+		l.limit = math.Max(float64(l.min), math.Min(l.limit, float64(l.max)))
+	}
+	l.resetWindow()
+	l.wake()
+}
+
+func valueOr[T int | float64 | time.Duration](value, fallback T) T {
+	if value > 0 {
+		return value
+	}
+	return fallback
+}
+
+// acquire waits for a free slot open to the priority of ctx's send, or returns ctx's error if it ends first
+func (l *concurrencyLimiter) acquire(ctx context.Context) error {
+	rank := sendRank(ctx)
+	l.mu.Lock()
+	l.waiting[rank]++
+	for {
+		if l.inFlight < l.capacity(rank) && !l.outranked(rank) {
+			l.inFlight++
+			l.peak = max(l.peak, l.inFlight)
+			l.stopWaiting(rank)
+			l.mu.Unlock()
+			return nil
+		}
+		released := l.released
+		l.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			l.mu.Lock()
+			l.stopWaiting(rank)
+			l.mu.Unlock()
+			return ctx.Err()
+		case <-released:
+		}
+		l.mu.Lock()
+	}
+}
+
+// capacity returns how many sends may be in flight when one of the rank takes a slot: the whole limit for high
+// priority, less the reserve for the rest. The reserve always leaves them at least one slot. l.mu is held.
+func (l *concurrencyLimiter) capacity(rank int) int {
+	limit := int(l.limit)
+	if rank == rankHigh {
+		return limit
+	}
+	return limit - l.reserved(limit)
+}
+
+// reserved returns the slots of limit kept for high priority sends
+func (l *concurrencyLimiter) reserved(limit int) int {
+	return max(0, min(int(float64(limit)*l.reserve), limit-1))
+}
+
+// outranked reports whether a sender of higher priority than rank is waiting; l.mu is held
+func (l *concurrencyLimiter) outranked(rank int) bool {
+	for r := 0; r < rank; r++ {
+		if l.waiting[r] > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// stopWaiting removes a sender of the rank from the waiting ones. Lower priority senders it held back are woken
+// to recheck, as a slot may still be free for them. l.mu is held.
+func (l *concurrencyLimiter) stopWaiting(rank int) {
+	l.waiting[rank]--
+	if l.waiting[rank] == 0 {
+		for r := rank + 1; r < priorityRanks; r++ {
+			if l.waiting[r] > 0 {
+				l.wake()
+				return
+			}
+		}
+	}
+}
+
+// acquireSlot takes a slot of a fixed semaphore, or returns ctx's error if it ends first
+func acquireSlot(ctx context.Context, semaphore chan struct{}) error {
+	select {
+	case semaphore <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// release frees a slot and records how the send went: how long the provider took and whether it failed
+func (l *concurrencyLimiter) release(took time.Duration, failed bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.inFlight--
+	if l.adaptive {
+		l.sends++
+		l.totalTime += took
+		if failed {
+			l.failures++
+		}
+		if l.sends >= l.window {
+			l.adjust()
+		}
+	}
+	l.wake()
+}
+
+// adjust ends a window, moving the limit by what the window saw; l.mu is held
+func (l *concurrencyLimiter) adjust() {
+	average := l.totalTime / time.Duration(l.sends)
+	failureRate := float64(l.failures) / float64(l.sends)
+	var poolWaits int64
+	if l.poolStats != nil {
+		poolWaits = l.poolStats().WaitCount - l.poolWaits
+	}
+
+	reason := ""
+	switch {
+	case failureRate > l.errorRate:
+		reason = "provider errors"
+	case average > l.latency:
+		reason = "provider latency"
+	case poolWaits > 0:
+		reason = "database pool saturated"
+	}
+
+	previous := int(l.limit)
+	if reason != "" {
+		l.limit = math.Max(float64(l.min), math.Floor(l.limit*l.backoff))
+		if int(l.limit) < previous {
+			l.decreases++
+			l.lastReason = reason
+			log.Printf("Send concurrency lowered from %d to %d: %s (%.0f%% failed, %s average)",
+				previous, int(l.limit), reason, failureRate*100, average.Round(time.Millisecond))
+		}
+	} else if l.peak >= previous && previous < l.max {
+		// Only grow a limit that was the bottleneck; an idle limit says nothing about the provider
+		l.limit++
+		l.increases++
+		l.lastReason = "healthy"
+	}
+	l.resetWindow()
+}
+
+// resetWindow starts a new window; l.mu is held
+func (l *concurrencyLimiter) resetWindow() {
+	l.sends, l.failures, l.totalTime, l.peak = 0, 0, 0, l.inFlight
+	if l.poolStats != nil {
+		l.poolWaits = l.poolStats().WaitCount
+	}
+}
+
+// wake lets waiting senders recheck for a free slot; l.mu is held
+func (l *concurrencyLimiter) wake() {
+	close(l.released)
+	l.released = make(chan struct{})
+}
+
+// current returns the limit sends are held to right now
+func (l *concurrencyLimiter) current() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return int(l.limit)
+}
+
+// stats reports the limiter's state
+func (l *concurrencyLimiter) stats() ConcurrencyStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return ConcurrencyStats{
+		Adaptive:         l.adaptive,
+		Limit:            int(l.limit),
+		InFlight:         l.inFlight,
+		Reserved:         l.reserved(int(l.limit)),
+		Waiting:          l.waiting[rankHigh] + l.waiting[rankNormal] + l.waiting[rankLow],
+		Min:              l.min,
+		Max:              l.max,
+		Increases:        l.increases,
+		Decreases:        l.decreases,
+		LastChangeReason: l.lastReason,
+	}
+}
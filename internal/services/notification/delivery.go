@@ -0,0 +1,271 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"newsletter-service/internal/constants"
+	"newsletter-service/internal/daos"
+	"newsletter-service/internal/services/content"
+)
+
+// ErrDeliveryNotInProgress is returned when force-completing content that isn't sending
+var ErrDeliveryNotInProgress = errors.New("content delivery is not in progress")
+
+// deliveryRefreshBatch bounds how many sending contents one refresh counts
+const deliveryRefreshBatch = 500
+
+// forceCompletedError is the error message of emails cancelled by ForceCompleteDelivery
+const forceCompletedError = "cancelled: delivery was force-completed"
+
+// outstandingLog matches email logs the worker will still send: queued, or failed with retries left. SMS and
+// push are never retried, so only emails can be outstanding.
+const outstandingLog = "status IN ('" + constants.StatusPending + "', '" + constants.StatusFailed + "') AND channel = '" +
+	constants.NotificationTypeEmail + "' AND retry_count < ?"
+
+// RefreshDeliveryProgress counts the recipients of content that is still sending and completes the delivery of
+// content with none outstanding. It returns how many deliveries completed.
+func (s *notificationService) RefreshDeliveryProgress(ctx context.Context) (int, error) {
+	var contentIDs []uint
+	err := s.db.WithContext(ctx).Model(&content.Content{}).
+		Where("delivery_state = ?", daos.DeliveryStateSending).
+		Order("id").
+		Limit(deliveryRefreshBatch).
+		Pluck("id", &contentIDs).Error
+	if err != nil || len(contentIDs) == 0 {
+		return 0, err
+	}
+
+	progress, err := s.countDeliveries(s.db.WithContext(ctx), contentIDs)
+	if err != nil {
+		return 0, err
+	}
+
+	completed := 0
+	now := time.Now()
+	for _, contentID := range contentIDs {
+		p := progress[contentID]
+		updates := map[string]interface{}{
+			"delivery_sent":        p.Sent,
+			"delivery_failed":      p.Failed,
+			"delivery_outstanding": p.Outstanding,
+		}
+		if p.Outstanding == 0 {
+			updates["delivery_state"] = daos.DeliveryStateComplete
+			updates["delivery_completed_at"] = now
+		}
+
+		// Content force-completed since it was listed keeps that state
+		result := s.db.WithContext(ctx).Model(&content.Content{}).
+			Where("id = ? AND delivery_state = ?", contentID, daos.DeliveryStateSending).
+			Updates(updates)
+		if result.Error != nil {
+			return completed, fmt.Errorf("failed to update delivery of content %d: %w", contentID, result.Error)
+		}
+		if p.Outstanding == 0 && result.RowsAffected > 0 {
+			completed++
+			s.reportSend(ctx, contentID)
+		}
+	}
+	return completed, nil
+}
+
+// GetDeliveryProgress counts where the recipients of a content stand now, without waiting for the worker.
+// It returns gorm.ErrRecordNotFound for unknown content.
+func (s *notificationService) GetDeliveryProgress(ctx context.Context, contentID uint) (*DeliveryProgress, error) {
+	contentModel, err := s.contentService.GetContentByID(ctx, contentID)
+	if err != nil {
+		return nil, err
+	}
+
+	progress, err := s.countDeliveries(s.db.WithContext(ctx), []uint{contentID})
+	if err != nil {
+		return nil, err
+	}
+	p := progress[contentID]
+	p.State = contentModel.DeliveryState
+	p.CompletedAt = contentModel.DeliveryCompletedAt
+	return &p, nil
+}
+
+// ForceCompleteDelivery closes the delivery of a content that is still sending. Its outstanding emails are
+// failed for good so neither the queue nor the retries send them. It returns ErrDeliveryNotInProgress for
+// content that isn't sending and gorm.ErrRecordNotFound for unknown content.
+func (s *notificationService) ForceCompleteDelivery(ctx context.Context, contentID uint) (*DeliveryProgress, error) {
+	contentModel, err := s.contentService.GetContentByID(ctx, contentID)
+	if err != nil {
+		return nil, err
+	}
+	if contentModel.DeliveryState != daos.DeliveryStateSending {
+		return nil, ErrDeliveryNotInProgress
+	}
+
+	var p DeliveryProgress
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&EmailLog{}).
+			Where("content_id = ?", contentID).
+			Where(outstandingLog, constants.MaxEmailRetryCount).
+			Updates(map[string]interface{}{
+				"status":        constants.StatusFailed,
+				"retry_count":   constants.MaxEmailRetryCount,
+				"error_message": gorm.Expr("COALESCE(error_message, ?)", forceCompletedError),
+			}).Error
+		if err != nil {
+			return err
+		}
+
+		progress, err := s.countDeliveries(tx, []uint{contentID})
+		if err != nil {
+			return err
+		}
+		p = progress[contentID]
+		// Recipients without an email log are given up on with the rest
+		p.Failed, p.Outstanding = p.Failed+p.Outstanding, 0
+		now := time.Now()
+		p.State, p.CompletedAt = daos.DeliveryStateForceCompleted, &now
+
+		result := tx.Model(&content.Content{}).
+			Where("id = ? AND delivery_state = ?", contentID, daos.DeliveryStateSending).
+			Updates(map[string]interface{}{
+				"delivery_state":        p.State,
+				"delivery_sent":         p.Sent,
+				"delivery_failed":       p.Failed,
+				"delivery_outstanding":  p.Outstanding,
+				"delivery_completed_at": now,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrDeliveryNotInProgress // Completed by the worker in the meantime
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.reportSend(ctx, contentID)
+	return &p, nil
+}
+
+// expectRecipients records that a send is about to go out to the given email recipients of the content. Until
+// each has an email log, waiting in a provider batch or lost to a log that failed to save, countDeliveries
+// counts the difference as outstanding. The count is set to the content's email logs plus the recipients
+// without one, so dispatching the content again doesn't count a recipient twice.
+func (s *notificationService) expectRecipients(ctx context.Context, contentID uint, subscriberIDs []uint) {
+	db := s.db.WithContext(context.WithoutCancel(ctx))
+
+	var loggedIDs []uint
+	err := db.Model(&EmailLog{}).
+		Where("content_id = ? AND channel = ?", contentID, constants.NotificationTypeEmail).
+		Distinct().
+		Pluck("subscriber_id", &loggedIDs).Error
+	if err != nil {
+		fmt.Printf("Failed to record %d recipients of content %d: %v\n", len(subscriberIDs), contentID, err)
+		return
+	}
+	logged := make(map[uint]bool, len(loggedIDs))
+	for _, id := range loggedIDs {
+		logged[id] = true
+	}
+	unlogged := 0
+	for _, id := range subscriberIDs {
+		if !logged[id] {
+			unlogged++
+		}
+	}
+
+	logs := db.Model(&EmailLog{}).Select("COUNT(*)").
+		Where("content_id = ? AND channel = ?", contentID, constants.NotificationTypeEmail)
+	err = db.Model(&content.Content{}).
+		Where("id = ?", contentID).
+		UpdateColumn("delivery_recipients", gorm.Expr("(?) + ?", logs, unlogged)).Error
+	if err != nil {
+		fmt.Printf("Failed to record %d recipients of content %d: %v\n", len(subscriberIDs), contentID, err)
+	}
+}
+
+// reopenDelivery puts completed content back to sending after some of its emails were queued again. It is
+// reported again once they are through.
+func (s *notificationService) reopenDelivery(tx *gorm.DB, contentID uint) error {
+	return tx.Model(&content.Content{}).
+		Where("id = ? AND delivery_state = ?", contentID, daos.DeliveryStateComplete).
+		Updates(map[string]interface{}{
+			"delivery_state":        daos.DeliveryStateSending,
+			"delivery_completed_at": nil,
+			"delivery_report_due":   true,
+		}).Error
+}
+
+// countDeliveries counts the email logs of each content by where their recipient stands, in one query. Email
+// recipients dispatched without a log yet are outstanding too, or failed once the delivery was force-completed.
+func (s *notificationService) countDeliveries(db *gorm.DB, contentIDs []uint) (map[uint]DeliveryProgress, error) {
+	var rows []struct {
+		ContentID   uint
+		Total       int64
+		Emails      int64
+		Sent        int64
+		Outstanding int64
+	}
+	err := db.Model(&EmailLog{}).
+		Select(`content_id, COUNT(*) AS total,
+			COUNT(*) FILTER (WHERE channel = ?) AS emails,
+			COUNT(*) FILTER (WHERE status = ?) AS sent,
+			COUNT(*) FILTER (WHERE `+outstandingLog+`) AS outstanding`,
+			constants.NotificationTypeEmail, constants.StatusSent, constants.MaxEmailRetryCount).
+		Where("content_id IN ?", contentIDs).
+		Group("content_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to count deliveries: %w", err)
+	}
+
+	var dispatched []struct {
+		ID                 uint
+		DeliveryState      string
+		DeliveryRecipients int64
+	}
+	err = db.Model(&content.Content{}).
+		Select("id, delivery_state, delivery_recipients").
+		Where("id IN ?", contentIDs).
+		Scan(&dispatched).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to count dispatched recipients: %w", err)
+	}
+
+	recipients := make(map[uint]int64, len(dispatched))
+	forced := make(map[uint]bool)
+	for _, c := range dispatched {
+		recipients[c.ID] = c.DeliveryRecipients
+		forced[c.ID] = c.DeliveryState == daos.DeliveryStateForceCompleted
+	}
+	logged := make(map[uint]int64, len(rows))
+	progress := make(map[uint]DeliveryProgress, len(contentIDs))
+	for _, row := range rows {
+		logged[row.ContentID] = row.Emails
+		progress[row.ContentID] = DeliveryProgress{
+			ContentID:   row.ContentID,
+			Sent:        row.Sent,
+			Failed:      row.Total - row.Sent - row.Outstanding,
+			Outstanding: row.Outstanding,
+		}
+	}
+	for _, id := range contentIDs {
+		p := progress[id]
+		p.ContentID = id
+		// Content sent before recipients were counted, or with digests logged against it, can have more logs
+		if unlogged := recipients[id] - logged[id]; unlogged > 0 {
+			if forced[id] {
+				p.Failed += unlogged
+			} else {
+				p.Outstanding += unlogged
+			}
+		}
+		progress[id] = p
+	}
+	return progress, nil
+}
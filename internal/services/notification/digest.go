@@ -0,0 +1,214 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"gorm.io/gorm"
+
+	"newsletter-service/internal/constants"
+	"newsletter-service/internal/daos"
+	"newsletter-service/internal/providers/templates"
+	"newsletter-service/internal/services/content"
+	"newsletter-service/internal/tenant"
+	"newsletter-service/internal/tracing"
+)
+
+// Digest periods per frequency
+const (
+	dailyDigestPeriod  = 24 * time.Hour
+	weeklyDigestPeriod = 7 * 24 * time.Hour
+)
+
+// digestKey groups a subscriber's due preferences of one frequency into one digest
+type digestKey struct {
+	subscriberID uint
+	frequency    string
+}
+
+// QueueDigests queues a digest email for every subscriber whose daily or weekly email preferences are due.
+// A digest holds the content sent on those topics since the subscriber's last digest and is sent with the
+// other queued emails, so it shares their warm-up caps and retries. It returns the number of digests queued.
+func (s *notificationService) QueueDigests(ctx context.Context) (int, error) {
+	ctx, span := tracing.StartSpan(ctx, "notification.QueueDigests")
+	defer span.End()
+
+	now := time.Now()
+	var due []*daos.NotificationPreference
+	err := s.db.WithContext(ctx).
+		Where("channel = ?", constants.NotificationTypeEmail).
+		Where("(frequency = ? AND COALESCE(last_digest_at, created_at) <= ?) OR (frequency = ? AND COALESCE(last_digest_at, created_at) <= ?)",
+			daos.FrequencyDaily, now.Add(-dailyDigestPeriod), daos.FrequencyWeekly, now.Add(-weeklyDigestPeriod)).
+		Order("subscriber_id, topic_id").
+		Find(&due).Error
+	if err != nil {
+		tracing.RecordError(span, err)
+		return 0, fmt.Errorf("failed to get due digest preferences: %w", err)
+	}
+
+	groups := make(map[digestKey][]*daos.NotificationPreference)
+	var keys []digestKey
+	for _, p := range due {
+		key := digestKey{subscriberID: p.SubscriberID, frequency: p.Frequency}
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], p)
+	}
+
+	queued := 0
+	for _, key := range keys {
+		ok, err := s.queueDigest(ctx, key, groups[key], now)
+		if err != nil {
+			tracing.RecordError(span, err)
+			fmt.Printf("Failed to queue %s digest for subscriber %d: %v\n", key.frequency, key.subscriberID, err)
+			continue
+		}
+		if ok {
+			queued++
+		}
+	}
+
+	span.SetAttributes(attribute.Int("digest.due", len(keys)), attribute.Int("digest.queued", queued))
+	return queued, nil
+}
+
+// queueDigest queues one subscriber's digest and moves its preferences' window to now. Nothing is queued when
+// no content was sent, or the subscriber is gone or no longer receives email; the window still moves on.
+func (s *notificationService) queueDigest(ctx context.Context, key digestKey, preferences []*daos.NotificationPreference, now time.Time) (bool, error) {
+	var contents []*content.Content
+	subscriber, err := s.subscriberService.GetSubscriberByID(ctx, key.subscriberID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, err
+	}
+	if err == nil {
+		// The digest is made of the subscriber's organization's content and logged to it
+		ctx = tenant.WithOrganization(ctx, subscriber.OrganizationID)
+	}
+	if err == nil && subscriber.IsActive && daos.HasChannel(subscriber.Channels, constants.NotificationTypeEmail) {
+		contents, err = s.digestContents(ctx, subscriber, preferences, now)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	preferenceIDs := make([]uint, len(preferences))
+	for i, p := range preferences {
+		preferenceIDs[i] = p.ID
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if len(contents) > 0 {
+			latest := contents[len(contents)-1]
+			// The digest's own words follow the subscriber's locale, else the language of its latest content
+			digestLocale := subscriber.Locale
+			if digestLocale == "" {
+				digestLocale = latest.Locale
+			}
+			emailLog := &EmailLog{
+				SubscriberID: subscriber.ID,
+				ContentID:    latest.ID,
+				EmailAddress: subscriber.Email,
+				Subject:      digestSubject(key.frequency, digestLocale, latest.Title),
+				Body:         digestBody(contents),
+				Locale:       digestLocale,
+				Status:       constants.StatusPending,
+				Channel:      constants.NotificationTypeEmail,
+			}
+			if err := s.storeBody(tx, emailLog); err != nil {
+				return err
+			}
+			if err := tx.Create(emailLog).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Model(&daos.NotificationPreference{}).Where("id IN ?", preferenceIDs).Update("last_digest_at", now).Error
+	})
+	if err != nil {
+		return false, err
+	}
+	return len(contents) > 0, nil
+}
+
+// digestContents returns the email content sent on the preferences' topics within their digest windows,
+// oldest first and translated for the subscriber. Topics the subscriber has since unsubscribed from are left out,
+// as is premium content of topics they don't pay for.
+func (s *notificationService) digestContents(ctx context.Context, subscriber *daos.Subscriber, preferences []*daos.NotificationPreference, now time.Time) ([]*content.Content, error) {
+	subscriptions, err := s.subscriberService.GetSubscriptionsBySubscriberID(ctx, subscriber.ID)
+	if err != nil {
+		return nil, err
+	}
+	subscribed := make(map[uint]bool, len(subscriptions))
+	for _, subscription := range subscriptions {
+		subscribed[subscription.TopicID] = true
+	}
+
+	var contents []*content.Content
+	var entitledTopics map[uint]bool // Loaded with the first premium content
+	for _, p := range preferences {
+		if !subscribed[p.TopicID] {
+			continue
+		}
+		since := p.CreatedAt
+		if p.LastDigestAt != nil {
+			since = *p.LastDigestAt
+		}
+
+		var sent []*content.Content
+		err := s.db.WithContext(ctx).
+			Preload("Translations").
+			Where("topic_id = ? AND notifications_sent = ? AND notifications_sent_at > ? AND notifications_sent_at <= ?", p.TopicID, true, since, now).
+			Find(&sent).Error
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range sent {
+			if !daos.HasChannel(c.Channels, constants.NotificationTypeEmail) {
+				continue
+			}
+			if c.Premium && entitledTopics == nil {
+				if entitledTopics, err = s.loadEntitledTopics(ctx, subscriber.ID); err != nil {
+					return nil, err
+				}
+			}
+			if c.Premium && !entitledTopics[c.TopicID] {
+				continue
+			}
+			contents = append(contents, s.renderContent(ctx, localizeContent(c, subscriber.Locale)))
+		}
+	}
+
+	sort.Slice(contents, func(i, j int) bool {
+		return contents[i].NotificationsSentAt.Before(*contents[j].NotificationsSentAt)
+	})
+	return contents, nil
+}
+
+// digestSubject names the digest after its latest content, in the language of the locale
+func digestSubject(frequency, digestLocale, latestTitle string) string {
+	text := templates.StringsFor(digestLocale)
+	format := text.DailyDigestSubject
+	if frequency == daos.FrequencyWeekly {
+		format = text.WeeklyDigestSubject
+	}
+	return fmt.Sprintf(format, truncateText(latestTitle, 200))
+}
+
+// digestBody lists each content under its title, separated by rules
+func digestBody(contents []*content.Content) string {
+	var body strings.Builder
+	for i, c := range contents {
+		if i > 0 {
+			body.WriteString("\n<hr>\n")
+		}
+		body.WriteString("<h2>" + html.EscapeString(c.Title) + "</h2>\n")
+		body.WriteString(c.Body)
+	}
+	return body.String()
+}
@@ -0,0 +1,56 @@
+package notification
+
+import (
+	"context"
+
+	"newsletter-service/internal/daos"
+	"newsletter-service/internal/services/content"
+)
+
+// entitlements holds the subscribers paying for a plan of a premium content's topic. It is nil for content
+// that isn't premium, which every subscriber receives.
+type entitlements map[uint]bool
+
+// loadEntitlements reads who may receive the content
+func (s *notificationService) loadEntitlements(ctx context.Context, c *content.Content) (entitlements, error) {
+	if !c.Premium {
+		return nil, nil
+	}
+	var subscriberIDs []uint
+	err := s.db.WithContext(ctx).Model(&daos.BillingSubscription{}).
+		Where("topic_id = ? AND status IN ?", c.TopicID, daos.EntitledBillingStatuses).
+		Distinct().
+		Pluck("subscriber_id", &subscriberIDs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	entitled := make(entitlements, len(subscriberIDs))
+	for _, id := range subscriberIDs {
+		entitled[id] = true
+	}
+	return entitled, nil
+}
+
+// allows reports whether the subscriber receives the content
+func (e entitlements) allows(subscriberID uint) bool {
+	return e == nil || e[subscriberID]
+}
+
+// loadEntitledTopics returns the topics whose premium content a subscriber pays for
+func (s *notificationService) loadEntitledTopics(ctx context.Context, subscriberID uint) (map[uint]bool, error) {
+	var topicIDs []uint
+	err := s.db.WithContext(ctx).Model(&daos.BillingSubscription{}).
+		Where("subscriber_id = ? AND status IN ?", subscriberID, daos.EntitledBillingStatuses).
+		Distinct().
+		Pluck("topic_id", &topicIDs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	topics := make(map[uint]bool, len(topicIDs))
+	for _, id := range topicIDs {
+		topics[id] = true
+	}
+	return topics, nil
+}
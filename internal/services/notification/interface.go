@@ -2,7 +2,9 @@ package notification
 
 import (
 	"context"
+	"time"
 
+	"newsletter-service/internal/config"
 	"newsletter-service/internal/providers"
 )
 
@@ -12,8 +14,31 @@ type Service interface {
 	SendNotificationsByContentIDWithProvider(ctx context.Context, contentID uint, provider providers.EmailProviderInterface) error
 	RetryFailedEmails(ctx context.Context) error
 	RetryFailedEmailsWithProvider(ctx context.Context, provider providers.EmailProviderInterface) error
+	RetryFailedEmailsInBatches(ctx context.Context, batchSize int) (*RetryResult, error)
+	RequeueFailedEmailsByContentID(ctx context.Context, contentID uint) (*RequeueResult, error)
+	RefreshDeliveryProgress(ctx context.Context) (int, error)
+	GetDeliveryProgress(ctx context.Context, contentID uint) (*DeliveryProgress, error)
+	ForceCompleteDelivery(ctx context.Context, contentID uint) (*DeliveryProgress, error)
+	GetSendReport(ctx context.Context, contentID uint) (*SendReport, error)
+	SendQueuedEmails(ctx context.Context) (int, error)
+	QueueDigests(ctx context.Context) (int, error)
 	GetEmailLogs(ctx context.Context) ([]*EmailLog, error)
 	GetEmailLogsWithPagination(ctx context.Context, offset, limit int) ([]*EmailLog, int64, error)
+	GetFilteredEmailLogsWithPagination(ctx context.Context, filter EmailLogFilter, offset, limit int) ([]*EmailLog, int64, error)
 	GetEmailLogByID(ctx context.Context, id uint) (*EmailLog, error)
+	ArchiveEmailLogs(ctx context.Context) (int64, error)
+	GetRecentEmailLogsBySubscriberIDs(ctx context.Context, subscriberIDs []uint, limit int) (map[uint][]*EmailLog, error)
+	CountEmailLogsByStatus(ctx context.Context) (map[string]int64, error)
+	GetContentEmailLogSummary(ctx context.Context, contentID uint, topDomains int) (*EmailLogSummary, error)
+	GetProviderStatuses(ctx context.Context) ([]ProviderStatus, error)
+	GetProviderStats(ctx context.Context, providerName string, window time.Duration) (*ProviderStatsReport, error)
+	PauseProvider(ctx context.Context, providerName, reason, pausedBy string) (*ProviderPause, error)
+	ResumeProvider(ctx context.Context, providerName string) (*ProviderPause, error)
+	GetProviderPauses(ctx context.Context) ([]*ProviderPause, error)
+	GetConcurrencyStats() ConcurrencyStats
+	GetSendTimeoutStats() SendTimeoutStats
 	LogEmail(ctx context.Context, log *EmailLog) error
+	RecordOpen(ctx context.Context, subscriberID, contentID uint) error
+	SendTransactionalEmail(ctx context.Context, email providers.EmailNotification) (string, error)
+	ApplyConfig(cfg *config.Config) error
 }
\ No newline at end of file
@@ -0,0 +1,21 @@
+package notification
+
+import (
+	"context"
+
+	"newsletter-service/internal/providers/templates"
+	"newsletter-service/internal/services/content"
+)
+
+// withTaggedLinks returns c with the links in its body tagged with its UTM parameters, the topic's filling the
+// ones the content leaves empty. The tagged body is what gets logged, so retries send the same links.
+func (s *notificationService) withTaggedLinks(ctx context.Context, c *content.Content) *content.Content {
+	utm := c.UTM.Merge(s.topicSettingsFor(ctx, c.TopicID).utm)
+	body := templates.TagLinks(c.Body, templates.LinkTags{Source: utm.Source, Medium: utm.Medium, Campaign: utm.Campaign})
+	if body == c.Body {
+		return c
+	}
+	tagged := *c
+	tagged.Body = body
+	return &tagged
+}
@@ -0,0 +1,115 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+
+	"newsletter-service/internal/providers"
+	"newsletter-service/internal/services/content"
+)
+
+// contentVariants hands out a content in each reader's language during one send. Every translation is
+// localized and rendered once and then shared by all its readers.
+type contentVariants struct {
+	original     *content.Content // Already rendered
+	translations []*content.Translation
+	localized    map[string]*content.Content // By translation locale
+	render       func(*content.Content) *content.Content
+}
+
+// contentVariantsFor loads the translations of c, which must already be rendered. A content whose
+// translations fail to load is sent untranslated rather than not at all.
+func (s *notificationService) contentVariantsFor(ctx context.Context, c *content.Content) *contentVariants {
+	translations, err := s.contentService.GetTranslations(ctx, c.ID)
+	if err != nil {
+		fmt.Printf("Failed to load translations for content %d, sending it untranslated: %v\n", c.ID, err)
+	}
+	return &contentVariants{
+		original:     c,
+		translations: translations,
+		localized:    make(map[string]*content.Content, len(translations)),
+		render:       func(c *content.Content) *content.Content { return s.renderContent(ctx, c) },
+	}
+}
+
+// forLocale returns the variant for a reader preferring preferred. Variants are only used from the sending
+// goroutine, so they need no locking.
+func (v *contentVariants) forLocale(preferred string) *content.Content {
+	localized := content.Localize(v.original, v.translations, preferred)
+	if localized == v.original {
+		return v.original
+	}
+	if variant, ok := v.localized[localized.Locale]; ok {
+		return variant
+	}
+	variant := v.render(localized)
+	v.localized[localized.Locale] = variant
+	return variant
+}
+
+// localizeContent returns c in the reader's language using the translations preloaded into it
+func localizeContent(c *content.Content, preferred string) *content.Content {
+	translations := make([]*content.Translation, len(c.Translations))
+	for i := range c.Translations {
+		translations[i] = &c.Translations[i]
+	}
+	return content.Localize(c, translations, preferred)
+}
+
+// variantGroup is the recipients of one variant of a content. emails and subscribers share indexes; emails
+// is left empty when the sender builds them itself.
+type variantGroup struct {
+	content     *content.Content
+	emails      []providers.EmailNotification
+	subscribers []struct {
+		ID    uint
+		Email string
+	}
+}
+
+// variantGroups collects a send's recipients by the variant they receive, in the order each variant was
+// first seen
+type variantGroups struct {
+	index  map[*content.Content]int
+	groups []*variantGroup
+}
+
+func (g *variantGroups) add(variant *content.Content, subscriberID uint, address string, email *providers.EmailNotification) {
+	if g.index == nil {
+		g.index = make(map[*content.Content]int)
+	}
+	i, ok := g.index[variant]
+	if !ok {
+		i = len(g.groups)
+		g.index[variant] = i
+		g.groups = append(g.groups, &variantGroup{content: variant})
+	}
+	group := g.groups[i]
+	if email != nil {
+		group.emails = append(group.emails, *email)
+	}
+	group.subscribers = append(group.subscribers, struct {
+		ID    uint
+		Email string
+	}{ID: subscriberID, Email: address})
+}
+
+// count returns the number of recipients in every group
+func (g *variantGroups) count() int {
+	total := 0
+	for _, group := range g.groups {
+		total += len(group.subscribers)
+	}
+	return total
+}
+
+// subscriberIDs returns the recipients in every group
+func (g *variantGroups) subscriberIDs() []uint {
+	ids := make([]uint, 0, g.count())
+	for _, group := range g.groups {
+		for _, sub := range group.subscribers {
+			ids = append(ids, sub.ID)
+		}
+	}
+	return ids
+}
@@ -1,9 +1,196 @@
 package notification
 
 import (
+	"time"
+
 	"newsletter-service/internal/daos"
 )
 
 // Type aliases for backward compatibility
 type EmailLog = daos.EmailLog
 type EmailNotification = daos.EmailNotification
+type WarmupCounter = daos.WarmupCounter
+type ProviderHourlyStat = daos.ProviderHourlyStat
+type ProviderPause = daos.ProviderPause
+type EmailLogBody = daos.EmailLogBody
+
+// EmailLogFilter narrows down and orders email log queries
+type EmailLogFilter struct {
+	Status       string
+	ContentID    uint
+	SubscriberID uint
+	EmailAddress string // Matched case-insensitively
+	Provider     string
+	MessageID    string // Provider message ID, for correlating bounces and webhook events
+	Channel      string // "email" or "sms"
+	From         *time.Time
+	To           *time.Time
+	SortBy       string // One of EmailLogSortFields; defaults to created_at
+	Ascending    bool
+	Archived     bool // List email_logs_archive instead of email_logs
+}
+
+// EmailLogSortFields lists the columns email logs can be sorted by
+var EmailLogSortFields = []string{"id", "created_at", "sent_at", "status", "retry_count"}
+
+// Failure classes derived from email log error messages
+const (
+	ErrorClassRateLimited = "rate_limited"
+	ErrorClassTimeout     = "timeout"
+	ErrorClassAuth        = "auth"
+	ErrorClassRejected    = "rejected"
+	ErrorClassConnection  = "connection"
+	ErrorClassOther       = "other"
+	ErrorClassUnknown     = "unknown" // Failed without an error message
+)
+
+// errorClassPatterns maps failure classes to case-insensitive error message patterns, checked in order
+var errorClassPatterns = []struct {
+	Class    string
+	Patterns []string
+}{
+	{ErrorClassRateLimited, []string{"%rate limit%", "%too many%", "%429%", "%throttl%"}},
+	{ErrorClassTimeout, []string{"%timeout%", "%timed out%", "%deadline exceeded%"}},
+	{ErrorClassAuth, []string{"%auth%", "%535%", "%401%", "%403%"}},
+	{ErrorClassRejected, []string{"%reject%", "%550%", "%551%", "%553%", "%bounce%", "%blocked%", "%invalid%"}},
+	{ErrorClassConnection, []string{"%connection%", "%dial%", "%eof%", "%no such host%"}},
+}
+
+// DefaultTopFailingDomains is the number of domains reported in an email log summary
+const DefaultTopFailingDomains = 10
+
+// EmailLogSummary aggregates the delivery logs of one content
+type EmailLogSummary struct {
+	ContentID         uint             `json:"content_id"`
+	Total             int64            `json:"total"`
+	ByStatus          map[string]int64 `json:"by_status"`
+	ByErrorClass      map[string]int64 `json:"by_error_class"` // Failed logs only
+	TopFailingDomains []DomainFailures `json:"top_failing_domains"`
+}
+
+// DomainFailures counts failed sends to one recipient domain
+type DomainFailures struct {
+	Domain      string  `json:"domain"`
+	Failed      int64   `json:"failed"`
+	Total       int64   `json:"total"`
+	FailureRate float64 `json:"failure_rate"`
+}
+
+// RequeueResult reports which failed email logs of a content were queued for another attempt
+type RequeueResult struct {
+	ContentID  uint  `json:"content_id"`
+	Requeued   int64 `json:"requeued"`
+	Exhausted  int64 `json:"exhausted"`  // Skipped: retry limit reached
+	Suppressed int64 `json:"suppressed"` // Skipped: subscriber inactive or deleted
+}
+
+// DeliveryProgress is where each recipient of a sent content stands. Outstanding recipients are queued, or
+// failed with retries left; the delivery is complete when there are none.
+type DeliveryProgress struct {
+	ContentID   uint       `json:"content_id"`
+	State       string     `json:"state"` // One of daos.DeliveryState*, empty until the content is sent
+	Sent        int64      `json:"sent"`
+	Failed      int64      `json:"failed"` // Failed for good: out of retries, or an SMS or push that failed
+	Outstanding int64      `json:"outstanding"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+// SendReport summarises the delivery of one content, made when it completes
+type SendReport struct {
+	ContentID  uint                `json:"content_id"`
+	TopicID    uint                `json:"topic_id"`
+	Title      string              `json:"title"`
+	State      string              `json:"state"`
+	Recipients int64               `json:"recipients"`
+	Delivered  int64               `json:"delivered"`
+	Failed     int64               `json:"failed"`                // Including emails cancelled by a force-complete
+	StartedAt  *time.Time          `json:"started_at,omitempty"`  // First email logged
+	FinishedAt *time.Time          `json:"finished_at,omitempty"` // Last email sent or failed
+	Duration   string              `json:"duration,omitempty"`
+	TopErrors  []ErrorCount        `json:"top_errors"`
+	Providers  []ProviderBreakdown `json:"providers"`
+}
+
+// ErrorCount is how many of a content's emails failed with one error message
+type ErrorCount struct {
+	Error string `json:"error"`
+	Count int64  `json:"count"`
+}
+
+// ProviderBreakdown counts a content's emails by the provider that sent them
+type ProviderBreakdown struct {
+	Provider string `json:"provider"`
+	Sent     int64  `json:"sent"`
+	Failed   int64  `json:"failed"`
+}
+
+// RetryResult counts the outcome of one retry run over failed emails
+type RetryResult struct {
+	Attempted int `json:"attempted"`
+	Sent      int `json:"sent"`
+	Skipped   int `json:"skipped"` // Subscriber inactive or deleted, or no provider available
+}
+
+// ConcurrencyStats is how many emails the service sends at once and how the limit has moved
+type ConcurrencyStats struct {
+	Adaptive         bool   `json:"adaptive"`
+	Limit            int    `json:"limit"`
+	InFlight         int    `json:"in_flight"`
+	Reserved         int    `json:"reserved"` // Slots of the limit only high priority sends may use
+	Waiting          int    `json:"waiting"`  // Sends waiting for a slot
+	Min              int    `json:"min"`
+	Max              int    `json:"max"`
+	Increases        int64  `json:"increases"`
+	Decreases        int64  `json:"decreases"`
+	LastChangeReason string `json:"last_change_reason,omitempty"`
+}
+
+// SendTimeoutStats counts provider calls that ran past [worker] send_timeout and were abandoned as failed
+type SendTimeoutStats struct {
+	SendTimeout string           `json:"send_timeout"`
+	Timeouts    int64            `json:"timeouts"`
+	ByProvider  map[string]int64 `json:"by_provider"`
+}
+
+// ProviderStatus reports the health, load and warm-up progress of one configured email provider
+type ProviderStatus struct {
+	Name               string          `json:"name"`
+	Type               string          `json:"type"`
+	Priority           int             `json:"priority"`
+	IsHealthy          bool            `json:"is_healthy"`
+	Paused             bool            `json:"paused"` // New emails go to the other providers until it is resumed
+	EmailsSentLastHour int             `json:"emails_sent_last_hour"`
+	CurrentLoad        int             `json:"current_load"` // Percentage of the hourly limit
+	LastError          string          `json:"last_error,omitempty"`
+	Warmup             *WarmupProgress `json:"warmup,omitempty"` // Only for providers with a warm-up policy
+}
+
+// WarmupProgress is where a warm-up policy stands today
+type WarmupProgress struct {
+	Policy    string `json:"policy"`     // Provider name or sending domain the cap is shared by
+	StartDate string `json:"start_date"` // YYYY-MM-DD
+	Day       int    `json:"day"`        // 0 before the start date
+	TotalDays int    `json:"total_days"`
+	DailyCap  int    `json:"daily_cap"`
+	SentToday int    `json:"sent_today"`
+	Remaining int    `json:"remaining"`
+	Completed bool   `json:"completed"` // Past the last day, sends are uncapped
+}
+
+// ProviderStatsReport is one provider's persisted send counts over a range, hour by hour
+type ProviderStatsReport struct {
+	Provider    string              `json:"provider"`
+	From        time.Time           `json:"from"`
+	To          time.Time           `json:"to"` // Exclusive; the last hour is still in progress
+	Sent        int                 `json:"sent"`
+	Failed      int                 `json:"failed"`
+	FailureRate float64             `json:"failure_rate"`
+	Hours       []ProviderHourStats `json:"hours"`
+}
+
+// ProviderHourStats counts one hour of a provider's sends
+type ProviderHourStats struct {
+	Hour   time.Time `json:"hour"`
+	Sent   int       `json:"sent"`
+	Failed int       `json:"failed"`
+}
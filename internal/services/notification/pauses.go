@@ -0,0 +1,84 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"newsletter-service/internal/providers"
+)
+
+// providerPauseRefresh is how often paused providers are read again, so a pause made through the web API
+// reaches the worker within it
+const providerPauseRefresh = 10 * time.Second
+
+// ErrProviderNotPaused is returned when resuming a provider that isn't paused
+var ErrProviderNotPaused = errors.New("provider is not paused")
+
+// newProviderPauses reads the paused providers from db
+func newProviderPauses(db *gorm.DB) *providers.ProviderPauses {
+	return providers.NewProviderPauses(func(ctx context.Context) ([]string, error) {
+		var names []string
+		err := db.WithContext(ctx).Model(&ProviderPause{}).Pluck("provider", &names).Error
+		return names, err
+	}, providerPauseRefresh)
+}
+
+// PauseProvider stops routing new emails to the provider; the other providers take its share until it is
+// resumed. Emails already handed to it, such as those waiting in its batch, still go out. Pausing a paused
+// provider replaces the reason. The pause is stored, so restarted workers keep the provider paused.
+func (s *notificationService) PauseProvider(ctx context.Context, providerName, reason, pausedBy string) (*ProviderPause, error) {
+	known, err := s.isProviderKnown(ctx, providerName)
+	if err != nil {
+		return nil, err
+	}
+	if !known {
+		return nil, ErrProviderNotFound
+	}
+
+	pause := &ProviderPause{Provider: providerName, Reason: reason, PausedBy: pausedBy}
+	err = s.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "provider"}},
+		DoUpdates: clause.AssignmentColumns([]string{"reason", "paused_by", "updated_at"}),
+	}, clause.Returning{}).Create(pause).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to pause provider %s: %w", providerName, err)
+	}
+
+	s.pauses.Invalidate()
+	log.Printf("Provider %s paused by %s: %s", providerName, pausedBy, reason)
+	return pause, nil
+}
+
+// ResumeProvider routes new emails to a paused provider again and returns the pause it lifted. Emails queued
+// while every provider was paused are sent by the worker's next run.
+func (s *notificationService) ResumeProvider(ctx context.Context, providerName string) (*ProviderPause, error) {
+	var pause ProviderPause
+	err := s.db.WithContext(ctx).Where("provider = ?", providerName).First(&pause).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrProviderNotPaused
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pause of provider %s: %w", providerName, err)
+	}
+
+	if err := s.db.WithContext(ctx).Delete(&pause).Error; err != nil {
+		return nil, fmt.Errorf("failed to resume provider %s: %w", providerName, err)
+	}
+
+	s.pauses.Invalidate()
+	log.Printf("Provider %s resumed", providerName)
+	return &pause, nil
+}
+
+// GetProviderPauses returns the paused providers by name
+func (s *notificationService) GetProviderPauses(ctx context.Context) ([]*ProviderPause, error) {
+	var pauses []*ProviderPause
+	err := s.db.WithContext(ctx).Order("provider").Find(&pauses).Error
+	return pauses, err
+}
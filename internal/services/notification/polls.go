@@ -0,0 +1,63 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"newsletter-service/internal/config"
+	"newsletter-service/internal/providers/templates"
+	"newsletter-service/internal/services/content"
+	"newsletter-service/internal/services/poll"
+)
+
+// pollsConfig returns the current polls configuration
+func (s *notificationService) pollsConfig() config.PollsConfig {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.polls
+}
+
+// withPolls returns c with the polls its body includes rendered in place, their answer links left for each
+// email to sign. Includes are removed while polls are off, or when the polls can't be loaded, so template syntax
+// never reaches subscribers.
+func (s *notificationService) withPolls(ctx context.Context, c *content.Content) *content.Content {
+	ids := poll.IDs(c.Body)
+	if len(ids) == 0 {
+		return c
+	}
+
+	polls := make(map[uint]*poll.Poll, len(ids))
+	cfg := s.pollsConfig()
+	if cfg.Enabled {
+		var found []*poll.Poll
+		err := s.db.WithContext(ctx).
+			Preload("Options", func(db *gorm.DB) *gorm.DB { return db.Order("position, id") }).
+			Where("id IN ? AND content_id = ?", ids, c.ID).
+			Find(&found).Error
+		if err != nil {
+			fmt.Printf("Failed to load the polls of content %d, sending it without them: %v\n", c.ID, err)
+		}
+		for _, p := range found {
+			polls[p.ID] = p
+		}
+	}
+
+	body, err := poll.Replace(templates.BodyHTML(c.Body), polls, cfg.PublicURL)
+	if err != nil {
+		fmt.Printf("Failed to render the polls of content %d, sending it without them: %v\n", c.ID, err)
+		body, _ = poll.Replace(c.Body, nil, cfg.PublicURL)
+	}
+	rendered := *c
+	rendered.Body = body
+	return &rendered
+}
+
+// personalizePolls signs the answer links of body for the subscriber an email goes to
+func (s *notificationService) personalizePolls(body string, subscriberID uint) string {
+	if !poll.HasLinks(body) {
+		return body
+	}
+	return poll.Personalize(body, s.pollsConfig().Secret, subscriberID)
+}
@@ -0,0 +1,34 @@
+package notification
+
+import (
+	"context"
+
+	"newsletter-service/internal/daos"
+)
+
+// topicFrequencies holds each subscriber's chosen frequency per channel for one topic
+type topicFrequencies map[uint]map[string]string
+
+// loadTopicFrequencies reads the notification preferences set for a topic
+func (s *notificationService) loadTopicFrequencies(ctx context.Context, topicID uint) (topicFrequencies, error) {
+	var preferences []*daos.NotificationPreference
+	if err := s.db.WithContext(ctx).Where("topic_id = ?", topicID).Find(&preferences).Error; err != nil {
+		return nil, err
+	}
+
+	frequencies := make(topicFrequencies)
+	for _, p := range preferences {
+		if frequencies[p.SubscriberID] == nil {
+			frequencies[p.SubscriberID] = make(map[string]string)
+		}
+		frequencies[p.SubscriberID][p.Channel] = p.Frequency
+	}
+	return frequencies, nil
+}
+
+// instant reports whether the subscriber receives content on the channel as it is sent. Without a preference
+// they do; digest subscribers get it with their next digest and "off" not at all.
+func (f topicFrequencies) instant(subscriberID uint, channel string) bool {
+	frequency := f[subscriberID][channel]
+	return frequency == "" || frequency == daos.FrequencyInstant
+}
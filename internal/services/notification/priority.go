@@ -0,0 +1,41 @@
+package notification
+
+import (
+	"context"
+
+	"newsletter-service/internal/daos"
+	"newsletter-service/internal/services/content"
+)
+
+// Ranks of send priorities, highest first; they index the limiter's waiting senders
+const (
+	rankHigh = iota
+	rankNormal
+	rankLow
+	priorityRanks
+)
+
+type priorityKey struct{}
+
+// withSendPriority marks the sends made with ctx as coming from content of the given priority
+func withSendPriority(ctx context.Context, priority string) context.Context {
+	return context.WithValue(ctx, priorityKey{}, priority)
+}
+
+// sendRank returns the rank of the content ctx sends, normal when unmarked
+func sendRank(ctx context.Context) int {
+	priority, _ := ctx.Value(priorityKey{}).(string)
+	switch priority {
+	case daos.ContentPriorityHigh:
+		return rankHigh
+	case daos.ContentPriorityLow:
+		return rankLow
+	default:
+		return rankNormal
+	}
+}
+
+// isUrgent reports whether the content's emails should skip provider batching
+func isUrgent(c *content.Content) bool {
+	return c.Priority == daos.ContentPriorityHigh
+}
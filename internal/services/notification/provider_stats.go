@@ -0,0 +1,165 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"newsletter-service/internal/providers"
+)
+
+// Provider stats ranges, counted in whole hours
+const (
+	DefaultProviderStatsRange = 24 * time.Hour
+	MaxProviderStatsRange     = 30 * 24 * time.Hour
+)
+
+// ErrProviderNotFound is returned for stats of a provider that is neither configured nor has sent anything
+var ErrProviderNotFound = errors.New("provider not found")
+
+// ParseProviderStatsRange parses a stats range such as "24h" or "7d", between one hour and 30 days.
+// An empty range is DefaultProviderStatsRange.
+func ParseProviderStatsRange(value string) (time.Duration, error) {
+	if value == "" {
+		return DefaultProviderStatsRange, nil
+	}
+
+	var window time.Duration
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("range must be hours or days, e.g. 24h or 7d")
+		}
+		window = time.Duration(n) * 24 * time.Hour
+	} else {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			return 0, fmt.Errorf("range must be hours or days, e.g. 24h or 7d")
+		}
+		window = parsed
+	}
+
+	if window < time.Hour || window > MaxProviderStatsRange || window%time.Hour != 0 {
+		return 0, fmt.Errorf("range must be whole hours between 1h and 30d")
+	}
+	return window, nil
+}
+
+// recordProviderSends adds send outcomes to the provider's counter for the current hour. The counters live in
+// the database so every web and worker replica reports the same numbers, and survive restarts.
+func (s *notificationService) recordProviderSends(ctx context.Context, providerName string, sent, failed int) {
+	if providerName == "" || sent+failed == 0 {
+		return
+	}
+
+	now := time.Now()
+	stat := ProviderHourlyStat{
+		Provider:  providerName,
+		Hour:      now.UTC().Truncate(time.Hour),
+		Sent:      sent,
+		Failed:    failed,
+		UpdatedAt: now,
+	}
+	err := s.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "provider"}, {Name: "hour"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"sent":       gorm.Expr("provider_hourly_stats.sent + ?", sent),
+			"failed":     gorm.Expr("provider_hourly_stats.failed + ?", failed),
+			"updated_at": now,
+		}),
+	}).Create(&stat).Error
+	if err != nil {
+		fmt.Printf("Failed to record send stats for provider %s: %v\n", providerName, err)
+	}
+}
+
+// GetProviderStats returns a provider's hourly sends and failures over the window ending with the current hour.
+// Hours without sends are included with zero counts.
+func (s *notificationService) GetProviderStats(ctx context.Context, providerName string, window time.Duration) (*ProviderStatsReport, error) {
+	hours := int(window / time.Hour)
+	to := time.Now().UTC().Truncate(time.Hour)
+	from := to.Add(-time.Duration(hours-1) * time.Hour)
+
+	var rows []ProviderHourlyStat
+	err := s.db.WithContext(ctx).
+		Where("provider = ? AND hour >= ?", providerName, from).
+		Order("hour").
+		Find(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stats for provider %s: %w", providerName, err)
+	}
+
+	if len(rows) == 0 {
+		known, err := s.isProviderKnown(ctx, providerName)
+		if err != nil {
+			return nil, err
+		}
+		if !known {
+			return nil, ErrProviderNotFound
+		}
+	}
+
+	byHour := make(map[time.Time]ProviderHourlyStat, len(rows))
+	for _, row := range rows {
+		byHour[row.Hour.UTC()] = row
+	}
+
+	report := &ProviderStatsReport{
+		Provider: providerName,
+		From:     from,
+		To:       to.Add(time.Hour),
+		Hours:    make([]ProviderHourStats, 0, hours),
+	}
+	for hour := from; !hour.After(to); hour = hour.Add(time.Hour) {
+		row := byHour[hour]
+		report.Hours = append(report.Hours, ProviderHourStats{Hour: hour, Sent: row.Sent, Failed: row.Failed})
+		report.Sent += row.Sent
+		report.Failed += row.Failed
+	}
+	if attempts := report.Sent + report.Failed; attempts > 0 {
+		report.FailureRate = float64(report.Failed) / float64(attempts)
+	}
+
+	return report, nil
+}
+
+// isProviderKnown reports whether the provider is configured in this process or has sent from any other
+func (s *notificationService) isProviderKnown(ctx context.Context, providerName string) (bool, error) {
+	if s.isProviderConfigured(providerName) {
+		return true, nil
+	}
+	var count int64
+	err := s.db.WithContext(ctx).Model(&ProviderHourlyStat{}).Where("provider = ?", providerName).Limit(1).Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to get stats for provider %s: %w", providerName, err)
+	}
+	return count > 0, nil
+}
+
+// isProviderConfigured reports whether this process has an enabled provider with the name. The web API runs
+// without providers, so it only knows providers from their recorded stats.
+func (s *notificationService) isProviderConfigured(providerName string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.providerFactory == nil {
+		return false
+	}
+	factories := []*providers.ProviderFactory{s.providerFactory}
+	for _, factory := range s.orgFactories {
+		factories = append(factories, factory)
+	}
+	for _, factory := range factories {
+		for _, provider := range factory.GetProviders() {
+			if provider.GetProviderName() == providerName {
+				return true
+			}
+		}
+	}
+	return false
+}
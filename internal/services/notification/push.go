@@ -0,0 +1,134 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"newsletter-service/internal/constants"
+	"newsletter-service/internal/daos"
+	"newsletter-service/internal/providers"
+	"newsletter-service/internal/services/content"
+	"newsletter-service/internal/tracing"
+)
+
+// pushBodyLength keeps push text within what lock screens and notification centres show
+const pushBodyLength = 200
+
+// getPushFactory returns the push channel's providers, or nil when no push provider is enabled
+func (s *notificationService) getPushFactory() *providers.ProviderFactory {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.pushFactory
+}
+
+// sendPushNotifications pushes content to every registered device of the given subscribers, who turned push
+// on for the content's topic. Each device gets a log on the push channel. Devices the push service no
+// longer knows are removed; other failures are logged but not retried.
+func (s *notificationService) sendPushNotifications(ctx context.Context, c *content.Content, subscriberIDs []uint) int {
+	ctx, span := tracing.StartSpan(ctx, "notification.sendPushNotifications",
+		attribute.Int("content.id", int(c.ID)),
+		attribute.Int("push.subscribers", len(subscriberIDs)),
+	)
+	defer span.End()
+
+	pushFactory := s.getPushFactory()
+	if pushFactory == nil {
+		return 0
+	}
+
+	var devices []*daos.PushDevice
+	if err := s.db.WithContext(ctx).Where("subscriber_id IN ?", subscriberIDs).Find(&devices).Error; err != nil {
+		tracing.RecordError(span, err)
+		fmt.Printf("Failed to load push devices for content ID %d: %v\n", c.ID, err)
+		return 0
+	}
+
+	body := truncateText(plainText(c.Body), pushBodyLength)
+	notifications := make([]providers.EmailNotification, len(devices))
+	devicesByToken := make(map[string]*daos.PushDevice, len(devices))
+	for i, device := range devices {
+		notifications[i] = providers.EmailNotification{To: device.Token, Subject: c.Title, Body: body}
+		if device.Platform == daos.PushPlatformWebPush {
+			notifications[i].PushKeys = &providers.PushKeys{P256dh: device.P256dh, Auth: device.Auth}
+		}
+		devicesByToken[device.Token] = device
+	}
+
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, s.getConcurrencyLimit())
+	sentCount := make(chan int, len(notifications))
+
+	for provider, providerNotifications := range pushFactory.DistributePush(notifications) {
+		for _, notification := range providerNotifications {
+			wg.Add(1)
+			go func(p providers.EmailProviderInterface, n providers.EmailNotification) {
+				defer wg.Done()
+
+				device := devicesByToken[n.To]
+				pushLog := &EmailLog{
+					SubscriberID: device.SubscriberID,
+					ContentID:    c.ID,
+					EmailAddress: fmt.Sprintf("%s device %d", device.Platform, device.ID),
+					Subject:      c.Title,
+					Body:         n.Body,
+					Status:       constants.StatusSent,
+					Provider:     p.GetProviderName(),
+					Channel:      constants.NotificationTypePush,
+				}
+
+				messageID, err := "", acquireSlot(ctx, semaphore)
+				if err == nil {
+					defer func() { <-semaphore }()
+					messageID, err = callProvider(s, ctx, pushLog.Provider, func(ctx context.Context) (string, error) {
+						return p.SendEmail(ctx, &n)
+					})
+				}
+				if err != nil {
+					pushLog.Status = constants.StatusFailed
+					errorMsg := err.Error()
+					pushLog.ErrorMessage = &errorMsg
+					s.recordProviderSends(ctx, pushLog.Provider, 0, 1)
+					sentCount <- 0
+
+					if errors.Is(err, providers.ErrPushDeviceGone) {
+						if delErr := s.db.WithContext(ctx).Delete(device).Error; delErr != nil {
+							fmt.Printf("Failed to remove expired push device %d: %v\n", device.ID, delErr)
+						}
+					}
+				} else {
+					now := time.Now()
+					pushLog.SentAt = &now
+					pushLog.ProviderMessageID = messageID
+					if len(messageID) > 255 {
+						pushLog.ProviderMessageID = "" // Some push services return long message URLs
+					}
+					s.recordProviderSends(ctx, pushLog.Provider, 1, 0)
+					sentCount <- 1
+				}
+
+				if err := s.LogEmail(ctx, pushLog); err != nil {
+					fmt.Printf("Failed to log push notification for device %d: %v\n", device.ID, err)
+				}
+			}(provider, notification)
+		}
+	}
+
+	go func() {
+		wg.Wait()
+		close(sentCount)
+	}()
+
+	sent := 0
+	for count := range sentCount {
+		sent += count
+	}
+
+	span.SetAttributes(attribute.Int("push.devices", len(devices)), attribute.Int("push.sent", sent))
+	fmt.Printf("Sent %d/%d push notifications for content ID %d\n", sent, len(devices), c.ID)
+	return sent
+}
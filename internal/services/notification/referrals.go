@@ -0,0 +1,57 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"newsletter-service/internal/daos"
+	"newsletter-service/internal/providers"
+	"newsletter-service/internal/services/referral"
+)
+
+// referralLinksEnabled reports whether emails carry their recipient's referral link
+func (s *notificationService) referralLinksEnabled() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.referrals.Enabled
+}
+
+// referralURL returns the subscriber's referral link, or "" while referrals are off
+func (s *notificationService) referralURL(subscriber *daos.Subscriber) string {
+	s.mu.RLock()
+	cfg := s.referrals
+	s.mu.RUnlock()
+	if !cfg.Enabled {
+		return ""
+	}
+	return referral.Link(cfg.SignupURL, subscriber.ReferralCode)
+}
+
+// SendTransactionalEmail sends one email outside any content, such as a referral confirmation, through a
+// provider of the organization ctx is scoped to. Nothing is logged; the caller keeps track of the outcome.
+// An email queued in a provider batch counts as sent.
+func (s *notificationService) SendTransactionalEmail(ctx context.Context, email providers.EmailNotification) (string, error) {
+	factory := s.providerFactoryFor(ctx)
+	if factory == nil {
+		return "", fmt.Errorf("provider is required for sending emails - use NewServiceWithProviders")
+	}
+	provider := factory.GetProvider(1)
+	if provider == nil {
+		return "", fmt.Errorf("no email provider available")
+	}
+
+	providerName := provider.GetProviderName()
+	messageID, err := callProvider(s, ctx, providerName, func(ctx context.Context) (string, error) {
+		return provider.SendEmail(ctx, &email)
+	})
+	if errors.Is(err, providers.ErrEmailQueued) {
+		return "", nil
+	}
+	if err != nil {
+		s.recordProviderSends(ctx, providerName, 0, 1)
+		return "", err
+	}
+	s.recordProviderSends(ctx, providerName, 1, 0)
+	return messageID, nil
+}
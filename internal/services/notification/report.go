@@ -0,0 +1,181 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"newsletter-service/internal/constants"
+	"newsletter-service/internal/events"
+	"newsletter-service/internal/providers"
+	"newsletter-service/internal/services/content"
+	"newsletter-service/internal/tenant"
+)
+
+// sendReportTopErrors is how many distinct error messages a send report lists
+const sendReportTopErrors = 5
+
+// GetSendReport summarises the delivery of a content: its recipients by outcome, the errors most of its
+// failures share, how long the send took and what each provider sent. It returns gorm.ErrRecordNotFound for
+// unknown content.
+func (s *notificationService) GetSendReport(ctx context.Context, contentID uint) (*SendReport, error) {
+	contentModel, err := s.contentService.GetContentByID(ctx, contentID)
+	if err != nil {
+		return nil, err
+	}
+	db := s.db.WithContext(ctx)
+
+	progress, err := s.countDeliveries(db, []uint{contentID})
+	if err != nil {
+		return nil, err
+	}
+	p := progress[contentID]
+	report := &SendReport{
+		ContentID:  contentID,
+		TopicID:    contentModel.TopicID,
+		Title:      contentModel.Title,
+		State:      contentModel.DeliveryState,
+		Recipients: p.Sent + p.Failed + p.Outstanding,
+		Delivered:  p.Sent,
+		Failed:     p.Failed,
+		TopErrors:  []ErrorCount{},
+		Providers:  []ProviderBreakdown{},
+	}
+
+	var span struct {
+		StartedAt  *time.Time
+		FinishedAt *time.Time
+	}
+	err = db.Model(&EmailLog{}).
+		Select("MIN(created_at) AS started_at, MAX(COALESCE(sent_at, updated_at)) AS finished_at").
+		Where("content_id = ?", contentID).
+		Scan(&span).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to time the send: %w", err)
+	}
+	report.StartedAt, report.FinishedAt = span.StartedAt, span.FinishedAt
+	if span.StartedAt != nil && span.FinishedAt != nil {
+		report.Duration = span.FinishedAt.Sub(*span.StartedAt).Round(time.Second).String()
+	}
+
+	err = db.Model(&EmailLog{}).
+		Select("error_message AS error, COUNT(*) AS count").
+		Where("content_id = ? AND status = ? AND error_message IS NOT NULL", contentID, constants.StatusFailed).
+		Group("error_message").
+		Order("count DESC, error_message").
+		Limit(sendReportTopErrors).
+		Scan(&report.TopErrors).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to count send errors: %w", err)
+	}
+
+	err = db.Model(&EmailLog{}).
+		Select("provider, COUNT(*) FILTER (WHERE status = ?) AS sent, COUNT(*) FILTER (WHERE status = ?) AS failed",
+			constants.StatusSent, constants.StatusFailed).
+		Where("content_id = ? AND provider <> ''", contentID).
+		Group("provider").
+		Order("provider").
+		Scan(&report.Providers).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to count sends by provider: %w", err)
+	}
+
+	return report, nil
+}
+
+// reportSend sends the report of a content whose delivery just completed to the admins and webhooks
+// [send_reports] names. Each dispatch is reported once, and content sent before reports existed not at all. A
+// report that can't be made or sent is logged; the delivery stays complete.
+func (s *notificationService) reportSend(ctx context.Context, contentID uint) {
+	due, err := s.claimSendReport(ctx, contentID)
+	if err != nil {
+		fmt.Printf("Failed to claim the send report of content %d: %v\n", contentID, err)
+		return
+	}
+
+	s.mu.RLock()
+	cfg := s.sendReports
+	s.mu.RUnlock()
+	if !due || !cfg.Enabled {
+		return
+	}
+
+	report, err := s.GetSendReport(ctx, contentID)
+	if err != nil {
+		fmt.Printf("Failed to make the send report of content %d: %v\n", contentID, err)
+		return
+	}
+
+	if cfg.Webhook {
+		s.eventBus.Emit(ctx, events.SendReport, report)
+	}
+	if len(cfg.Recipients) == 0 {
+		return
+	}
+
+	// The report goes out through a provider of the content's organization
+	contentModel, err := s.contentService.GetContentByID(ctx, contentID)
+	if err != nil {
+		fmt.Printf("Failed to email the send report of content %d: %v\n", contentID, err)
+		return
+	}
+	ctx = tenant.WithOrganization(ctx, contentModel.OrganizationID)
+	email := sendReportEmail(report)
+	for _, recipient := range cfg.Recipients {
+		email.To = recipient
+		if _, err := s.SendTransactionalEmail(ctx, email); err != nil {
+			fmt.Printf("Failed to email the send report of content %d to %s: %v\n", contentID, recipient, err)
+		}
+	}
+}
+
+// claimSendReport clears the content's report due flag, reporting whether it was set. Of two workers completing
+// the same delivery only one claims it.
+func (s *notificationService) claimSendReport(ctx context.Context, contentID uint) (bool, error) {
+	result := s.db.WithContext(ctx).Model(&content.Content{}).
+		Where("id = ? AND delivery_report_due", contentID).
+		UpdateColumn("delivery_report_due", false)
+	return result.RowsAffected > 0, result.Error
+}
+
+// sendReportEmail lays a send report out as an email to admins
+func sendReportEmail(report *SendReport) providers.EmailNotification {
+	var body strings.Builder
+	fmt.Fprintf(&body, "<p>The delivery of <strong>%s</strong> (content %d) is %s.</p>\n",
+		html.EscapeString(report.Title), report.ContentID, strings.ReplaceAll(report.State, "_", " "))
+
+	body.WriteString("<table>\n")
+	row := func(label, value string) {
+		fmt.Fprintf(&body, "<tr><td>%s</td><td>%s</td></tr>\n", label, html.EscapeString(value))
+	}
+	row("Recipients", fmt.Sprint(report.Recipients))
+	row("Delivered", fmt.Sprint(report.Delivered))
+	row("Failed", fmt.Sprint(report.Failed))
+	if report.Duration != "" {
+		row("Duration", report.Duration)
+	}
+	body.WriteString("</table>\n")
+
+	if len(report.Providers) > 0 {
+		body.WriteString("<p>By provider:</p>\n<ul>\n")
+		for _, p := range report.Providers {
+			fmt.Fprintf(&body, "<li>%s: %d sent, %d failed</li>\n", html.EscapeString(p.Provider), p.Sent, p.Failed)
+		}
+		body.WriteString("</ul>\n")
+	}
+	if len(report.TopErrors) > 0 {
+		body.WriteString("<p>Most common errors:</p>\n<ul>\n")
+		for _, e := range report.TopErrors {
+			fmt.Fprintf(&body, "<li>%s (%d)</li>\n", html.EscapeString(e.Error), e.Count)
+		}
+		body.WriteString("</ul>\n")
+	}
+
+	return providers.EmailNotification{
+		Subject:     "Send report: " + report.Title,
+		Body:        body.String(),
+		PreviewText: fmt.Sprintf("%d of %d delivered, %d failed", report.Delivered, report.Recipients, report.Failed),
+	}
+}
@@ -0,0 +1,112 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"newsletter-service/internal/services/content"
+	"newsletter-service/internal/services/subscriber"
+)
+
+// recipientSchedule releases the recipients of local-time content as their local send time arrives.
+// Recipients that already have an email log for the content were released by an earlier run.
+type recipientSchedule struct {
+	content     *content.Content
+	defaultLoc  *time.Location
+	locations   map[string]*time.Location
+	alreadySent map[uint]bool
+	now         time.Time
+	waiting     int // Recipients whose local send time hasn't arrived yet
+}
+
+// newRecipientSchedule returns nil for content that isn't sent at a local time
+func (s *notificationService) newRecipientSchedule(ctx context.Context, c *content.Content) (*recipientSchedule, error) {
+	if c.LocalSendTime == "" {
+		return nil, nil
+	}
+
+	var sentIDs []uint
+	err := s.db.WithContext(ctx).
+		Model(&EmailLog{}).
+		Where("content_id = ?", c.ID).
+		Distinct().
+		Pluck("subscriber_id", &sentIDs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	alreadySent := make(map[uint]bool, len(sentIDs))
+	for _, id := range sentIDs {
+		alreadySent[id] = true
+	}
+
+	return &recipientSchedule{
+		content:     c,
+		defaultLoc:  s.getDefaultLocation(),
+		locations:   make(map[string]*time.Location),
+		alreadySent: alreadySent,
+		now:         time.Now(),
+	}, nil
+}
+
+// started reports whether any time zone has reached the local send time
+func (r *recipientSchedule) started() bool {
+	if r == nil {
+		return true
+	}
+	first, err := content.FirstLocalReleaseTime(r.content)
+	return err != nil || !r.now.Before(first)
+}
+
+// due reports whether a recipient should be sent now; recipients still waiting are counted
+func (r *recipientSchedule) due(sub *subscriber.Subscriber) bool {
+	if r == nil {
+		return true
+	}
+	if r.alreadySent[sub.ID] {
+		return false
+	}
+
+	release, err := content.LocalReleaseTime(r.content, r.location(sub.Timezone))
+	if err != nil || !r.now.Before(release) {
+		return true
+	}
+	r.waiting++
+	return false
+}
+
+// complete reports whether every recipient has been released, so the content can be marked sent
+func (r *recipientSchedule) complete() bool {
+	return r == nil || r.waiting == 0
+}
+
+// location resolves a subscriber's time zone, falling back to the default for unknown or invalid zones
+func (r *recipientSchedule) location(name string) *time.Location {
+	if name == "" {
+		return r.defaultLoc
+	}
+	if loc, ok := r.locations[name]; ok {
+		return loc
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		loc = r.defaultLoc
+	}
+	r.locations[name] = loc
+	return loc
+}
+
+// loadDefaultLocation resolves [subscribers] default_timezone, using UTC when it is unset or invalid
+func loadDefaultLocation(name string) *time.Location {
+	if name == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		fmt.Printf("Invalid default_timezone %q, using UTC: %v\n", name, err)
+		return time.UTC
+	}
+	return loc
+}
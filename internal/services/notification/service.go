@@ -2,25 +2,59 @@ package notification
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"newsletter-service/internal/config"
 	"newsletter-service/internal/constants"
+	"newsletter-service/internal/daos"
+	"newsletter-service/internal/events"
 	"newsletter-service/internal/providers"
 	"newsletter-service/internal/services/content"
+	"newsletter-service/internal/services/poll"
+	"newsletter-service/internal/services/snippet"
+	"newsletter-service/internal/services/sponsor"
 	"newsletter-service/internal/services/subscriber"
+	"newsletter-service/internal/tenant"
+	"newsletter-service/internal/tracing"
 )
 
 type notificationService struct {
 	db                *gorm.DB
 	contentService    content.Service
 	subscriberService subscriber.Service
+	snippets          snippet.Service // Expands the snippets content includes when it is sent
+	sponsors          sponsor.Service // Places the sponsor block of a booked slot into content when it is sent
 	providerFactory   *providers.ProviderFactory
+	orgFactories      map[uint]*providers.ProviderFactory // Organizations with their own [providers.organizations] list
 	workerConfig      *config.WorkerConfig
+	defaultLocation   *time.Location // Time zone of subscribers without one, for local-time sends
+	eventBus          *events.Bus
+	sendCounter       providers.SendCounter      // Kept across config reloads so hourly limits carry over
+	batchedLogs       sync.Map                   // IDs of email logs waiting in a provider batch, not resent until it reports back
+	topicSettings     sync.Map                   // Resolved topic settings by topic ID, see topicSettingsFor
+	smsFactory        *providers.ProviderFactory // SMS providers, nil when no SMS provider is enabled
+	smsMaxLength      int
+	pushFactory       *providers.ProviderFactory // Push providers, nil when no push provider is enabled
+	concurrency       *concurrencyLimiter        // Bounds concurrent email sends, adapting to provider health when enabled
+	timeouts          sendTimeouts               // Provider calls abandoned after the send timeout
+	referrals         config.ReferralsConfig     // Adds each recipient's referral link to their email when enabled
+	polls             config.PollsConfig         // Embeds the polls content includes, with answer links signed for each recipient
+	emailLogs         config.EmailLogsConfig     // Whether log bodies are stored inline or shared, see storeBody
+	sendReports       config.SendReportsConfig   // Who hears about a content once its delivery completes, see reportSend
+	pauses            *providers.ProviderPauses  // Email providers paused through the API, shared by every provider factory
+	mu                sync.RWMutex               // guards the provider factories, workerConfig, defaultLocation, referrals, polls, emailLogs and sendReports across config reloads
 }
 
 func NewService(db *gorm.DB, contentService content.Service, subscriberService subscriber.Service) Service {
@@ -28,23 +62,80 @@ func NewService(db *gorm.DB, contentService content.Service, subscriberService s
 		db:                db,
 		contentService:    contentService,
 		subscriberService: subscriberService,
+		snippets:          snippet.NewService(snippet.NewRepository(db)),
+		sponsors:          newSponsorService(db, contentService),
+		concurrency:       newConcurrencyLimiter(nil, poolStats(db)),
+		pauses:            newProviderPauses(db),
 	}
 }
 
-// NewServiceWithProviders creates a notification service with multi-provider support
-func NewServiceWithProviders(db *gorm.DB, contentService content.Service, subscriberService subscriber.Service, cfg *config.Config) (Service, error) {
+// NewServiceWithWorkerConfig creates a notification service that sends through the provider it is given, with
+// concurrency and retries set by workerConfig, e.g. for load tests against a mock provider
+func NewServiceWithWorkerConfig(db *gorm.DB, contentService content.Service, subscriberService subscriber.Service, workerConfig config.WorkerConfig) Service {
+	return &notificationService{
+		db:                db,
+		contentService:    contentService,
+		subscriberService: subscriberService,
+		snippets:          snippet.NewService(snippet.NewRepository(db)),
+		sponsors:          newSponsorService(db, contentService),
+		workerConfig:      &workerConfig,
+		concurrency:       newConcurrencyLimiter(&workerConfig, poolStats(db)),
+		pauses:            newProviderPauses(db),
+	}
+}
+
+// NewServiceWithProviders creates a notification service with multi-provider support. Provider hourly limits
+// are counted by sendCounter, shared between replicas when Redis-backed; nil counts per process.
+func NewServiceWithProviders(db *gorm.DB, contentService content.Service, subscriberService subscriber.Service, cfg *config.Config, eventBus *events.Bus, sendCounter providers.SendCounter) (Service, error) {
+	if sendCounter == nil {
+		sendCounter = providers.NewMemorySendCounter()
+	}
+
 	// Initialize provider factory
-	providerFactory, err := providers.NewProviderFactory(&cfg.Providers)
+	providerFactory, err := providers.NewProviderFactory(&cfg.Providers, sendCounter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize provider factory: %w", err)
 	}
+	orgFactories, err := newOrganizationFactories(&cfg.Providers, sendCounter)
+	if err != nil {
+		return nil, err
+	}
+
+	smsFactory, err := providers.NewSMSProviderFactory(&cfg.SMS, sendCounter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize SMS providers: %w", err)
+	}
+
+	pushFactory, err := providers.NewPushProviderFactory(&cfg.Push, sendCounter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize push providers: %w", err)
+	}
+
+	// Paused providers are stored, so they stay paused across restarts
+	pauses := newProviderPauses(db)
+	setPauses(pauses, providerFactory, orgFactories)
 
 	return &notificationService{
 		db:                db,
 		contentService:    contentService,
 		subscriberService: subscriberService,
+		snippets:          snippet.NewService(snippet.NewRepository(db)),
+		sponsors:          newSponsorService(db, contentService),
 		providerFactory:   providerFactory,
+		orgFactories:      orgFactories,
 		workerConfig:      &cfg.Worker,
+		defaultLocation:   loadDefaultLocation(cfg.Subscribers.DefaultTimezone),
+		eventBus:          eventBus,
+		sendCounter:       sendCounter,
+		smsFactory:        smsFactory,
+		smsMaxLength:      cfg.SMS.MaxLength,
+		pushFactory:       pushFactory,
+		concurrency:       newConcurrencyLimiter(&cfg.Worker, poolStats(db)),
+		referrals:         cfg.Referrals,
+		polls:             cfg.Polls,
+		emailLogs:         cfg.EmailLogs,
+		sendReports:       cfg.SendReports,
+		pauses:            pauses,
 	}, nil
 }
 
@@ -55,7 +146,10 @@ func (s *notificationService) SendNotificationsByContentID(ctx context.Context,
 
 // SendNotificationsByContentIDWithProvider sends notifications using the provided email provider with goroutine parallelization
 func (s *notificationService) SendNotificationsByContentIDWithProvider(ctx context.Context, contentID uint, provider providers.EmailProviderInterface) error {
-	if s.providerFactory != nil {
+	ctx, span := tracing.StartSpan(ctx, "notification.SendNotificationsByContentID", attribute.Int("content.id", int(contentID)))
+	defer span.End()
+
+	if s.getProviderFactory() != nil {
 		// Use multi-provider approach if available
 		return s.sendNotificationsMultiProvider(ctx, contentID)
 	}
@@ -71,51 +165,88 @@ func (s *notificationService) sendNotificationsSingleProvider(ctx context.Contex
 	if err != nil {
 		return fmt.Errorf("failed to get content: %w", err)
 	}
+	// Recipients are the content organization's subscribers, and the email logs are its own
+	ctx = tenant.WithOrganization(ctx, content.OrganizationID)
+	ctx = withSendPriority(ctx, content.Priority)
+	content = s.renderContent(ctx, content)
+
+	// Local-time content is released to each time zone as its send time arrives
+	schedule, err := s.newRecipientSchedule(ctx, content)
+	if err != nil {
+		return fmt.Errorf("failed to get recipient schedule: %w", err)
+	}
+	if !schedule.started() {
+		return nil
+	}
 
 	// Get subscribers for the topic
 	subscriptions, err := s.subscriberService.GetSubscriptionsByTopicID(ctx, content.TopicID)
 	if err != nil {
 		return fmt.Errorf("failed to get subscriptions: %w", err)
 	}
-
-	// Get active subscribers
-	var activeSubscribers []struct {
-		ID    uint
-		Email string
+	frequencies, err := s.loadTopicFrequencies(ctx, content.TopicID)
+	if err != nil {
+		return fmt.Errorf("failed to get notification preferences: %w", err)
 	}
+	entitled, err := s.loadEntitlements(ctx, content)
+	if err != nil {
+		return fmt.Errorf("failed to get premium entitlements: %w", err)
+	}
+
+	// Active subscribers, by the translation they receive
+	var recipients variantGroups
+	variants := s.contentVariantsFor(ctx, content)
 
 	for _, subscription := range subscriptions {
 		subscriber, err := s.subscriberService.GetSubscriberByID(ctx, subscription.SubscriberID)
-		if err != nil || !subscriber.IsActive {
+		if err != nil || !subscriber.IsActive || !schedule.due(subscriber) || !deliversOn(content, subscriber, constants.NotificationTypeEmail) {
+			continue
+		}
+		// Premium content only reaches subscribers paying for the topic, on every channel
+		if !entitled.allows(subscriber.ID) {
+			continue
+		}
+		if !frequencies.instant(subscriber.ID, constants.NotificationTypeEmail) {
 			continue
 		}
 
-		activeSubscribers = append(activeSubscribers, struct {
-			ID    uint
-			Email string
-		}{
-			ID:    subscriber.ID,
-			Email: subscriber.Email,
-		})
+		recipients.add(variants.forLocale(subscriber.Locale), subscriber.ID, subscriber.Email, nil)
 	}
 
-	if len(activeSubscribers) == 0 {
+	totalCount := recipients.count()
+	if totalCount == 0 {
+		s.markLocalTimeContentSent(ctx, schedule)
 		fmt.Printf("No active subscribers found for content ID %d\n", contentID)
 		return nil
 	}
 
-	// Send emails using the single provider
-	sentCount := s.sendEmailsConcurrently(ctx, contentID, activeSubscribers, content, provider)
-	totalCount := len(activeSubscribers)
-
-	// Mark notifications as sent
-	if totalCount > 0 {
-		if markErr := s.contentService.MarkNotificationsSent(ctx, contentID); markErr != nil {
-			fmt.Printf("Failed to mark notifications as sent for content %d: %v\n", contentID, markErr)
+	s.expectRecipients(ctx, contentID, recipients.subscriberIDs())
+
+	// Send each translation using the single provider. Recipients over the provider's warm-up cap are queued
+	// and carried over to the following days.
+	sentCount, queuedCount := 0, 0
+	for _, group := range recipients.groups {
+		allowed := s.allowWarmupSends(ctx, provider, len(group.subscribers))
+		for _, sub := range group.subscribers[allowed:] {
+			s.logEmailQueued(ctx, contentID, sub.ID, providers.EmailNotification{
+				To:          sub.Email,
+				Subject:     group.content.Title,
+				Body:        group.content.Body,
+				PreviewText: group.content.PreviewText,
+				Locale:      group.content.Locale,
+			})
 		}
+		queuedCount += len(group.subscribers) - allowed
+
+		sentCount += s.sendEmailsConcurrently(ctx, contentID, group.subscribers[:allowed], group.content, provider)
 	}
 
-	fmt.Printf("Sent %d/%d notifications for content ID %d\n", sentCount, totalCount, contentID)
+	// Mark notifications as sent once every time zone has been released
+	if totalCount > 0 && schedule.complete() {
+		s.markNotificationsSent(ctx, contentID)
+	}
+
+	fmt.Printf("Sent %d/%d notifications for content ID %d (%d queued)\n", sentCount, totalCount, contentID, queuedCount)
 	return nil
 }
 
@@ -126,111 +257,335 @@ func (s *notificationService) sendNotificationsMultiProvider(ctx context.Context
 	if err != nil {
 		return fmt.Errorf("failed to get content: %w", err)
 	}
+	// The rest of the send, from recipients to providers and email logs, belongs to the content's organization
+	ctx = tenant.WithOrganization(ctx, content.OrganizationID)
+	ctx = withSendPriority(ctx, content.Priority)
+	content = s.renderContent(ctx, content)
+
+	// Local-time content is released to each time zone as its send time arrives
+	schedule, err := s.newRecipientSchedule(ctx, content)
+	if err != nil {
+		return fmt.Errorf("failed to get recipient schedule: %w", err)
+	}
+	if !schedule.started() {
+		return nil
+	}
 
 	// Get subscribers for the topic
 	subscriptions, err := s.subscriberService.GetSubscriptionsByTopicID(ctx, content.TopicID)
 	if err != nil {
 		return fmt.Errorf("failed to get subscriptions: %w", err)
 	}
+	frequencies, err := s.loadTopicFrequencies(ctx, content.TopicID)
+	if err != nil {
+		return fmt.Errorf("failed to get notification preferences: %w", err)
+	}
+	entitled, err := s.loadEntitlements(ctx, content)
+	if err != nil {
+		return fmt.Errorf("failed to get premium entitlements: %w", err)
+	}
 
-	// Collect active subscriber emails
-	var activeEmails []providers.EmailNotification
-	var activeSubscribers []struct {
+	// Collect active subscriber emails by the translation they receive, phone numbers for subscribers receiving
+	// SMS, and subscribers who turned on push for the topic
+	var emailRecipients variantGroups
+	variants := s.contentVariantsFor(ctx, content)
+	var smsRecipients []struct {
 		ID    uint
 		Email string
 	}
+	var pushSubscriberIDs []uint
+	contentSender := s.senderFor(ctx, content.TopicID)
+	smsEnabled := s.getSMSFactory() != nil
+	pushEnabled := s.getPushFactory() != nil && daos.HasChannel(content.Channels, constants.NotificationTypePush)
 
 	for _, subscription := range subscriptions {
 		subscriber, err := s.subscriberService.GetSubscriberByID(ctx, subscription.SubscriberID)
-		if err != nil || !subscriber.IsActive {
+		if err != nil || !subscriber.IsActive || !schedule.due(subscriber) || !entitled.allows(subscriber.ID) {
 			continue
 		}
 
-		activeEmails = append(activeEmails, providers.EmailNotification{
-			To:      subscriber.Email,
-			Subject: content.Title,
-			Body:    content.Body,
-		})
+		if smsEnabled && deliversOn(content, subscriber, constants.NotificationTypeSMS) && frequencies.instant(subscriber.ID, constants.NotificationTypeSMS) {
+			smsRecipients = append(smsRecipients, struct {
+				ID    uint
+				Email string
+			}{
+				ID:    subscriber.ID,
+				Email: subscriber.Phone,
+			})
+		}
+		if pushEnabled && subscription.Push && frequencies.instant(subscriber.ID, constants.NotificationTypePush) {
+			pushSubscriberIDs = append(pushSubscriberIDs, subscriber.ID)
+		}
+		// Email subscribers on a digest get the content with their next digest
+		if !deliversOn(content, subscriber, constants.NotificationTypeEmail) || !frequencies.instant(subscriber.ID, constants.NotificationTypeEmail) {
+			continue
+		}
 
-		activeSubscribers = append(activeSubscribers, struct {
-			ID    uint
-			Email string
-		}{
-			ID:    subscriber.ID,
-			Email: subscriber.Email,
-		})
+		variant := variants.forLocale(subscriber.Locale)
+		email := providers.EmailNotification{
+			To:          subscriber.Email,
+			Subject:     variant.Title,
+			Body:        s.personalizePolls(variant.Body, subscriber.ID),
+			PreviewText: variant.PreviewText,
+			Locale:      variant.Locale,
+			ReferralURL: s.referralURL(subscriber),
+			Urgent:      isUrgent(content),
+		}
+		contentSender.apply(&email)
+		emailRecipients.add(variant, subscriber.ID, subscriber.Email, &email)
+	}
+
+	// Content is only marked sent once every time zone has been released
+	complete := schedule.complete()
+
+	if len(smsRecipients) > 0 {
+		s.sendSMSNotifications(ctx, content, smsRecipients)
+	}
+	if len(pushSubscriberIDs) > 0 {
+		s.sendPushNotifications(ctx, content, pushSubscriberIDs)
 	}
 
-	if len(activeEmails) == 0 {
+	if emailRecipients.count() == 0 {
+		if len(smsRecipients)+len(pushSubscriberIDs) > 0 && complete {
+			s.markNotificationsSent(ctx, contentID)
+			return nil
+		}
+		s.markLocalTimeContentSent(ctx, schedule)
 		fmt.Printf("No active subscribers found for content ID %d\n", contentID)
 		return nil
 	}
 
-	// Check if we should use bulk providers
-	bulkProviders := s.providerFactory.GetBulkCapableProviders()
-	if len(activeEmails) > 10 && len(bulkProviders) > 0 {
-		// Use bulk sending for large lists
-		return s.sendBulkEmails(ctx, contentID, activeEmails, activeSubscribers, content)
+	s.expectRecipients(ctx, contentID, emailRecipients.subscriberIDs())
+
+	// Each translation is sent on its own, in bulk when its list is large enough and bulk providers exist. A
+	// bulk email has one body for every recipient, so emails carrying referral links or signed poll links go out
+	// one by one.
+	bulkProviders := s.providerFactoryFor(ctx).GetBulkCapableProviders()
+	bulk := len(bulkProviders) > 0 && !s.referralLinksEnabled()
+	var errs []error
+	for _, group := range emailRecipients.groups {
+		if len(group.emails) > 10 && bulk && !poll.HasLinks(group.content.Body) {
+			errs = append(errs, s.sendBulkEmails(ctx, contentID, group.emails, group.subscribers, group.content, complete))
+			continue
+		}
+		errs = append(errs, s.sendDistributedEmails(ctx, contentID, group.emails, group.subscribers, group.content, complete))
+	}
+	return errors.Join(errs...)
+}
+
+// markNotificationsSent marks content as sent so no run sends it again, and starts tracking its delivery; see
+// RefreshDeliveryProgress. Sends cut short by a timeout still mark it, as the recipients already sent to would
+// otherwise get the content again on the next run.
+func (s *notificationService) markNotificationsSent(ctx context.Context, contentID uint) {
+	if err := s.contentService.MarkNotificationsSent(context.WithoutCancel(ctx), contentID); err != nil {
+		fmt.Printf("Failed to mark notifications as sent for content %d: %v\n", contentID, err)
 	}
+}
 
-	// Use distributed individual sending
-	return s.sendDistributedEmails(ctx, contentID, activeEmails, activeSubscribers, content)
+// markLocalTimeContentSent marks local-time content sent when a run finds nobody left to release
+func (s *notificationService) markLocalTimeContentSent(ctx context.Context, schedule *recipientSchedule) {
+	if schedule == nil || !schedule.complete() {
+		return
+	}
+	s.markNotificationsSent(ctx, schedule.content.ID)
 }
 
-// sendBulkEmails uses bulk-capable providers for large email lists
+// bulkChunk is a run of recipients sent to one provider in one bulk send. emails and subscribers share indexes.
+type bulkChunk struct {
+	provider    providers.EmailProviderInterface
+	emails      []providers.EmailNotification
+	subscribers []struct {
+		ID    uint
+		Email string
+	}
+}
+
+// sendBulkEmails uses bulk-capable providers for large email lists. Recipients are split into chunks of at
+// most each provider's MaxBatchSize, handed round-robin to the healthy bulk providers in priority order.
+// Chunks a provider rejects fall back to distributed sending.
 func (s *notificationService) sendBulkEmails(ctx context.Context, contentID uint, emails []providers.EmailNotification, subscribers []struct {
 	ID    uint
 	Email string
-}, content *content.Content) error {
+}, content *content.Content, markSent bool) error {
 
-	bulkProviders := s.providerFactory.GetBulkCapableProviders()
+	bulkProviders := s.bulkSendOrder(ctx)
 	if len(bulkProviders) == 0 {
 		return fmt.Errorf("no bulk capable providers available")
 	}
 
-	// Use the best bulk provider (highest priority, healthy)
-	bestProvider := bulkProviders[0]
+	ctx, span := tracing.StartSpan(ctx, "notification.sendBulkEmails",
+		attribute.Int("content.id", int(contentID)),
+		attribute.Int("email.recipients", len(emails)),
+		attribute.Int("provider.count", len(bulkProviders)),
+	)
+	defer span.End()
+
+	// Recipients over every provider's warm-up cap are queued and carried over to the following days
+	chunks, assigned := s.chunkBulkRecipients(ctx, bulkProviders, emails, subscribers)
+	span.SetAttributes(attribute.Int("email.queued", len(emails)-assigned), attribute.Int("email.chunks", len(chunks)))
+	for i, email := range emails[assigned:] {
+		s.logEmailQueued(ctx, contentID, subscribers[assigned+i].ID, email)
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	semaphore := make(chan struct{}, s.getConcurrencyLimit())
+	var failed []bulkChunk
+	for _, chunk := range chunks {
+		wg.Add(1)
+		go func(chunk bulkChunk) {
+			defer wg.Done()
+			err := acquireSlot(ctx, semaphore)
+			if err == nil {
+				defer func() { <-semaphore }()
+				err = s.sendBulkChunk(ctx, contentID, chunk, content)
+			}
+			if err != nil {
+				tracing.RecordError(span, err)
+				fmt.Printf("Bulk email to %d recipients via %s failed (%v), falling back to distributed sending\n",
+					len(chunk.emails), chunk.provider.GetProviderName(), err)
+				mu.Lock()
+				failed = append(failed, chunk)
+				mu.Unlock()
+			}
+		}(chunk)
+	}
+	wg.Wait()
+
+	// Content is marked sent once any recipient went out or was queued; otherwise the fallback decides
+	delivered := len(failed) < len(chunks) || assigned < len(emails)
+	if delivered && markSent {
+		s.markNotificationsSent(ctx, contentID)
+	}
+
+	if len(failed) == 0 {
+		return nil
+	}
+	var fallbackEmails []providers.EmailNotification
+	var fallbackSubscribers []struct {
+		ID    uint
+		Email string
+	}
+	for _, chunk := range failed {
+		fallbackEmails = append(fallbackEmails, chunk.emails...)
+		fallbackSubscribers = append(fallbackSubscribers, chunk.subscribers...)
+	}
+	return s.sendDistributedEmails(ctx, contentID, fallbackEmails, fallbackSubscribers, content, markSent && !delivered)
+}
+
+// bulkSendOrder returns the healthy bulk-capable providers by priority, or every bulk-capable provider when
+// none is healthy
+func (s *notificationService) bulkSendOrder(ctx context.Context) []providers.EmailProviderInterface {
+	bulkProviders := s.providerFactoryFor(ctx).GetBulkCapableProviders()
+
+	healthy := make([]providers.EmailProviderInterface, 0, len(bulkProviders))
 	for _, provider := range bulkProviders {
-		if provider.GetStats().IsHealthy && provider.GetPriority() < bestProvider.GetPriority() {
-			bestProvider = provider
+		if provider.GetStats().IsHealthy {
+			healthy = append(healthy, provider)
+		}
+	}
+	if len(healthy) > 0 {
+		bulkProviders = healthy
+	}
+
+	sort.SliceStable(bulkProviders, func(i, j int) bool {
+		return bulkProviders[i].GetPriority() < bulkProviders[j].GetPriority()
+	})
+	return bulkProviders
+}
+
+// chunkBulkRecipients splits the recipients into chunks sized by each provider's MaxBatchSize, taking the
+// providers in turn. A provider leaves the rotation once its warm-up cap is reached. It returns the chunks
+// and how many leading recipients they cover; the rest could not be placed today.
+func (s *notificationService) chunkBulkRecipients(ctx context.Context, bulkProviders []providers.EmailProviderInterface, emails []providers.EmailNotification, subscribers []struct {
+	ID    uint
+	Email string
+}) ([]bulkChunk, int) {
+	var chunks []bulkChunk
+	active := append([]providers.EmailProviderInterface(nil), bulkProviders...)
+	next, turn := 0, 0
+
+	for next < len(emails) && len(active) > 0 {
+		provider := active[turn%len(active)]
+
+		size := len(emails) - next
+		if maxBatch := provider.GetLimits().MaxBatchSize; maxBatch > 0 {
+			size = min(size, maxBatch)
 		}
+
+		granted := s.allowWarmupSends(ctx, provider, size)
+		if granted == 0 {
+			active = append(active[:turn%len(active)], active[turn%len(active)+1:]...)
+			continue
+		}
+
+		chunks = append(chunks, bulkChunk{
+			provider:    provider,
+			emails:      emails[next : next+granted],
+			subscribers: subscribers[next : next+granted],
+		})
+		next += granted
+		turn++
 	}
+	return chunks, next
+}
 
-	// Prepare bulk notification
-	recipientEmails := make([]string, len(emails))
-	for i, email := range emails {
+// sendBulkChunk sends one chunk as a single bulk email and logs its recipients as sent
+func (s *notificationService) sendBulkChunk(ctx context.Context, contentID uint, chunk bulkChunk, content *content.Content) error {
+	recipientEmails := make([]string, len(chunk.emails))
+	for i, email := range chunk.emails {
 		recipientEmails[i] = email.To
 	}
 
 	bulkNotification := &providers.BulkEmailNotification{
-		To:      recipientEmails,
-		Subject: content.Title,
-		Body:    content.Body,
+		To:          recipientEmails,
+		Subject:     content.Title,
+		Body:        content.Body,
+		PreviewText: content.PreviewText,
+		Locale:      content.Locale,
 	}
+	s.senderFor(ctx, content.TopicID).applyBulk(bulkNotification)
 
-	// Send bulk email
-	if err := bestProvider.SendBulkEmail(ctx, bulkNotification); err != nil {
-		fmt.Printf("Bulk email failed (%v), falling back to distributed sending\n", err)
-		return s.sendDistributedEmails(ctx, contentID, emails, subscribers, content)
+	providerName := chunk.provider.GetProviderName()
+	messageIDs, err := callProvider(s, ctx, providerName, func(ctx context.Context) (map[string]string, error) {
+		return chunk.provider.SendBulkEmail(ctx, bulkNotification)
+	})
+	if err != nil {
+		s.recordProviderSends(ctx, providerName, 0, len(chunk.emails))
+		return err
 	}
 
-	// Log success for all subscribers
-	return s.logBulkEmailSuccess(ctx, contentID, subscribers, content)
+	s.recordProviderSends(ctx, providerName, len(chunk.emails), 0)
+	return s.logBulkEmailSuccess(ctx, contentID, chunk.subscribers, content, providerName, messageIDs)
 }
 
 // sendDistributedEmails distributes emails across multiple providers
 func (s *notificationService) sendDistributedEmails(ctx context.Context, contentID uint, emails []providers.EmailNotification, subscribers []struct {
 	ID    uint
 	Email string
-}, content *content.Content) error {
+}, content *content.Content, markSent bool) error {
+	ctx, span := tracing.StartSpan(ctx, "notification.sendDistributedEmails",
+		attribute.Int("content.id", int(contentID)),
+		attribute.Int("email.recipients", len(emails)),
+	)
+	defer span.End()
 
 	// Distribute emails across healthy providers
-	distribution := s.providerFactory.DistributeEmails(emails)
+	providerFactory := s.providerFactoryFor(ctx)
+	distribution := providerFactory.DistributeEmails(emails)
 
 	var wg sync.WaitGroup
-	concurrencyLimit := s.getConcurrencyLimit()
-	semaphore := make(chan struct{}, concurrencyLimit)
 	successCount := make(chan int, len(emails))
+	queuedCount := 0
+
+	// With every provider paused the emails wait as queued logs, sent by the worker once one is resumed
+	if providerFactory.AllPaused() {
+		for _, email := range emails {
+			s.logEmailQueued(ctx, contentID, findSubscriberID(subscribers, email.To), email)
+		}
+		queuedCount = len(emails)
+	}
+	span.SetAttributes(attribute.Int("worker.concurrency", s.concurrency.current()))
 
 	// Send emails for each provider distribution
 	for provider, providerEmails := range distribution {
@@ -238,28 +593,51 @@ func (s *notificationService) sendDistributedEmails(ctx context.Context, content
 			continue
 		}
 
-		for _, email := range providerEmails {
+		// Emails over the provider's warm-up cap are queued and carried over to the following days
+		allowed := s.allowWarmupSends(ctx, provider, len(providerEmails))
+		for _, email := range providerEmails[allowed:] {
+			s.logEmailQueued(ctx, contentID, findSubscriberID(subscribers, email.To), email)
+		}
+		queuedCount += len(providerEmails) - allowed
+
+		for _, email := range providerEmails[:allowed] {
 			wg.Add(1)
 			go func(p providers.EmailProviderInterface, e providers.EmailNotification) {
 				defer wg.Done()
-				semaphore <- struct{}{}
-				defer func() { <-semaphore }()
 
 				// Find subscriber for this email
-				var subscriberID uint
-				for _, sub := range subscribers {
-					if sub.Email == e.To {
-						subscriberID = sub.ID
-						break
+				subscriberID := findSubscriberID(subscribers, e.To)
+				providerName := p.GetProviderName()
+				if err := s.concurrency.acquire(ctx); err != nil {
+					s.logEmailFailure(ctx, contentID, subscriberID, e, providerName, err)
+					successCount <- 0
+					return
+				}
+				logResult := func(ctx context.Context, messageID string, err error) {
+					if err != nil {
+						s.logEmailFailure(ctx, contentID, subscriberID, e, providerName, err)
+						s.recordProviderSends(ctx, providerName, 0, 1)
+					} else {
+						s.logEmailSuccess(ctx, contentID, subscriberID, e, providerName, messageID)
+						s.recordProviderSends(ctx, providerName, 1, 0)
 					}
 				}
 
-				// Send email and log result
-				if err := p.SendEmail(ctx, &e); err != nil {
-					s.logEmailFailure(ctx, contentID, subscriberID, e, err)
+				// Send email and log result; an email queued in a provider batch is logged when the batch reports back
+				e.OnResult = onBatchResult(ctx, logResult)
+				started := time.Now()
+				messageID, err := callProvider(s, ctx, providerName, func(ctx context.Context) (string, error) {
+					return p.SendEmail(ctx, &e)
+				})
+				s.concurrency.release(time.Since(started), isProviderFailure(err))
+				if errors.Is(err, providers.ErrEmailQueued) {
+					successCount <- 1
+					return
+				}
+				logResult(ctx, messageID, err)
+				if err != nil {
 					successCount <- 0
 				} else {
-					s.logEmailSuccess(ctx, contentID, subscriberID, e)
 					successCount <- 1
 				}
 			}(provider, email)
@@ -277,37 +655,182 @@ func (s *notificationService) sendDistributedEmails(ctx context.Context, content
 		sentCount += count
 	}
 
-	// Mark notifications as sent
-	if sentCount > 0 {
-		if markErr := s.contentService.MarkNotificationsSent(ctx, contentID); markErr != nil {
-			fmt.Printf("Failed to mark notifications as sent for content %d: %v\n", contentID, markErr)
+	// Mark notifications as sent; queued emails are sent by the worker
+	if (sentCount > 0 || queuedCount > 0) && markSent {
+		s.markNotificationsSent(ctx, contentID)
+	}
+
+	span.SetAttributes(attribute.Int("email.sent", sentCount), attribute.Int("email.queued", queuedCount))
+	fmt.Printf("Sent %d/%d notifications for content ID %d using multi-provider distribution (%d queued)\n", sentCount, len(emails), contentID, queuedCount)
+	return nil
+}
+
+// findSubscriberID returns the ID of the recipient with the given email, or 0 if it isn't listed
+func findSubscriberID(subscribers []struct {
+	ID    uint
+	Email string
+}, email string) uint {
+	for _, sub := range subscribers {
+		if sub.Email == email {
+			return sub.ID
 		}
 	}
+	return 0
+}
+
+// ApplyConfig swaps in provider settings and worker concurrency from a reloaded configuration.
+// Sends already in flight keep the providers they started with.
+func (s *notificationService) ApplyConfig(cfg *config.Config) error {
+	providerFactory, err := providers.NewProviderFactory(&cfg.Providers, s.sendCounter)
+	if err != nil {
+		return fmt.Errorf("failed to rebuild provider factory: %w", err)
+	}
+	orgFactories, err := newOrganizationFactories(&cfg.Providers, s.sendCounter)
+	if err != nil {
+		return err
+	}
+	smsFactory, err := providers.NewSMSProviderFactory(&cfg.SMS, s.sendCounter)
+	if err != nil {
+		return fmt.Errorf("failed to rebuild SMS providers: %w", err)
+	}
+	pushFactory, err := providers.NewPushProviderFactory(&cfg.Push, s.sendCounter)
+	if err != nil {
+		return fmt.Errorf("failed to rebuild push providers: %w", err)
+	}
 
-	fmt.Printf("Sent %d/%d notifications for content ID %d using multi-provider distribution\n", sentCount, len(emails), contentID)
+	setPauses(s.pauses, providerFactory, orgFactories)
+
+	workerConfig := cfg.Worker
+	defaultLocation := loadDefaultLocation(cfg.Subscribers.DefaultTimezone)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.providerFactory = providerFactory
+	s.orgFactories = orgFactories
+	s.smsFactory = smsFactory
+	s.smsMaxLength = cfg.SMS.MaxLength
+	s.pushFactory = pushFactory
+	s.workerConfig = &workerConfig
+	s.defaultLocation = defaultLocation
+	s.referrals = cfg.Referrals
+	s.polls = cfg.Polls
+	s.emailLogs = cfg.EmailLogs
+	s.sendReports = cfg.SendReports
+	s.concurrency.configure(&workerConfig)
 	return nil
 }
 
+// getProviderFactory returns the active provider factory, or nil when running without providers
+func (s *notificationService) getProviderFactory() *providers.ProviderFactory {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.providerFactory
+}
+
+// providerFactoryFor returns the provider factory for the organization ctx is scoped to: its own when it has
+// a [providers.organizations] entry, the shared one otherwise
+func (s *notificationService) providerFactoryFor(ctx context.Context) *providers.ProviderFactory {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if organizationID, ok := tenant.OrganizationID(ctx); ok {
+		if factory, exists := s.orgFactories[organizationID]; exists {
+			return factory
+		}
+	}
+	return s.providerFactory
+}
+
+// setPauses makes the email provider factories route around paused providers
+func setPauses(pauses *providers.ProviderPauses, providerFactory *providers.ProviderFactory, orgFactories map[uint]*providers.ProviderFactory) {
+	providerFactory.SetPauses(pauses)
+	for _, factory := range orgFactories {
+		factory.SetPauses(pauses)
+	}
+}
+
+// newOrganizationFactories builds a provider factory for each organization listed in [providers.organizations].
+// They share the provider settings, warm-up policies and hourly counters, and differ in which providers are enabled.
+func newOrganizationFactories(cfg *config.ProvidersConfig, sendCounter providers.SendCounter) (map[uint]*providers.ProviderFactory, error) {
+	factories := make(map[uint]*providers.ProviderFactory, len(cfg.Organizations))
+	for key, enabled := range cfg.Organizations {
+		organizationID, err := strconv.ParseUint(key, 10, 32)
+		if err != nil || organizationID == 0 {
+			return nil, fmt.Errorf("invalid organization ID %q in providers.organizations", key)
+		}
+
+		orgCfg := *cfg
+		orgCfg.Enabled = enabled
+		factory, err := providers.NewProviderFactory(&orgCfg, sendCounter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize providers for organization %d: %w", organizationID, err)
+		}
+		factories[uint(organizationID)] = factory
+	}
+	return factories, nil
+}
+
+// getDefaultLocation returns the time zone used for subscribers without one
+func (s *notificationService) getDefaultLocation() *time.Location {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.defaultLocation == nil {
+		return time.UTC
+	}
+	return s.defaultLocation
+}
+
+// GetConcurrencyStats reports the email send concurrency limit and how it has adapted
+func (s *notificationService) GetConcurrencyStats() ConcurrencyStats {
+	return s.concurrency.stats()
+}
+
+// isProviderFailure reports whether a send error reflects on the provider. Queued emails haven't been sent yet
+// and cancelled sends were stopped by the worker, so neither counts against it; a send timeout does.
+func isProviderFailure(err error) bool {
+	if err == nil || errors.Is(err, providers.ErrEmailQueued) || errors.Is(err, context.Canceled) {
+		return false
+	}
+	return errors.Is(err, ErrSendTimedOut) || !errors.Is(err, context.DeadlineExceeded)
+}
+
+// poolStats returns a reader of db's connection pool statistics, or nil when the pool isn't reachable
+func poolStats(db *gorm.DB) func() sql.DBStats {
+	if db == nil {
+		return nil
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil
+	}
+	return sqlDB.Stats
+}
+
 // getConcurrencyLimit returns the appropriate concurrency limit based on configuration
 func (s *notificationService) getConcurrencyLimit() int {
-	if s.workerConfig != nil {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.workerConfig != nil && s.workerConfig.MaxAsyncProcess > 0 {
 		return s.workerConfig.MaxAsyncProcess
 	}
-	return 10 // Default
+	return defaultConcurrency
 }
 
 // Helper methods for logging
-func (s *notificationService) logEmailSuccess(ctx context.Context, contentID uint, subscriberID uint, email providers.EmailNotification) {
+func (s *notificationService) logEmailSuccess(ctx context.Context, contentID uint, subscriberID uint, email providers.EmailNotification, providerName string, messageID string) {
 	now := time.Now()
 	emailLog := &EmailLog{
-		SubscriberID: subscriberID,
-		ContentID:    contentID,
-		EmailAddress: email.To,
-		Subject:      email.Subject,
-		Body:         email.Body,
-		Status:       constants.StatusSent,
-		SentAt:       &now,
-		RetryCount:   0,
+		SubscriberID:      subscriberID,
+		ContentID:         contentID,
+		EmailAddress:      email.To,
+		Subject:           email.Subject,
+		Body:              email.Body,
+		PreviewText:       email.PreviewText,
+		Locale:            email.Locale,
+		Status:            constants.StatusSent,
+		SentAt:            &now,
+		RetryCount:        0,
+		Provider:          providerName,
+		ProviderMessageID: messageID,
 	}
 
 	if err := s.LogEmail(ctx, emailLog); err != nil {
@@ -315,15 +838,18 @@ func (s *notificationService) logEmailSuccess(ctx context.Context, contentID uin
 	}
 }
 
-func (s *notificationService) logEmailFailure(ctx context.Context, contentID uint, subscriberID uint, email providers.EmailNotification, sendErr error) {
+func (s *notificationService) logEmailFailure(ctx context.Context, contentID uint, subscriberID uint, email providers.EmailNotification, providerName string, sendErr error) {
 	emailLog := &EmailLog{
 		SubscriberID: subscriberID,
 		ContentID:    contentID,
 		EmailAddress: email.To,
 		Subject:      email.Subject,
 		Body:         email.Body,
+		PreviewText:  email.PreviewText,
+		Locale:       email.Locale,
 		Status:       constants.StatusFailed,
 		RetryCount:   0,
+		Provider:     providerName,
 	}
 
 	if sendErr != nil {
@@ -339,7 +865,7 @@ func (s *notificationService) logEmailFailure(ctx context.Context, contentID uin
 func (s *notificationService) logBulkEmailSuccess(ctx context.Context, contentID uint, subscribers []struct {
 	ID    uint
 	Email string
-}, content *content.Content) error {
+}, content *content.Content, providerName string, messageIDs map[string]string) error {
 	now := time.Now()
 	var wg sync.WaitGroup
 
@@ -348,14 +874,18 @@ func (s *notificationService) logBulkEmailSuccess(ctx context.Context, contentID
 		go func(subID uint, email string) {
 			defer wg.Done()
 			emailLog := &EmailLog{
-				SubscriberID: subID,
-				ContentID:    contentID,
-				EmailAddress: email,
-				Subject:      content.Title,
-				Body:         content.Body,
-				Status:       constants.StatusSent,
-				SentAt:       &now,
-				RetryCount:   0,
+				SubscriberID:      subID,
+				ContentID:         contentID,
+				EmailAddress:      email,
+				Subject:           content.Title,
+				Body:              content.Body,
+				PreviewText:       content.PreviewText,
+				Locale:            content.Locale,
+				Status:            constants.StatusSent,
+				SentAt:            &now,
+				RetryCount:        0,
+				Provider:          providerName,
+				ProviderMessageID: messageIDs[email],
 			}
 
 			if err := s.LogEmail(ctx, emailLog); err != nil {
@@ -375,50 +905,89 @@ func (s *notificationService) sendEmailsConcurrently(ctx context.Context, conten
 }, content *content.Content, provider providers.EmailProviderInterface) int {
 	var wg sync.WaitGroup
 
-	// Use max_async_process from worker config or default to 10
-	concurrencyLimit := 10 // This should ideally come from WorkerConfig.MaxAsyncProcess
-	semaphore := make(chan struct{}, concurrencyLimit)
+	// Sends share the service's limiter, max_async_process or the adaptive limit
 	successCount := make(chan int, len(subscribers))
+	var batchedCount atomic.Int32 // Queued in a provider batch; their sends are recorded when it reports back
+	providerName := provider.GetProviderName()
+	contentSender := s.senderFor(ctx, content.TopicID)
 
 	for _, subscriber := range subscribers {
 		wg.Add(1)
 		go func(subID uint, email string) {
 			defer wg.Done()
-			semaphore <- struct{}{}        // Acquire semaphore
-			defer func() { <-semaphore }() // Release semaphore
 
+			logResult := func(ctx context.Context, messageID string, err error) {
+				emailLog := &EmailLog{
+					SubscriberID: subID,
+					ContentID:    contentID,
+					EmailAddress: email,
+					Subject:      content.Title,
+					Body:         content.Body,
+					PreviewText:  content.PreviewText,
+					Locale:       content.Locale,
+					Status:       constants.StatusSent,
+					RetryCount:   0,
+					Provider:     providerName,
+				}
+
+				if err != nil {
+					emailLog.Status = constants.StatusFailed
+					errorMsg := err.Error()
+					emailLog.ErrorMessage = &errorMsg
+				} else {
+					now := time.Now()
+					emailLog.SentAt = &now
+					emailLog.ProviderMessageID = messageID
+				}
+
+				// Log the email
+				if logErr := s.LogEmail(ctx, emailLog); logErr != nil {
+					fmt.Printf("Failed to log email for %s: %v\n", email, logErr)
+				}
+			}
+
+			// The log keeps the poll links unsigned; retries sign them again
 			notification := &providers.EmailNotification{
-				To:      email,
-				Subject: content.Title,
-				Body:    content.Body,
+				To:          email,
+				Subject:     content.Title,
+				Body:        s.personalizePolls(content.Body, subID),
+				PreviewText: content.PreviewText,
+				Locale:      content.Locale,
+				Urgent:      isUrgent(content),
+				OnResult: onBatchResult(ctx, func(ctx context.Context, messageID string, err error) {
+					logResult(ctx, messageID, err)
+					if err != nil {
+						s.recordProviderSends(ctx, providerName, 0, 1)
+					} else {
+						s.recordProviderSends(ctx, providerName, 1, 0)
+					}
+				}),
 			}
+			contentSender.apply(notification)
 
-			emailLog := &EmailLog{
-				SubscriberID: subID,
-				ContentID:    contentID,
-				EmailAddress: email,
-				Subject:      content.Title,
-				Body:         content.Body,
-				Status:       constants.StatusSent,
-				RetryCount:   0,
+			if err := s.concurrency.acquire(ctx); err != nil {
+				logResult(ctx, "", err)
+				successCount <- 0
+				return
 			}
 
 			// Send email
-			now := time.Now()
-			if err := provider.SendEmail(ctx, notification); err != nil {
-				emailLog.Status = constants.StatusFailed
-				errorMsg := err.Error()
-				emailLog.ErrorMessage = &errorMsg
+			started := time.Now()
+			messageID, err := callProvider(s, ctx, providerName, func(ctx context.Context) (string, error) {
+				return provider.SendEmail(ctx, notification)
+			})
+			s.concurrency.release(time.Since(started), isProviderFailure(err))
+			if errors.Is(err, providers.ErrEmailQueued) {
+				batchedCount.Add(1)
+				successCount <- 1
+				return
+			}
+			logResult(ctx, messageID, err)
+			if err != nil {
 				successCount <- 0
 			} else {
-				emailLog.SentAt = &now
 				successCount <- 1
 			}
-
-			// Log the email
-			if logErr := s.LogEmail(ctx, emailLog); logErr != nil {
-				fmt.Printf("Failed to log email for %s: %v\n", email, logErr)
-			}
 		}(subscriber.ID, subscriber.Email)
 	}
 
@@ -434,25 +1003,120 @@ func (s *notificationService) sendEmailsConcurrently(ctx context.Context, conten
 		sentCount += count
 	}
 
+	s.recordProviderSends(ctx, providerName, sentCount-int(batchedCount.Load()), len(subscribers)-sentCount)
 	return sentCount
 }
 
-// RetryFailedEmails retries without provider (for backward compatibility)
+// onBatchResult turns a send outcome handler into an OnResult callback. Batches report back after the send
+// call has returned, so the handler gets ctx without its cancellation.
+func onBatchResult(ctx context.Context, handle func(ctx context.Context, messageID string, err error)) func(string, error) {
+	ctx = context.WithoutCancel(ctx)
+	return func(messageID string, err error) {
+		handle(ctx, messageID, err)
+	}
+}
+
+// defaultRetryBatchSize is how many failed emails are loaded per query when no batch size is configured
+const defaultRetryBatchSize = 200
+
+// RetryFailedEmails retries failed emails through the configured providers, or fails without them
 func (s *notificationService) RetryFailedEmails(ctx context.Context) error {
-	return fmt.Errorf("provider is required for retrying emails - use RetryFailedEmailsWithProvider")
+	if s.getProviderFactory() == nil {
+		return fmt.Errorf("provider is required for retrying emails - use RetryFailedEmailsWithProvider")
+	}
+	_, err := s.RetryFailedEmailsInBatches(ctx, defaultRetryBatchSize)
+	return err
+}
+
+// RetryFailedEmailsInBatches retries every failed email under the retry limit through the configured providers,
+// loading batchSize logs at a time. Emails that fail again are retried on a later run.
+func (s *notificationService) RetryFailedEmailsInBatches(ctx context.Context, batchSize int) (*RetryResult, error) {
+	ctx, span := tracing.StartSpan(ctx, "notification.RetryFailedEmailsInBatches")
+	defer span.End()
+
+	if s.getProviderFactory() == nil {
+		return nil, fmt.Errorf("provider is required for retrying emails - use NewServiceWithProviders")
+	}
+	if batchSize <= 0 {
+		batchSize = defaultRetryBatchSize
+	}
+
+	result := &RetryResult{}
+	capped := make(map[string]bool)
+	var lastID uint
+	for {
+		// Page by id so logs that fail again aren't picked up twice in one run
+		var failedEmails []*EmailLog
+		err := s.db.WithContext(ctx).
+			Where("status = ? AND retry_count < ? AND id > ?", constants.StatusFailed, constants.MaxEmailRetryCount, lastID).
+			Where("channel = ?", constants.NotificationTypeEmail).
+			Order("id").
+			Limit(batchSize).
+			Find(&failedEmails).Error
+		if err != nil {
+			tracing.RecordError(span, err)
+			return result, fmt.Errorf("failed to get failed emails: %w", err)
+		}
+
+		for _, emailLog := range failedEmails {
+			// Once the run is cancelled, logs left alone keep their retry count for the next run
+			if ctx.Err() != nil {
+				break
+			}
+			lastID = emailLog.ID
+			logCtx := tenant.WithOrganization(ctx, emailLog.OrganizationID)
+
+			subscriber, err := s.subscriberService.GetSubscriberByID(logCtx, emailLog.SubscriberID)
+			if err != nil || !subscriber.IsActive {
+				result.Skipped++
+				continue
+			}
+
+			// Over a warm-up cap the email stays failed and is retried on a later day
+			provider := s.providerFactoryFor(logCtx).GetProvider(1)
+			if provider == nil || capped[provider.GetProviderName()] {
+				result.Skipped++
+				continue
+			}
+			if s.allowWarmupSends(logCtx, provider, 1) == 0 {
+				capped[provider.GetProviderName()] = true
+				result.Skipped++
+				continue
+			}
+
+			result.Attempted++
+			if s.deliverEmailLog(logCtx, provider, emailLog, s.referralURL(subscriber)) {
+				result.Sent++
+			}
+		}
+
+		if len(failedEmails) < batchSize || ctx.Err() != nil {
+			break
+		}
+	}
+
+	span.SetAttributes(attribute.Int("email.attempted", result.Attempted), attribute.Int("email.sent", result.Sent))
+	return result, nil
 }
 
 // RetryFailedEmailsWithProvider retries failed emails using the provided email provider
 func (s *notificationService) RetryFailedEmailsWithProvider(ctx context.Context, provider providers.EmailProviderInterface) error {
+	ctx, span := tracing.StartSpan(ctx, "notification.RetryFailedEmails")
+	defer span.End()
+
 	var failedEmails []*EmailLog
 
 	// Get failed emails that haven't exceeded retry limit
-	err := s.db.WithContext(ctx).Where("status = ? AND retry_count < ?", constants.StatusFailed, constants.MaxEmailRetryCount).Find(&failedEmails).Error
+	err := s.db.WithContext(ctx).Where("status = ? AND retry_count < ? AND channel = ?", constants.StatusFailed, constants.MaxEmailRetryCount, constants.NotificationTypeEmail).Find(&failedEmails).Error
 	if err != nil {
 		return fmt.Errorf("failed to get failed emails: %w", err)
 	}
 
 	for _, emailLog := range failedEmails {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
 		// Get subscriber
 		subscriber, err := s.subscriberService.GetSubscriberByID(ctx, emailLog.SubscriberID)
 		if err != nil {
@@ -463,31 +1127,201 @@ func (s *notificationService) RetryFailedEmailsWithProvider(ctx context.Context,
 			continue
 		}
 
-		notification := &providers.EmailNotification{
-			To:      emailLog.EmailAddress,
-			Subject: emailLog.Subject,
-			Body:    emailLog.Body,
+		// Over the warm-up cap the email stays failed and is retried on a later day
+		if s.allowWarmupSends(ctx, provider, 1) == 0 {
+			continue
 		}
 
-		// Retry sending
-		if err := provider.SendEmail(ctx, notification); err != nil {
-			// Update retry count
-			emailLog.RetryCount++
-			errorMsg := err.Error()
-			emailLog.ErrorMessage = &errorMsg
-		} else {
-			// Mark as sent
-			emailLog.Status = constants.StatusSent
-			now := time.Now()
-			emailLog.SentAt = &now
-			emailLog.ErrorMessage = nil
+		s.deliverEmailLog(ctx, provider, emailLog, s.referralURL(subscriber))
+	}
+
+	return nil
+}
+
+// deliverEmailLog sends an existing email log and saves the outcome: sent, or failed with its retry count
+// incremented. It reports whether the email was sent or queued in a provider batch, whose outcome is saved
+// when the batch reports back. Logs still waiting in a batch are skipped. The referral link isn't logged, so
+// the caller passes the recipient's current one.
+func (s *notificationService) deliverEmailLog(ctx context.Context, provider providers.EmailProviderInterface, emailLog *EmailLog, referralURL string) bool {
+	logID := emailLog.ID
+	if _, batched := s.batchedLogs.LoadOrStore(logID, struct{}{}); batched {
+		return false
+	}
+
+	// A shared body is read for the send only; the log keeps its reference when saved
+	withBody := *emailLog
+	if err := s.loadBody(ctx, &withBody); err != nil {
+		s.batchedLogs.Delete(logID)
+		fmt.Printf("Failed to resend email log %d: %v\n", logID, err)
+		return false
+	}
+
+	notification := &providers.EmailNotification{
+		To:          emailLog.EmailAddress,
+		Subject:     emailLog.Subject,
+		Body:        s.personalizePolls(withBody.Body, emailLog.SubscriberID),
+		PreviewText: emailLog.PreviewText,
+		Locale:      emailLog.Locale,
+		ReferralURL: referralURL,
+		OnResult: onBatchResult(ctx, func(ctx context.Context, messageID string, err error) {
+			s.saveDeliveryResult(ctx, emailLog, messageID, err)
+			s.batchedLogs.Delete(logID)
+		}),
+	}
+	s.senderForContent(ctx, emailLog.ContentID).apply(notification)
+
+	emailLog.Provider = provider.GetProviderName()
+	messageID, err := callProvider(s, ctx, emailLog.Provider, func(ctx context.Context) (string, error) {
+		return provider.SendEmail(ctx, notification)
+	})
+	if errors.Is(err, providers.ErrEmailQueued) {
+		return true
+	}
+	s.batchedLogs.Delete(logID)
+	return s.saveDeliveryResult(ctx, emailLog, messageID, err)
+}
+
+// saveDeliveryResult updates a resent email log with its send outcome and reports whether it was sent
+func (s *notificationService) saveDeliveryResult(ctx context.Context, emailLog *EmailLog, messageID string, sendErr error) bool {
+	sent := false
+	if sendErr != nil {
+		emailLog.Status = constants.StatusFailed
+		emailLog.RetryCount++
+		errorMsg := sendErr.Error()
+		emailLog.ErrorMessage = &errorMsg
+		emailLog.ProviderMessageID = ""
+		s.recordProviderSends(ctx, emailLog.Provider, 0, 1)
+	} else {
+		emailLog.Status = constants.StatusSent
+		now := time.Now()
+		emailLog.SentAt = &now
+		emailLog.ErrorMessage = nil
+		emailLog.ProviderMessageID = messageID
+		s.recordProviderSends(ctx, emailLog.Provider, 1, 0)
+		sent = true
+	}
+
+	// Update the log, even if the run was cancelled while the email was being sent
+	if err := s.db.WithContext(context.WithoutCancel(ctx)).Save(emailLog).Error; err == nil {
+		s.emitEmailEvent(ctx, emailLog)
+	}
+	return sent
+}
+
+// queuedEmailBatchSize caps how many queued email logs the worker sends per run
+const queuedEmailBatchSize = 500
+
+// RequeueFailedEmailsByContentID moves a content's failed email logs back to pending so the worker resends
+// them. Logs that reached the retry limit or belong to inactive or deleted subscribers are left failed. A
+// completed delivery is reopened until the requeued emails are through. It returns gorm.ErrRecordNotFound for unknown content.
+func (s *notificationService) RequeueFailedEmailsByContentID(ctx context.Context, contentID uint) (*RequeueResult, error) {
+	ctx, span := tracing.StartSpan(ctx, "notification.RequeueFailedEmails", attribute.Int("content.id", int(contentID)))
+	defer span.End()
+
+	if _, err := s.contentService.GetContentByID(ctx, contentID); err != nil {
+		return nil, err
+	}
+
+	activeSubscribers := s.db.Model(&subscriber.Subscriber{}).Select("id").Where("is_active = ?", true)
+	result := &RequeueResult{ContentID: contentID}
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var skipped struct {
+			Exhausted  int64
+			Suppressed int64
 		}
+		err := tx.Model(&EmailLog{}).
+			Select(`COUNT(*) FILTER (WHERE retry_count >= ?) AS exhausted,
+				COUNT(*) FILTER (WHERE retry_count < ? AND subscriber_id NOT IN (?)) AS suppressed`,
+				constants.MaxEmailRetryCount, constants.MaxEmailRetryCount, activeSubscribers).
+			Where("content_id = ? AND status = ? AND channel = ?", contentID, constants.StatusFailed, constants.NotificationTypeEmail).
+			Scan(&skipped).Error
+		if err != nil {
+			return err
+		}
+		result.Exhausted = skipped.Exhausted
+		result.Suppressed = skipped.Suppressed
+
+		update := tx.Model(&EmailLog{}).
+			Where("content_id = ? AND status = ? AND retry_count < ? AND channel = ?", contentID, constants.StatusFailed, constants.MaxEmailRetryCount, constants.NotificationTypeEmail).
+			Where("subscriber_id IN (?)", activeSubscribers).
+			Update("status", constants.StatusPending)
+		result.Requeued = update.RowsAffected
+		if update.Error != nil || result.Requeued == 0 {
+			return update.Error
+		}
+		return s.reopenDelivery(tx, contentID)
+	})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
 
-		// Update the log
-		s.db.WithContext(ctx).Save(emailLog)
+	span.SetAttributes(attribute.Int64("email.requeued", result.Requeued))
+	return result, nil
+}
+
+// queuedPriorityOrder sorts email logs by the priority of their content, high first
+const queuedPriorityOrder = "(SELECT CASE contents.priority WHEN 'high' THEN 0 WHEN 'low' THEN 2 ELSE 1 END " +
+	"FROM contents WHERE contents.id = email_logs.content_id)"
+
+// SendQueuedEmails sends pending email logs, such as those requeued for a content, through the configured
+// providers. Each log is updated in place: sent on success, or failed with its retry count incremented.
+func (s *notificationService) SendQueuedEmails(ctx context.Context) (int, error) {
+	ctx, span := tracing.StartSpan(ctx, "notification.SendQueuedEmails")
+	defer span.End()
+
+	if s.getProviderFactory() == nil {
+		return 0, fmt.Errorf("provider is required for sending queued emails - use NewServiceWithProviders")
 	}
 
-	return nil
+	// Emails of high priority content go first, so a backlog of queued digests doesn't hold them up
+	var queued []*EmailLog
+	err := s.db.WithContext(ctx).
+		Where("status = ? AND retry_count < ? AND channel = ?", constants.StatusPending, constants.MaxEmailRetryCount, constants.NotificationTypeEmail).
+		Order(queuedPriorityOrder).
+		Order("id").
+		Limit(queuedEmailBatchSize).
+		Find(&queued).Error
+	if err != nil {
+		tracing.RecordError(span, err)
+		return 0, fmt.Errorf("failed to get queued emails: %w", err)
+	}
+
+	sentCount := 0
+	capped := make(map[string]bool)
+	for _, emailLog := range queued {
+		if ctx.Err() != nil {
+			break
+		}
+		logCtx := tenant.WithOrganization(ctx, emailLog.OrganizationID)
+
+		// The subscriber may have been deactivated since the log was queued
+		subscriber, err := s.subscriberService.GetSubscriberByID(logCtx, emailLog.SubscriberID)
+		if err != nil || !subscriber.IsActive {
+			emailLog.Status = constants.StatusFailed
+			if err := s.db.WithContext(ctx).Save(emailLog).Error; err == nil {
+				s.emitEmailEvent(ctx, emailLog)
+			}
+			continue
+		}
+
+		// Logs over a warm-up cap stay pending until a following day's cap allows them
+		provider := s.providerFactoryFor(logCtx).GetProvider(1)
+		if provider == nil || capped[provider.GetProviderName()] {
+			continue
+		}
+		if s.allowWarmupSends(logCtx, provider, 1) == 0 {
+			capped[provider.GetProviderName()] = true
+			continue
+		}
+		if s.deliverEmailLog(logCtx, provider, emailLog, s.referralURL(subscriber)) {
+			sentCount++
+		}
+	}
+
+	span.SetAttributes(attribute.Int("email.queued", len(queued)), attribute.Int("email.sent", sentCount))
+	return sentCount, nil
 }
 
 func (s *notificationService) GetEmailLogs(ctx context.Context) ([]*EmailLog, error) {
@@ -510,12 +1344,272 @@ func (s *notificationService) GetEmailLogsWithPagination(ctx context.Context, of
 	return logs, total, err
 }
 
+// GetFilteredEmailLogsWithPagination lists email logs matching the filter in the requested order
+func (s *notificationService) GetFilteredEmailLogsWithPagination(ctx context.Context, filter EmailLogFilter, offset, limit int) ([]*EmailLog, int64, error) {
+	var logs []*EmailLog
+	var total int64
+
+	query := func() *gorm.DB {
+		return applyEmailLogFilter(s.db.WithContext(ctx).Model(&EmailLog{}).Table(emailLogTable(filter)), filter)
+	}
+
+	// Get total count
+	if err := query().Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Get paginated results; id breaks ties so pages are stable
+	err := query().Order(emailLogOrder(filter)).
+		Order(clause.OrderByColumn{Column: clause.Column{Name: "id"}, Desc: !filter.Ascending}).
+		Offset(offset).Limit(limit).Find(&logs).Error
+	return logs, total, err
+}
+
+func applyEmailLogFilter(query *gorm.DB, filter EmailLogFilter) *gorm.DB {
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.ContentID > 0 {
+		query = query.Where("content_id = ?", filter.ContentID)
+	}
+	if filter.SubscriberID > 0 {
+		query = query.Where("subscriber_id = ?", filter.SubscriberID)
+	}
+	if filter.EmailAddress != "" {
+		query = query.Where("LOWER(email_address) = LOWER(?)", filter.EmailAddress)
+	}
+	if filter.Provider != "" {
+		query = query.Where("provider = ?", filter.Provider)
+	}
+	if filter.MessageID != "" {
+		query = query.Where("provider_message_id = ?", filter.MessageID)
+	}
+	if filter.Channel != "" {
+		query = query.Where("channel = ?", filter.Channel)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at <= ?", *filter.To)
+	}
+	return query
+}
+
+// emailLogOrder builds the ORDER BY clause from an allowlisted column
+func emailLogOrder(filter EmailLogFilter) clause.OrderByColumn {
+	column := "created_at"
+	for _, field := range EmailLogSortFields {
+		if field == filter.SortBy {
+			column = field
+		}
+	}
+	return clause.OrderByColumn{Column: clause.Column{Name: column}, Desc: !filter.Ascending}
+}
+
 func (s *notificationService) GetEmailLogByID(ctx context.Context, id uint) (*EmailLog, error) {
 	var log EmailLog
 	err := s.db.WithContext(ctx).First(&log, id).Error
-	return &log, err
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = s.db.WithContext(ctx).Table(constants.TableNameEmailLogsArchive).First(&log, id).Error
+	}
+	if err != nil {
+		return &log, err
+	}
+	return &log, s.loadBody(ctx, &log)
+}
+
+// GetRecentEmailLogsBySubscriberIDs returns up to limit most recent logs per subscriber in one query
+func (s *notificationService) GetRecentEmailLogsBySubscriberIDs(ctx context.Context, subscriberIDs []uint, limit int) (map[uint][]*EmailLog, error) {
+	var logs []*EmailLog
+	ranked := s.db.WithContext(ctx).
+		Model(&EmailLog{}).
+		Select("*, ROW_NUMBER() OVER (PARTITION BY subscriber_id ORDER BY created_at DESC) AS row_num").
+		Where("subscriber_id IN ?", subscriberIDs)
+	err := s.db.WithContext(ctx).
+		Table("(?) AS ranked", ranked).
+		Where("row_num <= ?", limit).
+		Order("subscriber_id, created_at desc").
+		Find(&logs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	bySubscriber := make(map[uint][]*EmailLog, len(subscriberIDs))
+	for _, log := range logs {
+		bySubscriber[log.SubscriberID] = append(bySubscriber[log.SubscriberID], log)
+	}
+	return bySubscriber, nil
+}
+
+// CountEmailLogsByStatus returns the number of email logs in each delivery status
+func (s *notificationService) CountEmailLogsByStatus(ctx context.Context) (map[string]int64, error) {
+	var rows []struct {
+		Status string
+		Count  int64
+	}
+	err := s.db.WithContext(ctx).
+		Model(&EmailLog{}).
+		Select("status, COUNT(*) AS count").
+		Group("status").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	return counts, nil
+}
+
+// GetContentEmailLogSummary counts a content's email logs by status and failure class and ranks recipient
+// domains by failures, in a single aggregate query. It returns gorm.ErrRecordNotFound for unknown content.
+func (s *notificationService) GetContentEmailLogSummary(ctx context.Context, contentID uint, topDomains int) (*EmailLogSummary, error) {
+	if _, err := s.contentService.GetContentByID(ctx, contentID); err != nil {
+		return nil, err
+	}
+	if topDomains <= 0 {
+		topDomains = DefaultTopFailingDomains
+	}
+
+	classCase, classArgs := errorClassCase()
+	args := append([]interface{}{constants.StatusFailed, constants.StatusFailed}, classArgs...)
+	args = append(args, contentID)
+
+	// One row per status, per failure class and per domain; the GROUPING flags tell the sets apart
+	var rows []struct {
+		Status     *string
+		ErrorClass *string
+		Domain     *string
+		Total      int64
+		Failed     int64
+		GStatus    int
+		GClass     int
+		GDomain    int
+	}
+	err := s.db.WithContext(ctx).Raw(`
+		SELECT status, error_class, domain,
+		       COUNT(*) AS total,
+		       COUNT(*) FILTER (WHERE status = ?) AS failed,
+		       GROUPING(status) AS g_status,
+		       GROUPING(error_class) AS g_class,
+		       GROUPING(domain) AS g_domain
+		FROM (
+			SELECT status,
+			       CASE WHEN status <> ? THEN NULL `+classCase+` END AS error_class,
+			       LOWER(SPLIT_PART(email_address, '@', 2)) AS domain
+			FROM email_logs
+			WHERE content_id = ? AND deleted_at IS NULL
+		) AS logs
+		GROUP BY GROUPING SETS ((status), (error_class), (domain))`,
+		args...).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &EmailLogSummary{
+		ContentID:         contentID,
+		ByStatus:          make(map[string]int64),
+		ByErrorClass:      make(map[string]int64),
+		TopFailingDomains: []DomainFailures{},
+	}
+	for _, row := range rows {
+		switch {
+		case row.GStatus == 0 && row.Status != nil:
+			summary.ByStatus[*row.Status] = row.Total
+			summary.Total += row.Total
+		case row.GClass == 0 && row.ErrorClass != nil:
+			summary.ByErrorClass[*row.ErrorClass] = row.Total
+		case row.GDomain == 0 && row.Domain != nil && row.Failed > 0:
+			summary.TopFailingDomains = append(summary.TopFailingDomains, DomainFailures{
+				Domain:      *row.Domain,
+				Failed:      row.Failed,
+				Total:       row.Total,
+				FailureRate: float64(row.Failed) / float64(row.Total),
+			})
+		}
+	}
+
+	sort.Slice(summary.TopFailingDomains, func(i, j int) bool {
+		a, b := summary.TopFailingDomains[i], summary.TopFailingDomains[j]
+		if a.Failed != b.Failed {
+			return a.Failed > b.Failed
+		}
+		return a.Domain < b.Domain
+	})
+	if len(summary.TopFailingDomains) > topDomains {
+		summary.TopFailingDomains = summary.TopFailingDomains[:topDomains]
+	}
+	return summary, nil
 }
 
+// errorClassCase builds the WHEN branches that classify a failed log's error message
+func errorClassCase() (string, []interface{}) {
+	var sql strings.Builder
+	var args []interface{}
+
+	sql.WriteString("WHEN error_message IS NULL OR error_message = '' THEN ? ")
+	args = append(args, ErrorClassUnknown)
+	for _, class := range errorClassPatterns {
+		conditions := make([]string, len(class.Patterns))
+		for i, pattern := range class.Patterns {
+			conditions[i] = "error_message ILIKE ?"
+			args = append(args, pattern)
+		}
+		sql.WriteString("WHEN " + strings.Join(conditions, " OR ") + " THEN ? ")
+		args = append(args, class.Class)
+	}
+	sql.WriteString("ELSE ?")
+	args = append(args, ErrorClassOther)
+
+	return sql.String(), args
+}
+
+// LogEmail saves an email log. The write outlives ctx's cancellation: an email that went out before a send or
+// job timeout must still be logged.
 func (s *notificationService) LogEmail(ctx context.Context, log *EmailLog) error {
-	return s.db.WithContext(ctx).Create(log).Error
+	ctx = context.WithoutCancel(ctx)
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := s.storeBody(tx, log); err != nil {
+			return err
+		}
+		return tx.Create(log).Error
+	})
+	if err != nil {
+		return err
+	}
+	s.emitEmailEvent(ctx, log)
+	return nil
+}
+
+// RecordOpen marks the delivered email for a subscriber and content as opened. Only the first open is kept.
+func (s *notificationService) RecordOpen(ctx context.Context, subscriberID, contentID uint) error {
+	return s.db.WithContext(ctx).
+		Model(&EmailLog{}).
+		Where("subscriber_id = ? AND content_id = ? AND status = ? AND channel = ? AND opened_at IS NULL", subscriberID, contentID, constants.StatusSent, constants.NotificationTypeEmail).
+		Update("opened_at", time.Now()).Error
+}
+
+// emitEmailEvent announces the outcome of a recorded send attempt
+func (s *notificationService) emitEmailEvent(ctx context.Context, log *EmailLog) {
+	eventType := events.EmailSent
+	if log.Status == constants.StatusFailed {
+		eventType = events.EmailFailed
+	} else if log.Status != constants.StatusSent {
+		return
+	}
+
+	data := map[string]interface{}{
+		"email_log_id":  log.ID,
+		"subscriber_id": log.SubscriberID,
+		"content_id":    log.ContentID,
+		"email":         log.EmailAddress,
+		"retry_count":   log.RetryCount,
+	}
+	if log.ErrorMessage != nil {
+		data["error"] = *log.ErrorMessage
+	}
+	s.eventBus.Emit(ctx, eventType, data)
 }
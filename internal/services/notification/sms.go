@@ -0,0 +1,154 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"newsletter-service/internal/constants"
+	"newsletter-service/internal/daos"
+	"newsletter-service/internal/providers"
+	"newsletter-service/internal/services/content"
+	"newsletter-service/internal/services/subscriber"
+	"newsletter-service/internal/tracing"
+)
+
+// defaultSMSMaxLength keeps an SMS within two concatenated GSM segments
+const defaultSMSMaxLength = 320
+
+// htmlTags are stripped from content bodies sent as SMS or push notifications
+var htmlTags = regexp.MustCompile(`<[^>]*>`)
+
+// deliversOn reports whether content goes out on a channel and the subscriber accepts it there. SMS also
+// needs the subscriber's phone number.
+func deliversOn(c *content.Content, sub *subscriber.Subscriber, channel string) bool {
+	if !daos.HasChannel(c.Channels, channel) || !daos.HasChannel(sub.Channels, channel) {
+		return false
+	}
+	return channel != constants.NotificationTypeSMS || sub.Phone != ""
+}
+
+// smsText renders content as a plain-text SMS: the title, then the body without markup, cut to maxLength characters
+func smsText(c *content.Content, maxLength int) string {
+	if maxLength <= 0 {
+		maxLength = defaultSMSMaxLength
+	}
+	return truncateText(c.Title+"\n"+plainText(c.Body), maxLength)
+}
+
+// plainText strips markup from a content body and collapses its whitespace, for channels without HTML
+func plainText(body string) string {
+	return strings.Join(strings.Fields(htmlTags.ReplaceAllString(body, " ")), " ")
+}
+
+// truncateText cuts text to maxLength characters, ending with an ellipsis when cut
+func truncateText(text string, maxLength int) string {
+	runes := []rune(text)
+	if len(runes) <= maxLength {
+		return text
+	}
+	return string(runes[:maxLength-1]) + "…"
+}
+
+// getSMSFactory returns the SMS channel's providers, or nil when no SMS provider is enabled
+func (s *notificationService) getSMSFactory() *providers.ProviderFactory {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.smsFactory
+}
+
+// sendSMSNotifications texts content to its SMS recipients, spread across the SMS providers by their load
+// balancer, and logs each message with the sms channel. Failed SMS are not retried.
+func (s *notificationService) sendSMSNotifications(ctx context.Context, c *content.Content, recipients []struct {
+	ID    uint
+	Email string
+}) int {
+	ctx, span := tracing.StartSpan(ctx, "notification.sendSMSNotifications",
+		attribute.Int("content.id", int(c.ID)),
+		attribute.Int("sms.recipients", len(recipients)),
+	)
+	defer span.End()
+
+	smsFactory := s.getSMSFactory()
+	if smsFactory == nil {
+		return 0
+	}
+
+	s.mu.RLock()
+	text := smsText(c, s.smsMaxLength)
+	s.mu.RUnlock()
+
+	messages := make([]providers.EmailNotification, len(recipients))
+	for i, recipient := range recipients {
+		messages[i] = providers.EmailNotification{To: recipient.Email, Subject: c.Title, Body: text}
+	}
+
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, s.getConcurrencyLimit())
+	sentCount := make(chan int, len(messages))
+
+	for provider, providerMessages := range smsFactory.DistributeEmails(messages) {
+		for _, message := range providerMessages {
+			wg.Add(1)
+			go func(p providers.EmailProviderInterface, m providers.EmailNotification) {
+				defer wg.Done()
+
+				smsLog := &EmailLog{
+					SubscriberID: findSubscriberID(recipients, m.To),
+					ContentID:    c.ID,
+					EmailAddress: m.To,
+					Subject:      c.Title,
+					Body:         m.Body,
+					Status:       constants.StatusSent,
+					Provider:     p.GetProviderName(),
+					Channel:      constants.NotificationTypeSMS,
+				}
+
+				// Messages still waiting for a slot when the run is cancelled are logged as failed
+				messageID, err := "", acquireSlot(ctx, semaphore)
+				if err == nil {
+					defer func() { <-semaphore }()
+					messageID, err = callProvider(s, ctx, smsLog.Provider, func(ctx context.Context) (string, error) {
+						return p.SendEmail(ctx, &m)
+					})
+				}
+				if err != nil {
+					smsLog.Status = constants.StatusFailed
+					errorMsg := err.Error()
+					smsLog.ErrorMessage = &errorMsg
+					s.recordProviderSends(ctx, smsLog.Provider, 0, 1)
+					sentCount <- 0
+				} else {
+					now := time.Now()
+					smsLog.SentAt = &now
+					smsLog.ProviderMessageID = messageID
+					s.recordProviderSends(ctx, smsLog.Provider, 1, 0)
+					sentCount <- 1
+				}
+
+				if err := s.LogEmail(ctx, smsLog); err != nil {
+					fmt.Printf("Failed to log SMS for %s: %v\n", m.To, err)
+				}
+			}(provider, message)
+		}
+	}
+
+	go func() {
+		wg.Wait()
+		close(sentCount)
+	}()
+
+	sent := 0
+	for count := range sentCount {
+		sent += count
+	}
+
+	span.SetAttributes(attribute.Int("sms.sent", sent))
+	fmt.Printf("Sent %d/%d SMS for content ID %d\n", sent, len(recipients), c.ID)
+	return sent
+}
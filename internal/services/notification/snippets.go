@@ -0,0 +1,31 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+
+	"newsletter-service/internal/services/content"
+	"newsletter-service/internal/services/snippet"
+)
+
+// renderContent returns c as it is sent: snippets expanded into its body, its polls rendered and its sponsor
+// block added, then its links tagged, those of the snippets and the sponsor included
+func (s *notificationService) renderContent(ctx context.Context, c *content.Content) *content.Content {
+	return s.withTaggedLinks(ctx, s.withSponsor(ctx, s.withPolls(ctx, s.withSnippets(ctx, c))))
+}
+
+// withSnippets returns c with the snippets its body includes expanded. When they can't be loaded the includes
+// are left out, so template syntax never reaches subscribers.
+func (s *notificationService) withSnippets(ctx context.Context, c *content.Content) *content.Content {
+	body, err := s.snippets.Expand(ctx, c.Body)
+	if err != nil {
+		fmt.Printf("Failed to expand snippets of content %d, sending it without them: %v\n", c.ID, err)
+		body, _ = snippet.Replace(c.Body, nil)
+	}
+	if body == c.Body {
+		return c
+	}
+	expanded := *c
+	expanded.Body = body
+	return &expanded
+}
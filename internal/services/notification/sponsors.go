@@ -0,0 +1,53 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"newsletter-service/internal/daos"
+	"newsletter-service/internal/providers/templates"
+	"newsletter-service/internal/services/content"
+	"newsletter-service/internal/services/sponsor"
+	"newsletter-service/internal/services/topic"
+)
+
+func newSponsorService(db *gorm.DB, contentService content.Service) sponsor.Service {
+	return sponsor.NewService(sponsor.NewRepository(db), topic.NewService(topic.NewRepository(db)), contentService)
+}
+
+// withSponsor returns c with the block of the sponsor booked into it, labeled in the content's language. When
+// the placement can't be loaded or rendered the content goes out unsponsored rather than not at all.
+func (s *notificationService) withSponsor(ctx context.Context, c *content.Content) *content.Content {
+	placement, err := s.sponsors.PlacementFor(ctx, c)
+	if err != nil {
+		fmt.Printf("Failed to load the sponsor of content %d, sending it without one: %v\n", c.ID, err)
+		return c
+	}
+	if placement == nil {
+		return c
+	}
+
+	block, err := templates.RenderSponsor(templates.SponsorData{
+		Name:    placement.Sponsor.Name,
+		URL:     placement.Sponsor.URL,
+		LogoURL: placement.Sponsor.LogoURL,
+		Message: placement.Message(),
+		Locale:  c.Locale,
+	})
+	if err != nil {
+		fmt.Printf("Failed to render the sponsor block of content %d, sending it without one: %v\n", c.ID, err)
+		return c
+	}
+
+	// Blank lines keep the block a paragraph of its own
+	sponsored := *c
+	body := templates.BodyHTML(c.Body)
+	if placement.Slot.Position == daos.SponsorPositionBottom {
+		sponsored.Body = body + "\n\n" + block
+	} else {
+		sponsored.Body = block + "\n\n" + body
+	}
+	return &sponsored
+}
@@ -0,0 +1,78 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// defaultSendTimeout bounds a provider call when [worker] send_timeout is unset
+const defaultSendTimeout = time.Minute
+
+// ErrSendTimedOut is wrapped into the error of a provider call abandoned after the send timeout
+var ErrSendTimedOut = errors.New("send timed out")
+
+// sendTimeouts counts provider calls abandoned after the send timeout since the service started
+type sendTimeouts struct {
+	mu         sync.Mutex
+	total      int64
+	byProvider map[string]int64
+}
+
+func (t *sendTimeouts) record(providerName string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.byProvider == nil {
+		t.byProvider = make(map[string]int64)
+	}
+	t.total++
+	t.byProvider[providerName]++
+}
+
+func (t *sendTimeouts) snapshot() (int64, map[string]int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	byProvider := make(map[string]int64, len(t.byProvider))
+	for name, count := range t.byProvider {
+		byProvider[name] = count
+	}
+	return t.total, byProvider
+}
+
+// getSendTimeout returns how long one provider call may take
+func (s *notificationService) getSendTimeout() time.Duration {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.workerConfig != nil && s.workerConfig.SendTimeout > 0 {
+		return s.workerConfig.SendTimeout
+	}
+	return defaultSendTimeout
+}
+
+// callProvider makes one provider call bounded by the send timeout. A call that fails once the timeout has
+// passed is counted against the provider and its error wraps ErrSendTimedOut; one cut short because ctx itself
+// ended, e.g. by the job timeout, is not, as the provider wasn't at fault.
+func callProvider[T any](s *notificationService, ctx context.Context, providerName string, send func(ctx context.Context) (T, error)) (T, error) {
+	timeout := s.getSendTimeout()
+	sendCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	result, err := send(sendCtx)
+	if err != nil && errors.Is(sendCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil {
+		s.timeouts.record(providerName)
+		err = fmt.Errorf("%w after %s via %s: %w", ErrSendTimedOut, timeout, providerName, err)
+	}
+	return result, err
+}
+
+// GetSendTimeoutStats reports how many provider calls were abandoned after the send timeout
+func (s *notificationService) GetSendTimeoutStats() SendTimeoutStats {
+	total, byProvider := s.timeouts.snapshot()
+	return SendTimeoutStats{
+		SendTimeout: s.getSendTimeout().String(),
+		Timeouts:    total,
+		ByProvider:  byProvider,
+	}
+}
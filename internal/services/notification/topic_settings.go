@@ -0,0 +1,59 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"newsletter-service/internal/daos"
+)
+
+// topicSettingsCacheTTL is how long a topic's resolved settings are reused; edits show up after it at the latest
+const topicSettingsCacheTTL = time.Minute
+
+// topicSettings are what a topic, with its organization, sets on every email it sends
+type topicSettings struct {
+	sender sender
+	utm    daos.UTM // Link tags, which a content's own override field by field
+}
+
+type cachedTopicSettings struct {
+	settings  topicSettings
+	expiresAt time.Time
+}
+
+// topicSettingsFor returns a topic's settings, so a send resolves them once rather than per email. Lookup
+// failures are logged and fall back to the provider defaults without link tags rather than holding up the send.
+func (s *notificationService) topicSettingsFor(ctx context.Context, topicID uint) topicSettings {
+	if cached, ok := s.topicSettings.Load(topicID); ok && time.Now().Before(cached.(cachedTopicSettings).expiresAt) {
+		return cached.(cachedTopicSettings).settings
+	}
+
+	resolved, err := s.resolveTopicSettings(ctx, topicID)
+	if err != nil {
+		fmt.Printf("Failed to load settings for topic %d, using provider defaults: %v\n", topicID, err)
+		return topicSettings{}
+	}
+	s.topicSettings.Store(topicID, cachedTopicSettings{settings: resolved, expiresAt: time.Now().Add(topicSettingsCacheTTL)})
+	return resolved
+}
+
+func (s *notificationService) resolveTopicSettings(ctx context.Context, topicID uint) (topicSettings, error) {
+	if s.db == nil {
+		return topicSettings{}, nil
+	}
+
+	// Content of a deleted topic can still be retried, so its settings must still be found
+	var topic daos.Topic
+	if err := s.db.WithContext(ctx).Unscoped().First(&topic, topicID).Error; err != nil {
+		return topicSettings{}, fmt.Errorf("failed to get topic: %w", err)
+	}
+	var organization daos.Organization
+	if err := s.db.WithContext(ctx).First(&organization, topic.OrganizationID).Error; err != nil {
+		return topicSettings{}, fmt.Errorf("failed to get organization: %w", err)
+	}
+	return topicSettings{
+		sender: newSender(topic.Branding.Merge(organization.Branding)),
+		utm:    topic.UTM,
+	}, nil
+}
@@ -0,0 +1,170 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"newsletter-service/internal/constants"
+	"newsletter-service/internal/providers"
+)
+
+// reserveWarmup claims up to n of today's sends under a provider's warm-up policy and returns how many were
+// granted. Providers without a policy, or past its last day, get all n. Attempts count against the cap
+// whether or not they are delivered, and the counters are shared by every replica through the database.
+func (s *notificationService) reserveWarmup(ctx context.Context, provider providers.EmailProviderInterface, n int) (int, error) {
+	providerFactory := s.providerFactoryFor(ctx)
+	if providerFactory == nil || n <= 0 {
+		return n, nil
+	}
+	policy := providerFactory.GetWarmupPolicy(provider.GetProviderName())
+	if policy == nil {
+		return n, nil
+	}
+
+	now := time.Now()
+	dailyCap, active := policy.DailyCap(now)
+	if !active {
+		return n, nil
+	}
+
+	day := providers.WarmupDate(now)
+	granted := 0
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		counter := WarmupCounter{PolicyKey: policy.Key, Day: day}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&counter).Error; err != nil {
+			return err
+		}
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("policy_key = ? AND day = ?", policy.Key, day).
+			First(&counter).Error
+		if err != nil {
+			return err
+		}
+
+		granted = max(min(n, dailyCap-counter.Sent), 0)
+		if granted == 0 {
+			return nil
+		}
+		return tx.Model(&WarmupCounter{}).
+			Where("policy_key = ? AND day = ?", policy.Key, day).
+			Updates(map[string]interface{}{"sent": counter.Sent + granted, "updated_at": now}).Error
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to reserve warm-up sends for %s: %w", policy.Key, err)
+	}
+	return granted, nil
+}
+
+// allowWarmupSends is reserveWarmup for the send paths: when the counter can't be read, nothing is sent
+// and the emails wait for a later run rather than risk going over the cap
+func (s *notificationService) allowWarmupSends(ctx context.Context, provider providers.EmailProviderInterface, n int) int {
+	granted, err := s.reserveWarmup(ctx, provider, n)
+	if err != nil {
+		fmt.Printf("Holding %d emails for provider %s: %v\n", n, provider.GetProviderName(), err)
+		return 0
+	}
+	if granted < n {
+		fmt.Printf("Provider %s reached its warm-up cap, %d emails carried over\n", provider.GetProviderName(), n-granted)
+	}
+	return granted
+}
+
+// logEmailQueued records an email held back by a warm-up cap as pending, so the worker sends it once a
+// following day's cap allows
+func (s *notificationService) logEmailQueued(ctx context.Context, contentID uint, subscriberID uint, email providers.EmailNotification) {
+	emailLog := &EmailLog{
+		SubscriberID: subscriberID,
+		ContentID:    contentID,
+		EmailAddress: email.To,
+		Subject:      email.Subject,
+		Body:         email.Body,
+		PreviewText:  email.PreviewText,
+		Locale:       email.Locale,
+		Status:       constants.StatusPending,
+		RetryCount:   0,
+	}
+
+	if err := s.LogEmail(ctx, emailLog); err != nil {
+		fmt.Printf("Failed to queue email for %s: %v\n", email.To, err)
+	}
+}
+
+// GetProviderStatuses reports the health, load and warm-up progress of every enabled provider
+func (s *notificationService) GetProviderStatuses(ctx context.Context) ([]ProviderStatus, error) {
+	providerFactory := s.providerFactoryFor(ctx)
+	if providerFactory == nil {
+		return nil, fmt.Errorf("provider status requires configured providers - use NewServiceWithProviders")
+	}
+
+	now := time.Now()
+	progressByPolicy := make(map[string]*WarmupProgress)
+	enabled := providerFactory.GetProviders()
+	statuses := make([]ProviderStatus, 0, len(enabled))
+
+	for _, provider := range enabled {
+		stats := provider.GetStats()
+		status := ProviderStatus{
+			Name:               provider.GetProviderName(),
+			Type:               string(provider.GetProviderType()),
+			Priority:           provider.GetPriority(),
+			IsHealthy:          stats.IsHealthy,
+			Paused:             providerFactory.IsPaused(provider.GetProviderName()),
+			EmailsSentLastHour: stats.EmailsSentLastHour,
+			CurrentLoad:        stats.CurrentLoad,
+		}
+		if stats.LastError != nil {
+			status.LastError = stats.LastError.Error()
+		}
+
+		if policy := providerFactory.GetWarmupPolicy(status.Name); policy != nil {
+			progress, ok := progressByPolicy[policy.Key]
+			if !ok {
+				var err error
+				progress, err = s.warmupProgress(ctx, policy, now)
+				if err != nil {
+					return nil, err
+				}
+				progressByPolicy[policy.Key] = progress
+			}
+			status.Warmup = progress
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+// warmupProgress reads today's counter of a warm-up policy
+func (s *notificationService) warmupProgress(ctx context.Context, policy *providers.WarmupPolicy, now time.Time) (*WarmupProgress, error) {
+	dailyCap, active := policy.DailyCap(now)
+	progress := &WarmupProgress{
+		Policy:    policy.Key,
+		StartDate: policy.StartDate.Format(time.DateOnly),
+		Day:       policy.Day(now),
+		TotalDays: len(policy.DailyCaps),
+		Completed: !active,
+	}
+	if !active {
+		return progress, nil
+	}
+
+	var sent int
+	err := s.db.WithContext(ctx).
+		Model(&WarmupCounter{}).
+		Select("sent").
+		Where("policy_key = ? AND day = ?", policy.Key, providers.WarmupDate(now)).
+		Scan(&sent).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get warm-up progress for %s: %w", policy.Key, err)
+	}
+
+	progress.DailyCap = dailyCap
+	progress.SentToday = sent
+	progress.Remaining = max(dailyCap-sent, 0)
+	return progress, nil
+}
@@ -0,0 +1,12 @@
+package organization
+
+// Core contains shared business logic for organization domain
+type Core struct {
+	service Service
+}
+
+func NewCore(service Service) *Core {
+	return &Core{
+		service: service,
+	}
+}
@@ -0,0 +1,17 @@
+package organization
+
+import "context"
+
+type Repository interface {
+	Create(ctx context.Context, organization *Organization) error
+	GetByID(ctx context.Context, id uint) (*Organization, error)
+	GetAllWithPagination(ctx context.Context, offset, limit int) ([]*Organization, int64, error)
+	Update(ctx context.Context, id uint, updates map[string]interface{}) error
+}
+
+type Service interface {
+	CreateOrganization(ctx context.Context, organization *Organization) error
+	GetOrganizationByID(ctx context.Context, id uint) (*Organization, error)
+	GetOrganizationsWithPagination(ctx context.Context, offset, limit int) ([]*Organization, int64, error)
+	UpdateOrganization(ctx context.Context, id uint, updates map[string]interface{}) error
+}
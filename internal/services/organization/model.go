@@ -0,0 +1,6 @@
+package organization
+
+import "newsletter-service/internal/daos"
+
+// Type alias for backward compatibility
+type Organization = daos.Organization
@@ -0,0 +1,46 @@
+package organization
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) Create(ctx context.Context, organization *Organization) error {
+	return r.db.WithContext(ctx).Create(organization).Error
+}
+
+func (r *repository) GetByID(ctx context.Context, id uint) (*Organization, error) {
+	var organization Organization
+	err := r.db.WithContext(ctx).First(&organization, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &organization, nil
+}
+
+func (r *repository) GetAllWithPagination(ctx context.Context, offset, limit int) ([]*Organization, int64, error) {
+	var organizations []*Organization
+	var total int64
+
+	// Get total count
+	if err := r.db.WithContext(ctx).Model(&Organization{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Get paginated results
+	err := r.db.WithContext(ctx).Order("id").Offset(offset).Limit(limit).Find(&organizations).Error
+	return organizations, total, err
+}
+
+func (r *repository) Update(ctx context.Context, id uint, updates map[string]interface{}) error {
+	return r.db.WithContext(ctx).Model(&Organization{}).Where("id = ?", id).Updates(updates).Error
+}
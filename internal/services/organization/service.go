@@ -0,0 +1,27 @@
+package organization
+
+import "context"
+
+type service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) Service {
+	return &service{repo: repo}
+}
+
+func (s *service) CreateOrganization(ctx context.Context, organization *Organization) error {
+	return s.repo.Create(ctx, organization)
+}
+
+func (s *service) GetOrganizationByID(ctx context.Context, id uint) (*Organization, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *service) GetOrganizationsWithPagination(ctx context.Context, offset, limit int) ([]*Organization, int64, error) {
+	return s.repo.GetAllWithPagination(ctx, offset, limit)
+}
+
+func (s *service) UpdateOrganization(ctx context.Context, id uint, updates map[string]interface{}) error {
+	return s.repo.Update(ctx, id, updates)
+}
@@ -0,0 +1,12 @@
+package poll
+
+// Core contains shared business logic for poll domain
+type Core struct {
+	service Service
+}
+
+func NewCore(service Service) *Core {
+	return &Core{
+		service: service,
+	}
+}
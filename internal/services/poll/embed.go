@@ -0,0 +1,159 @@
+package poll
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"html/template"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// includePattern matches an include such as {{poll 12}}, on its own in a paragraph or within text
+var includePattern = regexp.MustCompile(`<p>\s*\{\{\s*poll\s+(\d+)\s*\}\}\s*</p>|\{\{\s*poll\s+(\d+)\s*\}\}`)
+
+// placeholderPattern matches the token of an answer link not yet signed for its recipient. Rendered content is
+// shared by every recipient and logged that way; each email signs its links when it is sent.
+var placeholderPattern = regexp.MustCompile(`poll-token-(\d+)-(\d+)`)
+
+// signatureLength is how many hex characters of the HMAC a token carries
+const signatureLength = 32
+
+var blockTemplate = template.Must(template.New("poll").Parse(`<div class="poll" style="margin: 20px 0; padding: 15px; border: 1px solid #e0e0e0; border-radius: 8px;">
+    <strong>{{.Question}}</strong><br>
+    {{range .Options}}<a href="{{.URL}}" style="display: inline-block; margin: 10px 10px 0 0; padding: 8px 16px; border: 1px solid #007bff; border-radius: 4px; color: #007bff; text-decoration: none;">{{.Label}}</a> {{end}}
+</div>`))
+
+// IDs returns the distinct poll IDs body includes, in order of first use
+func IDs(body string) []uint {
+	var ids []uint
+	seen := make(map[uint]bool)
+	for _, match := range includePattern.FindAllStringSubmatch(body, -1) {
+		if id, ok := includeID(match); ok && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// Replace swaps each include in body for its poll's block, with answer links under publicURL. Includes of polls
+// missing from polls are removed, so a deleted poll, or one of another content, never leaves template syntax
+// in an email.
+func Replace(body string, polls map[uint]*Poll, publicURL string) (string, error) {
+	var renderErr error
+	expanded := includePattern.ReplaceAllStringFunc(body, func(include string) string {
+		id, _ := includeID(includePattern.FindStringSubmatch(include))
+		poll, ok := polls[id]
+		if !ok || renderErr != nil {
+			return ""
+		}
+		block, err := renderBlock(poll, publicURL)
+		if err != nil {
+			renderErr = err
+			return ""
+		}
+		if strings.HasPrefix(include, "<p>") {
+			return block
+		}
+		// Blank lines keep a block included within text a paragraph of its own
+		return "\n\n" + block + "\n\n"
+	})
+	if renderErr != nil {
+		return "", renderErr
+	}
+	return expanded, nil
+}
+
+// Personalize signs the answer links in body for the subscriber the email goes to. Bodies without polls are
+// returned as they are, and so are the links when no secret is set.
+func Personalize(body, secret string, subscriberID uint) string {
+	if secret == "" {
+		return body
+	}
+	return placeholderPattern.ReplaceAllStringFunc(body, func(placeholder string) string {
+		match := placeholderPattern.FindStringSubmatch(placeholder)
+		pollID, _ := strconv.ParseUint(match[1], 10, 64)
+		optionID, _ := strconv.ParseUint(match[2], 10, 64)
+		return Token(secret, uint(pollID), uint(optionID), subscriberID)
+	})
+}
+
+// HasLinks reports whether body has answer links still to be signed for each recipient
+func HasLinks(body string) bool {
+	return placeholderPattern.MatchString(body)
+}
+
+// Token returns the token of a subscriber's answer link to one option of a poll
+func Token(secret string, pollID, optionID, subscriberID uint) string {
+	payload := fmt.Sprintf("%d.%d.%d", pollID, optionID, subscriberID)
+	return payload + "." + sign(secret, payload)
+}
+
+// Verify returns the poll, option and subscriber a token answers for, or ErrInvalidToken when it wasn't
+// signed with secret
+func Verify(secret, token string) (pollID, optionID, subscriberID uint, err error) {
+	parts := strings.Split(token, ".")
+	if secret == "" || len(parts) != 4 {
+		return 0, 0, 0, ErrInvalidToken
+	}
+	payload := strings.Join(parts[:3], ".")
+	if !hmac.Equal([]byte(parts[3]), []byte(sign(secret, payload))) {
+		return 0, 0, 0, ErrInvalidToken
+	}
+
+	ids := make([]uint, 3)
+	for i, part := range parts[:3] {
+		id, parseErr := strconv.ParseUint(part, 10, 64)
+		if parseErr != nil || id == 0 {
+			return 0, 0, 0, ErrInvalidToken
+		}
+		ids[i] = uint(id)
+	}
+	return ids[0], ids[1], ids[2], nil
+}
+
+func sign(secret, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("poll:" + payload))
+	return hex.EncodeToString(mac.Sum(nil))[:signatureLength]
+}
+
+// includeID returns the poll ID of an include, matched on its own in a paragraph or within text
+func includeID(match []string) (uint, bool) {
+	raw := match[1]
+	if raw == "" {
+		raw = match[2]
+	}
+	id, err := strconv.ParseUint(raw, 10, 64)
+	return uint(id), err == nil
+}
+
+// renderBlock renders a poll as a question over a link per option. The links carry placeholders that
+// Personalize signs for each recipient. Line breaks are dropped so the block stays one paragraph of the body.
+func renderBlock(poll *Poll, publicURL string) (string, error) {
+	type option struct {
+		Label string
+		URL   string
+	}
+	data := struct {
+		Question string
+		Options  []option
+	}{Question: poll.Question}
+	base := strings.TrimRight(publicURL, "/") + "/polls/respond?token="
+	for _, o := range poll.Options {
+		data.Options = append(data.Options, option{
+			Label: o.Label,
+			URL:   base + fmt.Sprintf("poll-token-%d-%d", poll.ID, o.ID),
+		})
+	}
+
+	var buf bytes.Buffer
+	if err := blockTemplate.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to execute poll template: %w", err)
+	}
+	return strings.Join(strings.Fields(buf.String()), " "), nil
+}
@@ -0,0 +1,25 @@
+package poll
+
+import (
+	"context"
+)
+
+type Repository interface {
+	CreatePoll(ctx context.Context, poll *Poll) error
+	GetPollByID(ctx context.Context, id uint) (*Poll, error)
+	GetPollsByContentID(ctx context.Context, contentID uint) ([]*Poll, error)
+	UpdatePoll(ctx context.Context, id uint, updates map[string]interface{}) error
+	DeletePoll(ctx context.Context, id uint) error
+	SaveResponse(ctx context.Context, response *Response) error
+	CountResponses(ctx context.Context, pollIDs []uint) ([]OptionCount, error)
+}
+
+type Service interface {
+	CreatePoll(ctx context.Context, poll *Poll) error
+	GetPollByID(ctx context.Context, id uint) (*Poll, error)
+	UpdatePoll(ctx context.Context, id uint, updates map[string]interface{}) error
+	DeletePoll(ctx context.Context, id uint) error
+	GetResults(ctx context.Context, id uint) (*Results, error)
+	GetContentResults(ctx context.Context, contentID uint) ([]*Results, error)
+	Respond(ctx context.Context, token string) (*Answer, error)
+}
@@ -0,0 +1,51 @@
+package poll
+
+import (
+	"errors"
+
+	"newsletter-service/internal/daos"
+)
+
+// Type aliases for backward compatibility
+type Poll = daos.Poll
+type Option = daos.PollOption
+type Response = daos.PollResponse
+
+var (
+	// ErrContentNotFound is returned for polls in a content the organization doesn't have
+	ErrContentNotFound = errors.New("content not found")
+	// ErrInvalidToken is returned for answer links that weren't signed with the configured secret
+	ErrInvalidToken = errors.New("invalid poll token")
+	// ErrPollNotFound is returned for answers to a poll or option that has since been deleted
+	ErrPollNotFound = errors.New("poll not found")
+	// ErrPollClosed is returned for answers after the poll's closing time
+	ErrPollClosed = errors.New("poll is closed")
+	// ErrSubscriberNotFound is returned for answers from a subscriber who has since been deleted
+	ErrSubscriberNotFound = errors.New("subscriber not found")
+)
+
+// Answer is a response recorded from an answer link
+type Answer struct {
+	Poll   *Poll
+	Option *Option
+}
+
+// OptionResult is how many subscribers chose an option
+type OptionResult struct {
+	Option    Option
+	Responses int64
+}
+
+// Results is a poll with the responses to each of its options
+type Results struct {
+	Poll      *Poll
+	Options   []OptionResult
+	Responses int64
+}
+
+// OptionCount counts the responses to one option of a poll
+type OptionCount struct {
+	PollID    uint
+	OptionID  uint
+	Responses int64
+}
@@ -0,0 +1,79 @@
+package poll
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+// CreatePoll creates a poll with its options
+func (r *repository) CreatePoll(ctx context.Context, poll *Poll) error {
+	return r.db.WithContext(ctx).Create(poll).Error
+}
+
+func (r *repository) GetPollByID(ctx context.Context, id uint) (*Poll, error) {
+	var poll Poll
+	err := r.db.WithContext(ctx).Preload("Options", orderOptions).First(&poll, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &poll, nil
+}
+
+// GetPollsByContentID returns the polls of a content in the order they were created
+func (r *repository) GetPollsByContentID(ctx context.Context, contentID uint) ([]*Poll, error) {
+	var polls []*Poll
+	err := r.db.WithContext(ctx).Preload("Options", orderOptions).
+		Where("content_id = ?", contentID).
+		Order("id").
+		Find(&polls).Error
+	return polls, err
+}
+
+func (r *repository) UpdatePoll(ctx context.Context, id uint, updates map[string]interface{}) error {
+	return r.db.WithContext(ctx).Model(&Poll{}).Where("id = ?", id).Updates(updates).Error
+}
+
+func (r *repository) DeletePoll(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&Poll{}, id).Error
+}
+
+// SaveResponse records a subscriber's answer, replacing the one they gave before
+func (r *repository) SaveResponse(ctx context.Context, response *Response) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "poll_id"}, {Name: "subscriber_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"option_id":  response.OptionID,
+			"updated_at": time.Now(),
+		}),
+	}).Create(response).Error
+}
+
+// CountResponses counts the responses to each option of the polls; options nobody chose are left out
+func (r *repository) CountResponses(ctx context.Context, pollIDs []uint) ([]OptionCount, error) {
+	var counts []OptionCount
+	if len(pollIDs) == 0 {
+		return counts, nil
+	}
+	err := r.db.WithContext(ctx).Model(&Response{}).
+		Select("poll_id, option_id, COUNT(*) AS responses").
+		Where("poll_id IN ?", pollIDs).
+		Group("poll_id, option_id").
+		Scan(&counts).Error
+	return counts, err
+}
+
+// orderOptions preloads a poll's options in the order they are shown
+func orderOptions(db *gorm.DB) *gorm.DB {
+	return db.Order("position, id")
+}
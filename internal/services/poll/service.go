@@ -0,0 +1,167 @@
+package poll
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"newsletter-service/internal/config"
+	"newsletter-service/internal/services/content"
+	"newsletter-service/internal/services/subscriber"
+	"newsletter-service/internal/tenant"
+)
+
+type service struct {
+	repo              Repository
+	contentService    content.Service
+	subscriberService subscriber.Service
+	cfg               config.PollsConfig
+}
+
+func NewService(repo Repository, contentService content.Service, subscriberService subscriber.Service, cfg config.PollsConfig) Service {
+	return &service{
+		repo:              repo,
+		contentService:    contentService,
+		subscriberService: subscriberService,
+		cfg:               cfg,
+	}
+}
+
+// CreatePoll creates a poll with its options in a content of the organization ctx is scoped to. The content
+// shows it wherever its body includes {{poll <id>}}.
+func (s *service) CreatePoll(ctx context.Context, poll *Poll) error {
+	if _, err := s.contentService.GetContentByID(ctx, poll.ContentID); errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrContentNotFound
+	} else if err != nil {
+		return err
+	}
+	for i := range poll.Options {
+		poll.Options[i].Position = i
+	}
+	return s.repo.CreatePoll(ctx, poll)
+}
+
+func (s *service) GetPollByID(ctx context.Context, id uint) (*Poll, error) {
+	return s.repo.GetPollByID(ctx, id)
+}
+
+// UpdatePoll changes a poll's question or closing time. Emails already sent keep the question they went out
+// with; their links answer the updated poll.
+func (s *service) UpdatePoll(ctx context.Context, id uint, updates map[string]interface{}) error {
+	if _, err := s.repo.GetPollByID(ctx, id); err != nil {
+		return err
+	}
+	return s.repo.UpdatePoll(ctx, id, updates)
+}
+
+// DeletePoll deletes a poll. Sends from then on leave it out, and links in emails already sent stop answering.
+func (s *service) DeletePoll(ctx context.Context, id uint) error {
+	if _, err := s.repo.GetPollByID(ctx, id); err != nil {
+		return err
+	}
+	return s.repo.DeletePoll(ctx, id)
+}
+
+// GetResults returns a poll with how many subscribers chose each option
+func (s *service) GetResults(ctx context.Context, id uint) (*Results, error) {
+	poll, err := s.repo.GetPollByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	results, err := s.results(ctx, []*Poll{poll})
+	if err != nil {
+		return nil, err
+	}
+	return results[0], nil
+}
+
+// GetContentResults returns the results of every poll in a content
+func (s *service) GetContentResults(ctx context.Context, contentID uint) ([]*Results, error) {
+	if _, err := s.contentService.GetContentByID(ctx, contentID); errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrContentNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	polls, err := s.repo.GetPollsByContentID(ctx, contentID)
+	if err != nil {
+		return nil, err
+	}
+	return s.results(ctx, polls)
+}
+
+// Respond records the answer of an answer link's token. Links come from emails, so ctx isn't scoped to an
+// organization; the answer is recorded in the poll's. A later answer from the same subscriber replaces theirs.
+func (s *service) Respond(ctx context.Context, token string) (*Answer, error) {
+	pollID, optionID, subscriberID, err := Verify(s.cfg.Secret, token)
+	if err != nil {
+		return nil, err
+	}
+
+	poll, err := s.repo.GetPollByID(ctx, pollID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrPollNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	ctx = tenant.WithOrganization(ctx, poll.OrganizationID)
+
+	var option *Option
+	for i := range poll.Options {
+		if poll.Options[i].ID == optionID {
+			option = &poll.Options[i]
+		}
+	}
+	if option == nil {
+		return nil, ErrPollNotFound
+	}
+	if poll.ClosesAt != nil && time.Now().After(*poll.ClosesAt) {
+		return nil, ErrPollClosed
+	}
+	if _, err := s.subscriberService.GetSubscriberByID(ctx, subscriberID); errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrSubscriberNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	response := &Response{
+		PollID:         poll.ID,
+		OptionID:       option.ID,
+		SubscriberID:   subscriberID,
+		OrganizationID: poll.OrganizationID,
+	}
+	if err := s.repo.SaveResponse(ctx, response); err != nil {
+		return nil, err
+	}
+	return &Answer{Poll: poll, Option: option}, nil
+}
+
+// results counts the responses to each option of the polls, including the options nobody chose
+func (s *service) results(ctx context.Context, polls []*Poll) ([]*Results, error) {
+	pollIDs := make([]uint, 0, len(polls))
+	for _, poll := range polls {
+		pollIDs = append(pollIDs, poll.ID)
+	}
+	counts, err := s.repo.CountResponses(ctx, pollIDs)
+	if err != nil {
+		return nil, err
+	}
+	byOption := make(map[uint]int64, len(counts))
+	for _, count := range counts {
+		byOption[count.OptionID] = count.Responses
+	}
+
+	results := make([]*Results, 0, len(polls))
+	for _, poll := range polls {
+		result := &Results{Poll: poll, Options: make([]OptionResult, 0, len(poll.Options))}
+		for _, option := range poll.Options {
+			responses := byOption[option.ID]
+			result.Options = append(result.Options, OptionResult{Option: option, Responses: responses})
+			result.Responses += responses
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
@@ -0,0 +1,12 @@
+package preference
+
+// Core contains shared business logic for notification preference domain
+type Core struct {
+	service Service
+}
+
+func NewCore(service Service) *Core {
+	return &Core{
+		service: service,
+	}
+}
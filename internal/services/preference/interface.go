@@ -0,0 +1,14 @@
+package preference
+
+import "context"
+
+type Repository interface {
+	GetBySubscriberID(ctx context.Context, subscriberID uint) ([]*Preference, error)
+	GetSubscribedTopics(ctx context.Context, subscriberID uint) ([]SubscribedTopic, error)
+	Upsert(ctx context.Context, preferences []*Preference) error
+}
+
+type Service interface {
+	GetPreferences(ctx context.Context, subscriberID uint) ([]TopicPreference, error)
+	UpdatePreferences(ctx context.Context, subscriberID uint, preferences []TopicPreference) error
+}
@@ -0,0 +1,47 @@
+package preference
+
+import (
+	"errors"
+
+	"newsletter-service/internal/constants"
+	"newsletter-service/internal/daos"
+)
+
+// Type alias for backward compatibility
+type Preference = daos.NotificationPreference
+
+// Notification frequencies
+const (
+	FrequencyInstant = daos.FrequencyInstant
+	FrequencyDaily   = daos.FrequencyDaily
+	FrequencyWeekly  = daos.FrequencyWeekly
+	FrequencyOff     = daos.FrequencyOff
+)
+
+// Channels are the delivery channels a frequency can be chosen for
+var Channels = []string{constants.NotificationTypeEmail, constants.NotificationTypeSMS, constants.NotificationTypePush}
+
+// Preference validation errors
+var (
+	ErrNotSubscribed   = errors.New("subscriber is not subscribed to the topic")
+	ErrDigestEmailOnly = errors.New("daily and weekly digests are only available for email")
+)
+
+// TopicPreference is a subscriber's effective frequency for one subscribed topic on one channel
+type TopicPreference struct {
+	TopicID   uint
+	TopicName string
+	Channel   string
+	Frequency string
+}
+
+// SubscribedTopic is a topic the subscriber is subscribed to
+type SubscribedTopic struct {
+	ID   uint
+	Name string
+}
+
+// IsDigest reports whether a frequency collects content into digests
+func IsDigest(frequency string) bool {
+	return frequency == FrequencyDaily || frequency == FrequencyWeekly
+}
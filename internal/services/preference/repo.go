@@ -0,0 +1,54 @@
+package preference
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"newsletter-service/internal/daos"
+)
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) GetBySubscriberID(ctx context.Context, subscriberID uint) ([]*Preference, error) {
+	var preferences []*Preference
+	err := r.db.WithContext(ctx).Where("subscriber_id = ?", subscriberID).Order("topic_id, channel").Find(&preferences).Error
+	return preferences, err
+}
+
+func (r *repository) GetSubscribedTopics(ctx context.Context, subscriberID uint) ([]SubscribedTopic, error) {
+	var topics []SubscribedTopic
+	err := r.db.WithContext(ctx).
+		Model(&daos.Subscription{}).
+		Select("topics.id, topics.name").
+		Joins("JOIN topics ON topics.id = subscriptions.topic_id AND topics.deleted_at IS NULL").
+		Where("subscriptions.subscriber_id = ?", subscriberID).
+		Order("topics.name").
+		Scan(&topics).Error
+	return topics, err
+}
+
+// Upsert stores the preferences, replacing the frequency of existing ones. The digest window is kept when a
+// preference moves between digest frequencies, so content already waiting for a digest isn't skipped; it
+// restarts when a preference becomes a digest.
+func (r *repository) Upsert(ctx context.Context, preferences []*Preference) error {
+	if len(preferences) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "subscriber_id"}, {Name: "topic_id"}, {Name: "channel"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"frequency":  gorm.Expr("excluded.frequency"),
+			"updated_at": gorm.Expr("excluded.updated_at"),
+			"last_digest_at": gorm.Expr("CASE WHEN notification_preferences.frequency IN ? THEN notification_preferences.last_digest_at ELSE excluded.last_digest_at END",
+				[]string{FrequencyDaily, FrequencyWeekly}),
+		}),
+	}).Create(&preferences).Error
+}
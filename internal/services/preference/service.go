@@ -0,0 +1,95 @@
+package preference
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"newsletter-service/internal/constants"
+)
+
+type service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) Service {
+	return &service{repo: repo}
+}
+
+// GetPreferences returns the subscriber's frequency for every subscribed topic and channel, instant where no
+// preference was set
+func (s *service) GetPreferences(ctx context.Context, subscriberID uint) ([]TopicPreference, error) {
+	topics, err := s.repo.GetSubscribedTopics(ctx, subscriberID)
+	if err != nil {
+		return nil, err
+	}
+	stored, err := s.repo.GetBySubscriberID(ctx, subscriberID)
+	if err != nil {
+		return nil, err
+	}
+
+	frequencies := make(map[uint]map[string]string)
+	for _, p := range stored {
+		if frequencies[p.TopicID] == nil {
+			frequencies[p.TopicID] = make(map[string]string)
+		}
+		frequencies[p.TopicID][p.Channel] = p.Frequency
+	}
+
+	preferences := make([]TopicPreference, 0, len(topics)*len(Channels))
+	for _, topic := range topics {
+		for _, channel := range Channels {
+			frequency := frequencies[topic.ID][channel]
+			if frequency == "" {
+				frequency = FrequencyInstant
+			}
+			preferences = append(preferences, TopicPreference{
+				TopicID:   topic.ID,
+				TopicName: topic.Name,
+				Channel:   channel,
+				Frequency: frequency,
+			})
+		}
+	}
+	return preferences, nil
+}
+
+// UpdatePreferences sets frequencies for topics the subscriber is subscribed to. Digests are email only; SMS
+// and push are either instant or off.
+func (s *service) UpdatePreferences(ctx context.Context, subscriberID uint, preferences []TopicPreference) error {
+	topics, err := s.repo.GetSubscribedTopics(ctx, subscriberID)
+	if err != nil {
+		return err
+	}
+	subscribed := make(map[uint]bool, len(topics))
+	for _, topic := range topics {
+		subscribed[topic.ID] = true
+	}
+
+	// A topic and channel given twice keeps the last frequency; one upsert can't write the same row twice
+	now := time.Now()
+	rows := make([]*Preference, 0, len(preferences))
+	index := make(map[string]int, len(preferences))
+	for _, p := range preferences {
+		if !subscribed[p.TopicID] {
+			return ErrNotSubscribed
+		}
+		if IsDigest(p.Frequency) && p.Channel != constants.NotificationTypeEmail {
+			return ErrDigestEmailOnly
+		}
+		key := fmt.Sprintf("%d:%s", p.TopicID, p.Channel)
+		if i, ok := index[key]; ok {
+			rows[i].Frequency = p.Frequency
+			continue
+		}
+		index[key] = len(rows)
+		rows = append(rows, &Preference{
+			SubscriberID: subscriberID,
+			TopicID:      p.TopicID,
+			Channel:      p.Channel,
+			Frequency:    p.Frequency,
+			LastDigestAt: &now,
+		})
+	}
+	return s.repo.Upsert(ctx, rows)
+}
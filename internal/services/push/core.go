@@ -0,0 +1,12 @@
+package push
+
+// Core contains shared business logic for push device domain
+type Core struct {
+	service Service
+}
+
+func NewCore(service Service) *Core {
+	return &Core{
+		service: service,
+	}
+}
@@ -0,0 +1,15 @@
+package push
+
+import "context"
+
+type Repository interface {
+	Upsert(ctx context.Context, device *Device) error
+	GetBySubscriberID(ctx context.Context, subscriberID uint) ([]*Device, error)
+	Delete(ctx context.Context, subscriberID, id uint) (bool, error)
+}
+
+type Service interface {
+	RegisterDevice(ctx context.Context, device *Device) error
+	GetDevicesBySubscriberID(ctx context.Context, subscriberID uint) ([]*Device, error)
+	DeleteDevice(ctx context.Context, subscriberID, id uint) (bool, error)
+}
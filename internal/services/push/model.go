@@ -0,0 +1,19 @@
+package push
+
+import (
+	"errors"
+
+	"newsletter-service/internal/daos"
+)
+
+// Type alias for backward compatibility
+type Device = daos.PushDevice
+
+// Device platforms
+const (
+	PlatformFCM     = daos.PushPlatformFCM
+	PlatformWebPush = daos.PushPlatformWebPush
+)
+
+// ErrMissingKeys is returned when a Web Push device is registered without its subscription keys
+var ErrMissingKeys = errors.New("web push devices need p256dh and auth keys")
@@ -0,0 +1,37 @@
+package push
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+// Upsert stores a device, or moves an already registered token to the device's subscriber with fresh keys.
+// Browsers and apps re-register the same token, and a shared device may change hands.
+func (r *repository) Upsert(ctx context.Context, device *Device) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "token"}},
+		DoUpdates: clause.AssignmentColumns([]string{"subscriber_id", "platform", "p256dh", "auth", "updated_at"}),
+	}).Create(device).Error
+}
+
+func (r *repository) GetBySubscriberID(ctx context.Context, subscriberID uint) ([]*Device, error) {
+	var devices []*Device
+	err := r.db.WithContext(ctx).Where("subscriber_id = ?", subscriberID).Order("id").Find(&devices).Error
+	return devices, err
+}
+
+// Delete removes one of the subscriber's devices and reports whether it existed
+func (r *repository) Delete(ctx context.Context, subscriberID, id uint) (bool, error) {
+	result := r.db.WithContext(ctx).Where("subscriber_id = ?", subscriberID).Delete(&Device{}, id)
+	return result.RowsAffected > 0, result.Error
+}
@@ -0,0 +1,31 @@
+package push
+
+import "context"
+
+type service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) Service {
+	return &service{repo: repo}
+}
+
+// RegisterDevice stores a device for push notifications. Registering a token again updates it in place.
+func (s *service) RegisterDevice(ctx context.Context, device *Device) error {
+	if device.Platform == PlatformWebPush && (device.P256dh == "" || device.Auth == "") {
+		return ErrMissingKeys
+	}
+	if device.Platform != PlatformWebPush {
+		device.P256dh = ""
+		device.Auth = ""
+	}
+	return s.repo.Upsert(ctx, device)
+}
+
+func (s *service) GetDevicesBySubscriberID(ctx context.Context, subscriberID uint) ([]*Device, error) {
+	return s.repo.GetBySubscriberID(ctx, subscriberID)
+}
+
+func (s *service) DeleteDevice(ctx context.Context, subscriberID, id uint) (bool, error) {
+	return s.repo.Delete(ctx, subscriberID, id)
+}
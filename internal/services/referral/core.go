@@ -0,0 +1,12 @@
+package referral
+
+// Core contains shared business logic for referral domain
+type Core struct {
+	service Service
+}
+
+func NewCore(service Service) *Core {
+	return &Core{
+		service: service,
+	}
+}
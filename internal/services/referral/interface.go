@@ -0,0 +1,34 @@
+package referral
+
+import (
+	"context"
+	"time"
+
+	"newsletter-service/internal/providers"
+)
+
+type Repository interface {
+	GetSubscriberByCode(ctx context.Context, code string) (*Subscriber, error)
+	ExistsByNormalizedEmail(ctx context.Context, normalizedEmail string) (bool, error)
+	GetTopicNames(ctx context.Context, subscriberID uint) ([]string, error)
+	Create(ctx context.Context, referral *Referral) error
+	GetByToken(ctx context.Context, token string) (*Referral, error)
+	GetPendingByEmail(ctx context.Context, email string, createdAfter time.Time) (*Referral, error)
+	GetUnsent(ctx context.Context, createdAfter time.Time, limit int) ([]*Referral, error)
+	MarkConfirmationSent(ctx context.Context, id uint, sentAt time.Time) error
+	Credit(ctx context.Context, referral *Referral, subscriberID uint, confirmedAt time.Time) (bool, error)
+	GetLeaderboard(ctx context.Context, limit int) ([]*Subscriber, error)
+}
+
+// Mailer sends one email straight away. The worker's notification service sends confirmation emails through
+// its providers.
+type Mailer interface {
+	SendTransactionalEmail(ctx context.Context, email providers.EmailNotification) (string, error)
+}
+
+type Service interface {
+	Redeem(ctx context.Context, redemption Redemption) (*Referral, error)
+	Confirm(ctx context.Context, token string) (*Confirmation, error)
+	SendConfirmations(ctx context.Context) (int, error)
+	GetLeaderboard(ctx context.Context, limit int) ([]LeaderboardEntry, error)
+}
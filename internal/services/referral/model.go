@@ -0,0 +1,66 @@
+package referral
+
+import (
+	"errors"
+	"net/url"
+
+	"newsletter-service/internal/daos"
+)
+
+// Type aliases for backward compatibility
+type Referral = daos.Referral
+type Subscriber = daos.Subscriber
+
+// Referral statuses
+const (
+	StatusPending   = "pending"   // Waiting for the reader to confirm
+	StatusConfirmed = "confirmed" // The reader subscribed and the referrer was credited
+)
+
+var (
+	// ErrDisabled is returned for redemptions while [referrals] is not enabled
+	ErrDisabled = errors.New("referrals are not enabled")
+	// ErrUnknownCode is returned for a code no active subscriber has
+	ErrUnknownCode = errors.New("referral code not found")
+	// ErrAlreadySubscribed is returned when the referred address already belongs to a subscriber
+	ErrAlreadySubscribed = errors.New("this address is already subscribed")
+	// ErrInvalidToken is returned for a confirmation link that doesn't exist or has expired
+	ErrInvalidToken = errors.New("confirmation link is invalid or has expired")
+)
+
+// Redemption is a reader signing up through a referral link
+type Redemption struct {
+	Code  string
+	Email string
+	Name  string
+}
+
+// Confirmation is the outcome of following a confirmation link. Subscriber and TopicNames are only set by
+// the call that created the subscriber; confirming again leaves them nil.
+type Confirmation struct {
+	Referral   *Referral
+	Subscriber *Subscriber
+	TopicNames []string
+}
+
+// LeaderboardEntry is one subscriber on the referral leaderboard
+type LeaderboardEntry struct {
+	Rank       int
+	Subscriber *Subscriber
+}
+
+// Link returns the referral link for code: signupURL with ref=code added to its query. It returns "" when
+// signupURL isn't a URL or code is empty.
+func Link(signupURL, code string) string {
+	if signupURL == "" || code == "" {
+		return ""
+	}
+	u, err := url.Parse(signupURL)
+	if err != nil {
+		return ""
+	}
+	query := u.Query()
+	query.Set("ref", code)
+	u.RawQuery = query.Encode()
+	return u.String()
+}
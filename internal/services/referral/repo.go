@@ -0,0 +1,123 @@
+package referral
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+// GetSubscriberByCode finds the subscriber a referral code belongs to. Redemptions come in without an
+// organization, so the lookup spans all of them.
+func (r *repository) GetSubscriberByCode(ctx context.Context, code string) (*Subscriber, error) {
+	var subscriber Subscriber
+	err := r.db.WithContext(ctx).Where("referral_code = ?", code).First(&subscriber).Error
+	if err != nil {
+		return nil, err
+	}
+	return &subscriber, nil
+}
+
+// ExistsByNormalizedEmail reports whether a live subscriber of the organization ctx is scoped to has the address
+func (r *repository) ExistsByNormalizedEmail(ctx context.Context, normalizedEmail string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&Subscriber{}).Where("normalized_email = ?", normalizedEmail).Count(&count).Error
+	return count > 0, err
+}
+
+// GetTopicNames returns the topics a subscriber is on that still take subscriptions
+func (r *repository) GetTopicNames(ctx context.Context, subscriberID uint) ([]string, error) {
+	var topicNames []string
+	err := r.db.WithContext(ctx).
+		Table("subscriptions").
+		Joins("JOIN topics ON topics.id = subscriptions.topic_id").
+		Where("subscriptions.subscriber_id = ? AND subscriptions.deleted_at IS NULL", subscriberID).
+		Where("topics.deleted_at IS NULL AND topics.archived_at IS NULL").
+		Order("topics.name").
+		Pluck("topics.name", &topicNames).Error
+	return topicNames, err
+}
+
+func (r *repository) Create(ctx context.Context, referral *Referral) error {
+	return r.db.WithContext(ctx).Create(referral).Error
+}
+
+func (r *repository) GetByToken(ctx context.Context, token string) (*Referral, error) {
+	var referral Referral
+	err := r.db.WithContext(ctx).Where("token = ?", token).First(&referral).Error
+	if err != nil {
+		return nil, err
+	}
+	return &referral, nil
+}
+
+// GetPendingByEmail returns the latest pending referral of an address created after createdAfter
+func (r *repository) GetPendingByEmail(ctx context.Context, email string, createdAfter time.Time) (*Referral, error) {
+	var referral Referral
+	err := r.db.WithContext(ctx).
+		Where("lower(email) = lower(?) AND status = ? AND created_at > ?", email, StatusPending, createdAfter).
+		Order("id desc").
+		First(&referral).Error
+	if err != nil {
+		return nil, err
+	}
+	return &referral, nil
+}
+
+// GetUnsent returns pending referrals created after createdAfter whose confirmation email hasn't been sent,
+// oldest first
+func (r *repository) GetUnsent(ctx context.Context, createdAfter time.Time, limit int) ([]*Referral, error) {
+	var referrals []*Referral
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND confirmation_sent_at IS NULL AND created_at > ?", StatusPending, createdAfter).
+		Order("id").
+		Limit(limit).
+		Find(&referrals).Error
+	return referrals, err
+}
+
+func (r *repository) MarkConfirmationSent(ctx context.Context, id uint, sentAt time.Time) error {
+	return r.db.WithContext(ctx).Model(&Referral{}).Where("id = ?", id).Update("confirmation_sent_at", sentAt).Error
+}
+
+// Credit marks a pending referral confirmed by the subscriber it created and adds one to its referrer's count,
+// in one transaction. It reports false, changing nothing, when the referral was already confirmed.
+func (r *repository) Credit(ctx context.Context, referral *Referral, subscriberID uint, confirmedAt time.Time) (bool, error) {
+	credited := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&Referral{}).
+			Where("id = ? AND status = ?", referral.ID, StatusPending).
+			Updates(map[string]interface{}{
+				"status":        StatusConfirmed,
+				"confirmed_at":  confirmedAt,
+				"subscriber_id": subscriberID,
+			})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		credited = true
+		return tx.Model(&Subscriber{}).
+			Where("id = ?", referral.ReferrerID).
+			Update("referral_count", gorm.Expr("referral_count + 1")).Error
+	})
+	return credited, err
+}
+
+// GetLeaderboard returns the subscribers with the most confirmed referrals, ties going to the earlier subscriber
+func (r *repository) GetLeaderboard(ctx context.Context, limit int) ([]*Subscriber, error) {
+	var subscribers []*Subscriber
+	err := r.db.WithContext(ctx).
+		Where("referral_count > 0").
+		Order("referral_count desc, id").
+		Limit(limit).
+		Find(&subscribers).Error
+	return subscribers, err
+}
@@ -0,0 +1,268 @@
+package referral
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"html"
+	"log"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"newsletter-service/internal/config"
+	"newsletter-service/internal/daos"
+	"newsletter-service/internal/providers"
+	"newsletter-service/internal/services/subscriber"
+	"newsletter-service/internal/tenant"
+)
+
+// Defaults for settings left at zero
+const (
+	defaultConfirmWithin   = 7 * 24 * time.Hour
+	defaultLeaderboardSize = 10
+	maxLeaderboardSize     = 100
+)
+
+// confirmationBatchSize caps how many confirmation emails one run sends
+const confirmationBatchSize = 200
+
+type service struct {
+	repo              Repository
+	subscriberService subscriber.Service
+	mailer            Mailer // Nil in the web API, which leaves confirmation emails to the worker
+	cfg               config.ReferralsConfig
+}
+
+func NewService(repo Repository, subscriberService subscriber.Service, cfg config.ReferralsConfig) Service {
+	return &service{
+		repo:              repo,
+		subscriberService: subscriberService,
+		cfg:               cfg,
+	}
+}
+
+// NewServiceWithMailer creates a referral service that also sends confirmation emails through mailer
+func NewServiceWithMailer(repo Repository, subscriberService subscriber.Service, cfg config.ReferralsConfig, mailer Mailer) Service {
+	return &service{
+		repo:              repo,
+		subscriberService: subscriberService,
+		mailer:            mailer,
+		cfg:               cfg,
+	}
+}
+
+// Redeem records a reader signing up through a referral link. The reader becomes a subscriber of the
+// referrer's organization only once they follow the confirmation link the worker emails them. A reader whose
+// earlier signup is still waiting for confirmation gets that referral back.
+func (s *service) Redeem(ctx context.Context, redemption Redemption) (*Referral, error) {
+	if !s.cfg.Enabled {
+		return nil, ErrDisabled
+	}
+
+	referrer, err := s.repo.GetSubscriberByCode(ctx, redemption.Code)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && !referrer.IsActive) {
+		return nil, ErrUnknownCode
+	}
+	if err != nil {
+		return nil, err
+	}
+	ctx = tenant.WithOrganization(ctx, referrer.OrganizationID)
+
+	// Existing subscribers, the referrer included, can't be referred
+	exists, err := s.repo.ExistsByNormalizedEmail(ctx, subscriber.NormalizeEmail(redemption.Email))
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, ErrAlreadySubscribed
+	}
+
+	// Signing up again while a confirmation is pending sends nothing new
+	email := strings.TrimSpace(redemption.Email)
+	pending, err := s.repo.GetPendingByEmail(ctx, email, time.Now().Add(-s.confirmWithin()))
+	if err == nil {
+		return pending, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	token, err := newToken()
+	if err != nil {
+		return nil, err
+	}
+	referral := &Referral{
+		ReferrerID: referrer.ID,
+		Email:      email,
+		Name:       strings.TrimSpace(redemption.Name),
+		Token:      token,
+		Status:     StatusPending,
+	}
+	if err := s.repo.Create(ctx, referral); err != nil {
+		return nil, err
+	}
+	return referral, nil
+}
+
+// Confirm subscribes the reader of a pending referral to the referrer's topics and credits the referrer.
+// Confirming again returns the referral without crediting twice.
+func (s *service) Confirm(ctx context.Context, token string) (*Confirmation, error) {
+	referral, err := s.repo.GetByToken(ctx, token)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrInvalidToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	if referral.Status == StatusConfirmed {
+		return &Confirmation{Referral: referral}, nil
+	}
+	if time.Since(referral.CreatedAt) > s.confirmWithin() {
+		return nil, ErrInvalidToken
+	}
+	ctx = tenant.WithOrganization(ctx, referral.OrganizationID)
+
+	topicNames, err := s.repo.GetTopicNames(ctx, referral.ReferrerID)
+	if err != nil {
+		return nil, err
+	}
+	referrerID := referral.ReferrerID
+	created := &Subscriber{
+		Name:           referral.Name,
+		Email:          referral.Email,
+		OrganizationID: referral.OrganizationID,
+		ReferredByID:   &referrerID,
+		SignupSource:   daos.SignupSourceReferral,
+	}
+	if _, err := s.subscriberService.CreateSubscriberWithTopics(ctx, created, topicNames); err != nil {
+		if !errors.Is(err, subscriber.ErrDuplicateEmail) {
+			return nil, err
+		}
+		// A second click racing the first finds the subscriber the first one created
+		if current, getErr := s.repo.GetByToken(ctx, token); getErr == nil && current.Status == StatusConfirmed {
+			return &Confirmation{Referral: current}, nil
+		}
+		return nil, ErrAlreadySubscribed
+	}
+
+	// The referrer's cached record shows the new count once the subscriber cache entry expires
+	now := time.Now()
+	if _, err := s.repo.Credit(ctx, referral, created.ID, now); err != nil {
+		return nil, err
+	}
+	referral.Status = StatusConfirmed
+	referral.ConfirmedAt = &now
+	referral.SubscriberID = &created.ID
+	return &Confirmation{Referral: referral, Subscriber: created, TopicNames: topicNames}, nil
+}
+
+// SendConfirmations emails the confirmation link of every pending referral that hasn't had one, and returns
+// how many were sent. Failed sends are tried again on the next run until the link expires.
+func (s *service) SendConfirmations(ctx context.Context) (int, error) {
+	if !s.cfg.Enabled {
+		return 0, nil
+	}
+	if s.mailer == nil {
+		return 0, fmt.Errorf("a mailer is required to send confirmation emails - use NewServiceWithMailer")
+	}
+
+	referrals, err := s.repo.GetUnsent(ctx, time.Now().Add(-s.confirmWithin()), confirmationBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get pending referrals: %w", err)
+	}
+
+	sent := 0
+	var errs []error
+	for _, referral := range referrals {
+		if ctx.Err() != nil {
+			break
+		}
+		referralCtx := tenant.WithOrganization(ctx, referral.OrganizationID)
+
+		referrerName := ""
+		if referrer, err := s.subscriberService.GetSubscriberByID(referralCtx, referral.ReferrerID); err == nil {
+			referrerName = referrer.Name
+		}
+		if _, err := s.mailer.SendTransactionalEmail(referralCtx, s.confirmationEmail(referral, referrerName)); err != nil {
+			log.Printf("Failed to send referral confirmation %d: %v", referral.ID, err)
+			errs = append(errs, err)
+			continue
+		}
+		if err := s.repo.MarkConfirmationSent(referralCtx, referral.ID, time.Now()); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		sent++
+	}
+	return sent, errors.Join(errs...)
+}
+
+// confirmationEmail is the email asking a referred reader to confirm their subscription
+func (s *service) confirmationEmail(referral *Referral, referrerName string) providers.EmailNotification {
+	link := strings.TrimRight(s.cfg.PublicURL, "/") + "/referrals/confirm?token=" + referral.Token
+	invitedBy := "You"
+	if referrerName != "" {
+		invitedBy = html.EscapeString(referrerName) + " invited you and you"
+	}
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "<p>Hi %s,</p>\n", html.EscapeString(referral.Name))
+	fmt.Fprintf(&body, "<p>%s asked to subscribe with this address. Confirm to start receiving the newsletter:</p>\n", invitedBy)
+	fmt.Fprintf(&body, "<p><a href=\"%s\">Confirm your subscription</a></p>\n", html.EscapeString(link))
+	body.WriteString("<p>If you didn't sign up, ignore this email and you won't hear from us again.</p>")
+
+	return providers.EmailNotification{
+		To:          referral.Email,
+		Subject:     "Confirm your subscription",
+		Body:        body.String(),
+		PreviewText: "One click to confirm your subscription",
+	}
+}
+
+// GetLeaderboard ranks the subscribers of the organization ctx is scoped to by confirmed referrals. A limit
+// of 0 uses [referrals] leaderboard_size.
+func (s *service) GetLeaderboard(ctx context.Context, limit int) ([]LeaderboardEntry, error) {
+	if limit <= 0 {
+		limit = s.cfg.LeaderboardSize
+	}
+	if limit <= 0 {
+		limit = defaultLeaderboardSize
+	}
+	limit = min(limit, maxLeaderboardSize)
+
+	subscribers, err := s.repo.GetLeaderboard(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	// Subscribers with the same count share a rank
+	entries := make([]LeaderboardEntry, len(subscribers))
+	for i, sub := range subscribers {
+		rank := i + 1
+		if i > 0 && sub.ReferralCount == subscribers[i-1].ReferralCount {
+			rank = entries[i-1].Rank
+		}
+		entries[i] = LeaderboardEntry{Rank: rank, Subscriber: sub}
+	}
+	return entries, nil
+}
+
+func (s *service) confirmWithin() time.Duration {
+	if s.cfg.ConfirmWithin > 0 {
+		return s.cfg.ConfirmWithin
+	}
+	return defaultConfirmWithin
+}
+
+// newToken returns a random token for a confirmation link
+func newToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate confirmation token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
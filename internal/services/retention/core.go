@@ -0,0 +1,12 @@
+package retention
+
+// Core contains shared business logic for retention domain
+type Core struct {
+	service Service
+}
+
+func NewCore(service Service) *Core {
+	return &Core{
+		service: service,
+	}
+}
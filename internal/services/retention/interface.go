@@ -0,0 +1,19 @@
+package retention
+
+import (
+	"context"
+	"time"
+)
+
+type Repository interface {
+	CountAnonymizable(ctx context.Context, table Table, before time.Time) (int64, error)
+	Anonymize(ctx context.Context, table Table, before time.Time, limit int) (int64, error)
+	CountExpired(ctx context.Context, table Table, before time.Time) (int64, error)
+	DeleteExpired(ctx context.Context, table Table, before time.Time, limit int) (int64, error)
+	CountOrphans(ctx context.Context, orphans Orphans) (int64, error)
+	DeleteOrphans(ctx context.Context, orphans Orphans, limit int) (int64, error)
+}
+
+type Service interface {
+	Enforce(ctx context.Context, dryRun bool) (*Report, error)
+}
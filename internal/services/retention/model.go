@@ -0,0 +1,96 @@
+package retention
+
+import (
+	"time"
+
+	"newsletter-service/internal/constants"
+)
+
+// AnonymizedEmail replaces recipient addresses in anonymized email logs
+const AnonymizedEmail = "anonymized@invalid"
+
+// Table describes how retention applies to one table
+type Table struct {
+	Name       string
+	TimeColumn string                 // Age of a row is measured from this column; NULL never expires
+	Anonymized string                 // SQL condition true for rows that were already anonymized
+	Anonymize  map[string]interface{} // Column values written when anonymizing, nil if the table is delete-only
+	Orphans    *Orphans               // Rows of another table that only exist for this one, removed once unreferenced
+}
+
+// Orphans describes a dependent table cleaned up after its owner was anonymized or deleted
+type Orphans struct {
+	Table     string
+	Key       string // Column rows are deleted by
+	Condition string // SQL condition true for rows nothing references any more
+}
+
+// emailLogBodies are the shared bodies of email logs, archived or not. Bodies younger than an hour are left
+// alone, their log may not be committed yet.
+var emailLogBodies = &Orphans{
+	Table: constants.TableNameEmailLogBodies,
+	Key:   "hash",
+	Condition: "created_at < NOW() - INTERVAL '1 hour'" +
+		" AND NOT EXISTS (SELECT 1 FROM email_logs WHERE email_logs.body_hash = email_log_bodies.hash)" +
+		" AND NOT EXISTS (SELECT 1 FROM email_logs_archive WHERE email_logs_archive.body_hash = email_log_bodies.hash)",
+}
+
+// Tables lists every table a retention policy can be configured for
+var Tables = map[string]Table{
+	constants.TableNameEmailLogs: {
+		Name:       constants.TableNameEmailLogs,
+		TimeColumn: "created_at",
+		Anonymized: "email_address = '" + AnonymizedEmail + "'",
+		Anonymize:  map[string]interface{}{"email_address": AnonymizedEmail, "body": "", "body_hash": ""},
+		Orphans:    emailLogBodies,
+	},
+	constants.TableNameEmailLogsArchive: {
+		Name:       constants.TableNameEmailLogsArchive,
+		TimeColumn: "created_at",
+		Anonymized: "email_address = '" + AnonymizedEmail + "'",
+		Anonymize:  map[string]interface{}{"email_address": AnonymizedEmail, "body": "", "body_hash": ""},
+		Orphans:    emailLogBodies,
+	},
+	constants.TableNameWebhookDeliveries: {
+		Name:       constants.TableNameWebhookDeliveries,
+		TimeColumn: "created_at",
+		Anonymized: "payload = '{}'::jsonb",
+		Anonymize:  map[string]interface{}{"payload": "{}", "response_body": nil},
+	},
+	constants.TableNameAuditLogs: {
+		Name:       constants.TableNameAuditLogs,
+		TimeColumn: "created_at",
+		Anonymized: "before_state IS NULL AND after_state IS NULL AND changes IS NULL AND ip_address = ''",
+		Anonymize:  map[string]interface{}{"before_state": nil, "after_state": nil, "changes": nil, "ip_address": ""},
+	},
+	// Soft-deleted rows are purged by age since deletion; foreign keys cascade to their subscriptions, contents and logs
+	constants.TableNameTopics: {
+		Name:       constants.TableNameTopics,
+		TimeColumn: "deleted_at",
+	},
+	constants.TableNameSubscribers: {
+		Name:       constants.TableNameSubscribers,
+		TimeColumn: "deleted_at",
+	},
+	constants.TableNameContents: {
+		Name:       constants.TableNameContents,
+		TimeColumn: "deleted_at",
+	},
+}
+
+// Report summarises one enforcement run; in a dry run the counts are rows that would change
+type Report struct {
+	DryRun    bool          `json:"dry_run"`
+	Tables    []TableReport `json:"tables"`
+	StartedAt time.Time     `json:"started_at"`
+	Duration  string        `json:"duration"`
+}
+
+type TableReport struct {
+	Table           string     `json:"table"`
+	AnonymizeBefore *time.Time `json:"anonymize_before,omitempty"`
+	Anonymized      int64      `json:"anonymized"`
+	DeleteBefore    *time.Time `json:"delete_before,omitempty"`
+	Deleted         int64      `json:"deleted"`
+	OrphansDeleted  int64      `json:"orphans_deleted,omitempty"`
+}
@@ -0,0 +1,66 @@
+package retention
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+// Table names and conditions come from the fixed Tables registry, never from user input
+
+func (r *repository) CountAnonymizable(ctx context.Context, table Table, before time.Time) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Table(table.Name).
+		Where(fmt.Sprintf("%s < ? AND NOT (%s)", table.TimeColumn, table.Anonymized), before).
+		Count(&count).Error
+	return count, err
+}
+
+// Anonymize scrubs up to limit rows older than before that still hold personal data
+func (r *repository) Anonymize(ctx context.Context, table Table, before time.Time, limit int) (int64, error) {
+	batch := r.db.WithContext(ctx).Table(table.Name).Select("id").
+		Where(fmt.Sprintf("%s < ? AND NOT (%s)", table.TimeColumn, table.Anonymized), before).
+		Limit(limit)
+	result := r.db.WithContext(ctx).Table(table.Name).Where("id IN (?)", batch).Updates(table.Anonymize)
+	return result.RowsAffected, result.Error
+}
+
+func (r *repository) CountExpired(ctx context.Context, table Table, before time.Time) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Table(table.Name).
+		Where(fmt.Sprintf("%s < ?", table.TimeColumn), before).
+		Count(&count).Error
+	return count, err
+}
+
+// DeleteExpired permanently deletes up to limit rows older than before, including soft-deleted ones
+func (r *repository) DeleteExpired(ctx context.Context, table Table, before time.Time, limit int) (int64, error) {
+	result := r.db.WithContext(ctx).Exec(
+		fmt.Sprintf("DELETE FROM %[1]s WHERE id IN (SELECT id FROM %[1]s WHERE %[2]s < ? LIMIT ?)", table.Name, table.TimeColumn),
+		before, limit)
+	return result.RowsAffected, result.Error
+}
+
+func (r *repository) CountOrphans(ctx context.Context, orphans Orphans) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Table(orphans.Table).Where(orphans.Condition).Count(&count).Error
+	return count, err
+}
+
+// DeleteOrphans deletes up to limit dependent rows that no longer have an owner
+func (r *repository) DeleteOrphans(ctx context.Context, orphans Orphans, limit int) (int64, error) {
+	result := r.db.WithContext(ctx).Exec(
+		fmt.Sprintf("DELETE FROM %[1]s WHERE %[2]s IN (SELECT %[2]s FROM %[1]s WHERE %[3]s LIMIT ?)", orphans.Table, orphans.Key, orphans.Condition),
+		limit)
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,123 @@
+package retention
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"newsletter-service/internal/config"
+)
+
+type service struct {
+	repo Repository
+	cfg  config.RetentionConfig
+}
+
+// NewService creates a retention service. Policies for tables without retention support are ignored with a warning.
+func NewService(repo Repository, cfg config.RetentionConfig) Service {
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 1000
+	}
+	for name, policy := range cfg.Tables {
+		table, ok := Tables[name]
+		if !ok {
+			log.Printf("Warning: retention policy for unsupported table %q is ignored", name)
+			continue
+		}
+		if policy.AnonymizeAfter > 0 && table.Anonymize == nil {
+			log.Printf("Warning: table %q cannot be anonymized, anonymize_after is ignored", name)
+		}
+	}
+	return &service{repo: repo, cfg: cfg}
+}
+
+// Enforce anonymizes and deletes rows past their configured age. Deletion runs after anonymization,
+// so rows old enough for both are simply deleted on the next run. With dryRun only counts are reported.
+func (s *service) Enforce(ctx context.Context, dryRun bool) (*Report, error) {
+	report := &Report{DryRun: dryRun, StartedAt: time.Now()}
+	defer func() { report.Duration = time.Since(report.StartedAt).String() }()
+
+	names := make([]string, 0, len(s.cfg.Tables))
+	for name := range s.cfg.Tables {
+		if _, ok := Tables[name]; ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		tableReport, err := s.enforceTable(ctx, Tables[name], s.cfg.Tables[name], report.StartedAt, dryRun)
+		report.Tables = append(report.Tables, tableReport)
+		if err != nil {
+			return report, fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return report, nil
+}
+
+func (s *service) enforceTable(ctx context.Context, table Table, policy config.RetentionPolicy, now time.Time, dryRun bool) (TableReport, error) {
+	report := TableReport{Table: table.Name}
+
+	if policy.AnonymizeAfter > 0 && table.Anonymize != nil {
+		before := now.Add(-policy.AnonymizeAfter)
+		report.AnonymizeBefore = &before
+		count, err := s.runBatches(ctx, dryRun, func() (int64, error) {
+			return s.repo.CountAnonymizable(ctx, table, before)
+		}, func() (int64, error) {
+			return s.repo.Anonymize(ctx, table, before, s.cfg.BatchSize)
+		})
+		report.Anonymized = count
+		if err != nil {
+			return report, err
+		}
+	}
+
+	if policy.DeleteAfter > 0 {
+		before := now.Add(-policy.DeleteAfter)
+		report.DeleteBefore = &before
+		count, err := s.runBatches(ctx, dryRun, func() (int64, error) {
+			return s.repo.CountExpired(ctx, table, before)
+		}, func() (int64, error) {
+			return s.repo.DeleteExpired(ctx, table, before, s.cfg.BatchSize)
+		})
+		report.Deleted = count
+		if err != nil {
+			return report, err
+		}
+	}
+
+	if table.Orphans != nil {
+		count, err := s.runBatches(ctx, dryRun, func() (int64, error) {
+			return s.repo.CountOrphans(ctx, *table.Orphans)
+		}, func() (int64, error) {
+			return s.repo.DeleteOrphans(ctx, *table.Orphans, s.cfg.BatchSize)
+		})
+		report.OrphansDeleted = count
+		if err != nil {
+			return report, err
+		}
+	}
+
+	return report, nil
+}
+
+// runBatches counts in a dry run, otherwise repeats apply until a batch changes no rows
+func (s *service) runBatches(ctx context.Context, dryRun bool, count, apply func() (int64, error)) (int64, error) {
+	if dryRun {
+		return count()
+	}
+
+	var total int64
+	for {
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+		affected, err := apply()
+		total += affected
+		if err != nil || affected == 0 {
+			return total, err
+		}
+	}
+}
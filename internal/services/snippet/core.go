@@ -0,0 +1,12 @@
+package snippet
+
+// Core contains shared business logic for snippet domain
+type Core struct {
+	service Service
+}
+
+func NewCore(service Service) *Core {
+	return &Core{
+		service: service,
+	}
+}
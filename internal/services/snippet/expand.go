@@ -0,0 +1,56 @@
+package snippet
+
+import (
+	"bytes"
+	"regexp"
+
+	"github.com/yuin/goldmark"
+
+	"newsletter-service/internal/daos"
+)
+
+// includePattern matches an include such as {{snippet "signature"}}, spaces inside the braces allowed
+var includePattern = regexp.MustCompile(`\{\{\s*snippet\s+"([^"{}]*)"\s*\}\}`)
+
+// Names returns the distinct snippet names body includes, in order of first use
+func Names(body string) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, match := range includePattern.FindAllStringSubmatch(body, -1) {
+		if name := match[1]; !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Render returns a snippet's body as HTML. Markdown is converted with raw HTML left out; HTML snippets are used
+// as written.
+func Render(s *Snippet) string {
+	if s.Format != daos.SnippetFormatMarkdown {
+		return s.Body
+	}
+	var buf bytes.Buffer
+	// Writing to a bytes.Buffer can't fail
+	_ = goldmark.Convert([]byte(s.Body), &buf)
+	return buf.String()
+}
+
+// Replace swaps each include in body for the rendered snippet of that name. Includes of unknown snippets are
+// removed, so a deleted snippet never leaves template syntax in an email, and their names are returned.
+// Snippets are not expanded within snippets.
+func Replace(body string, rendered map[string]string) (string, []string) {
+	var missing []string
+	seen := make(map[string]bool)
+	expanded := includePattern.ReplaceAllStringFunc(body, func(include string) string {
+		name := includePattern.FindStringSubmatch(include)[1]
+		html, ok := rendered[name]
+		if !ok && !seen[name] {
+			seen[name] = true
+			missing = append(missing, name)
+		}
+		return html
+	})
+	return expanded, missing
+}
@@ -0,0 +1,22 @@
+package snippet
+
+import "context"
+
+type Repository interface {
+	Create(ctx context.Context, snippet *Snippet) error
+	GetByID(ctx context.Context, id uint) (*Snippet, error)
+	GetByNames(ctx context.Context, names []string) ([]*Snippet, error)
+	GetAllWithPagination(ctx context.Context, offset, limit int) ([]*Snippet, int64, error)
+	ExistsByName(ctx context.Context, name string, excludeID uint) (bool, error)
+	Update(ctx context.Context, id uint, updates map[string]interface{}) error
+	Delete(ctx context.Context, id uint) error
+}
+
+type Service interface {
+	CreateSnippet(ctx context.Context, snippet *Snippet) error
+	GetSnippetByID(ctx context.Context, id uint) (*Snippet, error)
+	GetSnippetsWithPagination(ctx context.Context, offset, limit int) ([]*Snippet, int64, error)
+	UpdateSnippet(ctx context.Context, id uint, updates map[string]interface{}) error
+	DeleteSnippet(ctx context.Context, id uint) error
+	Expand(ctx context.Context, body string) (string, error)
+}
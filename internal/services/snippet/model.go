@@ -0,0 +1,29 @@
+package snippet
+
+import (
+	"errors"
+	"regexp"
+
+	"newsletter-service/internal/daos"
+)
+
+// Type alias for backward compatibility
+type Snippet = daos.Snippet
+
+var (
+	// ErrDuplicateName is returned when another snippet of the organization already has the name
+	ErrDuplicateName = errors.New("a snippet with this name already exists")
+	// ErrInvalidName is returned for a name that can't be written inside {{snippet "..."}}
+	ErrInvalidName = errors.New("snippet names may only contain letters, digits, '-', '_' and '.'")
+)
+
+// namePattern is what a snippet name may contain, so it can always be included without escaping
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// ValidateName checks that name can be included in content
+func ValidateName(name string) error {
+	if !namePattern.MatchString(name) {
+		return ErrInvalidName
+	}
+	return nil
+}
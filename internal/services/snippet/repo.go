@@ -0,0 +1,69 @@
+package snippet
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) Create(ctx context.Context, snippet *Snippet) error {
+	return r.db.WithContext(ctx).Create(snippet).Error
+}
+
+func (r *repository) GetByID(ctx context.Context, id uint) (*Snippet, error) {
+	var snippet Snippet
+	err := r.db.WithContext(ctx).First(&snippet, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &snippet, nil
+}
+
+// GetByNames returns the snippets with the given names; names without one are left out
+func (r *repository) GetByNames(ctx context.Context, names []string) ([]*Snippet, error) {
+	var snippets []*Snippet
+	if len(names) == 0 {
+		return snippets, nil
+	}
+	err := r.db.WithContext(ctx).Where("name IN ?", names).Find(&snippets).Error
+	return snippets, err
+}
+
+func (r *repository) GetAllWithPagination(ctx context.Context, offset, limit int) ([]*Snippet, int64, error) {
+	var snippets []*Snippet
+	var total int64
+
+	if err := r.db.WithContext(ctx).Model(&Snippet{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := r.db.WithContext(ctx).Order("name").Offset(offset).Limit(limit).Find(&snippets).Error
+	return snippets, total, err
+}
+
+// ExistsByName reports whether a snippet other than excludeID has the name
+func (r *repository) ExistsByName(ctx context.Context, name string, excludeID uint) (bool, error) {
+	var count int64
+	query := r.db.WithContext(ctx).Model(&Snippet{}).Where("name = ?", name)
+	if excludeID != 0 {
+		query = query.Where("id <> ?", excludeID)
+	}
+	err := query.Count(&count).Error
+	return count > 0, err
+}
+
+func (r *repository) Update(ctx context.Context, id uint, updates map[string]interface{}) error {
+	return r.db.WithContext(ctx).Model(&Snippet{}).Where("id = ?", id).Updates(updates).Error
+}
+
+func (r *repository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&Snippet{}, id).Error
+}
@@ -0,0 +1,85 @@
+package snippet
+
+import (
+	"context"
+	"fmt"
+	"log"
+)
+
+type service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) Service {
+	return &service{repo: repo}
+}
+
+func (s *service) CreateSnippet(ctx context.Context, snippet *Snippet) error {
+	if err := ValidateName(snippet.Name); err != nil {
+		return err
+	}
+	if err := s.ensureUniqueName(ctx, snippet.Name, 0); err != nil {
+		return err
+	}
+	return s.repo.Create(ctx, snippet)
+}
+
+func (s *service) GetSnippetByID(ctx context.Context, id uint) (*Snippet, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *service) GetSnippetsWithPagination(ctx context.Context, offset, limit int) ([]*Snippet, int64, error) {
+	return s.repo.GetAllWithPagination(ctx, offset, limit)
+}
+
+// UpdateSnippet changes a snippet; a rename breaks the includes of the old name
+func (s *service) UpdateSnippet(ctx context.Context, id uint, updates map[string]interface{}) error {
+	if name, ok := updates["name"].(string); ok {
+		if err := ValidateName(name); err != nil {
+			return err
+		}
+		if err := s.ensureUniqueName(ctx, name, id); err != nil {
+			return err
+		}
+	}
+	return s.repo.Update(ctx, id, updates)
+}
+
+func (s *service) DeleteSnippet(ctx context.Context, id uint) error {
+	return s.repo.Delete(ctx, id)
+}
+
+// Expand replaces the snippet includes in body with the current snippets of the organization ctx is scoped
+// to. Includes of unknown snippets are dropped with a warning rather than failing the send.
+func (s *service) Expand(ctx context.Context, body string) (string, error) {
+	names := Names(body)
+	if len(names) == 0 {
+		return body, nil
+	}
+
+	snippets, err := s.repo.GetByNames(ctx, names)
+	if err != nil {
+		return "", fmt.Errorf("failed to load snippets: %w", err)
+	}
+	rendered := make(map[string]string, len(snippets))
+	for _, snippet := range snippets {
+		rendered[snippet.Name] = Render(snippet)
+	}
+
+	expanded, missing := Replace(body, rendered)
+	if len(missing) > 0 {
+		log.Printf("Warning: content includes unknown snippets %q, left out", missing)
+	}
+	return expanded, nil
+}
+
+func (s *service) ensureUniqueName(ctx context.Context, name string, excludeID uint) error {
+	exists, err := s.repo.ExistsByName(ctx, name, excludeID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return ErrDuplicateName
+	}
+	return nil
+}
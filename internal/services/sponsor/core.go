@@ -0,0 +1,12 @@
+package sponsor
+
+// Core contains shared business logic for sponsor domain
+type Core struct {
+	service Service
+}
+
+func NewCore(service Service) *Core {
+	return &Core{
+		service: service,
+	}
+}
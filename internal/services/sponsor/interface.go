@@ -0,0 +1,39 @@
+package sponsor
+
+import (
+	"context"
+	"time"
+)
+
+type Repository interface {
+	CreateSponsor(ctx context.Context, sponsor *Sponsor) error
+	GetSponsorByID(ctx context.Context, id uint) (*Sponsor, error)
+	GetSponsorByIDUnscoped(ctx context.Context, id uint) (*Sponsor, error)
+	GetSponsorsWithPagination(ctx context.Context, offset, limit int) ([]*Sponsor, int64, error)
+	UpdateSponsor(ctx context.Context, id uint, updates map[string]interface{}) error
+	DeleteSponsor(ctx context.Context, id uint) error
+	CreateSlot(ctx context.Context, slot *Slot) error
+	GetSlotByID(ctx context.Context, id uint) (*Slot, error)
+	GetSlotByContentID(ctx context.Context, contentID uint) (*Slot, error)
+	GetSlotsWithPagination(ctx context.Context, filter SlotFilter, offset, limit int) ([]*Slot, int64, error)
+	GetSlotsBySponsor(ctx context.Context, sponsorID uint) ([]*Slot, error)
+	SlotTaken(ctx context.Context, contentID *uint, topicID uint, date *time.Time) (bool, error)
+	ClaimDatedSlot(ctx context.Context, topicID uint, date time.Time, contentID uint) (bool, error)
+	MarkServed(ctx context.Context, id uint, servedAt time.Time) error
+	DeleteSlot(ctx context.Context, id uint) (bool, error)
+	GetDeliveryStats(ctx context.Context, contentIDs []uint) ([]DeliveryStats, error)
+}
+
+type Service interface {
+	CreateSponsor(ctx context.Context, sponsor *Sponsor) error
+	GetSponsorByID(ctx context.Context, id uint) (*Sponsor, error)
+	GetSponsorsWithPagination(ctx context.Context, offset, limit int) ([]*Sponsor, int64, error)
+	UpdateSponsor(ctx context.Context, id uint, updates map[string]interface{}) error
+	DeleteSponsor(ctx context.Context, id uint) error
+	BookSlot(ctx context.Context, slot *Slot) error
+	GetSlotByID(ctx context.Context, id uint) (*Slot, error)
+	GetSlotsWithPagination(ctx context.Context, filter SlotFilter, offset, limit int) ([]*Slot, int64, error)
+	CancelSlot(ctx context.Context, id uint) error
+	GetReport(ctx context.Context, sponsorID uint) (*Report, error)
+	PlacementFor(ctx context.Context, c *Content) (*Placement, error)
+}
@@ -0,0 +1,82 @@
+package sponsor
+
+import (
+	"errors"
+	"time"
+
+	"newsletter-service/internal/daos"
+)
+
+// Type aliases for backward compatibility
+type Sponsor = daos.Sponsor
+type Slot = daos.SponsorSlot
+type Content = daos.Content
+
+var (
+	// ErrSponsorNotFound is returned for slots of a sponsor the organization doesn't have
+	ErrSponsorNotFound = errors.New("sponsor not found")
+	// ErrTopicNotFound is returned for dated slots in a topic the organization doesn't have
+	ErrTopicNotFound = errors.New("topic not found")
+	// ErrContentNotFound is returned for slots in a content the organization doesn't have
+	ErrContentNotFound = errors.New("content not found")
+	// ErrInvalidSlot is returned for slots booked in both or neither of a content and a topic's date
+	ErrInvalidSlot = errors.New("a slot is booked in either a content or a topic on a date")
+	// ErrContentSent is returned for slots in a content that has already been sent
+	ErrContentSent = errors.New("content has already been sent")
+	// ErrDatePassed is returned for dated slots before today
+	ErrDatePassed = errors.New("slot date has passed")
+	// ErrSlotTaken is returned when the content or the topic's date already has a sponsor
+	ErrSlotTaken = errors.New("slot is already booked")
+	// ErrSlotServed is returned for canceling a slot whose content has gone out
+	ErrSlotServed = errors.New("slot has already been served")
+)
+
+// Placement is the sponsor block a content carries
+type Placement struct {
+	Sponsor *Sponsor
+	Slot    *Slot
+}
+
+// Message is the copy of the block: the slot's own, or else the sponsor's
+func (p *Placement) Message() string {
+	if p.Slot.Message != "" {
+		return p.Slot.Message
+	}
+	return p.Sponsor.Message
+}
+
+// SlotFilter narrows a slot listing; zero fields match everything
+type SlotFilter struct {
+	SponsorID uint
+	TopicID   uint
+	Served    *bool
+}
+
+// SlotStats is how a served slot's content did. Opens of the content are its sponsor block's impressions;
+// digests are counted with the latest content they carry.
+type SlotStats struct {
+	Slot        *Slot
+	Sends       int64
+	Impressions int64
+}
+
+// Report is a sponsor's slots with the sends and impressions of those served
+type Report struct {
+	Sponsor     *Sponsor
+	Slots       []SlotStats
+	Sends       int64
+	Impressions int64
+}
+
+// DeliveryStats counts the email logs of one content
+type DeliveryStats struct {
+	ContentID   uint
+	Sends       int64
+	Impressions int64
+}
+
+// slotDate is the date a dated slot books, midnight UTC
+func slotDate(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,176 @@
+package sponsor
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"newsletter-service/internal/constants"
+	"newsletter-service/internal/daos"
+)
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) CreateSponsor(ctx context.Context, sponsor *Sponsor) error {
+	return r.db.WithContext(ctx).Create(sponsor).Error
+}
+
+func (r *repository) GetSponsorByID(ctx context.Context, id uint) (*Sponsor, error) {
+	var sponsor Sponsor
+	err := r.db.WithContext(ctx).First(&sponsor, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &sponsor, nil
+}
+
+// GetSponsorByIDUnscoped finds a sponsor even after it was deleted, for slots it had already been served in
+func (r *repository) GetSponsorByIDUnscoped(ctx context.Context, id uint) (*Sponsor, error) {
+	var sponsor Sponsor
+	err := r.db.WithContext(ctx).Unscoped().First(&sponsor, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &sponsor, nil
+}
+
+func (r *repository) GetSponsorsWithPagination(ctx context.Context, offset, limit int) ([]*Sponsor, int64, error) {
+	var sponsors []*Sponsor
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&Sponsor{})
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("name").Offset(offset).Limit(limit).Find(&sponsors).Error
+	return sponsors, total, err
+}
+
+func (r *repository) UpdateSponsor(ctx context.Context, id uint, updates map[string]interface{}) error {
+	return r.db.WithContext(ctx).Model(&Sponsor{}).Where("id = ?", id).Updates(updates).Error
+}
+
+// DeleteSponsor deletes a sponsor with the slots it hasn't been served in yet. Served slots stay for its
+// report.
+func (r *repository) DeleteSponsor(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("sponsor_id = ? AND served_at IS NULL", id).Delete(&Slot{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&Sponsor{}, id).Error
+	})
+}
+
+func (r *repository) CreateSlot(ctx context.Context, slot *Slot) error {
+	return r.db.WithContext(ctx).Create(slot).Error
+}
+
+func (r *repository) GetSlotByID(ctx context.Context, id uint) (*Slot, error) {
+	var slot Slot
+	err := r.db.WithContext(ctx).First(&slot, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &slot, nil
+}
+
+func (r *repository) GetSlotByContentID(ctx context.Context, contentID uint) (*Slot, error) {
+	var slot Slot
+	err := r.db.WithContext(ctx).Where("content_id = ?", contentID).First(&slot).Error
+	if err != nil {
+		return nil, err
+	}
+	return &slot, nil
+}
+
+// GetSlotsWithPagination lists slots matching the filter, newest first
+func (r *repository) GetSlotsWithPagination(ctx context.Context, filter SlotFilter, offset, limit int) ([]*Slot, int64, error) {
+	var slots []*Slot
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&Slot{})
+	if filter.SponsorID != 0 {
+		query = query.Where("sponsor_id = ?", filter.SponsorID)
+	}
+	if filter.TopicID != 0 {
+		query = query.Where("topic_id = ?", filter.TopicID)
+	}
+	if filter.Served != nil {
+		if *filter.Served {
+			query = query.Where("served_at IS NOT NULL")
+		} else {
+			query = query.Where("served_at IS NULL")
+		}
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("id desc").Offset(offset).Limit(limit).Find(&slots).Error
+	return slots, total, err
+}
+
+// GetSlotsBySponsor returns every slot of a sponsor in the order they were booked
+func (r *repository) GetSlotsBySponsor(ctx context.Context, sponsorID uint) ([]*Slot, error) {
+	var slots []*Slot
+	err := r.db.WithContext(ctx).Where("sponsor_id = ?", sponsorID).Order("id").Find(&slots).Error
+	return slots, err
+}
+
+// SlotTaken reports whether a slot is already booked in the content, or when contentID is nil, in the topic
+// on the date
+func (r *repository) SlotTaken(ctx context.Context, contentID *uint, topicID uint, date *time.Time) (bool, error) {
+	query := r.db.WithContext(ctx).Model(&Slot{})
+	if contentID != nil {
+		query = query.Where("content_id = ?", *contentID)
+	} else {
+		query = query.Where("topic_id = ? AND date = ?", topicID, *date)
+	}
+	var count int64
+	err := query.Count(&count).Error
+	return count > 0, err
+}
+
+// ClaimDatedSlot gives the unclaimed slot of the topic's date to the content about to be sent, reporting
+// whether there was one. Two workers sending the same date can't both claim it.
+func (r *repository) ClaimDatedSlot(ctx context.Context, topicID uint, date time.Time, contentID uint) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&Slot{}).
+		Where("topic_id = ? AND date = ? AND content_id IS NULL", topicID, date).
+		Update("content_id", contentID)
+	return result.RowsAffected > 0, result.Error
+}
+
+// MarkServed records when a slot's content first went out
+func (r *repository) MarkServed(ctx context.Context, id uint, servedAt time.Time) error {
+	return r.db.WithContext(ctx).Model(&Slot{}).
+		Where("id = ? AND served_at IS NULL", id).
+		Update("served_at", servedAt).Error
+}
+
+// DeleteSlot deletes a slot that hasn't been served, reporting false when it had been by then
+func (r *repository) DeleteSlot(ctx context.Context, id uint) (bool, error) {
+	result := r.db.WithContext(ctx).Where("id = ? AND served_at IS NULL", id).Delete(&Slot{})
+	return result.RowsAffected > 0, result.Error
+}
+
+// GetDeliveryStats counts the emails sent of each content and how many of them were opened
+func (r *repository) GetDeliveryStats(ctx context.Context, contentIDs []uint) ([]DeliveryStats, error) {
+	var stats []DeliveryStats
+	if len(contentIDs) == 0 {
+		return stats, nil
+	}
+	err := r.db.WithContext(ctx).Model(&daos.EmailLog{}).
+		Select("content_id, COUNT(*) FILTER (WHERE status = ?) AS sends, COUNT(opened_at) AS impressions", constants.StatusSent).
+		Where("content_id IN ? AND channel = ?", contentIDs, constants.NotificationTypeEmail).
+		Group("content_id").
+		Scan(&stats).Error
+	return stats, err
+}
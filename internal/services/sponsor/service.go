@@ -0,0 +1,215 @@
+package sponsor
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"newsletter-service/internal/daos"
+	"newsletter-service/internal/services/content"
+	"newsletter-service/internal/services/topic"
+)
+
+type service struct {
+	repo           Repository
+	topicService   topic.Service
+	contentService content.Service
+}
+
+func NewService(repo Repository, topicService topic.Service, contentService content.Service) Service {
+	return &service{
+		repo:           repo,
+		topicService:   topicService,
+		contentService: contentService,
+	}
+}
+
+func (s *service) CreateSponsor(ctx context.Context, sponsor *Sponsor) error {
+	return s.repo.CreateSponsor(ctx, sponsor)
+}
+
+func (s *service) GetSponsorByID(ctx context.Context, id uint) (*Sponsor, error) {
+	return s.repo.GetSponsorByID(ctx, id)
+}
+
+func (s *service) GetSponsorsWithPagination(ctx context.Context, offset, limit int) ([]*Sponsor, int64, error) {
+	return s.repo.GetSponsorsWithPagination(ctx, offset, limit)
+}
+
+// UpdateSponsor changes a sponsor. Content already sent keeps the block it went out with; digests and
+// later sends show the change.
+func (s *service) UpdateSponsor(ctx context.Context, id uint, updates map[string]interface{}) error {
+	if _, err := s.repo.GetSponsorByID(ctx, id); err != nil {
+		return err
+	}
+	return s.repo.UpdateSponsor(ctx, id, updates)
+}
+
+// DeleteSponsor deletes a sponsor and cancels the slots it hasn't been served in
+func (s *service) DeleteSponsor(ctx context.Context, id uint) error {
+	if _, err := s.repo.GetSponsorByID(ctx, id); err != nil {
+		return err
+	}
+	return s.repo.DeleteSponsor(ctx, id)
+}
+
+// BookSlot books a placement for a sponsor of the organization ctx is scoped to: in a content not sent yet,
+// or in the first content its topic sends on a date from today on
+func (s *service) BookSlot(ctx context.Context, slot *Slot) error {
+	if (slot.ContentID == nil) == (slot.Date == nil) {
+		return ErrInvalidSlot
+	}
+	if _, err := s.repo.GetSponsorByID(ctx, slot.SponsorID); errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrSponsorNotFound
+	} else if err != nil {
+		return err
+	}
+
+	if slot.ContentID != nil {
+		c, err := s.contentService.GetContentByID(ctx, *slot.ContentID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrContentNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if c.NotificationsSent {
+			return ErrContentSent
+		}
+		slot.TopicID = c.TopicID
+	} else {
+		if _, err := s.topicService.GetTopicByID(ctx, slot.TopicID); errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrTopicNotFound
+		} else if err != nil {
+			return err
+		}
+		date := slotDate(*slot.Date)
+		if date.Before(slotDate(time.Now())) {
+			return ErrDatePassed
+		}
+		slot.Date = &date
+	}
+
+	taken, err := s.repo.SlotTaken(ctx, slot.ContentID, slot.TopicID, slot.Date)
+	if err != nil {
+		return err
+	}
+	if taken {
+		return ErrSlotTaken
+	}
+	if slot.Position == "" {
+		slot.Position = daos.SponsorPositionTop
+	}
+	return s.repo.CreateSlot(ctx, slot)
+}
+
+func (s *service) GetSlotByID(ctx context.Context, id uint) (*Slot, error) {
+	return s.repo.GetSlotByID(ctx, id)
+}
+
+func (s *service) GetSlotsWithPagination(ctx context.Context, filter SlotFilter, offset, limit int) ([]*Slot, int64, error) {
+	return s.repo.GetSlotsWithPagination(ctx, filter, offset, limit)
+}
+
+// CancelSlot cancels a placement whose content hasn't gone out
+func (s *service) CancelSlot(ctx context.Context, id uint) error {
+	slot, err := s.repo.GetSlotByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if slot.ServedAt != nil {
+		return ErrSlotServed
+	}
+	deleted, err := s.repo.DeleteSlot(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrSlotServed
+	}
+	return nil
+}
+
+// GetReport returns a sponsor's slots with the sends and impressions of the content each was served in
+func (s *service) GetReport(ctx context.Context, sponsorID uint) (*Report, error) {
+	sponsor, err := s.repo.GetSponsorByID(ctx, sponsorID)
+	if err != nil {
+		return nil, err
+	}
+	slots, err := s.repo.GetSlotsBySponsor(ctx, sponsorID)
+	if err != nil {
+		return nil, err
+	}
+
+	var contentIDs []uint
+	for _, slot := range slots {
+		if slot.ServedAt != nil && slot.ContentID != nil {
+			contentIDs = append(contentIDs, *slot.ContentID)
+		}
+	}
+	stats, err := s.repo.GetDeliveryStats(ctx, contentIDs)
+	if err != nil {
+		return nil, err
+	}
+	byContent := make(map[uint]DeliveryStats, len(stats))
+	for _, stat := range stats {
+		byContent[stat.ContentID] = stat
+	}
+
+	report := &Report{Sponsor: sponsor, Slots: make([]SlotStats, 0, len(slots))}
+	for _, slot := range slots {
+		entry := SlotStats{Slot: slot}
+		if slot.ServedAt != nil && slot.ContentID != nil {
+			stat := byContent[*slot.ContentID]
+			entry.Sends, entry.Impressions = stat.Sends, stat.Impressions
+		}
+		report.Slots = append(report.Slots, entry)
+		report.Sends += entry.Sends
+		report.Impressions += entry.Impressions
+	}
+	return report, nil
+}
+
+// PlacementFor returns the sponsor block c carries, or nil when it has none. Content about to be sent for the
+// first time is given the slot booked for its topic on its send date, unless it has a slot of its own, and the
+// slot is marked served. ctx must be scoped to the content's organization.
+func (s *service) PlacementFor(ctx context.Context, c *Content) (*Placement, error) {
+	slot, err := s.repo.GetSlotByContentID(ctx, c.ID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// Content that went out without a sponsor, e.g. in a digest, doesn't take a later booking
+		if c.NotificationsSent {
+			return nil, nil
+		}
+		claimed, claimErr := s.repo.ClaimDatedSlot(ctx, c.TopicID, slotDate(sendDate(c)), c.ID)
+		if claimErr != nil || !claimed {
+			return nil, claimErr
+		}
+		slot, err = s.repo.GetSlotByContentID(ctx, c.ID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if slot.ServedAt == nil && !c.NotificationsSent {
+		now := time.Now()
+		if err := s.repo.MarkServed(ctx, slot.ID, now); err != nil {
+			return nil, err
+		}
+		slot.ServedAt = &now
+	}
+	sponsor, err := s.repo.GetSponsorByIDUnscoped(ctx, slot.SponsorID)
+	if err != nil {
+		return nil, err
+	}
+	return &Placement{Sponsor: sponsor, Slot: slot}, nil
+}
+
+// sendDate is the day a content goes out: its send time when it has one, or else now
+func sendDate(c *Content) time.Time {
+	if c.SendAt != nil {
+		return *c.SendAt
+	}
+	return time.Now()
+}
@@ -0,0 +1,12 @@
+package stats
+
+// Core contains shared business logic for stats domain
+type Core struct {
+	service Service
+}
+
+func NewCore(service Service) *Core {
+	return &Core{
+		service: service,
+	}
+}
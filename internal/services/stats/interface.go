@@ -0,0 +1,20 @@
+package stats
+
+import (
+	"context"
+	"time"
+)
+
+type Repository interface {
+	GetOverview(ctx context.Context, since time.Time) (*Overview, error)
+	GetBuckets(ctx context.Context, metric, interval string, from, to time.Time) ([]Point, error)
+	GetDomainStats(ctx context.Context, since time.Time, limit int) ([]DomainStats, error)
+	GetSignupBuckets(ctx context.Context, by, interval string, from, to time.Time) ([]SignupBucket, error)
+}
+
+type Service interface {
+	GetOverview(ctx context.Context) (*Overview, error)
+	GetTimeseries(ctx context.Context, metric, interval string, from, to time.Time) (*Timeseries, error)
+	GetDomainReport(ctx context.Context, days, limit int) (*DomainReport, error)
+	GetSignupReport(ctx context.Context, by, interval string, from, to time.Time, limit int) (*SignupReport, error)
+}
@@ -0,0 +1,165 @@
+package stats
+
+import (
+	"time"
+)
+
+// Timeseries metrics
+const (
+	MetricSends         = "sends"
+	MetricFailures      = "failures"
+	MetricOpens         = "opens"
+	MetricSubscriptions = "subscriptions"
+	MetricUnsubscribes  = "unsubscribes"
+	MetricSignups       = "signups"
+)
+
+// Metrics lists every metric accepted by the timeseries endpoint
+var Metrics = []string{MetricSends, MetricFailures, MetricOpens, MetricSubscriptions, MetricUnsubscribes, MetricSignups}
+
+// Signup attribution fields the signups report can break new subscribers down by
+const (
+	SignupsBySource      = "source"
+	SignupsByForm        = "form"
+	SignupsByUTMSource   = "utm_source"
+	SignupsByUTMMedium   = "utm_medium"
+	SignupsByUTMCampaign = "utm_campaign"
+	SignupsByCountry     = "country"
+	SignupsByReferrer    = "referrer" // The referring page's host
+	SignupsByAPIKey      = "api_key"
+)
+
+// SignupDimensions lists every breakdown accepted by the signups report
+var SignupDimensions = []string{
+	SignupsBySource, SignupsByForm, SignupsByUTMSource, SignupsByUTMMedium, SignupsByUTMCampaign,
+	SignupsByCountry, SignupsByReferrer, SignupsByAPIKey,
+}
+
+// NoSignupValue labels subscribers without the attribution field, such as those created before it was recorded
+const NoSignupValue = "(none)"
+
+// Signups report defaults and bounds
+const (
+	DefaultSignupValues = 10
+	MaxSignupValues     = 50
+)
+
+// Timeseries bucket sizes
+const (
+	IntervalDay   = "day"
+	IntervalWeek  = "week"
+	IntervalMonth = "month"
+)
+
+// Intervals lists every bucket size accepted by the timeseries endpoint
+var Intervals = []string{IntervalDay, IntervalWeek, IntervalMonth}
+
+// MaxBuckets caps how many points a single timeseries request can return
+const MaxBuckets = 366
+
+// Overview is a point-in-time summary of the whole service
+type Overview struct {
+	Topics        int64           `json:"topics"`
+	Subscribers   SubscriberStats `json:"subscribers"`
+	Subscriptions int64           `json:"subscriptions"`
+	Contents      ContentStats    `json:"contents"`
+	Emails        EmailStats      `json:"emails"`
+	Last30Days    PeriodStats     `json:"last_30_days"`
+	GeneratedAt   time.Time       `json:"generated_at"`
+}
+
+type SubscriberStats struct {
+	Total    int64 `json:"total"`
+	Active   int64 `json:"active"`
+	Inactive int64 `json:"inactive"`
+}
+
+type ContentStats struct {
+	Total     int64 `json:"total"`
+	Published int64 `json:"published"`
+	Sent      int64 `json:"sent"` // Published content whose notifications went out
+	Drafts    int64 `json:"drafts"`
+}
+
+type EmailStats struct {
+	Total       int64   `json:"total"`
+	Sent        int64   `json:"sent"`
+	Failed      int64   `json:"failed"`
+	Pending     int64   `json:"pending"`
+	Opened      int64   `json:"opened"`
+	OpenRate    float64 `json:"open_rate"`    // Opened / Sent
+	FailureRate float64 `json:"failure_rate"` // Failed / Total
+}
+
+type PeriodStats struct {
+	Sends          int64 `json:"sends"`
+	Opens          int64 `json:"opens"`
+	NewSubscribers int64 `json:"new_subscriptions"`
+	Unsubscribes   int64 `json:"unsubscribes"`
+}
+
+// Domain report defaults and bounds
+const (
+	DefaultDomainReportDays  = 7
+	DefaultDomainReportLimit = 20
+	MaxDomainReportDays      = 90
+	MaxDomainReportLimit     = 100
+)
+
+// DomainReport lists delivery outcomes per recipient domain, most failures first
+type DomainReport struct {
+	Since   time.Time     `json:"since"`
+	Domains []DomainStats `json:"domains"`
+}
+
+// DomainStats counts the emails sent to one recipient domain
+type DomainStats struct {
+	Domain        string     `json:"domain"`
+	Total         int64      `json:"total"`
+	Sent          int64      `json:"sent"`
+	Failed        int64      `json:"failed"`
+	Pending       int64      `json:"pending"`
+	FailureRate   float64    `json:"failure_rate"` // Failed / Total
+	LastFailureAt *time.Time `json:"last_failure_at"`
+}
+
+// Timeseries is one metric bucketed over a UTC time range
+type Timeseries struct {
+	Metric   string    `json:"metric"`
+	Interval string    `json:"interval"`
+	From     time.Time `json:"from"`
+	To       time.Time `json:"to"`
+	Total    int64     `json:"total"`
+	Points   []Point   `json:"points"`
+}
+
+// SignupReport counts new subscribers per value of one attribution field over a UTC time range. Values
+// beyond the limit are left out of Values but counted in Total.
+type SignupReport struct {
+	By       string        `json:"by"`
+	Interval string        `json:"interval"`
+	From     time.Time     `json:"from"`
+	To       time.Time     `json:"to"`
+	Total    int64         `json:"total"`
+	Values   []SignupValue `json:"values"`
+}
+
+// SignupValue is the signups attributed to one value, such as a campaign, bucketed like a timeseries
+type SignupValue struct {
+	Value  string  `json:"value"`
+	Total  int64   `json:"total"`
+	Points []Point `json:"points"`
+}
+
+// SignupBucket is the signups attributed to one value in the bucket starting at Time
+type SignupBucket struct {
+	Value string
+	Time  time.Time
+	Count int64
+}
+
+// Point is the metric value for the bucket starting at Time
+type Point struct {
+	Time  time.Time `json:"time"`
+	Value int64     `json:"value"`
+}
@@ -0,0 +1,183 @@
+package stats
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"newsletter-service/internal/constants"
+	"newsletter-service/internal/tenant"
+)
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+// metricSource describes where a timeseries metric is counted from
+type metricSource struct {
+	table     string
+	column    string // Timestamp the metric is bucketed by
+	condition string
+}
+
+// metricSources maps metrics to fixed SQL fragments; only validated metric names are looked up
+var metricSources = map[string]metricSource{
+	MetricSends:    {table: "email_logs", column: "sent_at", condition: "status = '" + constants.StatusSent + "' AND deleted_at IS NULL"},
+	MetricFailures: {table: "email_logs", column: "created_at", condition: "status = '" + constants.StatusFailed + "' AND deleted_at IS NULL"},
+	MetricOpens:    {table: "email_logs", column: "opened_at", condition: "deleted_at IS NULL"},
+	// Unsubscribing soft-deletes the subscription, so both metrics include deleted rows
+	MetricSubscriptions: {table: "subscriptions", column: "created_at", condition: "TRUE"},
+	MetricUnsubscribes:  {table: "subscriptions", column: "deleted_at", condition: "TRUE"},
+	// Deleted subscribers still signed up
+	MetricSignups: {table: "subscribers", column: "created_at", condition: "TRUE"},
+}
+
+// signupColumns maps signups report breakdowns to fixed SQL expressions on subscribers
+var signupColumns = map[string]string{
+	SignupsBySource:      "signup_source",
+	SignupsByForm:        "signup_form",
+	SignupsByUTMSource:   "utm_source",
+	SignupsByUTMMedium:   "utm_medium",
+	SignupsByUTMCampaign: "utm_campaign",
+	SignupsByCountry:     "signup_country",
+	SignupsByReferrer:    "COALESCE(LOWER(SUBSTRING(signup_referrer FROM '^[A-Za-z][A-Za-z0-9+.-]*://([^/:?#]+)')), '')",
+	SignupsByAPIKey:      "COALESCE(signup_api_key_id::text, '')",
+}
+
+// organizationCondition returns a condition limiting a raw query to the organization ctx is scoped to, or one
+// matching every row for unscoped contexts. Raw SQL is not filtered by the tenant plugin.
+func organizationCondition(ctx context.Context) string {
+	if organizationID, ok := tenant.OrganizationID(ctx); ok {
+		return fmt.Sprintf("organization_id = %d", organizationID)
+	}
+	return "TRUE"
+}
+
+// GetOverview counts current totals and activity since the given time
+func (r *repository) GetOverview(ctx context.Context, since time.Time) (*Overview, error) {
+	db := r.db.WithContext(ctx)
+	org := organizationCondition(ctx)
+	overview := &Overview{}
+
+	if err := db.Raw(`SELECT COUNT(*) FROM topics WHERE deleted_at IS NULL AND ` + org).Scan(&overview.Topics).Error; err != nil {
+		return nil, err
+	}
+
+	err := db.Raw(`
+		SELECT COUNT(*) AS total,
+		       COUNT(*) FILTER (WHERE is_active) AS active,
+		       COUNT(*) FILTER (WHERE NOT is_active) AS inactive
+		FROM subscribers WHERE deleted_at IS NULL AND ` + org).Scan(&overview.Subscribers).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.Raw(`SELECT COUNT(*) FROM subscriptions WHERE deleted_at IS NULL AND ` + org).Scan(&overview.Subscriptions).Error; err != nil {
+		return nil, err
+	}
+
+	err = db.Raw(`
+		SELECT COUNT(*) AS total,
+		       COUNT(*) FILTER (WHERE is_published) AS published,
+		       COUNT(*) FILTER (WHERE is_published AND notifications_sent) AS sent,
+		       COUNT(*) FILTER (WHERE NOT is_published) AS drafts
+		FROM contents WHERE deleted_at IS NULL AND ` + org).Scan(&overview.Contents).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Raw(`
+		SELECT COUNT(*) AS total,
+		       COUNT(*) FILTER (WHERE status = ?) AS sent,
+		       COUNT(*) FILTER (WHERE status = ?) AS failed,
+		       COUNT(*) FILTER (WHERE status = ?) AS pending,
+		       COUNT(*) FILTER (WHERE opened_at IS NOT NULL) AS opened
+		FROM email_logs WHERE deleted_at IS NULL AND `+org,
+		constants.StatusSent, constants.StatusFailed, constants.StatusPending).Scan(&overview.Emails).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Raw(fmt.Sprintf(`
+		SELECT
+		  (SELECT COUNT(*) FROM email_logs WHERE deleted_at IS NULL AND status = ? AND sent_at >= ? AND %[1]s) AS sends,
+		  (SELECT COUNT(*) FROM email_logs WHERE deleted_at IS NULL AND opened_at >= ? AND %[1]s) AS opens,
+		  (SELECT COUNT(*) FROM subscriptions WHERE created_at >= ? AND %[1]s) AS new_subscribers,
+		  (SELECT COUNT(*) FROM subscriptions WHERE deleted_at >= ? AND %[1]s) AS unsubscribes`, org),
+		constants.StatusSent, since, since, since, since).Scan(&overview.Last30Days).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return overview, nil
+}
+
+// GetBuckets counts a metric per UTC interval in [from, to). Empty buckets are omitted.
+func (r *repository) GetBuckets(ctx context.Context, metric, interval string, from, to time.Time) ([]Point, error) {
+	source, ok := metricSources[metric]
+	if !ok {
+		return nil, fmt.Errorf("unknown metric %q", metric)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT date_trunc(?, %[2]s AT TIME ZONE 'UTC') AS time, COUNT(*) AS value
+		FROM %[1]s
+		WHERE %[2]s >= ? AND %[2]s < ? AND %[3]s AND %[4]s
+		GROUP BY 1 ORDER BY 1`, source.table, source.column, source.condition, organizationCondition(ctx))
+
+	var points []Point
+	if err := r.db.WithContext(ctx).Raw(query, interval, from, to).Scan(&points).Error; err != nil {
+		return nil, err
+	}
+	return points, nil
+}
+
+// GetSignupBuckets counts subscribers created in [from, to) per value of an attribution field and UTC
+// interval. Empty buckets are omitted.
+func (r *repository) GetSignupBuckets(ctx context.Context, by, interval string, from, to time.Time) ([]SignupBucket, error) {
+	column, ok := signupColumns[by]
+	if !ok {
+		return nil, fmt.Errorf("unknown signup breakdown %q", by)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %[1]s AS value, date_trunc(?, created_at AT TIME ZONE 'UTC') AS time, COUNT(*) AS count
+		FROM subscribers
+		WHERE created_at >= ? AND created_at < ? AND %[2]s
+		GROUP BY 1, 2 ORDER BY 2`, column, organizationCondition(ctx))
+
+	var buckets []SignupBucket
+	if err := r.db.WithContext(ctx).Raw(query, interval, from, to).Scan(&buckets).Error; err != nil {
+		return nil, err
+	}
+	return buckets, nil
+}
+
+// GetDomainStats counts email logs created since the given time per recipient domain, most failures first
+func (r *repository) GetDomainStats(ctx context.Context, since time.Time, limit int) ([]DomainStats, error) {
+	var domains []DomainStats
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT LOWER(SPLIT_PART(email_address, '@', 2)) AS domain,
+		       COUNT(*) AS total,
+		       COUNT(*) FILTER (WHERE status = ?) AS sent,
+		       COUNT(*) FILTER (WHERE status = ?) AS failed,
+		       COUNT(*) FILTER (WHERE status = ?) AS pending,
+		       MAX(updated_at) FILTER (WHERE status = ?) AS last_failure_at
+		FROM email_logs
+		WHERE deleted_at IS NULL AND created_at >= ? AND `+organizationCondition(ctx)+`
+		GROUP BY 1
+		ORDER BY failed DESC, total DESC, domain
+		LIMIT ?`,
+		constants.StatusSent, constants.StatusFailed, constants.StatusPending, constants.StatusFailed, since, limit).
+		Scan(&domains).Error
+	if err != nil {
+		return nil, err
+	}
+	return domains, nil
+}
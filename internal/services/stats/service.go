@@ -0,0 +1,228 @@
+package stats
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+)
+
+var (
+	ErrUnknownMetric   = errors.New("unknown metric")
+	ErrUnknownInterval = errors.New("unknown interval")
+	ErrUnknownSignupBy = errors.New("unknown signup breakdown")
+	ErrInvalidRange    = errors.New("invalid time range")
+)
+
+// overviewPeriod is the window used for the overview's recent-activity counts
+const overviewPeriod = 30 * 24 * time.Hour
+
+type service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) Service {
+	return &service{repo: repo}
+}
+
+func (s *service) GetOverview(ctx context.Context) (*Overview, error) {
+	now := time.Now().UTC()
+	overview, err := s.repo.GetOverview(ctx, now.Add(-overviewPeriod))
+	if err != nil {
+		return nil, err
+	}
+
+	if overview.Emails.Sent > 0 {
+		overview.Emails.OpenRate = float64(overview.Emails.Opened) / float64(overview.Emails.Sent)
+	}
+	if overview.Emails.Total > 0 {
+		overview.Emails.FailureRate = float64(overview.Emails.Failed) / float64(overview.Emails.Total)
+	}
+	overview.GeneratedAt = now
+	return overview, nil
+}
+
+// GetTimeseries buckets a metric by UTC day, ISO week or calendar month between from and to.
+// from is aligned down to the start of its bucket and every bucket up to to is returned, including empty ones.
+func (s *service) GetTimeseries(ctx context.Context, metric, interval string, from, to time.Time) (*Timeseries, error) {
+	if !contains(Metrics, metric) {
+		return nil, ErrUnknownMetric
+	}
+	if !contains(Intervals, interval) {
+		return nil, ErrUnknownInterval
+	}
+
+	from, to = truncate(from.UTC(), interval), to.UTC()
+	starts, err := bucketStarts(from, to, interval)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.repo.GetBuckets(ctx, metric, interval, from, to)
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[int64]int64, len(rows))
+	for _, row := range rows {
+		values[row.Time.Unix()] = row.Value
+	}
+
+	series := &Timeseries{
+		Metric:   metric,
+		Interval: interval,
+		From:     from,
+		To:       to,
+		Points:   make([]Point, 0, len(starts)),
+	}
+	for _, start := range starts {
+		value := values[start.Unix()]
+		series.Total += value
+		series.Points = append(series.Points, Point{Time: start, Value: value})
+	}
+	return series, nil
+}
+
+// GetSignupReport breaks the subscribers created between from and to down by one attribution field, bucketed
+// like GetTimeseries. The limit values with the most signups are listed, each with every bucket; limit falls
+// back to its default and is capped.
+func (s *service) GetSignupReport(ctx context.Context, by, interval string, from, to time.Time, limit int) (*SignupReport, error) {
+	if !contains(SignupDimensions, by) {
+		return nil, ErrUnknownSignupBy
+	}
+	if !contains(Intervals, interval) {
+		return nil, ErrUnknownInterval
+	}
+	if limit <= 0 {
+		limit = DefaultSignupValues
+	}
+	limit = min(limit, MaxSignupValues)
+
+	from, to = truncate(from.UTC(), interval), to.UTC()
+	starts, err := bucketStarts(from, to, interval)
+	if err != nil {
+		return nil, err
+	}
+
+	buckets, err := s.repo.GetSignupBuckets(ctx, by, interval, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &SignupReport{By: by, Interval: interval, From: from, To: to, Values: []SignupValue{}}
+	totals := make(map[string]int64)
+	counts := make(map[string]map[int64]int64)
+	for _, bucket := range buckets {
+		value := bucket.Value
+		if value == "" {
+			value = NoSignupValue
+		}
+		if counts[value] == nil {
+			counts[value] = make(map[int64]int64)
+		}
+		counts[value][bucket.Time.Unix()] += bucket.Count
+		totals[value] += bucket.Count
+		report.Total += bucket.Count
+	}
+
+	values := make([]string, 0, len(totals))
+	for value := range totals {
+		values = append(values, value)
+	}
+	sort.Slice(values, func(i, j int) bool {
+		if totals[values[i]] != totals[values[j]] {
+			return totals[values[i]] > totals[values[j]]
+		}
+		return values[i] < values[j]
+	})
+	if len(values) > limit {
+		values = values[:limit]
+	}
+
+	for _, value := range values {
+		series := SignupValue{Value: value, Total: totals[value], Points: make([]Point, 0, len(starts))}
+		for _, start := range starts {
+			series.Points = append(series.Points, Point{Time: start, Value: counts[value][start.Unix()]})
+		}
+		report.Values = append(report.Values, series)
+	}
+	return report, nil
+}
+
+// GetDomainReport reports delivery outcomes per recipient domain over the last days, so domains that
+// throttle or reject our mail stand out. days and limit fall back to their defaults and are capped.
+func (s *service) GetDomainReport(ctx context.Context, days, limit int) (*DomainReport, error) {
+	if days <= 0 {
+		days = DefaultDomainReportDays
+	}
+	if limit <= 0 {
+		limit = DefaultDomainReportLimit
+	}
+	days = min(days, MaxDomainReportDays)
+	limit = min(limit, MaxDomainReportLimit)
+
+	since := time.Now().UTC().AddDate(0, 0, -days)
+	domains, err := s.repo.GetDomainStats(ctx, since, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range domains {
+		if domains[i].Total > 0 {
+			domains[i].FailureRate = float64(domains[i].Failed) / float64(domains[i].Total)
+		}
+	}
+	if domains == nil {
+		domains = []DomainStats{}
+	}
+	return &DomainReport{Since: since, Domains: domains}, nil
+}
+
+// bucketStarts lists the start of every bucket from the aligned from up to to, failing for an empty range or
+// one with more than MaxBuckets buckets
+func bucketStarts(from, to time.Time, interval string) ([]time.Time, error) {
+	if !from.Before(to) {
+		return nil, ErrInvalidRange
+	}
+	var starts []time.Time
+	for t := from; t.Before(to); t = next(t, interval) {
+		if len(starts) == MaxBuckets {
+			return nil, ErrInvalidRange
+		}
+		starts = append(starts, t)
+	}
+	return starts, nil
+}
+
+// truncate returns the start of the bucket containing t (weeks start on Monday, matching Postgres date_trunc)
+func truncate(t time.Time, interval string) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	switch interval {
+	case IntervalWeek:
+		offset := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -offset)
+	case IntervalMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return day
+	}
+}
+
+func next(t time.Time, interval string) time.Time {
+	switch interval {
+	case IntervalWeek:
+		return t.AddDate(0, 0, 7)
+	case IntervalMonth:
+		return t.AddDate(0, 1, 0)
+	default:
+		return t.AddDate(0, 0, 1)
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
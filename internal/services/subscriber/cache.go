@@ -0,0 +1,105 @@
+package subscriber
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"newsletter-service/internal/tenant"
+)
+
+// Subscribers are looked up once per queued email, so a short TTL already saves most of those queries.
+// Writes through this service invalidate straight away; engagement sunsets, address checks and retention
+// purges write the table directly and show up once the entry expires.
+const subscriberCacheTTL = 2 * time.Minute
+
+// subscriptionCountCacheTTL bounds how stale a topic's subscription count can be
+const subscriptionCountCacheTTL = time.Minute
+
+// subscriptionCountGenerationKey holds the generation subscription counts are cached under. Many writes
+// (unsubscribing by subscription ID, merges, bulk creates) don't know which topics they change, so every
+// subscription write moves to a new generation instead of deleting counts one topic at a time.
+const subscriptionCountGenerationKey = "topic:subscriptions:generation"
+
+// getCachedSubscriber returns the cached subscriber, or nil when it isn't cached or belongs to another
+// organization than ctx is scoped to
+func (s *service) getCachedSubscriber(ctx context.Context, id uint) *Subscriber {
+	if s.cache == nil {
+		return nil
+	}
+	var subscriber Subscriber
+	found, err := s.cache.Get(ctx, subscriberKey(id), &subscriber)
+	if err != nil {
+		log.Printf("Warning: failed to read subscriber cache: %v", err)
+		return nil
+	}
+	if !found {
+		return nil
+	}
+	if organizationID, ok := tenant.OrganizationID(ctx); ok && subscriber.OrganizationID != organizationID {
+		return nil
+	}
+	return &subscriber
+}
+
+func (s *service) setCachedSubscriber(ctx context.Context, subscriber *Subscriber) {
+	if s.cache == nil {
+		return
+	}
+	if err := s.cache.Set(ctx, subscriberKey(subscriber.ID), subscriber, subscriberCacheTTL); err != nil {
+		log.Printf("Warning: failed to cache subscriber: %v", err)
+	}
+}
+
+func (s *service) invalidateSubscribers(ctx context.Context, ids ...uint) {
+	if s.cache == nil {
+		return
+	}
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = subscriberKey(id)
+	}
+	if err := s.cache.Delete(ctx, keys...); err != nil {
+		log.Printf("Warning: failed to invalidate subscriber cache: %v", err)
+	}
+}
+
+// subscriptionCountGeneration returns the current count generation, or "" when counts can't be cached
+func (s *service) subscriptionCountGeneration(ctx context.Context) string {
+	var generation string
+	found, err := s.cache.Get(ctx, subscriptionCountGenerationKey, &generation)
+	if err != nil {
+		log.Printf("Warning: failed to read subscription count cache: %v", err)
+		return ""
+	}
+	if !found {
+		// Start a generation so a later write has something to move on from
+		generation = strconv.FormatInt(time.Now().UnixNano(), 36)
+		if err := s.cache.Set(ctx, subscriptionCountGenerationKey, generation, 0); err != nil {
+			log.Printf("Warning: failed to cache subscription counts: %v", err)
+			return ""
+		}
+	}
+	return generation
+}
+
+// invalidateSubscriptionCounts drops every cached subscription count after subscriptions change
+func (s *service) invalidateSubscriptionCounts(ctx context.Context) {
+	if s.cache == nil {
+		return
+	}
+	generation := strconv.FormatInt(time.Now().UnixNano(), 36)
+	if err := s.cache.Set(ctx, subscriptionCountGenerationKey, generation, 0); err != nil {
+		log.Printf("Warning: failed to invalidate subscription count cache: %v", err)
+	}
+}
+
+func subscriberKey(id uint) string {
+	return fmt.Sprintf("subscriber:%d", id)
+}
+
+func subscriptionCountKey(generation string, topicID uint) string {
+	return fmt.Sprintf("topic:subscriptions:%s:%d", generation, topicID)
+}
@@ -9,42 +9,76 @@ type BulkSubscriberUpdate struct {
 	TopicNames []string               `json:"topic_names"`
 }
 
+// BulkCreateResult is the outcome of one row of a bulk create
+type BulkCreateResult struct {
+	Index      int         // Position of the row in the request
+	Subscriber *Subscriber // Created subscriber; only valid when Err is nil
+	TopicNames []string
+	Err        error
+}
+
 type Repository interface {
 	Create(ctx context.Context, subscriber *Subscriber) error
-	CreateWithTopics(ctx context.Context, subscriber *Subscriber, topicIDs []uint) error
+	CreateWithTopicNames(ctx context.Context, subscriber *Subscriber, topicNames []string, createMissing bool) ([]string, error)
+	BulkCreateWithTopicNames(ctx context.Context, subscribers []*Subscriber, topicNamesList [][]string, createMissing bool) ([][]string, []error, error)
 	GetByID(ctx context.Context, id uint) (*Subscriber, error)
 	GetByIDWithTopics(ctx context.Context, id uint) (*Subscriber, []string, error)
+	GetByIDs(ctx context.Context, ids []uint) ([]*Subscriber, error)
 	GetAll(ctx context.Context) ([]*Subscriber, error)
 	GetAllWithPagination(ctx context.Context, offset, limit int) ([]*Subscriber, int64, error)
+	GetAllIncludingDeletedWithPagination(ctx context.Context, offset, limit int) ([]*Subscriber, int64, error)
+	GetBySignupWithPagination(ctx context.Context, filter SignupFilter, includeDeleted bool, offset, limit int) ([]*Subscriber, int64, error)
 	Update(ctx context.Context, id uint, updates map[string]interface{}) error
+	ExistsByNormalizedEmail(ctx context.Context, normalizedEmail string, excludeID uint) (bool, error)
+	GetByEmails(ctx context.Context, emails, normalizedEmails []string) ([]*Subscriber, error)
+	GetDuplicateGroupsWithPagination(ctx context.Context, offset, limit int) ([]DuplicateGroup, int64, error)
+	Merge(ctx context.Context, sourceID, targetID uint) error
+	MigrateSubscriptions(ctx context.Context, sourceTopicID, targetTopicID uint, move bool) (*MigrationResult, error)
 	UpdateSubscribedTopics(ctx context.Context, subscriberID uint, topicIDs []uint) error
 	Delete(ctx context.Context, id uint) error
-	Subscribe(ctx context.Context, subscriberID, topicID uint) error
+	GetDeletedByID(ctx context.Context, id uint) (*Subscriber, error)
+	Restore(ctx context.Context, id uint) error
+	Subscribe(ctx context.Context, subscriberID, topicID uint) (bool, error)
 	Unsubscribe(ctx context.Context, subscriptionID uint) error
+	UpdateSubscription(ctx context.Context, subscriptionID uint, updates map[string]interface{}) (bool, error)
 	GetAllSubscriptions(ctx context.Context) ([]*Subscription, error)
 	GetAllSubscriptionsWithPagination(ctx context.Context, offset, limit int) ([]*Subscription, int64, error)
 	GetSubscriptionsBySubscriberID(ctx context.Context, subscriberID uint) ([]*Subscription, error)
 	GetSubscriptionsByTopicID(ctx context.Context, topicID uint) ([]*Subscription, error)
+	GetSubscriptionsBySubscriberIDs(ctx context.Context, subscriberIDs []uint) ([]*Subscription, error)
+	CountSubscriptionsByTopicIDs(ctx context.Context, topicIDs []uint) (map[uint]int64, error)
 	GetSubscribedTopicNames(ctx context.Context, subscriberID uint) ([]string, error)
+	GetSubscribedTopicNamesByIDs(ctx context.Context, subscriberIDs []uint) (map[uint][]string, error)
 }
 
 type Service interface {
 	CreateSubscriber(ctx context.Context, subscriber *Subscriber) error
-	CreateSubscriberWithTopics(ctx context.Context, subscriber *Subscriber, topicNames []string) error
-	BulkCreateSubscribers(ctx context.Context, subscribers []*Subscriber, topicNamesList [][]string) ([]uint, []error)
+	CreateSubscriberWithTopics(ctx context.Context, subscriber *Subscriber, topicNames []string) ([]string, error)
+	BulkCreateSubscribers(ctx context.Context, subscribers []*Subscriber, topicNamesList [][]string) ([]BulkCreateResult, error)
 	GetSubscriberByID(ctx context.Context, id uint) (*Subscriber, error)
 	GetSubscriberByIDWithTopics(ctx context.Context, id uint) (*Subscriber, []string, error)
+	GetSubscribersByIDs(ctx context.Context, ids []uint) ([]*Subscriber, error)
+	GetSubscribedTopicNamesByIDs(ctx context.Context, subscriberIDs []uint) (map[uint][]string, error)
 	GetAllSubscribers(ctx context.Context) ([]*Subscriber, error)
 	GetAllSubscribersWithPagination(ctx context.Context, offset, limit int) ([]*Subscriber, int64, error)
+	GetAllSubscribersIncludingDeletedWithPagination(ctx context.Context, offset, limit int) ([]*Subscriber, int64, error)
+	GetSubscribersBySignupWithPagination(ctx context.Context, filter SignupFilter, includeDeleted bool, offset, limit int) ([]*Subscriber, int64, error)
 	UpdateSubscriber(ctx context.Context, id uint, updates map[string]interface{}) error
 	UpdateSubscriberWithTopics(ctx context.Context, id uint, updates map[string]interface{}, topicNames []string) error
 	BulkUpdateSubscribers(ctx context.Context, updates []BulkSubscriberUpdate) []error
 	DeleteSubscriber(ctx context.Context, id uint) error
+	RestoreSubscriber(ctx context.Context, id uint) error
+	GetDuplicateGroupsWithPagination(ctx context.Context, offset, limit int) ([]DuplicateGroup, int64, error)
+	MergeSubscribers(ctx context.Context, sourceID, targetID uint) error
+	MigrateSubscribers(ctx context.Context, sourceTopicID, targetTopicID uint, move bool) (*MigrationResult, error)
 	BulkDeleteSubscribers(ctx context.Context, ids []uint) []error
-	Subscribe(ctx context.Context, subscriberID, topicID uint) error
+	Subscribe(ctx context.Context, subscriberID, topicID uint) (bool, error)
 	Unsubscribe(ctx context.Context, subscriptionID uint) error
+	UpdateSubscription(ctx context.Context, subscriptionID uint, updates map[string]interface{}) (bool, error)
 	GetAllSubscriptions(ctx context.Context) ([]*Subscription, error)
 	GetAllSubscriptionsWithPagination(ctx context.Context, offset, limit int) ([]*Subscription, int64, error)
 	GetSubscriptionsBySubscriberID(ctx context.Context, subscriberID uint) ([]*Subscription, error)
 	GetSubscriptionsByTopicID(ctx context.Context, topicID uint) ([]*Subscription, error)
+	GetSubscriptionsBySubscriberIDs(ctx context.Context, subscriberIDs []uint) ([]*Subscription, error)
+	CountSubscriptionsByTopicIDs(ctx context.Context, topicIDs []uint) (map[uint]int64, error)
 }
@@ -1,9 +1,55 @@
 package subscriber
 
 import (
+	"errors"
+	"strings"
+
 	"newsletter-service/internal/daos"
 )
 
 // Type aliases for backward compatibility
 type Subscriber = daos.Subscriber
 type Subscription = daos.Subscription
+type SignupFilter = daos.SignupFilter
+
+// ErrDuplicateEmail is returned when another subscriber already has the same normalized address
+var ErrDuplicateEmail = errors.New("a subscriber with this email address already exists")
+
+// ErrTopicsNotFound is returned when subscribed topics do not exist and may not be created
+var ErrTopicsNotFound = errors.New("some topics not found")
+
+// ErrTopicArchived is returned when subscribing to an archived topic
+var ErrTopicArchived = errors.New("topics are archived")
+
+// ErrSameTopic is returned when a topic's subscribers are migrated to the topic itself
+var ErrSameTopic = errors.New("subscribers can't be migrated to the same topic")
+
+// ErrSelfMerge is returned when a subscriber is merged into itself
+var ErrSelfMerge = errors.New("cannot merge a subscriber into itself")
+
+// MigrationResult counts the subscriptions a migration from one topic to another touched
+type MigrationResult struct {
+	Migrated          int64 `json:"migrated"`           // Subscriptions created on the target topic
+	AlreadySubscribed int64 `json:"already_subscribed"` // Subscribers already on the target topic
+	Removed           int64 `json:"removed"`            // Subscriptions ended on the source topic by a move
+}
+
+// DuplicateGroup is a set of subscribers sharing a normalized address
+type DuplicateGroup struct {
+	NormalizedEmail string
+	Subscribers     []*Subscriber
+}
+
+// NormalizeEmail lowercases an address and strips plus-addressing ("jane+news@x.com" -> "jane@x.com")
+func NormalizeEmail(email string) string {
+	email = strings.ToLower(strings.TrimSpace(email))
+	at := strings.LastIndexByte(email, '@')
+	if at < 0 {
+		return email
+	}
+	local, domain := email[:at], email[at:]
+	if plus := strings.IndexByte(local, '+'); plus > 0 {
+		local = local[:plus]
+	}
+	return local + domain
+}
@@ -2,8 +2,15 @@ package subscriber
 
 import (
 	"context"
+	"fmt"
+	"math"
+	"strings"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"newsletter-service/internal/constants"
+	"newsletter-service/internal/daos"
 )
 
 type repository struct {
@@ -27,6 +34,12 @@ func (r *repository) GetByID(ctx context.Context, id uint) (*Subscriber, error)
 	return &subscriber, nil
 }
 
+func (r *repository) GetByIDs(ctx context.Context, ids []uint) ([]*Subscriber, error) {
+	var subscribers []*Subscriber
+	err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&subscribers).Error
+	return subscribers, err
+}
+
 func (r *repository) GetAll(ctx context.Context) ([]*Subscriber, error) {
 	var subscribers []*Subscriber
 	err := r.db.WithContext(ctx).Order("created_at desc").Find(&subscribers).Error
@@ -47,6 +60,39 @@ func (r *repository) GetAllWithPagination(ctx context.Context, offset, limit int
 	return subscribers, total, err
 }
 
+// GetAllIncludingDeletedWithPagination lists soft-deleted subscribers alongside live ones
+func (r *repository) GetAllIncludingDeletedWithPagination(ctx context.Context, offset, limit int) ([]*Subscriber, int64, error) {
+	var subscribers []*Subscriber
+	var total int64
+
+	// Get total count
+	if err := r.db.WithContext(ctx).Unscoped().Model(&Subscriber{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Get paginated results
+	err := r.db.WithContext(ctx).Unscoped().Order("created_at desc").Offset(offset).Limit(limit).Find(&subscribers).Error
+	return subscribers, total, err
+}
+
+// GetBySignupWithPagination lists subscribers matching a signup filter, newest first
+func (r *repository) GetBySignupWithPagination(ctx context.Context, filter SignupFilter, includeDeleted bool, offset, limit int) ([]*Subscriber, int64, error) {
+	query := r.db.WithContext(ctx).Model(&Subscriber{})
+	if includeDeleted {
+		query = query.Unscoped()
+	}
+	query = filter.Apply(query)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var subscribers []*Subscriber
+	err := query.Order("created_at desc").Offset(offset).Limit(limit).Find(&subscribers).Error
+	return subscribers, total, err
+}
+
 func (r *repository) Update(ctx context.Context, id uint, updates map[string]interface{}) error {
 	return r.db.WithContext(ctx).Model(&Subscriber{}).Where("id = ?", id).Updates(updates).Error
 }
@@ -55,18 +101,55 @@ func (r *repository) Delete(ctx context.Context, id uint) error {
 	return r.db.WithContext(ctx).Delete(&Subscriber{}, id).Error
 }
 
-func (r *repository) Subscribe(ctx context.Context, subscriberID, topicID uint) error {
+// GetDeletedByID returns a soft-deleted subscriber
+func (r *repository) GetDeletedByID(ctx context.Context, id uint) (*Subscriber, error) {
+	var subscriber Subscriber
+	err := r.db.WithContext(ctx).Unscoped().Where("deleted_at IS NOT NULL").First(&subscriber, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &subscriber, nil
+}
+
+// Restore undoes a soft delete. It returns gorm.ErrRecordNotFound when no deleted subscriber has the ID.
+func (r *repository) Restore(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).Unscoped().Model(&Subscriber{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// Subscribe subscribes a subscriber to a topic and reports whether it created the subscription; an existing
+// live one is left as it is
+func (r *repository) Subscribe(ctx context.Context, subscriberID, topicID uint) (bool, error) {
 	subscription := &Subscription{
 		SubscriberID: subscriberID,
 		TopicID:      topicID,
 	}
-	return r.db.WithContext(ctx).Create(subscription).Error
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:     []clause.Column{{Name: "subscriber_id"}, {Name: "topic_id"}},
+		TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "deleted_at IS NULL"}}},
+		DoNothing:   true,
+	}).Create(subscription)
+	return result.RowsAffected > 0, result.Error
 }
 
 func (r *repository) Unsubscribe(ctx context.Context, subscriptionID uint) error {
 	return r.db.WithContext(ctx).Delete(&Subscription{}, subscriptionID).Error
 }
 
+// UpdateSubscription updates a subscription and reports whether it exists
+func (r *repository) UpdateSubscription(ctx context.Context, subscriptionID uint, updates map[string]interface{}) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&Subscription{}).Where("id = ?", subscriptionID).Updates(updates)
+	return result.RowsAffected > 0, result.Error
+}
+
 func (r *repository) GetAllSubscriptions(ctx context.Context) ([]*Subscription, error) {
 	var subscriptions []*Subscription
 	err := r.db.WithContext(ctx).Order("created_at desc").Find(&subscriptions).Error
@@ -99,26 +182,251 @@ func (r *repository) GetSubscriptionsByTopicID(ctx context.Context, topicID uint
 	return subscriptions, err
 }
 
-func (r *repository) CreateWithTopics(ctx context.Context, subscriber *Subscriber, topicIDs []uint) error {
-	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		// Create subscriber
+func (r *repository) GetSubscriptionsBySubscriberIDs(ctx context.Context, subscriberIDs []uint) ([]*Subscription, error) {
+	var subscriptions []*Subscription
+	err := r.db.WithContext(ctx).Where("subscriber_id IN ?", subscriberIDs).Order("created_at desc").Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+func (r *repository) CountSubscriptionsByTopicIDs(ctx context.Context, topicIDs []uint) (map[uint]int64, error) {
+	var rows []struct {
+		TopicID uint
+		Count   int64
+	}
+	err := r.db.WithContext(ctx).
+		Model(&Subscription{}).
+		Select("topic_id, COUNT(*) AS count").
+		Where("topic_id IN ?", topicIDs).
+		Group("topic_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[uint]int64, len(rows))
+	for _, row := range rows {
+		counts[row.TopicID] = row.Count
+	}
+	return counts, nil
+}
+
+// bulkInsertBatchSize is the number of rows per INSERT statement in bulk creates
+const bulkInsertBatchSize = 100
+
+// CreateWithTopicNames creates the subscriber and its subscriptions in one transaction and returns the names of
+// the topics it is subscribed to. Unknown topics are created when createMissing is set; otherwise nothing is
+// written and ErrTopicsNotFound is returned.
+func (r *repository) CreateWithTopicNames(ctx context.Context, subscriber *Subscriber, topicNames []string, createMissing bool) ([]string, error) {
+	var subscribed []string
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		names := uniqueNames(topicNames)
+		topics, err := resolveTopics(tx, names, createMissing)
+		if err != nil {
+			return err
+		}
+		topicIDs, err := topicIDsFor(names, topics)
+		if err != nil {
+			return err
+		}
+		archived, err := archivedTopicNames(tx, names)
+		if err != nil {
+			return err
+		}
+		if err := checkNotArchived(names, archived); err != nil {
+			return err
+		}
+
 		if err := tx.Create(subscriber).Error; err != nil {
 			return err
 		}
 
-		// Create subscriptions
-		for _, topicID := range topicIDs {
-			subscription := &Subscription{
-				SubscriberID: subscriber.ID,
-				TopicID:      topicID,
+		if len(topicIDs) > 0 {
+			subscriptions := make([]*Subscription, len(topicIDs))
+			for i, topicID := range topicIDs {
+				subscriptions[i] = &Subscription{SubscriberID: subscriber.ID, TopicID: topicID}
 			}
-			if err := tx.Create(subscription).Error; err != nil {
+			if err := tx.Create(&subscriptions).Error; err != nil {
 				return err
 			}
 		}
 
-		return nil
+		subscribed, err = subscribedTopicNames(tx, subscriber.ID)
+		return err
 	})
+	if err != nil {
+		return nil, err
+	}
+	return subscribed, nil
+}
+
+// BulkCreateWithTopicNames creates subscribers and their subscriptions in one transaction with batch inserts.
+// Rows naming topics that do not exist (and may not be created) are skipped; their error is returned at the
+// row's index in rowErrs. subscribed holds the topic names of each created row. A non-nil err means the
+// transaction was rolled back and nothing was created.
+func (r *repository) BulkCreateWithTopicNames(ctx context.Context, subscribers []*Subscriber, topicNamesList [][]string, createMissing bool) (subscribed [][]string, rowErrs []error, err error) {
+	subscribed = make([][]string, len(subscribers))
+	rowErrs = make([]error, len(subscribers))
+
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var allNames []string
+		for _, names := range topicNamesList {
+			allNames = append(allNames, names...)
+		}
+		topics, err := resolveTopics(tx, uniqueNames(allNames), createMissing)
+		if err != nil {
+			return err
+		}
+		archived, err := archivedTopicNames(tx, uniqueNames(allNames))
+		if err != nil {
+			return err
+		}
+
+		var creatable []*Subscriber
+		rowTopicIDs := make([][]uint, len(subscribers))
+		for i, subscriber := range subscribers {
+			names := uniqueNames(topicNamesList[i])
+			topicIDs, err := topicIDsFor(names, topics)
+			if err == nil {
+				err = checkNotArchived(names, archived)
+			}
+			if err != nil {
+				rowErrs[i] = err
+				continue
+			}
+			rowTopicIDs[i] = topicIDs
+			subscribed[i] = names
+			creatable = append(creatable, subscriber)
+		}
+		if len(creatable) == 0 {
+			return nil
+		}
+
+		if err := tx.CreateInBatches(creatable, bulkInsertBatchSize).Error; err != nil {
+			return err
+		}
+
+		var subscriptions []*Subscription
+		for i, subscriber := range subscribers {
+			for _, topicID := range rowTopicIDs[i] {
+				subscriptions = append(subscriptions, &Subscription{SubscriberID: subscriber.ID, TopicID: topicID})
+			}
+		}
+		if len(subscriptions) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(subscriptions, bulkInsertBatchSize).Error
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return subscribed, rowErrs, nil
+}
+
+// resolveTopics maps topic names to IDs, creating unknown topics when createMissing is set.
+// Names without a topic are absent from the result.
+func resolveTopics(tx *gorm.DB, names []string, createMissing bool) (map[string]uint, error) {
+	topicIDs := make(map[string]uint, len(names))
+	if len(names) == 0 {
+		return topicIDs, nil
+	}
+
+	var topics []*daos.Topic
+	if err := tx.Where("name IN ?", names).Find(&topics).Error; err != nil {
+		return nil, err
+	}
+	for _, t := range topics {
+		topicIDs[t.Name] = t.ID
+	}
+
+	missing := missingTopicNames(names, topicIDs)
+	if len(missing) == 0 || !createMissing {
+		return topicIDs, nil
+	}
+
+	created := make([]*daos.Topic, len(missing))
+	for i, name := range missing {
+		created[i] = &daos.Topic{Name: name}
+	}
+	// A concurrent request may create the same topic; keep whichever insert won
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&created).Error; err != nil {
+		return nil, err
+	}
+
+	topics = nil
+	if err := tx.Where("name IN ?", missing).Find(&topics).Error; err != nil {
+		return nil, err
+	}
+	for _, t := range topics {
+		topicIDs[t.Name] = t.ID
+	}
+	return topicIDs, nil
+}
+
+// topicIDsFor returns the IDs of the named topics, or ErrTopicsNotFound listing the names without a topic
+func topicIDsFor(names []string, topicIDs map[string]uint) ([]uint, error) {
+	if missing := missingTopicNames(names, topicIDs); len(missing) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrTopicsNotFound, strings.Join(missing, ", "))
+	}
+
+	ids := make([]uint, len(names))
+	for i, name := range names {
+		ids[i] = topicIDs[name]
+	}
+	return ids, nil
+}
+
+// archivedTopicNames returns which of the named topics are archived
+func archivedTopicNames(tx *gorm.DB, names []string) (map[string]bool, error) {
+	archived := make(map[string]bool)
+	if len(names) == 0 {
+		return archived, nil
+	}
+	var archivedNames []string
+	if err := tx.Model(&daos.Topic{}).Where("name IN ? AND archived_at IS NOT NULL", names).Pluck("name", &archivedNames).Error; err != nil {
+		return nil, err
+	}
+	for _, name := range archivedNames {
+		archived[name] = true
+	}
+	return archived, nil
+}
+
+// checkNotArchived returns ErrTopicArchived listing the archived topics among names
+func checkNotArchived(names []string, archived map[string]bool) error {
+	var archivedNames []string
+	for _, name := range names {
+		if archived[name] {
+			archivedNames = append(archivedNames, name)
+		}
+	}
+	if len(archivedNames) > 0 {
+		return fmt.Errorf("%w: %s", ErrTopicArchived, strings.Join(archivedNames, ", "))
+	}
+	return nil
+}
+
+func missingTopicNames(names []string, topicIDs map[string]uint) []string {
+	var missing []string
+	for _, name := range names {
+		if _, ok := topicIDs[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
+
+// uniqueNames drops blank and repeated topic names, keeping the first occurrence
+func uniqueNames(names []string) []string {
+	seen := make(map[string]bool, len(names))
+	unique := make([]string, 0, len(names))
+	for _, name := range names {
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		unique = append(unique, name)
+	}
+	return unique
 }
 
 func (r *repository) GetByIDWithTopics(ctx context.Context, id uint) (*Subscriber, []string, error) {
@@ -136,6 +444,65 @@ func (r *repository) GetByIDWithTopics(ctx context.Context, id uint) (*Subscribe
 	return &subscriber, topicNames, nil
 }
 
+// MigrateSubscriptions subscribes the source topic's subscribers to the target topic in one transaction,
+// skipping those already on it. A move also ends their source subscriptions, which keeps the source's
+// history: the subscriptions are soft-deleted, as unsubscribing does. Deleted subscribers are left behind.
+func (r *repository) MigrateSubscriptions(ctx context.Context, sourceTopicID, targetTopicID uint, move bool) (*MigrationResult, error) {
+	result := &MigrationResult{}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Migrations of the same topics wait for each other rather than both copying the same subscribers
+		var topics []daos.Topic
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id IN ?", []uint{sourceTopicID, targetTopicID}).
+			Order("id").Find(&topics).Error; err != nil {
+			return err
+		}
+
+		source := tx.Model(&Subscription{}).
+			Where("topic_id = ?", sourceTopicID).
+			Where("subscriber_id IN (?)", tx.Model(&Subscriber{}).Select("id"))
+		onTarget := tx.Model(&Subscription{}).Select("subscriber_id").Where("topic_id = ?", targetTopicID)
+
+		if err := source.Session(&gorm.Session{}).Where("subscriber_id IN (?)", onTarget).
+			Count(&result.AlreadySubscribed).Error; err != nil {
+			return err
+		}
+
+		var migrating []*Subscription
+		if err := source.Session(&gorm.Session{}).Where("subscriber_id NOT IN (?)", onTarget).
+			Find(&migrating).Error; err != nil {
+			return err
+		}
+		if len(migrating) > 0 {
+			created := make([]*Subscription, len(migrating))
+			for i, subscription := range migrating {
+				created[i] = &Subscription{
+					SubscriberID:   subscription.SubscriberID,
+					TopicID:        targetTopicID,
+					OrganizationID: subscription.OrganizationID,
+					Push:           subscription.Push,
+				}
+			}
+			if err := tx.CreateInBatches(created, bulkInsertBatchSize).Error; err != nil {
+				return err
+			}
+			result.Migrated = int64(len(created))
+		}
+
+		if !move {
+			return nil
+		}
+		removed := tx.Where("topic_id = ?", sourceTopicID).
+			Where("subscriber_id IN (?)", tx.Model(&Subscriber{}).Select("id")).
+			Delete(&Subscription{})
+		result.Removed = removed.RowsAffected
+		return removed.Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (r *repository) UpdateSubscribedTopics(ctx context.Context, subscriberID uint, topicIDs []uint) error {
 	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		// Delete existing subscriptions
@@ -159,8 +526,41 @@ func (r *repository) UpdateSubscribedTopics(ctx context.Context, subscriberID ui
 }
 
 func (r *repository) GetSubscribedTopicNames(ctx context.Context, subscriberID uint) ([]string, error) {
-	var topicNames []string
+	return subscribedTopicNames(r.db.WithContext(ctx), subscriberID)
+}
+
+// GetSubscribedTopicNamesByIDs returns the topic names of several subscribers, keyed by subscriber, in one query.
+// Subscribers without live subscriptions are left out of the map.
+func (r *repository) GetSubscribedTopicNamesByIDs(ctx context.Context, subscriberIDs []uint) (map[uint][]string, error) {
+	topicNames := make(map[uint][]string)
+	if len(subscriberIDs) == 0 {
+		return topicNames, nil
+	}
+
+	var rows []struct {
+		SubscriberID uint
+		Name         string
+	}
 	err := r.db.WithContext(ctx).
+		Table("subscriptions").
+		Select("subscriptions.subscriber_id, topics.name").
+		Joins("JOIN topics ON topics.id = subscriptions.topic_id").
+		Where("subscriptions.subscriber_id IN ? AND subscriptions.deleted_at IS NULL AND topics.deleted_at IS NULL", subscriberIDs).
+		Order("subscriptions.id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		topicNames[row.SubscriberID] = append(topicNames[row.SubscriberID], row.Name)
+	}
+	return topicNames, nil
+}
+
+func subscribedTopicNames(db *gorm.DB, subscriberID uint) ([]string, error) {
+	var topicNames []string
+	err := db.
 		Table("subscriptions").
 		Select("topics.name").
 		Joins("JOIN topics ON topics.id = subscriptions.topic_id").
@@ -168,3 +568,121 @@ func (r *repository) GetSubscribedTopicNames(ctx context.Context, subscriberID u
 		Pluck("topics.name", &topicNames).Error
 	return topicNames, err
 }
+
+// GetByEmails returns subscribers, including soft-deleted ones, whose address or normalized address is in the lists
+func (r *repository) GetByEmails(ctx context.Context, emails, normalizedEmails []string) ([]*Subscriber, error) {
+	var subscribers []*Subscriber
+	if len(emails) == 0 && len(normalizedEmails) == 0 {
+		return subscribers, nil
+	}
+
+	err := r.db.WithContext(ctx).Unscoped().
+		Select("id", "email", "normalized_email", "deleted_at").
+		Where("email IN ? OR normalized_email IN ?", emails, normalizedEmails).
+		Find(&subscribers).Error
+	return subscribers, err
+}
+
+func (r *repository) ExistsByNormalizedEmail(ctx context.Context, normalizedEmail string, excludeID uint) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&Subscriber{}).
+		Where("normalized_email = ? AND id <> ?", normalizedEmail, excludeID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// GetDuplicateGroupsWithPagination pages through normalized addresses shared by more than one subscriber
+func (r *repository) GetDuplicateGroupsWithPagination(ctx context.Context, offset, limit int) ([]DuplicateGroup, int64, error) {
+	duplicates := func() *gorm.DB {
+		return r.db.WithContext(ctx).Model(&Subscriber{}).
+			Select("normalized_email").
+			Where("normalized_email <> ''").
+			Group("normalized_email").
+			Having("COUNT(*) > 1")
+	}
+
+	var total int64
+	if err := r.db.WithContext(ctx).Table("(?) AS duplicates", duplicates()).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var keys []string
+	if err := duplicates().Order("normalized_email").Offset(offset).Limit(limit).Pluck("normalized_email", &keys).Error; err != nil {
+		return nil, 0, err
+	}
+	if len(keys) == 0 {
+		return []DuplicateGroup{}, total, nil
+	}
+
+	var subscribers []*Subscriber
+	err := r.db.WithContext(ctx).Where("normalized_email IN ?", keys).Order("normalized_email, created_at").Find(&subscribers).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	groups := make([]DuplicateGroup, 0, len(keys))
+	index := make(map[string]int, len(keys))
+	for _, key := range keys {
+		index[key] = len(groups)
+		groups = append(groups, DuplicateGroup{NormalizedEmail: key})
+	}
+	for _, subscriber := range subscribers {
+		i := index[subscriber.NormalizedEmail]
+		groups[i].Subscribers = append(groups[i].Subscribers, subscriber)
+	}
+	return groups, total, nil
+}
+
+// Merge moves the source subscriber's subscriptions and email logs to the target, combines their
+// attributes and soft-deletes the source, all in one transaction
+func (r *repository) Merge(ctx context.Context, sourceID, targetID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var source, target Subscriber
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&source, sourceID).Error; err != nil {
+			return err
+		}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&target, targetID).Error; err != nil {
+			return err
+		}
+
+		// Move subscriptions to topics the target is not subscribed to, then drop the overlap
+		err := tx.Model(&Subscription{}).
+			Where("subscriber_id = ? AND topic_id NOT IN (?)", sourceID,
+				tx.Model(&Subscription{}).Select("topic_id").Where("subscriber_id = ?", targetID)).
+			Update("subscriber_id", targetID).Error
+		if err != nil {
+			return err
+		}
+		if err := tx.Where("subscriber_id = ?", sourceID).Delete(&Subscription{}).Error; err != nil {
+			return err
+		}
+
+		// Keep the full delivery history, including logs that were already soft-deleted
+		if err := tx.Unscoped().Model(&daos.EmailLog{}).Where("subscriber_id = ?", sourceID).Update("subscriber_id", targetID).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Model(&daos.EmailLog{}).Table(constants.TableNameEmailLogsArchive).
+			Where("subscriber_id = ?", sourceID).Update("subscriber_id", targetID).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&daos.PushDevice{}).Where("subscriber_id = ?", sourceID).Update("subscriber_id", targetID).Error; err != nil {
+			return err
+		}
+
+		updates := map[string]interface{}{
+			"is_active":        target.IsActive || source.IsActive,
+			"engagement_score": math.Max(target.EngagementScore, source.EngagementScore),
+		}
+		if target.Name == "" {
+			updates["name"] = source.Name
+		}
+		if source.LastEngagedAt != nil && (target.LastEngagedAt == nil || source.LastEngagedAt.After(*target.LastEngagedAt)) {
+			updates["last_engaged_at"] = source.LastEngagedAt
+		}
+		if err := tx.Model(&target).Updates(updates).Error; err != nil {
+			return err
+		}
+
+		return tx.Delete(&source).Error
+	})
+}
@@ -2,14 +2,26 @@ package subscriber
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
+	"slices"
+	"strings"
 
+	"gorm.io/gorm"
+
+	"newsletter-service/internal/cache"
+	"newsletter-service/internal/config"
+	"newsletter-service/internal/services/emailcheck"
 	"newsletter-service/internal/services/topic"
 )
 
 type service struct {
 	repo         Repository
 	topicService topic.Service
+	emailCheck   emailcheck.Service
+	cfg          config.SubscribersConfig
+	cache        cache.Cache
 }
 
 func NewService(repo Repository) Service {
@@ -23,12 +35,119 @@ func NewServiceWithTopic(repo Repository, topicService topic.Service) Service {
 	}
 }
 
+// NewServiceWithEmailCheck creates a subscriber service that validates addresses before creating subscribers
+func NewServiceWithEmailCheck(repo Repository, topicService topic.Service, emailCheck emailcheck.Service) Service {
+	return &service{
+		repo:         repo,
+		topicService: topicService,
+		emailCheck:   emailCheck,
+	}
+}
+
+// NewServiceWithConfig creates a subscriber service with address validation and the [subscribers] settings
+func NewServiceWithConfig(repo Repository, topicService topic.Service, emailCheck emailcheck.Service, cfg config.SubscribersConfig) Service {
+	return &service{
+		repo:         repo,
+		topicService: topicService,
+		emailCheck:   emailCheck,
+		cfg:          cfg,
+	}
+}
+
+// NewServiceWithCache creates a subscriber service that caches subscribers by ID and topic subscription counts.
+// emailCheck may be nil where subscribers are not created, as in the worker.
+func NewServiceWithCache(repo Repository, topicService topic.Service, emailCheck emailcheck.Service, cfg config.SubscribersConfig, c cache.Cache) Service {
+	return &service{
+		repo:         repo,
+		topicService: topicService,
+		emailCheck:   emailCheck,
+		cfg:          cfg,
+		cache:        c,
+	}
+}
+
 func (s *service) CreateSubscriber(ctx context.Context, subscriber *Subscriber) error {
-	return s.repo.Create(ctx, subscriber)
+	if err := s.checkEmail(ctx, subscriber); err != nil {
+		return err
+	}
+	return duplicateEmail(s.repo.Create(ctx, subscriber))
+}
+
+// checkEmail normalizes the subscriber's address, records its deliverability status and
+// rejects addresses that duplicate an existing subscriber
+func (s *service) checkEmail(ctx context.Context, subscriber *Subscriber) error {
+	if err := s.validateEmail(ctx, subscriber); err != nil {
+		return err
+	}
+	return s.ensureUniqueEmail(ctx, subscriber.NormalizedEmail, subscriber.ID)
+}
+
+// validateEmail runs the address checks and sets the normalized address without touching the database
+func (s *service) validateEmail(ctx context.Context, subscriber *Subscriber) error {
+	if s.emailCheck != nil {
+		result, err := s.emailCheck.Check(ctx, subscriber.Email)
+		if err != nil {
+			return err
+		}
+		subscriber.Email = result.Email
+		subscriber.EmailStatus = result.Status
+		subscriber.EmailCheckedAt = result.CheckedAt
+	}
+
+	subscriber.NormalizedEmail = NormalizeEmail(subscriber.Email)
+	return nil
+}
+
+// prepareEmailUpdate adds the normalized address to updates that change the email
+func (s *service) prepareEmailUpdate(ctx context.Context, id uint, updates map[string]interface{}) error {
+	email, ok := updates["email"].(string)
+	if !ok {
+		return nil
+	}
+
+	normalized := NormalizeEmail(email)
+	if err := s.ensureUniqueEmail(ctx, normalized, id); err != nil {
+		return err
+	}
+	updates["normalized_email"] = normalized
+	return nil
+}
+
+func (s *service) ensureUniqueEmail(ctx context.Context, normalizedEmail string, excludeID uint) error {
+	exists, err := s.repo.ExistsByNormalizedEmail(ctx, normalizedEmail, excludeID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return ErrDuplicateEmail
+	}
+	return nil
+}
+
+// duplicateEmail turns a unique violation on the address into ErrDuplicateEmail. ensureUniqueEmail can't see
+// a subscriber created concurrently or a soft-deleted one holding the exact address; the index catches both.
+func duplicateEmail(err error) error {
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return fmt.Errorf("%w: %w", ErrDuplicateEmail, err)
+	}
+	return err
 }
 
 func (s *service) GetSubscriberByID(ctx context.Context, id uint) (*Subscriber, error) {
-	return s.repo.GetByID(ctx, id)
+	if subscriber := s.getCachedSubscriber(ctx, id); subscriber != nil {
+		return subscriber, nil
+	}
+
+	subscriber, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	s.setCachedSubscriber(ctx, subscriber)
+	return subscriber, nil
+}
+
+func (s *service) GetSubscribersByIDs(ctx context.Context, ids []uint) ([]*Subscriber, error) {
+	return s.repo.GetByIDs(ctx, ids)
 }
 
 func (s *service) GetAllSubscribers(ctx context.Context) ([]*Subscriber, error) {
@@ -39,20 +158,158 @@ func (s *service) GetAllSubscribersWithPagination(ctx context.Context, offset, l
 	return s.repo.GetAllWithPagination(ctx, offset, limit)
 }
 
+func (s *service) GetAllSubscribersIncludingDeletedWithPagination(ctx context.Context, offset, limit int) ([]*Subscriber, int64, error) {
+	return s.repo.GetAllIncludingDeletedWithPagination(ctx, offset, limit)
+}
+
+// GetSubscribersBySignupWithPagination lists the subscribers whose signup attribution matches filter
+func (s *service) GetSubscribersBySignupWithPagination(ctx context.Context, filter SignupFilter, includeDeleted bool, offset, limit int) ([]*Subscriber, int64, error) {
+	return s.repo.GetBySignupWithPagination(ctx, filter, includeDeleted, offset, limit)
+}
+
 func (s *service) UpdateSubscriber(ctx context.Context, id uint, updates map[string]interface{}) error {
-	return s.repo.Update(ctx, id, updates)
+	if err := s.prepareEmailUpdate(ctx, id, updates); err != nil {
+		return err
+	}
+	if err := s.repo.Update(ctx, id, updates); err != nil {
+		return duplicateEmail(err)
+	}
+	s.invalidateSubscribers(ctx, id)
+	return nil
 }
 
 func (s *service) DeleteSubscriber(ctx context.Context, id uint) error {
-	return s.repo.Delete(ctx, id)
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+	s.invalidateSubscribers(ctx, id)
+	return nil
+}
+
+// RestoreSubscriber undoes a soft delete unless another live subscriber now has the same normalized address
+func (s *service) RestoreSubscriber(ctx context.Context, id uint) error {
+	subscriber, err := s.repo.GetDeletedByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if subscriber.NormalizedEmail != "" {
+		if err := s.ensureUniqueEmail(ctx, subscriber.NormalizedEmail, id); err != nil {
+			return err
+		}
+	}
+	return s.repo.Restore(ctx, id)
+}
+
+func (s *service) GetDuplicateGroupsWithPagination(ctx context.Context, offset, limit int) ([]DuplicateGroup, int64, error) {
+	return s.repo.GetDuplicateGroupsWithPagination(ctx, offset, limit)
+}
+
+// MergeSubscribers folds the source subscriber into the target and soft-deletes the source
+func (s *service) MergeSubscribers(ctx context.Context, sourceID, targetID uint) error {
+	if sourceID == targetID {
+		return ErrSelfMerge
+	}
+	if err := s.repo.Merge(ctx, sourceID, targetID); err != nil {
+		return err
+	}
+	s.invalidateSubscribers(ctx, sourceID, targetID)
+	s.invalidateSubscriptionCounts(ctx)
+	return nil
+}
+
+// Subscribe subscribes a subscriber to a topic. It is idempotent: created is false when the subscriber was
+// already subscribed, which is not an error.
+func (s *service) Subscribe(ctx context.Context, subscriberID, topicID uint) (bool, error) {
+	// Both lookups are limited to ctx's organization, so neither side can belong to another one
+	if _, err := s.repo.GetByID(ctx, subscriberID); err != nil {
+		return false, err
+	}
+	if s.topicService != nil {
+		topic, err := s.topicService.GetTopicByID(ctx, topicID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, fmt.Errorf("%w: topic %d", ErrTopicsNotFound, topicID)
+		} else if err != nil {
+			return false, err
+		}
+		if topic.ArchivedAt != nil {
+			return false, fmt.Errorf("%w: %s", ErrTopicArchived, topic.Name)
+		}
+	}
+	created, err := s.repo.Subscribe(ctx, subscriberID, topicID)
+	if err != nil {
+		return false, err
+	}
+	if created {
+		s.invalidateSubscriptionCounts(ctx)
+	}
+	return created, nil
 }
 
-func (s *service) Subscribe(ctx context.Context, subscriberID, topicID uint) error {
-	return s.repo.Subscribe(ctx, subscriberID, topicID)
+// MigrateSubscribers subscribes a topic's subscribers to another topic, which must not be archived. With move
+// they are also unsubscribed from the source topic, usually one being archived. It all happens in one
+// transaction, so a failed migration changes nothing.
+func (s *service) MigrateSubscribers(ctx context.Context, sourceTopicID, targetTopicID uint, move bool) (*MigrationResult, error) {
+	if s.topicService == nil {
+		return nil, fmt.Errorf("topic service not available - use NewServiceWithTopic")
+	}
+	if sourceTopicID == targetTopicID {
+		return nil, ErrSameTopic
+	}
+	if _, err := s.topicService.GetTopicByID(ctx, sourceTopicID); err != nil {
+		return nil, err
+	}
+	target, err := s.topicService.GetTopicByID(ctx, targetTopicID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("%w: topic %d", ErrTopicsNotFound, targetTopicID)
+	} else if err != nil {
+		return nil, err
+	}
+	if target.ArchivedAt != nil {
+		return nil, fmt.Errorf("%w: %s", ErrTopicArchived, target.Name)
+	}
+
+	result, err := s.repo.MigrateSubscriptions(ctx, sourceTopicID, targetTopicID, move)
+	if err != nil {
+		return nil, err
+	}
+	s.invalidateSubscriptionCounts(ctx)
+	return result, nil
+}
+
+// checkArchivedSubscriptions returns ErrTopicArchived when topics adds the subscriber to an archived topic.
+// Subscriptions the subscriber already has to archived topics may be kept.
+func (s *service) checkArchivedSubscriptions(ctx context.Context, subscriberID uint, topics []*topic.Topic) error {
+	var archived []string
+	for _, t := range topics {
+		if t.ArchivedAt != nil {
+			archived = append(archived, t.Name)
+		}
+	}
+	if len(archived) == 0 {
+		return nil
+	}
+
+	current, err := s.repo.GetSubscribedTopicNames(ctx, subscriberID)
+	if err != nil {
+		return err
+	}
+	archived = slices.DeleteFunc(archived, func(name string) bool { return slices.Contains(current, name) })
+	if len(archived) > 0 {
+		return fmt.Errorf("%w: %s", ErrTopicArchived, strings.Join(archived, ", "))
+	}
+	return nil
 }
 
 func (s *service) Unsubscribe(ctx context.Context, subscriptionID uint) error {
-	return s.repo.Unsubscribe(ctx, subscriptionID)
+	if err := s.repo.Unsubscribe(ctx, subscriptionID); err != nil {
+		return err
+	}
+	s.invalidateSubscriptionCounts(ctx)
+	return nil
+}
+
+func (s *service) UpdateSubscription(ctx context.Context, subscriptionID uint, updates map[string]interface{}) (bool, error) {
+	return s.repo.UpdateSubscription(ctx, subscriptionID, updates)
 }
 
 func (s *service) GetAllSubscriptions(ctx context.Context) ([]*Subscription, error) {
@@ -67,39 +324,85 @@ func (s *service) GetSubscriptionsBySubscriberID(ctx context.Context, subscriber
 	return s.repo.GetSubscriptionsBySubscriberID(ctx, subscriberID)
 }
 
-func (s *service) GetSubscriptionsByTopicID(ctx context.Context, topicID uint) ([]*Subscription, error) {
-	return s.repo.GetSubscriptionsByTopicID(ctx, topicID)
+func (s *service) GetSubscriptionsBySubscriberIDs(ctx context.Context, subscriberIDs []uint) ([]*Subscription, error) {
+	return s.repo.GetSubscriptionsBySubscriberIDs(ctx, subscriberIDs)
 }
 
-func (s *service) CreateSubscriberWithTopics(ctx context.Context, subscriber *Subscriber, topicNames []string) error {
-	if s.topicService == nil {
-		return fmt.Errorf("topic service not available - use NewServiceWithTopic")
+// CountSubscriptionsByTopicIDs returns the number of subscriptions per topic; topics without any are left out.
+// Only the topics missing from the cache are counted.
+func (s *service) CountSubscriptionsByTopicIDs(ctx context.Context, topicIDs []uint) (map[uint]int64, error) {
+	if s.cache == nil {
+		return s.repo.CountSubscriptionsByTopicIDs(ctx, topicIDs)
 	}
-
-	// Get topics by names
-	topics, err := s.topicService.GetTopicsByNames(ctx, topicNames)
-	if err != nil {
-		return fmt.Errorf("failed to get topics: %w", err)
+	generation := s.subscriptionCountGeneration(ctx)
+	if generation == "" {
+		return s.repo.CountSubscriptionsByTopicIDs(ctx, topicIDs)
 	}
 
-	// Check if all topics were found
-	if len(topics) != len(topicNames) {
-		return fmt.Errorf("some topics not found")
+	counts := make(map[uint]int64, len(topicIDs))
+	var missing []uint
+	for _, topicID := range topicIDs {
+		var count int64
+		found, err := s.cache.Get(ctx, subscriptionCountKey(generation, topicID), &count)
+		if err != nil || !found {
+			missing = append(missing, topicID)
+			continue
+		}
+		if count > 0 {
+			counts[topicID] = count
+		}
+	}
+	if len(missing) == 0 {
+		return counts, nil
 	}
 
-	// Extract topic IDs
-	topicIDs := make([]uint, len(topics))
-	for i, topic := range topics {
-		topicIDs[i] = topic.ID
+	loaded, err := s.repo.CountSubscriptionsByTopicIDs(ctx, missing)
+	if err != nil {
+		return nil, err
 	}
+	for _, topicID := range missing {
+		// Zero counts are cached too, so empty topics don't miss every time
+		count := loaded[topicID]
+		if err := s.cache.Set(ctx, subscriptionCountKey(generation, topicID), count, subscriptionCountCacheTTL); err != nil {
+			log.Printf("Warning: failed to cache subscription count: %v", err)
+		}
+		if count > 0 {
+			counts[topicID] = count
+		}
+	}
+	return counts, nil
+}
 
-	return s.repo.CreateWithTopics(ctx, subscriber, topicIDs)
+func (s *service) GetSubscriptionsByTopicID(ctx context.Context, topicID uint) ([]*Subscription, error) {
+	return s.repo.GetSubscriptionsByTopicID(ctx, topicID)
+}
+
+// CreateSubscriberWithTopics validates the address, then creates the subscriber and its subscriptions in a single
+// transaction and returns the names of the subscribed topics. Topics that do not exist fail the create with
+// ErrTopicsNotFound unless auto_create_topics is enabled.
+func (s *service) CreateSubscriberWithTopics(ctx context.Context, subscriber *Subscriber, topicNames []string) ([]string, error) {
+	if err := s.checkEmail(ctx, subscriber); err != nil {
+		return nil, err
+	}
+	subscribed, err := s.repo.CreateWithTopicNames(ctx, subscriber, topicNames, s.cfg.AutoCreateTopics)
+	if err != nil {
+		return nil, duplicateEmail(err)
+	}
+	if len(subscribed) > 0 {
+		s.invalidateSubscriptionCounts(ctx)
+	}
+	return subscribed, nil
 }
 
 func (s *service) GetSubscriberByIDWithTopics(ctx context.Context, id uint) (*Subscriber, []string, error) {
 	return s.repo.GetByIDWithTopics(ctx, id)
 }
 
+// GetSubscribedTopicNamesByIDs returns the topic names of a page of subscribers without a query per subscriber
+func (s *service) GetSubscribedTopicNamesByIDs(ctx context.Context, subscriberIDs []uint) (map[uint][]string, error) {
+	return s.repo.GetSubscribedTopicNamesByIDs(ctx, subscriberIDs)
+}
+
 func (s *service) UpdateSubscriberWithTopics(ctx context.Context, id uint, updates map[string]interface{}, topicNames []string) error {
 	if s.topicService == nil {
 		return fmt.Errorf("topic service not available - use NewServiceWithTopic")
@@ -107,9 +410,13 @@ func (s *service) UpdateSubscriberWithTopics(ctx context.Context, id uint, updat
 
 	// Update subscriber fields first
 	if len(updates) > 0 {
-		if err := s.repo.Update(ctx, id, updates); err != nil {
+		if err := s.prepareEmailUpdate(ctx, id, updates); err != nil {
 			return err
 		}
+		if err := s.repo.Update(ctx, id, updates); err != nil {
+			return duplicateEmail(err)
+		}
+		s.invalidateSubscribers(ctx, id)
 	}
 
 	// Update topics if provided
@@ -121,7 +428,10 @@ func (s *service) UpdateSubscriberWithTopics(ctx context.Context, id uint, updat
 
 		// Check if all topics were found
 		if len(topics) != len(topicNames) {
-			return fmt.Errorf("some topics not found")
+			return ErrTopicsNotFound
+		}
+		if err := s.checkArchivedSubscriptions(ctx, id, topics); err != nil {
+			return err
 		}
 
 		// Extract topic IDs
@@ -133,39 +443,81 @@ func (s *service) UpdateSubscriberWithTopics(ctx context.Context, id uint, updat
 		if err := s.repo.UpdateSubscribedTopics(ctx, id, topicIDs); err != nil {
 			return fmt.Errorf("failed to update subscriptions: %w", err)
 		}
+		s.invalidateSubscriptionCounts(ctx)
 	}
 
 	return nil
 }
 
-func (s *service) BulkCreateSubscribers(ctx context.Context, subscribers []*Subscriber, topicNamesList [][]string) ([]uint, []error) {
-	var successIDs []uint
-	var errors []error
+// BulkCreateSubscribers validates every row, then creates the valid rows and their subscriptions in a single
+// transaction with batch inserts. There is one result per row, in input order; rows that fail validation or
+// duplicate an existing subscriber (or an earlier row) carry their error and are not created. The returned
+// error is only set when the transaction failed, in which case no row was created.
+func (s *service) BulkCreateSubscribers(ctx context.Context, subscribers []*Subscriber, topicNamesList [][]string) ([]BulkCreateResult, error) {
+	results := make([]BulkCreateResult, len(subscribers))
+	emails := make([]string, 0, len(subscribers))
+	normalizedEmails := make([]string, 0, len(subscribers))
+	for i, subscriber := range subscribers {
+		results[i] = BulkCreateResult{Index: i, Subscriber: subscriber}
+		if err := s.validateEmail(ctx, subscriber); err != nil {
+			results[i].Err = err
+			continue
+		}
+		emails = append(emails, subscriber.Email)
+		normalizedEmails = append(normalizedEmails, subscriber.NormalizedEmail)
+	}
+
+	// Reject rows up front instead of letting a unique violation roll back the whole batch. Soft-deleted
+	// subscribers still hold their exact address until they are purged.
+	existing, err := s.repo.GetByEmails(ctx, emails, normalizedEmails)
+	if err != nil {
+		return nil, err
+	}
+	takenEmails := make(map[string]bool, len(existing))
+	takenNormalized := make(map[string]bool, len(existing))
+	for _, sub := range existing {
+		takenEmails[sub.Email] = true
+		if !sub.DeletedAt.Valid && sub.NormalizedEmail != "" {
+			takenNormalized[sub.NormalizedEmail] = true
+		}
+	}
 
+	var batch []*Subscriber
+	var batchTopicNames [][]string
+	var batchIndexes []int
 	for i, subscriber := range subscribers {
+		if results[i].Err != nil {
+			continue
+		}
+		if takenEmails[subscriber.Email] || takenNormalized[subscriber.NormalizedEmail] {
+			results[i].Err = ErrDuplicateEmail
+			continue
+		}
+		takenEmails[subscriber.Email] = true
+		takenNormalized[subscriber.NormalizedEmail] = true
+
 		var topicNames []string
 		if i < len(topicNamesList) {
 			topicNames = topicNamesList[i]
 		}
-
-		if len(topicNames) > 0 {
-			err := s.CreateSubscriberWithTopics(ctx, subscriber, topicNames)
-			if err != nil {
-				errors = append(errors, fmt.Errorf("subscriber %d: %w", i, err))
-			} else {
-				successIDs = append(successIDs, subscriber.ID)
-			}
-		} else {
-			err := s.CreateSubscriber(ctx, subscriber)
-			if err != nil {
-				errors = append(errors, fmt.Errorf("subscriber %d: %w", i, err))
-			} else {
-				successIDs = append(successIDs, subscriber.ID)
-			}
-		}
+		batch = append(batch, subscriber)
+		batchTopicNames = append(batchTopicNames, topicNames)
+		batchIndexes = append(batchIndexes, i)
+	}
+	if len(batch) == 0 {
+		return results, nil
 	}
 
-	return successIDs, errors
+	subscribed, rowErrs, err := s.repo.BulkCreateWithTopicNames(ctx, batch, batchTopicNames, s.cfg.AutoCreateTopics)
+	if err != nil {
+		return nil, duplicateEmail(err)
+	}
+	s.invalidateSubscriptionCounts(ctx)
+	for j, i := range batchIndexes {
+		results[i].TopicNames = subscribed[j]
+		results[i].Err = rowErrs[j]
+	}
+	return results, nil
 }
 
 func (s *service) BulkUpdateSubscribers(ctx context.Context, updates []BulkSubscriberUpdate) []error {
@@ -0,0 +1,103 @@
+package topic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"newsletter-service/internal/tenant"
+)
+
+type redisStatsCache struct {
+	client redis.UniversalClient
+}
+
+// NewRedisStatsCache creates a Redis-backed topic stats cache shared by all instances
+func NewRedisStatsCache(client redis.UniversalClient) StatsCache {
+	return &redisStatsCache{client: client}
+}
+
+func (r *redisStatsCache) Get(ctx context.Context, topicID uint, days int) (*Stats, bool, error) {
+	data, err := r.client.Get(ctx, statsKey(topicID, days)).Result()
+	if err == redis.Nil {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	var stats Stats
+	if err := json.Unmarshal([]byte(data), &stats); err != nil {
+		return nil, false, err
+	}
+	return &stats, true, nil
+}
+
+func (r *redisStatsCache) Set(ctx context.Context, stats *Stats, ttl time.Duration) error {
+	data, err := json.Marshal(stats)
+	if err != nil {
+		return err
+	}
+	return r.client.Set(ctx, statsKey(stats.TopicID, stats.Days), data, ttl).Err()
+}
+
+func statsKey(topicID uint, days int) string {
+	return fmt.Sprintf("topic:stats:%d:%d", topicID, days)
+}
+
+// getCached returns the cached topic with the name in ctx's organization. Names are only unique within an
+// organization, so unscoped lookups always miss.
+func (s *service) getCached(ctx context.Context, name string) (*Topic, bool) {
+	organizationID, ok := tenant.OrganizationID(ctx)
+	if !ok {
+		return nil, false
+	}
+	var topic Topic
+	found, err := s.cache.Get(ctx, nameKey(organizationID, name), &topic)
+	if err != nil {
+		log.Printf("Warning: failed to read topic cache: %v", err)
+		return nil, false
+	}
+	return &topic, found
+}
+
+// setCached caches a topic under its name. Unknown names are never cached, so creating or restoring a topic
+// has nothing to invalidate.
+func (s *service) setCached(ctx context.Context, topic *Topic) {
+	if err := s.cache.Set(ctx, nameKey(topic.OrganizationID, topic.Name), topic, topicCacheTTL); err != nil {
+		log.Printf("Warning: failed to cache topic: %v", err)
+	}
+}
+
+// cachedKey returns the key a topic is cached under before a write changes or removes it
+func (s *service) cachedKey(ctx context.Context, id uint) string {
+	if s.cache == nil {
+		return ""
+	}
+	topic, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return ""
+	}
+	return nameKey(topic.OrganizationID, topic.Name)
+}
+
+func (s *service) invalidate(ctx context.Context, key string) {
+	if s.cache == nil || key == "" {
+		return
+	}
+	if err := s.cache.Delete(ctx, key); err != nil {
+		log.Printf("Warning: failed to invalidate topic cache: %v", err)
+	}
+}
+
+func nameKey(organizationID uint, name string) string {
+	return fmt.Sprintf("topic:name:%d:%s", organizationID, name)
+}
+
+func (s *service) GetTopicsByIDs(ctx context.Context, ids []uint) ([]*Topic, error) {
+	return s.repo.GetByIDs(ctx, ids)
+}
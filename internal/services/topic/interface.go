@@ -2,26 +2,53 @@ package topic
 
 import (
 	"context"
+	"time"
+
+	"newsletter-service/internal/daos"
 )
 
 type Repository interface {
 	Create(ctx context.Context, topic *Topic) error
 	GetByID(ctx context.Context, id uint) (*Topic, error)
 	GetByName(ctx context.Context, name string) (*Topic, error)
+	GetBySlug(ctx context.Context, slug string) (*Topic, error)
+	SlugTaken(ctx context.Context, slug string, exceptID uint) (bool, error)
 	GetByNames(ctx context.Context, names []string) ([]*Topic, error)
+	GetByIDs(ctx context.Context, ids []uint) ([]*Topic, error)
 	GetAll(ctx context.Context) ([]*Topic, error)
 	GetAllWithPagination(ctx context.Context, offset, limit int) ([]*Topic, int64, error)
+	GetAllIncludingDeletedWithPagination(ctx context.Context, offset, limit int) ([]*Topic, int64, error)
 	Update(ctx context.Context, id uint, updates map[string]interface{}) error
-	Delete(ctx context.Context, id uint) error
+	Delete(ctx context.Context, id uint, force bool) (*DeleteSummary, error)
+	Restore(ctx context.Context, id uint) error
+	SetArchived(ctx context.Context, id uint, archived bool) error
+	GetSubscriptionCounts(ctx context.Context, topicID uint, from time.Time) (*SubscriptionCounts, error)
+	GetLastSend(ctx context.Context, topicID uint) (*SendPerformance, error)
+	GetOrganizationBranding(ctx context.Context, organizationID uint) (daos.Branding, error)
+}
+
+// StatsCache stores computed topic stats keyed by topic and period length
+type StatsCache interface {
+	Get(ctx context.Context, topicID uint, days int) (*Stats, bool, error)
+	Set(ctx context.Context, stats *Stats, ttl time.Duration) error
 }
 
 type Service interface {
 	CreateTopic(ctx context.Context, topic *Topic) error
 	GetTopicByID(ctx context.Context, id uint) (*Topic, error)
 	GetTopicByName(ctx context.Context, name string) (*Topic, error)
+	GetTopicBySlug(ctx context.Context, slug string) (*Topic, error)
 	GetTopicsByNames(ctx context.Context, names []string) ([]*Topic, error)
+	GetTopicsByIDs(ctx context.Context, ids []uint) ([]*Topic, error)
 	GetAllTopics(ctx context.Context) ([]*Topic, error)
 	GetAllTopicsWithPagination(ctx context.Context, offset, limit int) ([]*Topic, int64, error)
+	GetAllTopicsIncludingDeletedWithPagination(ctx context.Context, offset, limit int) ([]*Topic, int64, error)
 	UpdateTopic(ctx context.Context, id uint, updates map[string]interface{}) error
-	DeleteTopic(ctx context.Context, id uint) error
+	DeleteTopic(ctx context.Context, id uint, force bool) (*DeleteSummary, error)
+	RestoreTopic(ctx context.Context, id uint) error
+	ArchiveTopic(ctx context.Context, id uint) error
+	UnarchiveTopic(ctx context.Context, id uint) error
+	GetTopicStats(ctx context.Context, id uint, days int) (*Stats, error)
+	// GetTopicBranding returns a topic with the branding its emails use: its own, gaps filled by its organization's
+	GetTopicBranding(ctx context.Context, id uint) (*Topic, daos.Branding, error)
 }
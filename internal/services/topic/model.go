@@ -1,8 +1,77 @@
 package topic
 
 import (
+	"errors"
+	"time"
+
 	"newsletter-service/internal/daos"
 )
 
 // Type alias for backward compatibility
 type Topic = daos.Topic
+
+// ErrDuplicateName is returned when another topic of the organization, possibly a deleted one, has the name
+var ErrDuplicateName = errors.New("a topic with this name already exists")
+
+// ErrDuplicateSlug is returned when a topic of any organization, possibly a deleted one, has the slug asked for
+var ErrDuplicateSlug = errors.New("a topic with this slug already exists")
+
+// ErrInvalidSlug is returned for a slug that isn't lowercase letters and digits in hyphen-separated words
+var ErrInvalidSlug = errors.New("invalid topic slug")
+
+// ErrTopicInUse is returned when deleting a topic that still has subscriptions or unsent content without force
+var ErrTopicInUse = errors.New("topic has active content or subscriptions")
+
+// DeleteSummary counts what a topic deletion found referring to the topic and, when forced, what it changed
+type DeleteSummary struct {
+	ActiveContent        int64 `json:"active_content"` // Content not yet sent: drafts, scheduled and awaiting delivery
+	Subscriptions        int64 `json:"subscriptions"`
+	SubscriptionsRemoved int64 `json:"subscriptions_removed"`
+	ContentUnpublished   int64 `json:"content_unpublished"`
+}
+
+// Stats summarises a topic's audience and most recent send
+type Stats struct {
+	TopicID             uint             `json:"topic_id"`
+	ActiveSubscribers   int64            `json:"active_subscribers"`
+	InactiveSubscribers int64            `json:"inactive_subscribers"` // Still subscribed but deactivated
+	Days                int              `json:"days"`
+	From                time.Time        `json:"from"`
+	To                  time.Time        `json:"to"`
+	NewSubscriptions    int64            `json:"new_subscriptions"`
+	Churned             int64            `json:"churned"`
+	ChurnRate           float64          `json:"churn_rate"` // Churned / subscriptions at the start of the period
+	Growth              []DailyGrowth    `json:"growth"`
+	LastSend            *SendPerformance `json:"last_send"`
+	GeneratedAt         time.Time        `json:"generated_at"`
+}
+
+// DailyGrowth is one UTC day of subscription changes
+type DailyGrowth struct {
+	Date    string `json:"date"`
+	New     int64  `json:"new"`
+	Churned int64  `json:"churned"`
+	Net     int64  `json:"net"`
+	Total   int64  `json:"total"` // Subscriptions at the end of the day
+}
+
+// SendPerformance is the delivery outcome of the topic's latest sent content
+type SendPerformance struct {
+	ContentID   uint       `json:"content_id"`
+	Title       string     `json:"title"`
+	SentAt      *time.Time `json:"sent_at"`
+	Total       int64      `json:"total"`
+	Sent        int64      `json:"sent"`
+	Failed      int64      `json:"failed"`
+	Pending     int64      `json:"pending"`
+	SuccessRate float64    `json:"success_rate"`
+}
+
+// SubscriptionCounts holds raw aggregates used to build Stats
+type SubscriptionCounts struct {
+	Active       int64
+	Inactive     int64
+	TotalAtStart int64            // Subscriptions that existed at the start of the period
+	NewByDay     map[string]int64 // Keyed by UTC date (2006-01-02)
+	ChurnedByDay map[string]int64
+}
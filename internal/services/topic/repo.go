@@ -2,8 +2,14 @@ package topic
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"newsletter-service/internal/constants"
+	"newsletter-service/internal/daos"
 )
 
 type repository struct {
@@ -47,12 +53,104 @@ func (r *repository) GetAllWithPagination(ctx context.Context, offset, limit int
 	return topics, total, err
 }
 
+// GetAllIncludingDeletedWithPagination lists soft-deleted topics alongside live ones
+func (r *repository) GetAllIncludingDeletedWithPagination(ctx context.Context, offset, limit int) ([]*Topic, int64, error) {
+	var topics []*Topic
+	var total int64
+
+	// Get total count
+	if err := r.db.WithContext(ctx).Unscoped().Model(&Topic{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Get paginated results
+	err := r.db.WithContext(ctx).Unscoped().Order("created_at desc").Offset(offset).Limit(limit).Find(&topics).Error
+	return topics, total, err
+}
+
 func (r *repository) Update(ctx context.Context, id uint, updates map[string]interface{}) error {
 	return r.db.WithContext(ctx).Model(&Topic{}).Where("id = ?", id).Updates(updates).Error
 }
 
-func (r *repository) Delete(ctx context.Context, id uint) error {
-	return r.db.WithContext(ctx).Delete(&Topic{}, id).Error
+func (r *repository) GetOrganizationBranding(ctx context.Context, organizationID uint) (daos.Branding, error) {
+	var organization daos.Organization
+	if err := r.db.WithContext(ctx).First(&organization, organizationID).Error; err != nil {
+		return daos.Branding{}, err
+	}
+	return organization.Branding, nil
+}
+
+// Delete soft-deletes a topic unless subscriptions or unsent content still refer to it, in which case it
+// returns ErrTopicInUse with their counts. force removes the subscriptions and unpublishes the content first,
+// all in one transaction. Content that was already sent is history and never blocks a delete.
+func (r *repository) Delete(ctx context.Context, id uint, force bool) (*DeleteSummary, error) {
+	summary := &DeleteSummary{}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Hold the topic so nothing subscribes to it or adds content between the checks and the delete
+		var topic Topic
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&topic, id).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&daos.Subscription{}).Where("topic_id = ?", id).Count(&summary.Subscriptions).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&daos.Content{}).Where("topic_id = ? AND notifications_sent = ?", id, false).
+			Count(&summary.ActiveContent).Error; err != nil {
+			return err
+		}
+		if !force && (summary.Subscriptions > 0 || summary.ActiveContent > 0) {
+			return ErrTopicInUse
+		}
+
+		removed := tx.Where("topic_id = ?", id).Delete(&daos.Subscription{})
+		if removed.Error != nil {
+			return removed.Error
+		}
+		summary.SubscriptionsRemoved = removed.RowsAffected
+
+		unpublished := tx.Model(&daos.Content{}).
+			Where("topic_id = ? AND is_published = ? AND notifications_sent = ?", id, true, false).
+			Updates(map[string]interface{}{"is_published": false, "published_at": nil})
+		if unpublished.Error != nil {
+			return unpublished.Error
+		}
+		summary.ContentUnpublished = unpublished.RowsAffected
+
+		return tx.Delete(&topic).Error
+	})
+	return summary, err
+}
+
+// Restore undoes a soft delete. It returns gorm.ErrRecordNotFound when no deleted topic has the ID.
+func (r *repository) Restore(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).Unscoped().Model(&Topic{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// SetArchived archives or unarchives a topic. The archive time of a topic that is already archived is kept.
+// It returns gorm.ErrRecordNotFound when no topic has the ID.
+func (r *repository) SetArchived(ctx context.Context, id uint, archived bool) error {
+	var archivedAt interface{}
+	if archived {
+		archivedAt = gorm.Expr("COALESCE(archived_at, ?)", time.Now())
+	}
+	result := r.db.WithContext(ctx).Model(&Topic{}).Where("id = ?", id).Update("archived_at", archivedAt)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
 }
 
 func (r *repository) GetByName(ctx context.Context, name string) (*Topic, error) {
@@ -64,8 +162,141 @@ func (r *repository) GetByName(ctx context.Context, name string) (*Topic, error)
 	return &topic, nil
 }
 
+// GetBySlug finds a topic by its slug. Landing page requests come in without an organization, so the lookup
+// spans all of them.
+func (r *repository) GetBySlug(ctx context.Context, slug string) (*Topic, error) {
+	var topic Topic
+	err := r.db.WithContext(ctx).Where("slug = ?", slug).First(&topic).Error
+	if err != nil {
+		return nil, err
+	}
+	return &topic, nil
+}
+
+// SlugTaken reports whether a topic other than exceptID has the slug. Slugs are unique across organizations and
+// deleted topics keep theirs, so the raw query looks at every row.
+func (r *repository) SlugTaken(ctx context.Context, slug string, exceptID uint) (bool, error) {
+	var taken bool
+	err := r.db.WithContext(ctx).
+		Raw("SELECT EXISTS (SELECT 1 FROM topics WHERE slug = ? AND id <> ?)", slug, exceptID).
+		Scan(&taken).Error
+	return taken, err
+}
+
 func (r *repository) GetByNames(ctx context.Context, names []string) ([]*Topic, error) {
 	var topics []*Topic
 	err := r.db.WithContext(ctx).Where("name IN ?", names).Find(&topics).Error
 	return topics, err
 }
+
+func (r *repository) GetByIDs(ctx context.Context, ids []uint) ([]*Topic, error) {
+	var topics []*Topic
+	err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&topics).Error
+	return topics, err
+}
+
+// GetSubscriptionCounts aggregates a topic's current audience and daily subscription changes since from
+func (r *repository) GetSubscriptionCounts(ctx context.Context, topicID uint, from time.Time) (*SubscriptionCounts, error) {
+	counts := &SubscriptionCounts{
+		NewByDay:     make(map[string]int64),
+		ChurnedByDay: make(map[string]int64),
+	}
+
+	var audience struct {
+		Active   int64
+		Inactive int64
+	}
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT COUNT(*) FILTER (WHERE subscribers.is_active) AS active,
+		       COUNT(*) FILTER (WHERE NOT subscribers.is_active) AS inactive
+		FROM subscriptions
+		JOIN subscribers ON subscribers.id = subscriptions.subscriber_id AND subscribers.deleted_at IS NULL
+		WHERE subscriptions.topic_id = ? AND subscriptions.deleted_at IS NULL`, topicID).Scan(&audience).Error
+	if err != nil {
+		return nil, err
+	}
+	counts.Active = audience.Active
+	counts.Inactive = audience.Inactive
+
+	// Unsubscribing soft-deletes the subscription, so churn is read from deleted_at
+	err = r.db.WithContext(ctx).Raw(`
+		SELECT COUNT(*) FROM subscriptions
+		WHERE topic_id = ? AND created_at < ? AND (deleted_at IS NULL OR deleted_at >= ?)`,
+		topicID, from, from).Scan(&counts.TotalAtStart).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var buckets []struct {
+		Day     string
+		New     int64
+		Churned int64
+	}
+	err = r.db.WithContext(ctx).Raw(`
+		SELECT day, SUM(new) AS new, SUM(churned) AS churned FROM (
+			SELECT to_char(created_at AT TIME ZONE 'UTC', 'YYYY-MM-DD') AS day, 1 AS new, 0 AS churned
+			FROM subscriptions WHERE topic_id = ? AND created_at >= ?
+			UNION ALL
+			SELECT to_char(deleted_at AT TIME ZONE 'UTC', 'YYYY-MM-DD') AS day, 0 AS new, 1 AS churned
+			FROM subscriptions WHERE topic_id = ? AND deleted_at >= ?
+		) changes
+		GROUP BY day`, topicID, from, topicID, from).Scan(&buckets).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, bucket := range buckets {
+		counts.NewByDay[bucket.Day] = bucket.New
+		counts.ChurnedByDay[bucket.Day] = bucket.Churned
+	}
+
+	return counts, nil
+}
+
+// GetLastSend returns delivery counts for the topic's most recently sent content, or nil if nothing was sent
+func (r *repository) GetLastSend(ctx context.Context, topicID uint) (*SendPerformance, error) {
+	var content daos.Content
+	err := r.db.WithContext(ctx).
+		Where("topic_id = ? AND notifications_sent = ?", topicID, true).
+		Order("notifications_sent_at DESC NULLS LAST, id DESC").
+		First(&content).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []struct {
+		Status string
+		Count  int64
+	}
+	err = r.db.WithContext(ctx).Model(&daos.EmailLog{}).
+		Select("status, COUNT(*) AS count").
+		Where("content_id = ?", content.ID).
+		Group("status").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	performance := &SendPerformance{
+		ContentID: content.ID,
+		Title:     content.Title,
+		SentAt:    content.NotificationsSentAt,
+	}
+	for _, row := range rows {
+		performance.Total += row.Count
+		switch row.Status {
+		case constants.StatusSent:
+			performance.Sent = row.Count
+		case constants.StatusFailed:
+			performance.Failed = row.Count
+		case constants.StatusPending:
+			performance.Pending = row.Count
+		}
+	}
+	if performance.Total > 0 {
+		performance.SuccessRate = float64(performance.Sent) / float64(performance.Total)
+	}
+	return performance, nil
+}
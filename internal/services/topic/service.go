@@ -1,17 +1,54 @@
 package topic
 
-import "context"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+
+	"newsletter-service/internal/cache"
+	"newsletter-service/internal/daos"
+)
+
+// statsCacheTTL bounds how stale cached topic stats can be
+const statsCacheTTL = 5 * time.Minute
+
+// topicCacheTTL is how long a topic looked up by name is served from the cache. Writes through this
+// service invalidate it straight away; the TTL only matters for instances that do not share the cache.
+const topicCacheTTL = 10 * time.Minute
 
 type service struct {
-	repo Repository
+	repo       Repository
+	statsCache StatsCache
+	cache      cache.Cache
 }
 
 func NewService(repo Repository) Service {
 	return &service{repo: repo}
 }
 
+// NewServiceWithStatsCache creates a topic service that caches computed stats
+func NewServiceWithStatsCache(repo Repository, statsCache StatsCache) Service {
+	return &service{repo: repo, statsCache: statsCache}
+}
+
+// NewServiceWithCache creates a topic service that also caches topics looked up by name, which every send
+// and subscriber update resolves. statsCache may be nil.
+func NewServiceWithCache(repo Repository, statsCache StatsCache, c cache.Cache) Service {
+	return &service{repo: repo, statsCache: statsCache, cache: c}
+}
+
+// CreateTopic creates a topic. One created without a slug gets one made from its name.
 func (s *service) CreateTopic(ctx context.Context, topic *Topic) error {
-	return s.repo.Create(ctx, topic)
+	if topic.Slug != "" {
+		if err := s.checkSlug(ctx, topic.Slug, 0); err != nil {
+			return err
+		}
+	}
+	return duplicateName(s.repo.Create(ctx, topic))
 }
 
 func (s *service) GetTopicByID(ctx context.Context, id uint) (*Topic, error) {
@@ -26,18 +63,216 @@ func (s *service) GetAllTopicsWithPagination(ctx context.Context, offset, limit
 	return s.repo.GetAllWithPagination(ctx, offset, limit)
 }
 
+func (s *service) GetAllTopicsIncludingDeletedWithPagination(ctx context.Context, offset, limit int) ([]*Topic, int64, error) {
+	return s.repo.GetAllIncludingDeletedWithPagination(ctx, offset, limit)
+}
+
+// UpdateTopic applies updates to a topic. Renaming it keeps its slug, so links to its landing page go on working.
 func (s *service) UpdateTopic(ctx context.Context, id uint, updates map[string]interface{}) error {
-	return s.repo.Update(ctx, id, updates)
+	if slug, ok := updates["slug"].(string); ok {
+		if err := s.checkSlug(ctx, slug, id); err != nil {
+			return err
+		}
+	}
+	key := s.cachedKey(ctx, id)
+	if err := s.repo.Update(ctx, id, updates); err != nil {
+		return duplicateName(err)
+	}
+	s.invalidate(ctx, key)
+	return nil
+}
+
+func (s *service) GetTopicBranding(ctx context.Context, id uint) (*Topic, daos.Branding, error) {
+	topic, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, daos.Branding{}, err
+	}
+	organizationBranding, err := s.repo.GetOrganizationBranding(ctx, topic.OrganizationID)
+	if err != nil {
+		return nil, daos.Branding{}, fmt.Errorf("failed to get organization branding: %w", err)
+	}
+	return topic, topic.Branding.Merge(organizationBranding), nil
+}
+
+// checkSlug refuses a slug that is malformed or belongs to a topic other than id
+func (s *service) checkSlug(ctx context.Context, slug string, id uint) error {
+	if !daos.ValidSlug(slug) {
+		return ErrInvalidSlug
+	}
+	taken, err := s.repo.SlugTaken(ctx, slug, id)
+	if err != nil {
+		return fmt.Errorf("failed to check topic slug: %w", err)
+	}
+	if taken {
+		return ErrDuplicateSlug
+	}
+	return nil
+}
+
+// duplicateName reports the names index rejecting a topic as ErrDuplicateName
+func duplicateName(err error) error {
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return fmt.Errorf("%w: %w", ErrDuplicateName, err)
+	}
+	return err
+}
+
+// DeleteTopic soft-deletes a topic, refusing with ErrTopicInUse while it has subscriptions or unsent content
+// unless force is set. The summary holds what was found, and with force what was removed or unpublished.
+func (s *service) DeleteTopic(ctx context.Context, id uint, force bool) (*DeleteSummary, error) {
+	key := s.cachedKey(ctx, id)
+	summary, err := s.repo.Delete(ctx, id, force)
+	if err != nil {
+		return summary, err
+	}
+	s.invalidate(ctx, key)
+	return summary, nil
+}
+
+func (s *service) RestoreTopic(ctx context.Context, id uint) error {
+	return s.repo.Restore(ctx, id)
 }
 
-func (s *service) DeleteTopic(ctx context.Context, id uint) error {
-	return s.repo.Delete(ctx, id)
+// ArchiveTopic stops a topic taking new content and subscriptions. Its subscribers, content and email logs
+// are kept, and content already published is still sent.
+func (s *service) ArchiveTopic(ctx context.Context, id uint) error {
+	return s.setArchived(ctx, id, true)
+}
+
+// UnarchiveTopic lets an archived topic take new content and subscriptions again
+func (s *service) UnarchiveTopic(ctx context.Context, id uint) error {
+	return s.setArchived(ctx, id, false)
+}
+
+func (s *service) setArchived(ctx context.Context, id uint, archived bool) error {
+	key := s.cachedKey(ctx, id)
+	if err := s.repo.SetArchived(ctx, id, archived); err != nil {
+		return err
+	}
+	s.invalidate(ctx, key)
+	return nil
 }
 
 func (s *service) GetTopicByName(ctx context.Context, name string) (*Topic, error) {
-	return s.repo.GetByName(ctx, name)
+	if s.cache == nil {
+		return s.repo.GetByName(ctx, name)
+	}
+	if topic, found := s.getCached(ctx, name); found {
+		return topic, nil
+	}
+
+	topic, err := s.repo.GetByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	s.setCached(ctx, topic)
+	return topic, nil
 }
 
+func (s *service) GetTopicBySlug(ctx context.Context, slug string) (*Topic, error) {
+	return s.repo.GetBySlug(ctx, slug)
+}
+
+// GetTopicsByNames returns the topics with the given names, one per distinct name found. Only the names
+// missing from the cache are queried.
 func (s *service) GetTopicsByNames(ctx context.Context, names []string) ([]*Topic, error) {
-	return s.repo.GetByNames(ctx, names)
+	if s.cache == nil {
+		return s.repo.GetByNames(ctx, names)
+	}
+
+	var topics []*Topic
+	var missing []string
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		if topic, found := s.getCached(ctx, name); found {
+			topics = append(topics, topic)
+		} else {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) == 0 {
+		return topics, nil
+	}
+
+	loaded, err := s.repo.GetByNames(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+	for _, topic := range loaded {
+		s.setCached(ctx, topic)
+	}
+	return append(topics, loaded...), nil
+}
+
+// GetTopicStats returns audience size, daily growth and churn over the last days UTC days
+// (including today) and the outcome of the latest send. Results are cached when a cache is configured.
+func (s *service) GetTopicStats(ctx context.Context, id uint, days int) (*Stats, error) {
+	if _, err := s.repo.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	if s.statsCache != nil {
+		if stats, found, err := s.statsCache.Get(ctx, id, days); err != nil {
+			log.Printf("Warning: failed to read topic stats cache: %v", err)
+		} else if found {
+			return stats, nil
+		}
+	}
+
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	from := today.AddDate(0, 0, -(days - 1))
+
+	counts, err := s.repo.GetSubscriptionCounts(ctx, id, from)
+	if err != nil {
+		return nil, err
+	}
+	lastSend, err := s.repo.GetLastSend(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &Stats{
+		TopicID:             id,
+		ActiveSubscribers:   counts.Active,
+		InactiveSubscribers: counts.Inactive,
+		Days:                days,
+		From:                from,
+		To:                  now,
+		Growth:              make([]DailyGrowth, 0, days),
+		LastSend:            lastSend,
+		GeneratedAt:         now,
+	}
+
+	total := counts.TotalAtStart
+	for day := from; !day.After(today); day = day.AddDate(0, 0, 1) {
+		date := day.Format("2006-01-02")
+		growth := DailyGrowth{
+			Date:    date,
+			New:     counts.NewByDay[date],
+			Churned: counts.ChurnedByDay[date],
+		}
+		growth.Net = growth.New - growth.Churned
+		total += growth.Net
+		growth.Total = total
+
+		stats.NewSubscriptions += growth.New
+		stats.Churned += growth.Churned
+		stats.Growth = append(stats.Growth, growth)
+	}
+	if counts.TotalAtStart > 0 {
+		stats.ChurnRate = float64(stats.Churned) / float64(counts.TotalAtStart)
+	}
+
+	if s.statsCache != nil {
+		if err := s.statsCache.Set(ctx, stats, statsCacheTTL); err != nil {
+			log.Printf("Warning: failed to cache topic stats: %v", err)
+		}
+	}
+
+	return stats, nil
 }
@@ -0,0 +1,12 @@
+package webhook
+
+// Core contains shared business logic for webhook domain
+type Core struct {
+	service Service
+}
+
+func NewCore(service Service) *Core {
+	return &Core{
+		service: service,
+	}
+}
@@ -0,0 +1,117 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"newsletter-service/internal/constants"
+	"newsletter-service/internal/logger"
+)
+
+// maxResponseBodyBytes bounds how much of a receiver's response is kept in the delivery log
+const maxResponseBodyBytes = 1024
+
+// Sign returns the X-Webhook-Signature value for body sent at timestamp (Unix seconds).
+// Receivers recompute HMAC-SHA256 over "<timestamp>.<body>" with their secret and compare.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10) + "."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// deliver claims a delivery, posts it once and records the outcome and next retry
+func (s *service) deliver(ctx context.Context, webhook *Webhook, delivery *Delivery) {
+	now := time.Now()
+	claimed, err := s.repo.ClaimDelivery(ctx, delivery.ID, now, now.Add(s.cfg.Timeout+time.Minute))
+	if err != nil {
+		logger.Warn(ctx, "Failed to claim webhook delivery %d: %v", delivery.ID, err)
+		return
+	}
+	if !claimed {
+		// Another worker is already sending it
+		return
+	}
+
+	attempts := delivery.Attempts + 1
+	statusCode, responseBody, err := s.post(ctx, webhook, delivery)
+
+	updates := map[string]interface{}{
+		"attempts":      attempts,
+		"response_body": responseBody,
+	}
+	if statusCode > 0 {
+		updates["response_status"] = statusCode
+	}
+
+	if err == nil {
+		deliveredAt := time.Now()
+		updates["status"] = DeliveryStatusDelivered
+		updates["delivered_at"] = &deliveredAt
+		updates["next_attempt_at"] = nil
+		updates["error_message"] = nil
+	} else {
+		updates["error_message"] = err.Error()
+		if attempts >= s.cfg.MaxAttempts {
+			updates["status"] = DeliveryStatusFailed
+			updates["next_attempt_at"] = nil
+			logger.Warn(ctx, "Webhook delivery %d to %s failed permanently after %d attempts: %v", delivery.ID, webhook.URL, attempts, err)
+		} else {
+			nextAttemptAt := time.Now().Add(s.backoff(attempts))
+			updates["next_attempt_at"] = &nextAttemptAt
+		}
+	}
+
+	if err := s.repo.UpdateDelivery(ctx, delivery.ID, updates); err != nil {
+		logger.Warn(ctx, "Failed to record webhook delivery %d: %v", delivery.ID, err)
+	}
+}
+
+// post sends the signed payload and treats any 2xx response as delivered
+func (s *service) post(ctx context.Context, webhook *Webhook, delivery *Delivery) (int, string, error) {
+	body := []byte(delivery.Payload)
+	timestamp := time.Now().Unix()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, "", fmt.Errorf("invalid webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", constants.WebhookUserAgent)
+	req.Header.Set(constants.HeaderWebhookID, delivery.EventID)
+	req.Header.Set(constants.HeaderWebhookEvent, delivery.EventType)
+	req.Header.Set(constants.HeaderWebhookTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(constants.HeaderWebhookSignature, Sign(webhook.Secret, timestamp, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+
+	responseBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBodyBytes))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, string(responseBody), fmt.Errorf("webhook endpoint returned status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, string(responseBody), nil
+}
+
+// backoff doubles the configured delay after every failed attempt, capped at an hour
+func (s *service) backoff(attempts int) time.Duration {
+	delay := s.cfg.RetryBackoff
+	for i := 1; i < attempts && delay < maxRetryBackoff; i++ {
+		delay *= 2
+	}
+	if delay > maxRetryBackoff {
+		delay = maxRetryBackoff
+	}
+	return delay
+}
@@ -0,0 +1,33 @@
+package webhook
+
+import (
+	"context"
+	"time"
+
+	"newsletter-service/internal/events"
+)
+
+type Repository interface {
+	Create(ctx context.Context, webhook *Webhook) error
+	GetByID(ctx context.Context, id uint) (*Webhook, error)
+	GetAllWithPagination(ctx context.Context, offset, limit int) ([]*Webhook, int64, error)
+	GetActive(ctx context.Context) ([]*Webhook, error)
+	Update(ctx context.Context, id uint, updates map[string]interface{}) error
+	Delete(ctx context.Context, id uint) error
+	CreateDeliveries(ctx context.Context, deliveries []*Delivery) error
+	GetDeliveriesWithPagination(ctx context.Context, webhookID uint, offset, limit int) ([]*Delivery, int64, error)
+	GetDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*Delivery, error)
+	ClaimDelivery(ctx context.Context, id uint, now, leaseUntil time.Time) (bool, error)
+	UpdateDelivery(ctx context.Context, id uint, updates map[string]interface{}) error
+}
+
+type Service interface {
+	CreateWebhook(ctx context.Context, webhook *Webhook) (string, error)
+	GetWebhookByID(ctx context.Context, id uint) (*Webhook, error)
+	GetWebhooksWithPagination(ctx context.Context, offset, limit int) ([]*Webhook, int64, error)
+	UpdateWebhook(ctx context.Context, id uint, updates map[string]interface{}) error
+	DeleteWebhook(ctx context.Context, id uint) error
+	GetDeliveriesWithPagination(ctx context.Context, webhookID uint, offset, limit int) ([]*Delivery, int64, error)
+	Publish(ctx context.Context, event events.Event) error
+	ProcessDueDeliveries(ctx context.Context) error
+}
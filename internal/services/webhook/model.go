@@ -0,0 +1,55 @@
+package webhook
+
+import (
+	"strings"
+
+	"newsletter-service/internal/daos"
+	"newsletter-service/internal/events"
+)
+
+// Type alias for backward compatibility
+type Webhook = daos.Webhook
+type Delivery = daos.WebhookDelivery
+
+// EventTypes lists every event a webhook may subscribe to
+var EventTypes = []string{
+	events.SubscriberCreated,
+	events.SubscriberUnsubscribed,
+	events.ContentPublished,
+	events.SendCompleted,
+	events.SendReport,
+	events.EmailBounced,
+}
+
+// Delivery statuses
+const (
+	DeliveryStatusPending   = "pending"
+	DeliveryStatusDelivered = "delivered"
+	DeliveryStatusFailed    = "failed"
+)
+
+// SecretPrefix marks generated signing secrets so they are recognisable in logs and secret scanners
+const SecretPrefix = "whsec_"
+
+// EncodeEvents joins event types for storage
+func EncodeEvents(events []string) string {
+	return strings.Join(events, ",")
+}
+
+// DecodeEvents splits stored event types
+func DecodeEvents(events string) []string {
+	if events == "" {
+		return []string{}
+	}
+	return strings.Split(events, ",")
+}
+
+// subscribesTo reports whether the webhook wants events of eventType
+func subscribesTo(webhook *Webhook, eventType string) bool {
+	for _, event := range DecodeEvents(webhook.Events) {
+		if event == eventType {
+			return true
+		}
+	}
+	return false
+}
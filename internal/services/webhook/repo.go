@@ -0,0 +1,102 @@
+package webhook
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) Create(ctx context.Context, webhook *Webhook) error {
+	return r.db.WithContext(ctx).Create(webhook).Error
+}
+
+func (r *repository) GetByID(ctx context.Context, id uint) (*Webhook, error) {
+	var webhook Webhook
+	err := r.db.WithContext(ctx).First(&webhook, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+func (r *repository) GetAllWithPagination(ctx context.Context, offset, limit int) ([]*Webhook, int64, error) {
+	var webhooks []*Webhook
+	var total int64
+
+	if err := r.db.WithContext(ctx).Model(&Webhook{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := r.db.WithContext(ctx).Order("id DESC").Offset(offset).Limit(limit).Find(&webhooks).Error
+	return webhooks, total, err
+}
+
+func (r *repository) GetActive(ctx context.Context) ([]*Webhook, error) {
+	var webhooks []*Webhook
+	err := r.db.WithContext(ctx).Where("is_active = ?", true).Find(&webhooks).Error
+	return webhooks, err
+}
+
+func (r *repository) Update(ctx context.Context, id uint, updates map[string]interface{}) error {
+	return r.db.WithContext(ctx).Model(&Webhook{}).Where("id = ?", id).Updates(updates).Error
+}
+
+func (r *repository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&Webhook{}, id).Error
+}
+
+func (r *repository) CreateDeliveries(ctx context.Context, deliveries []*Delivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Create(&deliveries).Error
+}
+
+func (r *repository) GetDeliveriesWithPagination(ctx context.Context, webhookID uint, offset, limit int) ([]*Delivery, int64, error) {
+	var deliveries []*Delivery
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&Delivery{}).Where("webhook_id = ?", webhookID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("id DESC").Offset(offset).Limit(limit).Find(&deliveries).Error
+	return deliveries, total, err
+}
+
+// GetDueDeliveries returns pending deliveries whose next attempt is at or before now
+func (r *repository) GetDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*Delivery, error) {
+	var deliveries []*Delivery
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND next_attempt_at <= ?", DeliveryStatusPending, now).
+		Order("next_attempt_at ASC").
+		Limit(limit).
+		Find(&deliveries).Error
+	return deliveries, err
+}
+
+// ClaimDelivery leases a due delivery by pushing its next attempt to leaseUntil.
+// Only one worker can win the conditional update, so a delivery is never sent twice concurrently.
+func (r *repository) ClaimDelivery(ctx context.Context, id uint, now, leaseUntil time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&Delivery{}).
+		Where("id = ? AND status = ? AND next_attempt_at <= ?", id, DeliveryStatusPending, now).
+		Update("next_attempt_at", leaseUntil)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *repository) UpdateDelivery(ctx context.Context, id uint, updates map[string]interface{}) error {
+	return r.db.WithContext(ctx).Model(&Delivery{}).Where("id = ?", id).Updates(updates).Error
+}
@@ -0,0 +1,203 @@
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"gorm.io/gorm"
+
+	"newsletter-service/internal/config"
+	"newsletter-service/internal/events"
+	"newsletter-service/internal/logger"
+	"newsletter-service/internal/tracing"
+)
+
+// Defaults applied when the [webhooks] config section leaves a value unset
+const (
+	defaultTimeout      = 10 * time.Second
+	defaultMaxAttempts  = 8
+	defaultRetryBackoff = 30 * time.Second
+	maxRetryBackoff     = time.Hour
+	dueDeliveryBatch    = 100
+)
+
+type service struct {
+	repo   Repository
+	cfg    config.WebhookConfig
+	client *http.Client
+}
+
+func NewService(repo Repository, cfg config.WebhookConfig) Service {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultTimeout
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = defaultMaxAttempts
+	}
+	if cfg.RetryBackoff <= 0 {
+		cfg.RetryBackoff = defaultRetryBackoff
+	}
+
+	return &service{
+		repo:   repo,
+		cfg:    cfg,
+		client: &http.Client{Timeout: cfg.Timeout},
+	}
+}
+
+// CreateWebhook generates a signing secret, stores the webhook and returns the secret.
+// Receivers use the secret to verify the X-Webhook-Signature header.
+func (s *service) CreateWebhook(ctx context.Context, webhook *Webhook) (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	webhook.Secret = SecretPrefix + hex.EncodeToString(secret)
+	webhook.IsActive = true
+
+	if err := s.repo.Create(ctx, webhook); err != nil {
+		return "", err
+	}
+	return webhook.Secret, nil
+}
+
+func (s *service) GetWebhookByID(ctx context.Context, id uint) (*Webhook, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *service) GetWebhooksWithPagination(ctx context.Context, offset, limit int) ([]*Webhook, int64, error) {
+	return s.repo.GetAllWithPagination(ctx, offset, limit)
+}
+
+func (s *service) UpdateWebhook(ctx context.Context, id uint, updates map[string]interface{}) error {
+	if _, err := s.repo.GetByID(ctx, id); err != nil {
+		return err
+	}
+	return s.repo.Update(ctx, id, updates)
+}
+
+func (s *service) DeleteWebhook(ctx context.Context, id uint) error {
+	if _, err := s.repo.GetByID(ctx, id); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *service) GetDeliveriesWithPagination(ctx context.Context, webhookID uint, offset, limit int) ([]*Delivery, int64, error) {
+	return s.repo.GetDeliveriesWithPagination(ctx, webhookID, offset, limit)
+}
+
+// Subscribe queues webhook deliveries for every subscribable event emitted on bus
+func Subscribe(bus *events.Bus, service Service) {
+	bus.Subscribe(func(ctx context.Context, event events.Event) {
+		if err := service.Publish(ctx, event); err != nil {
+			logger.Warn(ctx, "Failed to queue %s webhook deliveries: %v", event.Type, err)
+		}
+	}, EventTypes...)
+}
+
+// Publish records a delivery for every active webhook subscribed to the event type and
+// sends them in the background. Failed deliveries are retried by ProcessDueDeliveries.
+func (s *service) Publish(ctx context.Context, event events.Event) error {
+	ctx, span := tracing.StartSpan(ctx, "webhook.Publish", attribute.String("webhook.event", event.Type))
+	defer span.End()
+
+	webhooks, err := s.repo.GetActive(ctx)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return err
+	}
+
+	targets := make(map[uint]*Webhook)
+	for _, webhook := range webhooks {
+		if subscribesTo(webhook, event.Type) {
+			targets[webhook.ID] = webhook
+		}
+	}
+	span.SetAttributes(attribute.Int("webhook.targets", len(targets)))
+	if len(targets) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to serialize webhook event: %w", err)
+	}
+
+	now := time.Now()
+	deliveries := make([]*Delivery, 0, len(targets))
+	for _, webhook := range targets {
+		deliveries = append(deliveries, &Delivery{
+			WebhookID:     webhook.ID,
+			EventID:       event.ID,
+			EventType:     event.Type,
+			Payload:       string(payload),
+			Status:        DeliveryStatusPending,
+			NextAttemptAt: &now,
+		})
+	}
+
+	if err := s.repo.CreateDeliveries(ctx, deliveries); err != nil {
+		tracing.RecordError(span, err)
+		return err
+	}
+
+	// Deliver without holding up the caller; the request context may be cancelled once it returns
+	background := context.WithoutCancel(ctx)
+	go func() {
+		for _, delivery := range deliveries {
+			s.deliver(background, targets[delivery.WebhookID], delivery)
+		}
+	}()
+
+	return nil
+}
+
+// ProcessDueDeliveries retries pending deliveries whose backoff has elapsed
+func (s *service) ProcessDueDeliveries(ctx context.Context) error {
+	ctx, span := tracing.StartSpan(ctx, "webhook.ProcessDueDeliveries")
+	defer span.End()
+
+	deliveries, err := s.repo.GetDueDeliveries(ctx, time.Now(), dueDeliveryBatch)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return err
+	}
+	span.SetAttributes(attribute.Int("webhook.due", len(deliveries)))
+
+	webhooks := make(map[uint]*Webhook)
+	for _, delivery := range deliveries {
+		webhook, ok := webhooks[delivery.WebhookID]
+		if !ok {
+			webhook, err = s.repo.GetByID(ctx, delivery.WebhookID)
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				logger.Warn(ctx, "Failed to load webhook %d for delivery %d: %v", delivery.WebhookID, delivery.ID, err)
+				continue
+			}
+			webhooks[delivery.WebhookID] = webhook
+		}
+
+		if webhook == nil || !webhook.IsActive {
+			message := "webhook is disabled or deleted"
+			if err := s.repo.UpdateDelivery(ctx, delivery.ID, map[string]interface{}{
+				"status":          DeliveryStatusFailed,
+				"error_message":   message,
+				"next_attempt_at": nil,
+			}); err != nil {
+				logger.Warn(ctx, "Failed to abandon webhook delivery %d: %v", delivery.ID, err)
+			}
+			continue
+		}
+
+		s.deliver(ctx, webhook, delivery)
+	}
+
+	return nil
+}
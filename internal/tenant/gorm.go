@@ -0,0 +1,96 @@
+package tenant
+
+import (
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// organizationField is the field models carry to belong to an organization
+const organizationField = "OrganizationID"
+
+// scopedKey marks a statement already filtered, since chained queries (Count then Find) share one statement
+const scopedKey = "tenant:scoped"
+
+// GormPlugin filters every query, update and delete on a model with an OrganizationID field to the organization
+// in the statement's context, and assigns that organization to created rows. Raw SQL is left alone; repositories
+// running raw queries on organization tables filter them themselves.
+type GormPlugin struct{}
+
+// NewGormPlugin creates the organization scoping plugin for use with db.Use
+func NewGormPlugin() gorm.Plugin {
+	return &GormPlugin{}
+}
+
+// Name implements gorm.Plugin
+func (p *GormPlugin) Name() string {
+	return "tenant"
+}
+
+// Initialize implements gorm.Plugin
+func (p *GormPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	if err := cb.Create().Before("gorm:create").Register("tenant:create", assignOrganization); err != nil {
+		return err
+	}
+	if err := cb.Query().Before("gorm:query").Register("tenant:query", scopeOrganization); err != nil {
+		return err
+	}
+	if err := cb.Update().Before("gorm:update").Register("tenant:update", scopeOrganization); err != nil {
+		return err
+	}
+	if err := cb.Delete().Before("gorm:delete").Register("tenant:delete", scopeOrganization); err != nil {
+		return err
+	}
+	return cb.Row().Before("gorm:row").Register("tenant:row", scopeOrganization)
+}
+
+// organizationSchemaField returns the statement model's organization field, or nil when it has none
+func organizationSchemaField(db *gorm.DB) *schema.Field {
+	if db.Error != nil || db.Statement.Schema == nil {
+		return nil
+	}
+	return db.Statement.Schema.LookUpField(organizationField)
+}
+
+func scopeOrganization(db *gorm.DB) {
+	organizationID, ok := OrganizationID(db.Statement.Context)
+	field := organizationSchemaField(db)
+	if !ok || field == nil {
+		return
+	}
+	if _, scoped := db.Statement.Settings.LoadOrStore(scopedKey, true); scoped {
+		return
+	}
+
+	db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{
+		clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: field.DBName}, Value: organizationID},
+	}})
+}
+
+// assignOrganization overwrites the organization of created rows, so a scoped request can't write into another
+func assignOrganization(db *gorm.DB) {
+	organizationID, ok := OrganizationID(db.Statement.Context)
+	field := organizationSchemaField(db)
+	if !ok || field == nil {
+		return
+	}
+
+	ctx := db.Statement.Context
+	value := db.Statement.ReflectValue
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			if err := field.Set(ctx, reflect.Indirect(value.Index(i)), organizationID); err != nil {
+				db.AddError(err)
+				return
+			}
+		}
+	case reflect.Struct:
+		if err := field.Set(ctx, value, organizationID); err != nil {
+			db.AddError(err)
+		}
+	}
+}
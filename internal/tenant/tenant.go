@@ -0,0 +1,24 @@
+package tenant
+
+import "context"
+
+// DefaultOrganizationID is the organization created with the organizations table. Rows that predate it belong
+// to it, as do requests made with the operator credentials that don't name an organization.
+const DefaultOrganizationID uint = 1
+
+type contextKey struct{}
+
+// WithOrganization scopes the database work done with ctx to one organization
+func WithOrganization(ctx context.Context, organizationID uint) context.Context {
+	return context.WithValue(ctx, contextKey{}, organizationID)
+}
+
+// OrganizationID returns the organization ctx is scoped to. Contexts without one, such as the worker's, see
+// every organization.
+func OrganizationID(ctx context.Context) (uint, bool) {
+	if ctx == nil {
+		return 0, false
+	}
+	id, ok := ctx.Value(contextKey{}).(uint)
+	return id, ok
+}
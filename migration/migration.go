@@ -0,0 +1,41 @@
+package migration
+
+import (
+	"database/sql"
+	"embed"
+	"fmt"
+
+	"github.com/pressly/goose/v3"
+)
+
+// Supported migration commands
+const (
+	CommandUp      = "up"
+	CommandDown    = "down"
+	CommandStatus  = "status"
+	CommandVersion = "version"
+)
+
+//go:embed sql/*.sql
+var FS embed.FS
+
+// Run executes a goose command against db using the migrations embedded in the binary
+func Run(db *sql.DB, command string) error {
+	goose.SetBaseFS(FS)
+	if err := goose.SetDialect("postgres"); err != nil {
+		return fmt.Errorf("failed to set goose dialect: %w", err)
+	}
+
+	switch command {
+	case CommandUp:
+		return goose.Up(db, "sql")
+	case CommandDown:
+		return goose.Down(db, "sql")
+	case CommandStatus:
+		return goose.Status(db, "sql")
+	case CommandVersion:
+		return goose.Version(db, "sql")
+	default:
+		return fmt.Errorf("unknown migration command %q (expected up, down, status or version)", command)
+	}
+}
@@ -0,0 +1,17 @@
+-- +goose Up
+-- Create api_keys table with optional per-key rate limit overrides
+CREATE TABLE IF NOT EXISTS api_keys (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    key_prefix VARCHAR(16) NOT NULL,
+    key_hash VARCHAR(64) NOT NULL UNIQUE,
+    rate_limit_bucket_size INTEGER NULL,
+    rate_limit_refill_size INTEGER NULL,
+    rate_limit_refill_seconds INTEGER NULL,
+    revoked_at TIMESTAMP WITH TIME ZONE NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS api_keys;
@@ -0,0 +1,11 @@
+-- +goose Up
+-- Track when a delivered email was first opened (via the tracking pixel)
+ALTER TABLE email_logs
+ADD COLUMN opened_at TIMESTAMP WITH TIME ZONE NULL;
+
+CREATE INDEX IF NOT EXISTS idx_email_logs_opened_at ON email_logs(opened_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_email_logs_opened_at;
+ALTER TABLE email_logs
+DROP COLUMN IF EXISTS opened_at;
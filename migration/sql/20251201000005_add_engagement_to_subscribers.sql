@@ -0,0 +1,17 @@
+-- +goose Up
+-- Engagement score and sunset policy state, maintained by the worker
+ALTER TABLE subscribers
+ADD COLUMN engagement_score DOUBLE PRECISION NOT NULL DEFAULT 0,
+ADD COLUMN last_engaged_at TIMESTAMP WITH TIME ZONE NULL,
+ADD COLUMN sunset_at TIMESTAMP WITH TIME ZONE NULL;
+
+CREATE INDEX IF NOT EXISTS idx_subscribers_engagement_score ON subscribers(engagement_score);
+CREATE INDEX IF NOT EXISTS idx_subscribers_sunset_at ON subscribers(sunset_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_subscribers_sunset_at;
+DROP INDEX IF EXISTS idx_subscribers_engagement_score;
+ALTER TABLE subscribers
+DROP COLUMN IF EXISTS sunset_at,
+DROP COLUMN IF EXISTS last_engaged_at,
+DROP COLUMN IF EXISTS engagement_score;
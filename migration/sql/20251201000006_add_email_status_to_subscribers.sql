@@ -0,0 +1,13 @@
+-- +goose Up
+-- Deliverability status from syntax, disposable-domain and MX checks
+ALTER TABLE subscribers
+ADD COLUMN email_status VARCHAR(20) NOT NULL DEFAULT 'unverified',
+ADD COLUMN email_checked_at TIMESTAMP WITH TIME ZONE NULL;
+
+CREATE INDEX IF NOT EXISTS idx_subscribers_email_status ON subscribers(email_status);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_subscribers_email_status;
+ALTER TABLE subscribers
+DROP COLUMN IF EXISTS email_checked_at,
+DROP COLUMN IF EXISTS email_status;
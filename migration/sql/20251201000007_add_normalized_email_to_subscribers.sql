@@ -0,0 +1,14 @@
+-- +goose Up
+-- Case- and plus-addressing-insensitive address used to detect duplicate subscribers
+ALTER TABLE subscribers
+ADD COLUMN normalized_email VARCHAR(255) NULL;
+
+UPDATE subscribers
+SET normalized_email = regexp_replace(lower(trim(email)), '^([^+@]+)\+[^@]*@', '\1@');
+
+CREATE INDEX IF NOT EXISTS idx_subscribers_normalized_email ON subscribers(normalized_email);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_subscribers_normalized_email;
+ALTER TABLE subscribers
+DROP COLUMN IF EXISTS normalized_email;
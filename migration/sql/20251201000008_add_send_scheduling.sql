@@ -0,0 +1,18 @@
+-- +goose Up
+-- Subscriber time zones and scheduled / local-time sends for content
+ALTER TABLE subscribers
+ADD COLUMN timezone VARCHAR(64) NULL;
+
+ALTER TABLE contents
+ADD COLUMN send_at TIMESTAMP WITH TIME ZONE NULL,
+ADD COLUMN local_send_time VARCHAR(5) NULL;
+
+CREATE INDEX IF NOT EXISTS idx_contents_send_at ON contents(send_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_contents_send_at;
+ALTER TABLE contents
+DROP COLUMN IF EXISTS local_send_time,
+DROP COLUMN IF EXISTS send_at;
+ALTER TABLE subscribers
+DROP COLUMN IF EXISTS timezone;
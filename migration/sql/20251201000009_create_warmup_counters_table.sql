@@ -0,0 +1,12 @@
+-- +goose Up
+-- Daily send counts of provider / sending domain warm-up policies
+CREATE TABLE IF NOT EXISTS warmup_counters (
+    policy_key VARCHAR(255) NOT NULL,
+    day DATE NOT NULL,
+    sent INTEGER NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (policy_key, day)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS warmup_counters;
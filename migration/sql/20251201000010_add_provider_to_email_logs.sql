@@ -0,0 +1,15 @@
+-- +goose Up
+-- Provider that sent each email and the message ID it assigned, for correlating bounces and webhook events
+ALTER TABLE email_logs
+ADD COLUMN provider VARCHAR(100) NULL,
+ADD COLUMN provider_message_id VARCHAR(255) NULL;
+
+CREATE INDEX IF NOT EXISTS idx_email_logs_provider ON email_logs(provider);
+CREATE INDEX IF NOT EXISTS idx_email_logs_provider_message_id ON email_logs(provider_message_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_email_logs_provider_message_id;
+DROP INDEX IF EXISTS idx_email_logs_provider;
+ALTER TABLE email_logs
+DROP COLUMN IF EXISTS provider_message_id,
+DROP COLUMN IF EXISTS provider;
@@ -0,0 +1,13 @@
+-- +goose Up
+-- Hourly send and failure counts per email provider
+CREATE TABLE IF NOT EXISTS provider_hourly_stats (
+    provider VARCHAR(100) NOT NULL,
+    hour TIMESTAMP WITH TIME ZONE NOT NULL,
+    sent INTEGER NOT NULL DEFAULT 0,
+    failed INTEGER NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (provider, hour)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS provider_hourly_stats;
@@ -0,0 +1,24 @@
+-- +goose Up
+-- Channels a subscriber accepts and content is delivered on, comma-separated; SMS needs a phone number
+ALTER TABLE subscribers
+ADD COLUMN phone VARCHAR(20) NULL,
+ADD COLUMN channels VARCHAR(50) NOT NULL DEFAULT 'email';
+
+ALTER TABLE contents
+ADD COLUMN channels VARCHAR(50) NOT NULL DEFAULT 'email';
+
+-- Delivery logs cover every channel; email_address holds the phone number of SMS logs
+ALTER TABLE email_logs
+ADD COLUMN channel VARCHAR(20) NOT NULL DEFAULT 'email';
+
+CREATE INDEX IF NOT EXISTS idx_email_logs_channel ON email_logs(channel);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_email_logs_channel;
+ALTER TABLE email_logs
+DROP COLUMN IF EXISTS channel;
+ALTER TABLE contents
+DROP COLUMN IF EXISTS channels;
+ALTER TABLE subscribers
+DROP COLUMN IF EXISTS channels,
+DROP COLUMN IF EXISTS phone;
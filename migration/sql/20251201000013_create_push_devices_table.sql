@@ -0,0 +1,27 @@
+-- +goose Up
+-- Devices registered for push notifications: FCM registration tokens, or Web Push subscription endpoints
+-- with their encryption keys
+CREATE TABLE IF NOT EXISTS push_devices (
+    id SERIAL PRIMARY KEY,
+    subscriber_id INTEGER NOT NULL REFERENCES subscribers(id) ON DELETE CASCADE,
+    platform VARCHAR(20) NOT NULL,
+    token VARCHAR(2048) NOT NULL,
+    p256dh VARCHAR(128) NULL,
+    auth VARCHAR(64) NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_push_devices_token ON push_devices(token);
+CREATE INDEX IF NOT EXISTS idx_push_devices_subscriber_id ON push_devices(subscriber_id);
+
+-- Push is opted into per topic
+ALTER TABLE subscriptions
+ADD COLUMN push BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- +goose Down
+ALTER TABLE subscriptions
+DROP COLUMN IF EXISTS push;
+DROP INDEX IF EXISTS idx_push_devices_subscriber_id;
+DROP INDEX IF EXISTS idx_push_devices_token;
+DROP TABLE IF EXISTS push_devices;
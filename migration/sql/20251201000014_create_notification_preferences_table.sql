@@ -0,0 +1,25 @@
+-- +goose Up
+-- How often each subscriber receives a topic's content per channel; no row means instantly
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    id SERIAL PRIMARY KEY,
+    subscriber_id INTEGER NOT NULL REFERENCES subscribers(id) ON DELETE CASCADE,
+    topic_id INTEGER NOT NULL REFERENCES topics(id) ON DELETE CASCADE,
+    channel VARCHAR(20) NOT NULL,
+    frequency VARCHAR(20) NOT NULL,
+    last_digest_at TIMESTAMP WITH TIME ZONE NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_notification_preferences_unique ON notification_preferences(subscriber_id, topic_id, channel);
+CREATE INDEX IF NOT EXISTS idx_notification_preferences_topic_id ON notification_preferences(topic_id);
+
+-- Digest runs look up email preferences by frequency
+CREATE INDEX IF NOT EXISTS idx_notification_preferences_digest ON notification_preferences(frequency, channel)
+WHERE frequency IN ('daily', 'weekly');
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_notification_preferences_digest;
+DROP INDEX IF EXISTS idx_notification_preferences_topic_id;
+DROP INDEX IF EXISTS idx_notification_preferences_unique;
+DROP TABLE IF EXISTS notification_preferences;
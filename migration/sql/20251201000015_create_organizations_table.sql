@@ -0,0 +1,52 @@
+-- +goose Up
+-- Organizations (workspaces) let one deployment serve several newsletters. Everything that existed before
+-- belongs to the default organization, id 1.
+CREATE TABLE IF NOT EXISTS organizations (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL UNIQUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+INSERT INTO organizations (id, name) VALUES (1, 'Default') ON CONFLICT (id) DO NOTHING;
+SELECT setval(pg_get_serial_sequence('organizations', 'id'), GREATEST((SELECT MAX(id) FROM organizations), 1));
+
+ALTER TABLE topics ADD COLUMN organization_id INTEGER NOT NULL DEFAULT 1 REFERENCES organizations(id);
+ALTER TABLE subscribers ADD COLUMN organization_id INTEGER NOT NULL DEFAULT 1 REFERENCES organizations(id);
+ALTER TABLE subscriptions ADD COLUMN organization_id INTEGER NOT NULL DEFAULT 1 REFERENCES organizations(id);
+ALTER TABLE contents ADD COLUMN organization_id INTEGER NOT NULL DEFAULT 1 REFERENCES organizations(id);
+ALTER TABLE email_logs ADD COLUMN organization_id INTEGER NOT NULL DEFAULT 1 REFERENCES organizations(id);
+ALTER TABLE api_keys ADD COLUMN organization_id INTEGER NOT NULL DEFAULT 1 REFERENCES organizations(id);
+
+CREATE INDEX IF NOT EXISTS idx_subscriptions_organization_id ON subscriptions(organization_id);
+CREATE INDEX IF NOT EXISTS idx_contents_organization_id ON contents(organization_id);
+CREATE INDEX IF NOT EXISTS idx_email_logs_organization_id ON email_logs(organization_id);
+CREATE INDEX IF NOT EXISTS idx_api_keys_organization_id ON api_keys(organization_id);
+
+-- Topic names and subscriber addresses only have to be unique within an organization. The unique
+-- constraints come from the create table migrations, the idx_ indexes from GORM auto-migration.
+ALTER TABLE topics DROP CONSTRAINT IF EXISTS topics_name_key;
+DROP INDEX IF EXISTS idx_topics_name;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_topics_organization_name ON topics(organization_id, name);
+ALTER TABLE subscribers DROP CONSTRAINT IF EXISTS subscribers_email_key;
+DROP INDEX IF EXISTS idx_subscribers_email;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_subscribers_organization_email ON subscribers(organization_id, email);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_subscribers_organization_email;
+ALTER TABLE subscribers ADD CONSTRAINT subscribers_email_key UNIQUE (email);
+DROP INDEX IF EXISTS idx_topics_organization_name;
+ALTER TABLE topics ADD CONSTRAINT topics_name_key UNIQUE (name);
+
+DROP INDEX IF EXISTS idx_api_keys_organization_id;
+DROP INDEX IF EXISTS idx_email_logs_organization_id;
+DROP INDEX IF EXISTS idx_contents_organization_id;
+DROP INDEX IF EXISTS idx_subscriptions_organization_id;
+
+ALTER TABLE api_keys DROP COLUMN IF EXISTS organization_id;
+ALTER TABLE email_logs DROP COLUMN IF EXISTS organization_id;
+ALTER TABLE contents DROP COLUMN IF EXISTS organization_id;
+ALTER TABLE subscriptions DROP COLUMN IF EXISTS organization_id;
+ALTER TABLE subscribers DROP COLUMN IF EXISTS organization_id;
+ALTER TABLE topics DROP COLUMN IF EXISTS organization_id;
+DROP TABLE IF EXISTS organizations;
@@ -0,0 +1,35 @@
+-- +goose Up
+-- Sender identity and email branding per organization, optionally overridden per topic. Empty values fall
+-- back to the organization, then to the provider's configured from address and the built-in template.
+ALTER TABLE organizations
+    ADD COLUMN branding_from_name VARCHAR(100) NOT NULL DEFAULT '',
+    ADD COLUMN branding_from_address VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN branding_reply_to VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN branding_logo_url VARCHAR(2048) NOT NULL DEFAULT '',
+    ADD COLUMN branding_primary_color VARCHAR(7) NOT NULL DEFAULT '',
+    ADD COLUMN branding_footer_text TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE topics
+    ADD COLUMN branding_from_name VARCHAR(100) NOT NULL DEFAULT '',
+    ADD COLUMN branding_from_address VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN branding_reply_to VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN branding_logo_url VARCHAR(2048) NOT NULL DEFAULT '',
+    ADD COLUMN branding_primary_color VARCHAR(7) NOT NULL DEFAULT '',
+    ADD COLUMN branding_footer_text TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE topics
+    DROP COLUMN IF EXISTS branding_footer_text,
+    DROP COLUMN IF EXISTS branding_primary_color,
+    DROP COLUMN IF EXISTS branding_logo_url,
+    DROP COLUMN IF EXISTS branding_reply_to,
+    DROP COLUMN IF EXISTS branding_from_address,
+    DROP COLUMN IF EXISTS branding_from_name;
+
+ALTER TABLE organizations
+    DROP COLUMN IF EXISTS branding_footer_text,
+    DROP COLUMN IF EXISTS branding_primary_color,
+    DROP COLUMN IF EXISTS branding_logo_url,
+    DROP COLUMN IF EXISTS branding_reply_to,
+    DROP COLUMN IF EXISTS branding_from_address,
+    DROP COLUMN IF EXISTS branding_from_name;
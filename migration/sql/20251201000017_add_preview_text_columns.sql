@@ -0,0 +1,8 @@
+-- +goose Up
+-- Preheader shown after the subject in inbox lists; email logs keep the one they were sent with for retries
+ALTER TABLE contents ADD COLUMN preview_text TEXT NOT NULL DEFAULT '';
+ALTER TABLE email_logs ADD COLUMN preview_text TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE email_logs DROP COLUMN IF EXISTS preview_text;
+ALTER TABLE contents DROP COLUMN IF EXISTS preview_text;
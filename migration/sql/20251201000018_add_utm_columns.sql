@@ -0,0 +1,22 @@
+-- +goose Up
+-- UTM parameters added to the links of sent emails; a content's override its topic's field by field
+ALTER TABLE topics
+    ADD COLUMN utm_source VARCHAR(100) NOT NULL DEFAULT '',
+    ADD COLUMN utm_medium VARCHAR(100) NOT NULL DEFAULT '',
+    ADD COLUMN utm_campaign VARCHAR(100) NOT NULL DEFAULT '';
+
+ALTER TABLE contents
+    ADD COLUMN utm_source VARCHAR(100) NOT NULL DEFAULT '',
+    ADD COLUMN utm_medium VARCHAR(100) NOT NULL DEFAULT '',
+    ADD COLUMN utm_campaign VARCHAR(100) NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE contents
+    DROP COLUMN IF EXISTS utm_campaign,
+    DROP COLUMN IF EXISTS utm_medium,
+    DROP COLUMN IF EXISTS utm_source;
+
+ALTER TABLE topics
+    DROP COLUMN IF EXISTS utm_campaign,
+    DROP COLUMN IF EXISTS utm_medium,
+    DROP COLUMN IF EXISTS utm_source;
@@ -0,0 +1,19 @@
+-- +goose Up
+-- Images uploaded for content, served publicly under their random key from local disk or S3
+CREATE TABLE IF NOT EXISTS assets (
+    id SERIAL PRIMARY KEY,
+    organization_id INTEGER NOT NULL DEFAULT 1 REFERENCES organizations(id),
+    key VARCHAR(64) NOT NULL,
+    filename VARCHAR(255) NOT NULL DEFAULT '',
+    content_type VARCHAR(100) NOT NULL,
+    size BIGINT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_assets_key ON assets(key);
+CREATE INDEX IF NOT EXISTS idx_assets_organization_id ON assets(organization_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_assets_organization_id;
+DROP INDEX IF EXISTS idx_assets_key;
+DROP TABLE IF EXISTS assets;
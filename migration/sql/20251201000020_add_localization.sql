@@ -0,0 +1,26 @@
+-- +goose Up
+-- Subscribers receive the translation of a content best matching their locale, and the content itself when
+-- none does. Email logs keep the locale so queued and retried emails render the template in it too.
+ALTER TABLE subscribers ADD COLUMN locale VARCHAR(35) NOT NULL DEFAULT '';
+ALTER TABLE contents ADD COLUMN locale VARCHAR(35) NOT NULL DEFAULT '';
+ALTER TABLE email_logs ADD COLUMN locale VARCHAR(35) NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS content_translations (
+    id SERIAL PRIMARY KEY,
+    content_id INTEGER NOT NULL REFERENCES contents(id) ON DELETE CASCADE,
+    locale VARCHAR(35) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    body TEXT NOT NULL,
+    preview_text TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_content_translations_content_locale ON content_translations(content_id, locale);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_content_translations_content_locale;
+DROP TABLE IF EXISTS content_translations;
+ALTER TABLE email_logs DROP COLUMN IF EXISTS locale;
+ALTER TABLE contents DROP COLUMN IF EXISTS locale;
+ALTER TABLE subscribers DROP COLUMN IF EXISTS locale;
@@ -0,0 +1,18 @@
+-- +goose Up
+-- Reusable HTML or Markdown blocks that content includes by name and that are expanded when it is sent
+CREATE TABLE IF NOT EXISTS snippets (
+    id SERIAL PRIMARY KEY,
+    organization_id INTEGER NOT NULL DEFAULT 1 REFERENCES organizations(id),
+    name VARCHAR(100) NOT NULL,
+    format VARCHAR(20) NOT NULL DEFAULT 'html',
+    body TEXT NOT NULL,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_snippets_organization_name ON snippets(organization_id, name);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_snippets_organization_name;
+DROP TABLE IF EXISTS snippets;
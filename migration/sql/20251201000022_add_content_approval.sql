@@ -0,0 +1,28 @@
+-- +goose Up
+-- Content is reviewed before it is published: its approval state, the reviewer asked to look at it, and a log
+-- of submissions, decisions and comments. Content published before now counts as published.
+ALTER TABLE contents ADD COLUMN approval_state VARCHAR(20) NOT NULL DEFAULT 'draft';
+ALTER TABLE contents ADD COLUMN reviewer VARCHAR(255) NOT NULL DEFAULT '';
+UPDATE contents SET approval_state = 'published' WHERE is_published = TRUE;
+CREATE INDEX IF NOT EXISTS idx_contents_approval_state ON contents(approval_state);
+
+CREATE TABLE IF NOT EXISTS content_approval_events (
+    id SERIAL PRIMARY KEY,
+    content_id INTEGER NOT NULL REFERENCES contents(id) ON DELETE CASCADE,
+    action VARCHAR(20) NOT NULL,
+    from_state VARCHAR(20) NOT NULL DEFAULT '',
+    to_state VARCHAR(20) NOT NULL DEFAULT '',
+    actor VARCHAR(255) NOT NULL,
+    reviewer VARCHAR(255) NOT NULL DEFAULT '',
+    comment TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_content_approval_events_content_id ON content_approval_events(content_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_content_approval_events_content_id;
+DROP TABLE IF EXISTS content_approval_events;
+DROP INDEX IF EXISTS idx_contents_approval_state;
+ALTER TABLE contents DROP COLUMN IF EXISTS reviewer;
+ALTER TABLE contents DROP COLUMN IF EXISTS approval_state;
@@ -0,0 +1,8 @@
+-- +goose Up
+-- Archived topics keep their history but take no new content or subscriptions
+ALTER TABLE topics ADD COLUMN archived_at TIMESTAMP WITH TIME ZONE;
+CREATE INDEX IF NOT EXISTS idx_topics_archived_at ON topics(archived_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_topics_archived_at;
+ALTER TABLE topics DROP COLUMN IF EXISTS archived_at;
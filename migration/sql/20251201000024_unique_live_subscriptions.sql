@@ -0,0 +1,25 @@
+-- +goose Up
+-- A subscriber may only hold one live subscription per topic. The table constraint from the create table
+-- migration also counted unsubscribed (soft-deleted) rows, so resubscribing failed; databases created by
+-- GORM auto-migration had no constraint at all and collected duplicates.
+ALTER TABLE subscriptions ADD COLUMN IF NOT EXISTS updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW();
+ALTER TABLE subscriptions ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE NULL;
+
+-- Keep the oldest of any duplicates
+UPDATE subscriptions SET deleted_at = NOW()
+WHERE deleted_at IS NULL
+  AND EXISTS (
+    SELECT 1 FROM subscriptions older
+    WHERE older.subscriber_id = subscriptions.subscriber_id
+      AND older.topic_id = subscriptions.topic_id
+      AND older.deleted_at IS NULL
+      AND older.id < subscriptions.id
+  );
+
+ALTER TABLE subscriptions DROP CONSTRAINT IF EXISTS subscriptions_subscriber_id_topic_id_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_subscriptions_subscriber_topic
+    ON subscriptions(subscriber_id, topic_id) WHERE deleted_at IS NULL;
+
+-- +goose Down
+-- The table-wide constraint isn't restored: unsubscribed rows may now repeat a live one
+DROP INDEX IF EXISTS idx_subscriptions_subscriber_topic;
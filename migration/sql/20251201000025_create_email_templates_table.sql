@@ -0,0 +1,11 @@
+-- +goose Up
+-- Email templates replacing the built-in ones when templates are loaded from the database
+CREATE TABLE IF NOT EXISTS email_templates (
+    name VARCHAR(50) PRIMARY KEY,
+    body TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS email_templates;
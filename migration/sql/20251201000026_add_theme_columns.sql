@@ -0,0 +1,39 @@
+-- +goose Up
+-- Theme of the email template, per organization and optionally per topic like the rest of the branding:
+-- page and text colors, font stack, and the colors and logo used when the reader's client is in dark mode.
+ALTER TABLE organizations
+    ADD COLUMN branding_dark_logo_url VARCHAR(2048) NOT NULL DEFAULT '',
+    ADD COLUMN branding_background_color VARCHAR(7) NOT NULL DEFAULT '',
+    ADD COLUMN branding_text_color VARCHAR(7) NOT NULL DEFAULT '',
+    ADD COLUMN branding_font_family VARCHAR(200) NOT NULL DEFAULT '',
+    ADD COLUMN branding_dark_primary_color VARCHAR(7) NOT NULL DEFAULT '',
+    ADD COLUMN branding_dark_background_color VARCHAR(7) NOT NULL DEFAULT '',
+    ADD COLUMN branding_dark_text_color VARCHAR(7) NOT NULL DEFAULT '';
+
+ALTER TABLE topics
+    ADD COLUMN branding_dark_logo_url VARCHAR(2048) NOT NULL DEFAULT '',
+    ADD COLUMN branding_background_color VARCHAR(7) NOT NULL DEFAULT '',
+    ADD COLUMN branding_text_color VARCHAR(7) NOT NULL DEFAULT '',
+    ADD COLUMN branding_font_family VARCHAR(200) NOT NULL DEFAULT '',
+    ADD COLUMN branding_dark_primary_color VARCHAR(7) NOT NULL DEFAULT '',
+    ADD COLUMN branding_dark_background_color VARCHAR(7) NOT NULL DEFAULT '',
+    ADD COLUMN branding_dark_text_color VARCHAR(7) NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE topics
+    DROP COLUMN IF EXISTS branding_dark_text_color,
+    DROP COLUMN IF EXISTS branding_dark_background_color,
+    DROP COLUMN IF EXISTS branding_dark_primary_color,
+    DROP COLUMN IF EXISTS branding_font_family,
+    DROP COLUMN IF EXISTS branding_text_color,
+    DROP COLUMN IF EXISTS branding_background_color,
+    DROP COLUMN IF EXISTS branding_dark_logo_url;
+
+ALTER TABLE organizations
+    DROP COLUMN IF EXISTS branding_dark_text_color,
+    DROP COLUMN IF EXISTS branding_dark_background_color,
+    DROP COLUMN IF EXISTS branding_dark_primary_color,
+    DROP COLUMN IF EXISTS branding_font_family,
+    DROP COLUMN IF EXISTS branding_text_color,
+    DROP COLUMN IF EXISTS branding_background_color,
+    DROP COLUMN IF EXISTS branding_dark_logo_url;
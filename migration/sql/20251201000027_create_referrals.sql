@@ -0,0 +1,46 @@
+-- +goose Up
+-- Every subscriber gets a code for their referral link; existing ones get a random one
+ALTER TABLE subscribers
+ADD COLUMN referral_code VARCHAR(16) NULL,
+ADD COLUMN referral_count INTEGER NOT NULL DEFAULT 0,
+ADD COLUMN referred_by_id INTEGER NULL REFERENCES subscribers(id) ON DELETE SET NULL;
+
+UPDATE subscribers
+SET referral_code = substr(md5(random()::text || clock_timestamp()::text || id::text), 1, 16)
+WHERE referral_code IS NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_subscribers_referral_code ON subscribers(referral_code);
+CREATE INDEX IF NOT EXISTS idx_subscribers_referral_count ON subscribers(referral_count);
+CREATE INDEX IF NOT EXISTS idx_subscribers_referred_by_id ON subscribers(referred_by_id);
+
+-- Readers who signed up through a referral link; they become subscribers once they confirm
+CREATE TABLE IF NOT EXISTS referrals (
+    id SERIAL PRIMARY KEY,
+    organization_id INTEGER NOT NULL DEFAULT 1 REFERENCES organizations(id),
+    referrer_id INTEGER NOT NULL REFERENCES subscribers(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    token VARCHAR(64) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    confirmation_sent_at TIMESTAMP WITH TIME ZONE NULL,
+    confirmed_at TIMESTAMP WITH TIME ZONE NULL,
+    subscriber_id INTEGER NULL REFERENCES subscribers(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_referrals_token ON referrals(token);
+CREATE INDEX IF NOT EXISTS idx_referrals_referrer_id ON referrals(referrer_id);
+CREATE INDEX IF NOT EXISTS idx_referrals_status ON referrals(status);
+CREATE INDEX IF NOT EXISTS idx_referrals_confirmation_sent_at ON referrals(confirmation_sent_at);
+CREATE INDEX IF NOT EXISTS idx_referrals_organization_id ON referrals(organization_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS referrals;
+DROP INDEX IF EXISTS idx_subscribers_referred_by_id;
+DROP INDEX IF EXISTS idx_subscribers_referral_count;
+DROP INDEX IF EXISTS idx_subscribers_referral_code;
+ALTER TABLE subscribers
+DROP COLUMN IF EXISTS referred_by_id,
+DROP COLUMN IF EXISTS referral_count,
+DROP COLUMN IF EXISTS referral_code;
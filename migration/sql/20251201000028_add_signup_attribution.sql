@@ -0,0 +1,32 @@
+-- +goose Up
+-- Where each subscriber signed up from; subscribers created before this are left blank
+ALTER TABLE subscribers
+ADD COLUMN signup_source VARCHAR(32) NOT NULL DEFAULT '',
+ADD COLUMN signup_form VARCHAR(100) NOT NULL DEFAULT '',
+ADD COLUMN signup_api_key_id INTEGER NULL REFERENCES api_keys(id) ON DELETE SET NULL,
+ADD COLUMN signup_referrer VARCHAR(500) NOT NULL DEFAULT '',
+ADD COLUMN signup_country VARCHAR(2) NOT NULL DEFAULT '',
+ADD COLUMN utm_source VARCHAR(100) NOT NULL DEFAULT '',
+ADD COLUMN utm_medium VARCHAR(100) NOT NULL DEFAULT '',
+ADD COLUMN utm_campaign VARCHAR(100) NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_subscribers_signup_source ON subscribers(signup_source);
+CREATE INDEX IF NOT EXISTS idx_subscribers_signup_form ON subscribers(signup_form);
+CREATE INDEX IF NOT EXISTS idx_subscribers_signup_api_key_id ON subscribers(signup_api_key_id);
+CREATE INDEX IF NOT EXISTS idx_subscribers_signup_country ON subscribers(signup_country);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_subscribers_signup_country;
+DROP INDEX IF EXISTS idx_subscribers_signup_api_key_id;
+DROP INDEX IF EXISTS idx_subscribers_signup_form;
+DROP INDEX IF EXISTS idx_subscribers_signup_source;
+
+ALTER TABLE subscribers
+DROP COLUMN IF EXISTS utm_campaign,
+DROP COLUMN IF EXISTS utm_medium,
+DROP COLUMN IF EXISTS utm_source,
+DROP COLUMN IF EXISTS signup_country,
+DROP COLUMN IF EXISTS signup_referrer,
+DROP COLUMN IF EXISTS signup_api_key_id,
+DROP COLUMN IF EXISTS signup_form,
+DROP COLUMN IF EXISTS signup_source;
@@ -0,0 +1,22 @@
+-- +goose Up
+-- Slugs address topic landing pages at /topics/<slug>, so unlike names they are unique across organizations
+ALTER TABLE topics ADD COLUMN slug VARCHAR(100);
+
+UPDATE topics
+SET slug = trim(both '-' from left(regexp_replace(lower(name), '[^a-z0-9]+', '-', 'g'), 90));
+
+UPDATE topics SET slug = 'topic-' || id WHERE slug = '';
+
+-- Topics of different organizations may share a name; all but the oldest get their ID appended
+UPDATE topics t
+SET slug = t.slug || '-' || t.id
+WHERE EXISTS (SELECT 1 FROM topics earlier WHERE earlier.slug = t.slug AND earlier.id < t.id);
+
+ALTER TABLE topics ALTER COLUMN slug SET NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_topics_slug ON topics(slug);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_topics_slug;
+
+ALTER TABLE topics DROP COLUMN IF EXISTS slug;
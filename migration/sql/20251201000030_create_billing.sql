@@ -0,0 +1,58 @@
+-- +goose Up
+-- Premium content only goes to subscribers paying for one of its topic's plans
+ALTER TABLE contents ADD COLUMN premium BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Paid tiers of a topic, each sold through a Stripe price
+CREATE TABLE IF NOT EXISTS plans (
+    id SERIAL PRIMARY KEY,
+    organization_id INTEGER NOT NULL DEFAULT 1 REFERENCES organizations(id),
+    topic_id INTEGER NOT NULL REFERENCES topics(id),
+    name VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    stripe_price_id VARCHAR(255) NOT NULL,
+    amount_cents BIGINT NOT NULL DEFAULT 0,
+    currency VARCHAR(3) NOT NULL DEFAULT '',
+    interval VARCHAR(10) NOT NULL DEFAULT '',
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_plans_stripe_price_id ON plans(stripe_price_id);
+CREATE INDEX IF NOT EXISTS idx_plans_topic_id ON plans(topic_id);
+CREATE INDEX IF NOT EXISTS idx_plans_organization_id ON plans(organization_id);
+CREATE INDEX IF NOT EXISTS idx_plans_deleted_at ON plans(deleted_at);
+
+-- Subscribers' paid subscriptions, mirrored from Stripe by its webhook events
+CREATE TABLE IF NOT EXISTS billing_subscriptions (
+    id SERIAL PRIMARY KEY,
+    organization_id INTEGER NOT NULL DEFAULT 1 REFERENCES organizations(id),
+    subscriber_id INTEGER NOT NULL REFERENCES subscribers(id) ON DELETE CASCADE,
+    plan_id INTEGER NOT NULL REFERENCES plans(id),
+    topic_id INTEGER NOT NULL REFERENCES topics(id),
+    stripe_customer_id VARCHAR(255) NOT NULL DEFAULT '',
+    stripe_subscription_id VARCHAR(255) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    current_period_end TIMESTAMP WITH TIME ZONE NULL,
+    cancel_at_period_end BOOLEAN NOT NULL DEFAULT FALSE,
+    payment_failed_at TIMESTAMP WITH TIME ZONE NULL,
+    dunning_emails_sent INTEGER NOT NULL DEFAULT 0,
+    last_dunning_email_at TIMESTAMP WITH TIME ZONE NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_billing_subscriptions_stripe_subscription_id ON billing_subscriptions(stripe_subscription_id);
+CREATE INDEX IF NOT EXISTS idx_billing_subscriptions_subscriber_id ON billing_subscriptions(subscriber_id);
+CREATE INDEX IF NOT EXISTS idx_billing_subscriptions_plan_id ON billing_subscriptions(plan_id);
+CREATE INDEX IF NOT EXISTS idx_billing_subscriptions_topic_id ON billing_subscriptions(topic_id);
+CREATE INDEX IF NOT EXISTS idx_billing_subscriptions_stripe_customer_id ON billing_subscriptions(stripe_customer_id);
+CREATE INDEX IF NOT EXISTS idx_billing_subscriptions_status ON billing_subscriptions(status);
+CREATE INDEX IF NOT EXISTS idx_billing_subscriptions_payment_failed_at ON billing_subscriptions(payment_failed_at);
+CREATE INDEX IF NOT EXISTS idx_billing_subscriptions_organization_id ON billing_subscriptions(organization_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS billing_subscriptions;
+DROP TABLE IF EXISTS plans;
+ALTER TABLE contents DROP COLUMN IF EXISTS premium;
@@ -0,0 +1,43 @@
+-- +goose Up
+-- Advertisers whose blocks paid placements put into content
+CREATE TABLE IF NOT EXISTS sponsors (
+    id SERIAL PRIMARY KEY,
+    organization_id INTEGER NOT NULL DEFAULT 1 REFERENCES organizations(id),
+    name VARCHAR(100) NOT NULL,
+    url VARCHAR(2048) NOT NULL,
+    logo_url VARCHAR(2048) NOT NULL DEFAULT '',
+    message TEXT NOT NULL DEFAULT '',
+    contact_email VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_sponsors_organization_id ON sponsors(organization_id);
+CREATE INDEX IF NOT EXISTS idx_sponsors_deleted_at ON sponsors(deleted_at);
+
+-- Booked placements: in one content, or in the first content a topic sends on a date
+CREATE TABLE IF NOT EXISTS sponsor_slots (
+    id SERIAL PRIMARY KEY,
+    organization_id INTEGER NOT NULL DEFAULT 1 REFERENCES organizations(id),
+    sponsor_id INTEGER NOT NULL REFERENCES sponsors(id),
+    topic_id INTEGER NOT NULL REFERENCES topics(id),
+    content_id INTEGER NULL REFERENCES contents(id),
+    date DATE NULL,
+    position VARCHAR(10) NOT NULL DEFAULT 'top',
+    message TEXT NOT NULL DEFAULT '',
+    price_cents BIGINT NOT NULL DEFAULT 0,
+    currency VARCHAR(3) NOT NULL DEFAULT '',
+    served_at TIMESTAMP WITH TIME ZONE NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_sponsor_slots_content_id ON sponsor_slots(content_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_sponsor_slots_topic_date ON sponsor_slots(topic_id, date);
+CREATE INDEX IF NOT EXISTS idx_sponsor_slots_sponsor_id ON sponsor_slots(sponsor_id);
+CREATE INDEX IF NOT EXISTS idx_sponsor_slots_organization_id ON sponsor_slots(organization_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS sponsor_slots;
+DROP TABLE IF EXISTS sponsors;
@@ -0,0 +1,47 @@
+-- +goose Up
+-- Questions embedded in a content and answered with one click from the email
+CREATE TABLE IF NOT EXISTS polls (
+    id SERIAL PRIMARY KEY,
+    organization_id INTEGER NOT NULL DEFAULT 1 REFERENCES organizations(id),
+    content_id INTEGER NOT NULL REFERENCES contents(id),
+    question VARCHAR(255) NOT NULL,
+    closes_at TIMESTAMP WITH TIME ZONE NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_polls_content_id ON polls(content_id);
+CREATE INDEX IF NOT EXISTS idx_polls_organization_id ON polls(organization_id);
+CREATE INDEX IF NOT EXISTS idx_polls_deleted_at ON polls(deleted_at);
+
+CREATE TABLE IF NOT EXISTS poll_options (
+    id SERIAL PRIMARY KEY,
+    poll_id INTEGER NOT NULL REFERENCES polls(id) ON DELETE CASCADE,
+    label VARCHAR(100) NOT NULL,
+    position INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_poll_options_poll_id ON poll_options(poll_id);
+
+-- One answer per subscriber and poll; clicking another option replaces it
+CREATE TABLE IF NOT EXISTS poll_responses (
+    id SERIAL PRIMARY KEY,
+    organization_id INTEGER NOT NULL DEFAULT 1 REFERENCES organizations(id),
+    poll_id INTEGER NOT NULL REFERENCES polls(id) ON DELETE CASCADE,
+    subscriber_id INTEGER NOT NULL REFERENCES subscribers(id) ON DELETE CASCADE,
+    option_id INTEGER NOT NULL REFERENCES poll_options(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_poll_responses_poll_subscriber ON poll_responses(poll_id, subscriber_id);
+CREATE INDEX IF NOT EXISTS idx_poll_responses_subscriber_id ON poll_responses(subscriber_id);
+CREATE INDEX IF NOT EXISTS idx_poll_responses_option_id ON poll_responses(option_id);
+CREATE INDEX IF NOT EXISTS idx_poll_responses_organization_id ON poll_responses(organization_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS poll_responses;
+DROP TABLE IF EXISTS poll_options;
+DROP TABLE IF EXISTS polls;
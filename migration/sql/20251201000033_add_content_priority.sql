@@ -0,0 +1,9 @@
+-- +goose Up
+-- How urgently content is sent: high, normal or low
+ALTER TABLE contents ADD COLUMN priority VARCHAR(10) NOT NULL DEFAULT 'normal';
+
+CREATE INDEX IF NOT EXISTS idx_contents_priority ON contents(priority);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_contents_priority;
+ALTER TABLE contents DROP COLUMN IF EXISTS priority;
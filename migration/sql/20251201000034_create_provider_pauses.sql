@@ -0,0 +1,15 @@
+-- +goose Up
+-- Email providers paused through the API; other providers take their sends until they are resumed
+CREATE TABLE IF NOT EXISTS provider_pauses (
+    id SERIAL PRIMARY KEY,
+    provider VARCHAR(100) NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    paused_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_provider_pauses_provider ON provider_pauses(provider);
+
+-- +goose Down
+DROP TABLE IF EXISTS provider_pauses;
@@ -0,0 +1,16 @@
+-- +goose Up
+-- Email bodies stored once and referenced by every log they were sent in ([email_logs] body_storage = "shared")
+CREATE TABLE IF NOT EXISTS email_log_bodies (
+    hash VARCHAR(64) PRIMARY KEY,
+    body TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+ALTER TABLE email_logs ADD COLUMN body_hash VARCHAR(64) NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_email_logs_body_hash ON email_logs(body_hash);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_email_logs_body_hash;
+ALTER TABLE email_logs DROP COLUMN IF EXISTS body_hash;
+DROP TABLE IF EXISTS email_log_bodies;
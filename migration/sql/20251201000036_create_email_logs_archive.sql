@@ -0,0 +1,7 @@
+-- +goose Up
+-- Cold storage for email logs past [email_logs] archive_after, moved there by the worker's email_log_archive job.
+-- Columns added to email_logs must be added here too; the job refuses to run while the tables differ.
+CREATE TABLE IF NOT EXISTS email_logs_archive (LIKE email_logs INCLUDING ALL);
+
+-- +goose Down
+DROP TABLE IF EXISTS email_logs_archive;
@@ -0,0 +1,20 @@
+-- +goose Up
+-- Delivery progress of sent content, kept by the worker's delivery_progress job
+ALTER TABLE contents ADD COLUMN delivery_state VARCHAR(20) NOT NULL DEFAULT '';
+ALTER TABLE contents ADD COLUMN delivery_sent BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE contents ADD COLUMN delivery_failed BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE contents ADD COLUMN delivery_outstanding BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE contents ADD COLUMN delivery_completed_at TIMESTAMP WITH TIME ZONE NULL;
+
+CREATE INDEX IF NOT EXISTS idx_contents_delivery_state ON contents(delivery_state);
+
+-- Content already sent is counted on the job's first runs
+UPDATE contents SET delivery_state = 'sending' WHERE notifications_sent = TRUE;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_contents_delivery_state;
+ALTER TABLE contents DROP COLUMN IF EXISTS delivery_completed_at;
+ALTER TABLE contents DROP COLUMN IF EXISTS delivery_outstanding;
+ALTER TABLE contents DROP COLUMN IF EXISTS delivery_failed;
+ALTER TABLE contents DROP COLUMN IF EXISTS delivery_sent;
+ALTER TABLE contents DROP COLUMN IF EXISTS delivery_state;
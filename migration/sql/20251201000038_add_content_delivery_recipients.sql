@@ -0,0 +1,7 @@
+-- +goose Up
+-- Email recipients dispatched for each content, so recipients without an email log yet (in a provider batch, or
+-- whose log failed to save) still count as outstanding. Content sent before stays at 0 and is counted by its logs.
+ALTER TABLE contents ADD COLUMN delivery_recipients BIGINT NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE contents DROP COLUMN IF EXISTS delivery_recipients;
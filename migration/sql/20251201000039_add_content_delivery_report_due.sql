@@ -0,0 +1,7 @@
+-- +goose Up
+-- Set when content is sent and cleared once its send report went out. Content sent before send reports existed
+-- stays FALSE, so completing its delivery reports nothing.
+ALTER TABLE contents ADD COLUMN delivery_report_due BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- +goose Down
+ALTER TABLE contents DROP COLUMN IF EXISTS delivery_report_due;
@@ -88,11 +88,15 @@ function setup() {
   echo "Running database migrations..."
   run_migration up
 
+  # Tokens are signed with a secret made for this environment unless one is given
+  AUTH_JWT_SECRET=${AUTH_JWT_SECRET:-$(openssl rand -hex 32)}
+
   echo "Building and running backend server container..."
   docker build -f scripts/Dockerfile.web -t newsletter-web .
   docker run -d --name newsletter-web --network newsletter-net \
     -e DATABASE_HOST=newsletter-postgres \
     -e REDIS_HOST=newsletter-redis \
+    -e AUTH_JWT_SECRET="$AUTH_JWT_SECRET" \
     -p 8080:8080 newsletter-web
 
   echo "Building and running worker container..."
@@ -100,6 +104,7 @@ function setup() {
   docker run -d --name newsletter-worker --network newsletter-net \
     -e DATABASE_HOST=newsletter-postgres \
     -e REDIS_HOST=newsletter-redis \
+    -e AUTH_JWT_SECRET="$AUTH_JWT_SECRET" \
     newsletter-worker
 
   echo "Backend server running on http://localhost:8080"